@@ -30,6 +30,14 @@ const (
 	// InitContainerName represents the container name of the init container.
 	InitContainerName = "foundationdb-kubernetes-init"
 
+	// KernelTuningContainerName represents the container name of the init container that applies kernel settings on the
+	// node, e.g. disabling transparent huge pages.
+	KernelTuningContainerName = "foundationdb-kernel-tuning"
+
+	// DiskQualificationContainerName represents the container name of the init container that runs the disk benchmark
+	// on newly provisioned volumes.
+	DiskQualificationContainerName = "foundationdb-disk-qualification"
+
 	// NoneFaultDomainKey represents the none fault domain, where every Pod is a fault domain.
 	NoneFaultDomainKey = "foundationdb.org/none"
 
@@ -57,4 +65,24 @@ const (
 
 	// RunningVersionKey defines the key name in the ConfigMap whose value is the FDB version that the cluster is currently running.
 	RunningVersionKey = "running-version"
+
+	/*
+		Connection Secret constants
+	*/
+
+	// ConnectionSecretSchemaVersion defines the version of the keys in the connection Secret. The version will only be
+	// changed if keys are removed or the format of a value is changed.
+	ConnectionSecretSchemaVersion = "v1"
+
+	// SchemaVersionKey defines the key name in the connection Secret whose value is the version of the schema of the Secret.
+	SchemaVersionKey = "schema-version"
+
+	// ConnectionStringKey defines the key name in the connection Secret whose value is the connection string.
+	ConnectionStringKey = "connection-string"
+
+	// APIVersionKey defines the key name in the connection Secret whose value is the API version that clients should use.
+	APIVersionKey = "api-version"
+
+	// TLSEnabledKey defines the key name in the connection Secret whose value defines if clients must connect with TLS.
+	TLSEnabledKey = "tls-enabled"
 )
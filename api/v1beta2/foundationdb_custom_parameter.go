@@ -23,6 +23,8 @@ package v1beta2
 import (
 	"fmt"
 	"strings"
+
+	"github.com/apple/foundationdb/fdbkubernetesmonitor/api"
 )
 
 // FoundationDBCustomParameter defines a single custom knob
@@ -96,3 +98,39 @@ func (customParameters FoundationDBCustomParameters) ValidateCustomParameters()
 
 	return nil
 }
+
+// reloadableKnob defines a knob that fdbserver can reload without a restart.
+type reloadableKnob struct {
+	// name of the knob as it is used in the custom parameters.
+	name string
+	// minimumVersion defines the minimum version of fdbserver that supports reloading this knob.
+	minimumVersion Version
+}
+
+// reloadableKnobs contains the knobs that can be updated through the configuration database while the fdbserver
+// processes are running. All other knobs require a restart of the fdbserver processes.
+var reloadableKnobs = []reloadableKnob{
+	{name: "knob_min_trace_severity", minimumVersion: Version{api.Version{Major: 7, Minor: 1, Patch: 0}}},
+	{name: "knob_target_bytes_per_storage_server", minimumVersion: Version{api.Version{Major: 7, Minor: 1, Patch: 0}}},
+	{name: "knob_spring_bytes_storage_server", minimumVersion: Version{api.Version{Major: 7, Minor: 1, Patch: 0}}},
+	{name: "knob_target_bytes_per_tlog", minimumVersion: Version{api.Version{Major: 7, Minor: 1, Patch: 0}}},
+	{name: "knob_spring_bytes_tlog", minimumVersion: Version{api.Version{Major: 7, Minor: 1, Patch: 0}}},
+	{name: "knob_auto_tag_throttling_enabled", minimumVersion: Version{api.Version{Major: 7, Minor: 1, Patch: 0}}},
+	{name: "knob_global_tag_throttling", minimumVersion: Version{api.Version{Major: 7, Minor: 3, Patch: 0}}},
+}
+
+// IsReloadableKnob returns true if the provided knob can be reloaded by fdbserver processes of the provided version
+// without a restart. The knob name is expected in the same format as in the custom parameters, e.g.
+// "knob_min_trace_severity", dashes are treated like underscores.
+func IsReloadableKnob(knob string, version Version) bool {
+	knob = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(knob)), "-", "_")
+	for _, reloadable := range reloadableKnobs {
+		if reloadable.name != knob {
+			continue
+		}
+
+		return version.IsAtLeast(reloadable.minimumVersion)
+	}
+
+	return false
+}
@@ -23,6 +23,7 @@ package v1beta2
 import (
 	"errors"
 
+	"github.com/apple/foundationdb/fdbkubernetesmonitor/api"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -96,4 +97,45 @@ var _ = Describe("FoundationDBCustomParameters", func() {
 			),
 		)
 	})
+
+	DescribeTable("checking if a knob is reloadable",
+		func(knob string, version Version, expected bool) {
+			Expect(IsReloadableKnob(knob, version)).To(Equal(expected))
+		},
+		Entry("trace knob with a supported version",
+			"knob_min_trace_severity",
+			Version{api.Version{Major: 7, Minor: 1, Patch: 25}},
+			true,
+		),
+		Entry("trace knob with dashes",
+			"knob-min-trace-severity",
+			Version{api.Version{Major: 7, Minor: 1, Patch: 25}},
+			true,
+		),
+		Entry("trace knob with an unsupported version",
+			"knob_min_trace_severity",
+			Version{api.Version{Major: 6, Minor: 3, Patch: 24}},
+			false,
+		),
+		Entry("throttling knob that requires a newer version",
+			"knob_global_tag_throttling",
+			Version{api.Version{Major: 7, Minor: 1, Patch: 25}},
+			false,
+		),
+		Entry("throttling knob with a supported version",
+			"knob_global_tag_throttling",
+			Version{api.Version{Major: 7, Minor: 3, Patch: 0}},
+			true,
+		),
+		Entry("knob that requires a restart",
+			"knob_disable_posix_kernel_aio",
+			Version{api.Version{Major: 7, Minor: 3, Patch: 0}},
+			false,
+		),
+		Entry("process argument",
+			"memory",
+			Version{api.Version{Major: 7, Minor: 3, Patch: 0}},
+			false,
+		),
+	)
 })
@@ -25,6 +25,14 @@ const (
 	// pod spec.
 	LastSpecKey = "foundationdb.org/last-applied-spec"
 
+	// LastSpecHashVersionKey provides the annotation name we use to store the
+	// version of the hash algorithm that was used to compute the pod spec hash.
+	LastSpecHashVersionKey = "foundationdb.org/last-applied-spec-hash-version"
+
+	// PendingResizeSpecKey provides the annotation name we use to store the hash of the
+	// pod spec while an in-place resize of the containers is not yet applied.
+	PendingResizeSpecKey = "foundationdb.org/pending-resize-spec"
+
 	// LastConfigMapKey provides the annotation name we use to store the hash of the
 	// config map.
 	LastConfigMapKey = "foundationdb.org/last-applied-config-map"
@@ -56,6 +64,51 @@ const (
 	// ImageTypeAnnotation is an annotation key that specifies the image type of the Pod.
 	ImageTypeAnnotation = "foundationdb.org/image-type"
 
+	// MassReplacementApprovalAnnotation is the annotation on the FoundationDBCluster that approves a mass replacement
+	// of misconfigured process groups. The value must match the metadata.generation of the cluster, which makes sure
+	// that an approval is only valid for the spec it was given for.
+	MassReplacementApprovalAnnotation = "foundationdb.org/approve-mass-replacement"
+
+	// ReplacementLoopAcknowledgementAnnotation is the annotation on the FoundationDBCluster that acknowledges a
+	// detected replacement loop. The value must be a Unix timestamp, all replacements before this timestamp will be
+	// ignored by the replacement loop detection.
+	ReplacementLoopAcknowledgementAnnotation = "foundationdb.org/acknowledge-replacement-loop"
+
+	// ApprovedReplacementsAnnotation is the annotation on the FoundationDBCluster that approves the replacements of
+	// process groups if replacements require an approval. The value is a comma separated list of process group IDs.
+	ApprovedReplacementsAnnotation = "foundationdb.org/approved-replacements"
+
+	// RecoverFromPVCsAnnotation is the annotation on the FoundationDBCluster that instructs the operator to recover the
+	// process groups of a newly created cluster from the existing PVCs that match the labels of the cluster. The value
+	// must be "true". This allows to recreate a cluster whose resources were deleted, while the PVCs were retained.
+	RecoverFromPVCsAnnotation = "foundationdb.org/recover-from-pvcs"
+
+	// SpecSettleSecondsAnnotation is the annotation on the FoundationDBCluster that defines for how many seconds the
+	// current generation of the spec must be observed before the operator performs destructive actions like
+	// replacements, exclusions, bounces or Pod updates. This allows GitOps tools to finish syncing all related
+	// resources before the operator acts on a partially applied change.
+	SpecSettleSecondsAnnotation = "foundationdb.org/spec-settle-seconds"
+
+	// ProcessClassServiceLabel is the label on the headless Services of a process class that defines the process class
+	// the Service was created for. The label is used to find the Services that must be deleted.
+	ProcessClassServiceLabel = "foundationdb.org/process-class-service"
+
+	// MonitorAPILabel is the label on the Services and HTTPRoutes that expose the API of the fdb-kubernetes-monitor
+	// of a process group. The label is used to find the Services and HTTPRoutes that must be deleted.
+	MonitorAPILabel = "foundationdb.org/monitor-api"
+
+	// ClusterClaimAnnotation is the annotation on a Pod or PVC that defines the name of the FoundationDBCluster
+	// that claimed the resource. Resources claimed by a different cluster will be ignored by the operator.
+	ClusterClaimAnnotation = "foundationdb.org/cluster-claim"
+
+	// ReplacementProtectedAnnotation is the annotation on a Pod that protects the process group of the Pod against
+	// automatic replacements if the value is "true".
+	ReplacementProtectedAnnotation = "foundationdb.org/replacement-protected"
+
+	// StagedCreationSchedulingGate is the scheduling gate that is added to new Pods if the staged Pod creation is
+	// enabled. The operator removes the scheduling gate once the Pod should be scheduled.
+	StagedCreationSchedulingGate = "foundationdb.org/staged-creation"
+
 	// FDBProcessGroupIDLabel represents the label that is used to represent a instance ID
 	FDBProcessGroupIDLabel = "foundationdb.org/fdb-process-group-id"
 
@@ -65,6 +118,10 @@ const (
 	// FDBClusterLabel represents the label that is used to represent the cluster of an instance
 	FDBClusterLabel = "foundationdb.org/fdb-cluster-name"
 
+	// FDBVolumeNameLabel represents the label that is used to represent the name of an additional volume claim. The
+	// label is not present on the PVC for the data volume.
+	FDBVolumeNameLabel = "foundationdb.org/fdb-volume-name"
+
 	// NodeSelectorNoScheduleLabel is a label used when adding node selectors to block scheduling.
 	NodeSelectorNoScheduleLabel = "foundationdb.org/no-schedule-allowed"
 
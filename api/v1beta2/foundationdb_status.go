@@ -209,6 +209,14 @@ type FoundationDBStatusProcessRoleInfo struct {
 	StoredBytes int `json:"stored_bytes,omitempty"`
 	// ID represent the role ID.
 	ID string `json:"id,omitempty"`
+	// StorageMetadata provides information about the storage server, this is only set for storage roles.
+	StorageMetadata FoundationDBStatusStorageMetadata `json:"storage_metadata,omitempty"`
+}
+
+// FoundationDBStatusStorageMetadata provides information about a storage server.
+type FoundationDBStatusStorageMetadata struct {
+	// StorageEngine defines the storage engine the storage server is running with.
+	StorageEngine StorageEngine `json:"storage_engine,omitempty"`
 }
 
 // FoundationDBStatusDataStatistics provides information about the data in
@@ -0,0 +1,176 @@
+/*
+ * foundationdb_tls.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1beta2
+
+import (
+	"crypto/tls"
+	"fmt"
+
+	"k8s.io/utils/pointer"
+)
+
+// TLSOptions defines the constraints for the TLS configuration of the cluster.
+type TLSOptions struct {
+	// RequireFIPSCompliance defines if the cluster must only use FIPS 140 compliant TLS settings. If enabled, TLS must
+	// be enabled for the main container and the sidecar container, the minimum TLS version must be at least TLS 1.2,
+	// only FIPS approved cipher suites can be defined and the cluster can only be managed by an operator that was built
+	// with FIPS 140 validated cryptography.
+	// The default is false.
+	// +kubebuilder:validation:Optional
+	RequireFIPSCompliance *bool `json:"requireFIPSCompliance,omitempty"`
+
+	// MinimumVersion defines the minimum TLS version that the operator accepts for connections to the sidecar.
+	// The default is TLS1.2.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=TLS1.0;TLS1.1;TLS1.2;TLS1.3
+	MinimumVersion *TLSVersion `json:"minimumVersion,omitempty"`
+
+	// CipherSuites defines the cipher suites, with their IANA names, that the operator accepts for connections to the
+	// sidecar. The cipher suites of TLS 1.3 are not configurable. If no cipher suites are defined, the default cipher
+	// suites of the operator will be used.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=32
+	CipherSuites []string `json:"cipherSuites,omitempty"`
+}
+
+// TLSVersion defines a version of the TLS protocol.
+type TLSVersion string
+
+const (
+	// TLSVersion10 represents TLS 1.0.
+	TLSVersion10 TLSVersion = "TLS1.0"
+	// TLSVersion11 represents TLS 1.1.
+	TLSVersion11 TLSVersion = "TLS1.1"
+	// TLSVersion12 represents TLS 1.2.
+	TLSVersion12 TLSVersion = "TLS1.2"
+	// TLSVersion13 represents TLS 1.3.
+	TLSVersion13 TLSVersion = "TLS1.3"
+)
+
+// tlsVersions maps the TLS versions to the versions of the crypto/tls package.
+var tlsVersions = map[TLSVersion]uint16{
+	TLSVersion10: tls.VersionTLS10,
+	TLSVersion11: tls.VersionTLS11,
+	TLSVersion12: tls.VersionTLS12,
+	TLSVersion13: tls.VersionTLS13,
+}
+
+// fipsApprovedCipherSuites contains the cipher suites that are approved for FIPS 140 compliant TLS connections.
+var fipsApprovedCipherSuites = map[uint16]None{
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256:   {},
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384:   {},
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256: {},
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384: {},
+	tls.TLS_AES_128_GCM_SHA256:                  {},
+	tls.TLS_AES_256_GCM_SHA384:                  {},
+}
+
+// GetVersionID returns the ID of the TLS version as defined in the crypto/tls package. If the TLS version is unknown,
+// 0 will be returned.
+func (version TLSVersion) GetVersionID() uint16 {
+	return tlsVersions[version]
+}
+
+// RequiresFIPSCompliance returns true if the cluster must only use FIPS 140 compliant TLS settings.
+func (cluster *FoundationDBCluster) RequiresFIPSCompliance() bool {
+	return pointer.BoolDeref(cluster.Spec.TLSOptions.RequireFIPSCompliance, false)
+}
+
+// GetMinimumTLSVersion returns the minimum TLS version for connections to the sidecar. The default is TLS1.2.
+func (cluster *FoundationDBCluster) GetMinimumTLSVersion() TLSVersion {
+	if cluster.Spec.TLSOptions.MinimumVersion == nil {
+		return TLSVersion12
+	}
+
+	return *cluster.Spec.TLSOptions.MinimumVersion
+}
+
+// GetTLSCipherSuiteIDs returns the IDs of the defined cipher suites as defined in the crypto/tls package. Unknown
+// cipher suites will be ignored. If no cipher suites are defined, nil will be returned.
+func (cluster *FoundationDBCluster) GetTLSCipherSuiteIDs() []uint16 {
+	if len(cluster.Spec.TLSOptions.CipherSuites) == 0 {
+		return nil
+	}
+
+	cipherSuites := getCipherSuitesByName()
+	ids := make([]uint16, 0, len(cluster.Spec.TLSOptions.CipherSuites))
+	for _, name := range cluster.Spec.TLSOptions.CipherSuites {
+		cipherSuite, ok := cipherSuites[name]
+		if !ok {
+			continue
+		}
+
+		ids = append(ids, cipherSuite.ID)
+	}
+
+	return ids
+}
+
+// getCipherSuitesByName returns all cipher suites that are implemented by the crypto/tls package by their IANA name.
+func getCipherSuitesByName() map[string]*tls.CipherSuite {
+	cipherSuites := map[string]*tls.CipherSuite{}
+	for _, cipherSuite := range append(tls.CipherSuites(), tls.InsecureCipherSuites()...) {
+		cipherSuites[cipherSuite.Name] = cipherSuite
+	}
+
+	return cipherSuites
+}
+
+// validateTLSOptions validates that the defined cipher suites are known and, if the cluster requires FIPS compliance,
+// that the TLS settings are FIPS 140 compliant.
+func (cluster *FoundationDBCluster) validateTLSOptions() []string {
+	var validations []string
+
+	cipherSuites := getCipherSuitesByName()
+	for _, name := range cluster.Spec.TLSOptions.CipherSuites {
+		cipherSuite, ok := cipherSuites[name]
+		if !ok {
+			validations = append(validations, fmt.Sprintf("cipher suite %s is not supported", name))
+			continue
+		}
+
+		if !cluster.RequiresFIPSCompliance() {
+			continue
+		}
+
+		if _, approved := fipsApprovedCipherSuites[cipherSuite.ID]; !approved {
+			validations = append(validations, fmt.Sprintf("cipher suite %s is not FIPS approved", name))
+		}
+	}
+
+	if !cluster.RequiresFIPSCompliance() {
+		return validations
+	}
+
+	if !cluster.Spec.MainContainer.EnableTLS {
+		validations = append(validations, "TLS must be enabled for the main container if FIPS compliance is required")
+	}
+
+	if !cluster.Spec.SidecarContainer.EnableTLS {
+		validations = append(validations, "TLS must be enabled for the sidecar container if FIPS compliance is required")
+	}
+
+	if cluster.GetMinimumTLSVersion().GetVersionID() < tls.VersionTLS12 {
+		validations = append(validations, fmt.Sprintf("minimum TLS version %s is not FIPS compliant, at least %s is required", cluster.GetMinimumTLSVersion(), TLSVersion12))
+	}
+
+	return validations
+}
@@ -0,0 +1,121 @@
+/*
+ * foundationdb_tls_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1beta2
+
+import (
+	"crypto/tls"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("[api] TLSOptions", func() {
+	tlsVersion11 := TLSVersion11
+	tlsVersion13 := TLSVersion13
+
+	DescribeTable("validating the TLS options",
+		func(mainContainerTLS bool, sidecarContainerTLS bool, options TLSOptions, expected []string) {
+			cluster := &FoundationDBCluster{
+				Spec: FoundationDBClusterSpec{
+					MainContainer:    ContainerOverrides{EnableTLS: mainContainerTLS},
+					SidecarContainer: ContainerOverrides{EnableTLS: sidecarContainerTLS},
+					TLSOptions:       options,
+				},
+			}
+
+			Expect(cluster.validateTLSOptions()).To(Equal(expected))
+		},
+		Entry("no TLS options are defined",
+			false,
+			false,
+			TLSOptions{},
+			nil,
+		),
+		Entry("an unknown cipher suite is defined",
+			false,
+			false,
+			TLSOptions{CipherSuites: []string{"TLS_UNKNOWN"}},
+			[]string{"cipher suite TLS_UNKNOWN is not supported"},
+		),
+		Entry("a non FIPS approved cipher suite is defined without FIPS compliance",
+			false,
+			false,
+			TLSOptions{CipherSuites: []string{"TLS_CHACHA20_POLY1305_SHA256"}},
+			nil,
+		),
+		Entry("FIPS compliant settings are defined",
+			true,
+			true,
+			TLSOptions{
+				RequireFIPSCompliance: pointer.Bool(true),
+				MinimumVersion:        &tlsVersion13,
+				CipherSuites:          []string{"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384"},
+			},
+			nil,
+		),
+		Entry("non FIPS compliant settings are defined",
+			true,
+			false,
+			TLSOptions{
+				RequireFIPSCompliance: pointer.Bool(true),
+				MinimumVersion:        &tlsVersion11,
+				CipherSuites:          []string{"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256"},
+			},
+			[]string{
+				"cipher suite TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256 is not FIPS approved",
+				"TLS must be enabled for the sidecar container if FIPS compliance is required",
+				"minimum TLS version TLS1.1 is not FIPS compliant, at least TLS1.2 is required",
+			},
+		),
+		Entry("FIPS compliance is required without TLS",
+			false,
+			false,
+			TLSOptions{RequireFIPSCompliance: pointer.Bool(true)},
+			[]string{
+				"TLS must be enabled for the main container if FIPS compliance is required",
+				"TLS must be enabled for the sidecar container if FIPS compliance is required",
+			},
+		),
+	)
+
+	When("getting the TLS settings", func() {
+		It("should return the defaults", func() {
+			cluster := &FoundationDBCluster{}
+			Expect(cluster.RequiresFIPSCompliance()).To(BeFalse())
+			Expect(cluster.GetMinimumTLSVersion()).To(Equal(TLSVersion12))
+			Expect(cluster.GetTLSCipherSuiteIDs()).To(BeNil())
+		})
+
+		It("should return the IDs of the known cipher suites", func() {
+			cluster := &FoundationDBCluster{
+				Spec: FoundationDBClusterSpec{
+					TLSOptions: TLSOptions{
+						CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_UNKNOWN"},
+					},
+				},
+			}
+
+			Expect(cluster.GetTLSCipherSuiteIDs()).To(Equal([]uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256}))
+			Expect(TLSVersion13.GetVersionID()).To(Equal(uint16(tls.VersionTLS13)))
+		})
+	})
+})
@@ -122,6 +122,22 @@ func (version Version) AutomaticallyRemovesDeadTesterProcesses() bool {
 	return version.IsAtLeast(Version{api.Version{Major: 7, Minor: 1, Patch: 55}})
 }
 
+// SupportsVersionVector returns true if the version of FDB supports the version vector feature.
+func (version Version) SupportsVersionVector() bool {
+	return version.IsAtLeast(Versions.SupportsVersionVector)
+}
+
+// SupportsGrayFailureDetection returns true if the version of FDB supports the worker health monitor to detect gray
+// failures.
+func (version Version) SupportsGrayFailureDetection() bool {
+	return version.IsAtLeast(Versions.SupportsGrayFailureDetection)
+}
+
+// SupportsIdempotencyIDs returns true if the version of FDB supports idempotency IDs for transactions.
+func (version Version) SupportsIdempotencyIDs() bool {
+	return version.IsAtLeast(Versions.SupportsIdempotencyIDs)
+}
+
 // IsProtocolCompatible determines whether two versions of FDB are protocol
 // compatible.
 func (version Version) IsProtocolCompatible(other Version) bool {
@@ -170,6 +186,9 @@ var Versions = struct {
 	SupportsDNSInClusterFile,
 	SupportsLocalityBasedExclusions71,
 	SupportsLocalityBasedExclusions,
+	SupportsVersionVector,
+	SupportsGrayFailureDetection,
+	SupportsIdempotencyIDs,
 	Default Version
 }{
 	Default:                           Version{api.Version{Major: 6, Minor: 2, Patch: 21}},
@@ -187,4 +206,7 @@ var Versions = struct {
 	SupportsDNSInClusterFile:          Version{api.Version{Major: 7, Minor: 0, Patch: 0}},
 	SupportsLocalityBasedExclusions71: Version{api.Version{Major: 7, Minor: 1, Patch: 42}},
 	SupportsLocalityBasedExclusions:   Version{api.Version{Major: 7, Minor: 3, Patch: 26}},
+	SupportsVersionVector:             Version{api.Version{Major: 7, Minor: 3, Patch: 0}},
+	SupportsGrayFailureDetection:      Version{api.Version{Major: 7, Minor: 1, Patch: 0}},
+	SupportsIdempotencyIDs:            Version{api.Version{Major: 7, Minor: 3, Patch: 0}},
 }
@@ -31,6 +31,11 @@ import (
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Generation",type="integer",JSONPath=".metadata.generation",description="Latest generation of the spec",priority=0
 // +kubebuilder:printcolumn:name="Reconciled",type="integer",JSONPath=".status.generations.reconciled",description="Last reconciled generation of the spec",priority=0
+// +kubebuilder:printcolumn:name="Running",type="boolean",JSONPath=".status.backupDetails.running",description="Backup running",priority=0
+// +kubebuilder:printcolumn:name="Paused",type="boolean",JSONPath=".status.backupDetails.paused",description="Backup paused",priority=1
+// +kubebuilder:printcolumn:name="Agents",type="integer",JSONPath=".status.agentCount",description="Number of running backup agents",priority=1
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".spec.version",description="Desired version",priority=1
+// +kubebuilder:printcolumn:name="URL",type="string",JSONPath=".status.backupDetails.url",description="Backup URL",priority=1
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:storageversion
 
@@ -111,6 +116,13 @@ type FoundationDBBackupSpec struct {
 	// +kubebuilder:validation:Enum=split;unified
 	// +kubebuilder:default:=split
 	ImageType *ImageType `json:"imageType,omitempty"`
+
+	// WaitForHealthyCluster defines if the operator should wait until the cluster is healthy, e.g. the cluster is
+	// available, fully recovered and has the desired fault tolerance, before starting the backup, modifying the
+	// backup or changing the backup agents.
+	// Default: false
+	// +kubebuilder:validation:Optional
+	WaitForHealthyCluster *bool `json:"waitForHealthyCluster,omitempty"`
 }
 
 // FoundationDBBackupStatus describes the current status of the backup for a cluster.
@@ -130,6 +142,10 @@ type FoundationDBBackupStatus struct {
 	// Generations provides information about the latest generation to be
 	// reconciled, or to reach other stages in reconciliation.
 	Generations BackupGenerationStatus `json:"generations,omitempty"`
+
+	// WaitingForHealthyCluster indicates that the operator is waiting for the
+	// cluster to become healthy before changing the backup.
+	WaitingForHealthyCluster bool `json:"waitingForHealthyCluster,omitempty"`
 }
 
 // FoundationDBBackupStatusBackupDetails provides information about the state
@@ -216,6 +232,11 @@ func (backup *FoundationDBBackup) ShouldRun() bool {
 	return backup.Spec.BackupState == "" || backup.Spec.BackupState == BackupStateRunning || backup.Spec.BackupState == BackupStatePaused
 }
 
+// ShouldWaitForHealthyCluster determines whether the operator should wait for a healthy cluster before changing the backup.
+func (backup *FoundationDBBackup) ShouldWaitForHealthyCluster() bool {
+	return pointer.BoolDeref(backup.Spec.WaitForHealthyCluster, false)
+}
+
 // ShouldBePaused determines whether the backups should be paused.
 func (backup *FoundationDBBackup) ShouldBePaused() bool {
 	return backup.Spec.BackupState == BackupStatePaused
@@ -17,32 +17,46 @@ limitations under the License.
 package v1beta2
 
 import (
+	"encoding/json"
 	"fmt"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"math"
 	"math/rand"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/apple/foundationdb/fdbkubernetesmonitor/api"
 	"github.com/go-logr/logr"
+	"github.com/robfig/cron/v3"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/apimachinery/pkg/util/validation"
+	utilversion "k8s.io/apimachinery/pkg/util/version"
 	"k8s.io/utils/pointer"
 )
 
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:shortName=fdb
 // +kubebuilder:subresource:status
+// +kubebuilder:subresource:scale:specpath=.spec.processCounts.stateless,statuspath=.status.statelessProcessGroups,selectorpath=.status.statelessSelector
 // +kubebuilder:printcolumn:name="Generation",type="integer",JSONPath=".metadata.generation",description="Latest generation of the spec",priority=0
 // +kubebuilder:printcolumn:name="Reconciled",type="integer",JSONPath=".status.generations.reconciled",description="Last reconciled generation of the spec",priority=0
 // +kubebuilder:printcolumn:name="Available",type="boolean",JSONPath=".status.health.available",description="Database available",priority=0
 // +kubebuilder:printcolumn:name="FullReplication",type="boolean",JSONPath=".status.health.fullReplication",description="Database fully replicated",priority=0
+// +kubebuilder:printcolumn:name="Healthy",type="boolean",JSONPath=".status.health.healthy",description="Database healthy",priority=1
+// +kubebuilder:printcolumn:name="FaultTolerance",type="integer",JSONPath=".status.health.faultTolerance",description="Number of zones that can fail without losing data or availability",priority=1
 // +kubebuilder:printcolumn:name="ReconciledProcessGroups",type="integer",JSONPath=".status.reconciledProcessGroups",description="Number of reconciled process groups",priority=1
 // +kubebuilder:printcolumn:name="DesiredProcessGroups",type="integer",JSONPath=".status.desiredProcessGroups",description="Desired number of process groups",priority=1
+// +kubebuilder:printcolumn:name="PendingRemovals",type="integer",JSONPath=".status.pendingRemovals",description="Number of process groups marked for removal",priority=1
 // +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".status.runningVersion",description="Running version",priority=0
+// +kubebuilder:printcolumn:name="DesiredVersion",type="string",JSONPath=".spec.version",description="Desired version",priority=1
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:storageversion
 
@@ -65,7 +79,7 @@ type FoundationDBClusterList struct {
 }
 
 var conditionsThatNeedReplacement = []ProcessGroupConditionType{MissingProcesses, PodFailing, MissingPod, MissingPVC,
-	MissingService, PodPending, NodeTaintReplacing, ProcessIsMarkedAsExcluded}
+	MissingService, PodPending, NodeTaintReplacing, ProcessIsMarkedAsExcluded, DiskQualificationFailed, VolumeNodeMissing}
 
 const (
 	oneHourDuration = 1 * time.Hour
@@ -73,6 +87,9 @@ const (
 	// maxProcessGroupIDNum is the upper limit for the process group ID numbers. The picked value should be good enough
 	// for the current setup but can be increased in the future.
 	maxProcessGroupIDNum = 99999
+
+	// zoneEncodedIDBlockSize is the size of the ID number block of a zone for the ZoneEncoded allocation strategy.
+	zoneEncodedIDBlockSize = 1000
 )
 
 func init() {
@@ -130,6 +147,19 @@ type FoundationDBClusterSpec struct {
 	// +kubebuilder:validation:MaxItems=500
 	ProcessGroupsToRemoveWithoutExclusion []ProcessGroupID `json:"processGroupsToRemoveWithoutExclusion,omitempty"`
 
+	// ApprovedReplacements defines the process groups that are approved to be replaced automatically, if
+	// automationOptions.replacements.requireApproval is enabled. This list contains the process group IDs.
+	// +kubebuilder:validation:MinItems=0
+	// +kubebuilder:validation:MaxItems=500
+	ApprovedReplacements []ProcessGroupID `json:"approvedReplacements,omitempty"`
+
+	// ProtectedProcessGroups defines the process groups that must never be marked for removal by the automatic
+	// replacements, e.g. to debug a specific Pod. Pods can also be protected with the
+	// foundationdb.org/replacement-protected annotation. This list contains the process group IDs.
+	// +kubebuilder:validation:MinItems=0
+	// +kubebuilder:validation:MaxItems=500
+	ProtectedProcessGroups []ProcessGroupID `json:"protectedProcessGroups,omitempty"`
+
 	// ConfigMap allows customizing the config map the operator creates.
 	ConfigMap *corev1.ConfigMap `json:"configMap,omitempty"`
 
@@ -166,17 +196,27 @@ type FoundationDBClusterSpec struct {
 	//
 	// This must be a valid Kubernetes label value. See
 	// https://kubernetes.io/docs/concepts/overview/working-with-objects/labels/#syntax-and-character-set
-	// for more details on that.
-	// +kubebuilder:validation:MaxLength=43
-	// +kubebuilder:validation:Pattern:=^[a-z0-9A-Z]([\-._a-z0-9A-Z])*[a-z0-9A-Z]$
+	// for more details on that. The maximum length of the prefix depends on the longest process class
+	// of the cluster, as the process group ID contains the prefix, the process class and the ID number.
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern:=^[a-z0-9A-Z]([\-._a-z0-9A-Z]*[a-z0-9A-Z])?$
 	ProcessGroupIDPrefix string `json:"processGroupIDPrefix,omitempty"`
 
 	// LockOptions allows customizing how we manage locks for global operations.
 	LockOptions LockOptions `json:"lockOptions,omitempty"`
 
+	// TLSOptions defines the constraints for the TLS configuration of the cluster.
+	TLSOptions TLSOptions `json:"tlsOptions,omitempty"`
+
 	// Routing defines the configuration for routing to our pods.
 	Routing RoutingConfig `json:"routing,omitempty"`
 
+	// PodDisruptionBudgets defines the configuration for the PodDisruptionBudgets that are managed by the operator.
+	PodDisruptionBudgets PodDisruptionBudgetConfig `json:"podDisruptionBudgets,omitempty"`
+
+	// ConnectionSecret defines the configuration for the Secret that contains the connection details of the cluster.
+	ConnectionSecret ConnectionSecretConfig `json:"connectionSecret,omitempty"`
+
 	// IgnoreUpgradabilityChecks determines whether we should skip the check for
 	// client compatibility when performing an upgrade.
 	IgnoreUpgradabilityChecks bool `json:"ignoreUpgradabilityChecks,omitempty"`
@@ -198,6 +238,13 @@ type FoundationDBClusterSpec struct {
 	// log processes. This also affects processes with the transaction class.
 	LogServersPerPod int `json:"logServersPerPod,omitempty"`
 
+	// StoragePools defines multiple pools of storage process groups that all use the storage process class but can
+	// use different volumes and Pod settings, e.g. a pool with fast local disks and a pool with large network attached
+	// disks. If storage pools are defined, the number of storage process groups is the sum of the counts of all
+	// storage pools and processCounts.storage must not be set.
+	// +kubebuilder:validation:MaxItems=10
+	StoragePools []StoragePool `json:"storagePools,omitempty"`
+
 	// MinimumUptimeSecondsForBounce defines the minimum time, in seconds, that the
 	// processes in the cluster must have been up for before the operator can
 	// execute a bounce.
@@ -211,6 +258,9 @@ type FoundationDBClusterSpec struct {
 	// +kubebuilder:default:=false
 	ReplaceInstancesWhenResourcesChange *bool `json:"replaceInstancesWhenResourcesChange,omitempty"`
 
+	// ReplacementTriggerPolicy defines which changes of the Pod trigger a replacement of the process group.
+	ReplacementTriggerPolicy *ReplacementTriggerPolicy `json:"replacementTriggerPolicy,omitempty"`
+
 	// Skip defines if the cluster should be skipped for reconciliation. This can be useful for
 	// investigating in issues or if the environment is unstable.
 	// +kubebuilder:default:=false
@@ -224,9 +274,28 @@ type FoundationDBClusterSpec struct {
 	// the coordinator selection process could conflict.
 	CoordinatorSelection []CoordinatorSelectionSetting `json:"coordinatorSelection,omitempty"`
 
+	// CoordinatorCount defines the number of coordinators the operator should recruit. If unset, the count is derived
+	// from the redundancy mode and the number of regions. The value must be an odd number and at least the
+	// number of coordinators required for the redundancy mode. If the FoundationDB cluster spans across multiple
+	// Kubernetes clusters or DCs the CoordinatorCount must match in all FoundationDB cluster resources.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=15
+	CoordinatorCount *int `json:"coordinatorCount,omitempty"`
+
+	// SpreadCoordinatorsAcrossDataCenters defines whether a cluster with a single usable region that spans at least
+	// three data centers, e.g. a primary data center with two satellites, should recruit 9 coordinators that are
+	// spread across the data centers. This allows the cluster to tolerate the loss of a data center. If
+	// CoordinatorCount is set, the CoordinatorCount will be used. The default is false.
+	SpreadCoordinatorsAcrossDataCenters *bool `json:"spreadCoordinatorsAcrossDataCenters,omitempty"`
+
 	// LabelConfig allows customizing labels used by the operator.
 	LabelConfig LabelConfig `json:"labels,omitempty"`
 
+	// PropagatedMetadata defines additional labels and annotations that the operator will add to the PVCs and
+	// Services it creates, e.g. for cost attribution. Changes are applied in place and will not cause any
+	// replacements.
+	PropagatedMetadata PropagatedMetadata `json:"propagatedMetadata,omitempty"`
+
 	// UseExplicitListenAddress determines if we should add a listen address
 	// that is separate from the public address.
 	// Deprecated: This setting will be removed in the next major release.
@@ -244,6 +313,89 @@ type FoundationDBClusterSpec struct {
 	// MaxZonesWithUnavailablePods defines the maximum number of zones that can have unavailable pods during the update process.
 	// When unset, there is no limit to the  number of zones with unavailable pods.
 	MaxZonesWithUnavailablePods *int `json:"maxZonesWithUnavailablePods,omitempty"`
+
+	// FeatureFlags defines FoundationDB features that should be enabled for this cluster. The operator validates
+	// those features against the desired version and translates them into the according fdbserver knobs.
+	FeatureFlags FeatureFlags `json:"featureFlags,omitempty"`
+
+	// Profile defines the tuning profile preset that should be applied to the cluster. The operator expands the profile
+	// into the knobs that are supported by the desired version of FoundationDB and into the default resource requests
+	// for the main container. Knobs defined in the customParameters of a process class and explicitly defined resources
+	// take precedence over the values of the profile. The expanded values are reported in the status.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=ssd-low-latency;throughput;mixed
+	Profile TuningProfile `json:"profile,omitempty"`
+
+	// TemplateRef references a FoundationDBClusterTemplate in the same namespace that provides the base spec of this
+	// cluster. The spec of the template is deep-merged under this spec: objects are merged field by field and the
+	// fields of this spec take precedence, lists and fields with a non-empty value in this spec replace the values of
+	// the template. The fields that are inherited from the template are reported in the status.
+	TemplateRef *ClusterTemplateReference `json:"templateRef,omitempty"`
+
+	// Monitoring defines the monitoring integrations that the operator provides for this cluster.
+	Monitoring ClusterMonitoringOptions `json:"monitoring,omitempty"`
+
+	// Recovery defines the manual recovery actions that the operator performs for this cluster. Those actions can
+	// lead to data loss and must be confirmed explicitly.
+	Recovery ClusterRecoveryOptions `json:"recovery,omitempty"`
+}
+
+// ImageTypeMigrationStatus contains the progress of the migration to a different image type.
+type ImageTypeMigrationStatus struct {
+	// TargetImageType is the image type the process groups are migrated to.
+	TargetImageType ImageType `json:"targetImageType,omitempty"`
+
+	// FaultDomain is the fault domain that is currently migrated. If the fault domain is empty, the migration waits
+	// until all migrated process groups are healthy. For process groups without a fault domain, the process group ID
+	// is used as fault domain.
+	FaultDomain FaultDomain `json:"faultDomain,omitempty"`
+
+	// MigratedProcessGroups is the number of process groups that are not running with a different image type.
+	MigratedProcessGroups int `json:"migratedProcessGroups,omitempty"`
+
+	// PendingProcessGroups is the number of process groups that are still running with a different image type.
+	PendingProcessGroups int `json:"pendingProcessGroups,omitempty"`
+
+	// Healthy reports if all migrated process groups are healthy.
+	Healthy bool `json:"healthy,omitempty"`
+}
+
+// FailedReplacement represents the automatic replacement of a failed process group.
+type FailedReplacement struct {
+	// ProcessGroupID is the ID of the replaced process group.
+	ProcessGroupID ProcessGroupID `json:"processGroupID,omitempty"`
+
+	// ProcessClass is the process class of the replaced process group.
+	ProcessClass ProcessClass `json:"processClass,omitempty"`
+
+	// Timestamp is the time when the process group was replaced.
+	Timestamp metav1.Time `json:"timestamp,omitempty"`
+}
+
+// DryRunReplacement represents a misconfigured process group that would be replaced if the replacements were not
+// running in dry-run mode.
+type DryRunReplacement struct {
+	// ProcessGroupID is the ID of the process group that would be replaced.
+	ProcessGroupID ProcessGroupID `json:"processGroupID,omitempty"`
+
+	// Reason is the reason why the process group would be replaced.
+	Reason RemovalReason `json:"reason,omitempty"`
+}
+
+// StorageClassMigrationStatus represents the progress of the migration of the process groups of a process class to
+// a new storage class.
+type StorageClassMigrationStatus struct {
+	// ProcessClass is the process class that is migrated.
+	ProcessClass ProcessClass `json:"processClass,omitempty"`
+
+	// TargetStorageClassName is the storage class the process groups are migrated to.
+	TargetStorageClassName string `json:"targetStorageClassName,omitempty"`
+
+	// MigratedProcessGroups is the number of process groups that are using the target storage class.
+	MigratedProcessGroups int `json:"migratedProcessGroups,omitempty"`
+
+	// PendingProcessGroups is the number of process groups that are still using a different storage class.
+	PendingProcessGroups int `json:"pendingProcessGroups,omitempty"`
 }
 
 // ImageType defines a single kind of images used in the cluster.
@@ -315,6 +467,40 @@ type FoundationDBClusterStatus struct {
 	// +kubebuilder:validation:MaxItems=10
 	ImageTypes []ImageType `json:"imageTypes,omitempty"`
 
+	// ImageTypeMigration contains the progress of the migration to a different image type. The field is only set while
+	// process groups are running with an image type that differs from the desired image type.
+	ImageTypeMigration *ImageTypeMigrationStatus `json:"imageTypeMigration,omitempty"`
+
+	// TuningProfile contains the values that the tuning profile defined in the spec was expanded to. The field is only
+	// set if a tuning profile is defined.
+	TuningProfile *TuningProfileStatus `json:"tuningProfile,omitempty"`
+
+	// ProcessGroupIDPrefixMigration contains the progress of the managed migration to a new processGroupIDPrefix. The
+	// field is only set while process groups with a different prefix exist.
+	ProcessGroupIDPrefixMigration *ProcessGroupIDPrefixMigrationStatus `json:"processGroupIDPrefixMigration,omitempty"`
+
+	// Template contains information about the FoundationDBClusterTemplate that was merged into the spec. The field is
+	// only set if the spec references a template.
+	Template *ClusterTemplateStatus `json:"template,omitempty"`
+
+	// StorageClassMigrations contains the progress of the migrations to a new storage class per process class. Only
+	// process classes with process groups that use a different storage class are listed.
+	StorageClassMigrations []StorageClassMigrationStatus `json:"storageClassMigrations,omitempty"`
+
+	// StorageEngineMigration contains the progress of the migration of the storage processes to the storage engine of
+	// the database configuration. The field is only set while the storage engine migration is enabled and storage
+	// process groups are running with a different storage engine.
+	StorageEngineMigration *StorageEngineMigrationStatus `json:"storageEngineMigration,omitempty"`
+
+	// ReloadedKnobs contains the knobs and their values that the operator has applied to the running processes without
+	// restarting them. Processes that are running with a different value for those knobs in their command line are not
+	// restarted.
+	ReloadedKnobs map[string]string `json:"reloadedKnobs,omitempty"`
+
+	// ActiveOperations contains the destructive operations, like bounces, exclusions, deletions and configuration
+	// changes, that were started by the operator and are not yet completed.
+	ActiveOperations []ActiveOperation `json:"activeOperations,omitempty"`
+
 	// ProcessGroups contain information about a process group.
 	// This information is used in multiple places to trigger the according action.
 	ProcessGroups []*ProcessGroupStatus `json:"processGroups,omitempty"`
@@ -331,6 +517,113 @@ type FoundationDBClusterStatus struct {
 
 	// ReconciledProcessGroups reflects the number of process groups that have no condition and are not marked for removal.
 	ReconciledProcessGroups int `json:"reconciledProcessGroups,omitempty"`
+
+	// PendingRemovals reflects the number of process groups that are marked for removal, e.g. because they are replaced.
+	PendingRemovals int `json:"pendingRemovals,omitempty"`
+
+	// StatelessProcessGroups reflects the number of stateless process groups that are not marked for removal. This
+	// field is used as the status replicas of the scale subresource.
+	StatelessProcessGroups int `json:"statelessProcessGroups,omitempty"`
+
+	// StatelessSelector is the label selector for the stateless Pods. This field is used as the selector of the scale
+	// subresource.
+	StatelessSelector string `json:"statelessSelector,omitempty"`
+
+	// ReplacementHistory contains the timestamps of the replacements of misconfigured process groups within the last
+	// hour. This field is only set if maxReplacementsPerHour is defined.
+	ReplacementHistory []metav1.Time `json:"replacementHistory,omitempty"`
+
+	// FailedReplacementHistory contains the automatic replacements of failed process groups within the window of
+	// the replacement loop detection. This field is only set if replacementLoopDetection is defined.
+	FailedReplacementHistory []FailedReplacement `json:"failedReplacementHistory,omitempty"`
+
+	// DryRunReplacements contains the misconfigured process groups that would be replaced if the replacements were
+	// not running in dry-run mode. This field is only set if dryRun is enabled.
+	DryRunReplacements []DryRunReplacement `json:"dryRunReplacements,omitempty"`
+
+	// OperatorVersion is the version of the operator that has completed the upgrade observation for this cluster.
+	// This field is only set if the operator runs with an upgrade observation window.
+	OperatorVersion string `json:"operatorVersion,omitempty"`
+
+	// OperatorUpgrade contains the actions that the operator would take after it was upgraded. The destructive actions
+	// are held back until the upgrade observation window has passed. This field is only set during the observation
+	// window.
+	OperatorUpgrade *OperatorUpgradeStatus `json:"operatorUpgrade,omitempty"`
+
+	// CoordinatorQuorumLoss contains information about the loss of the coordinator quorum. This field is only set
+	// while a quorum of the coordinators is not reachable or while a forced recovery of the coordinators is in
+	// progress.
+	CoordinatorQuorumLoss *CoordinatorQuorumLossStatus `json:"coordinatorQuorumLoss,omitempty"`
+
+	// ObservedGeneration is the generation of the spec that was observed by the operator during the last
+	// reconciliation. Together with the conditions this allows tools like Argo CD and Flux to determine if the status
+	// reflects the latest spec.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// GenerationObservedTimestamp defines when the operator has observed the current generation of the spec for the
+	// first time.
+	GenerationObservedTimestamp *metav1.Time `json:"generationObservedTimestamp,omitempty"`
+
+	// Conditions represents the latest observations of the state of the cluster. The operator maintains the Ready,
+	// Reconciling and Stalled conditions.
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:MaxItems=8
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+const (
+	// ClusterConditionReady is the condition that is true if the latest generation of the cluster is reconciled.
+	ClusterConditionReady = "Ready"
+	// ClusterConditionReconciling is the condition that is true if the operator is reconciling the latest
+	// generation of the cluster.
+	ClusterConditionReconciling = "Reconciling"
+	// ClusterConditionStalled is the condition that is true if the operator cannot reconcile the cluster without a
+	// change of the spec, e.g. because the spec is invalid.
+	ClusterConditionStalled = "Stalled"
+)
+
+// CoordinatorQuorumLossStatus contains information about a cluster that lost the quorum of its coordinators.
+type CoordinatorQuorumLossStatus struct {
+	// ConnectionString is the connection string whose coordinator quorum is not reachable.
+	ConnectionString string `json:"connectionString,omitempty"`
+
+	// DetectionTimestamp is the time when the operator detected the loss of the quorum.
+	DetectionTimestamp metav1.Time `json:"detectionTimestamp,omitempty"`
+
+	// ReachableCoordinators contains the addresses of the coordinators that are still reachable.
+	// +kubebuilder:validation:MaxItems=100
+	ReachableCoordinators []string `json:"reachableCoordinators,omitempty"`
+
+	// UnreachableCoordinators contains the addresses of the coordinators that are not reachable.
+	// +kubebuilder:validation:MaxItems=100
+	UnreachableCoordinators []string `json:"unreachableCoordinators,omitempty"`
+
+	// RecoveryConnectionString is the connection string with the reachable coordinators that the operator has
+	// re-seeded the cluster with. This field is only set after the recovery was confirmed in
+	// spec.recovery.forceNewCoordinators.
+	RecoveryConnectionString string `json:"recoveryConnectionString,omitempty"`
+}
+
+// OperatorUpgradeStatus contains the actions that an upgraded operator would take for a cluster.
+type OperatorUpgradeStatus struct {
+	// PreviousVersion is the version of the operator that managed the cluster before the upgrade. The previous
+	// version is empty if the cluster was not observed before.
+	PreviousVersion string `json:"previousVersion,omitempty"`
+
+	// Version is the version of the upgraded operator.
+	Version string `json:"version,omitempty"`
+
+	// ObservationStart is the time when the observation of the upgrade was started.
+	ObservationStart metav1.Time `json:"observationStart,omitempty"`
+
+	// PodUpdates contains the process groups whose Pods would be updated by the upgraded operator.
+	// +kubebuilder:validation:MaxItems=10000
+	PodUpdates []ProcessGroupID `json:"podUpdates,omitempty"`
+
+	// Replacements contains the process groups that would be replaced by the upgraded operator.
+	// +kubebuilder:validation:MaxItems=10000
+	Replacements []ProcessGroupID `json:"replacements,omitempty"`
 }
 
 // MaintenanceModeInfo contains information regarding the zone and process groups that are put
@@ -365,16 +658,150 @@ type ProcessGroupStatus struct {
 	Addresses []string `json:"addresses,omitempty"`
 	// RemoveTimestamp if not empty defines when the process group was marked for removal.
 	RemovalTimestamp *metav1.Time `json:"removalTimestamp,omitempty"`
+	// RemovalReason defines why the process group was marked for removal. This field is only set if the operator
+	// decided to remove the process group, e.g. because the process group was misconfigured or failed.
+	RemovalReason *RemovalReason `json:"removalReason,omitempty"`
 	// ExclusionTimestamp defines when the process group has been fully excluded.
 	// This is only used within the reconciliation process, and should not be considered authoritative.
 	ExclusionTimestamp *metav1.Time `json:"exclusionTimestamp,omitempty"`
 	// ExclusionSkipped determines if exclusion has been skipped for a process, which will allow the process group to be removed without exclusion.
 	ExclusionSkipped bool `json:"exclusionSkipped,omitempty"`
+	// PublicIPPinned defines if the public IP of this process group is pinned to the ClusterIP of a per process group
+	// Service. This will be set when the Pod of a coordinator is recreated and routing.pinCoordinatorIPs is enabled.
+	PublicIPPinned bool `json:"publicIPPinned,omitempty"`
 	// ProcessGroupConditions represents a list of degraded conditions that the process group is in.
 	ProcessGroupConditions []*ProcessGroupCondition `json:"processGroupConditions,omitempty"`
 	// FaultDomain represents the last seen fault domain from the cluster status. This can be used if a Pod or process
 	// is not running and would be missing in the cluster status.
 	FaultDomain FaultDomain `json:"faultDomain,omitempty"`
+	// OriginalProcessClass represents the process class that was used to generate the ProcessGroupID. This will only
+	// be set if the process group was reassigned to a different process class.
+	OriginalProcessClass ProcessClass `json:"originalProcessClass,omitempty"`
+	// PodName represents the name of the Pod for this process group. This will only be set if the Pod name was
+	// generated with a custom PodNamePrefix.
+	PodName string `json:"podName,omitempty"`
+	// Roles represents the roles of the processes of this process group as reported in the machine-readable status.
+	// +kubebuilder:validation:MaxItems=20
+	Roles []ProcessRole `json:"roles,omitempty"`
+	// RunningVersion represents the version of the fdbserver processes of this process group as reported in the
+	// machine-readable status.
+	RunningVersion string `json:"runningVersion,omitempty"`
+	// ProcessStartTimestamp represents when the fdbserver processes of this process group were started, based on the
+	// uptime reported in the machine-readable status. If multiple processes are running in this process group, the
+	// latest start will be used.
+	ProcessStartTimestamp *metav1.Time `json:"processStartTimestamp,omitempty"`
+	// VolumeNodeName represents the node that the data volume of this process group is bound to. This will only be
+	// set if the volume was provisioned with the WaitForFirstConsumer binding mode, e.g. for local persistent volumes.
+	VolumeNodeName string `json:"volumeNodeName,omitempty"`
+	// StandbyTimestamp if not empty defines when the process group was kept as a warm standby. A standby process group
+	// is fully excluded but still running and can be re-included to replace a failed process group of the same process
+	// class.
+	StandbyTimestamp *metav1.Time `json:"standbyTimestamp,omitempty"`
+	// ExclusionProgress represents the exclusion progress of the individual fdbserver processes of this process group.
+	// This will only be set for process groups that are marked for removal, are not yet fully excluded and run
+	// multiple fdbserver processes.
+	// +kubebuilder:validation:MaxItems=16
+	ExclusionProgress []ProcessExclusionProgress `json:"exclusionProgress,omitempty"`
+	// RemovalPhases represents when the process group entered the different phases of its removal. This will only be
+	// set for process groups that are marked for removal.
+	RemovalPhases *ProcessGroupRemovalPhases `json:"removalPhases,omitempty"`
+	// MonitorConfHash represents the hash of the dynamic configuration that the fdb-kubernetes-monitor or the sidecar
+	// of this process group has confirmed to be loaded. The operator will only restart the processes of this process
+	// group once this hash matches the desired configuration.
+	MonitorConfHash string `json:"monitorConfHash,omitempty"`
+	// MonitorConfPodUID represents the UID of the Pod that has confirmed to load the configuration with the
+	// MonitorConfHash. The MonitorConfHash is only valid for this Pod, a recreated Pod must confirm the configuration
+	// again.
+	MonitorConfPodUID types.UID `json:"monitorConfPodUID,omitempty"`
+	// StoragePool represents the name of the storage pool this process group belongs to. This will only be set for
+	// storage process groups that were created for a storage pool.
+	StoragePool string `json:"storagePool,omitempty"`
+}
+
+// ProcessGroupRemovalPhase represents a phase of the removal of a process group.
+// +kubebuilder:validation:MaxLength=64
+type ProcessGroupRemovalPhase string
+
+const (
+	// RemovalPhaseMarkedForRemoval represents a process group that is marked for removal, but the exclusion of its
+	// processes has not been started.
+	RemovalPhaseMarkedForRemoval ProcessGroupRemovalPhase = "MarkedForRemoval"
+	// RemovalPhaseExcluding represents a process group whose processes are excluded, but still serve roles.
+	RemovalPhaseExcluding ProcessGroupRemovalPhase = "Excluding"
+	// RemovalPhaseExcluded represents a process group whose processes are fully excluded.
+	RemovalPhaseExcluded ProcessGroupRemovalPhase = "Excluded"
+	// RemovalPhaseResourcesTerminating represents a process group whose resources are being terminated.
+	RemovalPhaseResourcesTerminating ProcessGroupRemovalPhase = "ResourcesTerminating"
+	// RemovalPhaseRemoved represents a process group whose resources are removed. The process group will be removed
+	// from the status once its processes are included again.
+	RemovalPhaseRemoved ProcessGroupRemovalPhase = "Removed"
+)
+
+// AllProcessGroupRemovalPhases returns all ProcessGroupRemovalPhase in the order they are entered.
+func AllProcessGroupRemovalPhases() []ProcessGroupRemovalPhase {
+	return []ProcessGroupRemovalPhase{
+		RemovalPhaseMarkedForRemoval,
+		RemovalPhaseExcluding,
+		RemovalPhaseExcluded,
+		RemovalPhaseResourcesTerminating,
+		RemovalPhaseRemoved,
+	}
+}
+
+// ProcessGroupRemovalPhases represents when a process group entered the different phases of its removal. Phases that
+// were skipped, e.g. the exclusion of process groups that are removed without exclusion, will not be set.
+type ProcessGroupRemovalPhases struct {
+	// MarkedForRemoval defines when the process group was marked for removal.
+	MarkedForRemoval *metav1.Time `json:"markedForRemoval,omitempty"`
+	// Excluding defines when the operator detected that the processes of the process group are excluded.
+	Excluding *metav1.Time `json:"excluding,omitempty"`
+	// Excluded defines when the processes of the process group were fully excluded.
+	Excluded *metav1.Time `json:"excluded,omitempty"`
+	// ResourcesTerminating defines when the operator detected that the resources of the process group are
+	// terminating.
+	ResourcesTerminating *metav1.Time `json:"resourcesTerminating,omitempty"`
+	// Removed defines when the resources of the process group were removed.
+	Removed *metav1.Time `json:"removed,omitempty"`
+}
+
+// GetTimestamp returns the timestamp when the provided phase was entered or nil if the phase was not entered.
+func (phases *ProcessGroupRemovalPhases) GetTimestamp(phase ProcessGroupRemovalPhase) *metav1.Time {
+	if phases == nil {
+		return nil
+	}
+
+	switch phase {
+	case RemovalPhaseMarkedForRemoval:
+		return phases.MarkedForRemoval
+	case RemovalPhaseExcluding:
+		return phases.Excluding
+	case RemovalPhaseExcluded:
+		return phases.Excluded
+	case RemovalPhaseResourcesTerminating:
+		return phases.ResourcesTerminating
+	case RemovalPhaseRemoved:
+		return phases.Removed
+	}
+
+	return nil
+}
+
+// ProcessExclusionProgress represents the exclusion progress of a single fdbserver process of a process group.
+type ProcessExclusionProgress struct {
+	// ProcessID represents the process ID of the fdbserver process.
+	// +kubebuilder:validation:MaxLength=255
+	ProcessID string `json:"processID"`
+	// Excluded defines whether the process is marked as excluded in the machine-readable status.
+	Excluded bool `json:"excluded,omitempty"`
+	// Roles represents the roles that the process still serves. An excluded process is done with the exclusion once
+	// it doesn't serve any roles.
+	// +kubebuilder:validation:MaxItems=20
+	Roles []ProcessRole `json:"roles,omitempty"`
+}
+
+// IsDone returns true if the process is excluded and doesn't serve any roles.
+func (progress ProcessExclusionProgress) IsDone() bool {
+	return progress.Excluded && len(progress.Roles) == 0
 }
 
 // String returns string representation.
@@ -439,6 +866,34 @@ func (processGroupID ProcessGroupID) GetIDNumber() (int, error) {
 	return idNum, nil
 }
 
+// GetProcessGroupIDProcessClass returns the process class that was used to generate the ProcessGroupID. For process
+// groups that were reassigned to a different process class this will be the original process class.
+func (processGroupStatus *ProcessGroupStatus) GetProcessGroupIDProcessClass() ProcessClass {
+	if processGroupStatus.OriginalProcessClass != "" {
+		return processGroupStatus.OriginalProcessClass
+	}
+
+	return processGroupStatus.ProcessClass
+}
+
+// ReassignProcessClass changes the process class of the process group to the provided process class without changing
+// the ProcessGroupID. The ProcessClassReassignment condition will be set to signal that the Pod must be updated.
+func (processGroupStatus *ProcessGroupStatus) ReassignProcessClass(processClass ProcessClass) {
+	if processGroupStatus.ProcessClass == processClass {
+		return
+	}
+
+	originalProcessClass := processGroupStatus.GetProcessGroupIDProcessClass()
+	if originalProcessClass == processClass {
+		processGroupStatus.OriginalProcessClass = ""
+	} else {
+		processGroupStatus.OriginalProcessClass = originalProcessClass
+	}
+
+	processGroupStatus.ProcessClass = processClass
+	processGroupStatus.UpdateCondition(ProcessClassReassignment, true)
+}
+
 // GetExclusionString returns the exclusion string
 func (processGroupStatus *ProcessGroupStatus) GetExclusionString() string {
 	return fmt.Sprintf("%s:%s", FDBLocalityExclusionPrefix, processGroupStatus.ProcessGroupID)
@@ -458,6 +913,9 @@ func (processGroupStatus *ProcessGroupStatus) SetExclude() {
 	}
 
 	processGroupStatus.ExclusionTimestamp = &metav1.Time{Time: time.Now()}
+	processGroupStatus.ExclusionProgress = nil
+	// The removal phases must be updated before the conditions are reset.
+	processGroupStatus.UpdateRemovalPhases()
 	// Reset all previous conditions as the operator will only track the ResourcesTerminating condition for process
 	// groups marked as removal. If the ResourcesTerminating condition is already set we are not removing it.
 	newConditions := make([]*ProcessGroupCondition, 0, 1)
@@ -477,6 +935,17 @@ func (processGroupStatus *ProcessGroupStatus) IsMarkedForRemoval() bool {
 	return !processGroupStatus.RemovalTimestamp.IsZero()
 }
 
+// MarkForRemovalWithReason marks a process group for removal and records the reason for the removal. If the process
+// group is already marked for removal, neither the RemovalTimestamp nor the RemovalReason will be changed.
+func (processGroupStatus *ProcessGroupStatus) MarkForRemovalWithReason(reason *RemovalReason) {
+	if !processGroupStatus.RemovalTimestamp.IsZero() {
+		return
+	}
+
+	processGroupStatus.RemovalReason = reason
+	processGroupStatus.MarkForRemoval()
+}
+
 // MarkForRemoval marks a process group for removal. If the RemovalTimestamp is already set it won't be changed.
 func (processGroupStatus *ProcessGroupStatus) MarkForRemoval() {
 	if !processGroupStatus.RemovalTimestamp.IsZero() {
@@ -486,16 +955,172 @@ func (processGroupStatus *ProcessGroupStatus) MarkForRemoval() {
 	processGroupStatus.RemovalTimestamp = &metav1.Time{Time: time.Now()}
 }
 
+// CancelRemoval resets the removal information of a process group that is marked for removal, so the process group
+// will be used as an active process group again. This should only be done if the exclusion of the process group has
+// not been started.
+func (processGroupStatus *ProcessGroupStatus) CancelRemoval() {
+	processGroupStatus.RemovalTimestamp = nil
+	processGroupStatus.RemovalReason = nil
+	processGroupStatus.RemovalPhases = nil
+	processGroupStatus.ExclusionSkipped = false
+}
+
+// UpdateRemovalPhases records the removal phases that the process group has entered, based on the removal and
+// exclusion information and the conditions of the process group. Timestamps of phases that were already entered will
+// not be changed. If the process group is not marked for removal, the removal phases will be reset.
+func (processGroupStatus *ProcessGroupStatus) UpdateRemovalPhases() {
+	if !processGroupStatus.IsMarkedForRemoval() {
+		processGroupStatus.RemovalPhases = nil
+		return
+	}
+
+	if processGroupStatus.RemovalPhases == nil {
+		processGroupStatus.RemovalPhases = &ProcessGroupRemovalPhases{}
+	}
+
+	phases := processGroupStatus.RemovalPhases
+	if phases.MarkedForRemoval == nil {
+		phases.MarkedForRemoval = processGroupStatus.RemovalTimestamp.DeepCopy()
+	}
+
+	// The conditions could be present before the process group was marked for removal, in this case the phase starts
+	// with the removal.
+	getPhaseStart := func(conditionType ProcessGroupConditionType) *metav1.Time {
+		conditionTime := processGroupStatus.GetConditionTime(conditionType)
+		if conditionTime == nil {
+			return nil
+		}
+
+		start := time.Unix(*conditionTime, 0)
+		if start.Before(phases.MarkedForRemoval.Time) {
+			start = phases.MarkedForRemoval.Time
+		}
+
+		return &metav1.Time{Time: start}
+	}
+
+	if phases.Excluding == nil && phases.Excluded == nil {
+		phases.Excluding = getPhaseStart(ProcessIsMarkedAsExcluded)
+	}
+
+	if phases.Excluded == nil && !processGroupStatus.ExclusionTimestamp.IsZero() {
+		phases.Excluded = processGroupStatus.ExclusionTimestamp.DeepCopy()
+	}
+
+	if phases.ResourcesTerminating == nil {
+		phases.ResourcesTerminating = getPhaseStart(ResourcesTerminating)
+	}
+}
+
+// MarkAsRemoved records that the resources of the process group were removed.
+func (processGroupStatus *ProcessGroupStatus) MarkAsRemoved() {
+	processGroupStatus.UpdateRemovalPhases()
+	if processGroupStatus.RemovalPhases == nil || processGroupStatus.RemovalPhases.Removed != nil {
+		return
+	}
+
+	processGroupStatus.RemovalPhases.Removed = &metav1.Time{Time: time.Now()}
+}
+
+// GetRemovalPhase returns the current removal phase of the process group. If the process group is not marked for
+// removal, an empty phase will be returned.
+func (processGroupStatus *ProcessGroupStatus) GetRemovalPhase() ProcessGroupRemovalPhase {
+	if !processGroupStatus.IsMarkedForRemoval() {
+		return ""
+	}
+
+	allPhases := AllProcessGroupRemovalPhases()
+	for i := len(allPhases) - 1; i >= 0; i-- {
+		if processGroupStatus.RemovalPhases.GetTimestamp(allPhases[i]) != nil {
+			return allPhases[i]
+		}
+	}
+
+	return RemovalPhaseMarkedForRemoval
+}
+
+// GetRemovalPhaseDurations returns how long the process group was in each of the removal phases it has entered. The
+// duration of the current phase is calculated until now.
+func (processGroupStatus *ProcessGroupStatus) GetRemovalPhaseDurations() map[ProcessGroupRemovalPhase]time.Duration {
+	if processGroupStatus.RemovalPhases == nil {
+		return nil
+	}
+
+	durations := map[ProcessGroupRemovalPhase]time.Duration{}
+	var lastPhase ProcessGroupRemovalPhase
+	var lastTimestamp *metav1.Time
+	for _, phase := range AllProcessGroupRemovalPhases() {
+		timestamp := processGroupStatus.RemovalPhases.GetTimestamp(phase)
+		if timestamp == nil {
+			continue
+		}
+
+		if lastTimestamp != nil {
+			durations[lastPhase] = timestamp.Sub(lastTimestamp.Time)
+		}
+
+		lastPhase = phase
+		lastTimestamp = timestamp
+	}
+
+	// The removed phase is the last phase, so there is no duration to track.
+	if lastTimestamp != nil && lastPhase != RemovalPhaseRemoved {
+		durations[lastPhase] = time.Since(lastTimestamp.Time)
+	}
+
+	return durations
+}
+
+// IsStandby returns if a process group is kept as a warm standby.
+func (processGroupStatus *ProcessGroupStatus) IsStandby() bool {
+	return !processGroupStatus.StandbyTimestamp.IsZero()
+}
+
+// MarkAsStandby marks a process group that is marked for removal and fully excluded as a warm standby. If the
+// StandbyTimestamp is already set it won't be changed.
+func (processGroupStatus *ProcessGroupStatus) MarkAsStandby() {
+	if !processGroupStatus.StandbyTimestamp.IsZero() {
+		return
+	}
+
+	processGroupStatus.StandbyTimestamp = &metav1.Time{Time: time.Now()}
+}
+
+// ReleaseStandby removes the standby marker of the process group, so the process group will be removed.
+func (processGroupStatus *ProcessGroupStatus) ReleaseStandby() {
+	processGroupStatus.StandbyTimestamp = nil
+}
+
+// ActivateStandby resets the removal and exclusion information of a standby process group, so the process group
+// will be used as an active process group again. The processes of the process group must be included before.
+func (processGroupStatus *ProcessGroupStatus) ActivateStandby() {
+	processGroupStatus.StandbyTimestamp = nil
+	processGroupStatus.RemovalTimestamp = nil
+	processGroupStatus.RemovalReason = nil
+	processGroupStatus.RemovalPhases = nil
+	processGroupStatus.ExclusionTimestamp = nil
+	processGroupStatus.ExclusionSkipped = false
+	// The condition will be added again by the next status update if the processes are still excluded.
+	processGroupStatus.UpdateCondition(ProcessIsMarkedAsExcluded, false)
+}
+
 // GetPodName returns the Pod name for the associated Process Group.
 func (processGroupStatus *ProcessGroupStatus) GetPodName(cluster *FoundationDBCluster) string {
+	// If the Pod name was stored during the creation of the process group, we have to use it to make sure the Pod
+	// name stays the same, even if the PodNamePrefix was changed.
+	if processGroupStatus.PodName != "" {
+		return processGroupStatus.PodName
+	}
+
 	var sb strings.Builder
 	sb.WriteString(cluster.Name)
 	sb.WriteString("-")
 	// The Pod name will always be in the format ${cluster}-${process-class}-${id}. The ID is currently not available
 	// in the processGroupStatus without doing any parsing, so we have to use the Process Group ID, which might contain
-	// a prefix, so we take the part after the prefix, which will be ${process-class}-${id}.
+	// a prefix, so we take the part after the prefix, which will be ${process-class}-${id}. For reassigned process
+	// groups the process class that was used to generate the Process Group ID will be used.
 	sanitizedProcessGroup := strings.ReplaceAll(string(processGroupStatus.ProcessGroupID), "_", "-")
-	sanitizedProcessClass := strings.ReplaceAll(string(processGroupStatus.ProcessClass), "_", "-")
+	sanitizedProcessClass := strings.ReplaceAll(string(processGroupStatus.GetProcessGroupIDProcessClass()), "_", "-")
 
 	idx := strings.Index(sanitizedProcessGroup, sanitizedProcessClass)
 	sb.WriteString(sanitizedProcessGroup[idx:])
@@ -503,6 +1128,29 @@ func (processGroupStatus *ProcessGroupStatus) GetPodName(cluster *FoundationDBCl
 	return sb.String()
 }
 
+// HasLoadedMonitorConf returns true if the provided Pod of this process group has confirmed that the dynamic
+// configuration with the provided hash was loaded. A recreated Pod must confirm the configuration again.
+func (processGroupStatus *ProcessGroupStatus) HasLoadedMonitorConf(podUID types.UID, configMapHash string) bool {
+	return processGroupStatus.MonitorConfPodUID == podUID && processGroupStatus.MonitorConfHash == configMapHash
+}
+
+// SetLoadedMonitorConf stores that the provided Pod of this process group has confirmed that the dynamic configuration
+// with the provided hash was loaded.
+func (processGroupStatus *ProcessGroupStatus) SetLoadedMonitorConf(podUID types.UID, configMapHash string) {
+	processGroupStatus.MonitorConfPodUID = podUID
+	processGroupStatus.MonitorConfHash = configMapHash
+}
+
+// GetImageTypeMigrationFaultDomain returns the fault domain that is used to migrate the process group to a different
+// image type. Process groups without a fault domain are migrated one by one, in this case the process group ID is used.
+func (processGroupStatus *ProcessGroupStatus) GetImageTypeMigrationFaultDomain() FaultDomain {
+	if processGroupStatus.FaultDomain == "" {
+		return FaultDomain(processGroupStatus.ProcessGroupID)
+	}
+
+	return processGroupStatus.FaultDomain
+}
+
 // NeedsReplacement checks if the ProcessGroupStatus has conditions that require a replacement of the failed Process Group.
 // The method will return the failure condition and the timestamp. If no failure is detected an empty condition and a 0
 // will be returned.
@@ -580,6 +1228,108 @@ func (processGroupStatus *ProcessGroupStatus) AddAddresses(addresses []string, i
 	}
 }
 
+// processStartTimestampTolerance defines the tolerance for changes of the process start timestamp. The start timestamp
+// is derived from the uptime of the processes and will slightly shift between the reconciliation loops.
+const processStartTimestampTolerance = time.Minute
+
+// UpdateExclusionProgress updates the exclusion progress of the individual processes of the process group based on the
+// processes reported in the machine-readable status. The progress is only tracked for process groups that are marked
+// for removal, are not yet excluded and run multiple processes, otherwise the progress will be reset.
+func (processGroupStatus *ProcessGroupStatus) UpdateExclusionProgress(processes []FoundationDBStatusProcessInfo) {
+	if !processGroupStatus.IsMarkedForRemoval() || processGroupStatus.IsExcluded() || len(processes) <= 1 {
+		processGroupStatus.ExclusionProgress = nil
+		return
+	}
+
+	progress := make([]ProcessExclusionProgress, 0, len(processes))
+	for _, process := range processes {
+		processID, ok := process.Locality[FDBLocalityProcessIDKey]
+		if !ok {
+			processID = process.Locality[FDBLocalityInstanceIDKey]
+		}
+
+		var roles []ProcessRole
+		for _, role := range process.Roles {
+			roles = append(roles, ProcessRole(role.Role))
+		}
+
+		sort.Slice(roles, func(i, j int) bool {
+			return roles[i] < roles[j]
+		})
+
+		progress = append(progress, ProcessExclusionProgress{
+			ProcessID: processID,
+			Excluded:  process.Excluded,
+			Roles:     roles,
+		})
+	}
+
+	sort.Slice(progress, func(i, j int) bool {
+		return progress[i].ProcessID < progress[j].ProcessID
+	})
+
+	processGroupStatus.ExclusionProgress = progress
+}
+
+// GetPendingExclusions returns the process IDs of all processes of the process group whose exclusion is not yet done.
+func (processGroupStatus *ProcessGroupStatus) GetPendingExclusions() []string {
+	var pending []string
+	for _, progress := range processGroupStatus.ExclusionProgress {
+		if progress.IsDone() {
+			continue
+		}
+
+		pending = append(pending, progress.ProcessID)
+	}
+
+	return pending
+}
+
+// UpdateProcessInformation updates the roles, the running version and the process start timestamp of the process group
+// based on the processes reported in the machine-readable status. If no processes are reported only the roles will be
+// reset.
+func (processGroupStatus *ProcessGroupStatus) UpdateProcessInformation(processes []FoundationDBStatusProcessInfo) {
+	if len(processes) == 0 {
+		processGroupStatus.Roles = nil
+		return
+	}
+
+	roles := map[ProcessRole]None{}
+	minUptime := processes[0].UptimeSeconds
+	for _, process := range processes {
+		for _, role := range process.Roles {
+			roles[ProcessRole(role.Role)] = None{}
+		}
+
+		if process.Version != "" {
+			processGroupStatus.RunningVersion = process.Version
+		}
+
+		if process.UptimeSeconds < minUptime {
+			minUptime = process.UptimeSeconds
+		}
+	}
+
+	processGroupStatus.Roles = nil
+	for role := range roles {
+		processGroupStatus.Roles = append(processGroupStatus.Roles, role)
+	}
+	sort.Slice(processGroupStatus.Roles, func(i, j int) bool {
+		return processGroupStatus.Roles[i] < processGroupStatus.Roles[j]
+	})
+
+	startTimestamp := time.Now().Add(-time.Duration(minUptime * float64(time.Second))).Truncate(time.Second)
+	// Only update the timestamp if the processes were restarted to prevent status updates in every reconciliation loop.
+	if processGroupStatus.ProcessStartTimestamp != nil {
+		difference := startTimestamp.Sub(processGroupStatus.ProcessStartTimestamp.Time)
+		if difference < processStartTimestampTolerance && difference > -processStartTimestampTolerance {
+			return
+		}
+	}
+
+	processGroupStatus.ProcessStartTimestamp = &metav1.Time{Time: startTimestamp}
+}
+
 // This method removes duplicates and empty strings from a list of addresses.
 func cleanAddressList(addresses []string) []string {
 	result := make([]string, 0, len(addresses))
@@ -740,9 +1490,10 @@ func (processGroupStatus *ProcessGroupStatus) addCondition(conditionType Process
 		}
 	}
 
-	// If a process group is marked for removal and is fully excluded we only keep the ResourcesTerminating condition.
+	// If a process group is marked for removal and is fully excluded we only keep the ResourcesTerminating condition
+	// and the conditions for deletions that must be done by a privileged user or controller.
 	if processGroupStatus.IsMarkedForRemoval() && processGroupStatus.IsExcluded() {
-		if conditionType != ResourcesTerminating {
+		if conditionType != ResourcesTerminating && conditionType != PodDeletionRequired && conditionType != PVCDeletionRequired {
 			return
 		}
 	}
@@ -850,6 +1601,18 @@ func (processGroupStatus *ProcessGroupStatus) GetConditionTime(conditionType Pro
 	return nil
 }
 
+// HasFailureCondition returns true if the process group has at least one condition that makes the process group
+// eligible for a replacement, independent of how long the condition has been present.
+func (processGroupStatus *ProcessGroupStatus) HasFailureCondition() bool {
+	for _, conditionType := range conditionsThatNeedReplacement {
+		if processGroupStatus.GetConditionTime(conditionType) != nil {
+			return true
+		}
+	}
+
+	return processGroupStatus.GetConditionTime(PodStuck) != nil
+}
+
 // IsUnderMaintenance checks if the process is in maintenance zone.
 func (processGroupStatus *ProcessGroupStatus) IsUnderMaintenance(maintenanceZone FaultDomain) bool {
 	// Only storage processes are affected by the maintenance zone.
@@ -905,6 +1668,60 @@ func (condition *ProcessGroupCondition) String() string {
 	return sb.String()
 }
 
+// RemovalReason describes why a process group was marked for removal.
+type RemovalReason struct {
+	// Type defines the kind of the reason.
+	Type RemovalReasonType `json:"type"`
+
+	// Message contains a human readable description of the reason.
+	// +kubebuilder:validation:MaxLength=1024
+	Message string `json:"message,omitempty"`
+}
+
+// RemovalReasonType defines the kind of reason why a process group was marked for removal.
+// +kubebuilder:validation:MaxLength=64
+type RemovalReasonType string
+
+const (
+	// RemovalReasonProcessGroupIDChanged is used if the process group ID doesn't match the processGroupIDPrefix.
+	RemovalReasonProcessGroupIDChanged RemovalReasonType = "ProcessGroupIDChanged"
+	// RemovalReasonPublicIPSourceChanged is used if the public IP source of the Pod has changed.
+	RemovalReasonPublicIPSourceChanged RemovalReasonType = "PublicIPSourceChanged"
+	// RemovalReasonServersPerPodChanged is used if the number of servers per Pod has changed.
+	RemovalReasonServersPerPodChanged RemovalReasonType = "ServersPerPodChanged"
+	// RemovalReasonResourcesChanged is used if the resource requests have been increased and
+	// replaceInstancesWhenResourcesChange is enabled.
+	RemovalReasonResourcesChanged RemovalReasonType = "ResourcesChanged"
+	// RemovalReasonNodeSelectorChanged is used if the node selector of the Pod has changed.
+	RemovalReasonNodeSelectorChanged RemovalReasonType = "NodeSelectorChanged"
+	// RemovalReasonServiceAccountChanged is used if the service account of the Pod has changed.
+	RemovalReasonServiceAccountChanged RemovalReasonType = "ServiceAccountChanged"
+	// RemovalReasonImagePullSecretsChanged is used if the Pod is missing a desired image pull secret.
+	RemovalReasonImagePullSecretsChanged RemovalReasonType = "ImagePullSecretsChanged"
+	// RemovalReasonImageTypeChanged is used if the image type has changed and the disk layout must be changed.
+	RemovalReasonImageTypeChanged RemovalReasonType = "ImageTypeChanged"
+	// RemovalReasonPodSpecChanged is used if the Pod spec has changed in a way that requires a replacement.
+	RemovalReasonPodSpecChanged RemovalReasonType = "PodSpecChanged"
+	// RemovalReasonSecurityContextChanged is used if the file security context of the Pod has changed.
+	RemovalReasonSecurityContextChanged RemovalReasonType = "SecurityContextChanged"
+	// RemovalReasonPVCChanged is used if the spec or the name of the PVC has changed.
+	RemovalReasonPVCChanged RemovalReasonType = "PVCChanged"
+	// RemovalReasonStorageClassChanged is used if the storage class of the PVC has changed.
+	RemovalReasonStorageClassChanged RemovalReasonType = "StorageClassChanged"
+	// RemovalReasonNodeVersionSkew is used if the node of the Pod runs an older version than required.
+	RemovalReasonNodeVersionSkew RemovalReasonType = "NodeVersionSkew"
+	// RemovalReasonProcessGroupFailed is used if the process group was automatically replaced because it failed.
+	RemovalReasonProcessGroupFailed RemovalReasonType = "ProcessGroupFailed"
+	// RemovalReasonNodeDraining is used if the node of the Pod is being drained.
+	RemovalReasonNodeDraining RemovalReasonType = "NodeDraining"
+	// RemovalReasonVolumeTopologyConflict is used if the desired Pod spec can't be scheduled on any node that
+	// satisfies the node affinity of the persistent volume.
+	RemovalReasonVolumeTopologyConflict RemovalReasonType = "VolumeTopologyConflict"
+	// RemovalReasonStorageEngineChanged is used if the storage processes of the process group are running with a
+	// different storage engine than the storage engine of the database configuration.
+	RemovalReasonStorageEngineChanged RemovalReasonType = "StorageEngineChanged"
+)
+
 // ProcessGroupConditionType represents a concrete ProcessGroupCondition.
 type ProcessGroupConditionType string
 
@@ -942,6 +1759,56 @@ const (
 	NodeTaintReplacing ProcessGroupConditionType = "NodeTaintReplacing"
 	// ProcessIsMarkedAsExcluded represents a process group where at least one process is excluded.
 	ProcessIsMarkedAsExcluded ProcessGroupConditionType = "ProcessIsMarkedAsExcluded"
+	// ProcessClassReassignment represents a process group that was reassigned to a different process class and
+	// the Pod must be updated to run with the new process class.
+	ProcessClassReassignment ProcessGroupConditionType = "ProcessClassReassignment"
+	// DiskQualificationFailed represents a process group where a volume performed below the thresholds of the disk
+	// qualification.
+	DiskQualificationFailed ProcessGroupConditionType = "DiskQualificationFailed"
+	// VolumeNodeMissing represents a process group where the data volume is bound to a node that doesn't exist
+	// anymore. The data on a local volume is lost in this case and the process group must be replaced.
+	VolumeNodeMissing ProcessGroupConditionType = "VolumeNodeMissing"
+	// PodStuck represents a process group where the Pod is stuck in the Pending phase or where a container is in the
+	// CrashLoopBackOff or ImagePullBackOff state.
+	PodStuck ProcessGroupConditionType = "PodStuck"
+	// GhostProcess represents a process group that was removed, but where processes with the localities or the
+	// addresses of the process group are still reporting to the database.
+	GhostProcess ProcessGroupConditionType = "GhostProcess"
+	// ReplacementLoop represents a failed process group that is not automatically replaced, because too many failed
+	// process groups of the same process class were replaced recently.
+	ReplacementLoop ProcessGroupConditionType = "ReplacementLoop"
+	// PendingReplacementApproval represents a process group that should be replaced, but the replacement was not yet
+	// approved.
+	PendingReplacementApproval ProcessGroupConditionType = "PendingReplacementApproval"
+	// ReplacementDeferredByDisruptionBudget represents a misconfigured process group whose replacement is deferred,
+	// because it would exceed the disruptions allowed by a PodDisruptionBudget.
+	ReplacementDeferredByDisruptionBudget ProcessGroupConditionType = "ReplacementDeferredByDisruptionBudget"
+	// PodDeletionRequired represents a process group where the Pod must be deleted, but the operator runs without
+	// the permissions for destructive actions. The Pod must be deleted by a privileged user or controller.
+	PodDeletionRequired ProcessGroupConditionType = "PodDeletionRequired"
+	// PVCDeletionRequired represents a removed process group where the PVCs must be deleted, but the operator runs
+	// without the permissions for destructive actions. The PVCs must be deleted by a privileged user or controller.
+	PVCDeletionRequired ProcessGroupConditionType = "PVCDeletionRequired"
+	// SecurityContextChangePending represents a process group with a changed file security context, where the
+	// replacement waits until the change persists for the securityContextChangeGracePeriodSeconds.
+	SecurityContextChangePending ProcessGroupConditionType = "SecurityContextChangePending"
+	// NodeDraining represents a process group whose Pod runs on a node that is cordoned or marked for removal by a
+	// node autoscaler like Karpenter or the cluster-autoscaler.
+	NodeDraining ProcessGroupConditionType = "NodeDraining"
+	// VolumeTopologyConflict represents a process group where the desired Pod spec can't be scheduled on any node that
+	// satisfies the node affinity of the persistent volume, e.g. because the node selector requires a different zone.
+	VolumeTopologyConflict ProcessGroupConditionType = "VolumeTopologyConflict"
+	// IncorrectStorageEngine represents a process group with storage processes that are running with a different
+	// storage engine than the storage engine of the database configuration. This condition is only set if the storage
+	// engine migration is enabled.
+	IncorrectStorageEngine ProcessGroupConditionType = "IncorrectStorageEngine"
+	// PendingKnobReload represents a process group whose processes are running with a different value for knobs that
+	// can be reloaded without a restart. This condition is only set if the knob hot reload is enabled.
+	PendingKnobReload ProcessGroupConditionType = "PendingKnobReload"
+	// NodeMemoryOvercommitted represents a process group whose Pod was not created, because no node has enough
+	// allocatable memory left for the memory knobs of the fdbserver processes. This condition is only set if the
+	// memory overcommit protection is enabled.
+	NodeMemoryOvercommitted ProcessGroupConditionType = "NodeMemoryOvercommitted"
 )
 
 // AllProcessGroupConditionTypes returns all ProcessGroupConditionType
@@ -961,6 +1828,22 @@ func AllProcessGroupConditionTypes() []ProcessGroupConditionType {
 		NodeTaintDetected,
 		NodeTaintReplacing,
 		ProcessIsMarkedAsExcluded,
+		ProcessClassReassignment,
+		DiskQualificationFailed,
+		VolumeNodeMissing,
+		PodStuck,
+		GhostProcess,
+		ReplacementLoop,
+		PendingReplacementApproval,
+		ReplacementDeferredByDisruptionBudget,
+		PodDeletionRequired,
+		PVCDeletionRequired,
+		SecurityContextChangePending,
+		NodeDraining,
+		VolumeTopologyConflict,
+		IncorrectStorageEngine,
+		PendingKnobReload,
+		NodeMemoryOvercommitted,
 	}
 }
 
@@ -997,6 +1880,38 @@ func GetProcessGroupConditionType(processGroupConditionType string) (ProcessGrou
 		return NodeTaintReplacing, nil
 	case "ProcessIsMarkedAsExcluded":
 		return ProcessIsMarkedAsExcluded, nil
+	case "ProcessClassReassignment":
+		return ProcessClassReassignment, nil
+	case "DiskQualificationFailed":
+		return DiskQualificationFailed, nil
+	case "VolumeNodeMissing":
+		return VolumeNodeMissing, nil
+	case "PodStuck":
+		return PodStuck, nil
+	case "GhostProcess":
+		return GhostProcess, nil
+	case "ReplacementLoop":
+		return ReplacementLoop, nil
+	case "PendingReplacementApproval":
+		return PendingReplacementApproval, nil
+	case "ReplacementDeferredByDisruptionBudget":
+		return ReplacementDeferredByDisruptionBudget, nil
+	case "PodDeletionRequired":
+		return PodDeletionRequired, nil
+	case "PVCDeletionRequired":
+		return PVCDeletionRequired, nil
+	case "SecurityContextChangePending":
+		return SecurityContextChangePending, nil
+	case "NodeDraining":
+		return NodeDraining, nil
+	case "VolumeTopologyConflict":
+		return VolumeTopologyConflict, nil
+	case "IncorrectStorageEngine":
+		return IncorrectStorageEngine, nil
+	case "PendingKnobReload":
+		return PendingKnobReload, nil
+	case "NodeMemoryOvercommitted":
+		return NodeMemoryOvercommitted, nil
 	}
 
 	return "", fmt.Errorf("unknown process group condition type: %s", processGroupConditionType)
@@ -1081,6 +1996,10 @@ type ClusterHealth struct {
 	// DataMovementPriority reports the priority of the highest-priority data
 	// movement in the cluster.
 	DataMovementPriority int `json:"dataMovementPriority,omitempty"`
+
+	// FaultTolerance reports the number of zones that can fail without losing
+	// data or availability.
+	FaultTolerance int `json:"faultTolerance,omitempty"`
 }
 
 // FoundationDBClusterAutomationOptions provides flags for enabling or disabling
@@ -1142,6 +2061,15 @@ type FoundationDBClusterAutomationOptions struct {
 	// +kubebuilder:validation:Minimum=0
 	MaxConcurrentReplacements *int `json:"maxConcurrentReplacements,omitempty"`
 
+	// MassReplacementThresholdPercentage defines the percentage of process groups that can be replaced because they
+	// are misconfigured in a single reconciliation without an explicit approval. If more process groups would be
+	// replaced, the operator will not replace any of them until the replacement is approved with the
+	// foundationdb.org/approve-mass-replacement annotation, independent of MaxConcurrentReplacements. If unset, no
+	// approval is required.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	MassReplacementThresholdPercentage *int `json:"massReplacementThresholdPercentage,omitempty"`
+
 	// DeletionMode defines the deletion mode for this cluster. This can be
 	// PodUpdateModeNone, PodUpdateModeAll, PodUpdateModeZone or PodUpdateModeProcessGroup. The
 	// DeletionMode defines how Pods are deleted in order to update them or
@@ -1168,24 +2096,404 @@ type FoundationDBClusterAutomationOptions struct {
 	// Defaults to 60.
 	WaitBetweenRemovalsSeconds *int `json:"waitBetweenRemovalsSeconds,omitempty"`
 
+	// VerifyProcessRemoval defines if the operator should verify that the processes of a removed process group are no
+	// longer reporting to the database, before the process group is included again and removed from the status. If
+	// processes are still reporting, the process group gets the GhostProcess condition and the exclusion is kept.
+	// The default is false.
+	VerifyProcessRemoval *bool `json:"verifyProcessRemoval,omitempty"`
+
+	// UseOnlinePVCExpansion defines whether the operator should expand the PVCs of the process groups in place, if the
+	// only change of the PVC spec is an increased storage request and the storage class allows volume expansion.
+	// Otherwise, the process groups will be replaced.
+	// The default is false.
+	UseOnlinePVCExpansion *bool `json:"useOnlinePVCExpansion,omitempty"`
+
 	// PodUpdateStrategy defines how Pod spec changes are rolled out either by replacing Pods or by deleting Pods.
+	// If set to InPlaceResize, changes that only modify the CPU or memory resources of the containers are applied by
+	// resizing the containers in place, all other changes are rolled out like with ReplaceTransactionSystem.
 	// The default for this is ReplaceTransactionSystem.
 	// +kubebuilder:validation:Optional
-	// +kubebuilder:validation:Enum=Replace;ReplaceTransactionSystem;Delete
+	// +kubebuilder:validation:Enum=Replace;ReplaceTransactionSystem;Delete;InPlaceResize
 	// +kubebuilder:default:=ReplaceTransactionSystem
 	PodUpdateStrategy PodUpdateStrategy `json:"podUpdateStrategy,omitempty"`
 
-	// UseManagementAPI defines if the operator should make use of the management API instead of
-	// using fdbcli to interact with the FoundationDB cluster.
-	UseManagementAPI *bool `json:"useManagementAPI,omitempty"`
+	// UseInPlaceResizeForStatefulProcesses defines whether the containers of stateful process groups, e.g. storage
+	// and log process groups, should be resized in place if the PodUpdateStrategy is InPlaceResize. If disabled only
+	// the containers of stateless process groups are resized in place.
+	// The default is false.
+	UseInPlaceResizeForStatefulProcesses *bool `json:"useInPlaceResizeForStatefulProcesses,omitempty"`
 
-	// MaintenanceModeOptions contains options for maintenance mode related settings.
+	// ImageChangePolicy defines how changes of the container images are rolled out. If set to InPlaceRegistryUpdate
+	// and only the registry of the images has changed, e.g. during a migration to a registry mirror, while the
+	// repository, tag and digest are identical, the operator will update the images of the Pods in place instead of
+	// recreating or replacing the Pods. All other changes are rolled out based on the PodUpdateStrategy.
+	// The default is Default.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Default;InPlaceRegistryUpdate
+	ImageChangePolicy ImageChangePolicy `json:"imageChangePolicy,omitempty"`
+
+	// KnobRolloutStrategy defines how processes are restarted if their command line has changed, e.g. because the
+	// customParameters of a process class were modified. Only processes of the affected process classes are restarted.
+	// If set to Canary, the operator restarts a single process group of each affected process class first and only
+	// restarts the remaining processes of this process class once the canary is reporting to the database with the new
+	// command line and the minimum uptime for bounces has passed.
+	// The default is All.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=All;Canary
+	KnobRolloutStrategy KnobRolloutStrategy `json:"knobRolloutStrategy,omitempty"`
+
+	// UseKnobHotReload defines whether the operator should apply changes of knobs that fdbserver can reload without a
+	// restart, e.g. the trace severity or some throttling knobs, through the configuration database instead of
+	// restarting the processes. Processes are still restarted if any other argument was changed. The reloaded knobs
+	// are applied to all processes, so they are only reloaded if all process classes use the same value.
+	// The default is false.
+	UseKnobHotReload *bool `json:"useKnobHotReload,omitempty"`
+
+	// UseManagementAPI defines if the operator should make use of the management API instead of
+	// using fdbcli to interact with the FoundationDB cluster.
+	UseManagementAPI *bool `json:"useManagementAPI,omitempty"`
+
+	// MaintenanceModeOptions contains options for maintenance mode related settings.
 	MaintenanceModeOptions MaintenanceModeOptions `json:"maintenanceModeOptions,omitempty"`
 
 	// IgnoreLogGroupsForUpgrade defines the list of LogGroups that should be ignored during fdb version upgrade.
 	// The default is a list that includes "fdb-kubernetes-operator".
 	// +kubebuilder:validation:MaxItems=10
 	IgnoreLogGroupsForUpgrade []LogGroup `json:"ignoreLogGroupsForUpgrade,omitempty"`
+
+	// UseProcessClassReassignment defines whether the operator is allowed to change the process class of an existing
+	// process group in place if the process counts are changed, e.g. from stateless to proxy. This is only done if
+	// the process classes are compatible, otherwise the process groups will be replaced. This setting has no effect
+	// if the PodUpdateStrategy is Replacement.
+	// The default is false.
+	UseProcessClassReassignment *bool `json:"useProcessClassReassignment,omitempty"`
+
+	// UseCompactProcessGroupIDs defines whether the operator should use the lowest unused ID number for new process
+	// groups instead of a random ID number. This keeps the process group IDs in a compact range, gaps that were
+	// created by previous replacements can be closed with the "kubectl fdb compact-process-group-ids" command.
+	// The default is false.
+	UseCompactProcessGroupIDs *bool `json:"useCompactProcessGroupIDs,omitempty"`
+
+	// CleanupStaleExclusions defines whether the operator should include exclusion entries that don't match any process
+	// in the database and any process group of this cluster. Those entries can be leaked by manual exclusions or
+	// interrupted removals and accumulate over time. The cleanup is skipped for clusters that span multiple data
+	// centers, as the exclusions could be managed by another operator instance.
+	// The default is false.
+	CleanupStaleExclusions *bool `json:"cleanupStaleExclusions,omitempty"`
+
+	// UseOrchestratedImageTypeMigration defines whether a change of the imageType should be rolled out one fault domain
+	// at a time. The operator only continues with the next fault domain once all migrated process groups are healthy,
+	// including the reachability of the fdb-kubernetes-monitor API for the unified image. A migration can be rolled
+	// back by changing the imageType back to the previous value. While the migration is in progress, process groups in
+	// other fault domains will not be updated or replaced.
+	// The default is false.
+	UseOrchestratedImageTypeMigration *bool `json:"useOrchestratedImageTypeMigration,omitempty"`
+
+	// MigratePodSpecHashes defines whether the operator should rewrite the spec hash annotation of Pods in place, if
+	// the hash was computed with an older version of the hash algorithm and the Pod spec itself hasn't changed. This
+	// prevents that an operator upgrade which changes the hash algorithm causes a replacement of all Pods.
+	// The default is false.
+	MigratePodSpecHashes *bool `json:"migratePodSpecHashes,omitempty"`
+
+	// DeriveMemoryKnobs defines whether the operator should derive the memory and cache_memory arguments of the
+	// fdbserver processes from the memory resources of the main container. The memory limit, or the memory request
+	// if no limit is defined, minus a proportional margin and a reserved overhead for the monitor process is split
+	// between the processes of a Pod. Values that are defined in the customParameters of a process class take precedence.
+	// The default is false.
+	DeriveMemoryKnobs *bool `json:"deriveMemoryKnobs,omitempty"`
+
+	// MemoryOvercommitProtection defines if the operator should check that a new Pod fits on at least one node before
+	// creating it. The memory of the new Pod is estimated from the memory knobs of the fdbserver processes and the
+	// memory that is already committed on a node is estimated from the memory limits of the FoundationDB Pods running
+	// on it. If unset the check is disabled.
+	MemoryOvercommitProtection *MemoryOvercommitProtectionOptions `json:"memoryOvercommitProtection,omitempty"`
+
+	// StatelessScaling defines the limits for the stateless process count, when the stateless process count is managed
+	// by an autoscaler through the scale subresource.
+	StatelessScaling *StatelessScalingOptions `json:"statelessScaling,omitempty"`
+
+	// ProcessGroupIDPrefixMigration defines how a change of the processGroupIDPrefix will be rolled out.
+	ProcessGroupIDPrefixMigration *ProcessGroupIDPrefixMigrationOptions `json:"processGroupIDPrefixMigration,omitempty"`
+
+	// StorageEngineMigration defines how the storage process groups are migrated after the storage engine in the
+	// database configuration was changed.
+	StorageEngineMigration *StorageEngineMigrationOptions `json:"storageEngineMigration,omitempty"`
+
+	// ProcessGroupIDAllocation defines how the ID numbers of new process groups are allocated.
+	ProcessGroupIDAllocation *ProcessGroupIDAllocationOptions `json:"processGroupIDAllocation,omitempty"`
+
+	// Paused contains options to pause specific categories of the operator automation, e.g. during an incident. The
+	// operator will continue to reconcile all other resources like the ConfigMap.
+	Paused PausedAutomationOptions `json:"paused,omitempty"`
+
+	// SafetyInterlock contains options to query an external endpoint before performing destructive actions.
+	SafetyInterlock SafetyInterlockOptions `json:"safetyInterlock,omitempty"`
+
+	// StagedPodCreation defines whether new Pods are created with a scheduling gate and released in waves.
+	StagedPodCreation *StagedPodCreationOptions `json:"stagedPodCreation,omitempty"`
+}
+
+// StagedPodCreationOptions defines how new Pods are released for scheduling. If enabled, all Pods are created upfront
+// with the foundationdb.org/staged-creation scheduling gate, so the Pods are already accounted for in the resource
+// quota. The operator removes the scheduling gate in waves and only releases the next wave once all Pods of the
+// previous wave are scheduled.
+type StagedPodCreationOptions struct {
+	// Enabled defines whether new Pods are created with a scheduling gate. Scheduling gates require Kubernetes 1.26
+	// or newer with the PodSchedulingReadiness feature gate enabled.
+	// The default is false.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// MaxPodsPerWave defines how many Pods are released for scheduling in a single wave. With the default pod
+	// anti-affinity the Pods of a wave will be spread across the fault domains.
+	// The default is 10.
+	// +kubebuilder:validation:Minimum=1
+	MaxPodsPerWave *int `json:"maxPodsPerWave,omitempty"`
+}
+
+// SafetyInterlockOptions controls the integration with an external endpoint that signals whether a freeze is active,
+// e.g. during an incident. While a freeze is active the operator defers destructive actions like replacements,
+// exclusions, bounces, configuration changes and Pod updates.
+type SafetyInterlockOptions struct {
+	// URL defines the endpoint that will be queried with a GET request. The endpoint must respond with a 200 status
+	// code and a JSON body like {"frozen": true, "reason": "incident"}. If the URL is empty the safety interlock is
+	// disabled.
+	// +kubebuilder:validation:MaxLength=2048
+	URL string `json:"url,omitempty"`
+
+	// CacheDurationSeconds defines how long the result of the endpoint will be cached by the operator.
+	// The default is 60.
+	// +kubebuilder:validation:Minimum=0
+	CacheDurationSeconds *int `json:"cacheDurationSeconds,omitempty"`
+
+	// TimeoutSeconds defines the timeout for the request to the endpoint.
+	// The default is 5.
+	// +kubebuilder:validation:Minimum=1
+	TimeoutSeconds *int `json:"timeoutSeconds,omitempty"`
+
+	// FailurePolicy defines how the operator behaves if the endpoint is not reachable or returns an invalid response.
+	// If set to Open the operator will continue with destructive actions, if set to Closed the operator will defer
+	// destructive actions until the endpoint responds again.
+	// The default is Closed.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Open;Closed
+	// +kubebuilder:default:=Closed
+	FailurePolicy SafetyInterlockFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+// MemoryOvercommitProtectionOptions defines how much memory of a node can be committed to FoundationDB Pods.
+type MemoryOvercommitProtectionOptions struct {
+	// OvercommitRatioPercentage defines the percentage of the allocatable memory of a node that can be committed to
+	// FoundationDB Pods. A value larger than 100 allows to overcommit the memory of a node.
+	// The default is 100.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1000
+	OvercommitRatioPercentage *int `json:"overcommitRatioPercentage,omitempty"`
+}
+
+// SafetyInterlockFailurePolicy defines how the operator behaves if the safety interlock endpoint cannot be queried.
+// +kubebuilder:validation:MaxLength=64
+type SafetyInterlockFailurePolicy string
+
+const (
+	// SafetyInterlockFailurePolicyOpen continues with destructive actions if the endpoint cannot be queried.
+	SafetyInterlockFailurePolicyOpen SafetyInterlockFailurePolicy = "Open"
+	// SafetyInterlockFailurePolicyClosed defers destructive actions if the endpoint cannot be queried.
+	SafetyInterlockFailurePolicyClosed SafetyInterlockFailurePolicy = "Closed"
+)
+
+// StatelessScalingOptions defines the limits for the stateless process count. The operator clamps the stateless
+// process count to these limits and will never use fewer stateless processes than required to run the stateless
+// roles of the database.
+type StatelessScalingOptions struct {
+	// MinProcesses defines the minimum number of stateless processes.
+	// +kubebuilder:validation:Minimum=1
+	MinProcesses *int `json:"minProcesses,omitempty"`
+
+	// MaxProcesses defines the maximum number of stateless processes.
+	// +kubebuilder:validation:Minimum=1
+	MaxProcesses *int `json:"maxProcesses,omitempty"`
+}
+
+// ProcessGroupIDPrefixMigrationOptions controls the migration of the process groups to a new processGroupIDPrefix.
+type ProcessGroupIDPrefixMigrationOptions struct {
+	// Enabled defines whether a change of the processGroupIDPrefix is rolled out as a managed migration. If enabled,
+	// the operator only replaces the process groups with a different prefix once the new prefix is confirmed in
+	// ConfirmedPrefix and the process groups will be replaced one process class and one fault domain at a time. If
+	// disabled, all process groups with a different prefix will be replaced once the processGroupIDPrefix changes.
+	// The default is false.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// ConfirmedPrefix must be set to the value of the processGroupIDPrefix to confirm the migration to the new prefix.
+	// As long as the values differ, the operator will not replace any process groups with a different prefix.
+	// +kubebuilder:validation:MaxLength=63
+	ConfirmedPrefix *string `json:"confirmedPrefix,omitempty"`
+
+	// ProcessClassOrder defines the order in which the process classes are migrated to the new prefix. Process
+	// classes that are not part of this list are migrated afterwards in alphabetical order.
+	// +kubebuilder:validation:MaxItems=10
+	ProcessClassOrder []ProcessClass `json:"processClassOrder,omitempty"`
+
+	// Paused defines whether the migration is paused. While the migration is paused, the operator will not replace
+	// any additional process groups with a different prefix, replacements that are already in progress will be
+	// completed. The default is false.
+	Paused *bool `json:"paused,omitempty"`
+}
+
+// StorageEngineMigrationOptions contains the options for the migration of the storage processes to a different storage
+// engine.
+type StorageEngineMigrationOptions struct {
+	// Enabled defines whether the operator replaces the storage process groups that are running with a different storage
+	// engine than the storage engine of the database configuration. The replacements are only started once the
+	// configuration change was applied to the database.
+	// The default is false.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// MaxConcurrentReplacements defines how many storage process groups are replaced concurrently for the migration.
+	// The default is 1.
+	// +kubebuilder:validation:Minimum=1
+	MaxConcurrentReplacements *int `json:"maxConcurrentReplacements,omitempty"`
+
+	// MaxQueuedDataMovementBytes defines the upper limit of bytes that can be queued for data movement. If more bytes
+	// are queued, the operator waits with the next replacement until data distribution has caught up. If unset, the
+	// operator only waits until data distribution is healthy.
+	// +kubebuilder:validation:Minimum=0
+	MaxQueuedDataMovementBytes *int `json:"maxQueuedDataMovementBytes,omitempty"`
+
+	// Paused defines whether the migration is paused. While the migration is paused, the operator will not replace
+	// any additional storage process groups, replacements that are already in progress will be completed.
+	// The default is false.
+	Paused *bool `json:"paused,omitempty"`
+}
+
+// StorageEngineMigrationStatus contains the progress of the migration of the storage processes to a different storage
+// engine.
+type StorageEngineMigrationStatus struct {
+	// TargetStorageEngine is the storage engine the storage processes are migrated to.
+	TargetStorageEngine StorageEngine `json:"targetStorageEngine,omitempty"`
+
+	// MigratedProcessGroups is the number of storage process groups that are running with the target storage engine.
+	MigratedProcessGroups int `json:"migratedProcessGroups,omitempty"`
+
+	// MigratingProcessGroups is the number of storage process groups that are currently replaced for the migration.
+	MigratingProcessGroups int `json:"migratingProcessGroups,omitempty"`
+
+	// PendingProcessGroups is the number of storage process groups that are running with a different storage engine
+	// and are not yet replaced.
+	PendingProcessGroups int `json:"pendingProcessGroups,omitempty"`
+}
+
+// ActiveOperationType defines the type of destructive operation that was started by the operator.
+// +kubebuilder:validation:MaxLength=64
+type ActiveOperationType string
+
+const (
+	// ActiveOperationTypeBounce represents a restart of fdbserver processes.
+	ActiveOperationTypeBounce ActiveOperationType = "Bounce"
+	// ActiveOperationTypeExclusion represents an exclusion of processes that are about to be removed.
+	ActiveOperationTypeExclusion ActiveOperationType = "Exclusion"
+	// ActiveOperationTypeDeletion represents a deletion of the Pods or other resources of process groups.
+	ActiveOperationTypeDeletion ActiveOperationType = "Deletion"
+	// ActiveOperationTypeConfigurationChange represents a change of the database configuration.
+	ActiveOperationTypeConfigurationChange ActiveOperationType = "ConfigurationChange"
+)
+
+// ActiveOperation represents a destructive operation that was started by the operator and is not yet completed.
+type ActiveOperation struct {
+	// ID uniquely identifies the operation.
+	ID string `json:"id"`
+
+	// Type defines the type of the operation.
+	Type ActiveOperationType `json:"type"`
+
+	// Owner is the name of the sub-reconciler that started the operation.
+	Owner string `json:"owner"`
+
+	// StartTimestamp is the time when the operation was started, in seconds since the epoch.
+	StartTimestamp int64 `json:"startTimestamp"`
+
+	// ProcessGroups contains the process groups that are targeted by the operation. Operations that target the whole
+	// cluster, like configuration changes, have no process groups.
+	ProcessGroups []ProcessGroupID `json:"processGroups,omitempty"`
+}
+
+// ProcessGroupIDPrefixMigrationStatus contains the progress of the managed migration to a new processGroupIDPrefix.
+type ProcessGroupIDPrefixMigrationStatus struct {
+	// TargetPrefix is the processGroupIDPrefix the process groups are migrated to.
+	TargetPrefix string `json:"targetPrefix,omitempty"`
+
+	// ProcessClass is the process class that is currently migrated.
+	ProcessClass ProcessClass `json:"processClass,omitempty"`
+
+	// FaultDomain is the fault domain that is currently migrated.
+	FaultDomain FaultDomain `json:"faultDomain,omitempty"`
+
+	// MigratedProcessGroups is the number of process groups that are using the new prefix.
+	MigratedProcessGroups int `json:"migratedProcessGroups,omitempty"`
+
+	// PendingProcessGroups is the number of process groups per process class that are still using a different prefix.
+	PendingProcessGroups map[ProcessClass]int `json:"pendingProcessGroups,omitempty"`
+
+	// Confirmed reports if the new prefix was confirmed in the confirmedPrefix of the migration options.
+	Confirmed bool `json:"confirmed,omitempty"`
+
+	// Paused reports if the migration is paused.
+	Paused bool `json:"paused,omitempty"`
+}
+
+// ProcessGroupIDAllocationOptions defines how the ID numbers of new process groups are allocated.
+type ProcessGroupIDAllocationOptions struct {
+	// Strategy defines the allocation strategy for the ID numbers of new process groups.
+	// If unset, the Dense strategy is used if useCompactProcessGroupIDs is enabled, otherwise the Random strategy.
+	// +kubebuilder:validation:Enum=Random;Dense;ZoneEncoded
+	Strategy *ProcessGroupIDAllocationStrategy `json:"strategy,omitempty"`
+
+	// Zones defines the zones that are encoded in the ID numbers if the ZoneEncoded strategy is used. The first zone
+	// uses the ID numbers 1001 to 1999, the second zone the ID numbers 2001 to 2999 and so on.
+	// +kubebuilder:validation:MaxItems=9
+	Zones []string `json:"zones,omitempty"`
+
+	// ZoneLabel defines the node label that is used to schedule the Pods of zone encoded process groups into their
+	// zone.
+	// The default is topology.kubernetes.io/zone.
+	// +kubebuilder:validation:MaxLength=317
+	ZoneLabel *string `json:"zoneLabel,omitempty"`
+}
+
+// ProcessGroupIDAllocationStrategy defines how the ID numbers of new process groups are allocated.
+// +kubebuilder:validation:MaxLength=64
+type ProcessGroupIDAllocationStrategy string
+
+const (
+	// ProcessGroupIDAllocationRandom picks a random unused ID number to reduce the risk of reusing the ID of a
+	// previous process group.
+	ProcessGroupIDAllocationRandom ProcessGroupIDAllocationStrategy = "Random"
+	// ProcessGroupIDAllocationDense picks the lowest unused ID number.
+	ProcessGroupIDAllocationDense ProcessGroupIDAllocationStrategy = "Dense"
+	// ProcessGroupIDAllocationZoneEncoded picks the lowest unused ID number in the block of the zone with the fewest
+	// process groups of the process class, e.g. 1xxx for the first zone.
+	ProcessGroupIDAllocationZoneEncoded ProcessGroupIDAllocationStrategy = "ZoneEncoded"
+)
+
+// PausedAutomationOptions controls which categories of the operator automation are paused. All categories default
+// to false, which means the automation is active.
+type PausedAutomationOptions struct {
+	// Replacements defines whether the operator is prevented from replacing process groups, either because they are
+	// misconfigured or because they are failed. Process groups that are already marked for removal will still be
+	// removed.
+	Replacements *bool `json:"replacements,omitempty"`
+
+	// Exclusions defines whether the operator is prevented from excluding processes.
+	Exclusions *bool `json:"exclusions,omitempty"`
+
+	// Bounces defines whether the operator is prevented from restarting fdbserver processes.
+	Bounces *bool `json:"bounces,omitempty"`
+
+	// ConfigurationChanges defines whether the operator is prevented from changing the database configuration. The
+	// initial configuration of a new cluster is not affected by this setting.
+	ConfigurationChanges *bool `json:"configurationChanges,omitempty"`
+
+	// PodUpdates defines whether the operator is prevented from deleting Pods to roll out Pod spec changes.
+	PodUpdates *bool `json:"podUpdates,omitempty"`
 }
 
 // LogGroup represents a LogGroup used by a FoundationDB process to log trace events. The LogGroup can be used to filter
@@ -1251,6 +2559,14 @@ type AutomaticReplacementOptions struct {
 	// The default is false.
 	FaultDomainBasedReplacements *bool `json:"faultDomainBasedReplacements,omitempty"`
 
+	// BatchByFaultDomain defines whether the replacements of misconfigured process groups are grouped by fault domain.
+	// If enabled, the operator will only replace process groups of a single fault domain at the same time and will
+	// only start the replacements in the next fault domain once all ongoing replacements are excluded. The fault
+	// domain is based on the fault domain key of the cluster. The number of replacements is still limited by
+	// MaxConcurrentReplacements.
+	// The default is false.
+	BatchByFaultDomain *bool `json:"batchByFaultDomain,omitempty"`
+
 	// FailureDetectionTimeSeconds controls how long a process must be
 	// failed or missing before it is automatically replaced.
 	// The default is 7200 seconds, or 2 hours.
@@ -1261,6 +2577,13 @@ type AutomaticReplacementOptions struct {
 	// The default is 1800 seconds, i.e., 30min
 	TaintReplacementTimeSeconds *int `json:"taintReplacementTimeSeconds,omitempty"`
 
+	// FailureDetectionWindowSeconds controls how long a Pod must be stuck in the Pending phase or have a container in
+	// the CrashLoopBackOff or ImagePullBackOff state before the process group is automatically replaced. Those process
+	// groups will get the PodStuck condition. If unset, stuck Pods are not detected and those process groups are
+	// only replaced based on FailureDetectionTimeSeconds.
+	// +kubebuilder:validation:Minimum=0
+	FailureDetectionWindowSeconds *int `json:"failureDetectionWindowSeconds,omitempty"`
+
 	// MaxConcurrentReplacements controls how many automatic replacements are allowed to take part.
 	// This will take the list of current replacements and then calculate the difference between
 	// maxConcurrentReplacements and the size of the list. e.g. if currently 3 replacements are
@@ -1284,6 +2607,274 @@ type AutomaticReplacementOptions struct {
 	// Defaults to 10% of the fault domains or at least 1.
 	// +kubebuilder:validation:XIntOrString
 	MaxFaultDomainsWithTaintedProcessGroups *intstr.IntOrString `json:"maxFaultDomainsWithTaintedProcessGroups,omitempty"`
+
+	// DryRun defines whether the replacements of misconfigured process groups are only recorded. If enabled, the
+	// operator will not mark misconfigured process groups for removal, but records them in the dryRunReplacements
+	// field of the cluster status. The replacements of failed process groups are not affected. The default is false.
+	DryRun *bool `json:"dryRun,omitempty"`
+
+	// RequireApproval defines whether the automatic replacements of failed and misconfigured process groups must be
+	// approved. If enabled, the operator adds the PendingReplacementApproval condition to the process groups that
+	// should be replaced and only marks them for removal once they are listed in the approvedReplacements of the
+	// cluster spec or in the foundationdb.org/approved-replacements annotation.
+	// The default is false.
+	RequireApproval *bool `json:"requireApproval,omitempty"`
+
+	// AllowedWindows defines the time windows in which misconfigured process groups can be replaced. If no windows
+	// are defined, misconfigured process groups can be replaced at any time. The replacements of failed process
+	// groups are not affected by those windows.
+	// +kubebuilder:validation:MaxItems=16
+	AllowedWindows []ReplacementWindow `json:"allowedWindows,omitempty"`
+
+	// MaxReplacementsPerHour defines how many misconfigured process groups can be replaced within one hour. If unset,
+	// the number of replacements per hour is not limited.
+	// +kubebuilder:validation:Minimum=0
+	MaxReplacementsPerHour *int `json:"maxReplacementsPerHour,omitempty"`
+
+	// MaxConcurrentPerClass defines how many process groups of a specific process class can be concurrently replaced,
+	// e.g. to allow 5 concurrent replacements of stateless process groups but only 1 of storage process groups. This
+	// limit applies to the replacements of failed and misconfigured process groups in addition to the global limits.
+	// Process groups of a process class that reached its limit will be skipped, so replacements of other process
+	// classes are not blocked. Process classes without an entry are only limited by the global limits.
+	MaxConcurrentPerClass map[ProcessClass]int `json:"maxConcurrentPerClass,omitempty"`
+
+	// MaxConcurrentStorageClassMigrations defines how many process groups can be concurrently replaced because the
+	// storage class of their PVC has changed. A process group counts as concurrently replaced until it is excluded.
+	// This limit applies in addition to the global limits. If unset, those replacements are only limited by the
+	// global limits.
+	// +kubebuilder:validation:Minimum=0
+	MaxConcurrentStorageClassMigrations *int `json:"maxConcurrentStorageClassMigrations,omitempty"`
+
+	// RespectPodDisruptionBudgets defines whether the replacements of misconfigured process groups respect the
+	// PodDisruptionBudgets in the namespace of the cluster. If enabled, the operator defers the replacement of a
+	// process group if a PodDisruptionBudget that selects its Pod doesn't allow any further disruptions, taking the
+	// ongoing removals into account. Those process groups get the ReplacementDeferredByDisruptionBudget condition.
+	// The replacements of failed process groups are not affected.
+	// The default is false.
+	RespectPodDisruptionBudgets *bool `json:"respectPodDisruptionBudgets,omitempty"`
+
+	// RequireFullReplication defines whether the replacements of misconfigured process groups are deferred while the
+	// database is unavailable or not fully replicated. If enabled, the operator checks the machine-readable status
+	// before marking misconfigured process groups for removal and emits a ReplacementDeferred event if the
+	// replacements are deferred. The replacements of failed process groups are not affected.
+	// The default is false.
+	RequireFullReplication *bool `json:"requireFullReplication,omitempty"`
+
+	// Strategy defines how process groups are replaced. With the Default strategy the replaced process group is
+	// excluded as soon as enough processes are running. With the Surge strategy the operator creates the replacement
+	// first and waits until the replacement processes have joined the cluster and the data distribution is healthy,
+	// before the replaced process group is excluded and removed.
+	// The default is Default.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Default;Surge
+	Strategy ReplacementStrategy `json:"strategy,omitempty"`
+
+	// PriorityOrder defines the order of process classes in which misconfigured process groups are replaced if the
+	// number of concurrent replacements is limited. Process groups of process classes listed first are replaced first,
+	// process classes without an entry are replaced after all listed process classes. Independent of this order,
+	// failing process groups are replaced before healthy process groups. If unset, process groups of stateless process
+	// classes are replaced before process groups of stateful process classes.
+	// +kubebuilder:validation:MaxItems=16
+	PriorityOrder []ProcessClass `json:"priorityOrder,omitempty"`
+
+	// MaxStandbyProcessGroups defines how many healthy process groups are kept running as warm standbys after their
+	// exclusion is completed, instead of being removed. A standby process group will be re-included to replace a
+	// failed process group of the same process class. Standby process groups that become unhealthy will be removed.
+	// The default is 0, which disables the standby process groups.
+	// +kubebuilder:validation:Minimum=0
+	MaxStandbyProcessGroups *int `json:"maxStandbyProcessGroups,omitempty"`
+
+	// CancelOnRecovery defines whether the removal of an automatically replaced process group is canceled if the
+	// process group recovers before its exclusion was started.
+	CancelOnRecovery *CancelOnRecoveryOptions `json:"cancelOnRecovery,omitempty"`
+
+	// ReplacementLoopDetection defines when the automatic replacements of failed process groups are stopped, because
+	// failed process groups of the same process class are replaced repeatedly, e.g. because of an issue with a node
+	// or an image. Those replacements must be acknowledged with the foundationdb.org/acknowledge-replacement-loop
+	// annotation before the operator continues to replace failed process groups of this process class.
+	ReplacementLoopDetection *ReplacementLoopDetectionOptions `json:"replacementLoopDetection,omitempty"`
+
+	// NodeVersionSkew defines the minimum versions of the nodes that run the Pods of the cluster. Process groups whose
+	// Pod runs on a node with an older version are replaced like misconfigured process groups, e.g. to roll out a new
+	// node image. If unset, the versions of the nodes are not checked.
+	NodeVersionSkew *NodeVersionSkewOptions `json:"nodeVersionSkew,omitempty"`
+
+	// GlobalBudget defines a replacement budget that is shared by all operator instances that manage the same
+	// database, e.g. the operator instances of the different data centers of a multi-region cluster. If unset, every
+	// operator instance only enforces its local limits.
+	GlobalBudget *GlobalReplacementBudgetOptions `json:"globalBudget,omitempty"`
+
+	// NodeDrain defines whether process groups are replaced once their node is being drained, e.g. by Karpenter or
+	// the cluster-autoscaler, instead of waiting until the Pods are evicted and the process groups are detected as
+	// failed. If unset, node drains are not detected.
+	NodeDrain *NodeDrainReplacementOptions `json:"nodeDrain,omitempty"`
+}
+
+// NodeDrainReplacementOptions defines which signals mark a node as being drained. Process groups whose Pod runs on a
+// drained node get the NodeDraining condition and are replaced like failed process groups without waiting for the
+// failure detection time. The replacements are limited by MaxConcurrentReplacements and the exclusion of the
+// replaced process groups is done like for any other removal.
+type NodeDrainReplacementOptions struct {
+	// Enabled defines whether process groups on drained nodes are replaced.
+	// The default is false.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// DetectCordonedNodes defines whether a cordoned node, e.g. a node that is marked as unschedulable by
+	// "kubectl drain", is treated as being drained.
+	// The default is true.
+	DetectCordonedNodes *bool `json:"detectCordonedNodes,omitempty"`
+
+	// TaintKeys defines the keys of the node taints that signal an upcoming drain of the node.
+	// The default is karpenter.sh/disruption, karpenter.sh/disrupted and ToBeDeletedByClusterAutoscaler.
+	// +kubebuilder:validation:MaxItems=16
+	TaintKeys []string `json:"taintKeys,omitempty"`
+
+	// Annotations defines the keys of the node annotations that signal an upcoming drain of the node.
+	// +kubebuilder:validation:MaxItems=16
+	Annotations []string `json:"annotations,omitempty"`
+}
+
+// GlobalReplacementBudgetOptions defines the replacement budget that is shared by all operator instances that manage
+// the same database. The in-flight replacements of every operator instance are stored in the database under the lock
+// prefix, so the locking system must be enabled.
+type GlobalReplacementBudgetOptions struct {
+	// MaxConcurrentReplacements defines how many process groups can be concurrently replaced by all operator
+	// instances. A process group counts as concurrently replaced until it is excluded. This limit applies to the
+	// replacements of failed and misconfigured process groups in addition to the local limits.
+	// +kubebuilder:validation:Minimum=0
+	MaxConcurrentReplacements *int `json:"maxConcurrentReplacements,omitempty"`
+
+	// LeaseDurationSeconds defines how long the in-flight replacements of an operator instance are counted after they
+	// were last refreshed by this operator instance. This prevents an operator instance that is not running anymore
+	// from blocking the replacements of the other operator instances.
+	// The default is 600 seconds, or 10 minutes.
+	// +kubebuilder:validation:Minimum=1
+	LeaseDurationSeconds *int `json:"leaseDurationSeconds,omitempty"`
+}
+
+// NodeVersionSkewOptions defines the minimum versions of the nodes. A process group is replaced if its node runs an
+// older version than any of the defined minimum versions. The versions are compared based on their numeric components,
+// e.g. "5.15.0-1051-azure" is compared as "5.15.0".
+type NodeVersionSkewOptions struct {
+	// MinimumKubeletVersion defines the minimum kubelet version reported in the node info, e.g. "v1.28.3".
+	// +kubebuilder:validation:MaxLength=64
+	MinimumKubeletVersion string `json:"minimumKubeletVersion,omitempty"`
+
+	// MinimumKernelVersion defines the minimum kernel version reported in the node info, e.g. "5.15.0".
+	// +kubebuilder:validation:MaxLength=64
+	MinimumKernelVersion string `json:"minimumKernelVersion,omitempty"`
+
+	// VersionLabel defines a node label that contains a version, e.g. the version of the OS image of the node.
+	// +kubebuilder:validation:MaxLength=317
+	VersionLabel string `json:"versionLabel,omitempty"`
+
+	// MinimumLabelVersion defines the minimum version in the VersionLabel of the node. Nodes without the label are
+	// treated as outdated.
+	// +kubebuilder:validation:MaxLength=64
+	MinimumLabelVersion string `json:"minimumLabelVersion,omitempty"`
+
+	// MaxConcurrentReplacements defines how many process groups can be concurrently replaced because their node is
+	// outdated. A process group counts as concurrently replaced until it is excluded. This limit applies in addition to
+	// the global limits.
+	// The default is 1.
+	// +kubebuilder:validation:Minimum=0
+	MaxConcurrentReplacements *int `json:"maxConcurrentReplacements,omitempty"`
+}
+
+// CancelOnRecoveryOptions defines if and when the operator cancels the removal of failed process groups that have
+// recovered.
+type CancelOnRecoveryOptions struct {
+	// Enabled defines whether the removal of a recovered process group is canceled.
+	// The default is false.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// GracePeriodSeconds defines how long after the process group was marked for removal the removal can be
+	// canceled. Process groups that were marked for removal before this window are removed even if they recovered.
+	// The default is 600 seconds, or 10 minutes.
+	// +kubebuilder:validation:Minimum=0
+	GracePeriodSeconds *int `json:"gracePeriodSeconds,omitempty"`
+}
+
+// ReplacementLoopDetectionOptions defines when a replacement loop of failed process groups is detected.
+type ReplacementLoopDetectionOptions struct {
+	// MaxReplacements defines how many failed process groups of a process class can be replaced within the window.
+	// If more process groups were replaced, the operator stops to replace failed process groups of this process class.
+	// If unset, replacement loops are not detected.
+	// +kubebuilder:validation:Minimum=1
+	MaxReplacements *int `json:"maxReplacements,omitempty"`
+
+	// WindowSeconds defines the window in which the replacements are counted.
+	// The default is 3600 seconds, or 1 hour.
+	// +kubebuilder:validation:Minimum=1
+	WindowSeconds *int `json:"windowSeconds,omitempty"`
+
+	// BackoffSeconds defines the minimum time between two replacements of failed process groups of the same process
+	// class. The backoff is doubled with every replacement of the process class within the window, e.g. with a
+	// backoff of 60 seconds the operator waits 60 seconds after the first replacement, 120 seconds after the second
+	// replacement and so on. The backoff is capped at the window.
+	// The default is 0, which disables the backoff.
+	// +kubebuilder:validation:Minimum=0
+	BackoffSeconds *int `json:"backoffSeconds,omitempty"`
+}
+
+// ReplacementTriggerPolicy defines which changes of the Pod trigger a replacement of the process group. If a trigger is
+// disabled, the change will be rolled out like any other change of the Pod spec, based on the PodUpdateStrategy.
+type ReplacementTriggerPolicy struct {
+	// NodeSelectorChanged defines if a change of the node selector triggers a replacement.
+	// The default is true.
+	NodeSelectorChanged *bool `json:"nodeSelectorChanged,omitempty"`
+
+	// PublicIPSourceChanged defines if a change of the public IP source triggers a replacement.
+	// The default is true.
+	PublicIPSourceChanged *bool `json:"publicIPSourceChanged,omitempty"`
+
+	// SecurityContextChanged defines if a change of the file security context triggers a replacement. This trigger
+	// has only an effect if the replacements on security context changes are enabled for the operator.
+	// The default is true.
+	SecurityContextChanged *bool `json:"securityContextChanged,omitempty"`
+
+	// SecurityContextChangeGracePeriodSeconds defines how long a change of the file security context must persist
+	// before the process group is replaced. This prevents replacements because of transient changes, e.g. by security
+	// admission controllers. While the grace period is running, the process group has the
+	// SecurityContextChangePending condition. A value of 0 replaces the process group immediately.
+	// The default is 0.
+	// +kubebuilder:validation:Minimum=0
+	SecurityContextChangeGracePeriodSeconds *int `json:"securityContextChangeGracePeriodSeconds,omitempty"`
+
+	// ServersPerPodChanged defines if a change of the servers per Pod triggers a replacement.
+	//
+	// Deprecated: A change of the servers per Pod always triggers a replacement, because the data directories and the
+	// process IDs of the processes change with the number of servers per Pod. This setting has no effect.
+	ServersPerPodChanged *bool `json:"serversPerPodChanged,omitempty"`
+
+	// ServiceAccountChanged defines if a change of the service account name triggers a replacement.
+	// The default is false.
+	ServiceAccountChanged *bool `json:"serviceAccountChanged,omitempty"`
+
+	// ImagePullSecretsChanged defines if a Pod that is missing one of the desired image pull secrets triggers a
+	// replacement. Additional image pull secrets of the Pod, e.g. added from the service account, are ignored.
+	// The default is false.
+	ImagePullSecretsChanged *bool `json:"imagePullSecretsChanged,omitempty"`
+
+	// VolumeTopologyConflict defines if a process group is replaced when the desired Pod spec can't be scheduled on
+	// any node that satisfies the node affinity of the persistent volume, e.g. if the node selector requires a
+	// different zone than the zone of the volume. Without a replacement, the recreated Pod would stay pending. This
+	// requires that the operator can read nodes and persistent volumes.
+	// The default is false.
+	VolumeTopologyConflict *bool `json:"volumeTopologyConflict,omitempty"`
+}
+
+// ReplacementWindow defines a time window in which misconfigured process groups can be replaced.
+type ReplacementWindow struct {
+	// Schedule defines when the window opens as a cron expression in the standard five field format, e.g.
+	// "0 2 * * 1-5" for every weekday at 2am. The schedule is evaluated in UTC, unless a time zone is specified
+	// with the CRON_TZ= prefix, e.g. "CRON_TZ=Europe/Berlin 0 2 * * 1-5".
+	// +kubebuilder:validation:MaxLength=128
+	Schedule string `json:"schedule"`
+
+	// DurationMinutes defines how long the window stays open after it was opened.
+	// +kubebuilder:validation:Minimum=1
+	DurationMinutes int `json:"durationMinutes"`
 }
 
 // ProcessSettings defines process-level settings.
@@ -1292,6 +2883,12 @@ type ProcessSettings struct {
 	// will throw an error and stop processing the cluster.
 	PodTemplate *corev1.PodTemplateSpec `json:"podTemplate,omitempty"`
 
+	// PodTemplateOverrides are strategically merged onto the PodTemplate that is used for this process class, which is
+	// the PodTemplate of this process class or, if unset, the PodTemplate of the general process class. This allows to
+	// change single fields, e.g. the nodeSelector or the resources of a container, without repeating the whole
+	// PodTemplate. Containers are merged by their name. This setting is not supported for the general process class.
+	PodTemplateOverrides *corev1.PodTemplateSpec `json:"podTemplateOverrides,omitempty"`
+
 	// VolumeClaimTemplate allows customizing the persistent volume claim for the
 	// pod.  This will be ignored by the operator for stateless processes.
 	VolumeClaimTemplate *corev1.PersistentVolumeClaim `json:"volumeClaimTemplate,omitempty"`
@@ -1301,1038 +2898,2768 @@ type ProcessSettings struct {
 	// from the [general] and [fdbmonitor] section are not supported. For more Information
 	// see: https://apple.github.io/foundationdb/configuration.html#general-section
 	CustomParameters FoundationDBCustomParameters `json:"customParameters,omitempty"`
+
+	// PodNamePrefix defines the prefix that is used instead of the process class for the Pod names of new process
+	// groups, the Pod name will be in the format ${cluster}-${podNamePrefix}-${id}. The Pod name is stored in the process
+	// group status, so changing this value will not change the Pod name of existing process groups. This setting is
+	// ignored for the general process class.
+	// +kubebuilder:validation:MaxLength=32
+	// +kubebuilder:validation:Pattern:=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	PodNamePrefix string `json:"podNamePrefix,omitempty"`
+
+	// RuntimeClassName defines the RuntimeClass that is used to run the Pods of this process class, e.g. a sandboxed
+	// runtime like gVisor or Kata Containers. Sandboxed runtimes add overhead to disk and network I/O, so the
+	// performance of the fdbserver processes should be verified before using them. If set, this value takes precedence
+	// over the runtimeClassName in the PodTemplate. Changing this value will recreate the Pods without replacing the
+	// process groups.
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern:=^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$
+	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
+
+	// KernelSettings defines the kernel settings that are applied for the Pods of this process class.
+	KernelSettings *KernelSettings `json:"kernelSettings,omitempty"`
+
+	// Shutdown defines how the Pods of this process class are shut down.
+	Shutdown *ShutdownSettings `json:"shutdown,omitempty"`
+
+	// AdditionalVolumeClaims defines additional persistent volume claims that are created for every process group
+	// of this process class and mounted into the main container, e.g. to use a separate volume for the spill data of
+	// the log processes. This will be ignored by the operator for stateless processes. Changes to the spec of an
+	// additional volume claim will replace the affected process groups.
+	// +kubebuilder:validation:MaxItems=8
+	AdditionalVolumeClaims []AdditionalVolumeClaim `json:"additionalVolumeClaims,omitempty"`
+
+	// DiskQualification defines a disk benchmark that is run on newly provisioned volumes before the fdbserver
+	// processes are started. This will be ignored by the operator for stateless processes.
+	DiskQualification *DiskQualificationSettings `json:"diskQualification,omitempty"`
+
+	// StartCommand defines customizations of the command that starts the fdbserver processes of this process class,
+	// e.g. to run the processes with numactl or to preload a library. Changing these settings will update the Pods
+	// according to the Pod update strategy.
+	StartCommand *StartCommandSettings `json:"startCommand,omitempty"`
 }
 
-// GetProcessSettings gets settings for a process.
-func (cluster *FoundationDBCluster) GetProcessSettings(processClass ProcessClass) ProcessSettings {
-	merged := ProcessSettings{}
-	entries := make([]ProcessSettings, 0, 2)
+// StoragePool defines a pool of storage process groups with their own volume and Pod settings.
+type StoragePool struct {
+	// Name of the storage pool. The name is stored in the process group status, so renaming a storage pool will
+	// replace all of its process groups.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=32
+	// +kubebuilder:validation:Pattern:=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	Name string `json:"name"`
 
-	entry, present := cluster.Spec.Processes[processClass]
-	if present {
-		entries = append(entries, entry)
-	}
+	// Count defines the number of storage process groups in this storage pool.
+	// +kubebuilder:validation:Minimum=0
+	Count int `json:"count,omitempty"`
 
-	entries = append(entries, cluster.Spec.Processes[ProcessClassGeneral])
-	for _, entry := range entries {
-		if merged.PodTemplate == nil {
-			merged.PodTemplate = entry.PodTemplate
-		}
-		if merged.VolumeClaimTemplate == nil && processClass.IsStateful() { // stateless pods will not use a PVC
-			merged.VolumeClaimTemplate = entry.VolumeClaimTemplate
-		}
-		if merged.CustomParameters == nil {
-			merged.CustomParameters = entry.CustomParameters
-		}
-	}
+	// PodTemplate allows customizing the Pods of this storage pool, e.g. to select different nodes. If unset the
+	// PodTemplate of the storage process settings will be used.
+	PodTemplate *corev1.PodTemplateSpec `json:"podTemplate,omitempty"`
 
-	return merged
+	// VolumeClaimTemplate allows customizing the persistent volume claims of this storage pool, e.g. to use a
+	// different size or storage class. If unset the VolumeClaimTemplate of the storage process settings will be used.
+	VolumeClaimTemplate *corev1.PersistentVolumeClaim `json:"volumeClaimTemplate,omitempty"`
 }
 
-// GetRoleCountsWithDefaults gets the role counts from the cluster spec and
-// fills in default values for any role counts that are 0.
-//
-// The default Storage value will be 2F + 1, where F is the cluster's fault
-// tolerance.
-//
-// The default Logs value will be 3 or 4 for three_data_hall.
-//
-// The default Proxies value will be 3.
-//
-// The default Resolvers value will be 1.
-//
-// The default RemoteLogs value will be equal to the Logs value when the
-// UsableRegions is greater than 1. It will be equal to -1 when the
-// UsableRegions is less than or equal to 1.
-//
-// The default LogRouters value will be equal to 3 times the Logs value when
-// the UsableRegions is greater than 1. It will be equal to -1 when the
-// UsableRegions is less than or equal to 1.
-func (cluster *FoundationDBCluster) GetRoleCountsWithDefaults() RoleCounts {
-	// We can ignore the error here since the version will be validated in an earlier step.
-	version, _ := ParseFdbVersion(cluster.GetRunningVersion())
-	return cluster.Spec.DatabaseConfiguration.GetRoleCountsWithDefaults(version, cluster.DesiredFaultTolerance())
+// StartCommandSettings defines customizations of the command that starts the fdbserver processes.
+type StartCommandSettings struct {
+	// Wrapper defines a command and its arguments that wraps the process monitor in the main container, e.g.
+	// ["numactl", "--interleave=all"]. The process monitor and the fdbserver processes started by it inherit the
+	// settings of the wrapper. The wrapper binary must be present in the main container image.
+	// +kubebuilder:validation:MaxItems=16
+	Wrapper []string `json:"wrapper,omitempty"`
+
+	// Env defines additional environment variables for the fdbserver processes, e.g. LD_PRELOAD. Environment
+	// variables with the FDB_ prefix are reserved for the operator.
+	// +kubebuilder:validation:MaxItems=32
+	Env []StartCommandEnvVar `json:"env,omitempty"`
 }
 
-// calculateProcessCount determines the process count from a given role count.
-//
-// alternatives provides a list of other process counts that can fulfill this
-// role instead. If any of those process counts is positive, then this will
-// return 0.
-func (cluster *FoundationDBCluster) calculateProcessCountFromRole(count int, alternatives ...int) int {
-	for _, value := range alternatives {
-		if value > 0 {
-			return 0
-		}
-	}
-	if count < 0 {
-		return 0
-	}
+// StartCommandEnvVar defines an environment variable for the fdbserver processes.
+type StartCommandEnvVar struct {
+	// Name of the environment variable.
+	// +kubebuilder:validation:MaxLength=128
+	// +kubebuilder:validation:Pattern:=^[-._a-zA-Z][-._a-zA-Z0-9]*$
+	Name string `json:"name"`
 
-	return count
+	// Value of the environment variable.
+	Value string `json:"value,omitempty"`
 }
 
-// calculateProcessCount calculates the process count for a process class based
-// on the counts for the roles it can fulfill.
-//
-// If addFaultTolerance is true, this will add the cluster's desired fault
-// tolerance to the result.
-//
-// If the cluster is using multi-KC replication, this will divide the total
-// count across the number of KCs in the data center.
-func (cluster *FoundationDBCluster) calculateProcessCount(addFaultTolerance bool, counts ...int) int {
-	var count = 0
+// DiskQualificationSettings defines the disk benchmark that is run on newly provisioned volumes. Volumes that perform
+// below the defined thresholds are rejected and the process group will be replaced.
+type DiskQualificationSettings struct {
+	// Image defines the image that is used to run the disk benchmark, the image must contain fio and a shell.
+	// +kubebuilder:validation:MaxLength=512
+	Image string `json:"image"`
 
-	if cluster.Spec.FaultDomain.ZoneIndex < 0 {
-		return -1
+	// RuntimeSeconds defines how long the benchmark runs for every volume.
+	// The default is 30.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=600
+	RuntimeSeconds *int `json:"runtimeSeconds,omitempty"`
+
+	// MinIOPS defines the minimum number of IOPS for random 4KiB reads and writes that a volume must provide. If
+	// unset, the IOPS are not checked.
+	// +kubebuilder:validation:Minimum=0
+	MinIOPS *int `json:"minIOPS,omitempty"`
+
+	// MaxLatencyMicroseconds defines the maximum mean completion latency in microseconds for random 4KiB reads and
+	// writes that a volume may have. If unset, the latency is not checked.
+	// +kubebuilder:validation:Minimum=0
+	MaxLatencyMicroseconds *int `json:"maxLatencyMicroseconds,omitempty"`
+}
+
+// AdditionalVolumeClaim defines an additional persistent volume claim for the process groups of a process class.
+type AdditionalVolumeClaim struct {
+	// Name defines the name of the volume. The PVC will be named ${podName}-${name} and the name must be unique
+	// across all volumes of the Pod.
+	// +kubebuilder:validation:MaxLength=32
+	// +kubebuilder:validation:Pattern:=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	Name string `json:"name"`
+
+	// MountPath defines the path where the volume is mounted in the main container.
+	// +kubebuilder:validation:MaxLength=256
+	MountPath string `json:"mountPath"`
+
+	// PerServer defines if one volume should be created for every server in the Pod, when serversPerPod is larger
+	// than 1. The PVCs will be named ${podName}-${name}-${serverNumber} and will be mounted at
+	// ${mountPath}/${serverNumber}, which matches the layout of the data directories of the fdbserver processes.
+	// The default is false.
+	PerServer *bool `json:"perServer,omitempty"`
+
+	// VolumeClaimTemplate allows customizing the persistent volume claim for this volume. The name of the template
+	// will be ignored.
+	VolumeClaimTemplate *corev1.PersistentVolumeClaim `json:"volumeClaimTemplate,omitempty"`
+}
+
+// ShutdownSettings defines the graceful shutdown behaviour of the Pods.
+type ShutdownSettings struct {
+	// TerminationGracePeriodSeconds defines the duration in seconds the Pod needs to terminate gracefully. If set,
+	// this value takes precedence over the terminationGracePeriodSeconds in the PodTemplate.
+	// +kubebuilder:validation:Minimum=0
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+
+	// SidecarShutdownDelaySeconds defines how long the sidecar container is kept running after the Pod is deleted,
+	// to make sure that the fdbserver processes receive SIGTERM and are stopped before the sidecar container. The
+	// delay must be lower than the termination grace period.
+	// +kubebuilder:validation:Minimum=0
+	SidecarShutdownDelaySeconds *int `json:"sidecarShutdownDelaySeconds,omitempty"`
+}
+
+// KernelSettings defines kernel tuning options for the fdbserver processes.
+type KernelSettings struct {
+	// Sysctls defines the namespaced sysctls that are set in the security context of the Pods, e.g. net.core.somaxconn
+	// or net.ipv4.tcp_keepalive_time. Sysctls that are considered unsafe by Kubernetes must be allowed on the kubelet
+	// with the --allowed-unsafe-sysctls flag, otherwise the Pods will be rejected.
+	// +kubebuilder:validation:MaxItems=32
+	Sysctls []corev1.Sysctl `json:"sysctls,omitempty"`
+
+	// DisableTransparentHugePages defines whether the operator should add a privileged init container that disables
+	// transparent huge pages before the fdbserver processes are started. This setting affects all workloads on the
+	// node that hosts the Pod.
+	// The default is false.
+	DisableTransparentHugePages *bool `json:"disableTransparentHugePages,omitempty"`
+}
+
+// namespacedSysctlPrefixes contains the prefixes of the sysctls that are namespaced by the Linux kernel and can
+// therefore be set per Pod.
+var namespacedSysctlPrefixes = []string{
+	"kernel.shm",
+	"kernel.msg",
+	"kernel.sem",
+	"fs.mqueue.",
+	"net.",
+}
+
+// FeatureFlags provides typed options for FoundationDB features that would otherwise require free-form knobs. Each
+// feature is only available in specific versions of FoundationDB and the operator will reject the cluster spec if a
+// feature is enabled for a version that doesn't support it. The knobs managed by a feature flag must not be set in the
+// customParameters.
+type FeatureFlags struct {
+	// EnableVersionVector defines whether the version vector feature should be enabled for the transaction system.
+	// This feature requires at least FDB 7.3.0.
+	EnableVersionVector *bool `json:"enableVersionVector,omitempty"`
+
+	// EnableGrayFailureDetection defines whether the worker health monitor should be enabled. If enabled the cluster
+	// controller will detect degraded processes (gray failures) and will trigger a recovery to exclude them from the
+	// transaction system. This feature requires at least FDB 7.1.0.
+	EnableGrayFailureDetection *bool `json:"enableGrayFailureDetection,omitempty"`
+
+	// IdempotencyIDsMinAgeSeconds defines the minimum duration in seconds that the cluster will keep the idempotency IDs
+	// of committed transactions. This setting requires at least FDB 7.3.0.
+	// +kubebuilder:validation:Minimum=0
+	IdempotencyIDsMinAgeSeconds *int `json:"idempotencyIDsMinAgeSeconds,omitempty"`
+}
+
+// GetFeatureFlagKnobs returns the knobs that must be passed to fdbserver for the enabled feature flags. Feature flags
+// that are not supported by the provided version will be ignored.
+func (cluster *FoundationDBCluster) GetFeatureFlagKnobs(version Version) FoundationDBCustomParameters {
+	knobs := FoundationDBCustomParameters{}
+	featureFlags := cluster.Spec.FeatureFlags
+
+	if pointer.BoolDeref(featureFlags.EnableVersionVector, false) && version.SupportsVersionVector() {
+		knobs = append(knobs, "knob_enable_version_vector=true", "knob_enable_version_vector_tlog_unicast=true")
 	}
 
-	for _, possibleCount := range counts {
-		if possibleCount > count {
-			count = possibleCount
-		}
+	if pointer.BoolDeref(featureFlags.EnableGrayFailureDetection, false) && version.SupportsGrayFailureDetection() {
+		knobs = append(knobs, "knob_enable_worker_health_monitor=true")
 	}
-	if count > 0 {
-		if addFaultTolerance {
-			count += cluster.DesiredFaultTolerance()
-		}
-		if cluster.Spec.FaultDomain.Key == "foundationdb.org/kubernetes-cluster" {
-			zoneCount := cluster.Spec.FaultDomain.ZoneCount
-			if zoneCount < 1 {
-				zoneCount = cluster.MinimumFaultDomains() + cluster.DesiredFaultTolerance()
-			}
-			overflow := count % zoneCount
-			count = count / zoneCount
-			if cluster.Spec.FaultDomain.ZoneIndex < overflow {
-				count++
-			}
-		}
-		return count
+
+	if featureFlags.IdempotencyIDsMinAgeSeconds != nil && version.SupportsIdempotencyIDs() {
+		knobs = append(knobs, FoundationDBCustomParameter(fmt.Sprintf("knob_idempotency_ids_min_age_seconds=%d", *featureFlags.IdempotencyIDsMinAgeSeconds)))
 	}
 
-	return -1
+	return knobs
 }
 
-// GetProcessCountsWithDefaults gets the process counts from the cluster spec
-// and fills in default values for any counts that are 0. The number of storage processes
-// will only reflect the number of Pods that will be created to host storage server processes.
-// If storageServersPerPod is set the total amount of storage server processes will be
-// the storage process count multiplied by storageServersPerPod.
-func (cluster *FoundationDBCluster) GetProcessCountsWithDefaults() (ProcessCounts, error) {
-	roleCounts := cluster.GetRoleCountsWithDefaults()
-	processCounts := cluster.Spec.ProcessCounts.DeepCopy()
+// TuningProfile defines a preset of knobs and resources that is tuned for a specific workload.
+// +kubebuilder:validation:MaxLength=32
+type TuningProfile string
 
-	var isSatellite, isMain bool
-	satelliteLogs := 0
-	for _, region := range cluster.Spec.DatabaseConfiguration.Regions {
-		for _, dataCenter := range region.DataCenters {
-			if dataCenter.ID == cluster.Spec.DataCenter {
-				if dataCenter.Satellite == 0 {
-					isMain = true
-				} else {
-					isSatellite = true
-					if region.SatelliteLogs > satelliteLogs {
-						satelliteLogs = region.SatelliteLogs
-					}
-				}
-			}
+const (
+	// TuningProfileSSDLowLatency reduces the batching intervals of the proxies and limits the data movement to keep the
+	// latency of the foreground traffic low.
+	TuningProfileSSDLowLatency TuningProfile = "ssd-low-latency"
+
+	// TuningProfileThroughput increases the batching of the proxies and the amount of queued data on the storage
+	// servers to optimize for throughput.
+	TuningProfileThroughput TuningProfile = "throughput"
+
+	// TuningProfileMixed provides a balanced configuration for workloads that mix latency sensitive and throughput
+	// oriented traffic.
+	TuningProfileMixed TuningProfile = "mixed"
+)
+
+// TuningProfileStatus contains the values that a tuning profile was expanded to.
+type TuningProfileStatus struct {
+	// Name of the tuning profile.
+	Name TuningProfile `json:"name,omitempty"`
+
+	// Version is the FoundationDB version that was used to expand the profile.
+	Version string `json:"version,omitempty"`
+
+	// Knobs contains the knobs that the operator passes to fdbserver for this profile. Knobs that are overridden by
+	// the customParameters of a process class are still listed here.
+	Knobs FoundationDBCustomParameters `json:"knobs,omitempty"`
+
+	// Resources contains the default resource requests for the main container.
+	Resources corev1.ResourceList `json:"resources,omitempty"`
+}
+
+// tuningProfileKnob defines a knob of a tuning profile and the minimum version of FoundationDB that supports it.
+type tuningProfileKnob struct {
+	parameter      FoundationDBCustomParameter
+	minimumVersion *Version
+}
+
+// tuningProfileKnobs contains the knobs for each tuning profile.
+var tuningProfileKnobs = map[TuningProfile][]tuningProfileKnob{
+	TuningProfileSSDLowLatency: {
+		{parameter: "knob_commit_transaction_batch_interval_max=0.005"},
+		{parameter: "knob_start_transaction_batch_interval_max=0.005"},
+		{parameter: "knob_storage_fetch_keys_rate_limit=25000000", minimumVersion: &Version{api.Version{Major: 7, Minor: 1, Patch: 0}}},
+	},
+	TuningProfileThroughput: {
+		{parameter: "knob_commit_transaction_batch_interval_max=0.050"},
+		{parameter: "knob_commit_transaction_batch_count_max=32768"},
+		{parameter: "knob_fetch_keys_parallelism_bytes=8000000"},
+		{parameter: "knob_target_bytes_per_storage_server=2000000000"},
+	},
+	TuningProfileMixed: {
+		{parameter: "knob_commit_transaction_batch_interval_max=0.010"},
+		{parameter: "knob_storage_fetch_keys_rate_limit=50000000", minimumVersion: &Version{api.Version{Major: 7, Minor: 1, Patch: 0}}},
+	},
+}
+
+// tuningProfileResources contains the default resource requests of the main container for each tuning profile.
+var tuningProfileResources = map[TuningProfile]corev1.ResourceList{
+	TuningProfileSSDLowLatency: {
+		corev1.ResourceCPU:    resource.MustParse("2"),
+		corev1.ResourceMemory: resource.MustParse("8Gi"),
+	},
+	TuningProfileThroughput: {
+		corev1.ResourceCPU:    resource.MustParse("2"),
+		corev1.ResourceMemory: resource.MustParse("16Gi"),
+	},
+	TuningProfileMixed: {
+		corev1.ResourceCPU:    resource.MustParse("1"),
+		corev1.ResourceMemory: resource.MustParse("8Gi"),
+	},
+}
+
+// GetTuningProfileKnobs returns the knobs of the defined tuning profile that are supported by the provided version.
+func (cluster *FoundationDBCluster) GetTuningProfileKnobs(version Version) FoundationDBCustomParameters {
+	knobs := FoundationDBCustomParameters{}
+	for _, knob := range tuningProfileKnobs[cluster.Spec.Profile] {
+		if knob.minimumVersion != nil && !version.IsAtLeast(*knob.minimumVersion) {
+			continue
 		}
+
+		knobs = append(knobs, knob.parameter)
 	}
 
-	if isSatellite && !isMain {
-		if processCounts.Log == 0 {
-			processCounts.Log = 1 + satelliteLogs
-			return *processCounts, nil
-		}
+	return knobs
+}
+
+// GetTuningProfileResources returns the default resource requests of the main container for the defined tuning
+// profile. If no tuning profile is defined nil will be returned.
+func (cluster *FoundationDBCluster) GetTuningProfileResources() corev1.ResourceList {
+	resources, ok := tuningProfileResources[cluster.Spec.Profile]
+	if !ok {
+		return nil
 	}
 
-	if processCounts.Storage == 0 {
-		processCounts.Storage = cluster.calculateProcessCount(false,
-			roleCounts.Storage)
+	return resources.DeepCopy()
+}
+
+// GetTuningProfileStatus returns the values that the defined tuning profile expands to for the provided version. If
+// no tuning profile is defined nil will be returned.
+func (cluster *FoundationDBCluster) GetTuningProfileStatus(version Version) *TuningProfileStatus {
+	if cluster.Spec.Profile == "" {
+		return nil
 	}
 
-	if processCounts.Log == 0 {
-		processCounts.Log = cluster.calculateProcessCount(true,
-			cluster.calculateProcessCountFromRole(roleCounts.Logs+satelliteLogs, processCounts.Log),
-			cluster.calculateProcessCountFromRole(roleCounts.RemoteLogs, processCounts.Log),
-		)
+	return &TuningProfileStatus{
+		Name:      cluster.Spec.Profile,
+		Version:   version.String(),
+		Knobs:     cluster.GetTuningProfileKnobs(version),
+		Resources: cluster.GetTuningProfileResources(),
 	}
+}
 
-	if processCounts.Stateless == 0 {
-		primaryStatelessCount := cluster.calculateProcessCountFromRole(1, processCounts.Master) +
-			cluster.calculateProcessCountFromRole(1, processCounts.ClusterController) +
-			cluster.calculateProcessCountFromRole(roleCounts.Resolvers, processCounts.Resolution)
-		primaryStatelessCount += cluster.calculateProcessCountFromRole(1, processCounts.Ratekeeper) +
-			cluster.calculateProcessCountFromRole(1, processCounts.DataDistributor)
+// derivedMemoryKnobsMonitorOverhead is the memory of the main container that is reserved for the monitor process
+// when the memory knobs are derived from the container resources.
+var derivedMemoryKnobsMonitorOverhead = resource.MustParse("256Mi")
 
-		fdbVersion, err := ParseFdbVersion(cluster.GetRunningVersion())
-		if err != nil {
-			return *processCounts, err
+// derivedMemoryKnobsMarginPercent is the percentage of the memory of the main container that is reserved when the
+// memory knobs are derived from the container resources. The memory knob only limits the resident memory that is
+// tracked by fdbserver, so the processes can use more memory than defined in the knob, and this overshoot grows with
+// the size of the process.
+const derivedMemoryKnobsMarginPercent = 10
+
+// GetDerivedMemoryKnobs returns the memory and cache_memory knobs for the processes of the provided process class,
+// derived from the memory resources of the main container. The memory limit is used if defined, otherwise the memory
+// request. The memory that remains after the proportional margin and the monitor overhead is split between the
+// processCount processes and a quarter of the process memory is used for the cache, matching the ratio of the fdbserver defaults. If the
+// derivation is disabled or the main container defines no memory resources no knobs will be returned.
+func (cluster *FoundationDBCluster) GetDerivedMemoryKnobs(processClass ProcessClass, processCount int) FoundationDBCustomParameters {
+	if !cluster.DeriveMemoryKnobs() {
+		return nil
+	}
+
+	podTemplate := cluster.GetProcessSettings(processClass).PodTemplate
+	if podTemplate == nil {
+		return nil
+	}
+
+	var memory *resource.Quantity
+	for _, container := range podTemplate.Spec.Containers {
+		if container.Name != MainContainerName {
+			continue
 		}
 
-		if fdbVersion.HasSeparatedProxies() && cluster.Spec.DatabaseConfiguration.AreSeparatedProxiesConfigured() {
-			primaryStatelessCount += cluster.calculateProcessCountFromRole(roleCounts.GrvProxies, processCounts.GrvProxy)
-			primaryStatelessCount += cluster.calculateProcessCountFromRole(roleCounts.CommitProxies, processCounts.CommitProxy)
-		} else {
-			primaryStatelessCount += cluster.calculateProcessCountFromRole(roleCounts.Proxies, processCounts.Proxy)
+		if limit, ok := container.Resources.Limits[corev1.ResourceMemory]; ok {
+			memory = &limit
+		} else if request, ok := container.Resources.Requests[corev1.ResourceMemory]; ok {
+			memory = &request
 		}
 
-		processCounts.Stateless = cluster.calculateProcessCount(true,
-			primaryStatelessCount,
-			cluster.calculateProcessCountFromRole(roleCounts.LogRouters),
-		)
+		break
 	}
 
-	return *processCounts, nil
-}
-
-// DesiredFaultTolerance returns the number of replicas we should be able to
-// lose when the cluster is at full replication health.
-func (cluster *FoundationDBCluster) DesiredFaultTolerance() int {
-	return DesiredFaultTolerance(cluster.Spec.DatabaseConfiguration.RedundancyMode)
-}
+	if memory == nil {
+		return nil
+	}
 
-// MinimumFaultDomains returns the number of fault domains the cluster needs
-// to function.
-func (cluster *FoundationDBCluster) MinimumFaultDomains() int {
-	return MinimumFaultDomains(cluster.Spec.DatabaseConfiguration.RedundancyMode)
-}
+	if processCount < 1 {
+		processCount = 1
+	}
 
-// DesiredCoordinatorCount returns the number of coordinators to recruit for a cluster.
-func (cluster *FoundationDBCluster) DesiredCoordinatorCount() int {
-	if cluster.Spec.DatabaseConfiguration.UsableRegions > 1 || cluster.Spec.DatabaseConfiguration.RedundancyMode == RedundancyModeThreeDataHall {
-		return 9
+	availableMemory := memory.Value() - memory.Value()*derivedMemoryKnobsMarginPercent/100 - derivedMemoryKnobsMonitorOverhead.Value()
+	processMemory := availableMemory / int64(processCount)
+	if processMemory <= 0 {
+		return nil
 	}
 
-	return cluster.MinimumFaultDomains() + cluster.DesiredFaultTolerance()
+	return FoundationDBCustomParameters{
+		FoundationDBCustomParameter(fmt.Sprintf("memory=%d", processMemory)),
+		FoundationDBCustomParameter(fmt.Sprintf("cache_memory=%d", processMemory/4)),
+	}
 }
 
-// CheckReconciliation compares the spec and the status to determine if
-// reconciliation is complete.
-func (cluster *FoundationDBCluster) CheckReconciliation(log logr.Logger) (bool, error) {
-	logger := log.WithValues("method", "CheckReconciliation", "namespace", cluster.Namespace, "cluster", cluster.Name)
-	var reconciled = true
-	if !cluster.Status.Configured {
-		logger.Info("Pending initial database configuration", "state", "NeedsConfigurationChange")
-		cluster.Status.Generations.NeedsConfigurationChange = cluster.ObjectMeta.Generation
-		return false, nil
+// validateTuningProfile checks if the defined tuning profile is known and makes sure that the knobs of the profile
+// are not managed by the feature flags.
+func (cluster *FoundationDBCluster) validateTuningProfile(version Version) []string {
+	if cluster.Spec.Profile == "" {
+		return nil
 	}
 
-	cluster.Status.Generations = ClusterGenerationStatus{
-		Reconciled: cluster.Status.Generations.Reconciled,
-	}
-	desiredCounts, err := cluster.GetProcessCountsWithDefaults()
-	if err != nil {
-		return false, err
+	if _, ok := tuningProfileKnobs[cluster.Spec.Profile]; !ok {
+		return []string{fmt.Sprintf("profile %s is not supported, valid profiles are %s, %s and %s", cluster.Spec.Profile, TuningProfileSSDLowLatency, TuningProfileThroughput, TuningProfileMixed)}
 	}
 
-	currentCounts := CreateProcessCountsFromProcessGroupStatus(cluster.Status.ProcessGroups, false)
+	managedKnobs := map[string]None{}
+	for _, knob := range cluster.GetFeatureFlagKnobs(version) {
+		managedKnobs[strings.Split(string(knob), "=")[0]] = None{}
+	}
 
-	diff := desiredCounts.Diff(currentCounts)
-	for _, delta := range diff {
-		if delta > 0 {
-			cluster.Status.Generations.NeedsGrow = cluster.ObjectMeta.Generation
-			reconciled = false
-		} else if delta < 0 {
-			cluster.Status.Generations.NeedsShrink = cluster.ObjectMeta.Generation
-			reconciled = false
+	var validations []string
+	for _, knob := range cluster.GetTuningProfileKnobs(version) {
+		knobName := strings.Split(string(knob), "=")[0]
+		if _, ok := managedKnobs[knobName]; ok {
+			validations = append(validations, fmt.Sprintf("knob %s of profile %s is managed by the featureFlags", knobName, cluster.Spec.Profile))
 		}
 	}
 
-	cluster.Status.DesiredProcessGroups = desiredCounts.Total()
+	return validations
+}
 
-	for _, processGroup := range cluster.Status.ProcessGroups {
-		if processGroup.IsMarkedForRemoval() {
-			if processGroup.GetConditionTime(ResourcesTerminating) != nil {
-				logger.Info("Has process group pending to remove", "processGroupID", processGroup.ProcessGroupID, "state", "HasPendingRemoval")
-				cluster.Status.Generations.HasPendingRemoval = cluster.ObjectMeta.Generation
-			} else {
-				logger.Info("Has process group with pending shrink", "processGroupID", processGroup.ProcessGroupID, "state", "NeedsShrink")
-				cluster.Status.Generations.NeedsShrink = cluster.ObjectMeta.Generation
-				reconciled = false
-			}
+// ClusterMonitoringOptions defines the monitoring integrations that the operator provides for a cluster.
+type ClusterMonitoringOptions struct {
+	// HealthEndpoint defines the settings for the HTTP health endpoint of this cluster.
+	HealthEndpoint HealthEndpointOptions `json:"healthEndpoint,omitempty"`
+}
 
-			continue
-		}
+// HealthEndpointOptions defines the settings for the HTTP health endpoint that reports the availability and the fault
+// tolerance of the database, e.g. for health checks of external load balancers or DNS failover systems.
+type HealthEndpointOptions struct {
+	// Enabled defines if the operator serves the health endpoint for this cluster. The health endpoint is only served
+	// if the operator is started with the --health-endpoint-addr flag.
+	// Default: false
+	Enabled *bool `json:"enabled,omitempty"`
 
-		if len(processGroup.ProcessGroupConditions) > 0 {
-			conditions := make([]ProcessGroupConditionType, 0, len(processGroup.ProcessGroupConditions))
-			for _, condition := range processGroup.ProcessGroupConditions {
-				// If there is at least one process with an incorrect command line, that means the operator has to restart
-				// processes.
-				if condition.ProcessGroupConditionType == IncorrectCommandLine && cluster.Status.Generations.NeedsBounce == 0 {
-					logger.V(1).Info("Pending restart of fdbserver processes", "state", "NeedsBounce")
-					cluster.Status.Generations.NeedsBounce = cluster.ObjectMeta.Generation
-				}
+	// MinimumFaultTolerance defines the minimum number of zones that can fail without losing data or availability
+	// for the cluster to be reported as healthy.
+	// Default: 0
+	// +kubebuilder:validation:Minimum=0
+	MinimumFaultTolerance *int `json:"minimumFaultTolerance,omitempty"`
 
-				// If there is at least one Pod with a IncorrectPodSpec condition we have to delete/recreate that Pod.
-				if condition.ProcessGroupConditionType == IncorrectPodSpec && cluster.Status.Generations.NeedsPodDeletion == 0 {
-					logger.V(1).Info("Pending restart of fdbserver processes", "state", "NeedsPodDeletion")
-					cluster.Status.Generations.NeedsPodDeletion = cluster.ObjectMeta.Generation
-				}
+	// RequireFullReplication defines if the cluster is only reported as healthy if the data is fully replicated.
+	// Default: false
+	RequireFullReplication *bool `json:"requireFullReplication,omitempty"`
+}
 
-				conditions = append(conditions, condition.ProcessGroupConditionType)
-			}
+// HealthEndpointEnabled returns true if the operator should serve the health endpoint for this cluster.
+func (cluster *FoundationDBCluster) HealthEndpointEnabled() bool {
+	return pointer.BoolDeref(cluster.Spec.Monitoring.HealthEndpoint.Enabled, false)
+}
 
-			logger.Info("Has unhealthy process group", "processGroupID", processGroup.ProcessGroupID, "state", "HasUnhealthyProcess", "conditions", conditions)
-			cluster.Status.Generations.HasUnhealthyProcess = cluster.ObjectMeta.Generation
-			reconciled = false
-			continue
-		}
+// GetHealthEndpointMinimumFaultTolerance returns the minimum fault tolerance for the cluster to be reported as
+// healthy by the health endpoint.
+func (cluster *FoundationDBCluster) GetHealthEndpointMinimumFaultTolerance() int {
+	return pointer.IntDeref(cluster.Spec.Monitoring.HealthEndpoint.MinimumFaultTolerance, 0)
+}
 
-		cluster.Status.ReconciledProcessGroups++
-	}
+// HealthEndpointRequiresFullReplication returns true if the cluster is only reported as healthy by the health
+// endpoint if the data is fully replicated.
+func (cluster *FoundationDBCluster) HealthEndpointRequiresFullReplication() bool {
+	return pointer.BoolDeref(cluster.Spec.Monitoring.HealthEndpoint.RequireFullReplication, false)
+}
 
-	if cluster.Status.DesiredProcessGroups != cluster.Status.ReconciledProcessGroups {
-		logger.Info("Not all process groups are reconciled", "desiredProcessGroups", cluster.Status.DesiredProcessGroups, "reconciledProcessGroups", cluster.Status.ReconciledProcessGroups)
-	}
+// ClusterRecoveryOptions defines the manual recovery actions that the operator performs for a cluster.
+type ClusterRecoveryOptions struct {
+	// ForceNewCoordinators instructs the operator to re-seed the coordinators from the coordinators that are still
+	// reachable, after the quorum of coordinators was permanently lost. The operator only performs the recovery if a
+	// quorum of the coordinators is not reachable and if all confirmations match the current state of the cluster.
+	ForceNewCoordinators *ForceNewCoordinatorsOptions `json:"forceNewCoordinators,omitempty"`
+}
 
-	if !cluster.Status.Health.Available {
-		logger.Info("Database unavailable", "state", "DatabaseUnavailable")
-		cluster.Status.Generations.DatabaseUnavailable = cluster.ObjectMeta.Generation
-		reconciled = false
+// ForceNewCoordinatorsOptions defines the confirmations that are required to re-seed the coordinators of a cluster.
+type ForceNewCoordinatorsOptions struct {
+	// ConfirmConnectionString must match the connection string whose coordinator quorum was lost, as reported in
+	// status.coordinatorQuorumLoss.connectionString. This makes sure that a confirmation is only applied to the
+	// quorum loss it was given for.
+	ConfirmConnectionString string `json:"confirmConnectionString,omitempty"`
+
+	// AcceptDataLoss confirms that the reachable coordinators might not have the latest coordinated state of the
+	// cluster and that the recovery can lose recently committed data.
+	AcceptDataLoss bool `json:"acceptDataLoss,omitempty"`
+}
+
+// ForceNewCoordinatorsConfirmed returns true if the forced recovery of the coordinators is confirmed for the provided
+// connection string.
+func (cluster *FoundationDBCluster) ForceNewCoordinatorsConfirmed(connectionString string) bool {
+	forceNewCoordinators := cluster.Spec.Recovery.ForceNewCoordinators
+	if forceNewCoordinators == nil || connectionString == "" {
+		return false
 	}
 
-	desiredConfiguration := cluster.DesiredDatabaseConfiguration()
-	if !equality.Semantic.DeepEqual(cluster.Status.DatabaseConfiguration, desiredConfiguration) {
-		logger.Info("Pending database configuration change", "state", "NeedsConfigurationChange", "current", cluster.Status.DatabaseConfiguration, "desired", desiredConfiguration)
-		cluster.Status.Generations.NeedsConfigurationChange = cluster.ObjectMeta.Generation
-		reconciled = false
+	return forceNewCoordinators.AcceptDataLoss && forceNewCoordinators.ConfirmConnectionString == connectionString
+}
+
+// validateFeatureFlags checks if the enabled feature flags are supported by the provided version and makes sure that
+// the knobs managed by the feature flags are not defined in the customParameters.
+func (cluster *FoundationDBCluster) validateFeatureFlags(version Version) []string {
+	var validations []string
+	featureFlags := cluster.Spec.FeatureFlags
+
+	if pointer.BoolDeref(featureFlags.EnableVersionVector, false) && !version.SupportsVersionVector() {
+		validations = append(validations, fmt.Sprintf("feature flag enableVersionVector is not supported on version %s", version))
 	}
 
-	if cluster.Status.HasIncorrectConfigMap {
-		logger.Info("Pending ConfigMap (Monitor config) configuration change", "state", "NeedsMonitorConfUpdate")
-		cluster.Status.Generations.NeedsMonitorConfUpdate = cluster.ObjectMeta.Generation
-		reconciled = false
+	if pointer.BoolDeref(featureFlags.EnableGrayFailureDetection, false) && !version.SupportsGrayFailureDetection() {
+		validations = append(validations, fmt.Sprintf("feature flag enableGrayFailureDetection is not supported on version %s", version))
 	}
 
-	if cluster.Status.HasIncorrectServiceConfig {
-		logger.Info("Pending Service configuration change", "state", "NeedsServiceUpdate")
-		cluster.Status.Generations.NeedsServiceUpdate = cluster.ObjectMeta.Generation
-		reconciled = false
+	if featureFlags.IdempotencyIDsMinAgeSeconds != nil && !version.SupportsIdempotencyIDs() {
+		validations = append(validations, fmt.Sprintf("feature flag idempotencyIDsMinAgeSeconds is not supported on version %s", version))
 	}
 
-	if cluster.Status.NeedsNewCoordinators {
-		logger.Info("Pending coordinator change", "state", "NeedsNewCoordinators")
-		cluster.Status.Generations.NeedsCoordinatorChange = cluster.ObjectMeta.Generation
-		reconciled = false
+	managedKnobs := map[string]None{}
+	for _, knob := range cluster.GetFeatureFlagKnobs(version) {
+		managedKnobs[strings.Split(string(knob), "=")[0]] = None{}
 	}
 
-	desiredAddressSet := RequiredAddressSet{}
-	if cluster.Spec.MainContainer.EnableTLS {
-		desiredAddressSet.TLS = true
-	} else {
-		desiredAddressSet.NonTLS = true
+	if len(managedKnobs) == 0 {
+		return validations
 	}
 
-	if cluster.Status.RequiredAddresses != desiredAddressSet {
-		logger.Info("Pending TLS change", "state", "HasExtraListeners")
-		cluster.Status.Generations.HasExtraListeners = cluster.ObjectMeta.Generation
-		reconciled = false
+	processClasses := make([]ProcessClass, 0, len(cluster.Spec.Processes))
+	for processClass := range cluster.Spec.Processes {
+		processClasses = append(processClasses, processClass)
 	}
 
-	lockDenyMap := make(map[string]bool, len(cluster.Spec.LockOptions.DenyList))
-	for _, denyListEntry := range cluster.Spec.LockOptions.DenyList {
-		lockDenyMap[denyListEntry.ID] = denyListEntry.Allow
+	// Sort the process classes to make sure the validation messages are stable.
+	sort.Slice(processClasses, func(i, j int) bool {
+		return processClasses[i] < processClasses[j]
+	})
+
+	for _, processClass := range processClasses {
+		for _, parameter := range cluster.Spec.Processes[processClass].CustomParameters {
+			parameterName := strings.TrimSpace(strings.Split(string(parameter), "=")[0])
+			if _, ok := managedKnobs[parameterName]; ok {
+				validations = append(validations, fmt.Sprintf("customParameter %s for process class %s is managed by the featureFlags and must be removed", parameterName, processClass))
+			}
+		}
 	}
 
-	for _, denyListID := range cluster.Status.Locks.DenyList {
+	return validations
+}
+
+// GetProcessSettings gets settings for a process.
+func (cluster *FoundationDBCluster) GetProcessSettings(processClass ProcessClass) ProcessSettings {
+	merged := ProcessSettings{}
+	entries := make([]ProcessSettings, 0, 2)
+
+	entry, present := cluster.Spec.Processes[processClass]
+	if present {
+		entries = append(entries, entry)
+	}
+
+	entries = append(entries, cluster.Spec.Processes[ProcessClassGeneral])
+	for _, entry := range entries {
+		if merged.PodTemplate == nil {
+			merged.PodTemplate = entry.PodTemplate
+		}
+		if merged.VolumeClaimTemplate == nil && processClass.IsStateful() { // stateless pods will not use a PVC
+			merged.VolumeClaimTemplate = entry.VolumeClaimTemplate
+		}
+		if merged.CustomParameters == nil {
+			merged.CustomParameters = entry.CustomParameters
+		}
+		if merged.RuntimeClassName == nil {
+			merged.RuntimeClassName = entry.RuntimeClassName
+		}
+		if merged.KernelSettings == nil {
+			merged.KernelSettings = entry.KernelSettings
+		}
+		if merged.Shutdown == nil {
+			merged.Shutdown = entry.Shutdown
+		}
+		if merged.AdditionalVolumeClaims == nil && processClass.IsStateful() {
+			merged.AdditionalVolumeClaims = entry.AdditionalVolumeClaims
+		}
+		if merged.DiskQualification == nil && processClass.IsStateful() {
+			merged.DiskQualification = entry.DiskQualification
+		}
+		if merged.StartCommand == nil {
+			merged.StartCommand = entry.StartCommand
+		}
+	}
+
+	if present && processClass != ProcessClassGeneral && entry.PodTemplateOverrides != nil {
+		// An invalid override is reported by the validation of the cluster spec.
+		podTemplate, err := mergePodTemplateOverrides(merged.PodTemplate, entry.PodTemplateOverrides)
+		if err == nil {
+			merged.PodTemplate = podTemplate
+		}
+	}
+
+	return merged
+}
+
+// GetProcessSettingsForProcessGroup returns the process settings for the provided process group. For process groups
+// of a storage pool the PodTemplate and the VolumeClaimTemplate of the storage pool take precedence over the process
+// settings of the storage process class.
+func (cluster *FoundationDBCluster) GetProcessSettingsForProcessGroup(processGroup *ProcessGroupStatus) ProcessSettings {
+	settings := cluster.GetProcessSettings(processGroup.ProcessClass)
+	storagePool := cluster.GetStoragePoolForProcessGroup(processGroup)
+	if storagePool == nil {
+		return settings
+	}
+
+	if storagePool.PodTemplate != nil {
+		settings.PodTemplate = storagePool.PodTemplate
+	}
+
+	if storagePool.VolumeClaimTemplate != nil {
+		settings.VolumeClaimTemplate = storagePool.VolumeClaimTemplate
+	}
+
+	return settings
+}
+
+// UseStoragePools returns true if storage pools are defined for this cluster.
+func (cluster *FoundationDBCluster) UseStoragePools() bool {
+	return len(cluster.Spec.StoragePools) > 0
+}
+
+// GetStoragePool returns the storage pool with the provided name or nil if no such storage pool is defined.
+func (cluster *FoundationDBCluster) GetStoragePool(name string) *StoragePool {
+	for idx := range cluster.Spec.StoragePools {
+		if cluster.Spec.StoragePools[idx].Name == name {
+			return &cluster.Spec.StoragePools[idx]
+		}
+	}
+
+	return nil
+}
+
+// GetStoragePoolForProcessGroup returns the storage pool of the provided process group or nil if the process group
+// doesn't belong to a storage pool that is defined in the cluster spec.
+func (cluster *FoundationDBCluster) GetStoragePoolForProcessGroup(processGroup *ProcessGroupStatus) *StoragePool {
+	if processGroup.ProcessClass != ProcessClassStorage || processGroup.StoragePool == "" {
+		return nil
+	}
+
+	return cluster.GetStoragePool(processGroup.StoragePool)
+}
+
+// getStoragePoolProcessCount returns the sum of the counts of all storage pools.
+func (cluster *FoundationDBCluster) getStoragePoolProcessCount() int {
+	count := 0
+	for _, storagePool := range cluster.Spec.StoragePools {
+		count += storagePool.Count
+	}
+
+	return count
+}
+
+// mergePodTemplateOverrides returns a new PodTemplateSpec with the overrides strategically merged onto the provided
+// PodTemplateSpec.
+func mergePodTemplateOverrides(podTemplate *corev1.PodTemplateSpec, overrides *corev1.PodTemplateSpec) (*corev1.PodTemplateSpec, error) {
+	if podTemplate == nil {
+		podTemplate = &corev1.PodTemplateSpec{}
+	}
+
+	original, err := json.Marshal(podTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	patch, err := json.Marshal(overrides)
+	if err != nil {
+		return nil, err
+	}
+
+	mergedJSON, err := strategicpatch.StrategicMergePatch(original, patch, corev1.PodTemplateSpec{})
+	if err != nil {
+		return nil, err
+	}
+
+	merged := &corev1.PodTemplateSpec{}
+	err = json.Unmarshal(mergedJSON, merged)
+	if err != nil {
+		return nil, err
+	}
+
+	return merged, nil
+}
+
+// GetRoleCountsWithDefaults gets the role counts from the cluster spec and
+// fills in default values for any role counts that are 0.
+//
+// The default Storage value will be 2F + 1, where F is the cluster's fault
+// tolerance.
+//
+// The default Logs value will be 3 or 4 for three_data_hall.
+//
+// The default Proxies value will be 3.
+//
+// The default Resolvers value will be 1.
+//
+// The default RemoteLogs value will be equal to the Logs value when the
+// UsableRegions is greater than 1. It will be equal to -1 when the
+// UsableRegions is less than or equal to 1.
+//
+// The default LogRouters value will be equal to 3 times the Logs value when
+// the UsableRegions is greater than 1. It will be equal to -1 when the
+// UsableRegions is less than or equal to 1.
+func (cluster *FoundationDBCluster) GetRoleCountsWithDefaults() RoleCounts {
+	// We can ignore the error here since the version will be validated in an earlier step.
+	version, _ := ParseFdbVersion(cluster.GetRunningVersion())
+	return cluster.Spec.DatabaseConfiguration.GetRoleCountsWithDefaults(version, cluster.DesiredFaultTolerance())
+}
+
+// calculateProcessCount determines the process count from a given role count.
+//
+// alternatives provides a list of other process counts that can fulfill this
+// role instead. If any of those process counts is positive, then this will
+// return 0.
+func (cluster *FoundationDBCluster) calculateProcessCountFromRole(count int, alternatives ...int) int {
+	for _, value := range alternatives {
+		if value > 0 {
+			return 0
+		}
+	}
+	if count < 0 {
+		return 0
+	}
+
+	return count
+}
+
+// calculateProcessCount calculates the process count for a process class based
+// on the counts for the roles it can fulfill.
+//
+// If addFaultTolerance is true, this will add the cluster's desired fault
+// tolerance to the result.
+//
+// If the cluster is using multi-KC replication, this will divide the total
+// count across the number of KCs in the data center.
+func (cluster *FoundationDBCluster) calculateProcessCount(addFaultTolerance bool, counts ...int) int {
+	var count = 0
+
+	if cluster.Spec.FaultDomain.ZoneIndex < 0 {
+		return -1
+	}
+
+	for _, possibleCount := range counts {
+		if possibleCount > count {
+			count = possibleCount
+		}
+	}
+	if count > 0 {
+		if addFaultTolerance {
+			count += cluster.DesiredFaultTolerance()
+		}
+		if cluster.Spec.FaultDomain.Key == "foundationdb.org/kubernetes-cluster" {
+			zoneCount := cluster.Spec.FaultDomain.ZoneCount
+			if zoneCount < 1 {
+				zoneCount = cluster.MinimumFaultDomains() + cluster.DesiredFaultTolerance()
+			}
+			overflow := count % zoneCount
+			count = count / zoneCount
+			if cluster.Spec.FaultDomain.ZoneIndex < overflow {
+				count++
+			}
+		}
+		return count
+	}
+
+	return -1
+}
+
+// GetProcessCountsWithDefaults gets the process counts from the cluster spec
+// and fills in default values for any counts that are 0. The number of storage processes
+// will only reflect the number of Pods that will be created to host storage server processes.
+// If storageServersPerPod is set the total amount of storage server processes will be
+// the storage process count multiplied by storageServersPerPod.
+func (cluster *FoundationDBCluster) GetProcessCountsWithDefaults() (ProcessCounts, error) {
+	roleCounts := cluster.GetRoleCountsWithDefaults()
+	processCounts := cluster.Spec.ProcessCounts.DeepCopy()
+
+	var isSatellite, isMain bool
+	satelliteLogs := 0
+	for _, region := range cluster.Spec.DatabaseConfiguration.Regions {
+		for _, dataCenter := range region.DataCenters {
+			if dataCenter.ID == cluster.Spec.DataCenter {
+				if dataCenter.Satellite == 0 {
+					isMain = true
+				} else {
+					isSatellite = true
+					if region.SatelliteLogs > satelliteLogs {
+						satelliteLogs = region.SatelliteLogs
+					}
+				}
+			}
+		}
+	}
+
+	if isSatellite && !isMain {
+		if processCounts.Log == 0 {
+			processCounts.Log = 1 + satelliteLogs
+			return *processCounts, nil
+		}
+	}
+
+	if cluster.UseStoragePools() {
+		processCounts.Storage = cluster.getStoragePoolProcessCount()
+		// A count of 0 would be replaced with the default, so we use -1 to signal that no storage processes are desired.
+		if processCounts.Storage == 0 {
+			processCounts.Storage = -1
+		}
+	}
+
+	if processCounts.Storage == 0 {
+		processCounts.Storage = cluster.calculateProcessCount(false,
+			roleCounts.Storage)
+	}
+
+	if processCounts.Log == 0 {
+		processCounts.Log = cluster.calculateProcessCount(true,
+			cluster.calculateProcessCountFromRole(roleCounts.Logs+satelliteLogs, processCounts.Log),
+			cluster.calculateProcessCountFromRole(roleCounts.RemoteLogs, processCounts.Log),
+		)
+	}
+
+	if processCounts.Stateless == 0 {
+		statelessCount, err := cluster.getRequiredStatelessProcessCount(processCounts, roleCounts)
+		if err != nil {
+			return *processCounts, err
+		}
+
+		processCounts.Stateless = statelessCount
+	} else if processCounts.Stateless > 0 {
+		// The stateless process count can be changed by an autoscaler through the scale subresource, so the count is
+		// always clamped, even if no StatelessScaling limits are defined.
+		statelessCount, err := cluster.getClampedStatelessProcessCount(processCounts, roleCounts)
+		if err != nil {
+			return *processCounts, err
+		}
+
+		processCounts.Stateless = statelessCount
+	}
+
+	return *processCounts, nil
+}
+
+// getClampedStatelessProcessCount returns the stateless process count clamped to the limits defined in the
+// StatelessScaling options, if those are defined. The returned count will never be lower than the number of stateless
+// processes required for the stateless roles.
+func (cluster *FoundationDBCluster) getClampedStatelessProcessCount(processCounts *ProcessCounts, roleCounts RoleCounts) (int, error) {
+	statelessCount := processCounts.Stateless
+
+	if scaling := cluster.Spec.AutomationOptions.StatelessScaling; scaling != nil {
+		if scaling.MaxProcesses != nil && statelessCount > *scaling.MaxProcesses {
+			statelessCount = *scaling.MaxProcesses
+		}
+
+		if scaling.MinProcesses != nil && statelessCount < *scaling.MinProcesses {
+			statelessCount = *scaling.MinProcesses
+		}
+	}
+
+	requiredCount, err := cluster.getRequiredStatelessProcessCount(processCounts, roleCounts)
+	if err != nil {
+		return statelessCount, err
+	}
+
+	if statelessCount < requiredCount {
+		return requiredCount, nil
+	}
+
+	return statelessCount, nil
+}
+
+// getRequiredStatelessProcessCount returns the number of stateless processes that are required for the stateless
+// roles of the database.
+func (cluster *FoundationDBCluster) getRequiredStatelessProcessCount(processCounts *ProcessCounts, roleCounts RoleCounts) (int, error) {
+	primaryStatelessCount := cluster.calculateProcessCountFromRole(1, processCounts.Master) +
+		cluster.calculateProcessCountFromRole(1, processCounts.ClusterController) +
+		cluster.calculateProcessCountFromRole(roleCounts.Resolvers, processCounts.Resolution)
+	primaryStatelessCount += cluster.calculateProcessCountFromRole(1, processCounts.Ratekeeper) +
+		cluster.calculateProcessCountFromRole(1, processCounts.DataDistributor)
+
+	fdbVersion, err := ParseFdbVersion(cluster.GetRunningVersion())
+	if err != nil {
+		return 0, err
+	}
+
+	if fdbVersion.HasSeparatedProxies() && cluster.Spec.DatabaseConfiguration.AreSeparatedProxiesConfigured() {
+		primaryStatelessCount += cluster.calculateProcessCountFromRole(roleCounts.GrvProxies, processCounts.GrvProxy)
+		primaryStatelessCount += cluster.calculateProcessCountFromRole(roleCounts.CommitProxies, processCounts.CommitProxy)
+	} else {
+		primaryStatelessCount += cluster.calculateProcessCountFromRole(roleCounts.Proxies, processCounts.Proxy)
+	}
+
+	return cluster.calculateProcessCount(true,
+		primaryStatelessCount,
+		cluster.calculateProcessCountFromRole(roleCounts.LogRouters),
+	), nil
+}
+
+// DesiredFaultTolerance returns the number of replicas we should be able to
+// lose when the cluster is at full replication health.
+func (cluster *FoundationDBCluster) DesiredFaultTolerance() int {
+	return DesiredFaultTolerance(cluster.Spec.DatabaseConfiguration.RedundancyMode)
+}
+
+// MinimumFaultDomains returns the number of fault domains the cluster needs
+// to function.
+func (cluster *FoundationDBCluster) MinimumFaultDomains() int {
+	return MinimumFaultDomains(cluster.Spec.DatabaseConfiguration.RedundancyMode)
+}
+
+// DesiredCoordinatorCount returns the number of coordinators to recruit for a cluster. If CoordinatorCount is set
+// this value will be returned, otherwise the count is derived from the redundancy mode and the number of data centers.
+func (cluster *FoundationDBCluster) DesiredCoordinatorCount() int {
+	if cluster.Spec.CoordinatorCount != nil {
+		return *cluster.Spec.CoordinatorCount
+	}
+
+	return cluster.GetDefaultCoordinatorCount()
+}
+
+// GetDefaultCoordinatorCount returns the number of coordinators derived from the redundancy mode and the number of
+// regions. Clusters with multiple usable regions or the three_data_hall redundancy mode will use 9 coordinators to be
+// able to tolerate the loss of a data center or data hall. The same applies to clusters that spread their coordinators
+// across the data centers, see UseDataCenterCoordinators.
+func (cluster *FoundationDBCluster) GetDefaultCoordinatorCount() int {
+	if cluster.Spec.DatabaseConfiguration.UsableRegions > 1 || cluster.Spec.DatabaseConfiguration.RedundancyMode == RedundancyModeThreeDataHall || cluster.UseDataCenterCoordinators() {
+		return 9
+	}
+
+	return cluster.getMinimumCoordinatorCount()
+}
+
+// UseDataCenterCoordinators returns true if the cluster has a single usable region that spans at least three data
+// centers and SpreadCoordinatorsAcrossDataCenters is enabled.
+func (cluster *FoundationDBCluster) UseDataCenterCoordinators() bool {
+	if !pointer.BoolDeref(cluster.Spec.SpreadCoordinatorsAcrossDataCenters, false) {
+		return false
+	}
+
+	return cluster.Spec.DatabaseConfiguration.UsableRegions <= 1 && cluster.Spec.DatabaseConfiguration.CountUniqueDataCenters() >= 3
+}
+
+// getMinimumCoordinatorCount returns the minimum number of coordinators that is required to tolerate the desired
+// fault tolerance of the redundancy mode.
+func (cluster *FoundationDBCluster) getMinimumCoordinatorCount() int {
+	return cluster.MinimumFaultDomains() + cluster.DesiredFaultTolerance()
+}
+
+// CheckReconciliation compares the spec and the status to determine if
+// reconciliation is complete.
+func (cluster *FoundationDBCluster) CheckReconciliation(log logr.Logger) (bool, error) {
+	logger := log.WithValues("method", "CheckReconciliation", "namespace", cluster.Namespace, "cluster", cluster.Name)
+	var reconciled = true
+	if !cluster.Status.Configured {
+		logger.Info("Pending initial database configuration", "state", "NeedsConfigurationChange")
+		cluster.Status.Generations.NeedsConfigurationChange = cluster.ObjectMeta.Generation
+		return false, nil
+	}
+
+	cluster.Status.Generations = ClusterGenerationStatus{
+		Reconciled: cluster.Status.Generations.Reconciled,
+	}
+	desiredCounts, err := cluster.GetProcessCountsWithDefaults()
+	if err != nil {
+		return false, err
+	}
+
+	currentCounts := CreateProcessCountsFromProcessGroupStatus(cluster.Status.ProcessGroups, false)
+
+	diff := desiredCounts.Diff(currentCounts)
+	for _, delta := range diff {
+		if delta > 0 {
+			cluster.Status.Generations.NeedsGrow = cluster.ObjectMeta.Generation
+			reconciled = false
+		} else if delta < 0 {
+			cluster.Status.Generations.NeedsShrink = cluster.ObjectMeta.Generation
+			reconciled = false
+		}
+	}
+
+	cluster.Status.DesiredProcessGroups = desiredCounts.Total()
+
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		// Standby process groups are kept intentionally and are not pending to be removed.
+		if processGroup.IsStandby() {
+			continue
+		}
+
+		if processGroup.IsMarkedForRemoval() {
+			cluster.Status.PendingRemovals++
+			if processGroup.GetConditionTime(ResourcesTerminating) != nil {
+				logger.Info("Has process group pending to remove", "processGroupID", processGroup.ProcessGroupID, "state", "HasPendingRemoval")
+				cluster.Status.Generations.HasPendingRemoval = cluster.ObjectMeta.Generation
+			} else {
+				logger.Info("Has process group with pending shrink", "processGroupID", processGroup.ProcessGroupID, "state", "NeedsShrink")
+				cluster.Status.Generations.NeedsShrink = cluster.ObjectMeta.Generation
+				reconciled = false
+			}
+
+			continue
+		}
+
+		if len(processGroup.ProcessGroupConditions) > 0 {
+			conditions := make([]ProcessGroupConditionType, 0, len(processGroup.ProcessGroupConditions))
+			for _, condition := range processGroup.ProcessGroupConditions {
+				// If there is at least one process with an incorrect command line, that means the operator has to restart
+				// processes.
+				if condition.ProcessGroupConditionType == IncorrectCommandLine && cluster.Status.Generations.NeedsBounce == 0 {
+					logger.V(1).Info("Pending restart of fdbserver processes", "state", "NeedsBounce")
+					cluster.Status.Generations.NeedsBounce = cluster.ObjectMeta.Generation
+				}
+
+				// If there is at least one Pod with a IncorrectPodSpec condition we have to delete/recreate that Pod.
+				if condition.ProcessGroupConditionType == IncorrectPodSpec && cluster.Status.Generations.NeedsPodDeletion == 0 {
+					logger.V(1).Info("Pending restart of fdbserver processes", "state", "NeedsPodDeletion")
+					cluster.Status.Generations.NeedsPodDeletion = cluster.ObjectMeta.Generation
+				}
+
+				conditions = append(conditions, condition.ProcessGroupConditionType)
+			}
+
+			logger.Info("Has unhealthy process group", "processGroupID", processGroup.ProcessGroupID, "state", "HasUnhealthyProcess", "conditions", conditions)
+			cluster.Status.Generations.HasUnhealthyProcess = cluster.ObjectMeta.Generation
+			reconciled = false
+			continue
+		}
+
+		cluster.Status.ReconciledProcessGroups++
+	}
+
+	if cluster.Status.DesiredProcessGroups != cluster.Status.ReconciledProcessGroups {
+		logger.Info("Not all process groups are reconciled", "desiredProcessGroups", cluster.Status.DesiredProcessGroups, "reconciledProcessGroups", cluster.Status.ReconciledProcessGroups)
+	}
+
+	if !cluster.Status.Health.Available {
+		logger.Info("Database unavailable", "state", "DatabaseUnavailable")
+		cluster.Status.Generations.DatabaseUnavailable = cluster.ObjectMeta.Generation
+		reconciled = false
+	}
+
+	desiredConfiguration := cluster.DesiredDatabaseConfiguration()
+	if !equality.Semantic.DeepEqual(cluster.Status.DatabaseConfiguration, desiredConfiguration) {
+		logger.Info("Pending database configuration change", "state", "NeedsConfigurationChange", "current", cluster.Status.DatabaseConfiguration, "desired", desiredConfiguration)
+		cluster.Status.Generations.NeedsConfigurationChange = cluster.ObjectMeta.Generation
+		reconciled = false
+	}
+
+	if cluster.Status.HasIncorrectConfigMap {
+		logger.Info("Pending ConfigMap (Monitor config) configuration change", "state", "NeedsMonitorConfUpdate")
+		cluster.Status.Generations.NeedsMonitorConfUpdate = cluster.ObjectMeta.Generation
+		reconciled = false
+	}
+
+	if cluster.Status.HasIncorrectServiceConfig {
+		logger.Info("Pending Service configuration change", "state", "NeedsServiceUpdate")
+		cluster.Status.Generations.NeedsServiceUpdate = cluster.ObjectMeta.Generation
+		reconciled = false
+	}
+
+	if cluster.Status.NeedsNewCoordinators {
+		logger.Info("Pending coordinator change", "state", "NeedsNewCoordinators")
+		cluster.Status.Generations.NeedsCoordinatorChange = cluster.ObjectMeta.Generation
+		reconciled = false
+	}
+
+	desiredAddressSet := RequiredAddressSet{}
+	if cluster.Spec.MainContainer.EnableTLS {
+		desiredAddressSet.TLS = true
+	} else {
+		desiredAddressSet.NonTLS = true
+	}
+
+	if cluster.Status.RequiredAddresses != desiredAddressSet {
+		logger.Info("Pending TLS change", "state", "HasExtraListeners")
+		cluster.Status.Generations.HasExtraListeners = cluster.ObjectMeta.Generation
+		reconciled = false
+	}
+
+	lockDenyMap := make(map[string]bool, len(cluster.Spec.LockOptions.DenyList))
+	for _, denyListEntry := range cluster.Spec.LockOptions.DenyList {
+		lockDenyMap[denyListEntry.ID] = denyListEntry.Allow
+	}
+
+	for _, denyListID := range cluster.Status.Locks.DenyList {
 		allow, present := lockDenyMap[denyListID]
 		if !present {
 			continue
 		}
-		if allow {
-			logger.Info("Pending lock acquire for configuration changes", "state", "NeedsLockConfigurationChanges", "allowed", allow)
-			cluster.Status.Generations.NeedsLockConfigurationChanges = cluster.ObjectMeta.Generation
-			reconciled = false
-		} else {
-			delete(lockDenyMap, denyListID)
+		if allow {
+			logger.Info("Pending lock acquire for configuration changes", "state", "NeedsLockConfigurationChanges", "allowed", allow)
+			cluster.Status.Generations.NeedsLockConfigurationChanges = cluster.ObjectMeta.Generation
+			reconciled = false
+		} else {
+			delete(lockDenyMap, denyListID)
+		}
+	}
+
+	for _, allow := range lockDenyMap {
+		if !allow {
+			logger.Info("Pending lock acquire for configuration changes", "state", "NeedsLockConfigurationChanges", "allowed", allow)
+			cluster.Status.Generations.NeedsLockConfigurationChanges = cluster.ObjectMeta.Generation
+			reconciled = false
+			break
+		}
+	}
+
+	if reconciled {
+		cluster.Status.Generations.Reconciled = cluster.ObjectMeta.Generation
+	}
+
+	return reconciled, nil
+}
+
+// GetDesiredServersPerPod will return the expected server per Pod for the provided process class.
+func (cluster *FoundationDBCluster) GetDesiredServersPerPod(pClass ProcessClass) int {
+	if pClass == ProcessClassStorage {
+		return cluster.GetStorageServersPerPod()
+	}
+
+	if pClass.SupportsMultipleLogServers() {
+		return cluster.GetLogServersPerPod()
+	}
+
+	return 1
+}
+
+// GetStorageServersPerPod returns the StorageServer per Pod.
+func (cluster *FoundationDBCluster) GetStorageServersPerPod() int {
+	if cluster.Spec.StorageServersPerPod <= 1 {
+		return 1
+	}
+
+	return cluster.Spec.StorageServersPerPod
+}
+
+// GetLogServersPerPod returns the TLog processes per Pod.
+func (cluster *FoundationDBCluster) GetLogServersPerPod() int {
+	if cluster.Spec.LogServersPerPod <= 1 {
+		return 1
+	}
+
+	return cluster.Spec.LogServersPerPod
+}
+
+// alphanum provides the characters that are used for the generation ID in the
+// connection string.
+var alphanum = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// connectionStringPattern provides a regular expression for parsing the
+// connection string.
+var connectionStringPattern = regexp.MustCompile("(?m)^([^#][^:@]+):([^:@]+)@(.*)$")
+
+// ConnectionString models the contents of a cluster file in a structured way
+type ConnectionString struct {
+	// DatabaseName provides an identifier for the database which persists
+	// across coordinator changes.
+	DatabaseName string `json:"databaseName,omitempty"`
+
+	// GenerationID provides a unique ID for the current generation of
+	// coordinators.
+	GenerationID string `json:"generationID,omitempty"`
+
+	// Coordinators provides the addresses of the current coordinators.
+	Coordinators []string `json:"coordinators,omitempty"`
+}
+
+// ParseConnectionString parses a connection string from its string
+// representation
+func ParseConnectionString(str string) (ConnectionString, error) {
+	components := connectionStringPattern.FindStringSubmatch(str)
+	if components == nil {
+		return ConnectionString{}, fmt.Errorf("invalid connection string %s", str)
+	}
+
+	coordinatorsStrings := strings.Split(components[3], ",")
+	coordinators := make([]string, len(coordinatorsStrings))
+	for idx, coordinatorsString := range coordinatorsStrings {
+		coordinatorAddress, err := ParseProcessAddress(coordinatorsString)
+		if err != nil {
+			return ConnectionString{}, err
+		}
+
+		coordinators[idx] = coordinatorAddress.String()
+	}
+
+	return ConnectionString{
+		components[1],
+		components[2],
+		coordinators,
+	}, nil
+}
+
+// String formats a connection string as a string
+func (str *ConnectionString) String() string {
+	return fmt.Sprintf("%s:%s@%s", str.DatabaseName, str.GenerationID, strings.Join(str.Coordinators, ","))
+}
+
+// GenerateNewGenerationID builds a new generation ID
+func (str *ConnectionString) GenerateNewGenerationID() error {
+	id := strings.Builder{}
+	for i := 0; i < 32; i++ {
+		err := id.WriteByte(alphanum[rand.Intn(len(alphanum))])
+		if err != nil {
+			return err
+		}
+	}
+	str.GenerationID = id.String()
+	return nil
+}
+
+// GetFullAddress gets the full public address we should use for a process.
+// This will include the IP address, the port, and any additional flags.
+func (cluster *FoundationDBCluster) GetFullAddress(address string, processNumber int) ProcessAddress {
+	addresses := cluster.GetFullAddressList(address, true, processNumber)
+	if len(addresses) < 1 {
+		return ProcessAddress{}
+	}
+
+	// First element will always be the primary
+	return addresses[0]
+}
+
+// GetFullAddressList gets the full list of public addresses we should use for a
+// process.
+//
+// This will include the IP address, the port, and any additional flags.
+//
+// If a process needs multiple addresses, this will include all of them,
+// separated by commas. If you pass false for primaryOnly, this will return only
+// the primary address.
+func (cluster *FoundationDBCluster) GetFullAddressList(address string, primaryOnly bool, processNumber int) []ProcessAddress {
+	return GetFullAddressList(
+		address,
+		primaryOnly,
+		processNumber,
+		cluster.Status.RequiredAddresses.TLS,
+		cluster.Status.RequiredAddresses.NonTLS)
+}
+
+// HasCoordinators checks whether this connection string matches a set of
+// coordinators.
+func (str *ConnectionString) HasCoordinators(coordinators []ProcessAddress) bool {
+	matchedCoordinators := make(map[string]bool, len(str.Coordinators))
+	for _, address := range str.Coordinators {
+		matchedCoordinators[address] = false
+	}
+
+	for _, pAddr := range coordinators {
+		address := pAddr.String()
+		_, matched := matchedCoordinators[address]
+		if matched {
+			matchedCoordinators[address] = true
+		} else {
+			return false
+		}
+	}
+
+	for _, matched := range matchedCoordinators {
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+// FoundationDBClusterFaultDomain describes the fault domain that a cluster is
+// replicated across.
+type FoundationDBClusterFaultDomain struct {
+	// Key provides a topology key for the fault domain to replicate across.
+	Key string `json:"key,omitempty"`
+
+	// Value provides a harcoded value to use for the zoneid for the pods.
+	Value string `json:"value,omitempty"`
+
+	// ValueFrom provides a field selector to use as the source of the fault
+	// domain.
+	ValueFrom string `json:"valueFrom,omitempty"`
+
+	// ZoneCount provides the number of fault domains in the data center where
+	// these processes are running. This is only used in the
+	// `kubernetes-cluster` fault domain strategy.
+	ZoneCount int `json:"zoneCount,omitempty"`
+
+	// ZoneIndex provides the index of this Kubernetes cluster in the list of
+	// KCs in the data center. This is only used in the `kubernetes-cluster`
+	// fault domain strategy.
+	ZoneIndex int `json:"zoneIndex,omitempty"`
+}
+
+// ContainerOverrides provides options for customizing a container created by
+// the operator.
+type ContainerOverrides struct {
+	// EnableLivenessProbe defines if the sidecar should have a livenessProbe.
+	// This setting will be ignored on the main container.
+	EnableLivenessProbe *bool `json:"enableLivenessProbe,omitempty"`
+
+	// EnableReadinessProbe defines if the sidecar should have a readinessProbe.
+	// This setting will be ignored on the main container.
+	// Deprecated: Will be removed in the next major release.
+	EnableReadinessProbe *bool `json:"enableReadinessProbe,omitempty"`
+
+	// EnableTLS controls whether we should be listening on a TLS connection.
+	EnableTLS bool `json:"enableTls,omitempty"`
+
+	// PeerVerificationRules provides the rules for what client certificates
+	// the process should accept.
+	// +kubebuilder:validation:MaxLength=10000
+	PeerVerificationRules string `json:"peerVerificationRules,omitempty"`
+
+	// ImageConfigs allows customizing the image that we use for
+	// a container.
+	// +kubebuilder:validation:MaxItems=100
+	ImageConfigs []ImageConfig `json:"imageConfigs,omitempty"`
+}
+
+// DesiredDatabaseConfiguration builds the database configuration for the
+// cluster based on its spec.
+func (cluster *FoundationDBCluster) DesiredDatabaseConfiguration() DatabaseConfiguration {
+	configuration := cluster.Spec.DatabaseConfiguration.NormalizeConfigurationWithSeparatedProxies(cluster.GetRunningVersion(), cluster.Spec.DatabaseConfiguration.AreSeparatedProxiesConfigured())
+	configuration.RoleCounts = cluster.GetRoleCountsWithDefaults()
+	configuration.RoleCounts.Storage = 0
+
+	version, _ := ParseFdbVersion(cluster.GetRunningVersion())
+	if version.HasSeparatedProxies() && cluster.Spec.DatabaseConfiguration.AreSeparatedProxiesConfigured() {
+		configuration.RoleCounts.Proxies = 0
+	} else {
+		configuration.RoleCounts.GrvProxies = 0
+		configuration.RoleCounts.CommitProxies = 0
+	}
+
+	if configuration.StorageEngine == StorageEngineSSD {
+		configuration.StorageEngine = StorageEngineSSD2
+	}
+	if configuration.StorageEngine == StorageEngineMemory {
+		configuration.StorageEngine = StorageEngineMemory2
+	}
+
+	return configuration
+}
+
+// ClearMissingVersionFlags clears any version flags in the given configuration that are not
+// set in the configuration in the cluster spec.
+//
+// This allows us to compare the spec to the live configuration while ignoring
+// version flags that are unset in the spec.
+func (cluster *FoundationDBCluster) ClearMissingVersionFlags(configuration *DatabaseConfiguration) {
+	if cluster.Spec.DatabaseConfiguration.LogVersion == 0 {
+		configuration.LogVersion = 0
+	}
+	if cluster.Spec.DatabaseConfiguration.LogSpill == 0 {
+		configuration.LogSpill = 0
+	}
+}
+
+// IsBeingUpgraded determines whether the cluster has a pending upgrade.
+func (cluster *FoundationDBCluster) IsBeingUpgraded() bool {
+	return cluster.Status.RunningVersion != "" && cluster.Status.RunningVersion != cluster.Spec.Version
+}
+
+// IsBeingUpgradedWithVersionIncompatibleVersion determines whether the cluster has a pending upgrade to a version incompatible version.
+func (cluster *FoundationDBCluster) IsBeingUpgradedWithVersionIncompatibleVersion() bool {
+	if !cluster.IsBeingUpgraded() {
+		return false
+	}
+
+	runningVersion, _ := ParseFdbVersion(cluster.Status.RunningVersion)
+	desiredVersion, _ := ParseFdbVersion(cluster.Spec.Version)
+
+	return !runningVersion.IsProtocolCompatible(desiredVersion)
+}
+
+// VersionCompatibleUpgradeInProgress returns true if the cluster is currently being upgraded and the upgrade is to
+// a version compatible version.
+func (cluster *FoundationDBCluster) VersionCompatibleUpgradeInProgress() bool {
+	if !cluster.IsBeingUpgraded() {
+		return false
+	}
+
+	runningVersion, _ := ParseFdbVersion(cluster.Status.RunningVersion)
+	desiredVersion, _ := ParseFdbVersion(cluster.Spec.Version)
+
+	return runningVersion.IsProtocolCompatible(desiredVersion)
+}
+
+// ProcessGroupIsBeingRemoved determines if an instance is pending removal.
+func (cluster *FoundationDBCluster) ProcessGroupIsBeingRemoved(processGroupID ProcessGroupID) bool {
+	if processGroupID == "" {
+		return false
+	}
+
+	for _, status := range cluster.Status.ProcessGroups {
+		if status.ProcessGroupID == processGroupID && status.IsMarkedForRemoval() {
+			return true
+		}
+	}
+
+	for _, id := range cluster.Spec.ProcessGroupsToRemove {
+		if id == processGroupID {
+			return true
+		}
+	}
+
+	for _, id := range cluster.Spec.ProcessGroupsToRemoveWithoutExclusion {
+		if id == processGroupID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ShouldUseLocks determine whether we should use locks to coordinator global
+// operations.
+func (cluster *FoundationDBCluster) ShouldUseLocks() bool {
+	disabled := cluster.Spec.LockOptions.DisableLocks
+	if disabled != nil {
+		return !*disabled
+	}
+
+	return cluster.Spec.FaultDomain.ZoneCount > 1 || len(cluster.Spec.DatabaseConfiguration.Regions) > 1 ||
+		cluster.Spec.DatabaseConfiguration.RedundancyMode == RedundancyModeThreeDataHall
+}
+
+// GetLockPrefix gets the prefix for the keys where we store locking
+// information.
+func (cluster *FoundationDBCluster) GetLockPrefix() string {
+	if cluster.Spec.LockOptions.LockKeyPrefix != "" {
+		return cluster.Spec.LockOptions.LockKeyPrefix
+	}
+
+	return "\xff\x02/org.foundationdb.kubernetes-operator"
+}
+
+// GetMaintenancePrefix returns the prefix that is used by the operator to store and read maintenance related information.
+// The prefix will be the provided GetLockPrefix appended by "maintenance".
+func (cluster *FoundationDBCluster) GetMaintenancePrefix() string {
+	return fmt.Sprintf("%s/maintenance", cluster.GetLockPrefix())
+}
+
+// GetLockDuration determines how long we hold locks for.
+func (cluster *FoundationDBCluster) GetLockDuration() time.Duration {
+	minutes := 10
+	if cluster.Spec.LockOptions.LockDurationMinutes != nil {
+		minutes = *cluster.Spec.LockOptions.LockDurationMinutes
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// GetLockID gets the identifier for this instance of the operator when taking
+// locks. This is the `ProcessGroupIDPrefix` defined for this cluster.
+func (cluster *FoundationDBCluster) GetLockID() string {
+	return cluster.Spec.ProcessGroupIDPrefix
+}
+
+// NeedsExplicitListenAddress determines whether we pass a listen address
+// parameter to fdbserver.
+func (cluster *FoundationDBCluster) NeedsExplicitListenAddress() bool {
+	return cluster.GetPublicIPSource() == PublicIPSourceService || cluster.GetUseExplicitListenAddress()
+}
+
+// GetPublicIPSource returns the set PublicIPSource or the default PublicIPSourcePod
+func (cluster *FoundationDBCluster) GetPublicIPSource() PublicIPSource {
+	source := cluster.Spec.Routing.PublicIPSource
+	if source == nil {
+		return PublicIPSourcePod
+	}
+
+	return *source
+}
+
+// PinCoordinatorIPs returns true if the public IPs of coordinators should be pinned to a Service when the coordinator
+// Pod is recreated.
+func (cluster *FoundationDBCluster) PinCoordinatorIPs() bool {
+	if cluster.GetPublicIPSource() != PublicIPSourcePod || !cluster.GetUseExplicitListenAddress() || cluster.UseDNSInClusterFile() {
+		return false
+	}
+
+	return pointer.BoolDeref(cluster.Spec.Routing.PinCoordinatorIPs, false)
+}
+
+// GetPublicIPSourceForProcessGroup returns the PublicIPSource for the provided process group. If the public IP of the
+// process group is pinned, PublicIPSourceService will be returned.
+func (cluster *FoundationDBCluster) GetPublicIPSourceForProcessGroup(processGroup *ProcessGroupStatus) PublicIPSource {
+	if processGroup != nil && processGroup.PublicIPPinned && cluster.PinCoordinatorIPs() {
+		return PublicIPSourceService
+	}
+
+	return cluster.GetPublicIPSource()
+}
+
+// ManagePodDisruptionBudgets returns true if the operator should manage a PodDisruptionBudget for every process class.
+func (cluster *FoundationDBCluster) ManagePodDisruptionBudgets() bool {
+	return pointer.BoolDeref(cluster.Spec.PodDisruptionBudgets.Enabled, false)
+}
+
+// PublishConnectionSecret returns true if the operator should publish the connection details of the cluster in a Secret.
+func (cluster *FoundationDBCluster) PublishConnectionSecret() bool {
+	return pointer.BoolDeref(cluster.Spec.ConnectionSecret.Enabled, false)
+}
+
+// GetConnectionSecretName returns the name of the Secret that contains the connection details of the cluster.
+func (cluster *FoundationDBCluster) GetConnectionSecretName() string {
+	if cluster.Spec.ConnectionSecret.Name != "" {
+		return cluster.Spec.ConnectionSecret.Name
+	}
+
+	return fmt.Sprintf("%s-connection", cluster.Name)
+}
+
+// GetPodDisruptionBudgetMaxUnavailable returns the number of Pods of the process class that are allowed to be
+// unavailable. If no value is defined for the process class, the desired fault tolerance of the redundancy mode will be
+// returned.
+func (cluster *FoundationDBCluster) GetPodDisruptionBudgetMaxUnavailable(processClass ProcessClass) int {
+	if maxUnavailable, ok := cluster.Spec.PodDisruptionBudgets.MaxUnavailable[processClass]; ok {
+		return maxUnavailable
+	}
+
+	return cluster.DesiredFaultTolerance()
+}
+
+// LockOptions provides customization for locking global operations.
+type LockOptions struct {
+	// DisableLocks determines whether we should disable locking entirely.
+	DisableLocks *bool `json:"disableLocks,omitempty"`
+
+	// LockKeyPrefix provides a custom prefix for the keys in the database we
+	// use to store locks.
+	LockKeyPrefix string `json:"lockKeyPrefix,omitempty"`
+
+	// LockDurationMinutes determines the duration that locks should be valid
+	// for.
+	LockDurationMinutes *int `json:"lockDurationMinutes,omitempty"`
+
+	// DenyList manages configuration for whether an instance of the operator
+	// should be denied from taking locks.
+	DenyList []LockDenyListEntry `json:"denyList,omitempty"`
+}
+
+// LockDenyListEntry models an entry in the deny list for the locking system.
+type LockDenyListEntry struct {
+	// The ID of the operator instance this entry is targeting.
+	ID string `json:"id,omitempty"`
+
+	// Whether the instance is allowed to take locks.
+	Allow bool `json:"allow,omitempty"`
+}
+
+// RoutingConfig allows configuring routing to our pods, and services that sit
+// in front of them.
+type RoutingConfig struct {
+	// Headless determines whether we want to run a headless service for the
+	// cluster.
+	HeadlessService *bool `json:"headlessService,omitempty"`
+
+	// ProcessClassHeadlessServices defines the process classes for which the operator creates a headless Service named
+	// <cluster>-<process class>. The Service selects all Pods of the process class, so the Pods can be enumerated
+	// through DNS, e.g. for metrics scraping or debugging. Services of process classes that are removed from this list
+	// will be deleted by the operator.
+	// +kubebuilder:validation:MaxItems=20
+	ProcessClassHeadlessServices []ProcessClass `json:"processClassHeadlessServices,omitempty"`
+
+	// PublicIPSource specifies what source a process should use to get its
+	// public IPs.
+	//
+	// This supports the values `pod` and `service`.
+	PublicIPSource *PublicIPSource `json:"publicIPSource,omitempty"`
+
+	// PodIPFamily tells the pod which family of IP addresses to use.
+	// You can use 4 to represent IPv4, and 6 to represent IPv6.
+	// This feature is only supported in FDB 7.0 or later, and requires
+	// dual-stack support in your Kubernetes environment.
+	PodIPFamily *int `json:"podIPFamily,omitempty"`
+
+	// UseDNSInClusterFile determines whether to use DNS names rather than IP
+	// addresses to identify coordinators in the cluster file. This requires
+	// FoundationDB 7.0+.
+	UseDNSInClusterFile *bool `json:"useDNSInClusterFile,omitempty"`
+
+	// DefineDNSLocalityFields determines whether to define pod DNS names on pod
+	// specs and provide them in the locality arguments to fdbserver.
+	//
+	// This is ignored if UseDNSInCluster is true.
+	DefineDNSLocalityFields *bool `json:"defineDNSLocalityFields,omitempty"`
+
+	// DNSDomain defines the cluster domain used in a DNS name generated for a
+	// service.
+	// The default is `cluster.local`.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	DNSDomain *string `json:"dnsDomain,omitempty"`
+
+	// PinCoordinatorIPs determines whether the public IP of a coordinator should be pinned to the ClusterIP of a per
+	// process group Service when the coordinator Pod is recreated. Once pinned the process keeps its address across
+	// further Pod recreations, so no coordinator change is required. This only has an effect if the PublicIPSource is
+	// `pod`, an explicit listen address is used and DNS names are not used in the cluster file.
+	// The default is false.
+	PinCoordinatorIPs *bool `json:"pinCoordinatorIPs,omitempty"`
+
+	// MonitorAPIGateway defines whether the API of the fdb-kubernetes-monitor is exposed through Gateway API routes,
+	// so tooling outside of the namespace can query the state of the processes. This requires the unified image.
+	MonitorAPIGateway *MonitorAPIGatewayConfig `json:"monitorAPIGateway,omitempty"`
+}
+
+// MonitorAPIGatewayConfig defines how the API of the fdb-kubernetes-monitor is exposed through Gateway API routes. The
+// operator creates a Service and an HTTPRoute for every process group. The HTTPRoute matches the path prefix
+// /<namespace>/<cluster>/<process group ID> and forwards the requests without this prefix to the monitor API of the
+// Pod.
+type MonitorAPIGatewayConfig struct {
+	// Enabled defines whether the operator creates the routes for the monitor API.
+	// The default is false.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// ParentRefs defines the Gateways that the routes are attached to.
+	// +kubebuilder:validation:MaxItems=8
+	ParentRefs []GatewayParentReference `json:"parentRefs,omitempty"`
+
+	// Hostname defines the hostname that is matched by the routes. If unset the routes match all hostnames of the
+	// Gateway listeners.
+	// +kubebuilder:validation:MaxLength=253
+	Hostname string `json:"hostname,omitempty"`
+
+	// Port defines the port of the monitor API in the Pod.
+	// The default is 8081.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port *int `json:"port,omitempty"`
+
+	// CertificateIssuer defines the cert-manager issuer that signs the certificate for the Hostname. The certificate
+	// is stored in the Secret <cluster>-monitor-api-tls and can be used by the Gateway listener to terminate TLS and
+	// verify the client certificates. If unset, the operator doesn't manage a certificate.
+	CertificateIssuer *CertificateIssuerReference `json:"certificateIssuer,omitempty"`
+}
+
+// GatewayParentReference references a Gateway that a route is attached to.
+type GatewayParentReference struct {
+	// Name of the Gateway.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name"`
+
+	// Namespace of the Gateway. If unset the namespace of the cluster is used.
+	// +kubebuilder:validation:MaxLength=63
+	Namespace string `json:"namespace,omitempty"`
+
+	// SectionName defines the listener of the Gateway that the route is attached to. If unset the route is attached
+	// to all listeners of the Gateway.
+	// +kubebuilder:validation:MaxLength=253
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+// CertificateIssuerReference references a cert-manager issuer.
+type CertificateIssuerReference struct {
+	// Name of the issuer.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name"`
+
+	// Kind of the issuer, e.g. Issuer or ClusterIssuer.
+	// The default is Issuer.
+	// +kubebuilder:validation:MaxLength=63
+	Kind string `json:"kind,omitempty"`
+
+	// Group of the issuer.
+	// The default is cert-manager.io.
+	// +kubebuilder:validation:MaxLength=253
+	Group string `json:"group,omitempty"`
+}
+
+// PodDisruptionBudgetConfig allows configuring the PodDisruptionBudgets that are managed by the operator.
+type PodDisruptionBudgetConfig struct {
+	// Enabled determines whether the operator should create a PodDisruptionBudget for every process class of the
+	// cluster. The minAvailable of the PodDisruptionBudget is kept in sync with the desired process count of the
+	// process class.
+	// The default is false.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// MaxUnavailable defines how many Pods of a process class are allowed to be unavailable. If a process class is not
+	// defined, the desired fault tolerance of the redundancy mode will be used.
+	MaxUnavailable map[ProcessClass]int `json:"maxUnavailable,omitempty"`
+}
+
+// ConnectionSecretConfig defines the configuration for the Secret that contains the connection details of the cluster
+// for clients and infrastructure-as-code tools.
+type ConnectionSecretConfig struct {
+	// Enabled determines whether the operator should publish the connection details of the cluster in a Secret. The
+	// Secret is updated in the same reconciliation step as the connection string, e.g. after the coordinators have
+	// been changed.
+	// The default is false.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Name defines the name of the Secret. The default is "<cluster-name>-connection".
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name,omitempty"`
+
+	// Labels defines additional labels for the Secret.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations defines additional annotations for the Secret.
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// RequiredAddressSet provides settings for which addresses we need to listen
+// on.
+type RequiredAddressSet struct {
+	// TLS defines whether we need to listen on a TLS address.
+	TLS bool `json:"tls,omitempty"`
+
+	// NonTLS defines whether we need to listen on a non-TLS address.
+	NonTLS bool `json:"nonTLS,omitempty"`
+}
+
+// CrashLoopContainerObject specifies crash-loop target for specific container.
+type CrashLoopContainerObject struct {
+	// Name of the target container.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	ContainerName string `json:"containerName,omitempty"`
+
+	// Target processes to kill inside the container.
+	// +kubebuilder:validation:MinItems=0
+	// +kubebuilder:validation:MaxItems=10000
+	Targets []ProcessGroupID `json:"targets,omitempty"`
+}
+
+// BuggifyConfig provides options for injecting faults into a cluster for testing.
+type BuggifyConfig struct {
+	// NoSchedule defines a list of process group IDs that should fail to schedule.
+	NoSchedule []ProcessGroupID `json:"noSchedule,omitempty"`
+
+	// CrashLoops defines a list of process group IDs that should be put into a
+	// crash looping state.
+	// Deprecated: use CrashLoopContainers instead.
+	CrashLoop []ProcessGroupID `json:"crashLoop,omitempty"`
+
+	// CrashLoopContainers defines a list of process group IDs and containers
+	// that should be put into a crash looping state.
+	// +kubebuilder:validation:MinItems=0
+	// +kubebuilder:validation:MaxItems=8
+	CrashLoopContainers []CrashLoopContainerObject `json:"crashLoopContainers,omitempty"`
+
+	// EmptyMonitorConf instructs the operator to update all of the fdbmonitor.conf
+	// files to have zero fdbserver processes configured.
+	EmptyMonitorConf bool `json:"emptyMonitorConf,omitempty"`
+
+	// IgnoreDuringRestart instructs the operator to ignore the provided process groups IDs during the
+	// restart command. This can be useful to simulate cases where the kill command is not restarting all
+	// processes. IgnoreDuringRestart does not support the wildcard option to ignore all of this specific cluster processes.
+	// +kubebuilder:validation:MaxItems=1000
+	IgnoreDuringRestart []ProcessGroupID `json:"ignoreDuringRestart,omitempty"`
+
+	// BlockRemoval defines a list of process group IDs that will not be removed, even if they are marked for removal.
+	// The operator will trigger the exclusion but the removal of the resources will be blocked until they are removed
+	// from this list. This setting can be used to simulate cases where a process group is marked for removal but the
+	// resources are not yet removed.
+	// +kubebuilder:validation:MaxItems=1000
+	BlockRemoval []ProcessGroupID `json:"blockRemoval,omitempty"`
+}
+
+// LabelConfig allows customizing labels used by the operator.
+type LabelConfig struct {
+	// MatchLabels provides the labels that the operator should use to identify
+	// resources owned by the cluster. These will automatically be applied to
+	// all resources the operator creates.
+	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+
+	// ResourceLabels provides additional labels that the operator should apply to
+	// resources it creates.
+	ResourceLabels map[string]string `json:"resourceLabels,omitempty"`
+
+	// ProcessGroupIDLabels provides the labels that we use for the process group ID
+	// field. The first label will be used by the operator when filtering
+	// resources.
+	// +kubebuilder:validation:MaxItems=100
+	ProcessGroupIDLabels []string `json:"processGroupIDLabels,omitempty"`
+
+	// ProcessClassLabels provides the labels that we use for the process class
+	// field. The first label will be used by the operator when filtering
+	// resources.
+	// +kubebuilder:validation:MaxItems=100
+	ProcessClassLabels []string `json:"processClassLabels,omitempty"`
+
+	// FilterOnOwnerReferences determines whether we should check that resources
+	// are owned by the cluster object, in addition to the constraints provided
+	// by the match labels.
+	// Deprecated: This setting will be removed in the next major release.
+	FilterOnOwnerReferences *bool `json:"filterOnOwnerReference,omitempty"`
+
+	// ClaimResources defines if the operator should add the foundationdb.org/cluster-claim annotation to the Pods and
+	// PVCs of the cluster. Claimed resources are only managed by the cluster named in the annotation, which
+	// allows the operator to detect resources that match the labels of multiple clusters when the operator is not
+	// filtering on owner references. Existing resources without the annotation are adopted by the cluster.
+	// Defaults to false.
+	ClaimResources *bool `json:"claimResources,omitempty"`
+}
+
+// PropagatedMetadata defines labels and annotations that are propagated to the PVCs and Services of a cluster.
+// Labels and annotations that are managed by the operator or defined in the volume claim template take precedence.
+type PropagatedMetadata struct {
+	// Labels defines the labels that will be added.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations defines the annotations that will be added.
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// PublicIPSource models options for how a pod gets its public IP.
+type PublicIPSource string
+
+const (
+	// PublicIPSourcePod specifies that a pod gets its IP from the pod IP.
+	PublicIPSourcePod PublicIPSource = "pod"
+
+	// PublicIPSourceService specifies that a pod gets its IP from a service.
+	PublicIPSourceService PublicIPSource = "service"
+)
+
+// AddServersPerDisk adds serverPerDisk to the status field to keep track which ConfigMaps should be kept
+func (clusterStatus *FoundationDBClusterStatus) AddServersPerDisk(serversPerDisk int, pClass ProcessClass) {
+	if pClass == ProcessClassStorage {
+		for _, curServersPerDisk := range clusterStatus.StorageServersPerDisk {
+			if curServersPerDisk == serversPerDisk {
+				return
+			}
+		}
+		clusterStatus.StorageServersPerDisk = append(clusterStatus.StorageServersPerDisk, serversPerDisk)
+		return
+	}
+
+	if pClass.SupportsMultipleLogServers() {
+		for _, curServersPerDisk := range clusterStatus.LogServersPerDisk {
+			if curServersPerDisk == serversPerDisk {
+				return
+			}
 		}
+		clusterStatus.LogServersPerDisk = append(clusterStatus.LogServersPerDisk, serversPerDisk)
 	}
+}
 
-	for _, allow := range lockDenyMap {
-		if !allow {
-			logger.Info("Pending lock acquire for configuration changes", "state", "NeedsLockConfigurationChanges", "allowed", allow)
-			cluster.Status.Generations.NeedsLockConfigurationChanges = cluster.ObjectMeta.Generation
-			reconciled = false
-			break
+// validateMaxConcurrentPerClass validates that the per process class limits for concurrent replacements are not
+// negative.
+func (cluster *FoundationDBCluster) validateMaxConcurrentPerClass() []string {
+	limits := cluster.Spec.AutomationOptions.Replacements.MaxConcurrentPerClass
+	processClasses := make([]ProcessClass, 0, len(limits))
+	for processClass := range limits {
+		processClasses = append(processClasses, processClass)
+	}
+	sort.Slice(processClasses, func(i, j int) bool {
+		return processClasses[i] < processClasses[j]
+	})
+
+	var validations []string
+	for _, processClass := range processClasses {
+		if limits[processClass] < 0 {
+			validations = append(validations, fmt.Sprintf("maxConcurrentPerClass %d for process class %s must not be negative", limits[processClass], processClass))
 		}
 	}
 
-	if reconciled {
-		cluster.Status.Generations.Reconciled = cluster.ObjectMeta.Generation
+	return validations
+}
+
+// validateReplacementPriorityOrder validates that every process class is only listed once in the priority order of
+// the replacements.
+func (cluster *FoundationDBCluster) validateReplacementPriorityOrder() []string {
+	seen := make(map[ProcessClass]None, len(cluster.Spec.AutomationOptions.Replacements.PriorityOrder))
+
+	var validations []string
+	for _, processClass := range cluster.Spec.AutomationOptions.Replacements.PriorityOrder {
+		if _, ok := seen[processClass]; ok {
+			validations = append(validations, fmt.Sprintf("process class %s is listed multiple times in the replacement priorityOrder", processClass))
+			continue
+		}
+
+		seen[processClass] = None{}
 	}
 
-	return reconciled, nil
+	return validations
 }
 
-// GetDesiredServersPerPod will return the expected server per Pod for the provided process class.
-func (cluster *FoundationDBCluster) GetDesiredServersPerPod(pClass ProcessClass) int {
-	if pClass == ProcessClassStorage {
-		return cluster.GetStorageServersPerPod()
+// validateReplacementWindows validates that the schedules of the replacement windows are valid cron expressions and
+// that every window stays open for at least one minute.
+func (cluster *FoundationDBCluster) validateReplacementWindows() []string {
+	var validations []string
+	for _, window := range cluster.Spec.AutomationOptions.Replacements.AllowedWindows {
+		_, err := cron.ParseStandard(window.Schedule)
+		if err != nil {
+			validations = append(validations, fmt.Sprintf("schedule \"%s\" of replacement window is not a valid cron expression: %s", window.Schedule, err.Error()))
+		}
+
+		if window.DurationMinutes < 1 {
+			validations = append(validations, fmt.Sprintf("durationMinutes %d of replacement window with schedule \"%s\" must be at least 1", window.DurationMinutes, window.Schedule))
+		}
 	}
 
-	if pClass.SupportsMultipleLogServers() {
-		return cluster.GetLogServersPerPod()
+	return validations
+}
+
+// validateStorageServersPerPod checks that multiple storage servers per Pod are only used with a storage engine that
+// stores the data on disk. The memory storage engines keep all data in memory, so multiple storage servers would
+// compete for the memory of the same Pod.
+func (cluster *FoundationDBCluster) validateStorageServersPerPod() []string {
+	if cluster.GetStorageServersPerPod() <= 1 {
+		return nil
 	}
 
-	return 1
+	storageEngine := cluster.Spec.DatabaseConfiguration.StorageEngine
+	if storageEngine == StorageEngineMemory || storageEngine == StorageEngineMemory2 {
+		return []string{fmt.Sprintf("storageServersPerPod %d is not supported with storage engine %s", cluster.Spec.StorageServersPerPod, storageEngine)}
+	}
+
+	return nil
 }
 
-// GetStorageServersPerPod returns the StorageServer per Pod.
-func (cluster *FoundationDBCluster) GetStorageServersPerPod() int {
-	if cluster.Spec.StorageServersPerPod <= 1 {
-		return 1
+// validateStoragePools checks that the storage pools have unique names and that the storage process count is not
+// defined in addition to the storage pools.
+func (cluster *FoundationDBCluster) validateStoragePools() []string {
+	if !cluster.UseStoragePools() {
+		return nil
 	}
 
-	return cluster.Spec.StorageServersPerPod
+	var validations []string
+	if cluster.Spec.ProcessCounts.Storage != 0 {
+		validations = append(validations, "processCounts.storage must not be set if storagePools are defined")
+	}
+
+	names := make(map[string]None, len(cluster.Spec.StoragePools))
+	for _, storagePool := range cluster.Spec.StoragePools {
+		if storagePool.Name == "" {
+			validations = append(validations, "storage pool name must not be empty")
+			continue
+		}
+
+		if _, ok := names[storagePool.Name]; ok {
+			validations = append(validations, fmt.Sprintf("storage pool %s is defined multiple times", storagePool.Name))
+			continue
+		}
+
+		if storagePool.Count < 0 {
+			validations = append(validations, fmt.Sprintf("storage pool %s has a negative count %d", storagePool.Name, storagePool.Count))
+		}
+
+		names[storagePool.Name] = None{}
+	}
+
+	return validations
 }
 
-// GetLogServersPerPod returns the TLog processes per Pod.
-func (cluster *FoundationDBCluster) GetLogServersPerPod() int {
-	if cluster.Spec.LogServersPerPod <= 1 {
-		return 1
+// validateProxies checks that the grv and commit proxy counts are not negative. For versions before 7.0 the separated
+// proxy counts are ignored and the proxies count is used, so a cluster that defines the separated proxy counts can be
+// created before it is upgraded to 7.0+.
+func (cluster *FoundationDBCluster) validateProxies() []string {
+	roleCounts := cluster.Spec.DatabaseConfiguration.RoleCounts
+
+	var validations []string
+	if roleCounts.GrvProxies < 0 {
+		validations = append(validations, fmt.Sprintf("grv_proxies %d must not be negative", roleCounts.GrvProxies))
 	}
 
-	return cluster.Spec.LogServersPerPod
+	if roleCounts.CommitProxies < 0 {
+		validations = append(validations, fmt.Sprintf("commit_proxies %d must not be negative", roleCounts.CommitProxies))
+	}
+
+	return validations
 }
 
-// alphanum provides the characters that are used for the generation ID in the
-// connection string.
-var alphanum = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+// validateRedundancyMode checks that the redundancy mode is known and that the defined number of storage processes
+// is enough to replicate the data according to the redundancy mode. The number of storage processes is not checked
+// for the three_data_hall redundancy mode, the satellite redundancy modes and the kubernetes-cluster fault domain, as
+// in those setups the storage processes are spread across multiple FoundationDBCluster resources.
+func (cluster *FoundationDBCluster) validateRedundancyMode() []string {
+	redundancyMode := cluster.Spec.DatabaseConfiguration.RedundancyMode
+	switch redundancyMode {
+	case RedundancyModeSingle, RedundancyModeDouble, RedundancyModeTriple, RedundancyModeUnset:
+	case RedundancyModeThreeDataHall, RedundancyModeOneSatelliteSingle, RedundancyModeOneSatelliteDouble:
+		return nil
+	default:
+		return []string{fmt.Sprintf("redundancy mode %s is not supported", redundancyMode)}
+	}
 
-// connectionStringPattern provides a regular expression for parsing the
-// connection string.
-var connectionStringPattern = regexp.MustCompile("(?m)^([^#][^:@]+):([^:@]+)@(.*)$")
+	if cluster.Spec.FaultDomain.Key == "foundationdb.org/kubernetes-cluster" {
+		return nil
+	}
 
-// ConnectionString models the contents of a cluster file in a structured way
-type ConnectionString struct {
-	// DatabaseName provides an identifier for the database which persists
-	// across coordinator changes.
-	DatabaseName string `json:"databaseName,omitempty"`
+	storageCount := cluster.Spec.ProcessCounts.Storage
+	if storageCount > 0 && storageCount < cluster.MinimumFaultDomains() {
+		return []string{fmt.Sprintf("processCounts.storage %d must be at least %d for redundancy mode %s", storageCount, cluster.MinimumFaultDomains(), redundancyMode)}
+	}
 
-	// GenerationID provides a unique ID for the current generation of
-	// coordinators.
-	GenerationID string `json:"generationID,omitempty"`
+	return nil
+}
 
-	// Coordinators provides the addresses of the current coordinators.
-	Coordinators []string `json:"coordinators,omitempty"`
+// validateFaultDomain checks that the fault domain key can be used as topology key and that the kubernetes-cluster
+// fault domain strategy has a valid zone.
+func (cluster *FoundationDBCluster) validateFaultDomain() []string {
+	faultDomain := cluster.Spec.FaultDomain
+	if faultDomain.Key == "" || faultDomain.Key == NoneFaultDomainKey {
+		return nil
+	}
+
+	if faultDomain.Key == "foundationdb.org/kubernetes-cluster" {
+		var validations []string
+		if faultDomain.Value == "" {
+			validations = append(validations, "faultDomain.value must be set for the foundationdb.org/kubernetes-cluster fault domain")
+		}
+
+		if faultDomain.ZoneCount > 0 && (faultDomain.ZoneIndex < 0 || faultDomain.ZoneIndex >= faultDomain.ZoneCount) {
+			validations = append(validations, fmt.Sprintf("faultDomain.zoneIndex %d must be between 0 and %d", faultDomain.ZoneIndex, faultDomain.ZoneCount-1))
+		}
+
+		return validations
+	}
+
+	if len(validation.IsQualifiedName(faultDomain.Key)) > 0 {
+		return []string{fmt.Sprintf("faultDomain.key %s is not a valid label key", faultDomain.Key)}
+	}
+
+	return nil
 }
 
-// ParseConnectionString parses a connection string from its string
-// representation
-func ParseConnectionString(str string) (ConnectionString, error) {
-	components := connectionStringPattern.FindStringSubmatch(str)
-	if components == nil {
-		return ConnectionString{}, fmt.Errorf("invalid connection string %s", str)
+// validateProcessGroupIDAllocation checks that the ZoneEncoded allocation strategy has unique and non-empty zones.
+func (cluster *FoundationDBCluster) validateProcessGroupIDAllocation() []string {
+	if cluster.GetProcessGroupIDAllocationStrategy() != ProcessGroupIDAllocationZoneEncoded {
+		return nil
 	}
 
-	coordinatorsStrings := strings.Split(components[3], ",")
-	coordinators := make([]string, len(coordinatorsStrings))
-	for idx, coordinatorsString := range coordinatorsStrings {
-		coordinatorAddress, err := ParseProcessAddress(coordinatorsString)
-		if err != nil {
-			return ConnectionString{}, err
+	zones := cluster.GetProcessGroupIDZones()
+	if len(zones) == 0 {
+		return []string{"the ZoneEncoded process group ID allocation requires at least one zone"}
+	}
+
+	var validations []string
+	seen := make(map[string]None, len(zones))
+	for _, zone := range zones {
+		if zone == "" {
+			validations = append(validations, "the zones of the ZoneEncoded process group ID allocation must not be empty")
+			continue
 		}
 
-		coordinators[idx] = coordinatorAddress.String()
+		if _, ok := seen[zone]; ok {
+			validations = append(validations, fmt.Sprintf("zone %s is defined multiple times for the ZoneEncoded process group ID allocation", zone))
+		}
+		seen[zone] = None{}
 	}
 
-	return ConnectionString{
-		components[1],
-		components[2],
-		coordinators,
-	}, nil
+	return validations
 }
 
-// String formats a connection string as a string
-func (str *ConnectionString) String() string {
-	return fmt.Sprintf("%s:%s@%s", str.DatabaseName, str.GenerationID, strings.Join(str.Coordinators, ","))
-}
+// validateNodeVersionSkew checks that the minimum versions of the nodes can be parsed.
+func (cluster *FoundationDBCluster) validateNodeVersionSkew() []string {
+	options := cluster.Spec.AutomationOptions.Replacements.NodeVersionSkew
+	if options == nil {
+		return nil
+	}
 
-// GenerateNewGenerationID builds a new generation ID
-func (str *ConnectionString) GenerateNewGenerationID() error {
-	id := strings.Builder{}
-	for i := 0; i < 32; i++ {
-		err := id.WriteByte(alphanum[rand.Intn(len(alphanum))])
+	var validations []string
+	for name, minimumVersion := range map[string]string{
+		"minimumKubeletVersion": options.MinimumKubeletVersion,
+		"minimumKernelVersion":  options.MinimumKernelVersion,
+		"minimumLabelVersion":   options.MinimumLabelVersion,
+	} {
+		if minimumVersion == "" {
+			continue
+		}
+
+		_, err := utilversion.ParseGeneric(minimumVersion)
 		if err != nil {
-			return err
+			validations = append(validations, fmt.Sprintf("nodeVersionSkew %s %s is not a valid version", name, minimumVersion))
 		}
 	}
-	str.GenerationID = id.String()
-	return nil
-}
 
-// GetFullAddress gets the full public address we should use for a process.
-// This will include the IP address, the port, and any additional flags.
-func (cluster *FoundationDBCluster) GetFullAddress(address string, processNumber int) ProcessAddress {
-	addresses := cluster.GetFullAddressList(address, true, processNumber)
-	if len(addresses) < 1 {
-		return ProcessAddress{}
+	if options.MinimumLabelVersion != "" && options.VersionLabel == "" {
+		validations = append(validations, "nodeVersionSkew minimumLabelVersion requires a versionLabel")
 	}
 
-	// First element will always be the primary
-	return addresses[0]
+	sort.Strings(validations)
+
+	return validations
 }
 
-// GetFullAddressList gets the full list of public addresses we should use for a
-// process.
-//
-// This will include the IP address, the port, and any additional flags.
-//
-// If a process needs multiple addresses, this will include all of them,
-// separated by commas. If you pass false for primaryOnly, this will return only
-// the primary address.
-func (cluster *FoundationDBCluster) GetFullAddressList(address string, primaryOnly bool, processNumber int) []ProcessAddress {
-	return GetFullAddressList(
-		address,
-		primaryOnly,
-		processNumber,
-		cluster.Status.RequiredAddresses.TLS,
-		cluster.Status.RequiredAddresses.NonTLS)
+// GetMaxStandbyProcessGroups returns the cluster setting for MaxStandbyProcessGroups, defaults to 0 if unset.
+func (cluster *FoundationDBCluster) GetMaxStandbyProcessGroups() int {
+	return pointer.IntDeref(cluster.Spec.AutomationOptions.Replacements.MaxStandbyProcessGroups, 0)
 }
 
-// HasCoordinators checks whether this connection string matches a set of
-// coordinators.
-func (str *ConnectionString) HasCoordinators(coordinators []ProcessAddress) bool {
-	matchedCoordinators := make(map[string]bool, len(str.Coordinators))
-	for _, address := range str.Coordinators {
-		matchedCoordinators[address] = false
+// GetMaxConcurrentAutomaticReplacements returns the cluster setting for MaxConcurrentReplacements, defaults to 1 if unset.
+func (cluster *FoundationDBCluster) GetMaxConcurrentAutomaticReplacements() int {
+	return pointer.IntDeref(cluster.Spec.AutomationOptions.Replacements.MaxConcurrentReplacements, 1)
+}
+
+// FaultDomainBasedReplacements returns true if the operator is allowed to replace all failed process groups of a
+// fault domain. Default is false
+func (cluster *FoundationDBCluster) FaultDomainBasedReplacements() bool {
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.Replacements.FaultDomainBasedReplacements, false)
+}
+
+// CoordinatorSelectionSetting defines the process class and the priority of it.
+// A higher priority means that the process class is preferred over another.
+type CoordinatorSelectionSetting struct {
+	// ProcessClass defines the process class to associate with priority with.
+	ProcessClass ProcessClass `json:"processClass,omitempty"`
+	// Priority defines the ordering of different process classes.
+	Priority int `json:"priority,omitempty"`
+}
+
+// IsEligibleAsCandidate checks if the given process has the right process class to be considered a valid coordinator.
+// This method will always return false for non stateful process classes.
+func (cluster *FoundationDBCluster) IsEligibleAsCandidate(pClass ProcessClass) bool {
+	if !pClass.IsStateful() {
+		return false
 	}
 
-	for _, pAddr := range coordinators {
-		address := pAddr.String()
-		_, matched := matchedCoordinators[address]
-		if matched {
-			matchedCoordinators[address] = true
-		} else {
-			return false
-		}
+	if len(cluster.Spec.CoordinatorSelection) == 0 {
+		return pClass.IsStateful()
 	}
 
-	for _, matched := range matchedCoordinators {
-		if !matched {
-			return false
+	for _, setting := range cluster.Spec.CoordinatorSelection {
+		if pClass == setting.ProcessClass {
+			return true
 		}
 	}
 
-	return true
+	return false
 }
 
-// FoundationDBClusterFaultDomain describes the fault domain that a cluster is
-// replicated across.
-type FoundationDBClusterFaultDomain struct {
-	// Key provides a topology key for the fault domain to replicate across.
-	Key string `json:"key,omitempty"`
+// GetEligibleCandidateClasses returns process classes that are eligible to become coordinators.
+func (cluster *FoundationDBCluster) GetEligibleCandidateClasses() []ProcessClass {
+	candidateClasses := []ProcessClass{}
 
-	// Value provides a harcoded value to use for the zoneid for the pods.
-	Value string `json:"value,omitempty"`
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if cluster.IsEligibleAsCandidate(processGroup.ProcessClass) {
+			candidateClasses = append(candidateClasses, processGroup.ProcessClass)
+		}
+	}
 
-	// ValueFrom provides a field selector to use as the source of the fault
-	// domain.
-	ValueFrom string `json:"valueFrom,omitempty"`
+	return candidateClasses
+}
 
-	// ZoneCount provides the number of fault domains in the data center where
-	// these processes are running. This is only used in the
-	// `kubernetes-cluster` fault domain strategy.
-	ZoneCount int `json:"zoneCount,omitempty"`
+// GetClassCandidatePriority returns the priority for a class. This will be used to sort the processes for coordinator selection.
+// If not setting is configured, the default will be 0.
+func (cluster *FoundationDBCluster) GetClassCandidatePriority(pClass ProcessClass) int {
+	for _, setting := range cluster.Spec.CoordinatorSelection {
+		if pClass == setting.ProcessClass {
+			return setting.Priority
+		}
+	}
 
-	// ZoneIndex provides the index of this Kubernetes cluster in the list of
-	// KCs in the data center. This is only used in the `kubernetes-cluster`
-	// fault domain strategy.
-	ZoneIndex int `json:"zoneIndex,omitempty"`
+	// For coordinators prefer log processes over storage processes, as those get migrated anyway during an upgrade.
+	if pClass.IsLogProcess() {
+		return 1
+	}
+
+	return 0
 }
 
-// ContainerOverrides provides options for customizing a container created by
-// the operator.
-type ContainerOverrides struct {
-	// EnableLivenessProbe defines if the sidecar should have a livenessProbe.
-	// This setting will be ignored on the main container.
-	EnableLivenessProbe *bool `json:"enableLivenessProbe,omitempty"`
+// ShouldFilterOnOwnerReferences determines if we should check owner references
+// when determining if a resource is related to this cluster.
+func (cluster *FoundationDBCluster) ShouldFilterOnOwnerReferences() bool {
+	return pointer.BoolDeref(cluster.Spec.LabelConfig.FilterOnOwnerReferences, false)
+}
 
-	// EnableReadinessProbe defines if the sidecar should have a readinessProbe.
-	// This setting will be ignored on the main container.
-	// Deprecated: Will be removed in the next major release.
-	EnableReadinessProbe *bool `json:"enableReadinessProbe,omitempty"`
+// ShouldClaimResources determines if the operator should add the ClusterClaimAnnotation to the Pods and PVCs of
+// this cluster.
+func (cluster *FoundationDBCluster) ShouldClaimResources() bool {
+	return pointer.BoolDeref(cluster.Spec.LabelConfig.ClaimResources, false)
+}
 
-	// EnableTLS controls whether we should be listening on a TLS connection.
-	EnableTLS bool `json:"enableTls,omitempty"`
+// ResourceOwnership describes the relationship between a resource and a cluster.
+type ResourceOwnership string
 
-	// PeerVerificationRules provides the rules for what client certificates
-	// the process should accept.
-	// +kubebuilder:validation:MaxLength=10000
-	PeerVerificationRules string `json:"peerVerificationRules,omitempty"`
+const (
+	// ResourceOwned represents a resource that is managed by the cluster.
+	ResourceOwned ResourceOwnership = "Owned"
 
-	// ImageConfigs allows customizing the image that we use for
-	// a container.
-	// +kubebuilder:validation:MaxItems=100
-	ImageConfigs []ImageConfig `json:"imageConfigs,omitempty"`
-}
+	// ResourceNotOwned represents a resource that is not managed by the cluster.
+	ResourceNotOwned ResourceOwnership = "NotOwned"
 
-// DesiredDatabaseConfiguration builds the database configuration for the
-// cluster based on its spec.
-func (cluster *FoundationDBCluster) DesiredDatabaseConfiguration() DatabaseConfiguration {
-	configuration := cluster.Spec.DatabaseConfiguration.NormalizeConfigurationWithSeparatedProxies(cluster.GetRunningVersion(), cluster.Spec.DatabaseConfiguration.AreSeparatedProxiesConfigured())
-	configuration.RoleCounts = cluster.GetRoleCountsWithDefaults()
-	configuration.RoleCounts.Storage = 0
+	// ResourceConflict represents a resource that matches the labels of the cluster but is claimed by a different
+	// cluster, either through an owner reference or through the ClusterClaimAnnotation.
+	ResourceConflict ResourceOwnership = "Conflict"
+)
 
-	version, _ := ParseFdbVersion(cluster.GetRunningVersion())
-	if version.HasSeparatedProxies() && cluster.Spec.DatabaseConfiguration.AreSeparatedProxiesConfigured() {
-		configuration.RoleCounts.Proxies = 0
-	} else {
-		configuration.RoleCounts.GrvProxies = 0
-		configuration.RoleCounts.CommitProxies = 0
+// GetResourceOwnership returns the ResourceOwnership of a resource that matches the labels of this cluster. A resource
+// is owned if it has an owner reference to this cluster. If the operator should not filter on owner references, a
+// resource without a claim and without an owner reference to another cluster will be owned by this cluster.
+func (cluster *FoundationDBCluster) GetResourceOwnership(metadata metav1.ObjectMeta) ResourceOwnership {
+	for _, reference := range metadata.OwnerReferences {
+		if reference.UID == cluster.UID {
+			return ResourceOwned
+		}
+
+		if reference.Kind == "FoundationDBCluster" && reference.Name != cluster.Name {
+			return ResourceConflict
+		}
 	}
 
-	if configuration.StorageEngine == StorageEngineSSD {
-		configuration.StorageEngine = StorageEngineSSD2
+	if cluster.ShouldFilterOnOwnerReferences() {
+		return ResourceNotOwned
 	}
-	if configuration.StorageEngine == StorageEngineMemory {
-		configuration.StorageEngine = StorageEngineMemory2
+
+	claim, ok := metadata.Annotations[ClusterClaimAnnotation]
+	if ok && claim != cluster.Name {
+		return ResourceConflict
 	}
 
-	return configuration
+	return ResourceOwned
 }
 
-// ClearMissingVersionFlags clears any version flags in the given configuration that are not
-// set in the configuration in the cluster spec.
-//
-// This allows us to compare the spec to the live configuration while ignoring
-// version flags that are unset in the spec.
-func (cluster *FoundationDBCluster) ClearMissingVersionFlags(configuration *DatabaseConfiguration) {
-	if cluster.Spec.DatabaseConfiguration.LogVersion == 0 {
-		configuration.LogVersion = 0
+// SkipProcessGroup checks if a ProcessGroupStatus should be skipped during reconciliation.
+func (cluster *FoundationDBCluster) SkipProcessGroup(processGroup *ProcessGroupStatus) bool {
+	if processGroup == nil {
+		return true
 	}
-	if cluster.Spec.DatabaseConfiguration.LogSpill == 0 {
-		configuration.LogSpill = 0
+
+	pendingTime := processGroup.GetConditionTime(PodPending)
+	if pendingTime == nil {
+		return false
 	}
-}
 
-// IsBeingUpgraded determines whether the cluster has a pending upgrade.
-func (cluster *FoundationDBCluster) IsBeingUpgraded() bool {
-	return cluster.Status.RunningVersion != "" && cluster.Status.RunningVersion != cluster.Spec.Version
+	return time.Unix(*pendingTime, 0).Add(cluster.GetIgnorePendingPodsDuration()).Before(time.Now())
 }
 
-// IsBeingUpgradedWithVersionIncompatibleVersion determines whether the cluster has a pending upgrade to a version incompatible version.
-func (cluster *FoundationDBCluster) IsBeingUpgradedWithVersionIncompatibleVersion() bool {
-	if !cluster.IsBeingUpgraded() {
-		return false
+// GetIgnorePendingPodsDuration returns the value of IgnorePendingPodsDuration or 5 minutes if unset.
+func (cluster *FoundationDBCluster) GetIgnorePendingPodsDuration() time.Duration {
+	if cluster.Spec.AutomationOptions.IgnorePendingPodsDuration == 0 {
+		return 5 * time.Minute
 	}
 
-	runningVersion, _ := ParseFdbVersion(cluster.Status.RunningVersion)
-	desiredVersion, _ := ParseFdbVersion(cluster.Spec.Version)
+	return cluster.Spec.AutomationOptions.IgnorePendingPodsDuration
+}
 
-	return !runningVersion.IsProtocolCompatible(desiredVersion)
+// GetIgnoreMissingProcessesSeconds returns the value of IgnoreMissingProcessesSecond or 30 seconds if unset.
+func (cluster *FoundationDBCluster) GetIgnoreMissingProcessesSeconds() time.Duration {
+	return time.Duration(pointer.IntDeref(cluster.Spec.AutomationOptions.IgnoreMissingProcessesSeconds, 30)) * time.Second
+}
+
+// GetFailedPodDuration returns the value of FailedPodDuration or 5 minutes if unset.
+func (cluster *FoundationDBCluster) GetFailedPodDuration() time.Duration {
+	return time.Duration(pointer.IntDeref(cluster.Spec.AutomationOptions.FailedPodDurationSeconds, 300)) * time.Second
+}
+
+// GetUseNonBlockingExcludes returns the value of useNonBlockingExcludes or false if unset.
+func (cluster *FoundationDBCluster) GetUseNonBlockingExcludes() bool {
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.UseNonBlockingExcludes, false)
 }
 
-// VersionCompatibleUpgradeInProgress returns true if the cluster is currently being upgraded and the upgrade is to
-// a version compatible version.
-func (cluster *FoundationDBCluster) VersionCompatibleUpgradeInProgress() bool {
-	if !cluster.IsBeingUpgraded() {
+// UseLocalitiesForExclusion returns the value of UseLocalitiesForExclusion or false if unset.
+func (cluster *FoundationDBCluster) UseLocalitiesForExclusion() bool {
+	fdbVersion, err := ParseFdbVersion(cluster.GetRunningVersion())
+	if err != nil {
+		// Fall back to use exclusions with IP if we can't parse the version.
+		// This should never happen since the version is validated in earlier steps.
 		return false
 	}
 
-	runningVersion, _ := ParseFdbVersion(cluster.Status.RunningVersion)
-	desiredVersion, _ := ParseFdbVersion(cluster.Spec.Version)
-
-	return runningVersion.IsProtocolCompatible(desiredVersion)
+	return fdbVersion.SupportsLocalityBasedExclusions() && pointer.BoolDeref(cluster.Spec.AutomationOptions.UseLocalitiesForExclusion, false)
 }
 
-// ProcessGroupIsBeingRemoved determines if an instance is pending removal.
-func (cluster *FoundationDBCluster) ProcessGroupIsBeingRemoved(processGroupID ProcessGroupID) bool {
-	if processGroupID == "" {
+// UseProcessClassReassignment returns the value of UseProcessClassReassignment or false if unset. If the PodUpdateStrategy
+// is set to Replacement, process groups will always be replaced and this method returns false.
+func (cluster *FoundationDBCluster) UseProcessClassReassignment() bool {
+	if cluster.Spec.AutomationOptions.PodUpdateStrategy == PodUpdateStrategyReplacement {
 		return false
 	}
 
-	for _, status := range cluster.Status.ProcessGroups {
-		if status.ProcessGroupID == processGroupID && status.IsMarkedForRemoval() {
-			return true
-		}
-	}
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.UseProcessClassReassignment, false)
+}
 
-	for _, id := range cluster.Spec.ProcessGroupsToRemove {
-		if id == processGroupID {
-			return true
-		}
+// UseCompactProcessGroupIDs returns the value of UseCompactProcessGroupIDs or false if unset.
+func (cluster *FoundationDBCluster) UseCompactProcessGroupIDs() bool {
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.UseCompactProcessGroupIDs, false)
+}
+
+// GetProcessGroupIDAllocationStrategy returns the strategy to allocate the ID numbers of new process groups. If no
+// strategy is defined, Dense will be returned if UseCompactProcessGroupIDs is enabled, otherwise Random.
+func (cluster *FoundationDBCluster) GetProcessGroupIDAllocationStrategy() ProcessGroupIDAllocationStrategy {
+	options := cluster.Spec.AutomationOptions.ProcessGroupIDAllocation
+	if options != nil && options.Strategy != nil {
+		return *options.Strategy
 	}
 
-	for _, id := range cluster.Spec.ProcessGroupsToRemoveWithoutExclusion {
-		if id == processGroupID {
-			return true
-		}
+	if cluster.UseCompactProcessGroupIDs() {
+		return ProcessGroupIDAllocationDense
 	}
 
-	return false
+	return ProcessGroupIDAllocationRandom
 }
 
-// ShouldUseLocks determine whether we should use locks to coordinator global
-// operations.
-func (cluster *FoundationDBCluster) ShouldUseLocks() bool {
-	disabled := cluster.Spec.LockOptions.DisableLocks
-	if disabled != nil {
-		return !*disabled
+// GetProcessGroupIDZones returns the zones that are encoded in the ID numbers if the ZoneEncoded strategy is used.
+func (cluster *FoundationDBCluster) GetProcessGroupIDZones() []string {
+	if cluster.Spec.AutomationOptions.ProcessGroupIDAllocation == nil {
+		return nil
 	}
 
-	return cluster.Spec.FaultDomain.ZoneCount > 1 || len(cluster.Spec.DatabaseConfiguration.Regions) > 1 ||
-		cluster.Spec.DatabaseConfiguration.RedundancyMode == RedundancyModeThreeDataHall
+	return cluster.Spec.AutomationOptions.ProcessGroupIDAllocation.Zones
 }
 
-// GetLockPrefix gets the prefix for the keys where we store locking
-// information.
-func (cluster *FoundationDBCluster) GetLockPrefix() string {
-	if cluster.Spec.LockOptions.LockKeyPrefix != "" {
-		return cluster.Spec.LockOptions.LockKeyPrefix
+// GetProcessGroupIDZoneLabel returns the node label that is used to schedule the Pods of zone encoded process groups.
+// The default is topology.kubernetes.io/zone.
+func (cluster *FoundationDBCluster) GetProcessGroupIDZoneLabel() string {
+	if cluster.Spec.AutomationOptions.ProcessGroupIDAllocation == nil {
+		return corev1.LabelTopologyZone
 	}
 
-	return "\xff\x02/org.foundationdb.kubernetes-operator"
+	return pointer.StringDeref(cluster.Spec.AutomationOptions.ProcessGroupIDAllocation.ZoneLabel, corev1.LabelTopologyZone)
 }
 
-// GetMaintenancePrefix returns the prefix that is used by the operator to store and read maintenance related information.
-// The prefix will be the provided GetLockPrefix appended by "maintenance".
-func (cluster *FoundationDBCluster) GetMaintenancePrefix() string {
-	return fmt.Sprintf("%s/maintenance", cluster.GetLockPrefix())
+// CleanupStaleExclusions returns the value of CleanupStaleExclusions or false if unset.
+func (cluster *FoundationDBCluster) CleanupStaleExclusions() bool {
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.CleanupStaleExclusions, false)
 }
 
-// GetLockDuration determines how long we hold locks for.
-func (cluster *FoundationDBCluster) GetLockDuration() time.Duration {
-	minutes := 10
-	if cluster.Spec.LockOptions.LockDurationMinutes != nil {
-		minutes = *cluster.Spec.LockOptions.LockDurationMinutes
-	}
-	return time.Duration(minutes) * time.Minute
+// UseOrchestratedImageTypeMigration returns the value of UseOrchestratedImageTypeMigration or false if unset.
+func (cluster *FoundationDBCluster) UseOrchestratedImageTypeMigration() bool {
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.UseOrchestratedImageTypeMigration, false)
 }
 
-// GetLockID gets the identifier for this instance of the operator when taking
-// locks. This is the `ProcessGroupIDPrefix` defined for this cluster.
-func (cluster *FoundationDBCluster) GetLockID() string {
-	return cluster.Spec.ProcessGroupIDPrefix
+// MigratePodSpecHashes returns the value of MigratePodSpecHashes or false if unset.
+func (cluster *FoundationDBCluster) MigratePodSpecHashes() bool {
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.MigratePodSpecHashes, false)
 }
 
-// NeedsExplicitListenAddress determines whether we pass a listen address
-// parameter to fdbserver.
-func (cluster *FoundationDBCluster) NeedsExplicitListenAddress() bool {
-	return cluster.GetPublicIPSource() == PublicIPSourceService || cluster.GetUseExplicitListenAddress()
-}
+// AddActiveOperation adds an operation to the active operations in the status and returns the ID of the operation. If an
+// operation with the same type, owner and process groups is already active, the ID of the existing operation will be
+// returned.
+func (cluster *FoundationDBCluster) AddActiveOperation(operationType ActiveOperationType, owner string, processGroups []ProcessGroupID) string {
+	sortedProcessGroups := make([]ProcessGroupID, len(processGroups))
+	copy(sortedProcessGroups, processGroups)
+	sort.Slice(sortedProcessGroups, func(i, j int) bool {
+		return sortedProcessGroups[i] < sortedProcessGroups[j]
+	})
 
-// GetPublicIPSource returns the set PublicIPSource or the default PublicIPSourcePod
-func (cluster *FoundationDBCluster) GetPublicIPSource() PublicIPSource {
-	source := cluster.Spec.Routing.PublicIPSource
-	if source == nil {
-		return PublicIPSourcePod
+	if len(sortedProcessGroups) == 0 {
+		sortedProcessGroups = nil
 	}
 
-	return *source
-}
+	for _, operation := range cluster.Status.ActiveOperations {
+		if operation.Type == operationType && operation.Owner == owner && equality.Semantic.DeepEqual(operation.ProcessGroups, sortedProcessGroups) {
+			return operation.ID
+		}
+	}
 
-// LockOptions provides customization for locking global operations.
-type LockOptions struct {
-	// DisableLocks determines whether we should disable locking entirely.
-	DisableLocks *bool `json:"disableLocks,omitempty"`
+	now := time.Now()
+	id := fmt.Sprintf("%s-%d", strings.ToLower(string(operationType)), now.UnixNano())
+	cluster.Status.ActiveOperations = append(cluster.Status.ActiveOperations, ActiveOperation{
+		ID:             id,
+		Type:           operationType,
+		Owner:          owner,
+		StartTimestamp: now.Unix(),
+		ProcessGroups:  sortedProcessGroups,
+	})
 
-	// LockKeyPrefix provides a custom prefix for the keys in the database we
-	// use to store locks.
-	LockKeyPrefix string `json:"lockKeyPrefix,omitempty"`
+	return id
+}
 
-	// LockDurationMinutes determines the duration that locks should be valid
-	// for.
-	LockDurationMinutes *int `json:"lockDurationMinutes,omitempty"`
+// GetActiveOperations returns the active operations of the provided types. If no type is provided all active
+// operations will be returned.
+func (cluster *FoundationDBCluster) GetActiveOperations(operationTypes ...ActiveOperationType) []ActiveOperation {
+	if len(operationTypes) == 0 {
+		return cluster.Status.ActiveOperations
+	}
 
-	// DenyList manages configuration for whether an instance of the operator
-	// should be denied from taking locks.
-	DenyList []LockDenyListEntry `json:"denyList,omitempty"`
+	operations := make([]ActiveOperation, 0, len(cluster.Status.ActiveOperations))
+	for _, operation := range cluster.Status.ActiveOperations {
+		for _, operationType := range operationTypes {
+			if operation.Type == operationType {
+				operations = append(operations, operation)
+				break
+			}
+		}
+	}
+
+	return operations
 }
 
-// LockDenyListEntry models an entry in the deny list for the locking system.
-type LockDenyListEntry struct {
-	// The ID of the operator instance this entry is targeting.
-	ID string `json:"id,omitempty"`
+// UseKnobHotReload returns the value of UseKnobHotReload or false if unset.
+func (cluster *FoundationDBCluster) UseKnobHotReload() bool {
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.UseKnobHotReload, false)
+}
 
-	// Whether the instance is allowed to take locks.
-	Allow bool `json:"allow,omitempty"`
+// DeriveMemoryKnobs returns the value of DeriveMemoryKnobs or false if unset.
+func (cluster *FoundationDBCluster) DeriveMemoryKnobs() bool {
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.DeriveMemoryKnobs, false)
 }
 
-// RoutingConfig allows configuring routing to our pods, and services that sit
-// in front of them.
-type RoutingConfig struct {
-	// Headless determines whether we want to run a headless service for the
-	// cluster.
-	HeadlessService *bool `json:"headlessService,omitempty"`
+// UseMemoryOvercommitProtection returns true if the operator should check that new Pods don't overcommit the memory
+// of the nodes.
+func (cluster *FoundationDBCluster) UseMemoryOvercommitProtection() bool {
+	return cluster.Spec.AutomationOptions.MemoryOvercommitProtection != nil
+}
 
-	// PublicIPSource specifies what source a process should use to get its
-	// public IPs.
-	//
-	// This supports the values `pod` and `service`.
-	PublicIPSource *PublicIPSource `json:"publicIPSource,omitempty"`
+// GetMemoryOvercommitRatioPercentage returns the percentage of the allocatable memory of a node that can be committed
+// to FoundationDB Pods, defaults to 100.
+func (cluster *FoundationDBCluster) GetMemoryOvercommitRatioPercentage() int {
+	if cluster.Spec.AutomationOptions.MemoryOvercommitProtection == nil {
+		return 100
+	}
 
-	// PodIPFamily tells the pod which family of IP addresses to use.
-	// You can use 4 to represent IPv4, and 6 to represent IPv6.
-	// This feature is only supported in FDB 7.0 or later, and requires
-	// dual-stack support in your Kubernetes environment.
-	PodIPFamily *int `json:"podIPFamily,omitempty"`
+	return pointer.IntDeref(cluster.Spec.AutomationOptions.MemoryOvercommitProtection.OvercommitRatioPercentage, 100)
+}
 
-	// UseDNSInClusterFile determines whether to use DNS names rather than IP
-	// addresses to identify coordinators in the cluster file. This requires
-	// FoundationDB 7.0+.
-	UseDNSInClusterFile *bool `json:"useDNSInClusterFile,omitempty"`
+// SkipProcessGroupForImageTypeMigration returns true if the process group should not be updated or replaced, because an
+// orchestrated image type migration is in progress, the process group is running with the provided image type that
+// must be migrated and the process group is not part of the fault domain that is currently migrated. Process groups
+// that are already running with the target image type are not affected by the migration.
+func (cluster *FoundationDBCluster) SkipProcessGroupForImageTypeMigration(processGroup *ProcessGroupStatus, imageType ImageType) bool {
+	migration := cluster.Status.ImageTypeMigration
+	if !cluster.UseOrchestratedImageTypeMigration() || migration == nil {
+		return false
+	}
 
-	// DefineDNSLocalityFields determines whether to define pod DNS names on pod
-	// specs and provide them in the locality arguments to fdbserver.
-	//
-	// This is ignored if UseDNSInCluster is true.
-	DefineDNSLocalityFields *bool `json:"defineDNSLocalityFields,omitempty"`
+	if imageType == migration.TargetImageType {
+		return false
+	}
 
-	// DNSDomain defines the cluster domain used in a DNS name generated for a
-	// service.
-	// The default is `cluster.local`.
-	// +kubebuilder:validation:MinLength=1
-	// +kubebuilder:validation:MaxLength=253
-	DNSDomain *string `json:"dnsDomain,omitempty"`
+	return migration.FaultDomain == "" || processGroup.GetImageTypeMigrationFaultDomain() != migration.FaultDomain
 }
 
-// RequiredAddressSet provides settings for which addresses we need to listen
-// on.
-type RequiredAddressSet struct {
-	// TLS defines whether we need to listen on a TLS address.
-	TLS bool `json:"tls,omitempty"`
+// UseOnlinePVCExpansion returns true if the PVCs should be expanded in place if only the storage request was
+// increased. Default is false.
+func (cluster *FoundationDBCluster) UseOnlinePVCExpansion() bool {
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.UseOnlinePVCExpansion, false)
+}
 
-	// NonTLS defines whether we need to listen on a non-TLS address.
-	NonTLS bool `json:"nonTLS,omitempty"`
+// ShouldVerifyProcessRemoval returns true if the operator should verify that the processes of removed process groups
+// are no longer reporting to the database. Default is false.
+func (cluster *FoundationDBCluster) ShouldVerifyProcessRemoval() bool {
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.VerifyProcessRemoval, false)
 }
 
-// CrashLoopContainerObject specifies crash-loop target for specific container.
-type CrashLoopContainerObject struct {
-	// Name of the target container.
-	// +kubebuilder:validation:MinLength=1
-	// +kubebuilder:validation:MaxLength=253
-	ContainerName string `json:"containerName,omitempty"`
+// DetectStuckPods returns true if process groups with Pods that are stuck should get the PodStuck condition and should
+// be replaced after the FailureDetectionWindowSeconds. Default is false.
+func (cluster *FoundationDBCluster) DetectStuckPods() bool {
+	return cluster.Spec.AutomationOptions.Replacements.FailureDetectionWindowSeconds != nil
+}
 
-	// Target processes to kill inside the container.
-	// +kubebuilder:validation:MinItems=0
-	// +kubebuilder:validation:MaxItems=10000
-	Targets []ProcessGroupID `json:"targets,omitempty"`
+// GetFailureDetectionWindowSeconds returns the time in seconds a Pod must be stuck before the process group is
+// replaced. Default is 0.
+func (cluster *FoundationDBCluster) GetFailureDetectionWindowSeconds() int {
+	return pointer.IntDeref(cluster.Spec.AutomationOptions.Replacements.FailureDetectionWindowSeconds, 0)
 }
 
-// BuggifyConfig provides options for injecting faults into a cluster for testing.
-type BuggifyConfig struct {
-	// NoSchedule defines a list of process group IDs that should fail to schedule.
-	NoSchedule []ProcessGroupID `json:"noSchedule,omitempty"`
+// CancelReplacementsOnRecovery returns true if the removal of failed process groups should be canceled if the process
+// group recovers. Default is false.
+func (cluster *FoundationDBCluster) CancelReplacementsOnRecovery() bool {
+	if cluster.Spec.AutomationOptions.Replacements.CancelOnRecovery == nil {
+		return false
+	}
 
-	// CrashLoops defines a list of process group IDs that should be put into a
-	// crash looping state.
-	// Deprecated: use CrashLoopContainers instead.
-	CrashLoop []ProcessGroupID `json:"crashLoop,omitempty"`
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.Replacements.CancelOnRecovery.Enabled, false)
+}
 
-	// CrashLoopContainers defines a list of process group IDs and containers
-	// that should be put into a crash looping state.
-	// +kubebuilder:validation:MinItems=0
-	// +kubebuilder:validation:MaxItems=8
-	CrashLoopContainers []CrashLoopContainerObject `json:"crashLoopContainers,omitempty"`
+// GetCancelOnRecoveryGracePeriodSeconds returns the time window in seconds after a process group was marked for
+// removal in which the removal can be canceled if the process group recovers. Default is 600.
+func (cluster *FoundationDBCluster) GetCancelOnRecoveryGracePeriodSeconds() int {
+	if cluster.Spec.AutomationOptions.Replacements.CancelOnRecovery == nil {
+		return 600
+	}
 
-	// EmptyMonitorConf instructs the operator to update all of the fdbmonitor.conf
-	// files to have zero fdbserver processes configured.
-	EmptyMonitorConf bool `json:"emptyMonitorConf,omitempty"`
+	return pointer.IntDeref(cluster.Spec.AutomationOptions.Replacements.CancelOnRecovery.GracePeriodSeconds, 600)
+}
 
-	// IgnoreDuringRestart instructs the operator to ignore the provided process groups IDs during the
-	// restart command. This can be useful to simulate cases where the kill command is not restarting all
-	// processes. IgnoreDuringRestart does not support the wildcard option to ignore all of this specific cluster processes.
-	// +kubebuilder:validation:MaxItems=1000
-	IgnoreDuringRestart []ProcessGroupID `json:"ignoreDuringRestart,omitempty"`
+// DetectReplacementLoops returns true if the automatic replacements of failed process groups should be stopped if
+// a replacement loop is detected. Default is false.
+func (cluster *FoundationDBCluster) DetectReplacementLoops() bool {
+	loopDetection := cluster.Spec.AutomationOptions.Replacements.ReplacementLoopDetection
+	return loopDetection != nil && loopDetection.MaxReplacements != nil
+}
 
-	// BlockRemoval defines a list of process group IDs that will not be removed, even if they are marked for removal.
-	// The operator will trigger the exclusion but the removal of the resources will be blocked until they are removed
-	// from this list. This setting can be used to simulate cases where a process group is marked for removal but the
-	// resources are not yet removed.
-	// +kubebuilder:validation:MaxItems=1000
-	BlockRemoval []ProcessGroupID `json:"blockRemoval,omitempty"`
+// GetReplacementLoopMaxReplacements returns the number of failed process groups of a process class that can be
+// replaced within the replacement loop detection window. Default is 0.
+func (cluster *FoundationDBCluster) GetReplacementLoopMaxReplacements() int {
+	if cluster.Spec.AutomationOptions.Replacements.ReplacementLoopDetection == nil {
+		return 0
+	}
+
+	return pointer.IntDeref(cluster.Spec.AutomationOptions.Replacements.ReplacementLoopDetection.MaxReplacements, 0)
 }
 
-// LabelConfig allows customizing labels used by the operator.
-type LabelConfig struct {
-	// MatchLabels provides the labels that the operator should use to identify
-	// resources owned by the cluster. These will automatically be applied to
-	// all resources the operator creates.
-	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+// GetReplacementLoopWindowSeconds returns the window in seconds in which the replacements of failed process groups
+// are counted for the replacement loop detection. Default is 3600.
+func (cluster *FoundationDBCluster) GetReplacementLoopWindowSeconds() int {
+	if cluster.Spec.AutomationOptions.Replacements.ReplacementLoopDetection == nil {
+		return 3600
+	}
 
-	// ResourceLabels provides additional labels that the operator should apply to
-	// resources it creates.
-	ResourceLabels map[string]string `json:"resourceLabels,omitempty"`
+	return pointer.IntDeref(cluster.Spec.AutomationOptions.Replacements.ReplacementLoopDetection.WindowSeconds, 3600)
+}
 
-	// ProcessGroupIDLabels provides the labels that we use for the process group ID
-	// field. The first label will be used by the operator when filtering
-	// resources.
-	// +kubebuilder:validation:MaxItems=100
-	ProcessGroupIDLabels []string `json:"processGroupIDLabels,omitempty"`
+// GetReplacementLoopBackoff returns the minimum time between two replacements of failed process groups of the same
+// process class, after the provided number of replacements of this process class within the replacement loop detection
+// window. Default is 0.
+func (cluster *FoundationDBCluster) GetReplacementLoopBackoff(replacements int) time.Duration {
+	if cluster.Spec.AutomationOptions.Replacements.ReplacementLoopDetection == nil || replacements < 1 {
+		return 0
+	}
 
-	// ProcessClassLabels provides the labels that we use for the process class
-	// field. The first label will be used by the operator when filtering
-	// resources.
-	// +kubebuilder:validation:MaxItems=100
-	ProcessClassLabels []string `json:"processClassLabels,omitempty"`
+	backoff := time.Duration(pointer.IntDeref(cluster.Spec.AutomationOptions.Replacements.ReplacementLoopDetection.BackoffSeconds, 0)) * time.Second
+	window := time.Duration(cluster.GetReplacementLoopWindowSeconds()) * time.Second
+	for idx := 1; idx < replacements && backoff < window; idx++ {
+		backoff *= 2
+	}
 
-	// FilterOnOwnerReferences determines whether we should check that resources
-	// are owned by the cluster object, in addition to the constraints provided
-	// by the match labels.
-	// Deprecated: This setting will be removed in the next major release.
-	FilterOnOwnerReferences *bool `json:"filterOnOwnerReference,omitempty"`
+	if backoff > window {
+		return window
+	}
+
+	return backoff
 }
 
-// PublicIPSource models options for how a pod gets its public IP.
-type PublicIPSource string
+// GetReplacementLoopAcknowledgementTime returns the time of the last acknowledged replacement loop, based on the
+// foundationdb.org/acknowledge-replacement-loop annotation. If the annotation is missing or invalid, the zero time is
+// returned.
+func (cluster *FoundationDBCluster) GetReplacementLoopAcknowledgementTime() time.Time {
+	acknowledgement, ok := cluster.Annotations[ReplacementLoopAcknowledgementAnnotation]
+	if !ok {
+		return time.Time{}
+	}
 
-const (
-	// PublicIPSourcePod specifies that a pod gets its IP from the pod IP.
-	PublicIPSourcePod PublicIPSource = "pod"
+	timestamp, err := strconv.ParseInt(acknowledgement, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
 
-	// PublicIPSourceService specifies that a pod gets its IP from a service.
-	PublicIPSourceService PublicIPSource = "service"
-)
+	return time.Unix(timestamp, 0)
+}
 
-// AddServersPerDisk adds serverPerDisk to the status field to keep track which ConfigMaps should be kept
-func (clusterStatus *FoundationDBClusterStatus) AddServersPerDisk(serversPerDisk int, pClass ProcessClass) {
-	if pClass == ProcessClassStorage {
-		for _, curServersPerDisk := range clusterStatus.StorageServersPerDisk {
-			if curServersPerDisk == serversPerDisk {
-				return
-			}
+// ReplacementsBatchedByFaultDomain returns true if the replacements of misconfigured process groups should be limited
+// to a single fault domain at a time. Default is false.
+func (cluster *FoundationDBCluster) ReplacementsBatchedByFaultDomain() bool {
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.Replacements.BatchByFaultDomain, false)
+}
+
+// ReplacementsRespectPodDisruptionBudgets returns true if the replacements of misconfigured process groups should be
+// deferred if they would exceed the disruptions allowed by a PodDisruptionBudget. Default is false.
+func (cluster *FoundationDBCluster) ReplacementsRespectPodDisruptionBudgets() bool {
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.Replacements.RespectPodDisruptionBudgets, false)
+}
+
+// ReplacementsRequireFullReplication returns true if the replacements of misconfigured process groups should be
+// deferred while the database is unavailable or not fully replicated. Default is false.
+func (cluster *FoundationDBCluster) ReplacementsRequireFullReplication() bool {
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.Replacements.RequireFullReplication, false)
+}
+
+// ReplacementsDryRun returns true if the replacements of misconfigured process groups are running in dry-run mode.
+func (cluster *FoundationDBCluster) ReplacementsDryRun() bool {
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.Replacements.DryRun, false)
+}
+
+// ReplacementsRequireApproval returns true if the automatic replacements of process groups must be approved.
+// Default is false.
+func (cluster *FoundationDBCluster) ReplacementsRequireApproval() bool {
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.Replacements.RequireApproval, false)
+}
+
+// ReplacementApproved returns true if the replacement of the process group was approved, either in the
+// approvedReplacements of the cluster spec or in the foundationdb.org/approved-replacements annotation.
+func (cluster *FoundationDBCluster) ReplacementApproved(processGroupID ProcessGroupID) bool {
+	for _, approvedProcessGroupID := range cluster.Spec.ApprovedReplacements {
+		if approvedProcessGroupID == processGroupID {
+			return true
 		}
-		clusterStatus.StorageServersPerDisk = append(clusterStatus.StorageServersPerDisk, serversPerDisk)
-		return
 	}
 
-	if pClass.SupportsMultipleLogServers() {
-		for _, curServersPerDisk := range clusterStatus.LogServersPerDisk {
-			if curServersPerDisk == serversPerDisk {
-				return
-			}
+	for _, approvedProcessGroupID := range strings.Split(cluster.Annotations[ApprovedReplacementsAnnotation], ",") {
+		if ProcessGroupID(strings.TrimSpace(approvedProcessGroupID)) == processGroupID {
+			return true
 		}
-		clusterStatus.LogServersPerDisk = append(clusterStatus.LogServersPerDisk, serversPerDisk)
 	}
+
+	return false
 }
 
-// GetMaxConcurrentAutomaticReplacements returns the cluster setting for MaxConcurrentReplacements, defaults to 1 if unset.
-func (cluster *FoundationDBCluster) GetMaxConcurrentAutomaticReplacements() int {
-	return pointer.IntDeref(cluster.Spec.AutomationOptions.Replacements.MaxConcurrentReplacements, 1)
+// ReplaceOnNodeSelectorChange returns true if a change of the node selector should trigger a replacement.
+func (cluster *FoundationDBCluster) ReplaceOnNodeSelectorChange() bool {
+	if cluster.Spec.ReplacementTriggerPolicy == nil {
+		return true
+	}
+
+	return pointer.BoolDeref(cluster.Spec.ReplacementTriggerPolicy.NodeSelectorChanged, true)
 }
 
-// FaultDomainBasedReplacements returns true if the operator is allowed to replace all failed process groups of a
-// fault domain. Default is false
-func (cluster *FoundationDBCluster) FaultDomainBasedReplacements() bool {
-	return pointer.BoolDeref(cluster.Spec.AutomationOptions.Replacements.FaultDomainBasedReplacements, false)
+// ReplaceOnPublicIPSourceChange returns true if a change of the public IP source should trigger a replacement.
+func (cluster *FoundationDBCluster) ReplaceOnPublicIPSourceChange() bool {
+	if cluster.Spec.ReplacementTriggerPolicy == nil {
+		return true
+	}
+
+	return pointer.BoolDeref(cluster.Spec.ReplacementTriggerPolicy.PublicIPSourceChanged, true)
 }
 
-// CoordinatorSelectionSetting defines the process class and the priority of it.
-// A higher priority means that the process class is preferred over another.
-type CoordinatorSelectionSetting struct {
-	// ProcessClass defines the process class to associate with priority with.
-	ProcessClass ProcessClass `json:"processClass,omitempty"`
-	// Priority defines the ordering of different process classes.
-	Priority int `json:"priority,omitempty"`
+// ReplaceOnSecurityContextChange returns true if a change of the file security context should trigger a replacement.
+func (cluster *FoundationDBCluster) ReplaceOnSecurityContextChange() bool {
+	if cluster.Spec.ReplacementTriggerPolicy == nil {
+		return true
+	}
+
+	return pointer.BoolDeref(cluster.Spec.ReplacementTriggerPolicy.SecurityContextChanged, true)
 }
 
-// IsEligibleAsCandidate checks if the given process has the right process class to be considered a valid coordinator.
-// This method will always return false for non stateful process classes.
-func (cluster *FoundationDBCluster) IsEligibleAsCandidate(pClass ProcessClass) bool {
-	if !pClass.IsStateful() {
+// ReplaceOnServiceAccountChange returns true if a change of the service account name should trigger a replacement.
+func (cluster *FoundationDBCluster) ReplaceOnServiceAccountChange() bool {
+	if cluster.Spec.ReplacementTriggerPolicy == nil {
 		return false
 	}
 
-	if len(cluster.Spec.CoordinatorSelection) == 0 {
-		return pClass.IsStateful()
-	}
+	return pointer.BoolDeref(cluster.Spec.ReplacementTriggerPolicy.ServiceAccountChanged, false)
+}
 
-	for _, setting := range cluster.Spec.CoordinatorSelection {
-		if pClass == setting.ProcessClass {
-			return true
-		}
+// ReplaceOnImagePullSecretsChange returns true if a missing image pull secret should trigger a replacement.
+func (cluster *FoundationDBCluster) ReplaceOnImagePullSecretsChange() bool {
+	if cluster.Spec.ReplacementTriggerPolicy == nil {
+		return false
 	}
 
-	return false
+	return pointer.BoolDeref(cluster.Spec.ReplacementTriggerPolicy.ImagePullSecretsChanged, false)
 }
 
-// GetEligibleCandidateClasses returns process classes that are eligible to become coordinators.
-func (cluster *FoundationDBCluster) GetEligibleCandidateClasses() []ProcessClass {
-	candidateClasses := []ProcessClass{}
+// ReplaceOnVolumeTopologyConflict returns true if a conflict between the desired Pod spec and the node affinity of the
+// persistent volume should trigger a replacement.
+func (cluster *FoundationDBCluster) ReplaceOnVolumeTopologyConflict() bool {
+	if cluster.Spec.ReplacementTriggerPolicy == nil {
+		return false
+	}
 
-	for _, processGroup := range cluster.Status.ProcessGroups {
-		if cluster.IsEligibleAsCandidate(processGroup.ProcessClass) {
-			candidateClasses = append(candidateClasses, processGroup.ProcessClass)
-		}
+	return pointer.BoolDeref(cluster.Spec.ReplacementTriggerPolicy.VolumeTopologyConflict, false)
+}
+
+// GetSecurityContextChangeGracePeriod returns how long a change of the file security context must persist before the
+// process group is replaced. The default is 0.
+func (cluster *FoundationDBCluster) GetSecurityContextChangeGracePeriod() time.Duration {
+	if cluster.Spec.ReplacementTriggerPolicy == nil {
+		return 0
 	}
 
-	return candidateClasses
+	return time.Duration(pointer.IntDeref(cluster.Spec.ReplacementTriggerPolicy.SecurityContextChangeGracePeriodSeconds, 0)) * time.Second
 }
 
-// GetClassCandidatePriority returns the priority for a class. This will be used to sort the processes for coordinator selection.
-// If not setting is configured, the default will be 0.
-func (cluster *FoundationDBCluster) GetClassCandidatePriority(pClass ProcessClass) int {
-	for _, setting := range cluster.Spec.CoordinatorSelection {
-		if pClass == setting.ProcessClass {
-			return setting.Priority
-		}
+// UseManagedProcessGroupIDPrefixMigration returns the value of ProcessGroupIDPrefixMigration.Enabled or false if unset.
+func (cluster *FoundationDBCluster) UseManagedProcessGroupIDPrefixMigration() bool {
+	if cluster.Spec.AutomationOptions.ProcessGroupIDPrefixMigration == nil {
+		return false
 	}
 
-	// For coordinators prefer log processes over storage processes, as those get migrated anyway during an upgrade.
-	if pClass.IsLogProcess() {
-		return 1
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.ProcessGroupIDPrefixMigration.Enabled, false)
+}
+
+// ProcessGroupIDPrefixMigrationConfirmed returns true if the current processGroupIDPrefix was confirmed for the
+// managed migration.
+func (cluster *FoundationDBCluster) ProcessGroupIDPrefixMigrationConfirmed() bool {
+	migration := cluster.Spec.AutomationOptions.ProcessGroupIDPrefixMigration
+	if migration == nil || migration.ConfirmedPrefix == nil {
+		return false
 	}
 
-	return 0
+	return *migration.ConfirmedPrefix == cluster.Spec.ProcessGroupIDPrefix
 }
 
-// ShouldFilterOnOwnerReferences determines if we should check owner references
-// when determining if a resource is related to this cluster.
-func (cluster *FoundationDBCluster) ShouldFilterOnOwnerReferences() bool {
-	return pointer.BoolDeref(cluster.Spec.LabelConfig.FilterOnOwnerReferences, false)
+// ProcessGroupIDPrefixMigrationPaused returns true if the managed migration to a new processGroupIDPrefix is paused.
+func (cluster *FoundationDBCluster) ProcessGroupIDPrefixMigrationPaused() bool {
+	migration := cluster.Spec.AutomationOptions.ProcessGroupIDPrefixMigration
+	if migration == nil {
+		return false
+	}
+
+	return pointer.BoolDeref(migration.Paused, false)
 }
 
-// SkipProcessGroup checks if a ProcessGroupStatus should be skipped during reconciliation.
-func (cluster *FoundationDBCluster) SkipProcessGroup(processGroup *ProcessGroupStatus) bool {
-	if processGroup == nil {
-		return true
+// GetProcessGroupIDPrefixMigrationOrder returns the provided process classes in the order in which they should be
+// migrated to the new processGroupIDPrefix. The process classes defined in the processClassOrder of the migration
+// options come first, all other process classes follow in alphabetical order.
+func (cluster *FoundationDBCluster) GetProcessGroupIDPrefixMigrationOrder(processClasses []ProcessClass) []ProcessClass {
+	priority := map[ProcessClass]int{}
+	migration := cluster.Spec.AutomationOptions.ProcessGroupIDPrefixMigration
+	if migration != nil {
+		for idx, processClass := range migration.ProcessClassOrder {
+			if _, ok := priority[processClass]; !ok {
+				priority[processClass] = idx
+			}
+		}
 	}
 
-	pendingTime := processGroup.GetConditionTime(PodPending)
-	if pendingTime == nil {
+	ordered := make([]ProcessClass, len(processClasses))
+	copy(ordered, processClasses)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		priorityI, okI := priority[ordered[i]]
+		priorityJ, okJ := priority[ordered[j]]
+		if okI && okJ {
+			return priorityI < priorityJ
+		}
+
+		if okI != okJ {
+			return okI
+		}
+
+		return ordered[i] < ordered[j]
+	})
+
+	return ordered
+}
+
+// ReplacementsPaused returns true if the replacements of process groups are paused.
+func (cluster *FoundationDBCluster) ReplacementsPaused() bool {
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.Paused.Replacements, false)
+}
+
+// ExclusionsPaused returns true if the exclusions of processes are paused.
+func (cluster *FoundationDBCluster) ExclusionsPaused() bool {
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.Paused.Exclusions, false)
+}
+
+// BouncesPaused returns true if the restarts of fdbserver processes are paused.
+func (cluster *FoundationDBCluster) BouncesPaused() bool {
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.Paused.Bounces, false)
+}
+
+// ConfigurationChangesPaused returns true if the changes to the database configuration are paused.
+func (cluster *FoundationDBCluster) ConfigurationChangesPaused() bool {
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.Paused.ConfigurationChanges, false)
+}
+
+// PodUpdatesPaused returns true if the Pod updates are paused.
+func (cluster *FoundationDBCluster) PodUpdatesPaused() bool {
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.Paused.PodUpdates, false)
+}
+
+// UseStagedPodCreation returns true if new Pods should be created with a scheduling gate. Default is false.
+func (cluster *FoundationDBCluster) UseStagedPodCreation() bool {
+	if cluster.Spec.AutomationOptions.StagedPodCreation == nil {
 		return false
 	}
 
-	return time.Unix(*pendingTime, 0).Add(cluster.GetIgnorePendingPodsDuration()).Before(time.Now())
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.StagedPodCreation.Enabled, false)
 }
 
-// GetIgnorePendingPodsDuration returns the value of IgnorePendingPodsDuration or 5 minutes if unset.
-func (cluster *FoundationDBCluster) GetIgnorePendingPodsDuration() time.Duration {
-	if cluster.Spec.AutomationOptions.IgnorePendingPodsDuration == 0 {
-		return 5 * time.Minute
+// GetStagedPodCreationMaxPodsPerWave returns the number of Pods that are released for scheduling in a single wave.
+// Default is 10.
+func (cluster *FoundationDBCluster) GetStagedPodCreationMaxPodsPerWave() int {
+	if cluster.Spec.AutomationOptions.StagedPodCreation == nil {
+		return 10
 	}
 
-	return cluster.Spec.AutomationOptions.IgnorePendingPodsDuration
+	return pointer.IntDeref(cluster.Spec.AutomationOptions.StagedPodCreation.MaxPodsPerWave, 10)
 }
 
-// GetIgnoreMissingProcessesSeconds returns the value of IgnoreMissingProcessesSecond or 30 seconds if unset.
-func (cluster *FoundationDBCluster) GetIgnoreMissingProcessesSeconds() time.Duration {
-	return time.Duration(pointer.IntDeref(cluster.Spec.AutomationOptions.IgnoreMissingProcessesSeconds, 30)) * time.Second
+// UseSafetyInterlock returns true if a URL for the safety interlock is defined.
+func (cluster *FoundationDBCluster) UseSafetyInterlock() bool {
+	return cluster.Spec.AutomationOptions.SafetyInterlock.URL != ""
 }
 
-// GetFailedPodDuration returns the value of FailedPodDuration or 5 minutes if unset.
-func (cluster *FoundationDBCluster) GetFailedPodDuration() time.Duration {
-	return time.Duration(pointer.IntDeref(cluster.Spec.AutomationOptions.FailedPodDurationSeconds, 300)) * time.Second
+// GetSafetyInterlockCacheDuration returns the duration for caching the result of the safety interlock endpoint,
+// defaults to 60 seconds.
+func (cluster *FoundationDBCluster) GetSafetyInterlockCacheDuration() time.Duration {
+	return time.Duration(pointer.IntDeref(cluster.Spec.AutomationOptions.SafetyInterlock.CacheDurationSeconds, 60)) * time.Second
 }
 
-// GetUseNonBlockingExcludes returns the value of useNonBlockingExcludes or false if unset.
-func (cluster *FoundationDBCluster) GetUseNonBlockingExcludes() bool {
-	return pointer.BoolDeref(cluster.Spec.AutomationOptions.UseNonBlockingExcludes, false)
+// GetSafetyInterlockTimeout returns the timeout for requests to the safety interlock endpoint, defaults to 5 seconds.
+func (cluster *FoundationDBCluster) GetSafetyInterlockTimeout() time.Duration {
+	return time.Duration(pointer.IntDeref(cluster.Spec.AutomationOptions.SafetyInterlock.TimeoutSeconds, 5)) * time.Second
 }
 
-// UseLocalitiesForExclusion returns the value of UseLocalitiesForExclusion or false if unset.
-func (cluster *FoundationDBCluster) UseLocalitiesForExclusion() bool {
-	fdbVersion, err := ParseFdbVersion(cluster.GetRunningVersion())
-	if err != nil {
-		// Fall back to use exclusions with IP if we can't parse the version.
-		// This should never happen since the version is validated in earlier steps.
-		return false
+// GetSafetyInterlockFailurePolicy returns the failure policy for the safety interlock, defaults to Closed.
+func (cluster *FoundationDBCluster) GetSafetyInterlockFailurePolicy() SafetyInterlockFailurePolicy {
+	if cluster.Spec.AutomationOptions.SafetyInterlock.FailurePolicy == "" {
+		return SafetyInterlockFailurePolicyClosed
 	}
 
-	return fdbVersion.SupportsLocalityBasedExclusions() && pointer.BoolDeref(cluster.Spec.AutomationOptions.UseLocalitiesForExclusion, false)
+	return cluster.Spec.AutomationOptions.SafetyInterlock.FailurePolicy
 }
 
 // GetProcessClassLabel provides the label that this cluster is using for the
@@ -2360,6 +5687,39 @@ func (cluster *FoundationDBCluster) GetMaxConcurrentReplacements() int {
 	return pointer.IntDeref(cluster.Spec.AutomationOptions.MaxConcurrentReplacements, math.MaxInt64)
 }
 
+// GetMassReplacementThresholdPercentage returns the value of MassReplacementThresholdPercentage or 0 if unset, which
+// disables the detection of mass replacements.
+func (cluster *FoundationDBCluster) GetMassReplacementThresholdPercentage() int {
+	return pointer.IntDeref(cluster.Spec.AutomationOptions.MassReplacementThresholdPercentage, 0)
+}
+
+// MassReplacementApproved returns true if a mass replacement was approved for the current generation of the cluster.
+func (cluster *FoundationDBCluster) MassReplacementApproved() bool {
+	approval, ok := cluster.Annotations[MassReplacementApprovalAnnotation]
+	if !ok {
+		return false
+	}
+
+	return approval == strconv.FormatInt(cluster.Generation, 10)
+}
+
+// GetSpecSettleDuration returns the duration that the spec of the cluster must be unchanged before the operator
+// performs destructive actions, based on the SpecSettleSecondsAnnotation. If the annotation is missing or invalid, 0
+// will be returned.
+func (cluster *FoundationDBCluster) GetSpecSettleDuration() time.Duration {
+	seconds, err := strconv.Atoi(cluster.Annotations[SpecSettleSecondsAnnotation])
+	if err != nil || seconds < 0 {
+		return 0
+	}
+
+	return time.Duration(seconds) * time.Second
+}
+
+// ShouldRecoverFromPVCs returns true if the process groups of the cluster should be recovered from the existing PVCs.
+func (cluster *FoundationDBCluster) ShouldRecoverFromPVCs() bool {
+	return cluster.Annotations[RecoverFromPVCsAnnotation] == "true"
+}
+
 // UseManagementAPI returns the value of UseManagementAPI or false if unset.
 func (cluster *FoundationDBCluster) UseManagementAPI() bool {
 	return pointer.BoolDeref(cluster.Spec.AutomationOptions.UseManagementAPI, false)
@@ -2454,8 +5814,191 @@ const (
 	PodUpdateStrategyTransactionReplacement PodUpdateStrategy = "ReplaceTransactionSystem"
 	// PodUpdateStrategyDelete delete all Pods if there is a spec change.
 	PodUpdateStrategyDelete PodUpdateStrategy = "Delete"
+	// PodUpdateStrategyInPlaceResize resizes the containers in place if only the CPU or memory resources have changed,
+	// all other changes are rolled out like with PodUpdateStrategyTransactionReplacement. This requires Kubernetes
+	// 1.27+ with the InPlacePodVerticalScaling feature gate enabled.
+	PodUpdateStrategyInPlaceResize PodUpdateStrategy = "InPlaceResize"
+)
+
+// ImageChangePolicy defines how changes of the container images should be applied.
+type ImageChangePolicy string
+
+const (
+	// ImageChangePolicyDefault rolls out image changes like any other Pod spec change.
+	ImageChangePolicyDefault ImageChangePolicy = "Default"
+	// ImageChangePolicyInPlaceRegistryUpdate updates the images in place if only the image registry has changed.
+	ImageChangePolicyInPlaceRegistryUpdate ImageChangePolicy = "InPlaceRegistryUpdate"
+)
+
+// KnobRolloutStrategy defines how processes with a changed command line should be restarted.
+type KnobRolloutStrategy string
+
+const (
+	// KnobRolloutStrategyAll restarts all processes with a changed command line at once.
+	KnobRolloutStrategyAll KnobRolloutStrategy = "All"
+	// KnobRolloutStrategyCanary restarts a single process group per process class first and the remaining processes
+	// of the process class once the canary is running with the new command line.
+	KnobRolloutStrategyCanary KnobRolloutStrategy = "Canary"
 )
 
+// ReplacementStrategy defines how process groups are replaced.
+type ReplacementStrategy string
+
+const (
+	// ReplacementStrategyDefault excludes the replaced process group as soon as enough processes are running.
+	ReplacementStrategyDefault ReplacementStrategy = "Default"
+	// ReplacementStrategySurge excludes and removes the replaced process group only once the replacement processes
+	// have joined the cluster and the data distribution is healthy.
+	ReplacementStrategySurge ReplacementStrategy = "Surge"
+)
+
+// UseSurgeReplacements returns true if the replaced process groups should only be excluded and removed once the
+// replacements are ready.
+func (cluster *FoundationDBCluster) UseSurgeReplacements() bool {
+	return cluster.Spec.AutomationOptions.Replacements.Strategy == ReplacementStrategySurge
+}
+
+// UseNodeVersionSkewReplacements returns true if process groups on nodes with an outdated version should be replaced.
+func (cluster *FoundationDBCluster) UseNodeVersionSkewReplacements() bool {
+	options := cluster.Spec.AutomationOptions.Replacements.NodeVersionSkew
+	if options == nil {
+		return false
+	}
+
+	return options.MinimumKubeletVersion != "" || options.MinimumKernelVersion != "" || (options.VersionLabel != "" && options.MinimumLabelVersion != "")
+}
+
+// GetMaxConcurrentNodeVersionSkewReplacements returns the number of process groups that can be concurrently replaced
+// because their node is outdated, defaults to 1.
+func (cluster *FoundationDBCluster) GetMaxConcurrentNodeVersionSkewReplacements() int {
+	if cluster.Spec.AutomationOptions.Replacements.NodeVersionSkew == nil {
+		return 1
+	}
+
+	return pointer.IntDeref(cluster.Spec.AutomationOptions.Replacements.NodeVersionSkew.MaxConcurrentReplacements, 1)
+}
+
+// ReplaceProcessGroupsOnNodeDrain returns true if process groups on drained nodes should be replaced.
+func (cluster *FoundationDBCluster) ReplaceProcessGroupsOnNodeDrain() bool {
+	if cluster.Spec.AutomationOptions.Replacements.NodeDrain == nil {
+		return false
+	}
+
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.Replacements.NodeDrain.Enabled, false)
+}
+
+// DetectCordonedNodes returns true if cordoned nodes should be treated as being drained, defaults to true.
+func (cluster *FoundationDBCluster) DetectCordonedNodes() bool {
+	if cluster.Spec.AutomationOptions.Replacements.NodeDrain == nil {
+		return true
+	}
+
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.Replacements.NodeDrain.DetectCordonedNodes, true)
+}
+
+// GetNodeDrainTaintKeys returns the keys of the node taints that signal an upcoming drain of the node, defaults to the
+// taints of Karpenter and the cluster-autoscaler.
+func (cluster *FoundationDBCluster) GetNodeDrainTaintKeys() []string {
+	if cluster.Spec.AutomationOptions.Replacements.NodeDrain == nil || len(cluster.Spec.AutomationOptions.Replacements.NodeDrain.TaintKeys) == 0 {
+		return []string{"karpenter.sh/disruption", "karpenter.sh/disrupted", "ToBeDeletedByClusterAutoscaler"}
+	}
+
+	return cluster.Spec.AutomationOptions.Replacements.NodeDrain.TaintKeys
+}
+
+// GetNodeDrainAnnotations returns the keys of the node annotations that signal an upcoming drain of the node.
+func (cluster *FoundationDBCluster) GetNodeDrainAnnotations() []string {
+	if cluster.Spec.AutomationOptions.Replacements.NodeDrain == nil {
+		return nil
+	}
+
+	return cluster.Spec.AutomationOptions.Replacements.NodeDrain.Annotations
+}
+
+// UseGlobalReplacementBudget returns true if the replacements are limited by a budget that is shared by all operator
+// instances that manage the same database.
+func (cluster *FoundationDBCluster) UseGlobalReplacementBudget() bool {
+	budget := cluster.Spec.AutomationOptions.Replacements.GlobalBudget
+	return budget != nil && budget.MaxConcurrentReplacements != nil
+}
+
+// GetGlobalReplacementBudgetLeaseDuration returns the duration for which the in-flight replacements of an operator
+// instance are counted in the global replacement budget, defaults to 10 minutes.
+func (cluster *FoundationDBCluster) GetGlobalReplacementBudgetLeaseDuration() time.Duration {
+	if cluster.Spec.AutomationOptions.Replacements.GlobalBudget == nil {
+		return 10 * time.Minute
+	}
+
+	return time.Duration(pointer.IntDeref(cluster.Spec.AutomationOptions.Replacements.GlobalBudget.LeaseDurationSeconds, 600)) * time.Second
+}
+
+// UpdateImageRegistryInPlace returns true if the images of the Pods should be updated in place if only the image
+// registry has changed.
+func (cluster *FoundationDBCluster) UpdateImageRegistryInPlace() bool {
+	return cluster.Spec.AutomationOptions.ImageChangePolicy == ImageChangePolicyInPlaceRegistryUpdate
+}
+
+// MigrateStorageEngine returns true if the storage process groups should be replaced if they are running with a
+// different storage engine than the storage engine of the database configuration.
+func (cluster *FoundationDBCluster) MigrateStorageEngine() bool {
+	if cluster.Spec.AutomationOptions.StorageEngineMigration == nil {
+		return false
+	}
+
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.StorageEngineMigration.Enabled, false)
+}
+
+// StorageEngineMigrationPaused returns true if no additional storage process groups should be replaced for the
+// storage engine migration.
+func (cluster *FoundationDBCluster) StorageEngineMigrationPaused() bool {
+	if cluster.Spec.AutomationOptions.StorageEngineMigration == nil {
+		return false
+	}
+
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.StorageEngineMigration.Paused, false)
+}
+
+// GetMaxConcurrentStorageEngineMigrations returns the number of storage process groups that can be replaced
+// concurrently for the storage engine migration, the default is 1.
+func (cluster *FoundationDBCluster) GetMaxConcurrentStorageEngineMigrations() int {
+	if cluster.Spec.AutomationOptions.StorageEngineMigration == nil {
+		return 1
+	}
+
+	return pointer.IntDeref(cluster.Spec.AutomationOptions.StorageEngineMigration.MaxConcurrentReplacements, 1)
+}
+
+// GetMaxQueuedDataMovementBytesForStorageEngineMigration returns the upper limit of bytes queued for data movement
+// before the next storage process group is replaced for the storage engine migration. If no limit is defined, nil
+// will be returned.
+func (cluster *FoundationDBCluster) GetMaxQueuedDataMovementBytesForStorageEngineMigration() *int {
+	if cluster.Spec.AutomationOptions.StorageEngineMigration == nil {
+		return nil
+	}
+
+	return cluster.Spec.AutomationOptions.StorageEngineMigration.MaxQueuedDataMovementBytes
+}
+
+// UseKnobRolloutCanary returns true if processes with a changed command line should be restarted with a canary
+// process group per process class.
+func (cluster *FoundationDBCluster) UseKnobRolloutCanary() bool {
+	return cluster.Spec.AutomationOptions.KnobRolloutStrategy == KnobRolloutStrategyCanary
+}
+
+// ResizeInPlace returns true if the containers of Pods with the provided process class should be resized in place if
+// only the CPU or memory resources have changed.
+func (cluster *FoundationDBCluster) ResizeInPlace(processClass ProcessClass) bool {
+	if cluster.Spec.AutomationOptions.PodUpdateStrategy != PodUpdateStrategyInPlaceResize {
+		return false
+	}
+
+	if processClass.IsStateful() {
+		return pointer.BoolDeref(cluster.Spec.AutomationOptions.UseInPlaceResizeForStatefulProcesses, false)
+	}
+
+	return true
+}
+
 // NeedsReplacement returns true if the Pod should be replaced if the Pod spec has changed
 func (cluster *FoundationDBCluster) NeedsReplacement(processGroup *ProcessGroupStatus) bool {
 	if cluster.Spec.AutomationOptions.PodUpdateStrategy == PodUpdateStrategyDelete {
@@ -2844,11 +6387,52 @@ func (cluster *FoundationDBCluster) GetCrashLoopContainerProcessGroups() map[str
 			crashLoopTargets[target.ContainerName] = make(map[ProcessGroupID]None)
 		}
 
-		for _, processID := range target.Targets {
-			crashLoopTargets[target.ContainerName][processID] = None{}
-		}
+		for _, processID := range target.Targets {
+			crashLoopTargets[target.ContainerName][processID] = None{}
+		}
+	}
+	return crashLoopTargets
+}
+
+// ExposeMonitorAPIThroughGateway returns true if the API of the fdb-kubernetes-monitor should be exposed through
+// Gateway API routes.
+func (cluster *FoundationDBCluster) ExposeMonitorAPIThroughGateway() bool {
+	if cluster.Spec.Routing.MonitorAPIGateway == nil {
+		return false
+	}
+
+	return pointer.BoolDeref(cluster.Spec.Routing.MonitorAPIGateway.Enabled, false)
+}
+
+// GetMonitorAPIPort returns the port of the fdb-kubernetes-monitor API, defaults to 8081.
+func (cluster *FoundationDBCluster) GetMonitorAPIPort() int {
+	if cluster.Spec.Routing.MonitorAPIGateway == nil {
+		return 8081
+	}
+
+	return pointer.IntDeref(cluster.Spec.Routing.MonitorAPIGateway.Port, 8081)
+}
+
+// validateMonitorAPIGateway validates the configuration of the monitor API routes.
+func (cluster *FoundationDBCluster) validateMonitorAPIGateway() []string {
+	if !cluster.ExposeMonitorAPIThroughGateway() {
+		return nil
+	}
+
+	var validations []string
+	if !cluster.UseUnifiedImage() {
+		validations = append(validations, "monitorAPIGateway requires the unified image")
+	}
+
+	if len(cluster.Spec.Routing.MonitorAPIGateway.ParentRefs) == 0 {
+		validations = append(validations, "monitorAPIGateway requires at least one parentRef")
 	}
-	return crashLoopTargets
+
+	if cluster.Spec.Routing.MonitorAPIGateway.CertificateIssuer != nil && cluster.Spec.Routing.MonitorAPIGateway.Hostname == "" {
+		validations = append(validations, "monitorAPIGateway requires a hostname if a certificateIssuer is defined")
+	}
+
+	return validations
 }
 
 // Validate checks if all settings in the cluster are valid, if not and error will be returned. If multiple issues are
@@ -2877,6 +6461,51 @@ func (cluster *FoundationDBCluster) Validate() error {
 		}
 	}
 
+	// Check if the enabled feature flags are supported by the defined FDB version.
+	validations = append(validations, cluster.validateFeatureFlags(version)...)
+	validations = append(validations, cluster.validateTuningProfile(version)...)
+	validations = append(validations, cluster.validatePodNamePrefixes()...)
+	validations = append(validations, cluster.validateProcessGroupIDPrefix()...)
+	validations = append(validations, cluster.validateRuntimeClassNames()...)
+	validations = append(validations, cluster.validatePodTemplateOverrides()...)
+	validations = append(validations, cluster.validateKernelSettings()...)
+	validations = append(validations, cluster.validateShutdownSettings()...)
+	validations = append(validations, cluster.validateStartCommandSettings()...)
+	validations = append(validations, cluster.validateAdditionalVolumeClaims()...)
+	validations = append(validations, cluster.validateMaxConcurrentPerClass()...)
+	validations = append(validations, cluster.validateReplacementPriorityOrder()...)
+	validations = append(validations, cluster.validateReplacementWindows()...)
+	validations = append(validations, cluster.validateNodeVersionSkew()...)
+	validations = append(validations, cluster.validateTLSOptions()...)
+	validations = append(validations, cluster.validateProcessGroupIDAllocation()...)
+	validations = append(validations, cluster.validateMonitorAPIGateway()...)
+	validations = append(validations, cluster.validateStorageServersPerPod()...)
+	validations = append(validations, cluster.validateStoragePools()...)
+	validations = append(validations, cluster.validateProxies()...)
+	validations = append(validations, cluster.validateRedundancyMode()...)
+	validations = append(validations, cluster.validateFaultDomain()...)
+
+	if cluster.UseGlobalReplacementBudget() && !cluster.ShouldUseLocks() {
+		validations = append(validations, "the global replacement budget requires the locking system to be enabled")
+	}
+
+	if scaling := cluster.Spec.AutomationOptions.StatelessScaling; scaling != nil && scaling.MinProcesses != nil && scaling.MaxProcesses != nil {
+		if *scaling.MinProcesses > *scaling.MaxProcesses {
+			validations = append(validations, fmt.Sprintf("statelessScaling minProcesses %d must not be greater than maxProcesses %d", *scaling.MinProcesses, *scaling.MaxProcesses))
+		}
+	}
+
+	if cluster.Spec.CoordinatorCount != nil {
+		coordinatorCount := *cluster.Spec.CoordinatorCount
+		if coordinatorCount%2 == 0 {
+			validations = append(validations, fmt.Sprintf("coordinatorCount %d must be an odd number", coordinatorCount))
+		}
+
+		if coordinatorCount < cluster.getMinimumCoordinatorCount() {
+			validations = append(validations, fmt.Sprintf("coordinatorCount %d must be at least %d for redundancy mode %s", coordinatorCount, cluster.getMinimumCoordinatorCount(), cluster.Spec.DatabaseConfiguration.RedundancyMode))
+		}
+	}
+
 	if len(validations) == 0 {
 		return nil
 	}
@@ -2925,10 +6554,13 @@ func (cluster *FoundationDBCluster) GetCurrentProcessGroupsAndProcessCounts() (m
 			return nil, nil, err
 		}
 
-		if len(processGroupIDs[processGroup.ProcessClass]) == 0 {
-			processGroupIDs[processGroup.ProcessClass] = map[int]bool{}
+		// Reassigned process groups keep their ProcessGroupID, so the ID number must be tracked for the process class
+		// that was used to generate the ProcessGroupID.
+		processGroupIDProcessClass := processGroup.GetProcessGroupIDProcessClass()
+		if len(processGroupIDs[processGroupIDProcessClass]) == 0 {
+			processGroupIDs[processGroupIDProcessClass] = map[int]bool{}
 		}
-		processGroupIDs[processGroup.ProcessClass][idNum] = true
+		processGroupIDs[processGroupIDProcessClass][idNum] = true
 
 		if !processGroup.IsMarkedForRemoval() {
 			processCounts[processGroup.ProcessClass]++
@@ -2967,8 +6599,8 @@ func (cluster *FoundationDBCluster) GetNextRandomProcessGroupID(processClass Pro
 }
 
 // GetNextProcessGroupID will return the next unused ProcessGroupID and the ID number based on the provided ProcessClass
-// and the mapping of used ProcessGroupID.
-// Deprecated: This method shouldn't be used anymore and GetNextRandomProcessGroupID is favoured.
+// and the mapping of used ProcessGroupID. This method should only be used if the Dense allocation strategy is used,
+// otherwise GetNextRandomProcessGroupID is favoured.
 func (cluster *FoundationDBCluster) GetNextProcessGroupID(processClass ProcessClass, processGroupIDs map[int]bool, idNum int) (ProcessGroupID, int) {
 	var processGroupID ProcessGroupID
 
@@ -2984,6 +6616,111 @@ func (cluster *FoundationDBCluster) GetNextProcessGroupID(processClass ProcessCl
 	return processGroupID, idNum
 }
 
+// GetNextZoneEncodedProcessGroupID will return the next unused ProcessGroupID and the ID number for the ZoneEncoded
+// allocation strategy. The zone with the fewest active process groups of the process class will be picked and the
+// lowest unused ID number in the block of this zone will be used.
+func (cluster *FoundationDBCluster) GetNextZoneEncodedProcessGroupID(processClass ProcessClass, processGroupIDs map[int]bool) (ProcessGroupID, int, error) {
+	zones := cluster.GetProcessGroupIDZones()
+	if len(zones) == 0 {
+		return "", -1, fmt.Errorf("the ZoneEncoded process group ID allocation requires at least one zone")
+	}
+
+	zoneCounts := make(map[string]int, len(zones))
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if processGroup.GetProcessGroupIDProcessClass() != processClass || processGroup.IsMarkedForRemoval() {
+			continue
+		}
+
+		zone, ok := cluster.GetZoneForProcessGroupID(processGroup.ProcessGroupID)
+		if ok {
+			zoneCounts[zone]++
+		}
+	}
+
+	zoneIndex := 0
+	for idx, zone := range zones {
+		if zoneCounts[zone] < zoneCounts[zones[zoneIndex]] {
+			zoneIndex = idx
+		}
+	}
+
+	offset := (zoneIndex + 1) * zoneEncodedIDBlockSize
+	for idNum := offset + 1; idNum < offset+zoneEncodedIDBlockSize; idNum++ {
+		if processGroupIDs[idNum] {
+			continue
+		}
+
+		_, processGroupID := cluster.GetProcessGroupID(processClass, idNum)
+		if cluster.ProcessGroupIsBeingRemoved(processGroupID) {
+			continue
+		}
+
+		return processGroupID, idNum, nil
+	}
+
+	return "", -1, fmt.Errorf("no unused process group ID for process class %s in zone %s", processClass, zones[zoneIndex])
+}
+
+// GetZoneForProcessGroupID returns the zone that is encoded in the ID number of the process group ID. If the
+// ZoneEncoded allocation strategy is not used or the ID number doesn't encode a configured zone, false will be returned.
+func (cluster *FoundationDBCluster) GetZoneForProcessGroupID(processGroupID ProcessGroupID) (string, bool) {
+	if cluster.GetProcessGroupIDAllocationStrategy() != ProcessGroupIDAllocationZoneEncoded {
+		return "", false
+	}
+
+	idNum, err := processGroupID.GetIDNumber()
+	if err != nil || idNum%zoneEncodedIDBlockSize == 0 {
+		return "", false
+	}
+
+	zones := cluster.GetProcessGroupIDZones()
+	zoneIndex := idNum/zoneEncodedIDBlockSize - 1
+	if zoneIndex < 0 || zoneIndex >= len(zones) {
+		return "", false
+	}
+
+	return zones[zoneIndex], true
+}
+
+// GetProcessGroupsForIDCompaction returns the process groups that must be replaced to close the gaps in the process
+// group ID numbers. For every process class the ID numbers of the active process groups should be in the range
+// from 1 to the number of active process groups, every process group with a higher ID number will be returned.
+// Process groups that are already marked for removal or that use a different ProcessGroupID prefix are ignored.
+func (cluster *FoundationDBCluster) GetProcessGroupsForIDCompaction() []ProcessGroupID {
+	activeProcessGroups := make(map[ProcessClass][]*ProcessGroupStatus)
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if processGroup.IsMarkedForRemoval() || cluster.ProcessGroupIsBeingRemoved(processGroup.ProcessGroupID) {
+			continue
+		}
+
+		processClass := processGroup.GetProcessGroupIDProcessClass()
+		activeProcessGroups[processClass] = append(activeProcessGroups[processClass], processGroup)
+	}
+
+	var candidates []ProcessGroupID
+	for _, processClass := range ProcessClasses {
+		processGroups := activeProcessGroups[processClass]
+		for _, processGroup := range processGroups {
+			idNum, err := processGroup.ProcessGroupID.GetIDNumber()
+			if err != nil {
+				continue
+			}
+
+			// Process groups with a different prefix will be replaced by the operator anyway.
+			_, expectedProcessGroupID := cluster.GetProcessGroupID(processClass, idNum)
+			if expectedProcessGroupID != processGroup.ProcessGroupID {
+				continue
+			}
+
+			if idNum > len(processGroups) {
+				candidates = append(candidates, processGroup.ProcessGroupID)
+			}
+		}
+	}
+
+	return candidates
+}
+
 // GetProcessGroupID generates a ProcessGroupID for a process group.
 //
 // This will return the Pod name and the ProcessGroupID.
@@ -2995,7 +6732,422 @@ func (cluster *FoundationDBCluster) GetProcessGroupID(processClass ProcessClass,
 		processGroupID = ProcessGroupID(fmt.Sprintf("%s-%d", processClass, idNum))
 	}
 
-	return fmt.Sprintf("%s-%s-%d", cluster.Name, processClass.GetProcessClassForPodName(), idNum), processGroupID
+	return fmt.Sprintf("%s-%s-%d", cluster.Name, cluster.GetPodNamePrefix(processClass), idNum), processGroupID
+}
+
+// GetPodNamePrefix returns the prefix that is used for the Pod names of new process groups of the provided process
+// class. If no PodNamePrefix is defined for the process class, the process class will be used.
+func (cluster *FoundationDBCluster) GetPodNamePrefix(processClass ProcessClass) string {
+	if cluster.hasCustomPodNamePrefix(processClass) {
+		return cluster.Spec.Processes[processClass].PodNamePrefix
+	}
+
+	return processClass.GetProcessClassForPodName()
+}
+
+// hasCustomPodNamePrefix returns true if a PodNamePrefix is defined for the provided process class.
+func (cluster *FoundationDBCluster) hasCustomPodNamePrefix(processClass ProcessClass) bool {
+	return processClass != ProcessClassGeneral && cluster.Spec.Processes[processClass].PodNamePrefix != ""
+}
+
+// GetCustomPodName returns the Pod name for the provided process group ID if a custom PodNamePrefix is defined for the
+// process class, otherwise an empty string will be returned.
+func (cluster *FoundationDBCluster) GetCustomPodName(processClass ProcessClass, processGroupID ProcessGroupID) string {
+	if !cluster.hasCustomPodNamePrefix(processClass) {
+		return ""
+	}
+
+	idNum, err := processGroupID.GetIDNumber()
+	if err != nil {
+		return ""
+	}
+
+	podName, _ := cluster.GetProcessGroupID(processClass, idNum)
+	return podName
+}
+
+// validatePodNamePrefixes checks that the custom PodNamePrefixes are not used by any other process class, otherwise
+// multiple process groups could end up with the same Pod name.
+func (cluster *FoundationDBCluster) validatePodNamePrefixes() []string {
+	var validations []string
+
+	prefixes := make(map[string]ProcessClass, len(ProcessClasses))
+	customPrefixClasses := make([]ProcessClass, 0, len(cluster.Spec.Processes))
+	for _, processClass := range ProcessClasses {
+		if cluster.hasCustomPodNamePrefix(processClass) {
+			customPrefixClasses = append(customPrefixClasses, processClass)
+			continue
+		}
+
+		prefixes[cluster.GetPodNamePrefix(processClass)] = processClass
+	}
+
+	for _, processClass := range customPrefixClasses {
+		prefix := cluster.GetPodNamePrefix(processClass)
+		if otherProcessClass, ok := prefixes[prefix]; ok {
+			validations = append(validations, fmt.Sprintf("podNamePrefix %s of process class %s is already used by process class %s", prefix, processClass, otherProcessClass))
+			continue
+		}
+
+		prefixes[prefix] = processClass
+	}
+
+	return validations
+}
+
+// processGroupIDPrefixRegex defines the allowed characters of the processGroupIDPrefix, the prefix must be a valid
+// Kubernetes label value.
+var processGroupIDPrefixRegex = regexp.MustCompile(`^[a-z0-9A-Z]([\-._a-z0-9A-Z]*[a-z0-9A-Z])?$`)
+
+// processGroupIDNumberLength is the number of digits that are reserved for the ID number in the process group ID.
+const processGroupIDNumberLength = 5
+
+// GetMaxProcessGroupIDPrefixLength returns the maximum length of the processGroupIDPrefix. The process group ID contains
+// the prefix, the longest process class of the cluster and the ID number and must fit into a Kubernetes label value.
+func (cluster *FoundationDBCluster) GetMaxProcessGroupIDPrefixLength() int {
+	var longestProcessClass int
+	processCounts, err := cluster.GetProcessCountsWithDefaults()
+	if err == nil {
+		for processClass, count := range processCounts.Map() {
+			if count > 0 && len(processClass) > longestProcessClass {
+				longestProcessClass = len(processClass)
+			}
+		}
+	}
+
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if len(processGroup.ProcessClass) > longestProcessClass {
+			longestProcessClass = len(processGroup.ProcessClass)
+		}
+	}
+
+	// The prefix, the process class and the ID number are separated by a "-".
+	return validation.LabelValueMaxLength - longestProcessClass - processGroupIDNumberLength - 2
+}
+
+// ValidateProcessGroupIDPrefix checks if the provided prefix can be used as processGroupIDPrefix of the cluster.
+func (cluster *FoundationDBCluster) ValidateProcessGroupIDPrefix(prefix string) error {
+	if prefix == "" {
+		return nil
+	}
+
+	maxLength := cluster.GetMaxProcessGroupIDPrefixLength()
+	if len(prefix) > maxLength {
+		return fmt.Errorf("processGroupIDPrefix %s must not be longer than %d characters", prefix, maxLength)
+	}
+
+	if !processGroupIDPrefixRegex.MatchString(prefix) {
+		return fmt.Errorf("processGroupIDPrefix %s must consist of alphanumeric characters, '-', '_' or '.' and must start and end with an alphanumeric character", prefix)
+	}
+
+	return nil
+}
+
+// validateProcessGroupIDPrefix validates the processGroupIDPrefix and the confirmed prefix of the managed migration.
+func (cluster *FoundationDBCluster) validateProcessGroupIDPrefix() []string {
+	var validations []string
+
+	err := cluster.ValidateProcessGroupIDPrefix(cluster.Spec.ProcessGroupIDPrefix)
+	if err != nil {
+		validations = append(validations, err.Error())
+	}
+
+	migration := cluster.Spec.AutomationOptions.ProcessGroupIDPrefixMigration
+	if migration != nil && migration.ConfirmedPrefix != nil {
+		err = cluster.ValidateProcessGroupIDPrefix(*migration.ConfirmedPrefix)
+		if err != nil {
+			validations = append(validations, fmt.Sprintf("confirmedPrefix is invalid: %s", err.Error()))
+		}
+	}
+
+	return validations
+}
+
+// GetRuntimeClassName returns the RuntimeClass that should be used for the Pods of the provided process class. The
+// runtimeClassName of the process settings takes precedence over the runtimeClassName of the PodTemplate.
+func (cluster *FoundationDBCluster) GetRuntimeClassName(processClass ProcessClass) *string {
+	processSettings := cluster.GetProcessSettings(processClass)
+	if processSettings.RuntimeClassName != nil {
+		return processSettings.RuntimeClassName
+	}
+
+	if processSettings.PodTemplate != nil {
+		return processSettings.PodTemplate.Spec.RuntimeClassName
+	}
+
+	return nil
+}
+
+// GetSysctls returns the sysctls that should be set for the Pods of the provided process class.
+func (cluster *FoundationDBCluster) GetSysctls(processClass ProcessClass) []corev1.Sysctl {
+	kernelSettings := cluster.GetProcessSettings(processClass).KernelSettings
+	if kernelSettings == nil {
+		return nil
+	}
+
+	return kernelSettings.Sysctls
+}
+
+// DisableTransparentHugePages returns true if transparent huge pages should be disabled for the Pods of the provided
+// process class.
+func (cluster *FoundationDBCluster) DisableTransparentHugePages(processClass ProcessClass) bool {
+	kernelSettings := cluster.GetProcessSettings(processClass).KernelSettings
+	if kernelSettings == nil {
+		return false
+	}
+
+	return pointer.BoolDeref(kernelSettings.DisableTransparentHugePages, false)
+}
+
+// GetTerminationGracePeriodSeconds returns the termination grace period for the Pods of the provided process class. If
+// no value is defined the Kubernetes default will be returned.
+func (cluster *FoundationDBCluster) GetTerminationGracePeriodSeconds(processClass ProcessClass) int64 {
+	processSettings := cluster.GetProcessSettings(processClass)
+	if processSettings.Shutdown != nil && processSettings.Shutdown.TerminationGracePeriodSeconds != nil {
+		return *processSettings.Shutdown.TerminationGracePeriodSeconds
+	}
+
+	if processSettings.PodTemplate != nil && processSettings.PodTemplate.Spec.TerminationGracePeriodSeconds != nil {
+		return *processSettings.PodTemplate.Spec.TerminationGracePeriodSeconds
+	}
+
+	return corev1.DefaultTerminationGracePeriodSeconds
+}
+
+// validateShutdownSettings validates that the sidecar shutdown delay is lower than the termination grace period. The
+// merged process settings are validated, as the shutdown settings and the termination grace period of a process class
+// can be inherited from the general process settings.
+func (cluster *FoundationDBCluster) validateShutdownSettings() []string {
+	var validations []string
+
+	for _, processClass := range cluster.getSortedProcessSettingsClasses() {
+		shutdown := cluster.GetProcessSettings(processClass).Shutdown
+		if shutdown == nil || shutdown.SidecarShutdownDelaySeconds == nil {
+			continue
+		}
+
+		gracePeriod := cluster.GetTerminationGracePeriodSeconds(processClass)
+		if int64(*shutdown.SidecarShutdownDelaySeconds) >= gracePeriod {
+			validations = append(validations, fmt.Sprintf("sidecarShutdownDelaySeconds %d for process class %s must be lower than the terminationGracePeriodSeconds %d", *shutdown.SidecarShutdownDelaySeconds, processClass, gracePeriod))
+		}
+	}
+
+	return validations
+}
+
+// validateStartCommandSettings validates that the start command wrapper defines a command and that the environment
+// variables are unique and don't use the prefix reserved for the operator.
+func (cluster *FoundationDBCluster) validateStartCommandSettings() []string {
+	var validations []string
+
+	for _, processClass := range cluster.getSortedProcessSettingsClasses() {
+		startCommand := cluster.Spec.Processes[processClass].StartCommand
+		if startCommand == nil {
+			continue
+		}
+
+		if len(startCommand.Wrapper) > 0 && strings.TrimSpace(startCommand.Wrapper[0]) == "" {
+			validations = append(validations, fmt.Sprintf("start command wrapper for process class %s must define a command", processClass))
+		}
+
+		names := make(map[string]None, len(startCommand.Env))
+		for _, env := range startCommand.Env {
+			if strings.HasPrefix(env.Name, "FDB_") {
+				validations = append(validations, fmt.Sprintf("start command environment variable %s for process class %s uses the reserved prefix FDB_", env.Name, processClass))
+			}
+
+			if _, ok := names[env.Name]; ok {
+				validations = append(validations, fmt.Sprintf("start command environment variable %s for process class %s is defined multiple times", env.Name, processClass))
+			}
+
+			names[env.Name] = None{}
+		}
+	}
+
+	return validations
+}
+
+// reservedVolumeNames contains the names of the volumes that are managed by the operator.
+var reservedVolumeNames = map[string]None{
+	"data":            {},
+	"dynamic-conf":    {},
+	"config-map":      {},
+	"fdb-trace-logs":  {},
+	"shared-binaries": {},
+}
+
+// reservedMountPaths contains the mount paths of the volumes that are managed by the operator.
+var reservedMountPaths = map[string]None{
+	"/var/fdb/data":            {},
+	"/var/dynamic-conf":        {},
+	"/var/log/fdb-trace-logs":  {},
+	"/var/fdb/shared-binaries": {},
+}
+
+// GetRuntimeSeconds returns the runtime of the disk benchmark for every volume.
+func (settings DiskQualificationSettings) GetRuntimeSeconds() int {
+	return pointer.IntDeref(settings.RuntimeSeconds, 30)
+}
+
+// UsePerServerVolumes returns true if one volume should be created for every server in the Pod.
+func (claim AdditionalVolumeClaim) UsePerServerVolumes() bool {
+	return pointer.BoolDeref(claim.PerServer, false)
+}
+
+// validateAdditionalVolumeClaims validates that the names and mount paths of the additional volume claims are unique
+// and don't conflict with the volumes managed by the operator. The claims are validated after merging them with the
+// general process settings, as the inherited claims are mounted the same way. The general process settings are
+// validated as defined, as the merged general settings never contain additional volume claims.
+func (cluster *FoundationDBCluster) validateAdditionalVolumeClaims() []string {
+	var validations []string
+
+	for _, processClass := range cluster.getSortedProcessSettingsClasses() {
+		processSettings := cluster.GetProcessSettings(processClass)
+		if processClass == ProcessClassGeneral {
+			processSettings = cluster.Spec.Processes[processClass]
+		}
+
+		if len(processSettings.AdditionalVolumeClaims) == 0 {
+			continue
+		}
+
+		var dataVolumeName string
+		if volumeClaimTemplate := processSettings.VolumeClaimTemplate; volumeClaimTemplate != nil {
+			dataVolumeName = volumeClaimTemplate.Name
+		}
+
+		names := make(map[string]None, len(processSettings.AdditionalVolumeClaims))
+		mountPaths := make(map[string]None, len(processSettings.AdditionalVolumeClaims))
+		for _, claim := range processSettings.AdditionalVolumeClaims {
+			if _, ok := reservedVolumeNames[claim.Name]; ok || claim.Name == dataVolumeName {
+				validations = append(validations, fmt.Sprintf("additional volume claim %s for process class %s uses a reserved name", claim.Name, processClass))
+			}
+
+			if _, ok := names[claim.Name]; ok {
+				validations = append(validations, fmt.Sprintf("additional volume claim %s for process class %s is defined multiple times", claim.Name, processClass))
+			}
+			names[claim.Name] = None{}
+
+			if !strings.HasPrefix(claim.MountPath, "/") {
+				validations = append(validations, fmt.Sprintf("mountPath %s of additional volume claim %s for process class %s must be an absolute path", claim.MountPath, claim.Name, processClass))
+			}
+
+			// Per server volumes are mounted in a sub directory of the mount path, so they can be mounted inside the
+			// data directory.
+			mountPath := strings.TrimSuffix(claim.MountPath, "/")
+			if _, ok := reservedMountPaths[mountPath]; ok && !claim.UsePerServerVolumes() {
+				validations = append(validations, fmt.Sprintf("mountPath %s of additional volume claim %s for process class %s is already used by the operator", claim.MountPath, claim.Name, processClass))
+			}
+
+			if _, ok := mountPaths[mountPath]; ok {
+				validations = append(validations, fmt.Sprintf("mountPath %s of additional volume claim %s for process class %s is used multiple times", claim.MountPath, claim.Name, processClass))
+			}
+			mountPaths[mountPath] = None{}
+		}
+	}
+
+	return validations
+}
+
+// validateKernelSettings validates that only namespaced sysctls are defined and that every sysctl is only defined once
+// per process class.
+func (cluster *FoundationDBCluster) validateKernelSettings() []string {
+	var validations []string
+
+	for _, processClass := range cluster.getSortedProcessSettingsClasses() {
+		kernelSettings := cluster.Spec.Processes[processClass].KernelSettings
+		if kernelSettings == nil {
+			continue
+		}
+
+		names := make(map[string]None, len(kernelSettings.Sysctls))
+		for _, sysctl := range kernelSettings.Sysctls {
+			if !isNamespacedSysctl(sysctl.Name) {
+				validations = append(validations, fmt.Sprintf("sysctl %s for process class %s is not namespaced and cannot be set for a Pod", sysctl.Name, processClass))
+			}
+
+			if _, ok := names[sysctl.Name]; ok {
+				validations = append(validations, fmt.Sprintf("sysctl %s for process class %s is defined multiple times", sysctl.Name, processClass))
+			}
+
+			names[sysctl.Name] = None{}
+		}
+	}
+
+	return validations
+}
+
+// isNamespacedSysctl returns true if the provided sysctl is namespaced and can be set per Pod.
+func isNamespacedSysctl(name string) bool {
+	for _, prefix := range namespacedSysctlPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getSortedProcessSettingsClasses returns the process classes that have process settings defined in a stable order.
+func (cluster *FoundationDBCluster) getSortedProcessSettingsClasses() []ProcessClass {
+	processClasses := make([]ProcessClass, 0, len(cluster.Spec.Processes))
+	for processClass := range cluster.Spec.Processes {
+		processClasses = append(processClasses, processClass)
+	}
+	sort.Slice(processClasses, func(i, j int) bool {
+		return processClasses[i] < processClasses[j]
+	})
+
+	return processClasses
+}
+
+// validateRuntimeClassNames validates that the runtimeClassName of the process settings doesn't conflict with the
+// runtimeClassName defined in the PodTemplate of the same process settings.
+func (cluster *FoundationDBCluster) validateRuntimeClassNames() []string {
+	var validations []string
+
+	for _, processClass := range cluster.getSortedProcessSettingsClasses() {
+		processSettings := cluster.Spec.Processes[processClass]
+		if processSettings.RuntimeClassName == nil || processSettings.PodTemplate == nil || processSettings.PodTemplate.Spec.RuntimeClassName == nil {
+			continue
+		}
+
+		if *processSettings.RuntimeClassName != *processSettings.PodTemplate.Spec.RuntimeClassName {
+			validations = append(validations, fmt.Sprintf("runtimeClassName %s of process class %s conflicts with runtimeClassName %s of the podTemplate", *processSettings.RuntimeClassName, processClass, *processSettings.PodTemplate.Spec.RuntimeClassName))
+		}
+	}
+
+	return validations
+}
+
+// validatePodTemplateOverrides validates that the podTemplateOverrides are only defined for specific process classes
+// and that they can be merged onto the PodTemplate of the process class.
+func (cluster *FoundationDBCluster) validatePodTemplateOverrides() []string {
+	var validations []string
+
+	for _, processClass := range cluster.getSortedProcessSettingsClasses() {
+		processSettings := cluster.Spec.Processes[processClass]
+		if processSettings.PodTemplateOverrides == nil {
+			continue
+		}
+
+		if processClass == ProcessClassGeneral {
+			validations = append(validations, "podTemplateOverrides are not supported for the general process class, use the podTemplate instead")
+			continue
+		}
+
+		podTemplate := processSettings.PodTemplate
+		if podTemplate == nil {
+			podTemplate = cluster.Spec.Processes[ProcessClassGeneral].PodTemplate
+		}
+
+		_, err := mergePodTemplateOverrides(podTemplate, processSettings.PodTemplateOverrides)
+		if err != nil {
+			validations = append(validations, fmt.Sprintf("podTemplateOverrides of process class %s cannot be merged: %s", processClass, err.Error()))
+		}
+	}
+
+	return validations
 }
 
 // IsPodIPFamily6 determines whether the podIPFamily setting in cluster is set to use the IPv6 family.
@@ -23,6 +23,7 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -30,6 +31,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 )
@@ -435,6 +437,17 @@ var _ = Describe("[api] FoundationDBCluster", func() {
 					Stateless: 22,
 				}))
 			})
+
+			When("the cluster is upgraded from a version without grv and commit proxies", func() {
+				It("should use the proxies count until the upgrade is done", func() {
+					cluster.Spec.Version = "7.1.0"
+					cluster.Status.RunningVersion = "6.3.24"
+					counts, err := cluster.GetProcessCountsWithDefaults()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(counts.Stateless).To(Equal(9))
+					Expect(cluster.DesiredDatabaseConfiguration().GetConfigurationString(cluster.GetRunningVersion())).To(ContainSubstring(" proxies=3 "))
+				})
+			})
 		})
 
 		It("should return the default process counts when proxies are unset", func() {
@@ -448,6 +461,54 @@ var _ = Describe("[api] FoundationDBCluster", func() {
 				Stateless: 22,
 			}))
 		})
+
+		When("the stateless scaling limits are defined", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.StatelessScaling = &StatelessScalingOptions{
+					MinProcesses: pointer.Int(10),
+					MaxProcesses: pointer.Int(20),
+				}
+			})
+
+			DescribeTable("should clamp the stateless process count",
+				func(stateless int, expected int) {
+					cluster.Spec.ProcessCounts.Stateless = stateless
+					counts, err := cluster.GetProcessCountsWithDefaults()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(counts.Stateless).To(Equal(expected))
+				},
+				Entry("the count is within the limits", 15, 15),
+				Entry("the count is lower than the minimum", 5, 10),
+				Entry("the count is higher than the maximum", 50, 20),
+				Entry("the count is unset", 0, 9),
+				Entry("the stateless processes are disabled", -1, -1),
+			)
+
+			When("the minimum is lower than the required stateless processes", func() {
+				BeforeEach(func() {
+					cluster.Spec.AutomationOptions.StatelessScaling.MinProcesses = pointer.Int(1)
+					cluster.Spec.ProcessCounts.Stateless = 2
+				})
+
+				It("should use the required stateless processes", func() {
+					counts, err := cluster.GetProcessCountsWithDefaults()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(counts.Stateless).To(Equal(9))
+				})
+			})
+		})
+
+		DescribeTable("should clamp the stateless process count without stateless scaling limits",
+			func(stateless int, expected int) {
+				cluster.Spec.ProcessCounts.Stateless = stateless
+				counts, err := cluster.GetProcessCountsWithDefaults()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(counts.Stateless).To(Equal(expected))
+			},
+			Entry("the count is higher than the required stateless processes", 15, 15),
+			Entry("the count is lower than the required stateless processes", 2, 9),
+			Entry("the stateless processes are disabled", -1, -1),
+		)
 	})
 
 	When("getting the default process counts with cross cluster replication", func() {
@@ -674,6 +735,81 @@ var _ = Describe("[api] FoundationDBCluster", func() {
 		})
 	})
 
+	When("getting the desired coordinator count", func() {
+		var cluster *FoundationDBCluster
+
+		BeforeEach(func() {
+			cluster = &FoundationDBCluster{
+				Spec: FoundationDBClusterSpec{
+					DatabaseConfiguration: DatabaseConfiguration{
+						RedundancyMode: RedundancyModeTriple,
+					},
+				},
+			}
+		})
+
+		It("should derive the coordinator count from the redundancy mode", func() {
+			Expect(cluster.DesiredCoordinatorCount()).To(Equal(5))
+		})
+
+		When("the cluster spans three data centers in a single region", func() {
+			BeforeEach(func() {
+				cluster.Spec.DatabaseConfiguration.Regions = []Region{
+					{
+						DataCenters: []DataCenter{
+							{ID: "primary", Priority: 1},
+							{ID: "satellite1", Satellite: 1, Priority: 1},
+							{ID: "satellite2", Satellite: 1},
+						},
+					},
+				}
+			})
+
+			It("should derive the coordinator count from the redundancy mode", func() {
+				Expect(cluster.DesiredCoordinatorCount()).To(Equal(5))
+			})
+
+			When("the coordinators should be spread across the data centers", func() {
+				BeforeEach(func() {
+					cluster.Spec.SpreadCoordinatorsAcrossDataCenters = pointer.Bool(true)
+				})
+
+				It("should use 9 coordinators", func() {
+					Expect(cluster.UseDataCenterCoordinators()).To(BeTrue())
+					Expect(cluster.DesiredCoordinatorCount()).To(Equal(9))
+				})
+			})
+		})
+
+		When("the cluster spans two data centers in a single region", func() {
+			BeforeEach(func() {
+				cluster.Spec.DatabaseConfiguration.Regions = []Region{
+					{
+						DataCenters: []DataCenter{
+							{ID: "primary", Priority: 1},
+							{ID: "satellite1", Satellite: 1},
+						},
+					},
+				}
+			})
+
+			It("should derive the coordinator count from the redundancy mode", func() {
+				Expect(cluster.DesiredCoordinatorCount()).To(Equal(5))
+			})
+		})
+
+		When("the coordinator count is overridden", func() {
+			BeforeEach(func() {
+				cluster.Spec.CoordinatorCount = pointer.Int(7)
+			})
+
+			It("should use the provided coordinator count", func() {
+				Expect(cluster.DesiredCoordinatorCount()).To(Equal(7))
+				Expect(cluster.GetDefaultCoordinatorCount()).To(Equal(5))
+			})
+		})
+	})
+
 	When("parsing the backup status for 6.2", func() {
 		It("should be parsed correctly", func() {
 			statusFile, err := os.OpenFile(filepath.Join("testdata", "fdbbackup_status_6_2.json"), os.O_RDONLY, os.ModePerm)
@@ -3084,6 +3220,7 @@ var _ = Describe("[api] FoundationDBCluster", func() {
 					Reconciled:  1,
 					NeedsShrink: 2,
 				}))
+				Expect(cluster.Status.PendingRemovals).To(Equal(1))
 
 				cluster = createCluster()
 				cluster.Spec.ProcessCounts.Storage = 2
@@ -3472,6 +3609,68 @@ var _ = Describe("[api] FoundationDBCluster", func() {
 			Expect(settings.PodTemplate.ObjectMeta.Labels).To(Equal(map[string]string{"test-label": "label2"}))
 			Expect(settings.CustomParameters).To(Equal(FoundationDBCustomParameters{"test_knob=value1"}))
 		})
+
+		It("should merge the pod template overrides onto the pod template", func() {
+			generalTemplate := &corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					NodeSelector: map[string]string{"pool": "default"},
+					Containers: []corev1.Container{
+						{
+							Name: MainContainerName,
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceCPU:    resource.MustParse("1"),
+									corev1.ResourceMemory: resource.MustParse("8Gi"),
+								},
+							},
+						},
+						{
+							Name:  SidecarContainerName,
+							Image: "foundationdb/foundationdb-kubernetes-sidecar",
+						},
+					},
+				},
+			}
+
+			cluster := &FoundationDBCluster{
+				Spec: FoundationDBClusterSpec{
+					Processes: map[ProcessClass]ProcessSettings{
+						ProcessClassGeneral: {
+							PodTemplate: generalTemplate,
+						},
+						ProcessClassLog: {
+							PodTemplateOverrides: &corev1.PodTemplateSpec{
+								Spec: corev1.PodSpec{
+									NodeSelector: map[string]string{"pool": "log"},
+									Containers: []corev1.Container{
+										{
+											Name: MainContainerName,
+											Resources: corev1.ResourceRequirements{
+												Requests: corev1.ResourceList{
+													corev1.ResourceCPU: resource.MustParse("4"),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+
+			settings := cluster.GetProcessSettings(ProcessClassLog)
+			Expect(settings.PodTemplate.Spec.NodeSelector).To(Equal(map[string]string{"pool": "log"}))
+			Expect(settings.PodTemplate.Spec.Containers).To(HaveLen(2))
+			Expect(settings.PodTemplate.Spec.Containers[0].Name).To(Equal(MainContainerName))
+			Expect(settings.PodTemplate.Spec.Containers[0].Resources.Requests.Cpu().String()).To(Equal("4"))
+			Expect(settings.PodTemplate.Spec.Containers[0].Resources.Requests.Memory().String()).To(Equal("8Gi"))
+			Expect(settings.PodTemplate.Spec.Containers[1].Image).To(Equal("foundationdb/foundationdb-kubernetes-sidecar"))
+
+			// The overrides must not modify the general pod template.
+			Expect(cluster.GetProcessSettings(ProcessClassStorage).PodTemplate).To(Equal(generalTemplate))
+			Expect(generalTemplate.Spec.NodeSelector).To(Equal(map[string]string{"pool": "default"}))
+		})
 	})
 
 	When("getting the lock options", func() {
@@ -3700,6 +3899,241 @@ var _ = Describe("[api] FoundationDBCluster", func() {
 		)
 	})
 
+	When("updating the process information of a process group", func() {
+		var processGroup *ProcessGroupStatus
+		var processes []FoundationDBStatusProcessInfo
+
+		BeforeEach(func() {
+			processGroup = &ProcessGroupStatus{
+				ProcessGroupID: "storage-1",
+				Roles:          []ProcessRole{ProcessRoleLog},
+			}
+
+			processes = []FoundationDBStatusProcessInfo{
+				{
+					Version:       "7.1.26",
+					UptimeSeconds: 3600,
+					Roles: []FoundationDBStatusProcessRoleInfo{
+						{Role: string(ProcessRoleStorage)},
+						{Role: string(ProcessRoleCoordinator)},
+					},
+				},
+				{
+					Version:       "7.1.26",
+					UptimeSeconds: 600,
+					Roles: []FoundationDBStatusProcessRoleInfo{
+						{Role: string(ProcessRoleStorage)},
+					},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			processGroup.UpdateProcessInformation(processes)
+		})
+
+		It("should update the process information", func() {
+			Expect(processGroup.Roles).To(Equal([]ProcessRole{ProcessRoleCoordinator, ProcessRoleStorage}))
+			Expect(processGroup.RunningVersion).To(Equal("7.1.26"))
+			Expect(processGroup.ProcessStartTimestamp).NotTo(BeNil())
+			Expect(processGroup.ProcessStartTimestamp.Time).To(BeTemporally("~", time.Now().Add(-10*time.Minute), 2*time.Second))
+		})
+
+		When("the processes were not restarted", func() {
+			var startTimestamp metav1.Time
+
+			BeforeEach(func() {
+				startTimestamp = metav1.Time{Time: time.Now().Add(-10*time.Minute - 5*time.Second).Truncate(time.Second)}
+				processGroup.ProcessStartTimestamp = &startTimestamp
+			})
+
+			It("should keep the process start timestamp", func() {
+				Expect(processGroup.ProcessStartTimestamp).To(Equal(&startTimestamp))
+			})
+		})
+
+		When("no processes are reported", func() {
+			BeforeEach(func() {
+				processes = nil
+				processGroup.RunningVersion = "7.1.25"
+			})
+
+			It("should only reset the roles", func() {
+				Expect(processGroup.Roles).To(BeEmpty())
+				Expect(processGroup.RunningVersion).To(Equal("7.1.25"))
+			})
+		})
+	})
+
+	When("updating the exclusion progress of a process group", func() {
+		var processGroup *ProcessGroupStatus
+		var processes []FoundationDBStatusProcessInfo
+
+		BeforeEach(func() {
+			processGroup = &ProcessGroupStatus{
+				ProcessGroupID:   "storage-1",
+				RemovalTimestamp: &metav1.Time{Time: time.Now()},
+			}
+
+			processes = []FoundationDBStatusProcessInfo{
+				{
+					Excluded: true,
+					Locality: map[string]string{
+						FDBLocalityProcessIDKey: "storage-1-2",
+					},
+					Roles: []FoundationDBStatusProcessRoleInfo{
+						{Role: string(ProcessRoleStorage)},
+					},
+				},
+				{
+					Excluded: true,
+					Locality: map[string]string{
+						FDBLocalityProcessIDKey: "storage-1-1",
+					},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			processGroup.UpdateExclusionProgress(processes)
+		})
+
+		It("should report the progress per process", func() {
+			Expect(processGroup.ExclusionProgress).To(Equal([]ProcessExclusionProgress{
+				{
+					ProcessID: "storage-1-1",
+					Excluded:  true,
+				},
+				{
+					ProcessID: "storage-1-2",
+					Excluded:  true,
+					Roles:     []ProcessRole{ProcessRoleStorage},
+				},
+			}))
+			Expect(processGroup.GetPendingExclusions()).To(ConsistOf("storage-1-2"))
+		})
+
+		When("the process group runs a single process", func() {
+			BeforeEach(func() {
+				processes = processes[:1]
+			})
+
+			It("should not report any progress", func() {
+				Expect(processGroup.ExclusionProgress).To(BeNil())
+			})
+		})
+
+		When("the process group is not marked for removal", func() {
+			BeforeEach(func() {
+				processGroup.RemovalTimestamp = nil
+			})
+
+			It("should not report any progress", func() {
+				Expect(processGroup.ExclusionProgress).To(BeNil())
+			})
+		})
+
+		When("the process group is fully excluded", func() {
+			BeforeEach(func() {
+				processGroup.SetExclude()
+			})
+
+			It("should not report any progress", func() {
+				Expect(processGroup.ExclusionProgress).To(BeNil())
+				Expect(processGroup.GetPendingExclusions()).To(BeEmpty())
+			})
+		})
+	})
+
+	When("updating the removal phases of a process group", func() {
+		var processGroup *ProcessGroupStatus
+		var removalTime time.Time
+
+		BeforeEach(func() {
+			removalTime = time.Now().Add(-10 * time.Minute).Truncate(time.Second)
+			processGroup = &ProcessGroupStatus{
+				ProcessGroupID:   "storage-1",
+				RemovalTimestamp: &metav1.Time{Time: removalTime},
+			}
+		})
+
+		JustBeforeEach(func() {
+			processGroup.UpdateRemovalPhases()
+		})
+
+		It("should be in the marked for removal phase", func() {
+			Expect(processGroup.GetRemovalPhase()).To(Equal(RemovalPhaseMarkedForRemoval))
+			Expect(processGroup.RemovalPhases.MarkedForRemoval.Time).To(BeTemporally("==", removalTime))
+			Expect(processGroup.GetRemovalPhaseDurations()).To(HaveKeyWithValue(RemovalPhaseMarkedForRemoval, BeNumerically(">=", 10*time.Minute)))
+		})
+
+		When("the process group is not marked for removal", func() {
+			BeforeEach(func() {
+				processGroup.RemovalTimestamp = nil
+			})
+
+			It("should not track any phase", func() {
+				Expect(processGroup.RemovalPhases).To(BeNil())
+				Expect(processGroup.GetRemovalPhase()).To(BeEmpty())
+				Expect(processGroup.GetRemovalPhaseDurations()).To(BeNil())
+			})
+		})
+
+		When("the exclusion was started before the process group was marked for removal", func() {
+			BeforeEach(func() {
+				processGroup.ProcessGroupConditions = []*ProcessGroupCondition{
+					{
+						ProcessGroupConditionType: ProcessIsMarkedAsExcluded,
+						Timestamp:                 removalTime.Add(-time.Minute).Unix(),
+					},
+				}
+			})
+
+			It("should start the excluding phase with the removal", func() {
+				Expect(processGroup.GetRemovalPhase()).To(Equal(RemovalPhaseExcluding))
+				Expect(processGroup.RemovalPhases.Excluding.Time).To(BeTemporally("==", removalTime))
+			})
+		})
+
+		When("the process group is excluded and removed", func() {
+			var exclusionTime time.Time
+
+			BeforeEach(func() {
+				exclusionTime = removalTime.Add(2 * time.Minute)
+				processGroup.ExclusionTimestamp = &metav1.Time{Time: exclusionTime}
+				processGroup.ProcessGroupConditions = []*ProcessGroupCondition{
+					{
+						ProcessGroupConditionType: ProcessIsMarkedAsExcluded,
+						Timestamp:                 removalTime.Add(time.Minute).Unix(),
+					},
+				}
+			})
+
+			JustBeforeEach(func() {
+				processGroup.MarkAsRemoved()
+			})
+
+			It("should report the duration of every phase", func() {
+				Expect(processGroup.GetRemovalPhase()).To(Equal(RemovalPhaseRemoved))
+				durations := processGroup.GetRemovalPhaseDurations()
+				Expect(durations).To(HaveLen(3))
+				Expect(durations).To(HaveKeyWithValue(RemovalPhaseMarkedForRemoval, time.Minute))
+				Expect(durations).To(HaveKeyWithValue(RemovalPhaseExcluding, time.Minute))
+				Expect(durations).To(HaveKey(RemovalPhaseExcluded))
+			})
+		})
+
+		When("the removal is canceled", func() {
+			JustBeforeEach(func() {
+				processGroup.CancelRemoval()
+			})
+
+			It("should reset the removal phases", func() {
+				Expect(processGroup.RemovalPhases).To(BeNil())
+			})
+		})
+	})
+
 	When("adding addresses to a process group", func() {
 		type testCase struct {
 			initialProcessGroup  ProcessGroupStatus
@@ -4070,37 +4504,142 @@ var _ = Describe("[api] FoundationDBCluster", func() {
 		)
 	})
 
-	When("checking if the process group needs a replacement", func() {
-		var processGroup *ProcessGroupStatus
-		var failureCondition ProcessGroupConditionType
-		var failureTime int64
-		var oldTimestamp int64
-
-		BeforeEach(func() {
-			processGroup = &ProcessGroupStatus{ProcessGroupID: "storage-1", ProcessClass: "storage"}
-			oldTimestamp = time.Now().Add(-1 * time.Hour).Unix()
-		})
-
-		JustBeforeEach(func() {
-			failureCondition, failureTime = processGroup.NeedsReplacement(60, 60)
-		})
-
-		Context("with no conditions", func() {
-			It("should not need replacement", func() {
-				Expect(failureTime).To(BeZero())
-				Expect(failureCondition).To(Equal(ProcessGroupConditionType("")))
-			})
-		})
-
-		Context("with a process group that went missing after the window", func() {
-			BeforeEach(func() {
-				processGroup.UpdateCondition(MissingProcesses, true)
-			})
+	When("checking whether the process group should be skipped for the image type migration", func() {
+		type testCase struct {
+			cluster  *FoundationDBCluster
+			expected bool
+		}
 
-			It("should not need replacement", func() {
-				Expect(failureTime).To(BeZero())
-				Expect(failureCondition).To(Equal(ProcessGroupConditionType("")))
-			})
+		DescribeTable("should return the expected result",
+			func(tc testCase) {
+				Expect(tc.cluster.SkipProcessGroupForImageTypeMigration(&ProcessGroupStatus{FaultDomain: "zone-a"}, ImageTypeSplit)).To(Equal(tc.expected))
+			},
+			Entry("the orchestrated migration is disabled",
+				testCase{
+					cluster: &FoundationDBCluster{
+						Status: FoundationDBClusterStatus{
+							ImageTypeMigration: &ImageTypeMigrationStatus{FaultDomain: "zone-b"},
+						},
+					},
+					expected: false,
+				}),
+			Entry("no migration is in progress",
+				testCase{
+					cluster: &FoundationDBCluster{
+						Spec: FoundationDBClusterSpec{
+							AutomationOptions: FoundationDBClusterAutomationOptions{
+								UseOrchestratedImageTypeMigration: pointer.Bool(true),
+							},
+						},
+					},
+					expected: false,
+				}),
+			Entry("the process group is part of the migrated fault domain",
+				testCase{
+					cluster: &FoundationDBCluster{
+						Spec: FoundationDBClusterSpec{
+							AutomationOptions: FoundationDBClusterAutomationOptions{
+								UseOrchestratedImageTypeMigration: pointer.Bool(true),
+							},
+						},
+						Status: FoundationDBClusterStatus{
+							ImageTypeMigration: &ImageTypeMigrationStatus{FaultDomain: "zone-a"},
+						},
+					},
+					expected: false,
+				}),
+			Entry("the process group is part of a different fault domain",
+				testCase{
+					cluster: &FoundationDBCluster{
+						Spec: FoundationDBClusterSpec{
+							AutomationOptions: FoundationDBClusterAutomationOptions{
+								UseOrchestratedImageTypeMigration: pointer.Bool(true),
+							},
+						},
+						Status: FoundationDBClusterStatus{
+							ImageTypeMigration: &ImageTypeMigrationStatus{FaultDomain: "zone-b"},
+						},
+					},
+					expected: true,
+				}),
+			Entry("the migration waits for the migrated process groups",
+				testCase{
+					cluster: &FoundationDBCluster{
+						Spec: FoundationDBClusterSpec{
+							AutomationOptions: FoundationDBClusterAutomationOptions{
+								UseOrchestratedImageTypeMigration: pointer.Bool(true),
+							},
+						},
+						Status: FoundationDBClusterStatus{
+							ImageTypeMigration: &ImageTypeMigrationStatus{},
+						},
+					},
+					expected: true,
+				}),
+			Entry("the process group is already running with the target image type",
+				testCase{
+					cluster: &FoundationDBCluster{
+						Spec: FoundationDBClusterSpec{
+							AutomationOptions: FoundationDBClusterAutomationOptions{
+								UseOrchestratedImageTypeMigration: pointer.Bool(true),
+							},
+						},
+						Status: FoundationDBClusterStatus{
+							ImageTypeMigration: &ImageTypeMigrationStatus{TargetImageType: ImageTypeSplit},
+						},
+					},
+					expected: false,
+				}),
+		)
+
+		It("should use the process group ID for process groups without a fault domain", func() {
+			cluster := &FoundationDBCluster{
+				Spec: FoundationDBClusterSpec{
+					AutomationOptions: FoundationDBClusterAutomationOptions{
+						UseOrchestratedImageTypeMigration: pointer.Bool(true),
+					},
+				},
+				Status: FoundationDBClusterStatus{
+					ImageTypeMigration: &ImageTypeMigrationStatus{TargetImageType: ImageTypeUnified, FaultDomain: "storage-1"},
+				},
+			}
+
+			Expect(cluster.SkipProcessGroupForImageTypeMigration(&ProcessGroupStatus{ProcessGroupID: "storage-1"}, ImageTypeSplit)).To(BeFalse())
+			Expect(cluster.SkipProcessGroupForImageTypeMigration(&ProcessGroupStatus{ProcessGroupID: "storage-2"}, ImageTypeSplit)).To(BeTrue())
+		})
+	})
+
+	When("checking if the process group needs a replacement", func() {
+		var processGroup *ProcessGroupStatus
+		var failureCondition ProcessGroupConditionType
+		var failureTime int64
+		var oldTimestamp int64
+
+		BeforeEach(func() {
+			processGroup = &ProcessGroupStatus{ProcessGroupID: "storage-1", ProcessClass: "storage"}
+			oldTimestamp = time.Now().Add(-1 * time.Hour).Unix()
+		})
+
+		JustBeforeEach(func() {
+			failureCondition, failureTime = processGroup.NeedsReplacement(60, 60)
+		})
+
+		Context("with no conditions", func() {
+			It("should not need replacement", func() {
+				Expect(failureTime).To(BeZero())
+				Expect(failureCondition).To(Equal(ProcessGroupConditionType("")))
+			})
+		})
+
+		Context("with a process group that went missing after the window", func() {
+			BeforeEach(func() {
+				processGroup.UpdateCondition(MissingProcesses, true)
+			})
+
+			It("should not need replacement", func() {
+				Expect(failureTime).To(BeZero())
+				Expect(failureCondition).To(Equal(ProcessGroupConditionType("")))
+			})
 		})
 
 		Context("with a process group that went missing before the window", func() {
@@ -4537,6 +5076,79 @@ var _ = Describe("[api] FoundationDBCluster", func() {
 				},
 				false,
 			),
+			Entry("Update strategy in place resize storage process",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						AutomationOptions: FoundationDBClusterAutomationOptions{
+							PodUpdateStrategy: PodUpdateStrategyInPlaceResize,
+						},
+					},
+				},
+				&ProcessGroupStatus{
+					ProcessClass: ProcessClassStorage,
+				},
+				false,
+			),
+			Entry("Update strategy in place resize log process",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						AutomationOptions: FoundationDBClusterAutomationOptions{
+							PodUpdateStrategy: PodUpdateStrategyInPlaceResize,
+						},
+					},
+				},
+				&ProcessGroupStatus{
+					ProcessClass: ProcessClassTransaction,
+				},
+				true,
+			),
+		)
+	})
+
+	When("checking if the containers should be resized in place", func() {
+		DescribeTable("it should return the expected result",
+			func(cluster *FoundationDBCluster, processClass ProcessClass, expected bool) {
+				Expect(cluster.ResizeInPlace(processClass)).To(Equal(expected))
+			},
+			Entry("Default update strategy stateless process",
+				&FoundationDBCluster{},
+				ProcessClassStateless,
+				false,
+			),
+			Entry("Update strategy in place resize stateless process",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						AutomationOptions: FoundationDBClusterAutomationOptions{
+							PodUpdateStrategy: PodUpdateStrategyInPlaceResize,
+						},
+					},
+				},
+				ProcessClassStateless,
+				true,
+			),
+			Entry("Update strategy in place resize storage process",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						AutomationOptions: FoundationDBClusterAutomationOptions{
+							PodUpdateStrategy: PodUpdateStrategyInPlaceResize,
+						},
+					},
+				},
+				ProcessClassStorage,
+				false,
+			),
+			Entry("Update strategy in place resize storage process with stateful resize enabled",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						AutomationOptions: FoundationDBClusterAutomationOptions{
+							PodUpdateStrategy:                    PodUpdateStrategyInPlaceResize,
+							UseInPlaceResizeForStatefulProcesses: pointer.Bool(true),
+						},
+					},
+				},
+				ProcessClassStorage,
+				true,
+			),
 		)
 	})
 
@@ -4918,86 +5530,901 @@ var _ = Describe("[api] FoundationDBCluster", func() {
 				},
 				fmt.Errorf("version: 6.1.0 is not supported, minimum supported version is: 6.2.20"),
 			),
-		)
-	})
-
-	When("adding processes to the no-schedule list", func() {
-		var cluster *FoundationDBCluster
-
-		BeforeEach(func() {
-			cluster = &FoundationDBCluster{}
-		})
-
-		When("the no-schedule list is empty", func() {
-			type testCase struct {
-				Instances                     []ProcessGroupID
-				ExpectedInstancesInNoSchedule []ProcessGroupID
-			}
-
-			DescribeTable("should add all targeted processes to the no-schedule list",
-				func(tc testCase) {
-					cluster.AddProcessGroupsToNoScheduleList(tc.Instances)
-					Expect(cluster.Spec.Buggify.NoSchedule).To(ContainElements(tc.ExpectedInstancesInNoSchedule))
-					Expect(len(cluster.Spec.Buggify.NoSchedule)).To(Equal(len(tc.ExpectedInstancesInNoSchedule)))
+			Entry("using the version vector feature flag with an unsupported version",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.4",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						FeatureFlags: FeatureFlags{
+							EnableVersionVector: pointer.Bool(true),
+						},
+					},
 				},
-				Entry("Adding single instance",
-					testCase{
-						Instances:                     []ProcessGroupID{"instance-1"},
-						ExpectedInstancesInNoSchedule: []ProcessGroupID{"instance-1"},
-					}),
-				Entry("Adding multiple instances",
-					testCase{
-						Instances:                     []ProcessGroupID{"instance-1", "instance-2"},
-						ExpectedInstancesInNoSchedule: []ProcessGroupID{"instance-1", "instance-2"},
-					}),
-			)
-		})
-
-		When("the no-schedule list is not empty", func() {
-			BeforeEach(func() {
-				cluster.Spec.Buggify.NoSchedule = []ProcessGroupID{"instance-1"}
-			})
-
-			type testCase struct {
-				Instances                     []ProcessGroupID
-				ExpectedInstancesInNoSchedule []ProcessGroupID
-			}
-
-			DescribeTable("should add all targeted processes to no-schedule list",
-				func(tc testCase) {
-					cluster.AddProcessGroupsToNoScheduleList(tc.Instances)
-					Expect(cluster.Spec.Buggify.NoSchedule).To(ContainElements(tc.ExpectedInstancesInNoSchedule))
-					Expect(len(cluster.Spec.Buggify.NoSchedule)).To(Equal(len(tc.ExpectedInstancesInNoSchedule)))
+				fmt.Errorf("feature flag enableVersionVector is not supported on version 7.1.4"),
+			),
+			Entry("using the feature flags with a supported version",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: Versions.SupportsVersionVector.String(),
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						FeatureFlags: FeatureFlags{
+							EnableVersionVector:         pointer.Bool(true),
+							EnableGrayFailureDetection:  pointer.Bool(true),
+							IdempotencyIDsMinAgeSeconds: pointer.Int(60),
+						},
+					},
 				},
-				Entry("Adding single instance",
-					testCase{
-						Instances:                     []ProcessGroupID{"instance-2"},
-						ExpectedInstancesInNoSchedule: []ProcessGroupID{"instance-1", "instance-2"},
-					}),
-				Entry("Adding multiple instances",
-					testCase{
-						Instances:                     []ProcessGroupID{"instance-2", "instance-3"},
-						ExpectedInstancesInNoSchedule: []ProcessGroupID{"instance-1", "instance-2", "instance-3"},
-					}),
-			)
-		})
-
-	})
-
-	When("removing processes from the no-schedule list", func() {
-		var cluster *FoundationDBCluster
-
-		BeforeEach(func() {
-			cluster = &FoundationDBCluster{
-				Spec: FoundationDBClusterSpec{
-					Buggify: BuggifyConfig{
-						NoSchedule: []ProcessGroupID{"instance-1", "instance-2", "instance-3"},
+				nil,
+			),
+			Entry("using the idempotency ids feature flag with an unsupported version",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.4",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						FeatureFlags: FeatureFlags{
+							IdempotencyIDsMinAgeSeconds: pointer.Int(60),
+						},
 					},
 				},
-			}
-		})
-
-		type testCase struct {
+				fmt.Errorf("feature flag idempotencyIDsMinAgeSeconds is not supported on version 7.1.4"),
+			),
+			Entry("using a custom parameter that is managed by the feature flags",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: Versions.SupportsVersionVector.String(),
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						FeatureFlags: FeatureFlags{
+							EnableVersionVector: pointer.Bool(true),
+						},
+						Processes: map[ProcessClass]ProcessSettings{
+							ProcessClassGeneral: {
+								CustomParameters: FoundationDBCustomParameters{
+									"knob_enable_version_vector=false",
+								},
+							},
+						},
+					},
+				},
+				fmt.Errorf("customParameter knob_enable_version_vector for process class general is managed by the featureFlags and must be removed"),
+			),
+			Entry("using a supported tuning profile",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.4",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						Profile: TuningProfileSSDLowLatency,
+					},
+				},
+				nil,
+			),
+			Entry("using an unknown tuning profile",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.4",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						Profile: "fast",
+					},
+				},
+				fmt.Errorf("profile fast is not supported, valid profiles are ssd-low-latency, throughput and mixed"),
+			),
+			Entry("using multiple storage servers per Pod with a memory storage engine",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.4",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineMemory,
+						},
+						StorageServersPerPod: 2,
+					},
+				},
+				fmt.Errorf("storageServersPerPod 2 is not supported with storage engine memory"),
+			),
+			Entry("using multiple storage servers per Pod with a ssd storage engine",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.4",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						StorageServersPerPod: 2,
+					},
+				},
+				nil,
+			),
+			Entry("using storage pools",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.4",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						StoragePools: []StoragePool{
+							{Name: "fast", Count: 3},
+							{Name: "dense", Count: 3},
+						},
+					},
+				},
+				nil,
+			),
+			Entry("using storage pools with a storage process count",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.4",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						ProcessCounts: ProcessCounts{
+							Storage: 5,
+						},
+						StoragePools: []StoragePool{
+							{Name: "fast", Count: 3},
+						},
+					},
+				},
+				fmt.Errorf("processCounts.storage must not be set if storagePools are defined"),
+			),
+			Entry("using storage pools with duplicate names",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.4",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						StoragePools: []StoragePool{
+							{Name: "fast", Count: 3},
+							{Name: "fast", Count: 2},
+						},
+					},
+				},
+				fmt.Errorf("storage pool fast is defined multiple times"),
+			),
+			Entry("using separated proxies",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.4",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+							RoleCounts: RoleCounts{
+								GrvProxies:    2,
+								CommitProxies: 4,
+							},
+						},
+					},
+				},
+				nil,
+			),
+			Entry("using negative separated proxy counts",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.4",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+							RoleCounts: RoleCounts{
+								GrvProxies:    -1,
+								CommitProxies: -2,
+							},
+						},
+					},
+				},
+				fmt.Errorf("grv_proxies -1 must not be negative, commit_proxies -2 must not be negative"),
+			),
+			Entry("using separated proxies on a version without separated proxies",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "6.3.24",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+							RoleCounts: RoleCounts{
+								GrvProxies:    2,
+								CommitProxies: 4,
+							},
+						},
+					},
+				},
+				nil,
+			),
+			Entry("using separated proxies and proxies on a version without separated proxies",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "6.3.24",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+							RoleCounts: RoleCounts{
+								Proxies:       6,
+								GrvProxies:    2,
+								CommitProxies: 4,
+							},
+						},
+					},
+				},
+				nil,
+			),
+			Entry("using an unknown redundancy mode",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.4",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine:  StorageEngineSSD2,
+							RedundancyMode: "quadruple",
+						},
+					},
+				},
+				fmt.Errorf("redundancy mode quadruple is not supported"),
+			),
+			Entry("using too few storage processes for the redundancy mode",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.4",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine:  StorageEngineSSD2,
+							RedundancyMode: RedundancyModeTriple,
+						},
+						ProcessCounts: ProcessCounts{
+							Storage: 2,
+						},
+					},
+				},
+				fmt.Errorf("processCounts.storage 2 must be at least 3 for redundancy mode triple"),
+			),
+			Entry("using enough storage processes for the redundancy mode",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.4",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine:  StorageEngineSSD2,
+							RedundancyMode: RedundancyModeTriple,
+						},
+						ProcessCounts: ProcessCounts{
+							Storage: 3,
+						},
+					},
+				},
+				nil,
+			),
+			Entry("using an invalid fault domain key",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.4",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						FaultDomain: FoundationDBClusterFaultDomain{
+							Key: "topology zone",
+						},
+					},
+				},
+				fmt.Errorf("faultDomain.key topology zone is not a valid label key"),
+			),
+			Entry("using the kubernetes-cluster fault domain without a value",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.4",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						FaultDomain: FoundationDBClusterFaultDomain{
+							Key:       "foundationdb.org/kubernetes-cluster",
+							ZoneCount: 3,
+							ZoneIndex: 3,
+						},
+					},
+				},
+				fmt.Errorf("faultDomain.value must be set for the foundationdb.org/kubernetes-cluster fault domain, faultDomain.zoneIndex 3 must be between 0 and 2"),
+			),
+			Entry("using a Pod name prefix that is used by another process class",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.4",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						Processes: map[ProcessClass]ProcessSettings{
+							ProcessClassStorage: {
+								PodNamePrefix: "log",
+							},
+						},
+					},
+				},
+				fmt.Errorf("podNamePrefix log of process class storage is already used by process class log"),
+			),
+			Entry("using a process group ID prefix that is too long",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.4",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						ProcessGroupIDPrefix: strings.Repeat("a", 48),
+					},
+				},
+				fmt.Errorf("processGroupIDPrefix %s must not be longer than 47 characters", strings.Repeat("a", 48)),
+			),
+			Entry("using a process group ID prefix that is too long for the cluster controller process class",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.4",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						ProcessCounts: ProcessCounts{
+							ClusterController: 1,
+						},
+						ProcessGroupIDPrefix: strings.Repeat("a", 39),
+					},
+				},
+				fmt.Errorf("processGroupIDPrefix %s must not be longer than 38 characters", strings.Repeat("a", 39)),
+			),
+			Entry("using a process group ID prefix with a single character",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.4",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						ProcessGroupIDPrefix: "a",
+					},
+				},
+				nil,
+			),
+			Entry("using a confirmed process group ID prefix with invalid characters",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.4",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						ProcessGroupIDPrefix: "dev",
+						AutomationOptions: FoundationDBClusterAutomationOptions{
+							ProcessGroupIDPrefixMigration: &ProcessGroupIDPrefixMigrationOptions{
+								ConfirmedPrefix: pointer.String("dev/"),
+							},
+						},
+					},
+				},
+				fmt.Errorf("confirmedPrefix is invalid: processGroupIDPrefix dev/ must consist of alphanumeric characters, '-', '_' or '.' and must start and end with an alphanumeric character"),
+			),
+			Entry("using a runtimeClassName that conflicts with the podTemplate",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.4",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						Processes: map[ProcessClass]ProcessSettings{
+							ProcessClassStorage: {
+								RuntimeClassName: pointer.String("gvisor"),
+								PodTemplate: &corev1.PodTemplateSpec{
+									Spec: corev1.PodSpec{
+										RuntimeClassName: pointer.String("kata"),
+									},
+								},
+							},
+						},
+					},
+				},
+				fmt.Errorf("runtimeClassName gvisor of process class storage conflicts with runtimeClassName kata of the podTemplate"),
+			),
+			Entry("using podTemplateOverrides for the general process class",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.4",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						Processes: map[ProcessClass]ProcessSettings{
+							ProcessClassGeneral: {
+								PodTemplateOverrides: &corev1.PodTemplateSpec{
+									Spec: corev1.PodSpec{
+										NodeSelector: map[string]string{"pool": "log"},
+									},
+								},
+							},
+						},
+					},
+				},
+				fmt.Errorf("podTemplateOverrides are not supported for the general process class, use the podTemplate instead"),
+			),
+			Entry("using an even coordinator count",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.4",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine:  StorageEngineSSD2,
+							RedundancyMode: RedundancyModeTriple,
+						},
+						CoordinatorCount: pointer.Int(6),
+					},
+				},
+				fmt.Errorf("coordinatorCount 6 must be an odd number"),
+			),
+			Entry("using a coordinator count lower than required by the redundancy mode",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.4",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine:  StorageEngineSSD2,
+							RedundancyMode: RedundancyModeTriple,
+						},
+						CoordinatorCount: pointer.Int(3),
+					},
+				},
+				fmt.Errorf("coordinatorCount 3 must be at least 5 for redundancy mode triple"),
+			),
+			Entry("using a valid coordinator count",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.4",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine:  StorageEngineSSD2,
+							RedundancyMode: RedundancyModeTriple,
+						},
+						CoordinatorCount: pointer.Int(7),
+					},
+				},
+				nil,
+			),
+			Entry("using sysctls that are not namespaced or defined multiple times",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.4",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						Processes: map[ProcessClass]ProcessSettings{
+							ProcessClassStorage: {
+								KernelSettings: &KernelSettings{
+									Sysctls: []corev1.Sysctl{
+										{Name: "vm.swappiness", Value: "0"},
+										{Name: "net.core.somaxconn", Value: "4096"},
+										{Name: "net.core.somaxconn", Value: "8192"},
+									},
+								},
+							},
+						},
+					},
+				},
+				fmt.Errorf("sysctl vm.swappiness for process class storage is not namespaced and cannot be set for a Pod, sysctl net.core.somaxconn for process class storage is defined multiple times"),
+			),
+			Entry("using namespaced sysctls",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.4",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						Processes: map[ProcessClass]ProcessSettings{
+							ProcessClassGeneral: {
+								KernelSettings: &KernelSettings{
+									Sysctls: []corev1.Sysctl{
+										{Name: "net.core.somaxconn", Value: "4096"},
+										{Name: "net.ipv4.tcp_keepalive_time", Value: "60"},
+									},
+									DisableTransparentHugePages: pointer.Bool(true),
+								},
+							},
+						},
+					},
+				},
+				nil,
+			),
+			Entry("using stateless scaling limits where the minimum is greater than the maximum",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.4",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						AutomationOptions: FoundationDBClusterAutomationOptions{
+							StatelessScaling: &StatelessScalingOptions{
+								MinProcesses: pointer.Int(10),
+								MaxProcesses: pointer.Int(5),
+							},
+						},
+					},
+				},
+				fmt.Errorf("statelessScaling minProcesses 10 must not be greater than maxProcesses 5"),
+			),
+			Entry("using a sidecar shutdown delay that is lower than the termination grace period",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.4",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						Processes: map[ProcessClass]ProcessSettings{
+							ProcessClassLog: {
+								Shutdown: &ShutdownSettings{
+									TerminationGracePeriodSeconds: pointer.Int64(60),
+									SidecarShutdownDelaySeconds:   pointer.Int(30),
+								},
+							},
+						},
+					},
+				},
+				nil,
+			),
+			Entry("using a sidecar shutdown delay that is not lower than the termination grace period",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.4",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						Processes: map[ProcessClass]ProcessSettings{
+							ProcessClassGeneral: {
+								PodTemplate: &corev1.PodTemplateSpec{
+									Spec: corev1.PodSpec{
+										TerminationGracePeriodSeconds: pointer.Int64(120),
+									},
+								},
+							},
+							ProcessClassLog: {
+								Shutdown: &ShutdownSettings{
+									SidecarShutdownDelaySeconds: pointer.Int(30),
+								},
+							},
+							ProcessClassStorage: {
+								Shutdown: &ShutdownSettings{
+									TerminationGracePeriodSeconds: pointer.Int64(10),
+									SidecarShutdownDelaySeconds:   pointer.Int(10),
+								},
+							},
+						},
+					},
+				},
+				fmt.Errorf("sidecarShutdownDelaySeconds 10 for process class storage must be lower than the terminationGracePeriodSeconds 10"),
+			),
+			Entry("using a sidecar shutdown delay that is inherited from the general process settings",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.4",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						Processes: map[ProcessClass]ProcessSettings{
+							ProcessClassGeneral: {
+								Shutdown: &ShutdownSettings{
+									SidecarShutdownDelaySeconds: pointer.Int(20),
+								},
+							},
+							ProcessClassLog: {
+								PodTemplate: &corev1.PodTemplateSpec{
+									Spec: corev1.PodSpec{
+										TerminationGracePeriodSeconds: pointer.Int64(10),
+									},
+								},
+							},
+						},
+					},
+				},
+				fmt.Errorf("sidecarShutdownDelaySeconds 20 for process class log must be lower than the terminationGracePeriodSeconds 10"),
+			),
+			Entry("using valid start command settings",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.4",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						Processes: map[ProcessClass]ProcessSettings{
+							ProcessClassStorage: {
+								StartCommand: &StartCommandSettings{
+									Wrapper: []string{"numactl", "--interleave=all"},
+									Env: []StartCommandEnvVar{
+										{Name: "LD_PRELOAD", Value: "/usr/lib/libshim.so"},
+									},
+								},
+							},
+						},
+					},
+				},
+				nil,
+			),
+			Entry("using invalid start command settings",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.4",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						Processes: map[ProcessClass]ProcessSettings{
+							ProcessClassStorage: {
+								StartCommand: &StartCommandSettings{
+									Wrapper: []string{"", "--interleave=all"},
+									Env: []StartCommandEnvVar{
+										{Name: "FDB_PUBLIC_IP", Value: "127.0.0.1"},
+										{Name: "LD_PRELOAD", Value: "/usr/lib/libshim.so"},
+										{Name: "LD_PRELOAD", Value: "/usr/lib/libother.so"},
+									},
+								},
+							},
+						},
+					},
+				},
+				fmt.Errorf("start command wrapper for process class storage must define a command, "+
+					"start command environment variable FDB_PUBLIC_IP for process class storage uses the reserved prefix FDB_, "+
+					"start command environment variable LD_PRELOAD for process class storage is defined multiple times"),
+			),
+			Entry("using valid additional volume claims",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.4",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						Processes: map[ProcessClass]ProcessSettings{
+							ProcessClassLog: {
+								AdditionalVolumeClaims: []AdditionalVolumeClaim{
+									{Name: "spill", MountPath: "/var/fdb/spill"},
+								},
+							},
+							ProcessClassStorage: {
+								AdditionalVolumeClaims: []AdditionalVolumeClaim{
+									{Name: "server", MountPath: "/var/fdb/data", PerServer: pointer.Bool(true)},
+								},
+							},
+						},
+					},
+				},
+				nil,
+			),
+			Entry("using invalid additional volume claims",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.4",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						Processes: map[ProcessClass]ProcessSettings{
+							ProcessClassStorage: {
+								AdditionalVolumeClaims: []AdditionalVolumeClaim{
+									{Name: "data", MountPath: "/var/fdb/extra"},
+									{Name: "spill", MountPath: "var/fdb/spill"},
+									{Name: "spill", MountPath: "/var/fdb/data"},
+									{Name: "logs", MountPath: "/var/fdb/extra/"},
+								},
+							},
+						},
+					},
+				},
+				fmt.Errorf("additional volume claim data for process class storage uses a reserved name, "+
+					"mountPath var/fdb/spill of additional volume claim spill for process class storage must be an absolute path, "+
+					"additional volume claim spill for process class storage is defined multiple times, "+
+					"mountPath /var/fdb/data of additional volume claim spill for process class storage is already used by the operator, "+
+					"mountPath /var/fdb/extra/ of additional volume claim logs for process class storage is used multiple times"),
+			),
+			Entry("using inherited additional volume claims that conflict with the data volume of a process class",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.4",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						Processes: map[ProcessClass]ProcessSettings{
+							ProcessClassGeneral: {
+								AdditionalVolumeClaims: []AdditionalVolumeClaim{
+									{Name: "spill", MountPath: "/var/fdb/spill"},
+								},
+							},
+							ProcessClassStorage: {
+								VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+									ObjectMeta: metav1.ObjectMeta{
+										Name: "spill",
+									},
+								},
+							},
+						},
+					},
+				},
+				fmt.Errorf("additional volume claim spill for process class storage uses a reserved name"),
+			),
+			Entry("using a negative limit for concurrent replacements of a process class",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.4",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						AutomationOptions: FoundationDBClusterAutomationOptions{
+							Replacements: AutomaticReplacementOptions{
+								MaxConcurrentPerClass: map[ProcessClass]int{
+									ProcessClassStateless: 5,
+									ProcessClassStorage:   -1,
+								},
+							},
+						},
+					},
+				},
+				fmt.Errorf("maxConcurrentPerClass -1 for process class storage must not be negative"),
+			),
+			Entry("using a process class multiple times in the replacement priority order",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.4",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						AutomationOptions: FoundationDBClusterAutomationOptions{
+							Replacements: AutomaticReplacementOptions{
+								PriorityOrder: []ProcessClass{
+									ProcessClassStateless,
+									ProcessClassLog,
+									ProcessClassStateless,
+								},
+							},
+						},
+					},
+				},
+				fmt.Errorf("process class stateless is listed multiple times in the replacement priorityOrder"),
+			),
+			Entry("using an invalid replacement window",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.4",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						AutomationOptions: FoundationDBClusterAutomationOptions{
+							Replacements: AutomaticReplacementOptions{
+								AllowedWindows: []ReplacementWindow{
+									{
+										Schedule:        "0 2 * * 1-5",
+										DurationMinutes: 60,
+									},
+									{
+										Schedule:        "0 25 * * *",
+										DurationMinutes: 0,
+									},
+								},
+							},
+						},
+					},
+				},
+				fmt.Errorf("schedule \"0 25 * * *\" of replacement window is not a valid cron expression: end of range (25) above maximum (23): 25, durationMinutes 0 of replacement window with schedule \"0 25 * * *\" must be at least 1"),
+			),
+			Entry("using an invalid minimum node version",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.4",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						AutomationOptions: FoundationDBClusterAutomationOptions{
+							Replacements: AutomaticReplacementOptions{
+								NodeVersionSkew: &NodeVersionSkewOptions{
+									MinimumKernelVersion: "latest",
+									MinimumLabelVersion:  "2024.10",
+								},
+							},
+						},
+					},
+				},
+				fmt.Errorf("nodeVersionSkew minimumKernelVersion latest is not a valid version, nodeVersionSkew minimumLabelVersion requires a versionLabel"),
+			),
+			Entry("using a runtimeClassName",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.4",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						Processes: map[ProcessClass]ProcessSettings{
+							ProcessClassStorage: {
+								RuntimeClassName: pointer.String("gvisor"),
+							},
+						},
+					},
+				},
+				nil,
+			),
+			Entry("using a unique Pod name prefix",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.4",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						Processes: map[ProcessClass]ProcessSettings{
+							ProcessClassStorage: {
+								PodNamePrefix: "ss",
+							},
+						},
+					},
+				},
+				nil,
+			),
+		)
+	})
+
+	When("adding processes to the no-schedule list", func() {
+		var cluster *FoundationDBCluster
+
+		BeforeEach(func() {
+			cluster = &FoundationDBCluster{}
+		})
+
+		When("the no-schedule list is empty", func() {
+			type testCase struct {
+				Instances                     []ProcessGroupID
+				ExpectedInstancesInNoSchedule []ProcessGroupID
+			}
+
+			DescribeTable("should add all targeted processes to the no-schedule list",
+				func(tc testCase) {
+					cluster.AddProcessGroupsToNoScheduleList(tc.Instances)
+					Expect(cluster.Spec.Buggify.NoSchedule).To(ContainElements(tc.ExpectedInstancesInNoSchedule))
+					Expect(len(cluster.Spec.Buggify.NoSchedule)).To(Equal(len(tc.ExpectedInstancesInNoSchedule)))
+				},
+				Entry("Adding single instance",
+					testCase{
+						Instances:                     []ProcessGroupID{"instance-1"},
+						ExpectedInstancesInNoSchedule: []ProcessGroupID{"instance-1"},
+					}),
+				Entry("Adding multiple instances",
+					testCase{
+						Instances:                     []ProcessGroupID{"instance-1", "instance-2"},
+						ExpectedInstancesInNoSchedule: []ProcessGroupID{"instance-1", "instance-2"},
+					}),
+			)
+		})
+
+		When("the no-schedule list is not empty", func() {
+			BeforeEach(func() {
+				cluster.Spec.Buggify.NoSchedule = []ProcessGroupID{"instance-1"}
+			})
+
+			type testCase struct {
+				Instances                     []ProcessGroupID
+				ExpectedInstancesInNoSchedule []ProcessGroupID
+			}
+
+			DescribeTable("should add all targeted processes to no-schedule list",
+				func(tc testCase) {
+					cluster.AddProcessGroupsToNoScheduleList(tc.Instances)
+					Expect(cluster.Spec.Buggify.NoSchedule).To(ContainElements(tc.ExpectedInstancesInNoSchedule))
+					Expect(len(cluster.Spec.Buggify.NoSchedule)).To(Equal(len(tc.ExpectedInstancesInNoSchedule)))
+				},
+				Entry("Adding single instance",
+					testCase{
+						Instances:                     []ProcessGroupID{"instance-2"},
+						ExpectedInstancesInNoSchedule: []ProcessGroupID{"instance-1", "instance-2"},
+					}),
+				Entry("Adding multiple instances",
+					testCase{
+						Instances:                     []ProcessGroupID{"instance-2", "instance-3"},
+						ExpectedInstancesInNoSchedule: []ProcessGroupID{"instance-1", "instance-2", "instance-3"},
+					}),
+			)
+		})
+
+	})
+
+	When("removing processes from the no-schedule list", func() {
+		var cluster *FoundationDBCluster
+
+		BeforeEach(func() {
+			cluster = &FoundationDBCluster{
+				Spec: FoundationDBClusterSpec{
+					Buggify: BuggifyConfig{
+						NoSchedule: []ProcessGroupID{"instance-1", "instance-2", "instance-3"},
+					},
+				},
+			}
+		})
+
+		type testCase struct {
 			Instances                         []ProcessGroupID
 			ExpectedInstancesInNoScheduleList []ProcessGroupID
 		}
@@ -5295,13 +6722,70 @@ var _ = Describe("[api] FoundationDBCluster", func() {
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "testing-cluster",
 				},
-			},
-			&ProcessGroupStatus{
-				ProcessGroupID: "this-is-my-fancy-prefix-cluster-controller-1",
-				ProcessClass:   ProcessClassClusterController,
-			},
-			"testing-cluster-cluster-controller-1"),
-	)
+			},
+			&ProcessGroupStatus{
+				ProcessGroupID: "this-is-my-fancy-prefix-cluster-controller-1",
+				ProcessClass:   ProcessClassClusterController,
+			},
+			"testing-cluster-cluster-controller-1"),
+		Entry("when the process group was reassigned to a different process class",
+			&FoundationDBCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "testing-cluster",
+				},
+			},
+			&ProcessGroupStatus{
+				ProcessGroupID:       "this-is-my-fancy-prefix-stateless-1",
+				ProcessClass:         ProcessClassProxy,
+				OriginalProcessClass: ProcessClassStateless,
+			},
+			"testing-cluster-stateless-1"),
+		Entry("when the process group has a stored Pod name",
+			&FoundationDBCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "testing-cluster",
+				},
+			},
+			&ProcessGroupStatus{
+				ProcessGroupID: "storage-1",
+				ProcessClass:   ProcessClassStorage,
+				PodName:        "testing-cluster-ss-1",
+			},
+			"testing-cluster-ss-1"),
+	)
+
+	When("getting the Pod name for a new process group", func() {
+		var cluster *FoundationDBCluster
+
+		BeforeEach(func() {
+			cluster = &FoundationDBCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "testing-cluster",
+				},
+				Spec: FoundationDBClusterSpec{
+					ProcessGroupIDPrefix: "testing",
+					Processes: map[ProcessClass]ProcessSettings{
+						ProcessClassStorage: {
+							PodNamePrefix: "ss",
+						},
+					},
+				},
+			}
+		})
+
+		It("should use the custom Pod name prefix", func() {
+			podName, processGroupID := cluster.GetProcessGroupID(ProcessClassStorage, 1)
+			Expect(podName).To(Equal("testing-cluster-ss-1"))
+			Expect(processGroupID).To(Equal(ProcessGroupID("testing-storage-1")))
+			Expect(cluster.GetCustomPodName(ProcessClassStorage, processGroupID)).To(Equal("testing-cluster-ss-1"))
+		})
+
+		It("should use the process class for process classes without a custom prefix", func() {
+			podName, _ := cluster.GetProcessGroupID(ProcessClassClusterController, 1)
+			Expect(podName).To(Equal("testing-cluster-cluster-controller-1"))
+			Expect(cluster.GetCustomPodName(ProcessClassClusterController, "testing-cluster_controller-1")).To(BeEmpty())
+		})
+	})
 
 	DescribeTable("when adding a condition to a process group", func(processGroup *ProcessGroupStatus, condition ProcessGroupConditionType, expectedConditions []*ProcessGroupCondition) {
 		processGroup.addCondition(condition)
@@ -5604,6 +7088,58 @@ var _ = Describe("[api] FoundationDBCluster", func() {
 			}, PodUpdateModeNone),
 	)
 
+	DescribeTable("when getting the public IP source for a process group", func(cluster *FoundationDBCluster, processGroup *ProcessGroupStatus, expected PublicIPSource) {
+		Expect(cluster.GetPublicIPSourceForProcessGroup(processGroup)).To(Equal(expected))
+	},
+		Entry("pinning is disabled",
+			&FoundationDBCluster{},
+			&ProcessGroupStatus{PublicIPPinned: true},
+			PublicIPSourcePod),
+		Entry("pinning is enabled and the public IP is not pinned",
+			&FoundationDBCluster{
+				Spec: FoundationDBClusterSpec{
+					Routing: RoutingConfig{
+						PinCoordinatorIPs: pointer.Bool(true),
+					},
+				},
+			},
+			&ProcessGroupStatus{},
+			PublicIPSourcePod),
+		Entry("pinning is enabled and the public IP is pinned",
+			&FoundationDBCluster{
+				Spec: FoundationDBClusterSpec{
+					Routing: RoutingConfig{
+						PinCoordinatorIPs: pointer.Bool(true),
+					},
+				},
+			},
+			&ProcessGroupStatus{PublicIPPinned: true},
+			PublicIPSourceService),
+		Entry("pinning is enabled and DNS names are used in the cluster file",
+			&FoundationDBCluster{
+				Spec: FoundationDBClusterSpec{
+					Version: "7.1.26",
+					Routing: RoutingConfig{
+						PinCoordinatorIPs:   pointer.Bool(true),
+						UseDNSInClusterFile: pointer.Bool(true),
+					},
+				},
+			},
+			&ProcessGroupStatus{PublicIPPinned: true},
+			PublicIPSourcePod),
+		Entry("pinning is enabled without an explicit listen address",
+			&FoundationDBCluster{
+				Spec: FoundationDBClusterSpec{
+					UseExplicitListenAddress: pointer.Bool(false),
+					Routing: RoutingConfig{
+						PinCoordinatorIPs: pointer.Bool(true),
+					},
+				},
+			},
+			&ProcessGroupStatus{PublicIPPinned: true},
+			PublicIPSourcePod),
+	)
+
 	DescribeTable("when getting the lock ID", func(cluster *FoundationDBCluster, expected string) {
 		Expect(cluster.GetLockID()).To(Equal(expected))
 	},
@@ -5619,6 +7155,256 @@ var _ = Describe("[api] FoundationDBCluster", func() {
 			}, "testing"),
 	)
 
+	DescribeTable("when getting the ownership of a resource", func(filterOnOwnerReferences bool, metadata metav1.ObjectMeta, expected ResourceOwnership) {
+		cluster := &FoundationDBCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test",
+				UID:  "1",
+			},
+			Spec: FoundationDBClusterSpec{
+				LabelConfig: LabelConfig{
+					FilterOnOwnerReferences: pointer.Bool(filterOnOwnerReferences),
+				},
+			},
+		}
+
+		Expect(cluster.GetResourceOwnership(metadata)).To(Equal(expected))
+	},
+		Entry("resource without owner reference and claim",
+			false,
+			metav1.ObjectMeta{},
+			ResourceOwned),
+		Entry("resource with an owner reference to the cluster",
+			true,
+			metav1.ObjectMeta{
+				OwnerReferences: []metav1.OwnerReference{{Kind: "FoundationDBCluster", Name: "test", UID: "1"}},
+			},
+			ResourceOwned),
+		Entry("resource without owner reference when filtering on owner references",
+			true,
+			metav1.ObjectMeta{},
+			ResourceNotOwned),
+		Entry("resource with an owner reference to a different cluster",
+			false,
+			metav1.ObjectMeta{
+				OwnerReferences: []metav1.OwnerReference{{Kind: "FoundationDBCluster", Name: "other", UID: "2"}},
+			},
+			ResourceConflict),
+		Entry("resource claimed by the cluster",
+			false,
+			metav1.ObjectMeta{
+				Annotations: map[string]string{ClusterClaimAnnotation: "test"},
+			},
+			ResourceOwned),
+		Entry("resource claimed by a different cluster",
+			false,
+			metav1.ObjectMeta{
+				Annotations: map[string]string{ClusterClaimAnnotation: "other"},
+			},
+			ResourceConflict),
+		Entry("resource claimed by a different cluster when filtering on owner references",
+			true,
+			metav1.ObjectMeta{
+				Annotations: map[string]string{ClusterClaimAnnotation: "other"},
+			},
+			ResourceNotOwned),
+	)
+
+	When("using the ZoneEncoded process group ID allocation", func() {
+		var cluster *FoundationDBCluster
+
+		BeforeEach(func() {
+			strategy := ProcessGroupIDAllocationZoneEncoded
+			cluster = &FoundationDBCluster{
+				Spec: FoundationDBClusterSpec{
+					AutomationOptions: FoundationDBClusterAutomationOptions{
+						ProcessGroupIDAllocation: &ProcessGroupIDAllocationOptions{
+							Strategy: &strategy,
+							Zones:    []string{"a", "b"},
+						},
+					},
+				},
+				Status: FoundationDBClusterStatus{
+					ProcessGroups: []*ProcessGroupStatus{
+						{ProcessGroupID: "storage-1001", ProcessClass: ProcessClassStorage},
+						{ProcessGroupID: "storage-1002", ProcessClass: ProcessClassStorage},
+						{ProcessGroupID: "storage-2001", ProcessClass: ProcessClassStorage},
+						{ProcessGroupID: "log-1001", ProcessClass: ProcessClassLog},
+					},
+				},
+			}
+		})
+
+		DescribeTable("getting the zone of a process group ID", func(processGroupID ProcessGroupID, expectedZone string, expectedOk bool) {
+			zone, ok := cluster.GetZoneForProcessGroupID(processGroupID)
+			Expect(ok).To(Equal(expectedOk))
+			Expect(zone).To(Equal(expectedZone))
+		},
+			Entry("first zone", ProcessGroupID("storage-1001"), "a", true),
+			Entry("second zone", ProcessGroupID("storage-2999"), "b", true),
+			Entry("zone is not configured", ProcessGroupID("storage-3001"), "", false),
+			Entry("ID number without a zone", ProcessGroupID("storage-5"), "", false),
+			Entry("ID number at the block boundary", ProcessGroupID("storage-2000"), "", false),
+		)
+
+		It("should pick the lowest unused ID number of the zone with the fewest process groups", func() {
+			processGroupID, idNum, err := cluster.GetNextZoneEncodedProcessGroupID(ProcessClassStorage, map[int]bool{1001: true, 1002: true, 2001: true})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(idNum).To(Equal(2002))
+			Expect(processGroupID).To(Equal(ProcessGroupID("storage-2002")))
+		})
+
+		When("a process group of the zone is marked for removal", func() {
+			BeforeEach(func() {
+				cluster.Status.ProcessGroups[0].MarkForRemoval()
+			})
+
+			It("should pick the zone of the removed process group", func() {
+				processGroupID, idNum, err := cluster.GetNextZoneEncodedProcessGroupID(ProcessClassStorage, map[int]bool{1001: true, 1002: true, 2001: true})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(idNum).To(Equal(1003))
+				Expect(processGroupID).To(Equal(ProcessGroupID("storage-1003")))
+			})
+		})
+
+		When("a zone is defined multiple times", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.ProcessGroupIDAllocation.Zones = []string{"a", "a"}
+			})
+
+			It("should report the duplicate zone", func() {
+				Expect(cluster.validateProcessGroupIDAllocation()).To(ConsistOf("zone a is defined multiple times for the ZoneEncoded process group ID allocation"))
+			})
+		})
+	})
+
+	DescribeTable("validating the monitor API gateway configuration", func(imageType ImageType, config *MonitorAPIGatewayConfig, expected []string) {
+		cluster := &FoundationDBCluster{
+			Spec: FoundationDBClusterSpec{
+				ImageType: &imageType,
+				Routing: RoutingConfig{
+					MonitorAPIGateway: config,
+				},
+			},
+		}
+
+		Expect(cluster.validateMonitorAPIGateway()).To(ConsistOf(expected))
+	},
+		Entry("no configuration", ImageTypeSplit, nil, nil),
+		Entry("disabled configuration with the split image",
+			ImageTypeSplit,
+			&MonitorAPIGatewayConfig{Enabled: pointer.Bool(false)},
+			nil),
+		Entry("valid configuration",
+			ImageTypeUnified,
+			&MonitorAPIGatewayConfig{
+				Enabled:           pointer.Bool(true),
+				ParentRefs:        []GatewayParentReference{{Name: "gateway"}},
+				Hostname:          "fdb.example.org",
+				CertificateIssuer: &CertificateIssuerReference{Name: "issuer"},
+			},
+			nil),
+		Entry("enabled with the split image",
+			ImageTypeSplit,
+			&MonitorAPIGatewayConfig{
+				Enabled:    pointer.Bool(true),
+				ParentRefs: []GatewayParentReference{{Name: "gateway"}},
+			},
+			[]string{"monitorAPIGateway requires the unified image"}),
+		Entry("enabled without parent references and a certificate issuer without a hostname",
+			ImageTypeUnified,
+			&MonitorAPIGatewayConfig{
+				Enabled:           pointer.Bool(true),
+				CertificateIssuer: &CertificateIssuerReference{Name: "issuer"},
+			},
+			[]string{"monitorAPIGateway requires at least one parentRef", "monitorAPIGateway requires a hostname if a certificateIssuer is defined"}),
+	)
+
+	DescribeTable("getting the tuning profile status", func(profile TuningProfile, version string, expected *TuningProfileStatus) {
+		cluster := &FoundationDBCluster{
+			Spec: FoundationDBClusterSpec{
+				Profile: profile,
+			},
+		}
+
+		parsedVersion, err := ParseFdbVersion(version)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cluster.GetTuningProfileStatus(parsedVersion)).To(Equal(expected))
+	},
+		Entry("no profile", TuningProfile(""), "7.1.25", nil),
+		Entry("the throughput profile",
+			TuningProfileThroughput,
+			"7.1.25",
+			&TuningProfileStatus{
+				Name:    TuningProfileThroughput,
+				Version: "7.1.25",
+				Knobs: FoundationDBCustomParameters{
+					"knob_commit_transaction_batch_interval_max=0.050",
+					"knob_commit_transaction_batch_count_max=32768",
+					"knob_fetch_keys_parallelism_bytes=8000000",
+					"knob_target_bytes_per_storage_server=2000000000",
+				},
+				Resources: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("2"),
+					corev1.ResourceMemory: resource.MustParse("16Gi"),
+				},
+			}),
+		Entry("the ssd-low-latency profile with a version that supports all knobs",
+			TuningProfileSSDLowLatency,
+			"7.1.25",
+			&TuningProfileStatus{
+				Name:    TuningProfileSSDLowLatency,
+				Version: "7.1.25",
+				Knobs: FoundationDBCustomParameters{
+					"knob_commit_transaction_batch_interval_max=0.005",
+					"knob_start_transaction_batch_interval_max=0.005",
+					"knob_storage_fetch_keys_rate_limit=25000000",
+				},
+				Resources: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("2"),
+					corev1.ResourceMemory: resource.MustParse("8Gi"),
+				},
+			}),
+		Entry("the ssd-low-latency profile with a version that doesn't support all knobs",
+			TuningProfileSSDLowLatency,
+			"6.3.24",
+			&TuningProfileStatus{
+				Name:    TuningProfileSSDLowLatency,
+				Version: "6.3.24",
+				Knobs: FoundationDBCustomParameters{
+					"knob_commit_transaction_batch_interval_max=0.005",
+					"knob_start_transaction_batch_interval_max=0.005",
+				},
+				Resources: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("2"),
+					corev1.ResourceMemory: resource.MustParse("8Gi"),
+				},
+			}),
+	)
+
+	DescribeTable("getting the process class order for the process group ID prefix migration", func(order []ProcessClass, processClasses []ProcessClass, expected []ProcessClass) {
+		cluster := &FoundationDBCluster{
+			Spec: FoundationDBClusterSpec{
+				AutomationOptions: FoundationDBClusterAutomationOptions{
+					ProcessGroupIDPrefixMigration: &ProcessGroupIDPrefixMigrationOptions{
+						ProcessClassOrder: order,
+					},
+				},
+			},
+		}
+
+		Expect(cluster.GetProcessGroupIDPrefixMigrationOrder(processClasses)).To(Equal(expected))
+	},
+		Entry("no order defined",
+			nil,
+			[]ProcessClass{ProcessClassTransaction, ProcessClassStorage, ProcessClassLog},
+			[]ProcessClass{ProcessClassLog, ProcessClassStorage, ProcessClassTransaction}),
+		Entry("an order for some process classes",
+			[]ProcessClass{ProcessClassTransaction, ProcessClassStateless},
+			[]ProcessClass{ProcessClassStorage, ProcessClassStateless, ProcessClassLog, ProcessClassTransaction},
+			[]ProcessClass{ProcessClassTransaction, ProcessClassStateless, ProcessClassLog, ProcessClassStorage}),
+	)
+
 	When("creating a new ProcessGroup", func() {
 		var processGroupID ProcessGroupID
 		var processClass ProcessClass
@@ -5656,4 +7442,220 @@ var _ = Describe("[api] FoundationDBCluster", func() {
 			})
 		})
 	})
+
+	When("getting the process groups for the ID compaction", func() {
+		var cluster *FoundationDBCluster
+
+		BeforeEach(func() {
+			cluster = &FoundationDBCluster{
+				Spec: FoundationDBClusterSpec{
+					ProcessGroupIDPrefix: "test",
+				},
+				Status: FoundationDBClusterStatus{
+					ProcessGroups: []*ProcessGroupStatus{
+						{
+							ProcessGroupID: "test-storage-1",
+							ProcessClass:   ProcessClassStorage,
+						},
+						{
+							ProcessGroupID: "test-storage-3",
+							ProcessClass:   ProcessClassStorage,
+						},
+						{
+							ProcessGroupID: "test-storage-7",
+							ProcessClass:   ProcessClassStorage,
+						},
+						{
+							ProcessGroupID:   "test-storage-9",
+							ProcessClass:     ProcessClassStorage,
+							RemovalTimestamp: &metav1.Time{Time: time.Now()},
+						},
+						{
+							ProcessGroupID: "old-storage-12",
+							ProcessClass:   ProcessClassStorage,
+						},
+						{
+							ProcessGroupID: "test-log-2",
+							ProcessClass:   ProcessClassLog,
+						},
+					},
+				},
+			}
+		})
+
+		It("should return the active process groups outside of the compact range", func() {
+			Expect(cluster.GetProcessGroupsForIDCompaction()).To(ConsistOf(ProcessGroupID("test-storage-7"), ProcessGroupID("test-log-2")))
+		})
+	})
+
+	When("reassigning the process class of a ProcessGroup", func() {
+		var processGroup *ProcessGroupStatus
+
+		BeforeEach(func() {
+			processGroup = &ProcessGroupStatus{
+				ProcessGroupID: "stateless-1",
+				ProcessClass:   ProcessClassStateless,
+			}
+			processGroup.ReassignProcessClass(ProcessClassProxy)
+		})
+
+		It("should update the process class and keep the original process class", func() {
+			Expect(processGroup.ProcessGroupID).To(Equal(ProcessGroupID("stateless-1")))
+			Expect(processGroup.ProcessClass).To(Equal(ProcessClassProxy))
+			Expect(processGroup.OriginalProcessClass).To(Equal(ProcessClassStateless))
+			Expect(processGroup.GetProcessGroupIDProcessClass()).To(Equal(ProcessClassStateless))
+			Expect(processGroup.GetConditionTime(ProcessClassReassignment)).NotTo(BeNil())
+		})
+
+		When("the process group is reassigned to another process class", func() {
+			BeforeEach(func() {
+				processGroup.ReassignProcessClass(ProcessClassCommitProxy)
+			})
+
+			It("should keep the original process class", func() {
+				Expect(processGroup.ProcessClass).To(Equal(ProcessClassCommitProxy))
+				Expect(processGroup.OriginalProcessClass).To(Equal(ProcessClassStateless))
+			})
+		})
+
+		When("the process group is reassigned to the original process class", func() {
+			BeforeEach(func() {
+				processGroup.ReassignProcessClass(ProcessClassStateless)
+			})
+
+			It("should reset the original process class", func() {
+				Expect(processGroup.ProcessClass).To(Equal(ProcessClassStateless))
+				Expect(processGroup.OriginalProcessClass).To(BeEmpty())
+				Expect(processGroup.GetProcessGroupIDProcessClass()).To(Equal(ProcessClassStateless))
+			})
+		})
+	})
+
+	When("adding active operations", func() {
+		var cluster *FoundationDBCluster
+		var id string
+
+		BeforeEach(func() {
+			cluster = &FoundationDBCluster{}
+			id = cluster.AddActiveOperation(ActiveOperationTypeBounce, "bounceProcesses", []ProcessGroupID{"storage-2", "storage-1"})
+		})
+
+		It("should add the operation with sorted process groups", func() {
+			Expect(id).To(HavePrefix("bounce-"))
+			Expect(cluster.Status.ActiveOperations).To(HaveLen(1))
+			Expect(cluster.Status.ActiveOperations[0].ID).To(Equal(id))
+			Expect(cluster.Status.ActiveOperations[0].ProcessGroups).To(Equal([]ProcessGroupID{"storage-1", "storage-2"}))
+			Expect(cluster.Status.ActiveOperations[0].StartTimestamp).To(BeNumerically(">", 0))
+		})
+
+		When("the same operation is added again", func() {
+			It("should return the ID of the existing operation", func() {
+				Expect(cluster.AddActiveOperation(ActiveOperationTypeBounce, "bounceProcesses", []ProcessGroupID{"storage-1", "storage-2"})).To(Equal(id))
+				Expect(cluster.Status.ActiveOperations).To(HaveLen(1))
+			})
+		})
+
+		When("an operation of a different type is added", func() {
+			BeforeEach(func() {
+				cluster.AddActiveOperation(ActiveOperationTypeConfigurationChange, "updateDatabaseConfiguration", nil)
+			})
+
+			It("should return the operations of the requested type", func() {
+				Expect(cluster.GetActiveOperations()).To(HaveLen(2))
+				operations := cluster.GetActiveOperations(ActiveOperationTypeConfigurationChange)
+				Expect(operations).To(HaveLen(1))
+				Expect(operations[0].Owner).To(Equal("updateDatabaseConfiguration"))
+				Expect(operations[0].ProcessGroups).To(BeNil())
+			})
+		})
+	})
+
+	When("using storage pools", func() {
+		var cluster *FoundationDBCluster
+		var processGroup *ProcessGroupStatus
+
+		BeforeEach(func() {
+			cluster = &FoundationDBCluster{
+				Spec: FoundationDBClusterSpec{
+					Version: "7.1.26",
+					Processes: map[ProcessClass]ProcessSettings{
+						ProcessClassGeneral: {
+							PodTemplate: &corev1.PodTemplateSpec{
+								Spec: corev1.PodSpec{
+									NodeSelector: map[string]string{"pool": "general"},
+								},
+							},
+							VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+								Spec: corev1.PersistentVolumeClaimSpec{
+									StorageClassName: pointer.String("general"),
+								},
+							},
+						},
+					},
+					StoragePools: []StoragePool{
+						{
+							Name:  "fast",
+							Count: 2,
+							PodTemplate: &corev1.PodTemplateSpec{
+								Spec: corev1.PodSpec{
+									NodeSelector: map[string]string{"pool": "fast"},
+								},
+							},
+						},
+						{
+							Name:  "dense",
+							Count: 3,
+							VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+								Spec: corev1.PersistentVolumeClaimSpec{
+									StorageClassName: pointer.String("dense"),
+								},
+							},
+						},
+					},
+				},
+			}
+			processGroup = NewProcessGroupStatus("storage-1", ProcessClassStorage, nil)
+		})
+
+		It("should use the sum of the storage pool counts as storage process count", func() {
+			counts, err := cluster.GetProcessCountsWithDefaults()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(counts.Storage).To(Equal(5))
+		})
+
+		When("the process group belongs to the fast storage pool", func() {
+			BeforeEach(func() {
+				processGroup.StoragePool = "fast"
+			})
+
+			It("should use the Pod template of the storage pool", func() {
+				settings := cluster.GetProcessSettingsForProcessGroup(processGroup)
+				Expect(settings.PodTemplate.Spec.NodeSelector).To(Equal(map[string]string{"pool": "fast"}))
+				Expect(settings.VolumeClaimTemplate.Spec.StorageClassName).To(Equal(pointer.String("general")))
+			})
+		})
+
+		When("the process group belongs to the dense storage pool", func() {
+			BeforeEach(func() {
+				processGroup.StoragePool = "dense"
+			})
+
+			It("should use the volume claim template of the storage pool", func() {
+				settings := cluster.GetProcessSettingsForProcessGroup(processGroup)
+				Expect(settings.PodTemplate.Spec.NodeSelector).To(Equal(map[string]string{"pool": "general"}))
+				Expect(settings.VolumeClaimTemplate.Spec.StorageClassName).To(Equal(pointer.String("dense")))
+			})
+		})
+
+		When("the storage pool of the process group is not defined", func() {
+			BeforeEach(func() {
+				processGroup.StoragePool = "removed"
+			})
+
+			It("should use the process settings of the process class", func() {
+				Expect(cluster.GetStoragePoolForProcessGroup(processGroup)).To(BeNil())
+				Expect(cluster.GetProcessSettingsForProcessGroup(processGroup)).To(Equal(cluster.GetProcessSettings(ProcessClassStorage)))
+			})
+		})
+	})
 })
@@ -0,0 +1,68 @@
+/*
+Copyright 2020-2022 FoundationDB project authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=fdbtemplate
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:storageversion
+
+// FoundationDBClusterTemplate is the Schema for the foundationdbclustertemplates API. A template provides the base
+// spec for all FoundationDBClusters that reference it in their templateRef.
+type FoundationDBClusterTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec FoundationDBClusterSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// FoundationDBClusterTemplateList contains a list of FoundationDBClusterTemplate objects
+type FoundationDBClusterTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FoundationDBClusterTemplate `json:"items"`
+}
+
+// ClusterTemplateReference references the FoundationDBClusterTemplate that provides the base spec of a cluster.
+type ClusterTemplateReference struct {
+	// Name of the FoundationDBClusterTemplate in the namespace of the cluster.
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name"`
+}
+
+// ClusterTemplateStatus contains information about the FoundationDBClusterTemplate that was merged into the spec of
+// the cluster.
+type ClusterTemplateStatus struct {
+	// Name of the FoundationDBClusterTemplate.
+	Name string `json:"name,omitempty"`
+
+	// Generation of the FoundationDBClusterTemplate that was merged into the spec.
+	Generation int64 `json:"generation,omitempty"`
+
+	// InheritedFields contains the paths of the fields in the resolved spec whose values are provided by the template.
+	InheritedFields []string `json:"inheritedFields,omitempty"`
+}
+
+func init() {
+	SchemeBuilder.Register(&FoundationDBClusterTemplate{}, &FoundationDBClusterTemplateList{})
+}
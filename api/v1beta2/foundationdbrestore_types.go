@@ -18,11 +18,14 @@ package v1beta2
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 )
 
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:shortName=fdbrestore
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Running",type="boolean",JSONPath=".status.running",description="Restore running",priority=0
+// +kubebuilder:printcolumn:name="Destination",type="string",JSONPath=".spec.destinationClusterName",description="Destination cluster",priority=1
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:storageversion
 
@@ -59,12 +62,22 @@ type FoundationDBRestoreSpec struct {
 	// CustomParameters defines additional parameters to pass to the backup
 	// agents.
 	CustomParameters FoundationDBCustomParameters `json:"customParameters,omitempty"`
+
+	// WaitForHealthyCluster defines if the operator should wait until the destination cluster is healthy, e.g. the
+	// cluster is available, fully recovered and has the desired fault tolerance, before starting the restore.
+	// Default: false
+	// +kubebuilder:validation:Optional
+	WaitForHealthyCluster *bool `json:"waitForHealthyCluster,omitempty"`
 }
 
 // FoundationDBRestoreStatus describes the current status of the restore for a cluster.
 type FoundationDBRestoreStatus struct {
 	// Running describes whether the restore is currently running.
 	Running bool `json:"running,omitempty"`
+
+	// WaitingForHealthyCluster indicates that the operator is waiting for the
+	// destination cluster to become healthy before starting the restore.
+	WaitingForHealthyCluster bool `json:"waitingForHealthyCluster,omitempty"`
 }
 
 // FoundationDBKeyRange describes a range of keys for a command.
@@ -92,6 +105,12 @@ func (restore *FoundationDBRestore) BackupName() string {
 	return restore.Spec.BlobStoreConfiguration.BackupName
 }
 
+// ShouldWaitForHealthyCluster determines whether the operator should wait for a healthy cluster before starting the
+// restore.
+func (restore *FoundationDBRestore) ShouldWaitForHealthyCluster() bool {
+	return pointer.BoolDeref(restore.Spec.WaitForHealthyCluster, false)
+}
+
 // BackupURL gets the destination url of the backup.
 func (restore *FoundationDBRestore) BackupURL() string {
 	return restore.Spec.BlobStoreConfiguration.getURL(restore.BackupName(), restore.Spec.BlobStoreConfiguration.BucketName())
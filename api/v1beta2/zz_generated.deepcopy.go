@@ -28,6 +28,51 @@ import (
 	netx "net"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActiveOperation) DeepCopyInto(out *ActiveOperation) {
+	*out = *in
+	if in.ProcessGroups != nil {
+		in, out := &in.ProcessGroups, &out.ProcessGroups
+		*out = make([]ProcessGroupID, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActiveOperation.
+func (in *ActiveOperation) DeepCopy() *ActiveOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(ActiveOperation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdditionalVolumeClaim) DeepCopyInto(out *AdditionalVolumeClaim) {
+	*out = *in
+	if in.PerServer != nil {
+		in, out := &in.PerServer, &out.PerServer
+		*out = new(bool)
+		**out = **in
+	}
+	if in.VolumeClaimTemplate != nil {
+		in, out := &in.VolumeClaimTemplate, &out.VolumeClaimTemplate
+		*out = new(corev1.PersistentVolumeClaim)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdditionalVolumeClaim.
+func (in *AdditionalVolumeClaim) DeepCopy() *AdditionalVolumeClaim {
+	if in == nil {
+		return nil
+	}
+	out := new(AdditionalVolumeClaim)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AutomaticReplacementOptions) DeepCopyInto(out *AutomaticReplacementOptions) {
 	*out = *in
@@ -41,6 +86,11 @@ func (in *AutomaticReplacementOptions) DeepCopyInto(out *AutomaticReplacementOpt
 		*out = new(bool)
 		**out = **in
 	}
+	if in.BatchByFaultDomain != nil {
+		in, out := &in.BatchByFaultDomain, &out.BatchByFaultDomain
+		*out = new(bool)
+		**out = **in
+	}
 	if in.FailureDetectionTimeSeconds != nil {
 		in, out := &in.FailureDetectionTimeSeconds, &out.FailureDetectionTimeSeconds
 		*out = new(int)
@@ -51,6 +101,11 @@ func (in *AutomaticReplacementOptions) DeepCopyInto(out *AutomaticReplacementOpt
 		*out = new(int)
 		**out = **in
 	}
+	if in.FailureDetectionWindowSeconds != nil {
+		in, out := &in.FailureDetectionWindowSeconds, &out.FailureDetectionWindowSeconds
+		*out = new(int)
+		**out = **in
+	}
 	if in.MaxConcurrentReplacements != nil {
 		in, out := &in.MaxConcurrentReplacements, &out.MaxConcurrentReplacements
 		*out = new(int)
@@ -68,6 +123,83 @@ func (in *AutomaticReplacementOptions) DeepCopyInto(out *AutomaticReplacementOpt
 		*out = new(intstr.IntOrString)
 		**out = **in
 	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RequireApproval != nil {
+		in, out := &in.RequireApproval, &out.RequireApproval
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AllowedWindows != nil {
+		in, out := &in.AllowedWindows, &out.AllowedWindows
+		*out = make([]ReplacementWindow, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxReplacementsPerHour != nil {
+		in, out := &in.MaxReplacementsPerHour, &out.MaxReplacementsPerHour
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxConcurrentPerClass != nil {
+		in, out := &in.MaxConcurrentPerClass, &out.MaxConcurrentPerClass
+		*out = make(map[ProcessClass]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MaxConcurrentStorageClassMigrations != nil {
+		in, out := &in.MaxConcurrentStorageClassMigrations, &out.MaxConcurrentStorageClassMigrations
+		*out = new(int)
+		**out = **in
+	}
+	if in.RespectPodDisruptionBudgets != nil {
+		in, out := &in.RespectPodDisruptionBudgets, &out.RespectPodDisruptionBudgets
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RequireFullReplication != nil {
+		in, out := &in.RequireFullReplication, &out.RequireFullReplication
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PriorityOrder != nil {
+		in, out := &in.PriorityOrder, &out.PriorityOrder
+		*out = make([]ProcessClass, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxStandbyProcessGroups != nil {
+		in, out := &in.MaxStandbyProcessGroups, &out.MaxStandbyProcessGroups
+		*out = new(int)
+		**out = **in
+	}
+	if in.CancelOnRecovery != nil {
+		in, out := &in.CancelOnRecovery, &out.CancelOnRecovery
+		*out = new(CancelOnRecoveryOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReplacementLoopDetection != nil {
+		in, out := &in.ReplacementLoopDetection, &out.ReplacementLoopDetection
+		*out = new(ReplacementLoopDetectionOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeVersionSkew != nil {
+		in, out := &in.NodeVersionSkew, &out.NodeVersionSkew
+		*out = new(NodeVersionSkewOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GlobalBudget != nil {
+		in, out := &in.GlobalBudget, &out.GlobalBudget
+		*out = new(GlobalReplacementBudgetOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeDrain != nil {
+		in, out := &in.NodeDrain, &out.NodeDrain
+		*out = new(NodeDrainReplacementOptions)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutomaticReplacementOptions.
@@ -157,6 +289,46 @@ func (in *BuggifyConfig) DeepCopy() *BuggifyConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CancelOnRecoveryOptions) DeepCopyInto(out *CancelOnRecoveryOptions) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.GracePeriodSeconds != nil {
+		in, out := &in.GracePeriodSeconds, &out.GracePeriodSeconds
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CancelOnRecoveryOptions.
+func (in *CancelOnRecoveryOptions) DeepCopy() *CancelOnRecoveryOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(CancelOnRecoveryOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateIssuerReference) DeepCopyInto(out *CertificateIssuerReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateIssuerReference.
+func (in *CertificateIssuerReference) DeepCopy() *CertificateIssuerReference {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateIssuerReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterGenerationStatus) DeepCopyInto(out *ClusterGenerationStatus) {
 	*out = *in
@@ -187,6 +359,111 @@ func (in *ClusterHealth) DeepCopy() *ClusterHealth {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterMonitoringOptions) DeepCopyInto(out *ClusterMonitoringOptions) {
+	*out = *in
+	in.HealthEndpoint.DeepCopyInto(&out.HealthEndpoint)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMonitoringOptions.
+func (in *ClusterMonitoringOptions) DeepCopy() *ClusterMonitoringOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterMonitoringOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRecoveryOptions) DeepCopyInto(out *ClusterRecoveryOptions) {
+	*out = *in
+	if in.ForceNewCoordinators != nil {
+		in, out := &in.ForceNewCoordinators, &out.ForceNewCoordinators
+		*out = new(ForceNewCoordinatorsOptions)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRecoveryOptions.
+func (in *ClusterRecoveryOptions) DeepCopy() *ClusterRecoveryOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRecoveryOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTemplateReference) DeepCopyInto(out *ClusterTemplateReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTemplateReference.
+func (in *ClusterTemplateReference) DeepCopy() *ClusterTemplateReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTemplateReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTemplateStatus) DeepCopyInto(out *ClusterTemplateStatus) {
+	*out = *in
+	if in.InheritedFields != nil {
+		in, out := &in.InheritedFields, &out.InheritedFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTemplateStatus.
+func (in *ClusterTemplateStatus) DeepCopy() *ClusterTemplateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTemplateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectionSecretConfig) DeepCopyInto(out *ConnectionSecretConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionSecretConfig.
+func (in *ConnectionSecretConfig) DeepCopy() *ConnectionSecretConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionSecretConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConnectionString) DeepCopyInto(out *ConnectionString) {
 	*out = *in
@@ -237,6 +514,32 @@ func (in *ContainerOverrides) DeepCopy() *ContainerOverrides {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CoordinatorQuorumLossStatus) DeepCopyInto(out *CoordinatorQuorumLossStatus) {
+	*out = *in
+	in.DetectionTimestamp.DeepCopyInto(&out.DetectionTimestamp)
+	if in.ReachableCoordinators != nil {
+		in, out := &in.ReachableCoordinators, &out.ReachableCoordinators
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnreachableCoordinators != nil {
+		in, out := &in.UnreachableCoordinators, &out.UnreachableCoordinators
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoordinatorQuorumLossStatus.
+func (in *CoordinatorQuorumLossStatus) DeepCopy() *CoordinatorQuorumLossStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CoordinatorQuorumLossStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CoordinatorSelectionSetting) DeepCopyInto(out *CoordinatorSelectionSetting) {
 	*out = *in
@@ -317,90 +620,197 @@ func (in *DatabaseConfiguration) DeepCopy() *DatabaseConfiguration {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ExcludedServers) DeepCopyInto(out *ExcludedServers) {
+func (in *DiskQualificationSettings) DeepCopyInto(out *DiskQualificationSettings) {
 	*out = *in
+	if in.RuntimeSeconds != nil {
+		in, out := &in.RuntimeSeconds, &out.RuntimeSeconds
+		*out = new(int)
+		**out = **in
+	}
+	if in.MinIOPS != nil {
+		in, out := &in.MinIOPS, &out.MinIOPS
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxLatencyMicroseconds != nil {
+		in, out := &in.MaxLatencyMicroseconds, &out.MaxLatencyMicroseconds
+		*out = new(int)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExcludedServers.
-func (in *ExcludedServers) DeepCopy() *ExcludedServers {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskQualificationSettings.
+func (in *DiskQualificationSettings) DeepCopy() *DiskQualificationSettings {
 	if in == nil {
 		return nil
 	}
-	out := new(ExcludedServers)
+	out := new(DiskQualificationSettings)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FaultTolerance) DeepCopyInto(out *FaultTolerance) {
+func (in *DryRunReplacement) DeepCopyInto(out *DryRunReplacement) {
 	*out = *in
+	out.Reason = in.Reason
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FaultTolerance.
-func (in *FaultTolerance) DeepCopy() *FaultTolerance {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DryRunReplacement.
+func (in *DryRunReplacement) DeepCopy() *DryRunReplacement {
 	if in == nil {
 		return nil
 	}
-	out := new(FaultTolerance)
+	out := new(DryRunReplacement)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FoundationDBBackup) DeepCopyInto(out *FoundationDBBackup) {
+func (in *ExcludedServers) DeepCopyInto(out *ExcludedServers) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBBackup.
-func (in *FoundationDBBackup) DeepCopy() *FoundationDBBackup {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExcludedServers.
+func (in *ExcludedServers) DeepCopy() *ExcludedServers {
 	if in == nil {
 		return nil
 	}
-	out := new(FoundationDBBackup)
+	out := new(ExcludedServers)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *FoundationDBBackup) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FoundationDBBackupList) DeepCopyInto(out *FoundationDBBackupList) {
+func (in *FailedReplacement) DeepCopyInto(out *FailedReplacement) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]FoundationDBBackup, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBBackupList.
-func (in *FoundationDBBackupList) DeepCopy() *FoundationDBBackupList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedReplacement.
+func (in *FailedReplacement) DeepCopy() *FailedReplacement {
 	if in == nil {
 		return nil
 	}
-	out := new(FoundationDBBackupList)
+	out := new(FailedReplacement)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *FoundationDBBackupList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FaultTolerance) DeepCopyInto(out *FaultTolerance) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FaultTolerance.
+func (in *FaultTolerance) DeepCopy() *FaultTolerance {
+	if in == nil {
+		return nil
+	}
+	out := new(FaultTolerance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FeatureFlags) DeepCopyInto(out *FeatureFlags) {
+	*out = *in
+	if in.EnableVersionVector != nil {
+		in, out := &in.EnableVersionVector, &out.EnableVersionVector
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableGrayFailureDetection != nil {
+		in, out := &in.EnableGrayFailureDetection, &out.EnableGrayFailureDetection
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IdempotencyIDsMinAgeSeconds != nil {
+		in, out := &in.IdempotencyIDsMinAgeSeconds, &out.IdempotencyIDsMinAgeSeconds
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureFlags.
+func (in *FeatureFlags) DeepCopy() *FeatureFlags {
+	if in == nil {
+		return nil
+	}
+	out := new(FeatureFlags)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForceNewCoordinatorsOptions) DeepCopyInto(out *ForceNewCoordinatorsOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForceNewCoordinatorsOptions.
+func (in *ForceNewCoordinatorsOptions) DeepCopy() *ForceNewCoordinatorsOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ForceNewCoordinatorsOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBBackup) DeepCopyInto(out *FoundationDBBackup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBBackup.
+func (in *FoundationDBBackup) DeepCopy() *FoundationDBBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FoundationDBBackup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBBackupList) DeepCopyInto(out *FoundationDBBackupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FoundationDBBackup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBBackupList.
+func (in *FoundationDBBackupList) DeepCopy() *FoundationDBBackupList {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBBackupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FoundationDBBackupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
 	return nil
 }
@@ -450,6 +860,11 @@ func (in *FoundationDBBackupSpec) DeepCopyInto(out *FoundationDBBackupSpec) {
 		*out = new(ImageType)
 		**out = **in
 	}
+	if in.WaitForHealthyCluster != nil {
+		in, out := &in.WaitForHealthyCluster, &out.WaitForHealthyCluster
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBBackupSpec.
@@ -594,11 +1009,36 @@ func (in *FoundationDBClusterAutomationOptions) DeepCopyInto(out *FoundationDBCl
 		*out = new(int)
 		**out = **in
 	}
+	if in.MassReplacementThresholdPercentage != nil {
+		in, out := &in.MassReplacementThresholdPercentage, &out.MassReplacementThresholdPercentage
+		*out = new(int)
+		**out = **in
+	}
 	if in.WaitBetweenRemovalsSeconds != nil {
 		in, out := &in.WaitBetweenRemovalsSeconds, &out.WaitBetweenRemovalsSeconds
 		*out = new(int)
 		**out = **in
 	}
+	if in.VerifyProcessRemoval != nil {
+		in, out := &in.VerifyProcessRemoval, &out.VerifyProcessRemoval
+		*out = new(bool)
+		**out = **in
+	}
+	if in.UseOnlinePVCExpansion != nil {
+		in, out := &in.UseOnlinePVCExpansion, &out.UseOnlinePVCExpansion
+		*out = new(bool)
+		**out = **in
+	}
+	if in.UseInPlaceResizeForStatefulProcesses != nil {
+		in, out := &in.UseInPlaceResizeForStatefulProcesses, &out.UseInPlaceResizeForStatefulProcesses
+		*out = new(bool)
+		**out = **in
+	}
+	if in.UseKnobHotReload != nil {
+		in, out := &in.UseKnobHotReload, &out.UseKnobHotReload
+		*out = new(bool)
+		**out = **in
+	}
 	if in.UseManagementAPI != nil {
 		in, out := &in.UseManagementAPI, &out.UseManagementAPI
 		*out = new(bool)
@@ -610,6 +1050,68 @@ func (in *FoundationDBClusterAutomationOptions) DeepCopyInto(out *FoundationDBCl
 		*out = make([]LogGroup, len(*in))
 		copy(*out, *in)
 	}
+	if in.UseProcessClassReassignment != nil {
+		in, out := &in.UseProcessClassReassignment, &out.UseProcessClassReassignment
+		*out = new(bool)
+		**out = **in
+	}
+	if in.UseCompactProcessGroupIDs != nil {
+		in, out := &in.UseCompactProcessGroupIDs, &out.UseCompactProcessGroupIDs
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CleanupStaleExclusions != nil {
+		in, out := &in.CleanupStaleExclusions, &out.CleanupStaleExclusions
+		*out = new(bool)
+		**out = **in
+	}
+	if in.UseOrchestratedImageTypeMigration != nil {
+		in, out := &in.UseOrchestratedImageTypeMigration, &out.UseOrchestratedImageTypeMigration
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MigratePodSpecHashes != nil {
+		in, out := &in.MigratePodSpecHashes, &out.MigratePodSpecHashes
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DeriveMemoryKnobs != nil {
+		in, out := &in.DeriveMemoryKnobs, &out.DeriveMemoryKnobs
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MemoryOvercommitProtection != nil {
+		in, out := &in.MemoryOvercommitProtection, &out.MemoryOvercommitProtection
+		*out = new(MemoryOvercommitProtectionOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StatelessScaling != nil {
+		in, out := &in.StatelessScaling, &out.StatelessScaling
+		*out = new(StatelessScalingOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProcessGroupIDPrefixMigration != nil {
+		in, out := &in.ProcessGroupIDPrefixMigration, &out.ProcessGroupIDPrefixMigration
+		*out = new(ProcessGroupIDPrefixMigrationOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StorageEngineMigration != nil {
+		in, out := &in.StorageEngineMigration, &out.StorageEngineMigration
+		*out = new(StorageEngineMigrationOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProcessGroupIDAllocation != nil {
+		in, out := &in.ProcessGroupIDAllocation, &out.ProcessGroupIDAllocation
+		*out = new(ProcessGroupIDAllocationOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Paused.DeepCopyInto(&out.Paused)
+	in.SafetyInterlock.DeepCopyInto(&out.SafetyInterlock)
+	if in.StagedPodCreation != nil {
+		in, out := &in.StagedPodCreation, &out.StagedPodCreation
+		*out = new(StagedPodCreationOptions)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBClusterAutomationOptions.
@@ -693,6 +1195,16 @@ func (in *FoundationDBClusterSpec) DeepCopyInto(out *FoundationDBClusterSpec) {
 		*out = make([]ProcessGroupID, len(*in))
 		copy(*out, *in)
 	}
+	if in.ApprovedReplacements != nil {
+		in, out := &in.ApprovedReplacements, &out.ApprovedReplacements
+		*out = make([]ProcessGroupID, len(*in))
+		copy(*out, *in)
+	}
+	if in.ProtectedProcessGroups != nil {
+		in, out := &in.ProtectedProcessGroups, &out.ProtectedProcessGroups
+		*out = make([]ProcessGroupID, len(*in))
+		copy(*out, *in)
+	}
 	if in.ConfigMap != nil {
 		in, out := &in.ConfigMap, &out.ConfigMap
 		*out = new(corev1.ConfigMap)
@@ -712,19 +1224,45 @@ func (in *FoundationDBClusterSpec) DeepCopyInto(out *FoundationDBClusterSpec) {
 	}
 	in.AutomationOptions.DeepCopyInto(&out.AutomationOptions)
 	in.LockOptions.DeepCopyInto(&out.LockOptions)
+	in.TLSOptions.DeepCopyInto(&out.TLSOptions)
 	in.Routing.DeepCopyInto(&out.Routing)
+	in.PodDisruptionBudgets.DeepCopyInto(&out.PodDisruptionBudgets)
+	in.ConnectionSecret.DeepCopyInto(&out.ConnectionSecret)
 	in.Buggify.DeepCopyInto(&out.Buggify)
+	if in.StoragePools != nil {
+		in, out := &in.StoragePools, &out.StoragePools
+		*out = make([]StoragePool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.ReplaceInstancesWhenResourcesChange != nil {
 		in, out := &in.ReplaceInstancesWhenResourcesChange, &out.ReplaceInstancesWhenResourcesChange
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ReplacementTriggerPolicy != nil {
+		in, out := &in.ReplacementTriggerPolicy, &out.ReplacementTriggerPolicy
+		*out = new(ReplacementTriggerPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.CoordinatorSelection != nil {
 		in, out := &in.CoordinatorSelection, &out.CoordinatorSelection
 		*out = make([]CoordinatorSelectionSetting, len(*in))
 		copy(*out, *in)
 	}
+	if in.CoordinatorCount != nil {
+		in, out := &in.CoordinatorCount, &out.CoordinatorCount
+		*out = new(int)
+		**out = **in
+	}
+	if in.SpreadCoordinatorsAcrossDataCenters != nil {
+		in, out := &in.SpreadCoordinatorsAcrossDataCenters, &out.SpreadCoordinatorsAcrossDataCenters
+		*out = new(bool)
+		**out = **in
+	}
 	in.LabelConfig.DeepCopyInto(&out.LabelConfig)
+	in.PropagatedMetadata.DeepCopyInto(&out.PropagatedMetadata)
 	if in.UseExplicitListenAddress != nil {
 		in, out := &in.UseExplicitListenAddress, &out.UseExplicitListenAddress
 		*out = new(bool)
@@ -740,6 +1278,14 @@ func (in *FoundationDBClusterSpec) DeepCopyInto(out *FoundationDBClusterSpec) {
 		*out = new(int)
 		**out = **in
 	}
+	in.FeatureFlags.DeepCopyInto(&out.FeatureFlags)
+	if in.TemplateRef != nil {
+		in, out := &in.TemplateRef, &out.TemplateRef
+		*out = new(ClusterTemplateReference)
+		**out = **in
+	}
+	in.Monitoring.DeepCopyInto(&out.Monitoring)
+	in.Recovery.DeepCopyInto(&out.Recovery)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBClusterSpec.
@@ -774,6 +1320,50 @@ func (in *FoundationDBClusterStatus) DeepCopyInto(out *FoundationDBClusterStatus
 		*out = make([]ImageType, len(*in))
 		copy(*out, *in)
 	}
+	if in.ImageTypeMigration != nil {
+		in, out := &in.ImageTypeMigration, &out.ImageTypeMigration
+		*out = new(ImageTypeMigrationStatus)
+		**out = **in
+	}
+	if in.TuningProfile != nil {
+		in, out := &in.TuningProfile, &out.TuningProfile
+		*out = new(TuningProfileStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProcessGroupIDPrefixMigration != nil {
+		in, out := &in.ProcessGroupIDPrefixMigration, &out.ProcessGroupIDPrefixMigration
+		*out = new(ProcessGroupIDPrefixMigrationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(ClusterTemplateStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StorageClassMigrations != nil {
+		in, out := &in.StorageClassMigrations, &out.StorageClassMigrations
+		*out = make([]StorageClassMigrationStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.StorageEngineMigration != nil {
+		in, out := &in.StorageEngineMigration, &out.StorageEngineMigration
+		*out = new(StorageEngineMigrationStatus)
+		**out = **in
+	}
+	if in.ReloadedKnobs != nil {
+		in, out := &in.ReloadedKnobs, &out.ReloadedKnobs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ActiveOperations != nil {
+		in, out := &in.ActiveOperations, &out.ActiveOperations
+		*out = make([]ActiveOperation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.ProcessGroups != nil {
 		in, out := &in.ProcessGroups, &out.ProcessGroups
 		*out = make([]*ProcessGroupStatus, len(*in))
@@ -787,6 +1377,47 @@ func (in *FoundationDBClusterStatus) DeepCopyInto(out *FoundationDBClusterStatus
 	}
 	in.Locks.DeepCopyInto(&out.Locks)
 	in.MaintenanceModeInfo.DeepCopyInto(&out.MaintenanceModeInfo)
+	if in.ReplacementHistory != nil {
+		in, out := &in.ReplacementHistory, &out.ReplacementHistory
+		*out = make([]v1.Time, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FailedReplacementHistory != nil {
+		in, out := &in.FailedReplacementHistory, &out.FailedReplacementHistory
+		*out = make([]FailedReplacement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DryRunReplacements != nil {
+		in, out := &in.DryRunReplacements, &out.DryRunReplacements
+		*out = make([]DryRunReplacement, len(*in))
+		copy(*out, *in)
+	}
+	if in.OperatorUpgrade != nil {
+		in, out := &in.OperatorUpgrade, &out.OperatorUpgrade
+		*out = new(OperatorUpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CoordinatorQuorumLoss != nil {
+		in, out := &in.CoordinatorQuorumLoss, &out.CoordinatorQuorumLoss
+		*out = new(CoordinatorQuorumLossStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GenerationObservedTimestamp != nil {
+		in, out := &in.GenerationObservedTimestamp, &out.GenerationObservedTimestamp
+		*out = new(v1.Time)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBClusterStatus.
@@ -819,56 +1450,114 @@ func (in FoundationDBCustomParameters) DeepCopy() FoundationDBCustomParameters {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FoundationDBKeyRange) DeepCopyInto(out *FoundationDBKeyRange) {
+func (in *FoundationDBClusterTemplate) DeepCopyInto(out *FoundationDBClusterTemplate) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBKeyRange.
-func (in *FoundationDBKeyRange) DeepCopy() *FoundationDBKeyRange {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBClusterTemplate.
+func (in *FoundationDBClusterTemplate) DeepCopy() *FoundationDBClusterTemplate {
 	if in == nil {
 		return nil
 	}
-	out := new(FoundationDBKeyRange)
+	out := new(FoundationDBClusterTemplate)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FoundationDBClusterTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FoundationDBLiveBackupStatus) DeepCopyInto(out *FoundationDBLiveBackupStatus) {
+func (in *FoundationDBClusterTemplateList) DeepCopyInto(out *FoundationDBClusterTemplateList) {
 	*out = *in
-	out.Status = in.Status
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FoundationDBClusterTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBLiveBackupStatus.
-func (in *FoundationDBLiveBackupStatus) DeepCopy() *FoundationDBLiveBackupStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBClusterTemplateList.
+func (in *FoundationDBClusterTemplateList) DeepCopy() *FoundationDBClusterTemplateList {
 	if in == nil {
 		return nil
 	}
-	out := new(FoundationDBLiveBackupStatus)
+	out := new(FoundationDBClusterTemplateList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FoundationDBClusterTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FoundationDBLiveBackupStatusState) DeepCopyInto(out *FoundationDBLiveBackupStatusState) {
+func (in *FoundationDBKeyRange) DeepCopyInto(out *FoundationDBKeyRange) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBLiveBackupStatusState.
-func (in *FoundationDBLiveBackupStatusState) DeepCopy() *FoundationDBLiveBackupStatusState {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBKeyRange.
+func (in *FoundationDBKeyRange) DeepCopy() *FoundationDBKeyRange {
 	if in == nil {
 		return nil
 	}
-	out := new(FoundationDBLiveBackupStatusState)
+	out := new(FoundationDBKeyRange)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FoundationDBRestore) DeepCopyInto(out *FoundationDBRestore) {
+func (in *FoundationDBLiveBackupStatus) DeepCopyInto(out *FoundationDBLiveBackupStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBLiveBackupStatus.
+func (in *FoundationDBLiveBackupStatus) DeepCopy() *FoundationDBLiveBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBLiveBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBLiveBackupStatusState) DeepCopyInto(out *FoundationDBLiveBackupStatusState) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBLiveBackupStatusState.
+func (in *FoundationDBLiveBackupStatusState) DeepCopy() *FoundationDBLiveBackupStatusState {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBLiveBackupStatusState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBRestore) DeepCopyInto(out *FoundationDBRestore) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
 	out.Status = in.Status
 }
@@ -941,6 +1630,11 @@ func (in *FoundationDBRestoreSpec) DeepCopyInto(out *FoundationDBRestoreSpec) {
 		*out = make(FoundationDBCustomParameters, len(*in))
 		copy(*out, *in)
 	}
+	if in.WaitForHealthyCluster != nil {
+		in, out := &in.WaitForHealthyCluster, &out.WaitForHealthyCluster
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBRestoreSpec.
@@ -1382,6 +2076,21 @@ func (in *FoundationDBStatusQosInfo) DeepCopy() *FoundationDBStatusQosInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBStatusStorageMetadata) DeepCopyInto(out *FoundationDBStatusStorageMetadata) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBStatusStorageMetadata.
+func (in *FoundationDBStatusStorageMetadata) DeepCopy() *FoundationDBStatusStorageMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBStatusStorageMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FoundationDBStatusSupportedVersion) DeepCopyInto(out *FoundationDBStatusSupportedVersion) {
 	*out = *in
@@ -1438,6 +2147,76 @@ func (in *FoundationDBUnreachableProcess) DeepCopy() *FoundationDBUnreachablePro
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayParentReference) DeepCopyInto(out *GatewayParentReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayParentReference.
+func (in *GatewayParentReference) DeepCopy() *GatewayParentReference {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayParentReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalReplacementBudgetOptions) DeepCopyInto(out *GlobalReplacementBudgetOptions) {
+	*out = *in
+	if in.MaxConcurrentReplacements != nil {
+		in, out := &in.MaxConcurrentReplacements, &out.MaxConcurrentReplacements
+		*out = new(int)
+		**out = **in
+	}
+	if in.LeaseDurationSeconds != nil {
+		in, out := &in.LeaseDurationSeconds, &out.LeaseDurationSeconds
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalReplacementBudgetOptions.
+func (in *GlobalReplacementBudgetOptions) DeepCopy() *GlobalReplacementBudgetOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalReplacementBudgetOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthEndpointOptions) DeepCopyInto(out *HealthEndpointOptions) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MinimumFaultTolerance != nil {
+		in, out := &in.MinimumFaultTolerance, &out.MinimumFaultTolerance
+		*out = new(int)
+		**out = **in
+	}
+	if in.RequireFullReplication != nil {
+		in, out := &in.RequireFullReplication, &out.RequireFullReplication
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthEndpointOptions.
+func (in *HealthEndpointOptions) DeepCopy() *HealthEndpointOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthEndpointOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImageConfig) DeepCopyInto(out *ImageConfig) {
 	*out = *in
@@ -1453,6 +2232,46 @@ func (in *ImageConfig) DeepCopy() *ImageConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageTypeMigrationStatus) DeepCopyInto(out *ImageTypeMigrationStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageTypeMigrationStatus.
+func (in *ImageTypeMigrationStatus) DeepCopy() *ImageTypeMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageTypeMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KernelSettings) DeepCopyInto(out *KernelSettings) {
+	*out = *in
+	if in.Sysctls != nil {
+		in, out := &in.Sysctls, &out.Sysctls
+		*out = make([]corev1.Sysctl, len(*in))
+		copy(*out, *in)
+	}
+	if in.DisableTransparentHugePages != nil {
+		in, out := &in.DisableTransparentHugePages, &out.DisableTransparentHugePages
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KernelSettings.
+func (in *KernelSettings) DeepCopy() *KernelSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(KernelSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LabelConfig) DeepCopyInto(out *LabelConfig) {
 	*out = *in
@@ -1485,6 +2304,11 @@ func (in *LabelConfig) DeepCopyInto(out *LabelConfig) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ClaimResources != nil {
+		in, out := &in.ClaimResources, &out.ClaimResources
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LabelConfig.
@@ -1617,163 +2441,604 @@ func (in *MaintenanceModeOptions) DeepCopy() *MaintenanceModeOptions {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *None) DeepCopyInto(out *None) {
+func (in *MemoryOvercommitProtectionOptions) DeepCopyInto(out *MemoryOvercommitProtectionOptions) {
 	*out = *in
+	if in.OvercommitRatioPercentage != nil {
+		in, out := &in.OvercommitRatioPercentage, &out.OvercommitRatioPercentage
+		*out = new(int)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new None.
-func (in *None) DeepCopy() *None {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemoryOvercommitProtectionOptions.
+func (in *MemoryOvercommitProtectionOptions) DeepCopy() *MemoryOvercommitProtectionOptions {
 	if in == nil {
 		return nil
 	}
-	out := new(None)
+	out := new(MemoryOvercommitProtectionOptions)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProcessAddress) DeepCopyInto(out *ProcessAddress) {
+func (in *MonitorAPIGatewayConfig) DeepCopyInto(out *MonitorAPIGatewayConfig) {
 	*out = *in
-	if in.IPAddress != nil {
-		in, out := &in.IPAddress, &out.IPAddress
-		*out = make(netx.IP, len(*in))
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ParentRefs != nil {
+		in, out := &in.ParentRefs, &out.ParentRefs
+		*out = make([]GatewayParentReference, len(*in))
 		copy(*out, *in)
 	}
-	if in.Flags != nil {
-		in, out := &in.Flags, &out.Flags
-		*out = make(map[string]bool, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int)
+		**out = **in
+	}
+	if in.CertificateIssuer != nil {
+		in, out := &in.CertificateIssuer, &out.CertificateIssuer
+		*out = new(CertificateIssuerReference)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProcessAddress.
-func (in *ProcessAddress) DeepCopy() *ProcessAddress {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitorAPIGatewayConfig.
+func (in *MonitorAPIGatewayConfig) DeepCopy() *MonitorAPIGatewayConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ProcessAddress)
+	out := new(MonitorAPIGatewayConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProcessCounts) DeepCopyInto(out *ProcessCounts) {
+func (in *NodeDrainReplacementOptions) DeepCopyInto(out *NodeDrainReplacementOptions) {
 	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DetectCordonedNodes != nil {
+		in, out := &in.DetectCordonedNodes, &out.DetectCordonedNodes
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TaintKeys != nil {
+		in, out := &in.TaintKeys, &out.TaintKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProcessCounts.
-func (in *ProcessCounts) DeepCopy() *ProcessCounts {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeDrainReplacementOptions.
+func (in *NodeDrainReplacementOptions) DeepCopy() *NodeDrainReplacementOptions {
 	if in == nil {
 		return nil
 	}
-	out := new(ProcessCounts)
+	out := new(NodeDrainReplacementOptions)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProcessGroupCondition) DeepCopyInto(out *ProcessGroupCondition) {
+func (in *NodeVersionSkewOptions) DeepCopyInto(out *NodeVersionSkewOptions) {
 	*out = *in
+	if in.MaxConcurrentReplacements != nil {
+		in, out := &in.MaxConcurrentReplacements, &out.MaxConcurrentReplacements
+		*out = new(int)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProcessGroupCondition.
-func (in *ProcessGroupCondition) DeepCopy() *ProcessGroupCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeVersionSkewOptions.
+func (in *NodeVersionSkewOptions) DeepCopy() *NodeVersionSkewOptions {
 	if in == nil {
 		return nil
 	}
-	out := new(ProcessGroupCondition)
+	out := new(NodeVersionSkewOptions)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProcessGroupStatus) DeepCopyInto(out *ProcessGroupStatus) {
+func (in *None) DeepCopyInto(out *None) {
 	*out = *in
-	if in.Addresses != nil {
-		in, out := &in.Addresses, &out.Addresses
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.RemovalTimestamp != nil {
-		in, out := &in.RemovalTimestamp, &out.RemovalTimestamp
-		*out = (*in).DeepCopy()
-	}
-	if in.ExclusionTimestamp != nil {
-		in, out := &in.ExclusionTimestamp, &out.ExclusionTimestamp
-		*out = (*in).DeepCopy()
-	}
-	if in.ProcessGroupConditions != nil {
-		in, out := &in.ProcessGroupConditions, &out.ProcessGroupConditions
-		*out = make([]*ProcessGroupCondition, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(ProcessGroupCondition)
-				**out = **in
-			}
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProcessGroupStatus.
-func (in *ProcessGroupStatus) DeepCopy() *ProcessGroupStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new None.
+func (in *None) DeepCopy() *None {
 	if in == nil {
 		return nil
 	}
-	out := new(ProcessGroupStatus)
+	out := new(None)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProcessSettings) DeepCopyInto(out *ProcessSettings) {
+func (in *OperatorUpgradeStatus) DeepCopyInto(out *OperatorUpgradeStatus) {
 	*out = *in
-	if in.PodTemplate != nil {
-		in, out := &in.PodTemplate, &out.PodTemplate
-		*out = new(corev1.PodTemplateSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.VolumeClaimTemplate != nil {
-		in, out := &in.VolumeClaimTemplate, &out.VolumeClaimTemplate
-		*out = new(corev1.PersistentVolumeClaim)
-		(*in).DeepCopyInto(*out)
+	in.ObservationStart.DeepCopyInto(&out.ObservationStart)
+	if in.PodUpdates != nil {
+		in, out := &in.PodUpdates, &out.PodUpdates
+		*out = make([]ProcessGroupID, len(*in))
+		copy(*out, *in)
 	}
-	if in.CustomParameters != nil {
-		in, out := &in.CustomParameters, &out.CustomParameters
-		*out = make(FoundationDBCustomParameters, len(*in))
+	if in.Replacements != nil {
+		in, out := &in.Replacements, &out.Replacements
+		*out = make([]ProcessGroupID, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProcessSettings.
-func (in *ProcessSettings) DeepCopy() *ProcessSettings {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorUpgradeStatus.
+func (in *OperatorUpgradeStatus) DeepCopy() *OperatorUpgradeStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ProcessSettings)
+	out := new(OperatorUpgradeStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RecoveryState) DeepCopyInto(out *RecoveryState) {
+func (in *PausedAutomationOptions) DeepCopyInto(out *PausedAutomationOptions) {
 	*out = *in
+	if in.Replacements != nil {
+		in, out := &in.Replacements, &out.Replacements
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Exclusions != nil {
+		in, out := &in.Exclusions, &out.Exclusions
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Bounces != nil {
+		in, out := &in.Bounces, &out.Bounces
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ConfigurationChanges != nil {
+		in, out := &in.ConfigurationChanges, &out.ConfigurationChanges
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PodUpdates != nil {
+		in, out := &in.PodUpdates, &out.PodUpdates
+		*out = new(bool)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecoveryState.
-func (in *RecoveryState) DeepCopy() *RecoveryState {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PausedAutomationOptions.
+func (in *PausedAutomationOptions) DeepCopy() *PausedAutomationOptions {
 	if in == nil {
 		return nil
 	}
-	out := new(RecoveryState)
+	out := new(PausedAutomationOptions)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Region) DeepCopyInto(out *Region) {
+func (in *PodDisruptionBudgetConfig) DeepCopyInto(out *PodDisruptionBudgetConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = make(map[ProcessClass]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodDisruptionBudgetConfig.
+func (in *PodDisruptionBudgetConfig) DeepCopy() *PodDisruptionBudgetConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PodDisruptionBudgetConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProcessAddress) DeepCopyInto(out *ProcessAddress) {
+	*out = *in
+	if in.IPAddress != nil {
+		in, out := &in.IPAddress, &out.IPAddress
+		*out = make(netx.IP, len(*in))
+		copy(*out, *in)
+	}
+	if in.Flags != nil {
+		in, out := &in.Flags, &out.Flags
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProcessAddress.
+func (in *ProcessAddress) DeepCopy() *ProcessAddress {
+	if in == nil {
+		return nil
+	}
+	out := new(ProcessAddress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProcessCounts) DeepCopyInto(out *ProcessCounts) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProcessCounts.
+func (in *ProcessCounts) DeepCopy() *ProcessCounts {
+	if in == nil {
+		return nil
+	}
+	out := new(ProcessCounts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProcessExclusionProgress) DeepCopyInto(out *ProcessExclusionProgress) {
+	*out = *in
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]ProcessRole, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProcessExclusionProgress.
+func (in *ProcessExclusionProgress) DeepCopy() *ProcessExclusionProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(ProcessExclusionProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProcessGroupCondition) DeepCopyInto(out *ProcessGroupCondition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProcessGroupCondition.
+func (in *ProcessGroupCondition) DeepCopy() *ProcessGroupCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(ProcessGroupCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProcessGroupIDAllocationOptions) DeepCopyInto(out *ProcessGroupIDAllocationOptions) {
+	*out = *in
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(ProcessGroupIDAllocationStrategy)
+		**out = **in
+	}
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ZoneLabel != nil {
+		in, out := &in.ZoneLabel, &out.ZoneLabel
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProcessGroupIDAllocationOptions.
+func (in *ProcessGroupIDAllocationOptions) DeepCopy() *ProcessGroupIDAllocationOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ProcessGroupIDAllocationOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProcessGroupIDPrefixMigrationOptions) DeepCopyInto(out *ProcessGroupIDPrefixMigrationOptions) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ConfirmedPrefix != nil {
+		in, out := &in.ConfirmedPrefix, &out.ConfirmedPrefix
+		*out = new(string)
+		**out = **in
+	}
+	if in.ProcessClassOrder != nil {
+		in, out := &in.ProcessClassOrder, &out.ProcessClassOrder
+		*out = make([]ProcessClass, len(*in))
+		copy(*out, *in)
+	}
+	if in.Paused != nil {
+		in, out := &in.Paused, &out.Paused
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProcessGroupIDPrefixMigrationOptions.
+func (in *ProcessGroupIDPrefixMigrationOptions) DeepCopy() *ProcessGroupIDPrefixMigrationOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ProcessGroupIDPrefixMigrationOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProcessGroupIDPrefixMigrationStatus) DeepCopyInto(out *ProcessGroupIDPrefixMigrationStatus) {
+	*out = *in
+	if in.PendingProcessGroups != nil {
+		in, out := &in.PendingProcessGroups, &out.PendingProcessGroups
+		*out = make(map[ProcessClass]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProcessGroupIDPrefixMigrationStatus.
+func (in *ProcessGroupIDPrefixMigrationStatus) DeepCopy() *ProcessGroupIDPrefixMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProcessGroupIDPrefixMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProcessGroupRemovalPhases) DeepCopyInto(out *ProcessGroupRemovalPhases) {
+	*out = *in
+	if in.MarkedForRemoval != nil {
+		in, out := &in.MarkedForRemoval, &out.MarkedForRemoval
+		*out = (*in).DeepCopy()
+	}
+	if in.Excluding != nil {
+		in, out := &in.Excluding, &out.Excluding
+		*out = (*in).DeepCopy()
+	}
+	if in.Excluded != nil {
+		in, out := &in.Excluded, &out.Excluded
+		*out = (*in).DeepCopy()
+	}
+	if in.ResourcesTerminating != nil {
+		in, out := &in.ResourcesTerminating, &out.ResourcesTerminating
+		*out = (*in).DeepCopy()
+	}
+	if in.Removed != nil {
+		in, out := &in.Removed, &out.Removed
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProcessGroupRemovalPhases.
+func (in *ProcessGroupRemovalPhases) DeepCopy() *ProcessGroupRemovalPhases {
+	if in == nil {
+		return nil
+	}
+	out := new(ProcessGroupRemovalPhases)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProcessGroupStatus) DeepCopyInto(out *ProcessGroupStatus) {
+	*out = *in
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RemovalTimestamp != nil {
+		in, out := &in.RemovalTimestamp, &out.RemovalTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.RemovalReason != nil {
+		in, out := &in.RemovalReason, &out.RemovalReason
+		*out = new(RemovalReason)
+		**out = **in
+	}
+	if in.ExclusionTimestamp != nil {
+		in, out := &in.ExclusionTimestamp, &out.ExclusionTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.ProcessGroupConditions != nil {
+		in, out := &in.ProcessGroupConditions, &out.ProcessGroupConditions
+		*out = make([]*ProcessGroupCondition, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(ProcessGroupCondition)
+				**out = **in
+			}
+		}
+	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]ProcessRole, len(*in))
+		copy(*out, *in)
+	}
+	if in.ProcessStartTimestamp != nil {
+		in, out := &in.ProcessStartTimestamp, &out.ProcessStartTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.StandbyTimestamp != nil {
+		in, out := &in.StandbyTimestamp, &out.StandbyTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.ExclusionProgress != nil {
+		in, out := &in.ExclusionProgress, &out.ExclusionProgress
+		*out = make([]ProcessExclusionProgress, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RemovalPhases != nil {
+		in, out := &in.RemovalPhases, &out.RemovalPhases
+		*out = new(ProcessGroupRemovalPhases)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProcessGroupStatus.
+func (in *ProcessGroupStatus) DeepCopy() *ProcessGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProcessGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProcessSettings) DeepCopyInto(out *ProcessSettings) {
+	*out = *in
+	if in.PodTemplate != nil {
+		in, out := &in.PodTemplate, &out.PodTemplate
+		*out = new(corev1.PodTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodTemplateOverrides != nil {
+		in, out := &in.PodTemplateOverrides, &out.PodTemplateOverrides
+		*out = new(corev1.PodTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VolumeClaimTemplate != nil {
+		in, out := &in.VolumeClaimTemplate, &out.VolumeClaimTemplate
+		*out = new(corev1.PersistentVolumeClaim)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CustomParameters != nil {
+		in, out := &in.CustomParameters, &out.CustomParameters
+		*out = make(FoundationDBCustomParameters, len(*in))
+		copy(*out, *in)
+	}
+	if in.RuntimeClassName != nil {
+		in, out := &in.RuntimeClassName, &out.RuntimeClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.KernelSettings != nil {
+		in, out := &in.KernelSettings, &out.KernelSettings
+		*out = new(KernelSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Shutdown != nil {
+		in, out := &in.Shutdown, &out.Shutdown
+		*out = new(ShutdownSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdditionalVolumeClaims != nil {
+		in, out := &in.AdditionalVolumeClaims, &out.AdditionalVolumeClaims
+		*out = make([]AdditionalVolumeClaim, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DiskQualification != nil {
+		in, out := &in.DiskQualification, &out.DiskQualification
+		*out = new(DiskQualificationSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StartCommand != nil {
+		in, out := &in.StartCommand, &out.StartCommand
+		*out = new(StartCommandSettings)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProcessSettings.
+func (in *ProcessSettings) DeepCopy() *ProcessSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(ProcessSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PropagatedMetadata) DeepCopyInto(out *PropagatedMetadata) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PropagatedMetadata.
+func (in *PropagatedMetadata) DeepCopy() *PropagatedMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(PropagatedMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecoveryState) DeepCopyInto(out *RecoveryState) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecoveryState.
+func (in *RecoveryState) DeepCopy() *RecoveryState {
+	if in == nil {
+		return nil
+	}
+	out := new(RecoveryState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Region) DeepCopyInto(out *Region) {
 	*out = *in
 	if in.DataCenters != nil {
 		in, out := &in.DataCenters, &out.DataCenters
@@ -1782,12 +3047,127 @@ func (in *Region) DeepCopyInto(out *Region) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Region.
-func (in *Region) DeepCopy() *Region {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Region.
+func (in *Region) DeepCopy() *Region {
+	if in == nil {
+		return nil
+	}
+	out := new(Region)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemovalReason) DeepCopyInto(out *RemovalReason) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemovalReason.
+func (in *RemovalReason) DeepCopy() *RemovalReason {
+	if in == nil {
+		return nil
+	}
+	out := new(RemovalReason)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplacementLoopDetectionOptions) DeepCopyInto(out *ReplacementLoopDetectionOptions) {
+	*out = *in
+	if in.MaxReplacements != nil {
+		in, out := &in.MaxReplacements, &out.MaxReplacements
+		*out = new(int)
+		**out = **in
+	}
+	if in.WindowSeconds != nil {
+		in, out := &in.WindowSeconds, &out.WindowSeconds
+		*out = new(int)
+		**out = **in
+	}
+	if in.BackoffSeconds != nil {
+		in, out := &in.BackoffSeconds, &out.BackoffSeconds
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplacementLoopDetectionOptions.
+func (in *ReplacementLoopDetectionOptions) DeepCopy() *ReplacementLoopDetectionOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplacementLoopDetectionOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplacementTriggerPolicy) DeepCopyInto(out *ReplacementTriggerPolicy) {
+	*out = *in
+	if in.NodeSelectorChanged != nil {
+		in, out := &in.NodeSelectorChanged, &out.NodeSelectorChanged
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PublicIPSourceChanged != nil {
+		in, out := &in.PublicIPSourceChanged, &out.PublicIPSourceChanged
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SecurityContextChanged != nil {
+		in, out := &in.SecurityContextChanged, &out.SecurityContextChanged
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SecurityContextChangeGracePeriodSeconds != nil {
+		in, out := &in.SecurityContextChangeGracePeriodSeconds, &out.SecurityContextChangeGracePeriodSeconds
+		*out = new(int)
+		**out = **in
+	}
+	if in.ServersPerPodChanged != nil {
+		in, out := &in.ServersPerPodChanged, &out.ServersPerPodChanged
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ServiceAccountChanged != nil {
+		in, out := &in.ServiceAccountChanged, &out.ServiceAccountChanged
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ImagePullSecretsChanged != nil {
+		in, out := &in.ImagePullSecretsChanged, &out.ImagePullSecretsChanged
+		*out = new(bool)
+		**out = **in
+	}
+	if in.VolumeTopologyConflict != nil {
+		in, out := &in.VolumeTopologyConflict, &out.VolumeTopologyConflict
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplacementTriggerPolicy.
+func (in *ReplacementTriggerPolicy) DeepCopy() *ReplacementTriggerPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(Region)
+	out := new(ReplacementTriggerPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplacementWindow) DeepCopyInto(out *ReplacementWindow) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplacementWindow.
+func (in *ReplacementWindow) DeepCopy() *ReplacementWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplacementWindow)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1830,6 +3210,11 @@ func (in *RoutingConfig) DeepCopyInto(out *RoutingConfig) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ProcessClassHeadlessServices != nil {
+		in, out := &in.ProcessClassHeadlessServices, &out.ProcessClassHeadlessServices
+		*out = make([]ProcessClass, len(*in))
+		copy(*out, *in)
+	}
 	if in.PublicIPSource != nil {
 		in, out := &in.PublicIPSource, &out.PublicIPSource
 		*out = new(PublicIPSource)
@@ -1855,6 +3240,16 @@ func (in *RoutingConfig) DeepCopyInto(out *RoutingConfig) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.PinCoordinatorIPs != nil {
+		in, out := &in.PinCoordinatorIPs, &out.PinCoordinatorIPs
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MonitorAPIGateway != nil {
+		in, out := &in.MonitorAPIGateway, &out.MonitorAPIGateway
+		*out = new(MonitorAPIGatewayConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoutingConfig.
@@ -1867,6 +3262,266 @@ func (in *RoutingConfig) DeepCopy() *RoutingConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SafetyInterlockOptions) DeepCopyInto(out *SafetyInterlockOptions) {
+	*out = *in
+	if in.CacheDurationSeconds != nil {
+		in, out := &in.CacheDurationSeconds, &out.CacheDurationSeconds
+		*out = new(int)
+		**out = **in
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SafetyInterlockOptions.
+func (in *SafetyInterlockOptions) DeepCopy() *SafetyInterlockOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(SafetyInterlockOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShutdownSettings) DeepCopyInto(out *ShutdownSettings) {
+	*out = *in
+	if in.TerminationGracePeriodSeconds != nil {
+		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SidecarShutdownDelaySeconds != nil {
+		in, out := &in.SidecarShutdownDelaySeconds, &out.SidecarShutdownDelaySeconds
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShutdownSettings.
+func (in *ShutdownSettings) DeepCopy() *ShutdownSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(ShutdownSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StagedPodCreationOptions) DeepCopyInto(out *StagedPodCreationOptions) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxPodsPerWave != nil {
+		in, out := &in.MaxPodsPerWave, &out.MaxPodsPerWave
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StagedPodCreationOptions.
+func (in *StagedPodCreationOptions) DeepCopy() *StagedPodCreationOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(StagedPodCreationOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StartCommandEnvVar) DeepCopyInto(out *StartCommandEnvVar) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StartCommandEnvVar.
+func (in *StartCommandEnvVar) DeepCopy() *StartCommandEnvVar {
+	if in == nil {
+		return nil
+	}
+	out := new(StartCommandEnvVar)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StartCommandSettings) DeepCopyInto(out *StartCommandSettings) {
+	*out = *in
+	if in.Wrapper != nil {
+		in, out := &in.Wrapper, &out.Wrapper
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]StartCommandEnvVar, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StartCommandSettings.
+func (in *StartCommandSettings) DeepCopy() *StartCommandSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(StartCommandSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StatelessScalingOptions) DeepCopyInto(out *StatelessScalingOptions) {
+	*out = *in
+	if in.MinProcesses != nil {
+		in, out := &in.MinProcesses, &out.MinProcesses
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxProcesses != nil {
+		in, out := &in.MaxProcesses, &out.MaxProcesses
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatelessScalingOptions.
+func (in *StatelessScalingOptions) DeepCopy() *StatelessScalingOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(StatelessScalingOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageClassMigrationStatus) DeepCopyInto(out *StorageClassMigrationStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageClassMigrationStatus.
+func (in *StorageClassMigrationStatus) DeepCopy() *StorageClassMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageClassMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageEngineMigrationOptions) DeepCopyInto(out *StorageEngineMigrationOptions) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxConcurrentReplacements != nil {
+		in, out := &in.MaxConcurrentReplacements, &out.MaxConcurrentReplacements
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxQueuedDataMovementBytes != nil {
+		in, out := &in.MaxQueuedDataMovementBytes, &out.MaxQueuedDataMovementBytes
+		*out = new(int)
+		**out = **in
+	}
+	if in.Paused != nil {
+		in, out := &in.Paused, &out.Paused
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageEngineMigrationOptions.
+func (in *StorageEngineMigrationOptions) DeepCopy() *StorageEngineMigrationOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageEngineMigrationOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageEngineMigrationStatus) DeepCopyInto(out *StorageEngineMigrationStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageEngineMigrationStatus.
+func (in *StorageEngineMigrationStatus) DeepCopy() *StorageEngineMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageEngineMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StoragePool) DeepCopyInto(out *StoragePool) {
+	*out = *in
+	if in.PodTemplate != nil {
+		in, out := &in.PodTemplate, &out.PodTemplate
+		*out = new(corev1.PodTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VolumeClaimTemplate != nil {
+		in, out := &in.VolumeClaimTemplate, &out.VolumeClaimTemplate
+		*out = new(corev1.PersistentVolumeClaim)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StoragePool.
+func (in *StoragePool) DeepCopy() *StoragePool {
+	if in == nil {
+		return nil
+	}
+	out := new(StoragePool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSOptions) DeepCopyInto(out *TLSOptions) {
+	*out = *in
+	if in.RequireFIPSCompliance != nil {
+		in, out := &in.RequireFIPSCompliance, &out.RequireFIPSCompliance
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MinimumVersion != nil {
+		in, out := &in.MinimumVersion, &out.MinimumVersion
+		*out = new(TLSVersion)
+		**out = **in
+	}
+	if in.CipherSuites != nil {
+		in, out := &in.CipherSuites, &out.CipherSuites
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSOptions.
+func (in *TLSOptions) DeepCopy() *TLSOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaintReplacementOption) DeepCopyInto(out *TaintReplacementOption) {
 	*out = *in
@@ -1892,6 +3547,33 @@ func (in *TaintReplacementOption) DeepCopy() *TaintReplacementOption {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TuningProfileStatus) DeepCopyInto(out *TuningProfileStatus) {
+	*out = *in
+	if in.Knobs != nil {
+		in, out := &in.Knobs, &out.Knobs
+		*out = make(FoundationDBCustomParameters, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TuningProfileStatus.
+func (in *TuningProfileStatus) DeepCopy() *TuningProfileStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TuningProfileStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Version) DeepCopyInto(out *Version) {
 	*out = *in
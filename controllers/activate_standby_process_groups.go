@@ -0,0 +1,174 @@
+/*
+ * activate_standby_process_groups.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbstatus"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// activateStandbyProcessGroups provides a reconciliation step for re-including standby process groups to replace
+// process groups that were marked for removal.
+type activateStandbyProcessGroups struct{}
+
+// reconcile runs the reconciler's work.
+func (a activateStandbyProcessGroups) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, logger logr.Logger) *requeue {
+	standbyProcessGroups, err := getStandbyProcessGroupsToActivate(cluster)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	if len(standbyProcessGroups) == 0 {
+		return nil
+	}
+
+	adminClient, err := r.getDatabaseClientProvider().GetAdminClient(cluster, r)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+	defer adminClient.Close()
+
+	// If the status is not cached, we have to fetch it.
+	if status == nil {
+		status, err = adminClient.GetStatus()
+		if err != nil {
+			return &requeue{curError: err}
+		}
+	}
+
+	processesToInclude, err := getStandbyProcessesToInclude(standbyProcessGroups, status)
+	if err != nil {
+		return &requeue{curError: err, delayedRequeue: true}
+	}
+
+	if len(processesToInclude) > 0 {
+		// Make sure the inclusion are coordinated across multiple operator instances.
+		if cluster.ShouldUseLocks() {
+			lockClient, err := r.getLockClient(cluster)
+			if err != nil {
+				return &requeue{curError: err}
+			}
+
+			_, err = lockClient.TakeLock()
+			if err != nil {
+				return &requeue{curError: err, delayedRequeue: true}
+			}
+
+			defer func() {
+				err = lockClient.ReleaseLock()
+				if err != nil {
+					logger.Error(err, "could not release lock")
+				}
+			}()
+		}
+
+		// Make sure it's safe to include processes.
+		err = fdbstatus.CanSafelyIncludeProcesses(cluster, status, r.MinimumRecoveryTimeForInclusion)
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+
+		r.Recorder.Event(cluster, corev1.EventTypeNormal, "IncludingStandbyProcesses", fmt.Sprintf("Including standby processes: %v", processesToInclude))
+		err = adminClient.IncludeProcesses(processesToInclude)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+	}
+
+	for _, processGroup := range standbyProcessGroups {
+		logger.Info("Activating standby process group", "processGroupID", processGroup.ProcessGroupID, "processClass", processGroup.ProcessClass)
+		processGroup.ActivateStandby()
+	}
+
+	err = r.updateOrApply(ctx, cluster)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	return nil
+}
+
+// getStandbyProcessGroupsToActivate returns the standby process groups that should be activated, to make sure that
+// every process class has the desired number of active process groups.
+func getStandbyProcessGroupsToActivate(cluster *fdbv1beta2.FoundationDBCluster) ([]*fdbv1beta2.ProcessGroupStatus, error) {
+	desiredCountStruct, err := cluster.GetProcessCountsWithDefaults()
+	if err != nil {
+		return nil, err
+	}
+	desiredCounts := desiredCountStruct.Map()
+
+	processCounts, _, err := cluster.GetCurrentProcessGroupsAndProcessCounts()
+	if err != nil {
+		return nil, err
+	}
+
+	standbyProcessGroups := make([]*fdbv1beta2.ProcessGroupStatus, 0)
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if !processGroup.IsStandby() {
+			continue
+		}
+
+		if getNonNegativeCount(desiredCounts[processGroup.ProcessClass])-processCounts[processGroup.ProcessClass] <= 0 {
+			continue
+		}
+
+		standbyProcessGroups = append(standbyProcessGroups, processGroup)
+		processCounts[processGroup.ProcessClass]++
+	}
+
+	return standbyProcessGroups, nil
+}
+
+// getStandbyProcessesToInclude returns the exclusion entries of the standby process groups that are present in the
+// exclusion list of the database.
+func getStandbyProcessesToInclude(standbyProcessGroups []*fdbv1beta2.ProcessGroupStatus, status *fdbv1beta2.FoundationDBStatus) ([]fdbv1beta2.ProcessAddress, error) {
+	excludedServers, err := fdbstatus.GetExclusions(status)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get excluded servers from status, %w", err)
+	}
+
+	excludedServersMap := make(map[string]fdbv1beta2.None, len(excludedServers))
+	for _, excludedServer := range excludedServers {
+		excludedServersMap[excludedServer.String()] = fdbv1beta2.None{}
+	}
+
+	processesToInclude := make([]fdbv1beta2.ProcessAddress, 0)
+	for _, processGroup := range standbyProcessGroups {
+		exclusionString := processGroup.GetExclusionString()
+		if _, ok := excludedServersMap[exclusionString]; ok {
+			processesToInclude = append(processesToInclude, fdbv1beta2.ProcessAddress{StringAddress: exclusionString})
+		}
+
+		for _, address := range processGroup.Addresses {
+			if _, ok := excludedServersMap[address]; ok {
+				processesToInclude = append(processesToInclude, fdbv1beta2.ProcessAddress{IPAddress: net.ParseIP(address)})
+			}
+		}
+	}
+
+	return processesToInclude, nil
+}
@@ -0,0 +1,86 @@
+/*
+ * activate_standby_process_groups_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient/mock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("activateStandbyProcessGroups", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var adminClient *mock.AdminClient
+	var standbyProcessGroup *fdbv1beta2.ProcessGroupStatus
+	var result *requeue
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		Expect(setupClusterForTest(cluster)).NotTo(HaveOccurred())
+
+		var err error
+		adminClient, err = mock.NewMockAdminClientUncast(cluster, k8sClient)
+		Expect(err).NotTo(HaveOccurred())
+
+		standbyProcessGroup = internal.PickProcessGroups(cluster, fdbv1beta2.ProcessClassStorage, 1)[0]
+		standbyProcessGroup.MarkForRemoval()
+		standbyProcessGroup.SetExclude()
+		standbyProcessGroup.UpdateCondition(fdbv1beta2.ProcessIsMarkedAsExcluded, true)
+		standbyProcessGroup.MarkAsStandby()
+		adminClient.ExcludedAddresses = map[string]fdbv1beta2.None{
+			standbyProcessGroup.GetExclusionString(): {},
+		}
+	})
+
+	JustBeforeEach(func() {
+		result = activateStandbyProcessGroups{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)
+	})
+
+	When("the process class has the desired number of active process groups", func() {
+		BeforeEach(func() {
+			cluster.Status.ProcessGroups = append(cluster.Status.ProcessGroups, fdbv1beta2.NewProcessGroupStatus("storage-1337", fdbv1beta2.ProcessClassStorage, nil))
+		})
+
+		It("should keep the standby process group", func() {
+			Expect(result).To(BeNil())
+			Expect(standbyProcessGroup.IsStandby()).To(BeTrue())
+			Expect(standbyProcessGroup.IsMarkedForRemoval()).To(BeTrue())
+			Expect(adminClient.ReincludedAddresses).To(BeEmpty())
+		})
+	})
+
+	When("the process class is missing an active process group", func() {
+		It("should include and activate the standby process group", func() {
+			Expect(result).To(BeNil())
+			Expect(standbyProcessGroup.IsStandby()).To(BeFalse())
+			Expect(standbyProcessGroup.IsMarkedForRemoval()).To(BeFalse())
+			Expect(standbyProcessGroup.IsExcluded()).To(BeFalse())
+			Expect(standbyProcessGroup.GetConditionTime(fdbv1beta2.ProcessIsMarkedAsExcluded)).To(BeNil())
+			Expect(adminClient.ReincludedAddresses).To(Equal(map[string]bool{
+				standbyProcessGroup.GetExclusionString(): true,
+			}))
+		})
+	})
+})
@@ -0,0 +1,105 @@
+/*
+ * active_operations.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+)
+
+// recordActiveOperation adds the operation to the active operations of the cluster status and persists the status, if
+// the operation was not already recorded.
+func (r *FoundationDBClusterReconciler) recordActiveOperation(ctx context.Context, logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, operationType fdbv1beta2.ActiveOperationType, owner string, processGroups []fdbv1beta2.ProcessGroupID) error {
+	activeOperations := len(cluster.Status.ActiveOperations)
+	id := cluster.AddActiveOperation(operationType, owner, processGroups)
+	if len(cluster.Status.ActiveOperations) == activeOperations {
+		return nil
+	}
+
+	logger.Info("Recorded active operation", "id", id, "type", operationType, "owner", owner, "processGroups", processGroups)
+	return r.updateOrApply(ctx, cluster)
+}
+
+// getActiveOperations returns the active operations of the cluster status that are not yet completed. An operation
+// is completed once all targeted process groups have reached the desired state or were removed. Configuration changes
+// are completed once the database configuration matches the desired configuration.
+func getActiveOperations(cluster *fdbv1beta2.FoundationDBCluster) []fdbv1beta2.ActiveOperation {
+	if len(cluster.Status.ActiveOperations) == 0 {
+		return nil
+	}
+
+	processGroups := make(map[fdbv1beta2.ProcessGroupID]*fdbv1beta2.ProcessGroupStatus, len(cluster.Status.ProcessGroups))
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		processGroups[processGroup.ProcessGroupID] = processGroup
+	}
+
+	activeOperations := make([]fdbv1beta2.ActiveOperation, 0, len(cluster.Status.ActiveOperations))
+	for _, operation := range cluster.Status.ActiveOperations {
+		if operation.Type == fdbv1beta2.ActiveOperationTypeConfigurationChange {
+			if cluster.Status.Generations.NeedsConfigurationChange > 0 {
+				activeOperations = append(activeOperations, operation)
+			}
+
+			continue
+		}
+
+		for _, processGroupID := range operation.ProcessGroups {
+			processGroup, ok := processGroups[processGroupID]
+			if !ok {
+				continue
+			}
+
+			if !operationCompletedForProcessGroup(operation.Type, processGroup) {
+				activeOperations = append(activeOperations, operation)
+				break
+			}
+		}
+	}
+
+	if len(activeOperations) == 0 {
+		return nil
+	}
+
+	return activeOperations
+}
+
+// operationCompletedForProcessGroup returns true if the operation of the provided type is completed for the process
+// group.
+func operationCompletedForProcessGroup(operationType fdbv1beta2.ActiveOperationType, processGroup *fdbv1beta2.ProcessGroupStatus) bool {
+	switch operationType {
+	case fdbv1beta2.ActiveOperationTypeBounce:
+		return processGroup.GetConditionTime(fdbv1beta2.IncorrectCommandLine) == nil
+	case fdbv1beta2.ActiveOperationTypeExclusion:
+		return processGroup.IsExcluded() || !processGroup.IsMarkedForRemoval()
+	case fdbv1beta2.ActiveOperationTypeDeletion:
+		// Process groups that are removed are completed once they are removed from the status.
+		if processGroup.IsMarkedForRemoval() {
+			return false
+		}
+
+		return processGroup.GetConditionTime(fdbv1beta2.MissingPod) == nil && processGroup.GetConditionTime(fdbv1beta2.IncorrectPodSpec) == nil
+	}
+
+	return true
+}
@@ -0,0 +1,184 @@
+/*
+ * active_operations_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("active_operations", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var processGroups []*fdbv1beta2.ProcessGroupStatus
+	var processGroupIDs []fdbv1beta2.ProcessGroupID
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		Expect(setupClusterForTest(cluster)).NotTo(HaveOccurred())
+
+		processGroups = internal.PickProcessGroups(cluster, fdbv1beta2.ProcessClassStorage, 2)
+		processGroupIDs = make([]fdbv1beta2.ProcessGroupID, 0, len(processGroups))
+		for _, processGroup := range processGroups {
+			processGroupIDs = append(processGroupIDs, processGroup.ProcessGroupID)
+		}
+	})
+
+	When("recording an active operation", func() {
+		JustBeforeEach(func() {
+			Expect(clusterReconciler.recordActiveOperation(context.TODO(), globalControllerLogger, cluster, fdbv1beta2.ActiveOperationTypeBounce, "bounceProcesses", processGroupIDs)).To(Succeed())
+		})
+
+		It("should persist the operation", func() {
+			Expect(cluster.Status.ActiveOperations).To(HaveLen(1))
+			Expect(cluster.Status.ActiveOperations[0].Type).To(Equal(fdbv1beta2.ActiveOperationTypeBounce))
+			Expect(cluster.Status.ActiveOperations[0].Owner).To(Equal("bounceProcesses"))
+			Expect(cluster.Status.ActiveOperations[0].ProcessGroups).To(ConsistOf(processGroupIDs))
+
+			_, err := reloadCluster(cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cluster.Status.ActiveOperations).To(HaveLen(1))
+		})
+
+		When("the same operation is recorded again", func() {
+			It("should not add a second operation", func() {
+				Expect(clusterReconciler.recordActiveOperation(context.TODO(), globalControllerLogger, cluster, fdbv1beta2.ActiveOperationTypeBounce, "bounceProcesses", processGroupIDs)).To(Succeed())
+				Expect(cluster.Status.ActiveOperations).To(HaveLen(1))
+			})
+		})
+	})
+
+	When("getting the active operations", func() {
+		var activeOperations []fdbv1beta2.ActiveOperation
+
+		JustBeforeEach(func() {
+			activeOperations = getActiveOperations(cluster)
+		})
+
+		When("a bounce was recorded", func() {
+			BeforeEach(func() {
+				cluster.AddActiveOperation(fdbv1beta2.ActiveOperationTypeBounce, "bounceProcesses", processGroupIDs)
+			})
+
+			When("the processes are not yet restarted", func() {
+				BeforeEach(func() {
+					processGroups[0].UpdateCondition(fdbv1beta2.IncorrectCommandLine, true)
+				})
+
+				It("should keep the operation", func() {
+					Expect(activeOperations).To(HaveLen(1))
+				})
+			})
+
+			When("the processes are restarted", func() {
+				It("should remove the operation", func() {
+					Expect(activeOperations).To(BeEmpty())
+				})
+			})
+
+			When("the process groups were removed", func() {
+				BeforeEach(func() {
+					cluster.Status.ProcessGroups = internal.PickProcessGroups(cluster, fdbv1beta2.ProcessClassLog, 1)
+				})
+
+				It("should remove the operation", func() {
+					Expect(activeOperations).To(BeEmpty())
+				})
+			})
+		})
+
+		When("an exclusion was recorded", func() {
+			BeforeEach(func() {
+				for _, processGroup := range processGroups {
+					processGroup.MarkForRemoval()
+				}
+				cluster.AddActiveOperation(fdbv1beta2.ActiveOperationTypeExclusion, "excludeProcesses", processGroupIDs)
+			})
+
+			When("the processes are not yet excluded", func() {
+				It("should keep the operation", func() {
+					Expect(activeOperations).To(HaveLen(1))
+				})
+			})
+
+			When("the processes are excluded", func() {
+				BeforeEach(func() {
+					for _, processGroup := range processGroups {
+						processGroup.SetExclude()
+					}
+				})
+
+				It("should remove the operation", func() {
+					Expect(activeOperations).To(BeEmpty())
+				})
+			})
+		})
+
+		When("a deletion was recorded", func() {
+			BeforeEach(func() {
+				cluster.AddActiveOperation(fdbv1beta2.ActiveOperationTypeDeletion, "updatePods", processGroupIDs)
+			})
+
+			When("the Pods are not yet recreated", func() {
+				BeforeEach(func() {
+					processGroups[1].UpdateCondition(fdbv1beta2.MissingPod, true)
+				})
+
+				It("should keep the operation", func() {
+					Expect(activeOperations).To(HaveLen(1))
+				})
+			})
+
+			When("the Pods are recreated", func() {
+				It("should remove the operation", func() {
+					Expect(activeOperations).To(BeEmpty())
+				})
+			})
+		})
+
+		When("a configuration change was recorded", func() {
+			BeforeEach(func() {
+				cluster.AddActiveOperation(fdbv1beta2.ActiveOperationTypeConfigurationChange, "updateDatabaseConfiguration", nil)
+			})
+
+			When("the configuration change is pending", func() {
+				BeforeEach(func() {
+					cluster.Status.Generations.NeedsConfigurationChange = 1
+				})
+
+				It("should keep the operation", func() {
+					Expect(activeOperations).To(HaveLen(1))
+					Expect(activeOperations[0].ProcessGroups).To(BeEmpty())
+				})
+			})
+
+			When("the configuration change is completed", func() {
+				It("should remove the operation", func() {
+					Expect(activeOperations).To(BeEmpty())
+				})
+			})
+		})
+	})
+})
@@ -28,6 +28,7 @@ import (
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 )
@@ -53,10 +54,15 @@ func (a addPods) reconcile(ctx context.Context, r *FoundationDBClusterReconciler
 		return &requeue{curError: err}
 	}
 
+	originalStatus := cluster.Status.DeepCopy()
+	var commitments []internal.NodeMemoryCommitment
+	var overcommitted int
+
 	for _, processGroup := range cluster.Status.ProcessGroups {
 		_, err := r.PodLifecycleManager.GetPod(ctx, r, cluster, processGroup.GetPodName(cluster))
 		// If no error is returned the Pod exists
 		if err == nil {
+			processGroup.UpdateCondition(fdbv1beta2.NodeMemoryOvercommitted, false)
 			continue
 		}
 
@@ -82,6 +88,11 @@ func (a addPods) reconcile(ctx context.Context, r *FoundationDBClusterReconciler
 			return &requeue{curError: err}
 		}
 
+		// If the volume of this process group is already bound to a node, the Pod can only be scheduled on this node.
+		if processGroup.VolumeNodeName != "" {
+			internal.RelaxPodAntiAffinity(&pod.Spec)
+		}
+
 		serverPerPod, err := internal.GetServersPerPodForPod(pod, processGroup.ProcessClass)
 		if err != nil {
 			return &requeue{curError: err}
@@ -94,7 +105,7 @@ func (a addPods) reconcile(ctx context.Context, r *FoundationDBClusterReconciler
 
 		pod.ObjectMeta.Annotations[fdbv1beta2.LastConfigMapKey] = configMapHash
 
-		if cluster.GetPublicIPSource() == fdbv1beta2.PublicIPSourceService {
+		if cluster.GetPublicIPSourceForProcessGroup(processGroup) == fdbv1beta2.PublicIPSourceService {
 			service := &corev1.Service{}
 			err = r.Get(ctx, types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}, service)
 			if err != nil {
@@ -108,6 +119,32 @@ func (a addPods) reconcile(ctx context.Context, r *FoundationDBClusterReconciler
 			pod.Annotations[fdbv1beta2.PublicIPAnnotation] = ip
 		}
 
+		// The scheduling gate will be removed by the releaseSchedulingGates reconciler.
+		if cluster.UseStagedPodCreation() {
+			pod.Spec.SchedulingGates = append(pod.Spec.SchedulingGates, corev1.PodSchedulingGate{Name: fdbv1beta2.StagedCreationSchedulingGate})
+		}
+
+		if cluster.UseMemoryOvercommitProtection() {
+			// The node memory commitments are only fetched once per reconciliation, the Pods created in this loop
+			// are not taken into account until the next reconciliation.
+			if commitments == nil {
+				commitments, err = internal.GetNodeMemoryCommitments(ctx, r, cluster)
+				if err != nil {
+					return &requeue{curError: err}
+				}
+			}
+
+			conflict := internal.GetMemoryOvercommitConflict(cluster, commitments, &pod.Spec, processGroup)
+			if conflict != "" {
+				logger.Info("Skipping Pod creation because it would overcommit the node memory", "processGroupID", processGroup.ProcessGroupID, "conflict", conflict)
+				r.Recorder.Event(cluster, corev1.EventTypeWarning, "NodeMemoryOvercommitted", fmt.Sprintf("skipped creating Pod for %s: %s", processGroup.ProcessGroupID, conflict))
+				processGroup.UpdateCondition(fdbv1beta2.NodeMemoryOvercommitted, true)
+				overcommitted++
+				continue
+			}
+		}
+
+		processGroup.UpdateCondition(fdbv1beta2.NodeMemoryOvercommitted, false)
 		err = r.PodLifecycleManager.CreatePod(logr.NewContext(ctx, logger), r, pod)
 		if err != nil {
 			if internal.IsQuotaExceeded(err) {
@@ -118,5 +155,16 @@ func (a addPods) reconcile(ctx context.Context, r *FoundationDBClusterReconciler
 		}
 	}
 
+	if !equality.Semantic.DeepEqual(cluster.Status, *originalStatus) {
+		err = r.updateOrApply(ctx, cluster)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+	}
+
+	if overcommitted > 0 {
+		return &requeue{message: fmt.Sprintf("%d Pods were not created to prevent a memory overcommit of the nodes", overcommitted), delayedRequeue: true}
+	}
+
 	return nil
 }
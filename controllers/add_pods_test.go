@@ -27,6 +27,9 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 	"sort"
 )
 
@@ -110,6 +113,72 @@ var _ = Describe("add_pods", func() {
 			expectNewPodToHaveBeenCreated(initialPods, newPods, cluster, newProcessGroupID)
 		})
 
+		When("the staged Pod creation is enabled", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.StagedPodCreation = &fdbv1beta2.StagedPodCreationOptions{
+					Enabled: pointer.Bool(true),
+				}
+			})
+
+			It("should create the pod with the scheduling gate", func() {
+				expectNewPodToHaveBeenCreated(initialPods, newPods, cluster, newProcessGroupID)
+				for _, pod := range newPods.Items {
+					if pod.Labels[fdbv1beta2.FDBProcessGroupIDLabel] != string(newProcessGroupID) {
+						continue
+					}
+
+					Expect(pod.Spec.SchedulingGates).To(ConsistOf(corev1.PodSchedulingGate{Name: fdbv1beta2.StagedCreationSchedulingGate}))
+				}
+			})
+		})
+
+		When("the memory overcommit protection is enabled", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.MemoryOvercommitProtection = &fdbv1beta2.MemoryOvercommitProtectionOptions{}
+				processGroupWithoutPod.VolumeNodeName = "small-node"
+				Expect(k8sClient.Create(context.TODO(), &corev1.Node{
+					ObjectMeta: metav1.ObjectMeta{Name: "small-node"},
+					Status: corev1.NodeStatus{
+						Allocatable: corev1.ResourceList{
+							corev1.ResourceMemory: resource.MustParse("4Gi"),
+						},
+					},
+				})).NotTo(HaveOccurred())
+			})
+
+			It("should requeue with a delay", func() {
+				Expect(requeue).NotTo(BeNil())
+				Expect(requeue.delayedRequeue).To(BeTrue())
+				Expect(requeue.message).To(Equal("1 Pods were not created to prevent a memory overcommit of the nodes"))
+			})
+
+			It("should not create the pod", func() {
+				Expect(newPods.Items).To(HaveLen(len(initialPods.Items)))
+			})
+
+			It("should set the NodeMemoryOvercommitted condition", func() {
+				processGroup := fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, newProcessGroupID)
+				Expect(processGroup).NotTo(BeNil())
+				Expect(processGroup.GetConditionTime(fdbv1beta2.NodeMemoryOvercommitted)).NotTo(BeNil())
+			})
+
+			When("the node has enough memory", func() {
+				BeforeEach(func() {
+					cluster.Spec.Processes = map[fdbv1beta2.ProcessClass]fdbv1beta2.ProcessSettings{
+						fdbv1beta2.ProcessClassGeneral: {CustomParameters: fdbv1beta2.FoundationDBCustomParameters{"memory=2GiB"}},
+					}
+				})
+
+				It("should not requeue", func() {
+					Expect(requeue).To(BeNil())
+				})
+
+				It("should create an extra pod", func() {
+					expectNewPodToHaveBeenCreated(initialPods, newPods, cluster, newProcessGroupID)
+				})
+			})
+		})
+
 		When("the process group is being removed", func() {
 			BeforeEach(func() {
 				processGroupWithoutPod.MarkForRemoval()
@@ -28,6 +28,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
 )
 
 // addProcessGroups provides a reconciliation step for adding new pods to a cluster.
@@ -46,12 +47,24 @@ func (a addProcessGroups) reconcile(ctx context.Context, r *FoundationDBClusterR
 	}
 
 	hasNewProcessGroups := false
+	if cluster.UseProcessClassReassignment() {
+		hasNewProcessGroups = reassignProcessGroups(r, cluster, desiredCounts, processCounts, logger)
+	}
+
 	for _, processClass := range fdbv1beta2.ProcessClasses {
 		desiredCount := desiredCounts[processClass]
 		if desiredCount < 0 {
 			desiredCount = 0
 		}
 		newCount := desiredCount - processCounts[processClass]
+		// If storage pools are used, the new process groups are assigned to the storage pools that have fewer process
+		// groups than desired. Surplus process groups of other storage pools are removed by the chooseRemovals reconciler.
+		var storagePools []string
+		if processClass == fdbv1beta2.ProcessClassStorage && cluster.UseStoragePools() {
+			storagePools = getStoragePoolsForNewProcessGroups(cluster)
+			newCount = len(storagePools)
+		}
+
 		if newCount <= 0 {
 			continue
 		}
@@ -65,9 +78,31 @@ func (a addProcessGroups) reconcile(ctx context.Context, r *FoundationDBClusterR
 		logger.Info("Adding new Process Groups", "processClass", processClass, "newCount", newCount, "desiredCount", desiredCount, "currentCount", processCounts[processClass])
 		r.Recorder.Event(cluster, corev1.EventTypeNormal, "AddingProcesses", fmt.Sprintf("Adding %d %s processes", newCount, processClass))
 		for i := 0; i < newCount; i++ {
-			processGroupID := cluster.GetNextRandomProcessGroupID(processClass, processGroupIDs[processClass])
+			var processGroupID fdbv1beta2.ProcessGroupID
+			switch cluster.GetProcessGroupIDAllocationStrategy() {
+			case fdbv1beta2.ProcessGroupIDAllocationDense:
+				var idNum int
+				processGroupID, idNum = cluster.GetNextProcessGroupID(processClass, processGroupIDs[processClass], 1)
+				processGroupIDs[processClass][idNum] = true
+			case fdbv1beta2.ProcessGroupIDAllocationZoneEncoded:
+				var idNum int
+				processGroupID, idNum, err = cluster.GetNextZoneEncodedProcessGroupID(processClass, processGroupIDs[processClass])
+				if err != nil {
+					return &requeue{curError: err}
+				}
+				processGroupIDs[processClass][idNum] = true
+			default:
+				processGroupID = cluster.GetNextRandomProcessGroupID(processClass, processGroupIDs[processClass])
+			}
+
 			logger.Info("Adding new Process Group to cluster", "processClass", processClass, "processGroupID", processGroupID)
-			cluster.Status.ProcessGroups = append(cluster.Status.ProcessGroups, fdbv1beta2.NewProcessGroupStatus(processGroupID, processClass, nil))
+			processGroup := fdbv1beta2.NewProcessGroupStatus(processGroupID, processClass, nil)
+			// Store the Pod name if a custom prefix is used, so that later changes of the prefix don't change the Pod name.
+			processGroup.PodName = cluster.GetCustomPodName(processClass, processGroupID)
+			if storagePools != nil {
+				processGroup.StoragePool = storagePools[i]
+			}
+			cluster.Status.ProcessGroups = append(cluster.Status.ProcessGroups, processGroup)
 		}
 	}
 
@@ -80,3 +115,82 @@ func (a addProcessGroups) reconcile(ctx context.Context, r *FoundationDBClusterR
 
 	return nil
 }
+
+// reassignProcessGroups changes the process class of process groups from process classes with more process groups than
+// desired to compatible process classes that require additional process groups. Those process groups will be updated
+// in place instead of being replaced. The processCounts will be updated to reflect the reassigned process groups.
+func reassignProcessGroups(r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, desiredCounts map[fdbv1beta2.ProcessClass]int, processCounts map[fdbv1beta2.ProcessClass]int, logger logr.Logger) bool {
+	hasReassignments := false
+	for _, processClass := range fdbv1beta2.ProcessClasses {
+		missingCount := getNonNegativeCount(desiredCounts[processClass]) - processCounts[processClass]
+		if missingCount <= 0 {
+			continue
+		}
+
+		for _, processGroup := range cluster.Status.ProcessGroups {
+			if missingCount <= 0 {
+				break
+			}
+
+			currentProcessClass := processGroup.ProcessClass
+			if currentProcessClass == processClass || processGroup.IsMarkedForRemoval() || cluster.ProcessGroupIsBeingRemoved(processGroup.ProcessGroupID) {
+				continue
+			}
+
+			// Only process groups of process classes that have more process groups than desired can be reassigned.
+			if processCounts[currentProcessClass] <= getNonNegativeCount(desiredCounts[currentProcessClass]) {
+				continue
+			}
+
+			if !internal.ProcessClassesAreCompatible(cluster, currentProcessClass, processClass) {
+				continue
+			}
+
+			// Process groups of storage pools are managed per storage pool, so they are not reassigned.
+			if cluster.UseStoragePools() && (currentProcessClass == fdbv1beta2.ProcessClassStorage || processClass == fdbv1beta2.ProcessClassStorage) {
+				continue
+			}
+
+			logger.Info("Reassigning Process Group to new process class", "processGroupID", processGroup.ProcessGroupID, "currentProcessClass", currentProcessClass, "desiredProcessClass", processClass)
+			r.Recorder.Event(cluster, corev1.EventTypeNormal, "ReassigningProcesses", fmt.Sprintf("Reassigning process group %s from %s to %s", processGroup.ProcessGroupID, currentProcessClass, processClass))
+			processGroup.ReassignProcessClass(processClass)
+			processCounts[currentProcessClass]--
+			processCounts[processClass]++
+			missingCount--
+			hasReassignments = true
+		}
+	}
+
+	return hasReassignments
+}
+
+// getNonNegativeCount returns the provided count or 0 if the count is negative.
+func getNonNegativeCount(count int) int {
+	if count < 0 {
+		return 0
+	}
+
+	return count
+}
+
+// getStoragePoolsForNewProcessGroups returns the storage pool for every storage process group that must be added to
+// reach the desired count of every storage pool. Process groups that are marked for removal are not counted.
+func getStoragePoolsForNewProcessGroups(cluster *fdbv1beta2.FoundationDBCluster) []string {
+	currentCounts := map[string]int{}
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if processGroup.ProcessClass != fdbv1beta2.ProcessClassStorage || processGroup.IsMarkedForRemoval() {
+			continue
+		}
+
+		currentCounts[processGroup.StoragePool]++
+	}
+
+	storagePools := make([]string, 0)
+	for _, storagePool := range cluster.Spec.StoragePools {
+		for i := currentCounts[storagePool.Name]; i < storagePool.Count; i++ {
+			storagePools = append(storagePools, storagePool.Name)
+		}
+	}
+
+	return storagePools
+}
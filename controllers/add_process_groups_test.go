@@ -22,12 +22,15 @@ package controllers
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
 )
 
 var _ = Describe("add_process_groups", func() {
@@ -175,6 +178,179 @@ var _ = Describe("add_process_groups", func() {
 		})
 	})
 
+	When("a custom Pod name prefix is defined for the storage processes", func() {
+		var initialProcessGroupIDs map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None
+
+		BeforeEach(func() {
+			cluster.Spec.Processes = map[fdbv1beta2.ProcessClass]fdbv1beta2.ProcessSettings{
+				fdbv1beta2.ProcessClassGeneral: cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral],
+				fdbv1beta2.ProcessClassStorage: {
+					PodNamePrefix: "ss",
+				},
+			}
+			cluster.Spec.ProcessCounts.Storage++
+
+			initialProcessGroupIDs = map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None{}
+			for _, processGroup := range cluster.Status.ProcessGroups {
+				initialProcessGroupIDs[processGroup.ProcessGroupID] = fdbv1beta2.None{}
+			}
+		})
+
+		It("should store the Pod name only for the new process group", func() {
+			for _, processGroup := range cluster.Status.ProcessGroups {
+				if _, ok := initialProcessGroupIDs[processGroup.ProcessGroupID]; ok {
+					Expect(processGroup.PodName).To(BeEmpty())
+					continue
+				}
+
+				idNum, err := processGroup.ProcessGroupID.GetIDNumber()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(processGroup.PodName).To(Equal(fmt.Sprintf("%s-ss-%d", cluster.Name, idNum)))
+				Expect(processGroup.GetPodName(cluster)).To(Equal(processGroup.PodName))
+			}
+		})
+	})
+
+	When("compact process group IDs are enabled", func() {
+		var initialProcessGroupIDs map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None
+		var usedIDNumbers map[int]bool
+
+		BeforeEach(func() {
+			cluster.Spec.AutomationOptions.UseCompactProcessGroupIDs = pointer.Bool(true)
+			cluster.Spec.ProcessCounts.Storage += 2
+
+			initialProcessGroupIDs = map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None{}
+			usedIDNumbers = map[int]bool{}
+			for _, processGroup := range cluster.Status.ProcessGroups {
+				initialProcessGroupIDs[processGroup.ProcessGroupID] = fdbv1beta2.None{}
+				if processGroup.ProcessClass != fdbv1beta2.ProcessClassStorage {
+					continue
+				}
+
+				idNum, err := processGroup.ProcessGroupID.GetIDNumber()
+				Expect(err).NotTo(HaveOccurred())
+				usedIDNumbers[idNum] = true
+			}
+		})
+
+		It("should add the storage processes with the lowest unused ID numbers", func() {
+			var expectedProcessGroupIDs []fdbv1beta2.ProcessGroupID
+			for idNum := 1; len(expectedProcessGroupIDs) < 2; idNum++ {
+				if usedIDNumbers[idNum] {
+					continue
+				}
+
+				_, processGroupID := cluster.GetProcessGroupID(fdbv1beta2.ProcessClassStorage, idNum)
+				expectedProcessGroupIDs = append(expectedProcessGroupIDs, processGroupID)
+			}
+
+			newProcessGroupIDs := make([]fdbv1beta2.ProcessGroupID, 0, 2)
+			for _, processGroup := range cluster.Status.ProcessGroups {
+				if _, ok := initialProcessGroupIDs[processGroup.ProcessGroupID]; ok {
+					continue
+				}
+
+				newProcessGroupIDs = append(newProcessGroupIDs, processGroup.ProcessGroupID)
+			}
+
+			Expect(newProcessGroupIDs).To(ConsistOf(expectedProcessGroupIDs))
+		})
+	})
+
+	When("zone encoded process group IDs are used", func() {
+		var initialProcessGroupIDs map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None
+
+		BeforeEach(func() {
+			strategy := fdbv1beta2.ProcessGroupIDAllocationZoneEncoded
+			cluster.Spec.AutomationOptions.ProcessGroupIDAllocation = &fdbv1beta2.ProcessGroupIDAllocationOptions{
+				Strategy: &strategy,
+				Zones:    []string{"zone-a", "zone-b"},
+			}
+			cluster.Spec.ProcessCounts.Storage += 2
+
+			initialProcessGroupIDs = map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None{}
+			for _, processGroup := range cluster.Status.ProcessGroups {
+				initialProcessGroupIDs[processGroup.ProcessGroupID] = fdbv1beta2.None{}
+			}
+		})
+
+		It("should add the storage processes with ID numbers that encode a zone", func() {
+			newProcessGroupIDs := make([]fdbv1beta2.ProcessGroupID, 0, 2)
+			for _, processGroup := range cluster.Status.ProcessGroups {
+				if _, ok := initialProcessGroupIDs[processGroup.ProcessGroupID]; ok {
+					continue
+				}
+
+				newProcessGroupIDs = append(newProcessGroupIDs, processGroup.ProcessGroupID)
+				Expect(processGroup.ProcessClass).To(Equal(fdbv1beta2.ProcessClassStorage))
+				_, ok := cluster.GetZoneForProcessGroupID(processGroup.ProcessGroupID)
+				Expect(ok).To(BeTrue())
+			}
+
+			Expect(newProcessGroupIDs).To(HaveLen(2))
+		})
+	})
+
+	When("the stateless process count is moved to the proxy process count", func() {
+		BeforeEach(func() {
+			cluster.Spec.ProcessCounts.Stateless = initialProcessCounts.Stateless - 1
+			cluster.Spec.ProcessCounts.Proxy = 1
+		})
+
+		When("the process class reassignment is disabled", func() {
+			It("should add a new proxy process group", func() {
+				Expect(newProcessCounts.Proxy).To(Equal(1))
+				Expect(newProcessCounts.Stateless).To(Equal(initialProcessCounts.Stateless))
+				Expect(cluster.Status.ProcessGroups).To(HaveLen(initialProcessCounts.Total() + 1))
+			})
+		})
+
+		When("the process class reassignment is enabled", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.UseProcessClassReassignment = pointer.Bool(true)
+			})
+
+			It("should reassign a stateless process group", func() {
+				Expect(newProcessCounts.Proxy).To(Equal(1))
+				Expect(newProcessCounts.Stateless).To(Equal(initialProcessCounts.Stateless - 1))
+				Expect(cluster.Status.ProcessGroups).To(HaveLen(initialProcessCounts.Total()))
+
+				for _, processGroup := range cluster.Status.ProcessGroups {
+					if processGroup.ProcessClass != fdbv1beta2.ProcessClassProxy {
+						continue
+					}
+
+					Expect(processGroup.OriginalProcessClass).To(Equal(fdbv1beta2.ProcessClassStateless))
+					Expect(processGroup.GetConditionTime(fdbv1beta2.ProcessClassReassignment)).NotTo(BeNil())
+				}
+			})
+
+			When("the proxy process class uses a different Pod template", func() {
+				BeforeEach(func() {
+					if cluster.Spec.Processes == nil {
+						cluster.Spec.Processes = map[fdbv1beta2.ProcessClass]fdbv1beta2.ProcessSettings{}
+					}
+
+					cluster.Spec.Processes[fdbv1beta2.ProcessClassProxy] = fdbv1beta2.ProcessSettings{
+						PodTemplate: &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								NodeSelector: map[string]string{
+									"proxy": "true",
+								},
+							},
+						},
+					}
+				})
+
+				It("should add a new proxy process group", func() {
+					Expect(newProcessCounts.Proxy).To(Equal(1))
+					Expect(newProcessCounts.Stateless).To(Equal(initialProcessCounts.Stateless))
+					Expect(cluster.Status.ProcessGroups).To(HaveLen(initialProcessCounts.Total() + 1))
+				})
+			})
+		})
+	})
+
 	When("a new processGroup is created", func() {
 		var processGroupStatus *fdbv1beta2.ProcessGroupStatus
 
@@ -214,4 +390,73 @@ var _ = Describe("add_process_groups", func() {
 			Expect(storageProcessGroups).To(HaveLen(storageProcessCount))
 		})
 	})
+
+	When("storage pools are defined", func() {
+		BeforeEach(func() {
+			cluster.Spec.ProcessCounts.Storage = 0
+			cluster.Spec.StoragePools = []fdbv1beta2.StoragePool{
+				{
+					Name:  "fast",
+					Count: 2,
+				},
+				{
+					Name:  "dense",
+					Count: 3,
+				},
+			}
+		})
+
+		It("should add the process groups for the storage pools", func() {
+			storagePoolCounts := map[string]int{}
+			for _, processGroup := range cluster.Status.ProcessGroups {
+				if processGroup.ProcessClass != fdbv1beta2.ProcessClassStorage {
+					continue
+				}
+
+				storagePoolCounts[processGroup.StoragePool]++
+			}
+
+			// The initial storage process groups don't belong to a storage pool and will be removed by the
+			// chooseRemovals reconciler.
+			Expect(storagePoolCounts).To(Equal(map[string]int{
+				"":      initialProcessCounts.Storage,
+				"fast":  2,
+				"dense": 3,
+			}))
+		})
+
+		It("should not change the log or stateless processes", func() {
+			Expect(newProcessCounts.Log).To(Equal(initialProcessCounts.Log))
+			Expect(newProcessCounts.Stateless).To(Equal(initialProcessCounts.Stateless))
+		})
+
+		When("a process group of a storage pool is marked for removal", func() {
+			BeforeEach(func() {
+				for i := 0; i < 2; i++ {
+					processGroup := fdbv1beta2.NewProcessGroupStatus(fdbv1beta2.ProcessGroupID(fmt.Sprintf("storage-%d", 100+i)), fdbv1beta2.ProcessClassStorage, nil)
+					processGroup.StoragePool = "fast"
+					cluster.Status.ProcessGroups = append(cluster.Status.ProcessGroups, processGroup)
+				}
+
+				cluster.Status.ProcessGroups[len(cluster.Status.ProcessGroups)-1].MarkForRemoval()
+			})
+
+			It("should only add the missing process groups", func() {
+				storagePoolCounts := map[string]int{}
+				for _, processGroup := range cluster.Status.ProcessGroups {
+					if processGroup.ProcessClass != fdbv1beta2.ProcessClassStorage || processGroup.IsMarkedForRemoval() {
+						continue
+					}
+
+					storagePoolCounts[processGroup.StoragePool]++
+				}
+
+				Expect(storagePoolCounts).To(Equal(map[string]int{
+					"":      initialProcessCounts.Storage,
+					"fast":  2,
+					"dense": 3,
+				}))
+			})
+		})
+	})
 })
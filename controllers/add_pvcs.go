@@ -26,6 +26,7 @@ import (
 	"github.com/go-logr/logr"
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal/replacements"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	corev1 "k8s.io/api/core/v1"
@@ -51,23 +52,58 @@ func (a addPVCs) reconcile(ctx context.Context, r *FoundationDBClusterReconciler
 		if pvc == nil {
 			continue
 		}
-		existingPVC := &corev1.PersistentVolumeClaim{}
 
-		err = r.Get(ctx, client.ObjectKey{Namespace: pvc.Namespace, Name: pvc.Name}, existingPVC)
+		additionalPVCs, err := internal.GetAdditionalPvcs(cluster, processGroup)
 		if err != nil {
-			if !k8serrors.IsNotFound(err) {
-				return &requeue{curError: err, delayedRequeue: true}
+			return &requeue{curError: err}
+		}
+
+		for idx, desiredPVC := range append([]*corev1.PersistentVolumeClaim{pvc}, additionalPVCs...) {
+			existingPVC := &corev1.PersistentVolumeClaim{}
+			err = r.Get(ctx, client.ObjectKey{Namespace: desiredPVC.Namespace, Name: desiredPVC.Name}, existingPVC)
+			// Only the data PVC of the process group will be expanded online.
+			if err == nil && idx == 0 && cluster.UseOnlinePVCExpansion() {
+				err = expandPVC(ctx, r, cluster, processGroup, existingPVC, desiredPVC, logger)
+				if err != nil {
+					return &requeue{curError: err, delayedRequeue: true}
+				}
+
+				continue
 			}
 
-			owner := internal.BuildOwnerReference(cluster.TypeMeta, cluster.ObjectMeta)
-			pvc.ObjectMeta.OwnerReferences = owner
-			logger.V(1).Info("Creating PVC", "name", pvc.Name)
-			err = r.Create(ctx, pvc)
 			if err != nil {
-				return &requeue{curError: err, delayedRequeue: true}
+				if !k8serrors.IsNotFound(err) {
+					return &requeue{curError: err, delayedRequeue: true}
+				}
+
+				owner := internal.BuildOwnerReference(cluster.TypeMeta, cluster.ObjectMeta)
+				desiredPVC.ObjectMeta.OwnerReferences = owner
+				logger.V(1).Info("Creating PVC", "name", desiredPVC.Name)
+				err = r.Create(ctx, desiredPVC)
+				if err != nil {
+					return &requeue{curError: err, delayedRequeue: true}
+				}
 			}
 		}
 	}
 
 	return nil
 }
+
+// expandPVC updates the storage request of the existing PVC, if the PVC can be expanded online.
+func expandPVC(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus, existingPVC *corev1.PersistentVolumeClaim, desiredPVC *corev1.PersistentVolumeClaim, logger logr.Logger) error {
+	canBeExpanded, err := replacements.PVCCanBeExpandedOnline(ctx, r, cluster, processGroup, existingPVC)
+	if err != nil || !canBeExpanded {
+		return err
+	}
+
+	desiredSize := desiredPVC.Spec.Resources.Requests[corev1.ResourceStorage]
+	logger.Info("Expanding PVC", "name", existingPVC.Name, "processGroupID", processGroup.ProcessGroupID, "currentSize", existingPVC.Spec.Resources.Requests.Storage().String(), "desiredSize", desiredSize.String())
+	existingPVC.Spec.Resources.Requests[corev1.ResourceStorage] = desiredSize
+	if existingPVC.Annotations == nil {
+		existingPVC.Annotations = map[string]string{}
+	}
+	existingPVC.Annotations[fdbv1beta2.LastSpecKey] = desiredPVC.Annotations[fdbv1beta2.LastSpecKey]
+
+	return r.Update(ctx, existingPVC)
+}
@@ -61,12 +61,22 @@ func (a addServices) reconcile(ctx context.Context, r *FoundationDBClusterReconc
 		}
 	}
 
-	if cluster.GetPublicIPSource() == fdbv1beta2.PublicIPSourceService {
+	err := addProcessClassServices(ctx, logger, cluster, r)
+	if err != nil {
+		return &requeue{curError: err, delayedRequeue: true}
+	}
+
+	if cluster.GetPublicIPSource() == fdbv1beta2.PublicIPSourceService || cluster.PinCoordinatorIPs() {
 		for _, processGroup := range cluster.Status.ProcessGroups {
 			if processGroup.IsMarkedForRemoval() && processGroup.IsExcluded() {
 				continue
 			}
 
+			// If the public IP source is pod, only the process groups with a pinned public IP require a service.
+			if cluster.GetPublicIPSourceForProcessGroup(processGroup) != fdbv1beta2.PublicIPSourceService {
+				continue
+			}
+
 			service, err := internal.GetService(cluster, processGroup)
 			if err != nil {
 				return &requeue{curError: err, delayedRequeue: true}
@@ -94,6 +104,46 @@ func (a addServices) reconcile(ctx context.Context, r *FoundationDBClusterReconc
 	return nil
 }
 
+// addProcessClassServices creates or updates the headless Services for the process classes defined in
+// processClassHeadlessServices.
+func addProcessClassServices(ctx context.Context, logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, r *FoundationDBClusterReconciler) error {
+	for _, processClass := range cluster.Spec.Routing.ProcessClassHeadlessServices {
+		service := internal.GetProcessClassHeadlessService(cluster, processClass)
+		existingService := &corev1.Service{}
+		err := r.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: service.Name}, existingService)
+		if err != nil {
+			if !k8serrors.IsNotFound(err) {
+				return err
+			}
+
+			logger.V(1).Info("Creating service", "name", service.Name, "processClass", processClass)
+			err = r.Create(ctx, service)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		// The ports depend on the number of servers per Pod, so they must be updated for the process class Services.
+		if !equality.Semantic.DeepEqual(existingService.Spec.Ports, service.Spec.Ports) {
+			existingService.Spec.Ports = service.Spec.Ports
+			logger.Info("Updating service ports", "name", service.Name, "processClass", processClass)
+			err = r.Update(ctx, existingService)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = updateService(ctx, logger, cluster, r, existingService, service)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // requiresRecreation returns true if the cluster supports podIPFamily as IPv6 and the existing service does not have
 // IPv6 in the IPFamilies.
 func requiresRecreation(cluster *fdbv1beta2.FoundationDBCluster, existingService *corev1.Service) bool {
@@ -232,6 +232,26 @@ var _ = Describe("add_services", func() {
 		})
 	})
 
+	When("headless services for the storage process class are enabled", func() {
+		BeforeEach(func() {
+			cluster.Spec.Routing.ProcessClassHeadlessServices = []fdbv1beta2.ProcessClass{fdbv1beta2.ProcessClassStorage}
+		})
+
+		It("should not requeue", func() {
+			Expect(requeue).To(BeNil())
+		})
+
+		It("should create an extra service", func() {
+			Expect(newServices.Items).To(HaveLen(len(initialServices.Items) + 1))
+
+			service := &corev1.Service{}
+			Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name + "-storage"}, service)).NotTo(HaveOccurred())
+			Expect(service.Spec.ClusterIP).To(Equal(corev1.ClusterIPNone))
+			Expect(service.Spec.Selector).To(HaveKeyWithValue(fdbv1beta2.FDBProcessClassLabel, string(fdbv1beta2.ProcessClassStorage)))
+			Expect(service.Labels).To(HaveKeyWithValue(fdbv1beta2.ProcessClassServiceLabel, string(fdbv1beta2.ProcessClassStorage)))
+		})
+	})
+
 	Context("with the podIPFamily 6", func() {
 		BeforeEach(func() {
 			cluster.Spec.Routing.PodIPFamily = pointer.Int(6)
@@ -22,6 +22,10 @@ package controllers
 
 import (
 	"context"
+	"fmt"
+	"time"
+
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbstatus"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -130,6 +134,50 @@ func (r *FoundationDBBackupReconciler) adminClientForBackup(ctx context.Context,
 	return adminClient, nil
 }
 
+// waitForHealthyCluster checks if the cluster is healthy before the operator starts or changes the backup, if the backup
+// is configured to wait for a healthy cluster. If the cluster is not healthy, the WaitingForHealthyCluster field in the
+// status will be set and a requeue will be returned.
+func (r *FoundationDBBackupReconciler) waitForHealthyCluster(ctx context.Context, backup *fdbv1beta2.FoundationDBBackup) *requeue {
+	if !backup.ShouldWaitForHealthyCluster() {
+		backup.Status.WaitingForHealthyCluster = false
+		return nil
+	}
+
+	logger := globalControllerLogger.WithValues("namespace", backup.Namespace, "backup", backup.Name)
+	cluster := &fdbv1beta2.FoundationDBCluster{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: backup.Namespace, Name: backup.Spec.ClusterName}, cluster)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	adminClient, err := r.adminClientForBackup(ctx, backup)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+	defer adminClient.Close()
+
+	status, err := adminClient.GetStatus()
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	healthErr := fdbstatus.CheckClusterHealth(logger, status, cluster)
+	if healthErr == nil {
+		backup.Status.WaitingForHealthyCluster = false
+		return nil
+	}
+
+	if !backup.Status.WaitingForHealthyCluster {
+		backup.Status.WaitingForHealthyCluster = true
+		err = r.updateOrApply(ctx, backup)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+	}
+
+	return &requeue{message: fmt.Sprintf("waiting for healthy cluster: %s", healthErr.Error()), delay: time.Minute}
+}
+
 // SetupWithManager prepares a reconciler for use.
 func (r *FoundationDBBackupReconciler) SetupWithManager(mgr ctrl.Manager, maxConcurrentReconciles int, selector metav1.LabelSelector) error {
 	err := mgr.GetFieldIndexer().IndexField(context.Background(), &appsv1.Deployment{}, "metadata.name", func(o client.Object) []string {
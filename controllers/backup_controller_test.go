@@ -33,8 +33,10 @@ import (
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	appsv1 "k8s.io/api/apps/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
 )
 
 func reloadBackup(backup *fdbv1beta2.FoundationDBBackup) (int64, error) {
@@ -312,4 +314,77 @@ var _ = Describe("backup_controller", func() {
 			})
 		})
 	})
+
+	When("waiting for a healthy cluster", func() {
+		BeforeEach(func() {
+			err = k8sClient.Create(context.TODO(), cluster)
+			Expect(err).NotTo(HaveOccurred())
+
+			result, err := reconcileCluster(cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Requeue).To(BeFalse())
+
+			_, err = reloadCluster(cluster)
+			Expect(err).NotTo(HaveOccurred())
+
+			backup.Spec.WaitForHealthyCluster = pointer.Bool(true)
+			err = k8sClient.Create(context.TODO(), backup)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		When("the cluster is unavailable", func() {
+			BeforeEach(func() {
+				Expect(adminClient.FreezeStatus()).To(Succeed())
+				adminClient.FrozenStatus.Client.DatabaseStatus.Available = false
+
+				result, err := reconcileBackup(backup)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Requeue).To(BeTrue())
+
+				_, err = reloadBackup(backup)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should not create the backup deployment", func() {
+				deployment := &appsv1.Deployment{}
+				deploymentName := fmt.Sprintf("%s-backup-agents", cluster.Name)
+
+				err := k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: cluster.Namespace, Name: deploymentName}, deployment)
+				Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			})
+
+			It("should report that the operator is waiting for a healthy cluster", func() {
+				Expect(backup.Status.WaitingForHealthyCluster).To(BeTrue())
+			})
+
+			It("should not start a backup", func() {
+				status, err := adminClient.GetBackupStatus()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(status.Status.Running).To(BeFalse())
+			})
+
+			When("the cluster becomes healthy again", func() {
+				BeforeEach(func() {
+					adminClient.UnfreezeStatus()
+
+					result, err := reconcileBackup(backup)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.Requeue).To(BeFalse())
+
+					_, err = reloadBackup(backup)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("should clear the waiting status", func() {
+					Expect(backup.Status.WaitingForHealthyCluster).To(BeFalse())
+				})
+
+				It("should start a backup", func() {
+					status, err := adminClient.GetBackupStatus()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(status.Status.Running).To(BeTrue())
+				})
+			})
+		})
+	})
 })
@@ -43,7 +43,7 @@ import (
 type bounceProcesses struct{}
 
 // reconcile runs the reconciler's work.
-func (bounceProcesses) reconcile(_ context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, logger logr.Logger) *requeue {
+func (bounceProcesses) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, logger logr.Logger) *requeue {
 	if !pointer.BoolDeref(cluster.Spec.AutomationOptions.KillProcesses, true) {
 		return nil
 	}
@@ -72,6 +72,10 @@ func (bounceProcesses) reconcile(_ context.Context, r *FoundationDBClusterReconc
 		return req
 	}
 
+	if cluster.UseKnobRolloutCanary() && !cluster.IsBeingUpgradedWithVersionIncompatibleVersion() {
+		addresses = getKnobRolloutCanaryAddresses(logger, cluster, addressMap, addresses)
+	}
+
 	// Only perform the check if the cluster controller must be restarted if the cluster was up long enough. This is an
 	// additional safety guard to reduce the risk of successive restarts in cases where unidirectional partitions occur.
 	if currentMinimumUptime > r.MinimumRequiredUptimeCCBounce.Seconds() {
@@ -90,6 +94,15 @@ func (bounceProcesses) reconcile(_ context.Context, r *FoundationDBClusterReconc
 		return nil
 	}
 
+	if cluster.BouncesPaused() {
+		r.Recorder.Event(cluster, corev1.EventTypeNormal, "NeedsBounce", "Spec require restarting processes, but bounces are paused")
+		return &requeue{message: "Bounces are paused", delayedRequeue: true}
+	}
+
+	if req := r.checkSafetyInterlock(ctx, logger, cluster, "bounces"); req != nil {
+		return req
+	}
+
 	logger.V(1).Info("processes that can be restarted", "addresses", addresses)
 
 	// Check if the cluster can safely bounce processes.
@@ -176,6 +189,11 @@ func (bounceProcesses) reconcile(_ context.Context, r *FoundationDBClusterReconc
 		return &requeue{curError: err}
 	}
 
+	err = r.recordActiveOperation(ctx, logger, cluster, fdbv1beta2.ActiveOperationTypeBounce, "bounceProcesses", getProcessGroupIDsForAddresses(addressMap, addresses))
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
 	// If the cluster was upgraded we will requeue and let the update_status command set the correct version.
 	// Updating the version in this method has the drawback that we upgrade the version independent of the success
 	// of the kill command. The kill command is not reliable, which means that some kill request might not be
@@ -187,6 +205,27 @@ func (bounceProcesses) reconcile(_ context.Context, r *FoundationDBClusterReconc
 	return nil
 }
 
+// getProcessGroupIDsForAddresses returns the IDs of the process groups that have at least one of the provided
+// addresses.
+func getProcessGroupIDsForAddresses(addressMap map[fdbv1beta2.ProcessGroupID][]fdbv1beta2.ProcessAddress, addresses []fdbv1beta2.ProcessAddress) []fdbv1beta2.ProcessGroupID {
+	addressSet := make(map[string]fdbv1beta2.None, len(addresses))
+	for _, address := range addresses {
+		addressSet[address.String()] = fdbv1beta2.None{}
+	}
+
+	processGroupIDs := make([]fdbv1beta2.ProcessGroupID, 0, len(addresses))
+	for processGroupID, processAddresses := range addressMap {
+		for _, address := range processAddresses {
+			if _, ok := addressSet[address.String()]; ok {
+				processGroupIDs = append(processGroupIDs, processGroupID)
+				break
+			}
+		}
+	}
+
+	return processGroupIDs
+}
+
 // getProcessesReadyForRestart returns a slice of process addresses that can be restarted. If addresses are missing or not all processes
 // have the latest configuration this method will return a requeue struct with more details.
 func getProcessesReadyForRestart(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, addressMap map[fdbv1beta2.ProcessGroupID][]fdbv1beta2.ProcessAddress) ([]fdbv1beta2.ProcessAddress, *requeue) {
@@ -289,6 +328,83 @@ func getProcessesReadyForRestart(logger logr.Logger, cluster *fdbv1beta2.Foundat
 	return addresses, nil
 }
 
+// getKnobRolloutCanaryAddresses limits the provided addresses to a single canary process group for every process class
+// that has no process running with the new command line. Once a process group of a process class is reporting to the
+// database with the new command line, all remaining processes of this process class will be restarted.
+func getKnobRolloutCanaryAddresses(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, addressMap map[fdbv1beta2.ProcessGroupID][]fdbv1beta2.ProcessAddress, addresses []fdbv1beta2.ProcessAddress) []fdbv1beta2.ProcessAddress {
+	restartAddresses := make(map[string]fdbv1beta2.None, len(addresses))
+	for _, address := range addresses {
+		restartAddresses[address.String()] = fdbv1beta2.None{}
+	}
+
+	hasCanary := map[fdbv1beta2.ProcessClass]bool{}
+	hasMissingProcesses := map[fdbv1beta2.ProcessClass]bool{}
+	pendingRestarts := map[fdbv1beta2.ProcessClass][]fdbv1beta2.ProcessGroupID{}
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if processGroup.IsMarkedForRemoval() {
+			continue
+		}
+
+		processAddresses := addressMap[processGroup.ProcessGroupID]
+		if len(processAddresses) == 0 || processGroup.GetConditionTime(fdbv1beta2.MissingProcesses) != nil {
+			// A process that is not reporting could be a canary that failed with the new command line.
+			hasMissingProcesses[processGroup.ProcessClass] = true
+			continue
+		}
+
+		if processGroup.GetConditionTime(fdbv1beta2.IncorrectCommandLine) == nil {
+			hasCanary[processGroup.ProcessClass] = true
+			continue
+		}
+
+		if _, ok := restartAddresses[processAddresses[0].String()]; ok {
+			pendingRestarts[processGroup.ProcessClass] = append(pendingRestarts[processGroup.ProcessClass], processGroup.ProcessGroupID)
+		}
+	}
+
+	skippedProcessGroups := map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None{}
+	for processClass, processGroupIDs := range pendingRestarts {
+		if hasCanary[processClass] {
+			continue
+		}
+
+		if hasMissingProcesses[processClass] {
+			logger.Info("Deferring knob rollout until all processes of the process class are reporting", "processClass", processClass)
+			for _, processGroupID := range processGroupIDs {
+				skippedProcessGroups[processGroupID] = fdbv1beta2.None{}
+			}
+			continue
+		}
+
+		logger.Info("Restarting canary for knob rollout", "processClass", processClass, "processGroupID", processGroupIDs[0])
+		for _, processGroupID := range processGroupIDs[1:] {
+			skippedProcessGroups[processGroupID] = fdbv1beta2.None{}
+		}
+	}
+
+	if len(skippedProcessGroups) == 0 {
+		return addresses
+	}
+
+	skippedAddresses := map[string]fdbv1beta2.None{}
+	for processGroupID := range skippedProcessGroups {
+		for _, address := range addressMap[processGroupID] {
+			skippedAddresses[address.String()] = fdbv1beta2.None{}
+		}
+	}
+
+	filteredAddresses := make([]fdbv1beta2.ProcessAddress, 0, len(addresses))
+	for _, address := range addresses {
+		if _, ok := skippedAddresses[address.String()]; ok {
+			continue
+		}
+
+		filteredAddresses = append(filteredAddresses, address)
+	}
+
+	return filteredAddresses
+}
+
 // getAddressesForUpgrade checks that all processes in a cluster are ready to be
 // upgraded and returns the full list of addresses.
 func getAddressesForUpgrade(logger logr.Logger, r *FoundationDBClusterReconciler, status *fdbv1beta2.FoundationDBStatus, lockClient fdbadminclient.LockClient, cluster *fdbv1beta2.FoundationDBCluster, version fdbv1beta2.Version) ([]fdbv1beta2.ProcessAddress, *requeue) {
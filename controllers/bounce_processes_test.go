@@ -31,6 +31,7 @@ import (
 	"k8s.io/utils/pointer"
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -95,6 +96,42 @@ var _ = Describe("bounceProcesses", func() {
 			Expect(adminClient.KilledAddresses).To(Equal(addresses))
 		})
 
+		When("bounces are paused", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.Paused.Bounces = pointer.Bool(true)
+			})
+
+			It("should requeue", func() {
+				Expect(requeue).NotTo(BeNil())
+				Expect(requeue.message).To(Equal("Bounces are paused"))
+				Expect(requeue.delayedRequeue).To(BeTrue())
+			})
+
+			It("should not kill any processes", func() {
+				Expect(adminClient.KilledAddresses).To(BeEmpty())
+			})
+		})
+
+		When("the safety interlock is active", func() {
+			BeforeEach(func() {
+				clusterReconciler.SafetyInterlockChecker = &frozenSafetyInterlockChecker{reason: "freeze is active: incident"}
+			})
+
+			AfterEach(func() {
+				clusterReconciler.SafetyInterlockChecker = nil
+			})
+
+			It("should requeue", func() {
+				Expect(requeue).NotTo(BeNil())
+				Expect(requeue.message).To(Equal("Deferring bounces: freeze is active: incident"))
+				Expect(requeue.delayedRequeue).To(BeTrue())
+			})
+
+			It("should not kill any processes", func() {
+				Expect(adminClient.KilledAddresses).To(BeEmpty())
+			})
+		})
+
 		When("one process is marked for removal", func() {
 			BeforeEach(func() {
 				pickedProcessGroups[0].MarkForRemoval()
@@ -139,6 +176,73 @@ var _ = Describe("bounceProcesses", func() {
 		})
 	})
 
+	When("the knob rollout strategy is Canary", func() {
+		var storageProcessGroups []*fdbv1beta2.ProcessGroupStatus
+
+		BeforeEach(func() {
+			cluster.Spec.AutomationOptions.KnobRolloutStrategy = fdbv1beta2.KnobRolloutStrategyCanary
+			storageProcessGroups = internal.PickProcessGroups(cluster, fdbv1beta2.ProcessClassStorage, len(cluster.Status.ProcessGroups))
+
+			for _, processGroup := range storageProcessGroups {
+				processGroup.UpdateCondition(fdbv1beta2.IncorrectCommandLine, true)
+			}
+
+			logProcessGroups := internal.PickProcessGroups(cluster, fdbv1beta2.ProcessClassLog, len(cluster.Status.ProcessGroups))
+			for _, processGroup := range logProcessGroups {
+				processGroup.UpdateCondition(fdbv1beta2.IncorrectCommandLine, true)
+			}
+		})
+
+		When("no process of the process class is running with the new command line", func() {
+			It("should not requeue", func() {
+				Expect(requeue).To(BeNil())
+			})
+
+			It("should only kill one canary per process class", func() {
+				Expect(adminClient.KilledAddresses).To(HaveLen(2))
+			})
+		})
+
+		When("the canary of the storage processes is running with the new command line", func() {
+			BeforeEach(func() {
+				storageProcessGroups[0].UpdateCondition(fdbv1beta2.IncorrectCommandLine, false)
+			})
+
+			It("should not requeue", func() {
+				Expect(requeue).To(BeNil())
+			})
+
+			It("should kill the remaining storage processes and one log canary", func() {
+				Expect(adminClient.KilledAddresses).To(HaveLen(len(storageProcessGroups)))
+				for _, processGroup := range storageProcessGroups[1:] {
+					for _, address := range processGroup.Addresses {
+						Expect(adminClient.KilledAddresses).To(HaveKey(fmt.Sprintf("%s:4501", address)))
+					}
+				}
+			})
+		})
+
+		When("a storage process is missing", func() {
+			BeforeEach(func() {
+				storageProcessGroups[0].UpdateCondition(fdbv1beta2.IncorrectCommandLine, false)
+				storageProcessGroups[0].UpdateCondition(fdbv1beta2.MissingProcesses, true)
+			})
+
+			It("should not requeue", func() {
+				Expect(requeue).To(BeNil())
+			})
+
+			It("should only kill the log canary", func() {
+				Expect(adminClient.KilledAddresses).To(HaveLen(1))
+				for _, processGroup := range storageProcessGroups {
+					for _, address := range processGroup.Addresses {
+						Expect(adminClient.KilledAddresses).NotTo(HaveKey(fmt.Sprintf("%s:4501", address)))
+					}
+				}
+			})
+		})
+	})
+
 	Context("with Pod in pending state", func() {
 		var pickedProcessGroups []*fdbv1beta2.ProcessGroupStatus
 
@@ -896,3 +1000,13 @@ var _ = Describe("bounceProcesses", func() {
 		})
 	})
 })
+
+// frozenSafetyInterlockChecker is an interlock.Checker that always reports an active freeze.
+type frozenSafetyInterlockChecker struct {
+	reason string
+}
+
+// IsFrozen always returns true and the configured reason.
+func (checker *frozenSafetyInterlockChecker) IsFrozen(_ context.Context, _ logr.Logger, _ *fdbv1beta2.FoundationDBCluster) (bool, string) {
+	return true, checker.reason
+}
@@ -100,5 +100,12 @@ func (c changeCoordinators) reconcile(ctx context.Context, r *FoundationDBCluste
 		return &requeue{curError: err, delayedRequeue: true}
 	}
 
+	// Update the connection Secret in the same step as the connection string, so that consumers of the Secret don't
+	// observe the previous coordinators until the next reconciliation.
+	err = r.updateConnectionSecret(ctx, logger, cluster)
+	if err != nil {
+		return &requeue{curError: err, delayedRequeue: true}
+	}
+
 	return nil
 }
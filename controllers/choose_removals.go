@@ -47,7 +47,6 @@ func (c chooseRemovals) reconcile(ctx context.Context, r *FoundationDBClusterRec
 		}
 	}
 
-	currentCounts := fdbv1beta2.CreateProcessCountsFromProcessGroupStatus(cluster.Status.ProcessGroups, true).Map()
 	desiredCountStruct, err := cluster.GetProcessCountsWithDefaults()
 	if err != nil {
 		return &requeue{curError: err, delayedRequeue: true}
@@ -79,14 +78,14 @@ func (c chooseRemovals) reconcile(ctx context.Context, r *FoundationDBClusterRec
 
 	remainingProcessMap := make(map[string]bool, len(cluster.Status.ProcessGroups))
 
-	for _, processClass := range fdbv1beta2.ProcessClasses {
-		desiredCount := desiredCounts[processClass]
-		excessCount := currentCounts[processClass] - desiredCount
-		processClassLocality := make([]locality.Info, 0, currentCounts[processClass])
+	for _, candidates := range getRemovalCandidates(cluster, desiredCounts) {
+		desiredCount := candidates.desiredCount
+		excessCount := len(candidates.processGroups) - desiredCount
+		processClassLocality := make([]locality.Info, 0, len(candidates.processGroups))
 
 		// TODO (johscheuer): We could add a higher priority to the process groups that have a condition that requires
 		// an automatic replacement.
-		for _, processGroup := range cluster.Status.ProcessGroupsByProcessClass(processClass) {
+		for _, processGroup := range candidates.processGroups {
 			if processGroup.IsMarkedForRemoval() {
 				excessCount--
 				continue
@@ -98,7 +97,11 @@ func (c chooseRemovals) reconcile(ctx context.Context, r *FoundationDBClusterRec
 		}
 
 		if excessCount > 0 {
-			r.Recorder.Event(cluster, corev1.EventTypeNormal, "ShrinkingProcesses", fmt.Sprintf("Removing %d %s processes", excessCount, processClass))
+			if candidates.storagePool != "" {
+				r.Recorder.Event(cluster, corev1.EventTypeNormal, "ShrinkingProcesses", fmt.Sprintf("Removing %d %s processes from storage pool %s", excessCount, candidates.processClass, candidates.storagePool))
+			} else {
+				r.Recorder.Event(cluster, corev1.EventTypeNormal, "ShrinkingProcesses", fmt.Sprintf("Removing %d %s processes", excessCount, candidates.processClass))
+			}
 
 			remainingProcesses, err := locality.ChooseDistributedProcesses(cluster, processClassLocality, desiredCount, locality.ProcessSelectionConstraint{})
 			if err != nil {
@@ -106,6 +109,8 @@ func (c chooseRemovals) reconcile(ctx context.Context, r *FoundationDBClusterRec
 			}
 
 			logger.Info("Chose remaining processes after shrink",
+				"processClass", candidates.processClass,
+				"storagePool", candidates.storagePool,
 				"desiredCount", desiredCount,
 				"options", processClassLocality,
 				"selected", remainingProcesses)
@@ -136,3 +141,63 @@ func (c chooseRemovals) reconcile(ctx context.Context, r *FoundationDBClusterRec
 
 	return nil
 }
+
+// removalCandidates represents a set of process groups from which the process groups that should be kept during a
+// shrink are chosen.
+type removalCandidates struct {
+	// processClass of the process groups.
+	processClass fdbv1beta2.ProcessClass
+	// storagePool of the process groups, this is only set for storage process groups if storage pools are used.
+	storagePool string
+	// desiredCount is the number of process groups that should be kept.
+	desiredCount int
+	// processGroups contains all process groups of this set, including the process groups marked for removal.
+	processGroups []*fdbv1beta2.ProcessGroupStatus
+}
+
+// getRemovalCandidates returns the removal candidates for every process class. If storage pools are used, the storage
+// process groups are split up per storage pool. Storage process groups that don't belong to a defined storage pool,
+// e.g. because the storage pool was removed from the spec, will all be removed.
+func getRemovalCandidates(cluster *fdbv1beta2.FoundationDBCluster, desiredCounts map[fdbv1beta2.ProcessClass]int) []removalCandidates {
+	candidates := make([]removalCandidates, 0, len(fdbv1beta2.ProcessClasses))
+	for _, processClass := range fdbv1beta2.ProcessClasses {
+		processGroups := cluster.Status.ProcessGroupsByProcessClass(processClass)
+		if processClass != fdbv1beta2.ProcessClassStorage || !cluster.UseStoragePools() {
+			candidates = append(candidates, removalCandidates{
+				processClass:  processClass,
+				desiredCount:  desiredCounts[processClass],
+				processGroups: processGroups,
+			})
+			continue
+		}
+
+		processGroupsPerPool := map[string][]*fdbv1beta2.ProcessGroupStatus{}
+		var unassigned []*fdbv1beta2.ProcessGroupStatus
+		for _, processGroup := range processGroups {
+			storagePool := cluster.GetStoragePoolForProcessGroup(processGroup)
+			if storagePool == nil {
+				unassigned = append(unassigned, processGroup)
+				continue
+			}
+
+			processGroupsPerPool[storagePool.Name] = append(processGroupsPerPool[storagePool.Name], processGroup)
+		}
+
+		for _, storagePool := range cluster.Spec.StoragePools {
+			candidates = append(candidates, removalCandidates{
+				processClass:  processClass,
+				storagePool:   storagePool.Name,
+				desiredCount:  storagePool.Count,
+				processGroups: processGroupsPerPool[storagePool.Name],
+			})
+		}
+
+		candidates = append(candidates, removalCandidates{
+			processClass:  processClass,
+			desiredCount:  0,
+			processGroups: unassigned,
+		})
+	}
+
+	return candidates
+}
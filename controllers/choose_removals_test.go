@@ -151,4 +151,64 @@ var _ = Describe("choose_removals", func() {
 			Expect(string(removals[1])).To(ContainSubstring("storage"))
 		})
 	})
+
+	Context("with storage pools", func() {
+		var fastProcessGroups, denseProcessGroups []fdbv1beta2.ProcessGroupID
+
+		BeforeEach(func() {
+			cluster.Spec.ProcessCounts.Storage = 0
+			cluster.Spec.StoragePools = []fdbv1beta2.StoragePool{
+				{
+					Name:  "fast",
+					Count: 2,
+				},
+				{
+					Name:  "dense",
+					Count: 2,
+				},
+			}
+
+			fastProcessGroups = nil
+			denseProcessGroups = nil
+			for idx, processGroup := range internal.PickProcessGroups(cluster, fdbv1beta2.ProcessClassStorage, 4) {
+				if idx < 2 {
+					processGroup.StoragePool = "fast"
+					fastProcessGroups = append(fastProcessGroups, processGroup.ProcessGroupID)
+					continue
+				}
+
+				processGroup.StoragePool = "dense"
+				denseProcessGroups = append(denseProcessGroups, processGroup.ProcessGroupID)
+			}
+		})
+
+		It("should not requeue", func() {
+			Expect(requeue).To(BeNil())
+		})
+
+		It("should not mark any removals", func() {
+			Expect(removals).To(BeNil())
+		})
+
+		When("the count of a storage pool is decreased", func() {
+			BeforeEach(func() {
+				cluster.Spec.StoragePools[1].Count = 1
+			})
+
+			It("should mark one of the process groups of the storage pool for removal", func() {
+				Expect(removals).To(HaveLen(1))
+				Expect(removals[0]).To(BeElementOf(denseProcessGroups))
+			})
+		})
+
+		When("a storage pool is removed", func() {
+			BeforeEach(func() {
+				cluster.Spec.StoragePools = cluster.Spec.StoragePools[:1]
+			})
+
+			It("should mark all process groups of the removed storage pool for removal", func() {
+				Expect(removals).To(ConsistOf(denseProcessGroups))
+			})
+		})
+	})
 })
@@ -0,0 +1,166 @@
+/*
+ * cleanup_stale_exclusions.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbstatus"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// cleanupStaleExclusions provides a reconciliation step for including exclusion entries that are not related to any
+// known process or process group.
+type cleanupStaleExclusions struct{}
+
+// reconcile runs the reconciler's work.
+func (c cleanupStaleExclusions) reconcile(_ context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, logger logr.Logger) *requeue {
+	if !cluster.CleanupStaleExclusions() {
+		return nil
+	}
+
+	// In a multi-region or multi data center setup, other operator instances could have excluded processes that are
+	// not part of this cluster resource.
+	if cluster.Spec.DatabaseConfiguration.CountUniqueDataCenters() > 1 {
+		logger.V(1).Info("Skipping the cleanup of stale exclusions as the cluster spans multiple data centers")
+		return nil
+	}
+
+	adminClient, err := r.getDatabaseClientProvider().GetAdminClient(cluster, r)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+	defer adminClient.Close()
+
+	// If the status is not cached, we have to fetch it.
+	if status == nil {
+		status, err = adminClient.GetStatus()
+		if err != nil {
+			return &requeue{curError: err}
+		}
+	}
+
+	staleExclusions, err := getStaleExclusions(cluster, status)
+	if err != nil {
+		return &requeue{curError: err, delayedRequeue: true}
+	}
+
+	if len(staleExclusions) == 0 {
+		return nil
+	}
+
+	// Make sure the inclusion are coordinated across multiple operator instances.
+	if cluster.ShouldUseLocks() {
+		lockClient, err := r.getLockClient(cluster)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+
+		_, err = lockClient.TakeLock()
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+
+		defer func() {
+			err = lockClient.ReleaseLock()
+			if err != nil {
+				logger.Error(err, "could not release lock")
+			}
+		}()
+	}
+
+	// Make sure it's safe to include processes.
+	err = fdbstatus.CanSafelyIncludeProcesses(cluster, status, r.MinimumRecoveryTimeForInclusion)
+	if err != nil {
+		return &requeue{curError: err, delayedRequeue: true}
+	}
+
+	logger.Info("Including stale exclusions", "exclusions", staleExclusions)
+	r.Recorder.Event(cluster, corev1.EventTypeNormal, "IncludingStaleExclusions", fmt.Sprintf("Including stale exclusions: %v", staleExclusions))
+
+	err = adminClient.IncludeProcesses(staleExclusions)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	return nil
+}
+
+// getStaleExclusions returns all exclusion entries that neither match a process reported in the machine-readable status
+// nor a process group of the cluster. Locality based exclusions are only considered if they target the instance ID.
+func getStaleExclusions(cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus) ([]fdbv1beta2.ProcessAddress, error) {
+	exclusions, err := fdbstatus.GetExclusions(status)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get excluded servers from status, %w", err)
+	}
+
+	if len(exclusions) == 0 {
+		return nil, nil
+	}
+
+	knownAddresses := map[string]fdbv1beta2.None{}
+	knownInstanceIDs := map[string]fdbv1beta2.None{}
+
+	for _, process := range status.Cluster.Processes {
+		knownAddresses[process.Address.MachineAddress()] = fdbv1beta2.None{}
+		instanceID, ok := process.Locality[fdbv1beta2.FDBLocalityInstanceIDKey]
+		if ok {
+			knownInstanceIDs[instanceID] = fdbv1beta2.None{}
+		}
+	}
+
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		knownInstanceIDs[string(processGroup.ProcessGroupID)] = fdbv1beta2.None{}
+		for _, address := range processGroup.Addresses {
+			knownAddresses[address] = fdbv1beta2.None{}
+		}
+	}
+
+	staleExclusions := make([]fdbv1beta2.ProcessAddress, 0)
+	instanceIDPrefix := fdbv1beta2.FDBLocalityExclusionPrefix + ":"
+	for _, exclusion := range exclusions {
+		if exclusion.IsEmpty() {
+			// Exclusions for other localities, e.g. a zone, are not managed by the operator.
+			if !strings.HasPrefix(exclusion.StringAddress, instanceIDPrefix) {
+				continue
+			}
+
+			if _, ok := knownInstanceIDs[strings.TrimPrefix(exclusion.StringAddress, instanceIDPrefix)]; ok {
+				continue
+			}
+
+			staleExclusions = append(staleExclusions, exclusion)
+			continue
+		}
+
+		if _, ok := knownAddresses[exclusion.MachineAddress()]; ok {
+			continue
+		}
+
+		staleExclusions = append(staleExclusions, exclusion)
+	}
+
+	return staleExclusions, nil
+}
@@ -0,0 +1,111 @@
+/*
+ * cleanup_stale_exclusions_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient/mock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("cleanupStaleExclusions", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var adminClient *mock.AdminClient
+	var result *requeue
+	var knownAddress string
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		Expect(setupClusterForTest(cluster)).NotTo(HaveOccurred())
+
+		var err error
+		adminClient, err = mock.NewMockAdminClientUncast(cluster, k8sClient)
+		Expect(err).NotTo(HaveOccurred())
+
+		processGroup := internal.PickProcessGroups(cluster, fdbv1beta2.ProcessClassStorage, 1)[0]
+		Expect(processGroup.Addresses).NotTo(BeEmpty())
+		knownAddress = processGroup.Addresses[0]
+
+		adminClient.ExcludedAddresses = map[string]fdbv1beta2.None{
+			knownAddress:                      {},
+			"192.168.255.1":                   {},
+			"locality_instance_id:removed":    {},
+			"locality_zoneid:maintenance":     {},
+			processGroup.GetExclusionString(): {},
+		}
+	})
+
+	JustBeforeEach(func() {
+		result = cleanupStaleExclusions{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)
+	})
+
+	When("the cleanup of stale exclusions is disabled", func() {
+		It("should not include any processes", func() {
+			Expect(result).To(BeNil())
+			Expect(adminClient.ExcludedAddresses).To(HaveLen(5))
+			Expect(adminClient.ReincludedAddresses).To(BeEmpty())
+		})
+	})
+
+	When("the cleanup of stale exclusions is enabled", func() {
+		BeforeEach(func() {
+			cluster.Spec.AutomationOptions.CleanupStaleExclusions = pointer.Bool(true)
+		})
+
+		It("should only include the stale exclusions", func() {
+			Expect(result).To(BeNil())
+			Expect(adminClient.ReincludedAddresses).To(Equal(map[string]bool{
+				"192.168.255.1":                true,
+				"locality_instance_id:removed": true,
+			}))
+			Expect(adminClient.ExcludedAddresses).To(HaveKey(knownAddress))
+			Expect(adminClient.ExcludedAddresses).To(HaveKey("locality_zoneid:maintenance"))
+		})
+
+		When("the cluster spans multiple data centers", func() {
+			BeforeEach(func() {
+				cluster.Spec.DatabaseConfiguration.Regions = []fdbv1beta2.Region{
+					{
+						DataCenters: []fdbv1beta2.DataCenter{
+							{ID: "primary", Priority: 1},
+							{ID: "primary-satellite", Satellite: 1},
+						},
+					},
+					{
+						DataCenters: []fdbv1beta2.DataCenter{
+							{ID: "remote", Priority: 0},
+						},
+					},
+				}
+			})
+
+			It("should not include any processes", func() {
+				Expect(result).To(BeNil())
+				Expect(adminClient.ReincludedAddresses).To(BeEmpty())
+			})
+		})
+	})
+})
@@ -0,0 +1,163 @@
+/*
+ * cluster_conditions.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// setReconciliationConditions carries over the observed generation and the conditions from the previous status and
+// updates the Ready and Reconciling conditions based on the reconciliation state of the cluster. The Stalled condition
+// is removed, as the spec of the cluster was accepted by the operator.
+func setReconciliationConditions(cluster *fdbv1beta2.FoundationDBCluster, previousStatus *fdbv1beta2.FoundationDBClusterStatus, reconciled bool) {
+	observeGeneration(cluster, previousStatus)
+	cluster.Status.Conditions = previousStatus.Conditions
+	meta.RemoveStatusCondition(&cluster.Status.Conditions, fdbv1beta2.ClusterConditionStalled)
+
+	if reconciled {
+		meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+			Type:               fdbv1beta2.ClusterConditionReady,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: cluster.Generation,
+			Reason:             "Reconciled",
+			Message:            fmt.Sprintf("Generation %d is reconciled", cluster.Generation),
+		})
+		meta.RemoveStatusCondition(&cluster.Status.Conditions, fdbv1beta2.ClusterConditionReconciling)
+		return
+	}
+
+	reason := getReconcilingReason(cluster.Status.Generations)
+	message := fmt.Sprintf("Generation %d is not yet reconciled", cluster.Generation)
+	meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+		Type:               fdbv1beta2.ClusterConditionReady,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: cluster.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+	meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+		Type:               fdbv1beta2.ClusterConditionReconciling,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: cluster.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// observeGeneration carries over the observed generation from the previous status and updates it if the operator
+// observes a new generation of the spec.
+func observeGeneration(cluster *fdbv1beta2.FoundationDBCluster, previousStatus *fdbv1beta2.FoundationDBClusterStatus) {
+	cluster.Status.ObservedGeneration = previousStatus.ObservedGeneration
+	cluster.Status.GenerationObservedTimestamp = previousStatus.GenerationObservedTimestamp
+	if cluster.Status.ObservedGeneration == cluster.Generation && cluster.Status.GenerationObservedTimestamp != nil {
+		return
+	}
+
+	cluster.Status.ObservedGeneration = cluster.Generation
+	cluster.Status.GenerationObservedTimestamp = &metav1.Time{Time: time.Now()}
+}
+
+// getReconcilingReason returns the reason for the Ready and Reconciling conditions of a cluster that is not
+// reconciled, based on the first pending stage of the reconciliation.
+func getReconcilingReason(generations fdbv1beta2.ClusterGenerationStatus) string {
+	pendingStages := []struct {
+		generation int64
+		reason     string
+	}{
+		{generations.DatabaseUnavailable, "DatabaseUnavailable"},
+		{generations.NeedsConfigurationChange, "NeedsConfigurationChange"},
+		{generations.NeedsCoordinatorChange, "NeedsCoordinatorChange"},
+		{generations.NeedsGrow, "NeedsGrow"},
+		{generations.NeedsShrink, "NeedsShrink"},
+		{generations.NeedsMonitorConfUpdate, "NeedsMonitorConfUpdate"},
+		{generations.NeedsBounce, "NeedsBounce"},
+		{generations.NeedsPodDeletion, "NeedsPodDeletion"},
+		{generations.NeedsServiceUpdate, "NeedsServiceUpdate"},
+		{generations.HasExtraListeners, "HasExtraListeners"},
+		{generations.NeedsLockConfigurationChanges, "NeedsLockConfigurationChanges"},
+		{generations.HasUnhealthyProcess, "HasUnhealthyProcess"},
+	}
+
+	for _, stage := range pendingStages {
+		if stage.generation > 0 {
+			return stage.reason
+		}
+	}
+
+	return "Reconciling"
+}
+
+// updateStalledCondition marks the cluster as stalled with the provided reason, e.g. because the spec is invalid, and
+// persists the status. The status is only updated if the conditions have changed.
+func (r *FoundationDBClusterReconciler) updateStalledCondition(ctx context.Context, cluster *fdbv1beta2.FoundationDBCluster, reason string, stalledErr error) error {
+	previousStatus := cluster.Status.DeepCopy()
+	observeGeneration(cluster, previousStatus)
+
+	for _, conditionType := range []string{fdbv1beta2.ClusterConditionStalled, fdbv1beta2.ClusterConditionReady} {
+		status := metav1.ConditionTrue
+		if conditionType == fdbv1beta2.ClusterConditionReady {
+			status = metav1.ConditionFalse
+		}
+
+		meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+			Type:               conditionType,
+			Status:             status,
+			ObservedGeneration: cluster.Generation,
+			Reason:             reason,
+			Message:            stalledErr.Error(),
+		})
+	}
+	meta.RemoveStatusCondition(&cluster.Status.Conditions, fdbv1beta2.ClusterConditionReconciling)
+
+	if equality.Semantic.DeepEqual(cluster.Status, *previousStatus) {
+		return nil
+	}
+
+	return r.updateOrApply(ctx, cluster)
+}
+
+// checkSpecSettled returns a delayed requeue if the cluster defines a settle duration for its spec and the current
+// generation of the spec was observed within this duration. This prevents destructive actions on a partially applied
+// change while a GitOps tool is still syncing related resources.
+func checkSpecSettled(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, action string) *requeue {
+	settleDuration := cluster.GetSpecSettleDuration()
+	if settleDuration <= 0 || cluster.Status.GenerationObservedTimestamp == nil {
+		return nil
+	}
+
+	remaining := time.Until(cluster.Status.GenerationObservedTimestamp.Add(settleDuration))
+	if remaining <= 0 {
+		return nil
+	}
+
+	message := fmt.Sprintf("Deferring %s: generation %d of the spec is settling for another %s", action, cluster.Status.ObservedGeneration, remaining.Round(time.Second).String())
+	logger.Info("Spec is settling", "action", action, "remaining", remaining.String())
+
+	return &requeue{message: message, delayedRequeue: true, delay: remaining}
+}
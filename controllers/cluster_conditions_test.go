@@ -0,0 +1,150 @@
+/*
+ * cluster_conditions_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("cluster_conditions", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		Expect(setupClusterForTest(cluster)).NotTo(HaveOccurred())
+	})
+
+	When("the cluster is reconciled", func() {
+		It("should report the cluster as ready", func() {
+			Expect(cluster.Status.ObservedGeneration).To(Equal(cluster.Generation))
+			Expect(cluster.Status.GenerationObservedTimestamp).NotTo(BeNil())
+
+			ready := meta.FindStatusCondition(cluster.Status.Conditions, fdbv1beta2.ClusterConditionReady)
+			Expect(ready).NotTo(BeNil())
+			Expect(ready.Status).To(Equal(metav1.ConditionTrue))
+			Expect(ready.Reason).To(Equal("Reconciled"))
+			Expect(ready.ObservedGeneration).To(Equal(cluster.Generation))
+			Expect(meta.FindStatusCondition(cluster.Status.Conditions, fdbv1beta2.ClusterConditionReconciling)).To(BeNil())
+			Expect(meta.FindStatusCondition(cluster.Status.Conditions, fdbv1beta2.ClusterConditionStalled)).To(BeNil())
+		})
+	})
+
+	When("the cluster is not reconciled", func() {
+		var previousStatus *fdbv1beta2.FoundationDBClusterStatus
+
+		BeforeEach(func() {
+			previousStatus = cluster.Status.DeepCopy()
+			cluster.Generation++
+			cluster.Status.Generations = fdbv1beta2.ClusterGenerationStatus{
+				Reconciled:  cluster.Generation - 1,
+				NeedsBounce: cluster.Generation,
+			}
+			setReconciliationConditions(cluster, previousStatus, false)
+		})
+
+		It("should report the cluster as reconciling", func() {
+			Expect(cluster.Status.ObservedGeneration).To(Equal(cluster.Generation))
+			Expect(cluster.Status.GenerationObservedTimestamp).NotTo(Equal(previousStatus.GenerationObservedTimestamp))
+
+			ready := meta.FindStatusCondition(cluster.Status.Conditions, fdbv1beta2.ClusterConditionReady)
+			Expect(ready).NotTo(BeNil())
+			Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+			Expect(ready.Reason).To(Equal("NeedsBounce"))
+
+			reconciling := meta.FindStatusCondition(cluster.Status.Conditions, fdbv1beta2.ClusterConditionReconciling)
+			Expect(reconciling).NotTo(BeNil())
+			Expect(reconciling.Status).To(Equal(metav1.ConditionTrue))
+			Expect(reconciling.Reason).To(Equal("NeedsBounce"))
+		})
+	})
+
+	When("the spec of the cluster is invalid", func() {
+		BeforeEach(func() {
+			Expect(clusterReconciler.updateStalledCondition(context.TODO(), cluster, "InvalidSpec", errors.New("invalid spec"))).To(Succeed())
+			_, err := reloadCluster(cluster)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should report the cluster as stalled", func() {
+			stalled := meta.FindStatusCondition(cluster.Status.Conditions, fdbv1beta2.ClusterConditionStalled)
+			Expect(stalled).NotTo(BeNil())
+			Expect(stalled.Status).To(Equal(metav1.ConditionTrue))
+			Expect(stalled.Reason).To(Equal("InvalidSpec"))
+			Expect(stalled.Message).To(Equal("invalid spec"))
+
+			ready := meta.FindStatusCondition(cluster.Status.Conditions, fdbv1beta2.ClusterConditionReady)
+			Expect(ready).NotTo(BeNil())
+			Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+		})
+
+		When("the cluster is reconciled again", func() {
+			BeforeEach(func() {
+				result, err := reconcileCluster(cluster)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Requeue).To(BeFalse())
+				_, err = reloadCluster(cluster)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should remove the stalled condition", func() {
+				Expect(meta.FindStatusCondition(cluster.Status.Conditions, fdbv1beta2.ClusterConditionStalled)).To(BeNil())
+				Expect(meta.IsStatusConditionTrue(cluster.Status.Conditions, fdbv1beta2.ClusterConditionReady)).To(BeTrue())
+			})
+		})
+	})
+
+	When("checking if the spec has settled", func() {
+		It("should not defer actions if no settle duration is defined", func() {
+			Expect(checkSpecSettled(globalControllerLogger, cluster, "replacements")).To(BeNil())
+		})
+
+		When("a settle duration is defined", func() {
+			BeforeEach(func() {
+				cluster.Annotations = map[string]string{
+					fdbv1beta2.SpecSettleSecondsAnnotation: "300",
+				}
+			})
+
+			It("should defer actions while the spec is settling", func() {
+				cluster.Status.GenerationObservedTimestamp = &metav1.Time{Time: time.Now()}
+				req := checkSpecSettled(globalControllerLogger, cluster, "replacements")
+				Expect(req).NotTo(BeNil())
+				Expect(req.delayedRequeue).To(BeTrue())
+				Expect(req.message).To(HavePrefix("Deferring replacements: generation"))
+			})
+
+			It("should not defer actions once the spec has settled", func() {
+				cluster.Status.GenerationObservedTimestamp = &metav1.Time{Time: time.Now().Add(-10 * time.Minute)}
+				Expect(checkSpecSettled(globalControllerLogger, cluster, "replacements")).To(BeNil())
+			})
+		})
+	})
+})
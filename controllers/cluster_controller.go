@@ -43,6 +43,13 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal/airgap"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal/clustertemplate"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal/connectionstring"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal/fips"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal/interlock"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal/replacements"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal/snapshot"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	sigyaml "sigs.k8s.io/yaml"
 
@@ -50,6 +57,7 @@ import (
 	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/podclient"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -91,7 +99,38 @@ type FoundationDBClusterReconciler struct {
 	// ClusterLabelKeyForNodeTrigger if set will trigger a reconciliation for all FoundationDBClusters that host a Pod
 	// on the affected node.
 	ClusterLabelKeyForNodeTrigger string
-	decodingSerializer            runtime.Serializer
+	// SafetyInterlockChecker will be used to check if an external freeze is active before performing destructive
+	// actions.
+	SafetyInterlockChecker interlock.Checker
+	// StatusSnapshotWriter if set will be used to periodically write snapshots of the machine-readable status.
+	StatusSnapshotWriter *snapshot.Writer
+	// PodSpecComparators will be used as additional checks if the Pod of a process group has drifted from the
+	// desired Pod spec and the process group must be replaced.
+	PodSpecComparators []podmanager.PodSpecComparator
+	// ConnectionStringCache if set will be used to cache the connection string that was verified against the cluster,
+	// so the connection string must only be fetched from the cluster if the cluster resource or its connection string
+	// changed.
+	ConnectionStringCache *connectionstring.Cache
+	// MaxConcurrentProcessGroupChecks defines how many process groups will be checked concurrently by the sub-reconcilers
+	// that run the same checks for every process group, e.g. to validate the process groups or to find misconfigured
+	// process groups. A value less than 2 will check the process groups serially.
+	MaxConcurrentProcessGroupChecks int
+	// OperatorVersion is the version of the running operator, it is used to detect upgrades of the operator.
+	OperatorVersion string
+	// UpgradeObservationWindow defines how long Pod updates and replacements of misconfigured process groups are held
+	// back after the operator was upgraded. During this window the operator reports the actions it would take in the
+	// cluster status. A value of 0 disables the observation of operator upgrades.
+	UpgradeObservationWindow time.Duration
+	// AirGapOptions defines the settings for running the operator in an air-gapped environment.
+	AirGapOptions airgap.Options
+	// DisableDestructiveActions defines if the operator runs without the permissions to delete Pods and PVCs. If set,
+	// the operator adds the PodDeletionRequired or PVCDeletionRequired condition to the process groups instead of
+	// deleting the resources.
+	DisableDestructiveActions bool
+	decodingSerializer        runtime.Serializer
+	// fieldIndexesAvailable is true if the field indexes from internal.GetFieldIndexes are registered, in this case the
+	// Pods and PVCs will be listed with the field indexes instead of the label selectors.
+	fieldIndexesAvailable bool
 }
 
 // NewFoundationDBClusterReconciler creates a new FoundationDBClusterReconciler with defaults.
@@ -100,6 +139,7 @@ func NewFoundationDBClusterReconciler(podLifecycleManager podmanager.PodLifecycl
 		PodLifecycleManager: podLifecycleManager,
 	}
 	r.PodClientProvider = r.newFdbPodClient
+	r.SafetyInterlockChecker = interlock.NewHTTPChecker(nil)
 	r.decodingSerializer = yaml.NewDecodingSerializer(unstructured.UnstructuredJSONScheme)
 
 	return r
@@ -107,8 +147,15 @@ func NewFoundationDBClusterReconciler(podLifecycleManager podmanager.PodLifecycl
 
 // +kubebuilder:rbac:groups=apps.foundationdb.org,resources=foundationdbclusters,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps.foundationdb.org,resources=foundationdbclusters/status,verbs=get;update;patch
-// +kubebuilder:rbac:groups="",resources=pods;configmaps;persistentvolumeclaims;events;secrets;services,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps.foundationdb.org,resources=foundationdbclustertemplates,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps;events;secrets;services,verbs=get;list;watch;create;update;patch;delete
+// The delete permissions for Pods and PVCs are granted by the separate destroy role in config/rbac/destroy_role.yaml,
+// so the operator can run with --disable-destructive-actions without those permissions.
+// +kubebuilder:rbac:groups="",resources=pods;persistentvolumeclaims,verbs=get;list;watch;create;update;patch
 // +kubebuilder:rbac:groups="coordination.k8s.io",resources=leases,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="policy",resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="gateway.networking.k8s.io",resources=httproutes,verbs=get;create;update;patch;delete
+// +kubebuilder:rbac:groups="cert-manager.io",resources=certificates,verbs=get;create;update;patch;delete
 
 // Reconcile runs the reconciliation logic.
 func (r *FoundationDBClusterReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
@@ -137,6 +184,21 @@ func (r *FoundationDBClusterReconciler) Reconcile(ctx context.Context, request c
 		return ctrl.Result{}, nil
 	}
 
+	err = clustertemplate.Resolve(ctx, r, cluster)
+	if err != nil {
+		r.Recorder.Event(cluster, corev1.EventTypeWarning, "ClusterTemplateResolutionFailed", err.Error())
+		return ctrl.Result{}, err
+	}
+
+	err = airgap.LoadImageConfigs(ctx, r, r.AirGapOptions, cluster)
+	if err != nil {
+		r.Recorder.Event(cluster, corev1.EventTypeWarning, "AirGapValidationFailed", err.Error())
+		return ctrl.Result{}, err
+	}
+
+	// The cluster defaulting webhook only stores the static defaults, all defaults that are derived from other fields of
+	// the spec, e.g. the resources of the tuning profile or the image configs, are applied here, so they follow changes
+	// of those fields.
 	err = internal.NormalizeClusterSpec(cluster, r.DeprecationOptions)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -151,9 +213,25 @@ func (r *FoundationDBClusterReconciler) Reconcile(ctx context.Context, request c
 	err = cluster.Validate()
 	if err != nil {
 		r.Recorder.Event(cluster, corev1.EventTypeWarning, "ClusterSpec not valid", err.Error())
+		statusErr := r.updateStalledCondition(ctx, cluster, "InvalidSpec", err)
+		if statusErr != nil {
+			clusterLog.Error(statusErr, "could not update the Stalled condition")
+		}
 		return ctrl.Result{}, fmt.Errorf("ClusterSpec is not valid: %w", err)
 	}
 
+	err = airgap.Validate(cluster, r.AirGapOptions)
+	if err != nil {
+		r.Recorder.Event(cluster, corev1.EventTypeWarning, "AirGapValidationFailed", err.Error())
+		return ctrl.Result{}, err
+	}
+
+	if cluster.RequiresFIPSCompliance() && !fips.Enabled() {
+		err = fmt.Errorf("cluster requires FIPS compliance, but the operator was not built with FIPS 140 validated cryptography")
+		r.Recorder.Event(cluster, corev1.EventTypeWarning, "FIPSValidationFailed", err.Error())
+		return ctrl.Result{}, err
+	}
+
 	supportedVersion, err := adminClient.VersionSupported(cluster.Spec.Version)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -172,30 +250,41 @@ func (r *FoundationDBClusterReconciler) Reconcile(ctx context.Context, request c
 	}
 
 	subReconcilers := []clusterSubReconciler{
+		recoverProcessGroupsFromPVCs{},
 		updateStatus{},
+		recoverCoordinatorQuorum{},
+		observeOperatorUpgrade{},
 		updateLockConfiguration{},
 		updateConfigMap{},
+		updateConnectionSecret{},
 		checkClientCompatibility{},
 		deletePodsForBuggification{},
 		replaceMisconfiguredProcessGroups{},
 		replaceFailedProcessGroups{},
+		activateStandbyProcessGroups{},
 		addProcessGroups{},
 		addServices{},
+		updateMonitorAPIGateway{},
+		updatePodDisruptionBudgets{},
 		addPVCs{},
 		addPods{},
+		releaseSchedulingGates{},
 		generateInitialClusterFile{},
 		removeIncompatibleProcesses{},
 		updateSidecarVersions{},
 		updatePodConfig{},
 		updateMetadata{},
 		updateDatabaseConfiguration{},
+		migrateStorageEngine{},
 		chooseRemovals{},
 		excludeProcesses{},
 		changeCoordinators{},
+		reloadKnobs{},
 		bounceProcesses{},
 		maintenanceModeChecker{},
 		updatePods{},
 		removeProcessGroups{},
+		cleanupStaleExclusions{},
 		removeServices{},
 		updateStatus{},
 	}
@@ -247,6 +336,12 @@ func (r *FoundationDBClusterReconciler) Reconcile(ctx context.Context, request c
 	return ctrl.Result{}, nil
 }
 
+// getPodListOptions returns the listOptions to list the Pods or PVCs of the cluster, if the field indexes are available
+// the field indexes will be used.
+func (r *FoundationDBClusterReconciler) getPodListOptions(cluster *fdbv1beta2.FoundationDBCluster, processClass fdbv1beta2.ProcessClass, id string) []client.ListOption {
+	return internal.GetIndexedPodListOptions(cluster, r.fieldIndexesAvailable, processClass, id)
+}
+
 // runClusterSubReconciler will start the subReconciler and will log the duration of the subReconciler.
 func runClusterSubReconciler(ctx context.Context, logger logr.Logger, subReconciler clusterSubReconciler, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus) *requeue {
 	subReconcileLogger := logger.WithValues("reconciler", fmt.Sprintf("%T", subReconciler))
@@ -261,6 +356,12 @@ func runClusterSubReconciler(ctx context.Context, logger logr.Logger, subReconci
 
 // updateIndexerForManager will set all the required field indexer for the FoundationDBClusterReconciler.
 func (r *FoundationDBClusterReconciler) updateIndexerForManager(mgr ctrl.Manager) error {
+	err := internal.SetupFieldIndexes(context.Background(), mgr.GetFieldIndexer())
+	if err != nil {
+		return err
+	}
+	r.fieldIndexesAvailable = true
+
 	if r.ClusterLabelKeyForNodeTrigger == "" {
 		return nil
 	}
@@ -303,20 +404,33 @@ func (r *FoundationDBClusterReconciler) SetupWithManager(mgr ctrl.Manager, maxCo
 		Owns(&corev1.Pod{}, globalPredicate).
 		Owns(&corev1.PersistentVolumeClaim{}, globalPredicate).
 		Owns(&corev1.ConfigMap{}, globalPredicate).
-		Owns(&corev1.Service{}, globalPredicate)
+		Owns(&corev1.Service{}, globalPredicate).
+		Owns(&policyv1.PodDisruptionBudget{}, globalPredicate)
 
 	if r.ClusterLabelKeyForNodeTrigger != "" {
 		managerBuilder.Watches(
 			&source.Kind{Type: &corev1.Node{}},
 			handler.EnqueueRequestsFromMapFunc(r.findFoundationDBClusterForNode),
 			builder.WithPredicates(
-				internal.NodeTaintChangedPredicate{
-					Logger: r.Log.WithName("NodeTaintChangedPredicate"),
-				},
+				predicate.Or(
+					internal.NodeTaintChangedPredicate{
+						Logger: r.Log.WithName("NodeTaintChangedPredicate"),
+					},
+					internal.NodeDrainChangedPredicate{
+						Logger: r.Log.WithName("NodeDrainChangedPredicate"),
+					},
+				),
 			),
 		)
 	}
 
+	// Reconcile all clusters that reference a FoundationDBClusterTemplate when the template changes.
+	managerBuilder.Watches(
+		&source.Kind{Type: &fdbv1beta2.FoundationDBClusterTemplate{}},
+		handler.EnqueueRequestsFromMapFunc(r.findFoundationDBClustersForTemplate),
+		builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+	)
+
 	for _, object := range watchedObjects {
 		managerBuilder.Owns(object)
 	}
@@ -324,6 +438,28 @@ func (r *FoundationDBClusterReconciler) SetupWithManager(mgr ctrl.Manager, maxCo
 	return managerBuilder.Complete(r)
 }
 
+// findFoundationDBClustersForTemplate returns the FoundationDBClusters that reference the provided
+// FoundationDBClusterTemplate.
+func (r *FoundationDBClusterReconciler) findFoundationDBClustersForTemplate(template client.Object) []reconcile.Request {
+	clusters := &fdbv1beta2.FoundationDBClusterList{}
+	err := r.List(context.Background(), clusters, client.InNamespace(template.GetNamespace()))
+	if err != nil {
+		r.Log.Error(err, "could not list FoundationDBClusters for template", "namespace", template.GetNamespace(), "template", template.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, cluster := range clusters.Items {
+		if cluster.Spec.TemplateRef == nil || cluster.Spec.TemplateRef.Name != template.GetName() {
+			continue
+		}
+
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name}})
+	}
+
+	return requests
+}
+
 // findFoundationDBClusterForNode will filter out all associated FoundationDBClusters that have a Pod running on that
 // specific node.
 func (r *FoundationDBClusterReconciler) findFoundationDBClusterForNode(node client.Object) []reconcile.Request {
@@ -456,6 +592,21 @@ func (r *FoundationDBClusterReconciler) getLockClient(cluster *fdbv1beta2.Founda
 	return r.getDatabaseClientProvider().GetLockClient(cluster)
 }
 
+// getGlobalReplacementBudget returns the replacement budget that is shared by all operator instances that manage the
+// same database. If the cluster defines no global replacement budget, nil will be returned.
+func (r *FoundationDBClusterReconciler) getGlobalReplacementBudget(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster) (*replacements.GlobalBudget, error) {
+	if !cluster.UseGlobalReplacementBudget() {
+		return nil, nil
+	}
+
+	lockClient, err := r.getLockClient(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	return replacements.NewGlobalBudget(logger, cluster, lockClient)
+}
+
 // takeLock attempts to acquire a lock.
 func (r *FoundationDBClusterReconciler) takeLock(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, action string) (bool, error) {
 	logger.Info("Taking lock on cluster", "namespace", cluster.Namespace, "cluster", cluster.Name, "action", action)
@@ -475,6 +626,29 @@ func (r *FoundationDBClusterReconciler) takeLock(logger logr.Logger, cluster *fd
 	return hasLock, nil
 }
 
+// checkSafetyInterlock returns a requeue if the current generation of the spec is still settling or if an external
+// freeze is active for the cluster and the provided destructive action must be deferred.
+func (r *FoundationDBClusterReconciler) checkSafetyInterlock(ctx context.Context, logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, action string) *requeue {
+	if req := checkSpecSettled(logger, cluster, action); req != nil {
+		return req
+	}
+
+	if r.SafetyInterlockChecker == nil {
+		return nil
+	}
+
+	frozen, reason := r.SafetyInterlockChecker.IsFrozen(ctx, logger, cluster)
+	if !frozen {
+		return nil
+	}
+
+	message := fmt.Sprintf("Deferring %s: %s", action, reason)
+	logger.Info("Safety interlock is active", "action", action, "reason", reason, "activeOperations", cluster.GetActiveOperations())
+	r.Recorder.Event(cluster, corev1.EventTypeNormal, "SafetyInterlockActive", message)
+
+	return &requeue{message: message, delayedRequeue: true, delay: max(cluster.GetSafetyInterlockCacheDuration(), 15*time.Second)}
+}
+
 // releaseLock attempts to release a lock.
 func (r *FoundationDBClusterReconciler) releaseLock(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster) error {
 	logger.Info("Release lock on cluster", "namespace", cluster.Namespace, "cluster", cluster.Name)
@@ -562,9 +736,13 @@ func (r *FoundationDBClusterReconciler) getStatusFromClusterOrDummyStatus(logger
 		}, nil
 	}
 
-	connectionString, err := tryConnectionOptions(logger, cluster, r)
-	if err != nil {
-		return nil, err
+	connectionString, cached := r.ConnectionStringCache.Get(cluster)
+	if !cached {
+		var err error
+		connectionString, err = tryConnectionOptions(logger, cluster, r)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Update the connection string if the newly fetched connection string is different from the current one and if the
@@ -589,9 +767,14 @@ func (r *FoundationDBClusterReconciler) getStatusFromClusterOrDummyStatus(logger
 
 	status, err := adminClient.GetStatus()
 	if err == nil {
+		r.ConnectionStringCache.Set(cluster, cluster.Status.ConnectionString)
 		return status, nil
 	}
 
+	// The cached connection string might be outdated, so the next reconciliation must fetch the connection string from
+	// the cluster.
+	r.ConnectionStringCache.Invalidate(cluster)
+
 	// When we reached this part of the code the above GetStatus() called failed for some reason.
 	if cluster.Status.Configured {
 		// If the cluster is currently under a version incompatible upgrade, we try to assume the current version based
@@ -287,6 +287,7 @@ var _ = Describe("cluster_controller", func() {
 					Healthy:              true,
 					FullReplication:      true,
 					DataMovementPriority: 0,
+					FaultTolerance:       cluster.DesiredFaultTolerance(),
 				}))
 
 				Expect(cluster.Status.StorageServersPerDisk).To(Equal([]int{1}))
@@ -1215,6 +1216,7 @@ var _ = Describe("cluster_controller", func() {
 						Expect(pod.ObjectMeta.Annotations).To(Equal(map[string]string{
 							fdbv1beta2.LastConfigMapKey:            configMapHash,
 							fdbv1beta2.LastSpecKey:                 hash,
+							fdbv1beta2.LastSpecHashVersionKey:      "2",
 							fdbv1beta2.PublicIPSourceAnnotation:    "pod",
 							"foundationdb.org/existing-annotation": "test-value",
 							"fdb-annotation":                       "value1",
@@ -1227,6 +1229,7 @@ var _ = Describe("cluster_controller", func() {
 					Expect(pod.ObjectMeta.Annotations).To(Equal(map[string]string{
 						fdbv1beta2.LastConfigMapKey:         configMapHash,
 						fdbv1beta2.LastSpecKey:              hash,
+						fdbv1beta2.LastSpecHashVersionKey:   "2",
 						fdbv1beta2.PublicIPSourceAnnotation: "pod",
 						"fdb-annotation":                    "value1",
 						fdbv1beta2.NodeAnnotation:           pod.Spec.NodeName,
@@ -1356,6 +1359,7 @@ var _ = Describe("cluster_controller", func() {
 						Expect(item.ObjectMeta.Annotations).To(Equal(map[string]string{
 							fdbv1beta2.LastConfigMapKey:         configMapHash,
 							fdbv1beta2.LastSpecKey:              hash,
+							fdbv1beta2.LastSpecHashVersionKey:   "2",
 							fdbv1beta2.PublicIPSourceAnnotation: "pod",
 							fdbv1beta2.NodeAnnotation:           item.Spec.NodeName,
 							fdbv1beta2.ImageTypeAnnotation:      string(fdbv1beta2.ImageTypeSplit),
@@ -1466,6 +1470,7 @@ var _ = Describe("cluster_controller", func() {
 					Expect(item.ObjectMeta.Annotations).To(Equal(map[string]string{
 						fdbv1beta2.LastConfigMapKey:         configMapHash,
 						fdbv1beta2.LastSpecKey:              hash,
+						fdbv1beta2.LastSpecHashVersionKey:   "2",
 						fdbv1beta2.PublicIPSourceAnnotation: "pod",
 						fdbv1beta2.NodeAnnotation:           item.Spec.NodeName,
 						fdbv1beta2.ImageTypeAnnotation:      string(fdbv1beta2.ImageTypeSplit),
@@ -0,0 +1,87 @@
+/*
+ * destructive_actions.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// deletePod deletes the Pod of the process group with the PodLifecycleManager. If destructive actions are disabled,
+// the PodDeletionRequired condition will be added to the process group instead and the caller must persist the status.
+func (r *FoundationDBClusterReconciler) deletePod(ctx context.Context, logger logr.Logger, processGroup *fdbv1beta2.ProcessGroupStatus, pod *corev1.Pod) error {
+	if r.DisableDestructiveActions {
+		logger.V(1).Info("Pod deletion requires a privileged user or controller", "processGroupID", processGroup.ProcessGroupID, "pod", pod.Name)
+		processGroup.UpdateCondition(fdbv1beta2.PodDeletionRequired, true)
+		return nil
+	}
+
+	return r.PodLifecycleManager.DeletePod(logr.NewContext(ctx, logger), r, pod)
+}
+
+// updatePods recreates the provided Pods with the PodLifecycleManager. If destructive actions are disabled, the
+// PodDeletionRequired condition will be added to the process groups of the Pods and the status will be persisted.
+func (r *FoundationDBClusterReconciler) updatePods(ctx context.Context, logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, pods []*corev1.Pod, unsafe bool) error {
+	if !r.DisableDestructiveActions {
+		return r.PodLifecycleManager.UpdatePods(logr.NewContext(ctx, logger), r, cluster, pods, unsafe)
+	}
+
+	processGroups := make([]*fdbv1beta2.ProcessGroupStatus, 0, len(pods))
+	for _, pod := range pods {
+		processGroup := fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, internal.GetProcessGroupIDFromMeta(cluster, pod.ObjectMeta))
+		if processGroup == nil {
+			logger.Info("Could not find process group for Pod", "pod", pod.Name)
+			continue
+		}
+
+		processGroup.UpdateCondition(fdbv1beta2.PodDeletionRequired, true)
+		processGroups = append(processGroups, processGroup)
+	}
+
+	return r.reportRequiredDeletions(ctx, logger, cluster, processGroups)
+}
+
+// reportRequiredDeletions persists the PodDeletionRequired and PVCDeletionRequired conditions of the provided process
+// groups and emits an ActionRequired event, so that a privileged user or controller can delete the resources.
+func (r *FoundationDBClusterReconciler) reportRequiredDeletions(ctx context.Context, logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, processGroups []*fdbv1beta2.ProcessGroupStatus) error {
+	var processGroupIDs []fdbv1beta2.ProcessGroupID
+	for _, processGroup := range processGroups {
+		if processGroup.GetConditionTime(fdbv1beta2.PodDeletionRequired) == nil && processGroup.GetConditionTime(fdbv1beta2.PVCDeletionRequired) == nil {
+			continue
+		}
+
+		processGroupIDs = append(processGroupIDs, processGroup.ProcessGroupID)
+	}
+
+	if len(processGroupIDs) == 0 {
+		return nil
+	}
+
+	logger.Info("Deletions require a privileged user or controller, because destructive actions are disabled", "processGroupIDs", processGroupIDs)
+	r.Recorder.Event(cluster, corev1.EventTypeWarning, "ActionRequired", fmt.Sprintf("Resources of the process groups %v must be deleted by a privileged user or controller", processGroupIDs))
+
+	return r.updateOrApply(ctx, cluster)
+}
@@ -28,6 +28,7 @@ import (
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal/coordinator"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal/replacements"
 	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbstatus"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -70,6 +71,15 @@ func (e excludeProcesses) reconcile(ctx context.Context, r *FoundationDBClusterR
 		return nil
 	}
 
+	if cluster.ExclusionsPaused() {
+		r.Recorder.Event(cluster, corev1.EventTypeNormal, "NeedsExclusion", "Spec require excluding processes, but exclusions are paused")
+		return &requeue{message: "Exclusions are paused", delayedRequeue: true}
+	}
+
+	if req := r.checkSafetyInterlock(ctx, logger, cluster, "exclusions"); req != nil {
+		return req
+	}
+
 	// Make sure the exclusions are coordinated across multiple operator instances.
 	if cluster.ShouldUseLocks() {
 		lockClient, err := r.getLockClient(cluster)
@@ -125,6 +135,13 @@ func (e excludeProcesses) reconcile(ctx context.Context, r *FoundationDBClusterR
 		ongoingExclusions := ongoingExclusionsByClass[processClass]
 		processesToExclude := fdbProcessesToExcludeByClass[processClass]
 
+		// With the Surge replacement strategy the processes are only excluded once their replacements are ready.
+		err = replacements.CheckSurgeReplacementsReady(cluster, status, processClass)
+		if err != nil {
+			contextLogger.Info("Waiting for replacements before continuing with the exclusion", "reason", err.Error(), "addressesToExclude", processesToExclude)
+			continue
+		}
+
 		allowedExclusions, missingProcesses := getAllowedExclusionsAndMissingProcesses(contextLogger, cluster, processClass, desiredProcessesMap[processClass], ongoingExclusions, r.InSimulation)
 		if allowedExclusions <= 0 {
 			contextLogger.Info("Waiting for missing processes before continuing with the exclusion", "missingProcesses", missingProcesses, "addressesToExclude", processesToExclude, "allowedExclusions", allowedExclusions, "ongoingExclusions", ongoingExclusions)
@@ -180,6 +197,11 @@ func (e excludeProcesses) reconcile(ctx context.Context, r *FoundationDBClusterR
 		return &requeue{curError: err, delayedRequeue: true}
 	}
 
+	err = r.recordActiveOperation(ctx, logger, cluster, fdbv1beta2.ActiveOperationTypeExclusion, "excludeProcesses", getProcessGroupIDsForExclusions(cluster, fdbProcessesToExclude))
+	if err != nil {
+		return &requeue{curError: err, delayedRequeue: true}
+	}
+
 	if coordinatorErr != nil {
 		return &requeue{curError: err, delayedRequeue: true}
 	}
@@ -194,6 +216,32 @@ func (e excludeProcesses) reconcile(ctx context.Context, r *FoundationDBClusterR
 	return nil
 }
 
+// getProcessGroupIDsForExclusions returns the IDs of the process groups that are targeted by the provided exclusions,
+// either by their locality or by one of their addresses.
+func getProcessGroupIDsForExclusions(cluster *fdbv1beta2.FoundationDBCluster, exclusions []fdbv1beta2.ProcessAddress) []fdbv1beta2.ProcessGroupID {
+	exclusionSet := make(map[string]fdbv1beta2.None, len(exclusions))
+	for _, exclusion := range exclusions {
+		exclusionSet[exclusion.MachineAddress()] = fdbv1beta2.None{}
+	}
+
+	processGroupIDs := make([]fdbv1beta2.ProcessGroupID, 0, len(exclusions))
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if _, ok := exclusionSet[processGroup.GetExclusionString()]; ok {
+			processGroupIDs = append(processGroupIDs, processGroup.ProcessGroupID)
+			continue
+		}
+
+		for _, address := range processGroup.Addresses {
+			if _, ok := exclusionSet[address]; ok {
+				processGroupIDs = append(processGroupIDs, processGroup.ProcessGroupID)
+				break
+			}
+		}
+	}
+
+	return processGroupIDs
+}
+
 func getProcessesToExclude(exclusions []fdbv1beta2.ProcessAddress, cluster *fdbv1beta2.FoundationDBCluster) (map[fdbv1beta2.ProcessClass][]fdbv1beta2.ProcessAddress, map[fdbv1beta2.ProcessClass]int) {
 	fdbProcessesToExcludeByClass := make(map[fdbv1beta2.ProcessClass][]fdbv1beta2.ProcessAddress)
 	// This map keeps track on how many processes are currently excluded but haven't finished the exclusion yet.
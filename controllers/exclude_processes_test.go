@@ -812,6 +812,59 @@ var _ = Describe("exclude_processes", func() {
 				Expect(initialConnectionString).NotTo(Equal(cluster.Status.ConnectionString))
 			})
 
+			When("exclusions are paused", func() {
+				BeforeEach(func() {
+					cluster.Spec.AutomationOptions.Paused.Exclusions = pointer.Bool(true)
+				})
+
+				It("should not exclude the process", func() {
+					adminClient, err := mock.NewMockAdminClientUncast(cluster, k8sClient)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(req).NotTo(BeNil())
+					Expect(req.message).To(Equal("Exclusions are paused"))
+					Expect(adminClient.ExcludedAddresses).To(BeEmpty())
+				})
+			})
+
+			When("the Surge replacement strategy is used", func() {
+				BeforeEach(func() {
+					cluster.Spec.AutomationOptions.Replacements.Strategy = fdbv1beta2.ReplacementStrategySurge
+				})
+
+				It("should not exclude the process until the replacement is ready", func() {
+					adminClient, err := mock.NewMockAdminClientUncast(cluster, k8sClient)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(req).NotTo(BeNil())
+					Expect(req.message).To(Equal("more exclusions needed but not allowed, have to wait for new processes to come up"))
+					Expect(adminClient.ExcludedAddresses).To(BeEmpty())
+				})
+
+				When("the replacement has joined the cluster", func() {
+					BeforeEach(func() {
+						for _, processGroup := range cluster.Status.ProcessGroups {
+							if !processGroup.IsMarkedForRemoval() {
+								continue
+							}
+
+							replacement := fdbv1beta2.NewProcessGroupStatus(processGroup.ProcessGroupID+"-replacement", processGroup.ProcessClass, nil)
+							replacement.ProcessGroupConditions = nil
+							cluster.Status.ProcessGroups = append(cluster.Status.ProcessGroups, replacement)
+							break
+						}
+					})
+
+					It("should exclude the process", func() {
+						adminClient, err := mock.NewMockAdminClientUncast(cluster, k8sClient)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(req).To(BeNil())
+						Expect(adminClient.ExcludedAddresses).To(HaveLen(1))
+					})
+				})
+			})
+
 			When("using localities", func() {
 				BeforeEach(func() {
 					cluster.Spec.AutomationOptions.UseLocalitiesForExclusion = pointer.Bool(true)
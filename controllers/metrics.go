@@ -22,8 +22,10 @@ package controllers
 
 import (
 	"context"
+	"time"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal/replacements"
 	"github.com/prometheus/client_golang/prometheus"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 )
@@ -94,6 +96,27 @@ var (
 		nil,
 	)
 
+	descProcessGroupRemovalPhase = prometheus.NewDesc(
+		"fdb_operator_process_group_removal_phase_total",
+		"the count of Fdb process groups in a specific removal phase.",
+		append(descClusterDefaultLabels, "process_class", "phase"),
+		nil,
+	)
+
+	descProcessGroupRemovalPhaseMaxDuration = prometheus.NewDesc(
+		"fdb_operator_process_group_removal_phase_max_duration_seconds",
+		"the longest time in seconds that a Fdb process group has been in its current removal phase.",
+		append(descClusterDefaultLabels, "process_class", "phase"),
+		nil,
+	)
+
+	descPendingReplacements = prometheus.NewDesc(
+		"fdb_operator_pending_replacements_total",
+		"the count of Fdb process groups that are marked for removal and whose resources are not yet removed, by removal reason.",
+		append(descClusterDefaultLabels, "process_class", "reason"),
+		nil,
+	)
+
 	desDesiredProcessGroups = prometheus.NewDesc(
 		"fdb_operator_desired_process_group_total",
 		"the count of the desired Fdb process groups",
@@ -102,6 +125,16 @@ var (
 	)
 )
 
+// connectionStringDivergences counts the events where the connection string of a source diverged from the resolved
+// connection string.
+var connectionStringDivergences = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "fdb_operator_connection_string_divergence_total",
+		Help: "the count of events where the connection string of a source diverged from the resolved connection string.",
+	},
+	append(descClusterDefaultLabels, "source"),
+)
+
 type fdbClusterCollector struct {
 	reconciler *FoundationDBClusterReconciler
 }
@@ -159,6 +192,20 @@ func collectMetrics(ch chan<- prometheus.Metric, cluster *fdbv1beta2.FoundationD
 		addGauge(descProcessGroupMarkedExcluded, float64(exclusions[pclass]), string(pclass))
 	}
 
+	phaseCounts, phaseDurations := getRemovalPhaseMetrics(cluster, time.Now())
+	for pclass, phaseMap := range phaseCounts {
+		for phase, count := range phaseMap {
+			addGauge(descProcessGroupRemovalPhase, float64(count), string(pclass), string(phase))
+			addGauge(descProcessGroupRemovalPhaseMaxDuration, phaseDurations[pclass][phase].Seconds(), string(pclass), string(phase))
+		}
+	}
+
+	for pclass, reasons := range replacements.GetPendingReplacements(cluster) {
+		for reason, count := range reasons {
+			addGauge(descPendingReplacements, float64(count), string(pclass), reason)
+		}
+	}
+
 	counts, err := cluster.GetProcessCountsWithDefaults()
 	if err != nil {
 		return
@@ -214,11 +261,49 @@ func getProcessGroupMetrics(cluster *fdbv1beta2.FoundationDBCluster) (map[fdbv1b
 	return metricMap, removals, exclusions
 }
 
+// getRemovalPhaseMetrics returns the count of process groups per removal phase and the longest time a process group
+// has spent in its current removal phase.
+func getRemovalPhaseMetrics(cluster *fdbv1beta2.FoundationDBCluster, now time.Time) (map[fdbv1beta2.ProcessClass]map[fdbv1beta2.ProcessGroupRemovalPhase]int, map[fdbv1beta2.ProcessClass]map[fdbv1beta2.ProcessGroupRemovalPhase]time.Duration) {
+	phaseCounts := map[fdbv1beta2.ProcessClass]map[fdbv1beta2.ProcessGroupRemovalPhase]int{}
+	phaseDurations := map[fdbv1beta2.ProcessClass]map[fdbv1beta2.ProcessGroupRemovalPhase]time.Duration{}
+
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if _, exists := phaseCounts[processGroup.ProcessClass]; !exists {
+			phaseCounts[processGroup.ProcessClass] = map[fdbv1beta2.ProcessGroupRemovalPhase]int{}
+			phaseDurations[processGroup.ProcessClass] = map[fdbv1beta2.ProcessGroupRemovalPhase]time.Duration{}
+			for _, phase := range fdbv1beta2.AllProcessGroupRemovalPhases() {
+				phaseCounts[processGroup.ProcessClass][phase] = 0
+				phaseDurations[processGroup.ProcessClass][phase] = 0
+			}
+		}
+
+		phase := processGroup.GetRemovalPhase()
+		if phase == "" {
+			continue
+		}
+
+		phaseCounts[processGroup.ProcessClass][phase]++
+		timestamp := processGroup.RemovalPhases.GetTimestamp(phase)
+		if timestamp == nil {
+			continue
+		}
+
+		duration := now.Sub(timestamp.Time)
+		if duration > phaseDurations[processGroup.ProcessClass][phase] {
+			phaseDurations[processGroup.ProcessClass][phase] = duration
+		}
+	}
+
+	return phaseCounts, phaseDurations
+}
+
 // InitCustomMetrics initializes the metrics collectors for the operator.
 func InitCustomMetrics(reconciler *FoundationDBClusterReconciler) {
 	metrics.Registry.MustRegister(
 		newFDBClusterCollector(reconciler),
+		connectionStringDivergences,
 	)
+	metrics.Registry.MustRegister(replacements.Collectors()...)
 }
 
 func boolFloat64(b bool) float64 {
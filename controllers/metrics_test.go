@@ -75,4 +75,28 @@ var _ = Describe("metrics", func() {
 			Expect(exclusions[fdbv1beta2.ProcessClassStateless]).To(BeNumerically("==", 1))
 		})
 	})
+
+	Context("Collecting the removal phase metrics", func() {
+		var now time.Time
+
+		BeforeEach(func() {
+			now = time.Now()
+			for _, processGroup := range cluster.Status.ProcessGroups {
+				processGroup.UpdateRemovalPhases()
+			}
+
+			cluster.Status.ProcessGroups[2].RemovalPhases.MarkedForRemoval = &metav1.Time{Time: now.Add(-5 * time.Minute)}
+		})
+
+		It("generate the removal phase metrics", func() {
+			counts, durations := getRemovalPhaseMetrics(cluster, now)
+			Expect(counts).To(HaveLen(3))
+			Expect(counts[fdbv1beta2.ProcessClassStorage]).To(HaveLen(len(fdbv1beta2.AllProcessGroupRemovalPhases())))
+			Expect(counts[fdbv1beta2.ProcessClassStorage][fdbv1beta2.RemovalPhaseMarkedForRemoval]).To(BeNumerically("==", 1))
+			Expect(counts[fdbv1beta2.ProcessClassLog][fdbv1beta2.RemovalPhaseMarkedForRemoval]).To(BeNumerically("==", 0))
+			Expect(counts[fdbv1beta2.ProcessClassStateless][fdbv1beta2.RemovalPhaseExcluded]).To(BeNumerically("==", 1))
+			Expect(counts[fdbv1beta2.ProcessClassStateless][fdbv1beta2.RemovalPhaseMarkedForRemoval]).To(BeNumerically("==", 0))
+			Expect(durations[fdbv1beta2.ProcessClassStorage][fdbv1beta2.RemovalPhaseMarkedForRemoval]).To(BeNumerically("~", 5*time.Minute, time.Second))
+		})
+	})
 })
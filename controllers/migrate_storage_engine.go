@@ -0,0 +1,155 @@
+/*
+ * migrate_storage_engine.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+)
+
+// migrateStorageEngine provides a reconciliation step for replacing the storage process groups that are running with a
+// different storage engine than the storage engine of the database configuration.
+type migrateStorageEngine struct{}
+
+// reconcile runs the reconciler's work.
+func (migrateStorageEngine) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, logger logr.Logger) *requeue {
+	migration := cluster.Status.StorageEngineMigration
+	if !cluster.MigrateStorageEngine() || migration == nil || migration.PendingProcessGroups == 0 {
+		return nil
+	}
+
+	if cluster.StorageEngineMigrationPaused() {
+		logger.Info("Skipping migrateStorageEngine reconciler as the storage engine migration is paused")
+		return nil
+	}
+
+	if cluster.ReplacementsPaused() {
+		logger.Info("Skipping migrateStorageEngine reconciler as replacements are paused")
+		return nil
+	}
+
+	// The storage process groups are only replaced once the database runs with the new storage engine, otherwise the
+	// new storage servers would be created with the previous storage engine.
+	desiredStorageEngine := cluster.DesiredDatabaseConfiguration().StorageEngine
+	if migration.TargetStorageEngine != desiredStorageEngine {
+		return &requeue{message: fmt.Sprintf("Waiting for the storage engine %s to be configured", desiredStorageEngine), delayedRequeue: true}
+	}
+
+	version, err := fdbv1beta2.ParseFdbVersion(cluster.GetRunningVersion())
+	if err != nil {
+		return &requeue{curError: err, delayedRequeue: true}
+	}
+
+	if !version.IsStorageEngineSupported(desiredStorageEngine) {
+		return &requeue{message: fmt.Sprintf("storage engine %s is not supported on version %s", desiredStorageEngine, version), delayedRequeue: true}
+	}
+
+	budget := cluster.GetMaxConcurrentStorageEngineMigrations() - migration.MigratingProcessGroups
+	if budget <= 0 {
+		logger.V(1).Info("Waiting for ongoing storage engine migrations", "migratingProcessGroups", migration.MigratingProcessGroups)
+		return nil
+	}
+
+	if req := r.checkSafetyInterlock(ctx, logger, cluster, "replacements"); req != nil {
+		return req
+	}
+
+	// If the status is not cached, we have to fetch it.
+	if status == nil {
+		adminClient, err := r.DatabaseClientProvider.GetAdminClient(cluster, r)
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+		defer adminClient.Close()
+
+		status, err = adminClient.GetStatus()
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+	}
+
+	if req := checkDataDistributionForStorageEngineMigration(logger, cluster, status); req != nil {
+		return req
+	}
+
+	var migratedProcessGroups []fdbv1beta2.ProcessGroupID
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if budget <= 0 {
+			break
+		}
+
+		if processGroup.ProcessClass != fdbv1beta2.ProcessClassStorage || processGroup.IsMarkedForRemoval() {
+			continue
+		}
+
+		if processGroup.GetConditionTime(fdbv1beta2.IncorrectStorageEngine) == nil {
+			continue
+		}
+
+		processGroup.MarkForRemovalWithReason(&fdbv1beta2.RemovalReason{
+			Type:    fdbv1beta2.RemovalReasonStorageEngineChanged,
+			Message: fmt.Sprintf("storage processes are not running with the storage engine %s", desiredStorageEngine),
+		})
+		migratedProcessGroups = append(migratedProcessGroups, processGroup.ProcessGroupID)
+		budget--
+	}
+
+	if len(migratedProcessGroups) == 0 {
+		return nil
+	}
+
+	logger.Info("Replacing process groups for storage engine migration", "processGroupIDs", migratedProcessGroups, "storageEngine", desiredStorageEngine)
+	r.Recorder.Event(cluster, corev1.EventTypeNormal, "StorageEngineMigration", fmt.Sprintf("Replacing process groups %v for the migration to storage engine %s", migratedProcessGroups, desiredStorageEngine))
+	err = r.updateOrApply(ctx, cluster)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	return nil
+}
+
+// checkDataDistributionForStorageEngineMigration returns a requeue if the database is unavailable or if data
+// distribution has not caught up with the previous replacements.
+func checkDataDistributionForStorageEngineMigration(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus) *requeue {
+	if !status.Client.DatabaseStatus.Available {
+		logger.Info("Deferring storage engine migration as the database is not available")
+		return &requeue{message: "Deferring storage engine migration until database is available", delayedRequeue: true, delay: 5 * time.Second}
+	}
+
+	if !status.Cluster.Data.State.Healthy {
+		logger.Info("Deferring storage engine migration as data distribution is not healthy", "state", status.Cluster.Data.State.Name)
+		return &requeue{message: "Deferring storage engine migration until data distribution is healthy", delayedRequeue: true, delay: time.Minute}
+	}
+
+	maxQueuedBytes := cluster.GetMaxQueuedDataMovementBytesForStorageEngineMigration()
+	if maxQueuedBytes != nil && status.Cluster.Data.MovingData.InQueueBytes > *maxQueuedBytes {
+		logger.Info("Deferring storage engine migration as too much data is queued for data movement", "inQueueBytes", status.Cluster.Data.MovingData.InQueueBytes, "maxQueuedDataMovementBytes", *maxQueuedBytes)
+		return &requeue{message: "Deferring storage engine migration until queued data movement is below the limit", delayedRequeue: true, delay: time.Minute}
+	}
+
+	return nil
+}
@@ -0,0 +1,204 @@
+/*
+ * migrate_storage_engine_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient/mock"
+	"k8s.io/utils/pointer"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("migrate_storage_engine", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var databaseStatus *fdbv1beta2.FoundationDBStatus
+	var storageProcessGroups []*fdbv1beta2.ProcessGroupStatus
+	var requeue *requeue
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		Expect(setupClusterForTest(cluster)).NotTo(HaveOccurred())
+
+		cluster.Spec.AutomationOptions.StorageEngineMigration = &fdbv1beta2.StorageEngineMigrationOptions{
+			Enabled: pointer.Bool(true),
+		}
+		cluster.Spec.DatabaseConfiguration.StorageEngine = fdbv1beta2.StorageEngineMemory2
+		cluster.Status.DatabaseConfiguration.StorageEngine = fdbv1beta2.StorageEngineMemory2
+
+		storageProcessGroups = internal.PickProcessGroups(cluster, fdbv1beta2.ProcessClassStorage, len(cluster.Status.ProcessGroups))
+		for _, processGroup := range storageProcessGroups {
+			processGroup.UpdateCondition(fdbv1beta2.IncorrectStorageEngine, true)
+		}
+
+		databaseStatus = nil
+	})
+
+	JustBeforeEach(func() {
+		cluster.Status.StorageEngineMigration = getStorageEngineMigrationStatus(cluster, cluster.Status.ProcessGroups)
+		requeue = migrateStorageEngine{}.reconcile(context.TODO(), clusterReconciler, cluster, databaseStatus, globalControllerLogger)
+	})
+
+	When("the storage engine migration is disabled", func() {
+		BeforeEach(func() {
+			cluster.Spec.AutomationOptions.StorageEngineMigration = nil
+		})
+
+		It("should not replace any process groups", func() {
+			Expect(requeue).To(BeNil())
+			Expect(cluster.Status.StorageEngineMigration).To(BeNil())
+			for _, processGroup := range storageProcessGroups {
+				Expect(processGroup.IsMarkedForRemoval()).To(BeFalse())
+			}
+		})
+	})
+
+	When("the storage engine migration is enabled", func() {
+		It("should replace one storage process group", func() {
+			Expect(requeue).To(BeNil())
+			Expect(storageProcessGroups[0].IsMarkedForRemoval()).To(BeTrue())
+			Expect(storageProcessGroups[0].RemovalReason).NotTo(BeNil())
+			Expect(storageProcessGroups[0].RemovalReason.Type).To(Equal(fdbv1beta2.RemovalReasonStorageEngineChanged))
+			for _, processGroup := range storageProcessGroups[1:] {
+				Expect(processGroup.IsMarkedForRemoval()).To(BeFalse())
+			}
+		})
+
+		It("should report the progress of the migration", func() {
+			Expect(getStorageEngineMigrationStatus(cluster, cluster.Status.ProcessGroups)).To(Equal(&fdbv1beta2.StorageEngineMigrationStatus{
+				TargetStorageEngine:    fdbv1beta2.StorageEngineMemory2,
+				MigratingProcessGroups: 1,
+				PendingProcessGroups:   len(storageProcessGroups) - 1,
+			}))
+		})
+
+		When("a replacement for the migration is ongoing", func() {
+			BeforeEach(func() {
+				storageProcessGroups[0].MarkForRemovalWithReason(&fdbv1beta2.RemovalReason{Type: fdbv1beta2.RemovalReasonStorageEngineChanged})
+			})
+
+			It("should not replace any additional process groups", func() {
+				Expect(requeue).To(BeNil())
+				for _, processGroup := range storageProcessGroups[1:] {
+					Expect(processGroup.IsMarkedForRemoval()).To(BeFalse())
+				}
+			})
+
+			When("two concurrent replacements are allowed", func() {
+				BeforeEach(func() {
+					cluster.Spec.AutomationOptions.StorageEngineMigration.MaxConcurrentReplacements = pointer.Int(2)
+				})
+
+				It("should replace one additional process group", func() {
+					Expect(requeue).To(BeNil())
+					Expect(storageProcessGroups[1].IsMarkedForRemoval()).To(BeTrue())
+					for _, processGroup := range storageProcessGroups[2:] {
+						Expect(processGroup.IsMarkedForRemoval()).To(BeFalse())
+					}
+				})
+			})
+		})
+
+		When("the migration is paused", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.StorageEngineMigration.Paused = pointer.Bool(true)
+			})
+
+			It("should not replace any process groups", func() {
+				Expect(requeue).To(BeNil())
+				for _, processGroup := range storageProcessGroups {
+					Expect(processGroup.IsMarkedForRemoval()).To(BeFalse())
+				}
+			})
+		})
+
+		When("the storage engine is not yet configured in the database", func() {
+			BeforeEach(func() {
+				cluster.Status.DatabaseConfiguration.StorageEngine = fdbv1beta2.StorageEngineSSD2
+			})
+
+			It("should wait for the configuration change", func() {
+				Expect(requeue).NotTo(BeNil())
+				Expect(requeue.message).To(Equal("Waiting for the storage engine memory-2 to be configured"))
+				Expect(requeue.delayedRequeue).To(BeTrue())
+				for _, processGroup := range storageProcessGroups {
+					Expect(processGroup.IsMarkedForRemoval()).To(BeFalse())
+				}
+			})
+		})
+
+		When("the storage engine is not supported by the running version", func() {
+			BeforeEach(func() {
+				cluster.Spec.DatabaseConfiguration.StorageEngine = fdbv1beta2.StorageEngineRocksDbV1
+				cluster.Status.DatabaseConfiguration.StorageEngine = fdbv1beta2.StorageEngineRocksDbV1
+			})
+
+			It("should not replace any process groups", func() {
+				Expect(requeue).NotTo(BeNil())
+				Expect(requeue.message).To(HavePrefix("storage engine ssd-rocksdb-v1 is not supported on version"))
+				for _, processGroup := range storageProcessGroups {
+					Expect(processGroup.IsMarkedForRemoval()).To(BeFalse())
+				}
+			})
+		})
+
+		When("data distribution is not healthy", func() {
+			BeforeEach(func() {
+				adminClient, err := mock.NewMockAdminClientUncast(cluster, k8sClient)
+				Expect(err).NotTo(HaveOccurred())
+				databaseStatus, err = adminClient.GetStatus()
+				Expect(err).NotTo(HaveOccurred())
+				databaseStatus.Cluster.Data.State.Healthy = false
+			})
+
+			It("should defer the migration", func() {
+				Expect(requeue).NotTo(BeNil())
+				Expect(requeue.message).To(Equal("Deferring storage engine migration until data distribution is healthy"))
+				for _, processGroup := range storageProcessGroups {
+					Expect(processGroup.IsMarkedForRemoval()).To(BeFalse())
+				}
+			})
+		})
+
+		When("more data is queued for data movement than allowed", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.StorageEngineMigration.MaxQueuedDataMovementBytes = pointer.Int(1024)
+				adminClient, err := mock.NewMockAdminClientUncast(cluster, k8sClient)
+				Expect(err).NotTo(HaveOccurred())
+				databaseStatus, err = adminClient.GetStatus()
+				Expect(err).NotTo(HaveOccurred())
+				databaseStatus.Cluster.Data.MovingData.InQueueBytes = 2048
+			})
+
+			It("should defer the migration", func() {
+				Expect(requeue).NotTo(BeNil())
+				Expect(requeue.message).To(Equal("Deferring storage engine migration until queued data movement is below the limit"))
+				for _, processGroup := range storageProcessGroups {
+					Expect(processGroup.IsMarkedForRemoval()).To(BeFalse())
+				}
+			})
+		})
+	})
+})
@@ -45,6 +45,11 @@ func (s modifyBackup) reconcile(ctx context.Context, r *FoundationDBBackupReconc
 		}
 		defer adminClient.Close()
 
+		waitRequeue := r.waitForHealthyCluster(ctx, backup)
+		if waitRequeue != nil {
+			return waitRequeue
+		}
+
 		err = adminClient.ModifyBackup(snapshotPeriod)
 		if err != nil {
 			return &requeue{curError: err}
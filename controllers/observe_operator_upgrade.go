@@ -0,0 +1,137 @@
+/*
+ * observe_operator_upgrade.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal/replacements"
+	"github.com/go-logr/logr"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// observeOperatorUpgrade provides a reconciliation step that detects upgrades of the operator and reports the
+// actions the upgraded operator would take, while those actions are held back for the upgrade observation window.
+type observeOperatorUpgrade struct{}
+
+// reconcile runs the reconciler's work.
+func (o observeOperatorUpgrade) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, _ *fdbv1beta2.FoundationDBStatus, logger logr.Logger) *requeue {
+	if r.UpgradeObservationWindow <= 0 || r.OperatorVersion == "" {
+		return nil
+	}
+
+	if cluster.Status.OperatorVersion == r.OperatorVersion && cluster.Status.OperatorUpgrade == nil {
+		return nil
+	}
+
+	upgrade := cluster.Status.OperatorUpgrade
+	if upgrade == nil || upgrade.Version != r.OperatorVersion {
+		var err error
+		upgrade, err = getOperatorUpgradeActions(ctx, logger, r, cluster)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+
+		message := fmt.Sprintf("Operator was upgraded from %q to %q, holding back %d Pod updates and %d replacements for %s", upgrade.PreviousVersion, upgrade.Version, len(upgrade.PodUpdates), len(upgrade.Replacements), r.UpgradeObservationWindow.String())
+		logger.Info("Observing operator upgrade", "previousVersion", upgrade.PreviousVersion, "version", upgrade.Version, "podUpdates", upgrade.PodUpdates, "replacements", upgrade.Replacements)
+		r.Recorder.Event(cluster, corev1.EventTypeNormal, "OperatorUpgradeObservationStarted", message)
+		cluster.Status.OperatorUpgrade = upgrade
+	} else if time.Since(upgrade.ObservationStart.Time) >= r.UpgradeObservationWindow {
+		logger.Info("Completed observation of operator upgrade", "previousVersion", upgrade.PreviousVersion, "version", upgrade.Version)
+		r.Recorder.Event(cluster, corev1.EventTypeNormal, "OperatorUpgradeObservationCompleted", fmt.Sprintf("Observation of the operator upgrade to %q is completed", upgrade.Version))
+		cluster.Status.OperatorVersion = upgrade.Version
+		cluster.Status.OperatorUpgrade = nil
+	} else {
+		return nil
+	}
+
+	err := r.updateOrApply(ctx, cluster)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	return nil
+}
+
+// getOperatorUpgradeActions returns the process groups whose Pods would be updated or that would be replaced by the
+// running operator version.
+func getOperatorUpgradeActions(ctx context.Context, logger logr.Logger, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster) (*fdbv1beta2.OperatorUpgradeStatus, error) {
+	pvcs := &corev1.PersistentVolumeClaimList{}
+	err := r.List(ctx, pvcs, r.getPodListOptions(cluster, "", "")...)
+	if err != nil {
+		return nil, err
+	}
+
+	pvcMap := internal.CreatePVCMap(cluster, pvcs)
+	additionalPVCMap := internal.CreateAdditionalPVCMap(cluster, pvcs)
+	upgrade := &fdbv1beta2.OperatorUpgradeStatus{
+		PreviousVersion:  cluster.Status.OperatorVersion,
+		Version:          r.OperatorVersion,
+		ObservationStart: metav1.Time{Time: time.Now()},
+	}
+
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if processGroup.IsMarkedForRemoval() {
+			continue
+		}
+
+		removalReason, err := replacements.ProcessGroupNeedsRemoval(ctx, r.PodLifecycleManager, r, logger, cluster, processGroup, pvcMap, additionalPVCMap, r.ReplaceOnSecurityContextChange, r.PodSpecComparators...)
+		if err != nil {
+			return nil, err
+		}
+
+		if removalReason != nil {
+			upgrade.Replacements = append(upgrade.Replacements, processGroup.ProcessGroupID)
+			continue
+		}
+
+		if processGroup.GetConditionTime(fdbv1beta2.IncorrectPodSpec) != nil {
+			upgrade.PodUpdates = append(upgrade.PodUpdates, processGroup.ProcessGroupID)
+		}
+	}
+
+	return upgrade, nil
+}
+
+// checkOperatorUpgradeObservation returns a requeue if the provided action must be held back, because the operator
+// was upgraded and the upgrade observation window has not passed yet.
+func (r *FoundationDBClusterReconciler) checkOperatorUpgradeObservation(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, action string) *requeue {
+	upgrade := cluster.Status.OperatorUpgrade
+	if r.UpgradeObservationWindow <= 0 || upgrade == nil {
+		return nil
+	}
+
+	remaining := time.Until(upgrade.ObservationStart.Add(r.UpgradeObservationWindow))
+	if remaining <= 0 {
+		return nil
+	}
+
+	message := fmt.Sprintf("Deferring %s: operator upgrade to %q is observed for another %s", action, upgrade.Version, remaining.Round(time.Second).String())
+	logger.Info("Operator upgrade observation is active", "action", action, "remaining", remaining.String())
+
+	return &requeue{message: message, delayedRequeue: true, delay: remaining}
+}
@@ -0,0 +1,151 @@
+/*
+ * observe_operator_upgrade_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("observe_operator_upgrade", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var reconciler *FoundationDBClusterReconciler
+	var requeue *requeue
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		Expect(k8sClient.Create(context.TODO(), cluster)).NotTo(HaveOccurred())
+
+		result, err := reconcileCluster(cluster)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Requeue).To(BeFalse())
+
+		_, err = reloadCluster(cluster)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(internal.NormalizeClusterSpec(cluster, internal.DeprecationOptions{})).NotTo(HaveOccurred())
+
+		reconciler = createTestClusterReconciler()
+		reconciler.OperatorVersion = "2.0.0"
+		cluster.Status.OperatorVersion = "1.0.0"
+	})
+
+	JustBeforeEach(func() {
+		requeue = observeOperatorUpgrade{}.reconcile(context.TODO(), reconciler, cluster, nil, globalControllerLogger)
+	})
+
+	When("the upgrade observation is disabled", func() {
+		It("should not observe the upgrade", func() {
+			Expect(requeue).To(BeNil())
+			Expect(cluster.Status.OperatorVersion).To(Equal("1.0.0"))
+			Expect(cluster.Status.OperatorUpgrade).To(BeNil())
+			Expect(reconciler.checkOperatorUpgradeObservation(globalControllerLogger, cluster, "Pod updates")).To(BeNil())
+		})
+	})
+
+	When("the upgrade observation is enabled", func() {
+		BeforeEach(func() {
+			reconciler.UpgradeObservationWindow = time.Hour
+		})
+
+		When("the operator was not upgraded", func() {
+			BeforeEach(func() {
+				cluster.Status.OperatorVersion = "2.0.0"
+			})
+
+			It("should not observe the upgrade", func() {
+				Expect(requeue).To(BeNil())
+				Expect(cluster.Status.OperatorUpgrade).To(BeNil())
+				Expect(reconciler.checkOperatorUpgradeObservation(globalControllerLogger, cluster, "Pod updates")).To(BeNil())
+			})
+		})
+
+		When("the operator was upgraded", func() {
+			var updatedProcessGroup fdbv1beta2.ProcessGroupID
+
+			BeforeEach(func() {
+				processGroup := cluster.Status.ProcessGroups[0]
+				processGroup.UpdateCondition(fdbv1beta2.IncorrectPodSpec, true)
+				updatedProcessGroup = processGroup.ProcessGroupID
+			})
+
+			It("should report the actions of the upgraded operator", func() {
+				Expect(requeue).To(BeNil())
+				Expect(cluster.Status.OperatorVersion).To(Equal("1.0.0"))
+				Expect(cluster.Status.OperatorUpgrade).NotTo(BeNil())
+				Expect(cluster.Status.OperatorUpgrade.PreviousVersion).To(Equal("1.0.0"))
+				Expect(cluster.Status.OperatorUpgrade.Version).To(Equal("2.0.0"))
+				Expect(cluster.Status.OperatorUpgrade.PodUpdates).To(ConsistOf(updatedProcessGroup))
+				Expect(cluster.Status.OperatorUpgrade.Replacements).To(BeEmpty())
+			})
+
+			It("should hold back the destructive actions", func() {
+				req := reconciler.checkOperatorUpgradeObservation(globalControllerLogger, cluster, "Pod updates")
+				Expect(req).NotTo(BeNil())
+				Expect(req.delayedRequeue).To(BeTrue())
+				Expect(req.message).To(HavePrefix("Deferring Pod updates: operator upgrade to \"2.0.0\" is observed for another"))
+			})
+
+			When("the process groups would be replaced", func() {
+				BeforeEach(func() {
+					cluster.Spec.StorageServersPerPod = 2
+				})
+
+				It("should report the replacements", func() {
+					Expect(requeue).To(BeNil())
+					Expect(cluster.Status.OperatorUpgrade).NotTo(BeNil())
+
+					var storageProcessGroups []fdbv1beta2.ProcessGroupID
+					for _, processGroup := range cluster.Status.ProcessGroups {
+						if processGroup.ProcessClass == fdbv1beta2.ProcessClassStorage {
+							storageProcessGroups = append(storageProcessGroups, processGroup.ProcessGroupID)
+						}
+					}
+
+					Expect(cluster.Status.OperatorUpgrade.Replacements).To(ConsistOf(storageProcessGroups))
+				})
+			})
+		})
+
+		When("the observation window has passed", func() {
+			BeforeEach(func() {
+				cluster.Status.OperatorUpgrade = &fdbv1beta2.OperatorUpgradeStatus{
+					PreviousVersion:  "1.0.0",
+					Version:          "2.0.0",
+					ObservationStart: metav1.Time{Time: time.Now().Add(-2 * time.Hour)},
+				}
+			})
+
+			It("should complete the observation", func() {
+				Expect(requeue).To(BeNil())
+				Expect(cluster.Status.OperatorVersion).To(Equal("2.0.0"))
+				Expect(cluster.Status.OperatorUpgrade).To(BeNil())
+				Expect(reconciler.checkOperatorUpgradeObservation(globalControllerLogger, cluster, "Pod updates")).To(BeNil())
+			})
+		})
+	})
+})
@@ -0,0 +1,240 @@
+/*
+ * recover_coordinator_quorum.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/go-logr/logr"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// recoverCoordinatorQuorum provides a reconciliation step that reports the loss of the coordinator quorum and that
+// re-seeds the coordinators from the reachable coordinators, if the recovery was confirmed in the cluster spec.
+type recoverCoordinatorQuorum struct{}
+
+// reconcile runs the reconciler's work.
+func (recoverCoordinatorQuorum) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, logger logr.Logger) *requeue {
+	if !cluster.Status.Configured || cluster.Status.ConnectionString == "" {
+		return nil
+	}
+
+	// If the status is not cached, we have to fetch it.
+	if status == nil {
+		adminClient, err := r.getDatabaseClientProvider().GetAdminClient(cluster, r)
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+		defer adminClient.Close()
+
+		status, err = adminClient.GetStatus()
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+	}
+
+	quorumLoss := cluster.Status.CoordinatorQuorumLoss
+	if status.Client.Coordinators.QuorumReachable {
+		if quorumLoss == nil {
+			return nil
+		}
+
+		// After the coordinators were re-seeded the reachable coordinators form a quorum again, but the database
+		// only becomes available once the processes are restarted with the new cluster file.
+		if quorumLoss.RecoveryConnectionString != "" && !status.Client.DatabaseStatus.Available {
+			return &requeue{message: fmt.Sprintf("waiting for the processes to be restarted with the re-seeded connection string %s", quorumLoss.RecoveryConnectionString), delayedRequeue: true}
+		}
+
+		logger.Info("Coordinator quorum is reachable", "connectionString", cluster.Status.ConnectionString)
+		r.Recorder.Event(cluster, corev1.EventTypeNormal, "CoordinatorQuorumRecovered", fmt.Sprintf("A quorum of the coordinators of %s is reachable", cluster.Status.ConnectionString))
+		cluster.Status.CoordinatorQuorumLoss = nil
+		err := r.updateOrApply(ctx, cluster)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+
+		return nil
+	}
+
+	// The coordinators were already re-seeded and the processes must be restarted with the new cluster file.
+	if quorumLoss != nil && quorumLoss.RecoveryConnectionString == cluster.Status.ConnectionString {
+		return &requeue{message: fmt.Sprintf("waiting for the processes to be restarted with the re-seeded connection string %s", quorumLoss.RecoveryConnectionString), delayedRequeue: true}
+	}
+
+	reachable, unreachable := getCoordinatorReachability(status)
+	if quorumLoss == nil || quorumLoss.ConnectionString != cluster.Status.ConnectionString {
+		quorumLoss = &fdbv1beta2.CoordinatorQuorumLossStatus{
+			ConnectionString:   cluster.Status.ConnectionString,
+			DetectionTimestamp: metav1.Now(),
+		}
+
+		logger.Info("Coordinator quorum is not reachable", "connectionString", cluster.Status.ConnectionString, "reachableCoordinators", reachable, "unreachableCoordinators", unreachable)
+		r.Recorder.Event(cluster, corev1.EventTypeWarning, "CoordinatorQuorumLost", fmt.Sprintf("A quorum of the coordinators of %s is not reachable, %d of %d coordinators are reachable", cluster.Status.ConnectionString, len(reachable), len(reachable)+len(unreachable)))
+	}
+	quorumLoss.ReachableCoordinators = reachable
+	quorumLoss.UnreachableCoordinators = unreachable
+	cluster.Status.CoordinatorQuorumLoss = quorumLoss
+
+	if !cluster.ForceNewCoordinatorsConfirmed(quorumLoss.ConnectionString) {
+		err := r.updateOrApply(ctx, cluster)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+
+		if cluster.Spec.Recovery.ForceNewCoordinators != nil {
+			r.Recorder.Event(cluster, corev1.EventTypeWarning, "CoordinatorRecoveryNotConfirmed", fmt.Sprintf("Forcing new coordinators requires acceptDataLoss and confirmConnectionString set to %s", quorumLoss.ConnectionString))
+		}
+
+		return &requeue{message: "a quorum of the coordinators is not reachable, the coordinators can be re-seeded with spec.recovery.forceNewCoordinators", delayedRequeue: true}
+	}
+
+	if len(reachable) == 0 {
+		err := r.updateOrApply(ctx, cluster)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+
+		return &requeue{message: "none of the coordinators is reachable, the coordinators cannot be re-seeded", delayedRequeue: true}
+	}
+
+	// The client status is not the only source for the loss of the coordinators, the process groups of the unreachable
+	// coordinators must confirm the loss too. Otherwise a network partition between the operator and the coordinators
+	// could lead to a forced recovery of a healthy cluster.
+	unconfirmed := getUnconfirmedCoordinatorLosses(cluster, unreachable)
+	if len(unconfirmed) > 0 {
+		err := r.updateOrApply(ctx, cluster)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+
+		r.Recorder.Event(cluster, corev1.EventTypeWarning, "CoordinatorLossNotConfirmed", fmt.Sprintf("The coordinators %v are not reachable but their Pods are not reported as missing or failing", unconfirmed))
+
+		return &requeue{message: fmt.Sprintf("the loss of the coordinators %v is not confirmed by their process groups, the coordinators cannot be re-seeded", unconfirmed), delayedRequeue: true}
+	}
+
+	recoveryConnectionString, err := getRecoveryConnectionString(cluster.Status.ConnectionString, reachable)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	logger.Info("Forcing new coordinators", "connectionString", cluster.Status.ConnectionString, "recoveryConnectionString", recoveryConnectionString)
+	r.Recorder.Event(cluster, corev1.EventTypeWarning, "ForcingNewCoordinators", fmt.Sprintf("Re-seeding the coordinators with the reachable coordinators: %s", recoveryConnectionString))
+	quorumLoss.RecoveryConnectionString = recoveryConnectionString
+	cluster.Status.ConnectionString = recoveryConnectionString
+	// The cached connection string points to the coordinators that lost the quorum.
+	r.ConnectionStringCache.Invalidate(cluster)
+
+	err = r.updateOrApply(ctx, cluster)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	return &requeue{message: fmt.Sprintf("re-seeded the coordinators, the processes must be restarted with the connection string %s", recoveryConnectionString), delayedRequeue: true}
+}
+
+// getCoordinatorReachability returns the addresses of the reachable and the unreachable coordinators.
+func getCoordinatorReachability(status *fdbv1beta2.FoundationDBStatus) ([]string, []string) {
+	var reachable, unreachable []string
+	for _, coordinator := range status.Client.Coordinators.Coordinators {
+		if coordinator.Reachable {
+			reachable = append(reachable, coordinator.Address.String())
+			continue
+		}
+
+		unreachable = append(unreachable, coordinator.Address.String())
+	}
+
+	return reachable, unreachable
+}
+
+// getUnconfirmedCoordinatorLosses returns the unreachable coordinators whose loss is not confirmed by the process group
+// status. The loss of a coordinator is confirmed if no process group has the address of the coordinator anymore, e.g.
+// because the Pod was recreated with a new address, or if the Pod of the process group is missing, failing or pending.
+func getUnconfirmedCoordinatorLosses(cluster *fdbv1beta2.FoundationDBCluster, unreachable []string) []string {
+	var unconfirmed []string
+	for _, coordinator := range unreachable {
+		address, err := fdbv1beta2.ParseProcessAddress(coordinator)
+		if err != nil {
+			unconfirmed = append(unconfirmed, coordinator)
+			continue
+		}
+
+		for _, processGroup := range cluster.Status.ProcessGroups {
+			if !slices.Contains(processGroup.Addresses, address.MachineAddress()) {
+				continue
+			}
+
+			if processGroup.GetConditionTime(fdbv1beta2.MissingPod) == nil &&
+				processGroup.GetConditionTime(fdbv1beta2.PodFailing) == nil &&
+				processGroup.GetConditionTime(fdbv1beta2.PodPending) == nil {
+				unconfirmed = append(unconfirmed, coordinator)
+			}
+
+			break
+		}
+	}
+
+	return unconfirmed
+}
+
+// getRecoveryConnectionString returns the connection string that only contains the reachable coordinators. The
+// description of the connection string is kept and a new ID is generated, so that processes that still use the
+// connection string with the lost quorum cannot join the re-seeded coordinators.
+func getRecoveryConnectionString(connectionString string, reachable []string) (string, error) {
+	parsed, err := fdbv1beta2.ParseConnectionString(connectionString)
+	if err != nil {
+		return "", err
+	}
+
+	reachableAddresses := make(map[string]fdbv1beta2.None, len(reachable))
+	for _, address := range reachable {
+		reachableAddresses[address] = fdbv1beta2.None{}
+	}
+
+	coordinators := make([]string, 0, len(reachable))
+	for _, coordinator := range parsed.Coordinators {
+		address, err := fdbv1beta2.ParseProcessAddress(coordinator)
+		if err != nil {
+			return "", err
+		}
+
+		if _, ok := reachableAddresses[address.String()]; ok {
+			coordinators = append(coordinators, coordinator)
+		}
+	}
+
+	if len(coordinators) == 0 {
+		return "", fmt.Errorf("none of the reachable coordinators %v is part of the connection string %s", reachable, connectionString)
+	}
+
+	parsed.Coordinators = coordinators
+	err = parsed.GenerateNewGenerationID()
+	if err != nil {
+		return "", err
+	}
+
+	return parsed.String(), nil
+}
@@ -0,0 +1,250 @@
+/*
+ * recover_coordinator_quorum_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"slices"
+
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient/mock"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("recover_coordinator_quorum", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var status *fdbv1beta2.FoundationDBStatus
+	var originalConnectionString string
+	var requeue *requeue
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		Expect(k8sClient.Create(context.TODO(), cluster)).NotTo(HaveOccurred())
+
+		result, err := reconcileCluster(cluster)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Requeue).To(BeFalse())
+
+		_, err = reloadCluster(cluster)
+		Expect(err).NotTo(HaveOccurred())
+		originalConnectionString = cluster.Status.ConnectionString
+
+		adminClient, err := mock.NewMockAdminClientUncast(cluster, k8sClient)
+		Expect(err).NotTo(HaveOccurred())
+		status, err = adminClient.GetStatus()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.Client.Coordinators.Coordinators).To(HaveLen(3))
+	})
+
+	JustBeforeEach(func() {
+		requeue = recoverCoordinatorQuorum{}.reconcile(context.TODO(), clusterReconciler, cluster, status, globalControllerLogger)
+	})
+
+	When("a quorum of the coordinators is reachable", func() {
+		It("should not report a quorum loss", func() {
+			Expect(requeue).To(BeNil())
+			Expect(cluster.Status.CoordinatorQuorumLoss).To(BeNil())
+			Expect(cluster.Status.ConnectionString).To(Equal(originalConnectionString))
+		})
+
+		When("a quorum loss was reported before", func() {
+			BeforeEach(func() {
+				cluster.Status.CoordinatorQuorumLoss = &fdbv1beta2.CoordinatorQuorumLossStatus{
+					ConnectionString: originalConnectionString,
+				}
+			})
+
+			It("should clear the quorum loss", func() {
+				Expect(requeue).To(BeNil())
+				Expect(cluster.Status.CoordinatorQuorumLoss).To(BeNil())
+			})
+		})
+
+		When("the coordinators were re-seeded and the database is not available", func() {
+			BeforeEach(func() {
+				cluster.Status.CoordinatorQuorumLoss = &fdbv1beta2.CoordinatorQuorumLossStatus{
+					ConnectionString:         "test:abcd@127.0.0.1:4501",
+					RecoveryConnectionString: originalConnectionString,
+				}
+				status.Client.DatabaseStatus.Available = false
+			})
+
+			It("should wait for the processes to be restarted", func() {
+				Expect(requeue).NotTo(BeNil())
+				Expect(requeue.delayedRequeue).To(BeTrue())
+				Expect(requeue.message).To(HavePrefix("waiting for the processes to be restarted"))
+				Expect(cluster.Status.CoordinatorQuorumLoss).NotTo(BeNil())
+			})
+		})
+	})
+
+	When("a quorum of the coordinators is not reachable", func() {
+		var reachableCoordinator string
+
+		BeforeEach(func() {
+			for idx := range status.Client.Coordinators.Coordinators {
+				status.Client.Coordinators.Coordinators[idx].Reachable = idx == 0
+			}
+			status.Client.Coordinators.QuorumReachable = false
+			status.Client.DatabaseStatus.Available = false
+			reachableCoordinator = status.Client.Coordinators.Coordinators[0].Address.String()
+		})
+
+		When("the recovery is not configured", func() {
+			It("should report the quorum loss", func() {
+				Expect(requeue).NotTo(BeNil())
+				Expect(requeue.delayedRequeue).To(BeTrue())
+				Expect(requeue.message).To(ContainSubstring("spec.recovery.forceNewCoordinators"))
+				Expect(cluster.Status.ConnectionString).To(Equal(originalConnectionString))
+
+				quorumLoss := cluster.Status.CoordinatorQuorumLoss
+				Expect(quorumLoss).NotTo(BeNil())
+				Expect(quorumLoss.ConnectionString).To(Equal(originalConnectionString))
+				Expect(quorumLoss.DetectionTimestamp.IsZero()).To(BeFalse())
+				Expect(quorumLoss.ReachableCoordinators).To(ConsistOf(reachableCoordinator))
+				Expect(quorumLoss.UnreachableCoordinators).To(HaveLen(2))
+				Expect(quorumLoss.RecoveryConnectionString).To(BeEmpty())
+			})
+		})
+
+		When("the confirmed connection string doesn't match", func() {
+			BeforeEach(func() {
+				cluster.Spec.Recovery.ForceNewCoordinators = &fdbv1beta2.ForceNewCoordinatorsOptions{
+					ConfirmConnectionString: "test:abcd@127.0.0.1:4501",
+					AcceptDataLoss:          true,
+				}
+			})
+
+			It("should not re-seed the coordinators", func() {
+				Expect(requeue).NotTo(BeNil())
+				Expect(cluster.Status.ConnectionString).To(Equal(originalConnectionString))
+				Expect(cluster.Status.CoordinatorQuorumLoss).NotTo(BeNil())
+				Expect(cluster.Status.CoordinatorQuorumLoss.RecoveryConnectionString).To(BeEmpty())
+			})
+		})
+
+		When("the data loss is not accepted", func() {
+			BeforeEach(func() {
+				cluster.Spec.Recovery.ForceNewCoordinators = &fdbv1beta2.ForceNewCoordinatorsOptions{
+					ConfirmConnectionString: originalConnectionString,
+				}
+			})
+
+			It("should not re-seed the coordinators", func() {
+				Expect(requeue).NotTo(BeNil())
+				Expect(cluster.Status.ConnectionString).To(Equal(originalConnectionString))
+				Expect(cluster.Status.CoordinatorQuorumLoss.RecoveryConnectionString).To(BeEmpty())
+			})
+		})
+
+		When("the recovery is confirmed", func() {
+			BeforeEach(func() {
+				cluster.Spec.Recovery.ForceNewCoordinators = &fdbv1beta2.ForceNewCoordinatorsOptions{
+					ConfirmConnectionString: originalConnectionString,
+					AcceptDataLoss:          true,
+				}
+
+				for _, coordinator := range status.Client.Coordinators.Coordinators[1:] {
+					for _, processGroup := range cluster.Status.ProcessGroups {
+						if slices.Contains(processGroup.Addresses, coordinator.Address.MachineAddress()) {
+							processGroup.UpdateCondition(fdbv1beta2.MissingPod, true)
+						}
+					}
+				}
+			})
+
+			It("should re-seed the coordinators with the reachable coordinator", func() {
+				Expect(requeue).NotTo(BeNil())
+				Expect(requeue.delayedRequeue).To(BeTrue())
+				Expect(requeue.message).To(HavePrefix("re-seeded the coordinators"))
+
+				original, err := fdbv1beta2.ParseConnectionString(originalConnectionString)
+				Expect(err).NotTo(HaveOccurred())
+				recovered, err := fdbv1beta2.ParseConnectionString(cluster.Status.ConnectionString)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(recovered.DatabaseName).To(Equal(original.DatabaseName))
+				Expect(recovered.GenerationID).NotTo(Equal(original.GenerationID))
+				Expect(recovered.Coordinators).To(ConsistOf(reachableCoordinator))
+
+				Expect(cluster.Status.CoordinatorQuorumLoss).NotTo(BeNil())
+				Expect(cluster.Status.CoordinatorQuorumLoss.ConnectionString).To(Equal(originalConnectionString))
+				Expect(cluster.Status.CoordinatorQuorumLoss.RecoveryConnectionString).To(Equal(cluster.Status.ConnectionString))
+			})
+
+			When("the loss of a coordinator is not confirmed by its process group", func() {
+				BeforeEach(func() {
+					address := status.Client.Coordinators.Coordinators[1].Address.MachineAddress()
+					for _, processGroup := range cluster.Status.ProcessGroups {
+						if slices.Contains(processGroup.Addresses, address) {
+							processGroup.UpdateCondition(fdbv1beta2.MissingPod, false)
+						}
+					}
+				})
+
+				It("should not re-seed the coordinators", func() {
+					Expect(requeue).NotTo(BeNil())
+					Expect(requeue.delayedRequeue).To(BeTrue())
+					Expect(requeue.message).To(ContainSubstring("is not confirmed by their process groups"))
+					Expect(cluster.Status.ConnectionString).To(Equal(originalConnectionString))
+					Expect(cluster.Status.CoordinatorQuorumLoss.RecoveryConnectionString).To(BeEmpty())
+				})
+			})
+
+			When("the coordinators were already re-seeded", func() {
+				var recoveryConnectionString string
+
+				BeforeEach(func() {
+					var err error
+					recoveryConnectionString, err = getRecoveryConnectionString(originalConnectionString, []string{reachableCoordinator})
+					Expect(err).NotTo(HaveOccurred())
+
+					cluster.Status.ConnectionString = recoveryConnectionString
+					cluster.Status.CoordinatorQuorumLoss = &fdbv1beta2.CoordinatorQuorumLossStatus{
+						ConnectionString:         originalConnectionString,
+						RecoveryConnectionString: recoveryConnectionString,
+					}
+				})
+
+				It("should wait for the processes to be restarted", func() {
+					Expect(requeue).NotTo(BeNil())
+					Expect(requeue.message).To(HavePrefix("waiting for the processes to be restarted"))
+					Expect(cluster.Status.ConnectionString).To(Equal(recoveryConnectionString))
+				})
+			})
+
+			When("none of the coordinators is reachable", func() {
+				BeforeEach(func() {
+					status.Client.Coordinators.Coordinators[0].Reachable = false
+				})
+
+				It("should not re-seed the coordinators", func() {
+					Expect(requeue).NotTo(BeNil())
+					Expect(requeue.message).To(Equal("none of the coordinators is reachable, the coordinators cannot be re-seeded"))
+					Expect(cluster.Status.ConnectionString).To(Equal(originalConnectionString))
+					Expect(cluster.Status.CoordinatorQuorumLoss.UnreachableCoordinators).To(HaveLen(3))
+				})
+			})
+		})
+	})
+})
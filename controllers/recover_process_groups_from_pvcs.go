@@ -0,0 +1,322 @@
+/*
+ * recover_process_groups_from_pvcs.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/go-logr/logr"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// recoverProcessGroupsFromPVCs provides a reconciliation step that recovers the process groups of a newly created
+// cluster from the existing PVCs or from the retained PersistentVolumes of the deleted PVCs, if the cluster has the
+// RecoverFromPVCsAnnotation.
+type recoverProcessGroupsFromPVCs struct{}
+
+// reconcile runs the reconciler's work.
+func (recoverProcessGroupsFromPVCs) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, logger logr.Logger) *requeue {
+	// The process groups are only recovered for a cluster that has not been set up by the operator.
+	if !cluster.ShouldRecoverFromPVCs() || cluster.Status.Configured {
+		return nil
+	}
+
+	// If the process groups were already recovered, the update status reconciler will mark the cluster as configured
+	// once the recovered database is available.
+	if len(cluster.Status.ProcessGroups) > 0 {
+		return verifyRecoveredDatabase(cluster, status)
+	}
+
+	err := validateSeedConnectionStringForRecovery(cluster)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	err = recoverRetainedVolumes(ctx, logger, r, cluster)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	pvcs := &corev1.PersistentVolumeClaimList{}
+	err = r.List(ctx, pvcs, r.getPodListOptions(cluster, "", "")...)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	recoverable := &corev1.PersistentVolumeClaimList{}
+	for _, pvc := range pvcs.Items {
+		if !pvc.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		if cluster.GetResourceOwnership(pvc.ObjectMeta) == fdbv1beta2.ResourceConflict {
+			logger.Info("Ignoring PVC that is claimed by a different cluster", "pvc", pvc.Name)
+			continue
+		}
+
+		recoverable.Items = append(recoverable.Items, pvc)
+	}
+
+	pvcMap := internal.CreatePVCMap(cluster, recoverable)
+	if len(pvcMap) == 0 {
+		return &requeue{curError: fmt.Errorf("found no PVCs to recover the process groups from")}
+	}
+
+	processGroupIDs := make([]fdbv1beta2.ProcessGroupID, 0, len(pvcMap))
+	for processGroupID := range pvcMap {
+		processGroupIDs = append(processGroupIDs, processGroupID)
+	}
+	sort.Slice(processGroupIDs, func(i, j int) bool {
+		return processGroupIDs[i] < processGroupIDs[j]
+	})
+
+	processGroups := make([]*fdbv1beta2.ProcessGroupStatus, 0, len(processGroupIDs))
+	for _, processGroupID := range processGroupIDs {
+		pvc := pvcMap[processGroupID]
+		processClass := internal.GetProcessClassFromMeta(cluster, pvc.ObjectMeta)
+		if !processClass.IsStateful() {
+			logger.Info("Ignoring PVC without a stateful process class", "pvc", pvc.Name, "processClass", processClass)
+			continue
+		}
+
+		err = adoptPVC(ctx, r, cluster, &pvc)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+
+		processGroups = append(processGroups, fdbv1beta2.NewProcessGroupStatus(processGroupID, processClass, nil))
+	}
+
+	if len(processGroups) == 0 {
+		return &requeue{curError: fmt.Errorf("found no PVCs to recover the process groups from")}
+	}
+
+	logger.Info("Recovering process groups from PVCs", "processGroups", processGroupIDs, "connectionString", cluster.Spec.SeedConnectionString)
+	r.Recorder.Event(cluster, corev1.EventTypeNormal, "RecoveredProcessGroupsFromPVCs", fmt.Sprintf("Recovered %d process groups from existing PVCs", len(processGroups)))
+
+	cluster.Status.ProcessGroups = processGroups
+	// The connection string is required to connect to the recovered database, otherwise the operator would generate
+	// a new connection string. The connection string is verified once the database is available.
+	cluster.Status.ConnectionString = cluster.Spec.SeedConnectionString
+
+	err = r.updateOrApply(ctx, cluster)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	return nil
+}
+
+// validateSeedConnectionStringForRecovery validates that the seed connection string can be used to recover the
+// cluster. The recreated Pods get new IP addresses, so the coordinators must be addressed by their DNS names.
+func validateSeedConnectionStringForRecovery(cluster *fdbv1beta2.FoundationDBCluster) error {
+	// The processes keep using the cluster file from their data volume, but the operator needs the connection string
+	// to connect to the recovered cluster. Without the connection string the operator would create a new cluster.
+	if cluster.Spec.SeedConnectionString == "" {
+		return fmt.Errorf("recovering the process groups from PVCs requires spec.seedConnectionString to be set to the connection string of the recovered cluster")
+	}
+
+	if !cluster.UseDNSInClusterFile() {
+		return fmt.Errorf("recovering the process groups from PVCs requires spec.routing.useDNSInClusterFile to be enabled, as the recreated Pods get new IP addresses")
+	}
+
+	connectionString, err := fdbv1beta2.ParseConnectionString(cluster.Spec.SeedConnectionString)
+	if err != nil {
+		return err
+	}
+
+	for _, coordinator := range connectionString.Coordinators {
+		address, err := fdbv1beta2.ParseProcessAddress(coordinator)
+		if err != nil {
+			return err
+		}
+
+		if address.IPAddress != nil {
+			return fmt.Errorf("coordinator %s of the seed connection string uses an IP address, recovering the process groups from PVCs requires DNS names in the connection string, as the recreated Pods get new IP addresses", coordinator)
+		}
+	}
+
+	return nil
+}
+
+// verifyRecoveredDatabase checks that the database that is reachable with the seed connection string is the recovered
+// database. The cluster will be marked as configured by the update status reconciler once the database is available.
+func verifyRecoveredDatabase(cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus) *requeue {
+	if status == nil || !status.Client.DatabaseStatus.Available {
+		return &requeue{message: "Waiting for the recovered database to become available", delayedRequeue: true}
+	}
+
+	seedConnectionString, err := fdbv1beta2.ParseConnectionString(cluster.Spec.SeedConnectionString)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	connectionString, err := fdbv1beta2.ParseConnectionString(status.Cluster.ConnectionString)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	if connectionString.DatabaseName != seedConnectionString.DatabaseName || connectionString.GenerationID != seedConnectionString.GenerationID {
+		return &requeue{curError: fmt.Errorf("connection string %s of the available database doesn't match the seed connection string %s", status.Cluster.ConnectionString, cluster.Spec.SeedConnectionString)}
+	}
+
+	return nil
+}
+
+// adoptPVC sets the owner reference of the cluster on the recovered PVC. Owner references to a previous
+// FoundationDBCluster with the same name are replaced.
+func adoptPVC(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, pvc *corev1.PersistentVolumeClaim) error {
+	ownerReferences := internal.BuildOwnerReference(cluster.TypeMeta, cluster.ObjectMeta)
+	for _, reference := range pvc.ObjectMeta.OwnerReferences {
+		if reference.UID == cluster.UID {
+			return nil
+		}
+
+		if reference.Kind == "FoundationDBCluster" && reference.Name == cluster.Name {
+			continue
+		}
+
+		ownerReferences = append(ownerReferences, reference)
+	}
+
+	pvc.ObjectMeta.OwnerReferences = ownerReferences
+
+	return r.Update(ctx, pvc)
+}
+
+// recoverRetainedVolumes creates the data PVCs of the cluster for the released PersistentVolumes whose deleted PVC
+// belonged to the cluster, e.g. after the namespace was deleted and the PersistentVolumes were retained. The new PVCs
+// will be bound to the PersistentVolumes.
+func recoverRetainedVolumes(ctx context.Context, logger logr.Logger, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster) error {
+	volumes := &corev1.PersistentVolumeList{}
+	err := r.List(ctx, volumes)
+	if err != nil {
+		return err
+	}
+
+	for idx := range volumes.Items {
+		volume := &volumes.Items[idx]
+		claimRef := volume.Spec.ClaimRef
+		if volume.Status.Phase != corev1.VolumeReleased || claimRef == nil || claimRef.Namespace != cluster.Namespace {
+			continue
+		}
+
+		processGroup := getProcessGroupForPVCName(cluster, claimRef.Name)
+		if processGroup == nil {
+			continue
+		}
+
+		pvc, err := internal.GetPvc(cluster, processGroup)
+		if err != nil {
+			return err
+		}
+
+		if pvc == nil || pvc.Name != claimRef.Name {
+			continue
+		}
+
+		existingPVC := &corev1.PersistentVolumeClaim{}
+		err = r.Get(ctx, client.ObjectKeyFromObject(pvc), existingPVC)
+		if err == nil {
+			continue
+		}
+
+		if !k8serrors.IsNotFound(err) {
+			return err
+		}
+
+		logger.Info("Recovering PVC from retained PersistentVolume", "pvc", pvc.Name, "persistentVolume", volume.Name, "processGroupID", processGroup.ProcessGroupID)
+		pvc.ObjectMeta.OwnerReferences = internal.BuildOwnerReference(cluster.TypeMeta, cluster.ObjectMeta)
+		pvc.Spec.VolumeName = volume.Name
+		if pvc.Spec.StorageClassName == nil {
+			pvc.Spec.StorageClassName = pointer.String(volume.Spec.StorageClassName)
+		}
+
+		err = r.Create(ctx, pvc)
+		if err != nil {
+			return err
+		}
+
+		// The claim reference still contains the UID of the deleted PVC, the reference must be updated so the
+		// PersistentVolume can be bound to the new PVC.
+		volume.Spec.ClaimRef = &corev1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "PersistentVolumeClaim",
+			Namespace:  pvc.Namespace,
+			Name:       pvc.Name,
+		}
+
+		err = r.Update(ctx, volume)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getProcessGroupForPVCName returns the process group of the data PVC with the provided name, if the name matches the
+// PVC name of a stateful process group of the cluster.
+func getProcessGroupForPVCName(cluster *fdbv1beta2.FoundationDBCluster, name string) *fdbv1beta2.ProcessGroupStatus {
+	processClasses := map[fdbv1beta2.ProcessClass]fdbv1beta2.None{}
+	processCounts, err := cluster.GetProcessCountsWithDefaults()
+	if err == nil {
+		for processClass := range processCounts.Map() {
+			processClasses[processClass] = fdbv1beta2.None{}
+		}
+	}
+
+	for processClass := range cluster.Spec.Processes {
+		processClasses[processClass] = fdbv1beta2.None{}
+	}
+
+	for processClass := range processClasses {
+		if !processClass.IsStateful() {
+			continue
+		}
+
+		prefix := fmt.Sprintf("%s-%s-", cluster.Name, cluster.GetPodNamePrefix(processClass))
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+
+		idNum, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(name, prefix), "-data"))
+		if err != nil {
+			continue
+		}
+
+		_, processGroupID := cluster.GetProcessGroupID(processClass, idNum)
+
+		return fdbv1beta2.NewProcessGroupStatus(processGroupID, processClass, nil)
+	}
+
+	return nil
+}
@@ -0,0 +1,257 @@
+/*
+ * recover_process_groups_from_pvcs_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"k8s.io/utils/pointer"
+	ctrlClient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("recover_process_groups_from_pvcs", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var requeue *requeue
+
+	createPVC := func(processGroupID fdbv1beta2.ProcessGroupID, processClass fdbv1beta2.ProcessClass, claim string) {
+		pvc, err := internal.GetPvc(cluster, fdbv1beta2.NewProcessGroupStatus(processGroupID, processClass, nil))
+		Expect(err).NotTo(HaveOccurred())
+		if claim != "" {
+			if pvc.Annotations == nil {
+				pvc.Annotations = map[string]string{}
+			}
+			pvc.Annotations[fdbv1beta2.ClusterClaimAnnotation] = claim
+		}
+		Expect(k8sClient.Create(context.TODO(), pvc)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		cluster.Annotations = map[string]string{
+			fdbv1beta2.RecoverFromPVCsAnnotation: "true",
+		}
+		cluster.Spec.Routing.UseDNSInClusterFile = pointer.Bool(true)
+		cluster.Spec.SeedConnectionString = "operator_test:abcd@operator-test-1-storage-1.operator-test-1.my-ns.svc.cluster.local:4501,operator-test-1-storage-2.operator-test-1.my-ns.svc.cluster.local:4501,operator-test-1-log-1.operator-test-1.my-ns.svc.cluster.local:4501"
+		Expect(internal.NormalizeClusterSpec(cluster, internal.DeprecationOptions{})).To(Succeed())
+	})
+
+	JustBeforeEach(func() {
+		Expect(k8sClient.Create(context.TODO(), cluster)).To(Succeed())
+		requeue = recoverProcessGroupsFromPVCs{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)
+	})
+
+	When("PVCs of the cluster exist", func() {
+		BeforeEach(func() {
+			createPVC("storage-1", fdbv1beta2.ProcessClassStorage, "")
+			createPVC("storage-2", fdbv1beta2.ProcessClassStorage, "")
+			createPVC("log-1", fdbv1beta2.ProcessClassLog, "")
+		})
+
+		It("should recover the process groups", func() {
+			Expect(requeue).To(BeNil())
+			Expect(cluster.Status.Configured).To(BeFalse())
+			Expect(cluster.Status.ConnectionString).To(Equal(cluster.Spec.SeedConnectionString))
+
+			recovered := map[fdbv1beta2.ProcessGroupID]fdbv1beta2.ProcessClass{}
+			for _, processGroup := range cluster.Status.ProcessGroups {
+				recovered[processGroup.ProcessGroupID] = processGroup.ProcessClass
+				Expect(processGroup.GetConditionTime(fdbv1beta2.MissingPod)).NotTo(BeNil())
+			}
+
+			Expect(recovered).To(Equal(map[fdbv1beta2.ProcessGroupID]fdbv1beta2.ProcessClass{
+				"log-1":     fdbv1beta2.ProcessClassLog,
+				"storage-1": fdbv1beta2.ProcessClassStorage,
+				"storage-2": fdbv1beta2.ProcessClassStorage,
+			}))
+		})
+
+		It("should adopt the PVCs", func() {
+			pvcs := &corev1.PersistentVolumeClaimList{}
+			Expect(k8sClient.List(context.TODO(), pvcs, internal.GetPodListOptions(cluster, "", "")...)).To(Succeed())
+			Expect(pvcs.Items).To(HaveLen(3))
+			for _, pvc := range pvcs.Items {
+				Expect(cluster.GetResourceOwnership(pvc.ObjectMeta)).To(Equal(fdbv1beta2.ResourceOwned))
+				Expect(pvc.OwnerReferences).To(HaveLen(1))
+				Expect(pvc.OwnerReferences[0].UID).To(Equal(cluster.UID))
+			}
+		})
+
+		When("the seed connection string uses IP addresses", func() {
+			BeforeEach(func() {
+				cluster.Spec.SeedConnectionString = "operator_test:abcd@1.1.0.1:4501,1.1.0.2:4501,1.1.0.3:4501"
+			})
+
+			It("should not recover the process groups", func() {
+				Expect(requeue).NotTo(BeNil())
+				Expect(requeue.curError).To(MatchError("coordinator 1.1.0.1:4501 of the seed connection string uses an IP address, recovering the process groups from PVCs requires DNS names in the connection string, as the recreated Pods get new IP addresses"))
+				Expect(cluster.Status.ProcessGroups).To(BeEmpty())
+			})
+		})
+
+		When("DNS names are not used in the cluster file", func() {
+			BeforeEach(func() {
+				cluster.Spec.Routing.UseDNSInClusterFile = pointer.Bool(false)
+			})
+
+			It("should not recover the process groups", func() {
+				Expect(requeue).NotTo(BeNil())
+				Expect(requeue.curError).To(MatchError("recovering the process groups from PVCs requires spec.routing.useDNSInClusterFile to be enabled, as the recreated Pods get new IP addresses"))
+				Expect(cluster.Status.ProcessGroups).To(BeEmpty())
+			})
+		})
+
+		When("a PVC is claimed by a different cluster", func() {
+			BeforeEach(func() {
+				createPVC("storage-3", fdbv1beta2.ProcessClassStorage, "other-cluster")
+			})
+
+			It("should ignore the claimed PVC", func() {
+				Expect(requeue).To(BeNil())
+				Expect(cluster.Status.ProcessGroups).To(HaveLen(3))
+				for _, processGroup := range cluster.Status.ProcessGroups {
+					Expect(processGroup.ProcessGroupID).NotTo(Equal(fdbv1beta2.ProcessGroupID("storage-3")))
+				}
+			})
+		})
+
+		When("the annotation is missing", func() {
+			BeforeEach(func() {
+				cluster.Annotations = nil
+			})
+
+			It("should not recover the process groups", func() {
+				Expect(requeue).To(BeNil())
+				Expect(cluster.Status.ProcessGroups).To(BeEmpty())
+				Expect(cluster.Status.Configured).To(BeFalse())
+			})
+		})
+
+		When("the seed connection string is missing", func() {
+			BeforeEach(func() {
+				cluster.Spec.SeedConnectionString = ""
+			})
+
+			It("should not recover the process groups", func() {
+				Expect(requeue).NotTo(BeNil())
+				Expect(requeue.curError).To(MatchError("recovering the process groups from PVCs requires spec.seedConnectionString to be set to the connection string of the recovered cluster"))
+				Expect(cluster.Status.ProcessGroups).To(BeEmpty())
+			})
+		})
+
+		When("the cluster already has process groups", func() {
+			BeforeEach(func() {
+				cluster.Status.ProcessGroups = []*fdbv1beta2.ProcessGroupStatus{
+					fdbv1beta2.NewProcessGroupStatus("storage-1", fdbv1beta2.ProcessClassStorage, nil),
+				}
+			})
+
+			It("should wait for the recovered database and not change the process groups", func() {
+				Expect(requeue).NotTo(BeNil())
+				Expect(requeue.message).To(Equal("Waiting for the recovered database to become available"))
+				Expect(cluster.Status.ProcessGroups).To(HaveLen(1))
+				Expect(cluster.Status.Configured).To(BeFalse())
+			})
+		})
+	})
+
+	When("no PVCs of the cluster exist", func() {
+		It("should return an error", func() {
+			Expect(requeue).NotTo(BeNil())
+			Expect(requeue.curError).To(MatchError("found no PVCs to recover the process groups from"))
+		})
+	})
+
+	When("a retained PersistentVolume of the cluster exists", func() {
+		BeforeEach(func() {
+			volume := &corev1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pv-storage-1",
+				},
+				Spec: corev1.PersistentVolumeSpec{
+					StorageClassName: "local",
+					ClaimRef: &corev1.ObjectReference{
+						Namespace: cluster.Namespace,
+						Name:      fmt.Sprintf("%s-storage-1-data", cluster.Name),
+						UID:       "deleted",
+					},
+				},
+				Status: corev1.PersistentVolumeStatus{
+					Phase: corev1.VolumeReleased,
+				},
+			}
+			Expect(k8sClient.Create(context.TODO(), volume)).To(Succeed())
+		})
+
+		It("should recreate the PVC and recover the process group", func() {
+			Expect(requeue).To(BeNil())
+			Expect(cluster.Status.ProcessGroups).To(HaveLen(1))
+			Expect(cluster.Status.ProcessGroups[0].ProcessGroupID).To(Equal(fdbv1beta2.ProcessGroupID("storage-1")))
+
+			pvc := &corev1.PersistentVolumeClaim{}
+			Expect(k8sClient.Get(context.TODO(), ctrlClient.ObjectKey{Namespace: cluster.Namespace, Name: fmt.Sprintf("%s-storage-1-data", cluster.Name)}, pvc)).To(Succeed())
+			Expect(pvc.Spec.VolumeName).To(Equal("pv-storage-1"))
+			Expect(pvc.OwnerReferences).To(HaveLen(1))
+
+			volume := &corev1.PersistentVolume{}
+			Expect(k8sClient.Get(context.TODO(), ctrlClient.ObjectKey{Name: "pv-storage-1"}, volume)).To(Succeed())
+			Expect(volume.Spec.ClaimRef).NotTo(BeNil())
+			Expect(volume.Spec.ClaimRef.Name).To(Equal(pvc.Name))
+			Expect(volume.Spec.ClaimRef.UID).To(BeEmpty())
+		})
+	})
+
+	When("the process groups were recovered", func() {
+		var status *fdbv1beta2.FoundationDBStatus
+
+		BeforeEach(func() {
+			cluster.Status.ProcessGroups = []*fdbv1beta2.ProcessGroupStatus{
+				fdbv1beta2.NewProcessGroupStatus("storage-1", fdbv1beta2.ProcessClassStorage, nil),
+			}
+			status = &fdbv1beta2.FoundationDBStatus{}
+			status.Client.DatabaseStatus.Available = true
+			status.Cluster.ConnectionString = cluster.Spec.SeedConnectionString
+		})
+
+		It("should accept a database with the seed connection string", func() {
+			Expect(verifyRecoveredDatabase(cluster, status)).To(BeNil())
+		})
+
+		When("the database has a different connection string", func() {
+			BeforeEach(func() {
+				status.Cluster.ConnectionString = "operator_test:efgh@operator-test-1-storage-1.operator-test-1.my-ns.svc.cluster.local:4501"
+			})
+
+			It("should return an error", func() {
+				result := verifyRecoveredDatabase(cluster, status)
+				Expect(result).NotTo(BeNil())
+				Expect(result.curError).To(HaveOccurred())
+			})
+		})
+	})
+})
@@ -0,0 +1,120 @@
+/*
+ * release_scheduling_gates.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// releaseSchedulingGates provides a reconciliation step for releasing Pods that were created with the staged creation
+// scheduling gate in waves.
+type releaseSchedulingGates struct{}
+
+// reconcile runs the reconciler's work.
+func (c releaseSchedulingGates) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, _ *fdbv1beta2.FoundationDBStatus, logger logr.Logger) *requeue {
+	pods, err := r.PodLifecycleManager.GetPods(ctx, r, cluster, r.getPodListOptions(cluster, "", "")...)
+	if err != nil {
+		return &requeue{curError: err, delayedRequeue: true}
+	}
+
+	gatedPods := make([]*corev1.Pod, 0, len(pods))
+	pendingPods := 0
+	for _, pod := range pods {
+		if hasStagedCreationSchedulingGate(pod) {
+			gatedPods = append(gatedPods, pod)
+			continue
+		}
+
+		if pod.Spec.NodeName == "" && pod.DeletionTimestamp.IsZero() {
+			pendingPods++
+		}
+	}
+
+	if len(gatedPods) == 0 {
+		return nil
+	}
+
+	// If the staged creation was disabled, all Pods will be released at once.
+	releaseCount := len(gatedPods)
+	if cluster.UseStagedPodCreation() {
+		// Only release the next wave once all Pods of the previous wave are scheduled.
+		if pendingPods > 0 {
+			logger.Info("Waiting for Pods of the previous wave to be scheduled", "pendingPods", pendingPods, "gatedPods", len(gatedPods))
+			return &requeue{message: fmt.Sprintf("waiting for %d Pods to be scheduled", pendingPods), delayedRequeue: true, delay: 15 * time.Second}
+		}
+
+		releaseCount = cluster.GetStagedPodCreationMaxPodsPerWave()
+		if releaseCount > len(gatedPods) {
+			releaseCount = len(gatedPods)
+		}
+	}
+
+	sort.Slice(gatedPods, func(i, j int) bool {
+		return gatedPods[i].Name < gatedPods[j].Name
+	})
+
+	logger.Info("Releasing scheduling gates", "pods", releaseCount, "gatedPods", len(gatedPods))
+	for _, pod := range gatedPods[:releaseCount] {
+		removeStagedCreationSchedulingGate(pod)
+		err = r.Update(ctx, pod)
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+	}
+
+	if releaseCount < len(gatedPods) {
+		return &requeue{message: fmt.Sprintf("%d Pods are waiting to be released", len(gatedPods)-releaseCount), delayedRequeue: true, delay: 15 * time.Second}
+	}
+
+	return nil
+}
+
+// hasStagedCreationSchedulingGate returns true if the Pod has the staged creation scheduling gate.
+func hasStagedCreationSchedulingGate(pod *corev1.Pod) bool {
+	for _, gate := range pod.Spec.SchedulingGates {
+		if gate.Name == fdbv1beta2.StagedCreationSchedulingGate {
+			return true
+		}
+	}
+
+	return false
+}
+
+// removeStagedCreationSchedulingGate removes the staged creation scheduling gate from the Pod.
+func removeStagedCreationSchedulingGate(pod *corev1.Pod) {
+	gates := make([]corev1.PodSchedulingGate, 0, len(pod.Spec.SchedulingGates))
+	for _, gate := range pod.Spec.SchedulingGates {
+		if gate.Name == fdbv1beta2.StagedCreationSchedulingGate {
+			continue
+		}
+
+		gates = append(gates, gate)
+	}
+
+	pod.Spec.SchedulingGates = gates
+}
@@ -0,0 +1,128 @@
+/*
+ * release_scheduling_gates_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("release_scheduling_gates", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var req *requeue
+	var gatedPods int
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		Expect(k8sClient.Create(context.TODO(), cluster)).To(Succeed())
+
+		result, err := reconcileCluster(cluster)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Requeue).To(BeFalse())
+
+		_, err = reloadCluster(cluster)
+		Expect(err).NotTo(HaveOccurred())
+
+		cluster.Spec.AutomationOptions.StagedPodCreation = &fdbv1beta2.StagedPodCreationOptions{
+			Enabled:        pointer.Bool(true),
+			MaxPodsPerWave: pointer.Int(2),
+		}
+
+		pods := &corev1.PodList{}
+		Expect(k8sClient.List(context.TODO(), pods)).To(Succeed())
+
+		// Mark all Pods as scheduled and add the scheduling gate to the first 3 Pods.
+		for idx := range pods.Items {
+			pod := &pods.Items[idx]
+			pod.Spec.SchedulingGates = nil
+			pod.Spec.NodeName = "node-1"
+			if idx < 3 {
+				pod.Spec.SchedulingGates = []corev1.PodSchedulingGate{{Name: fdbv1beta2.StagedCreationSchedulingGate}}
+				pod.Spec.NodeName = ""
+			}
+
+			Expect(k8sClient.Update(context.TODO(), pod)).To(Succeed())
+		}
+	})
+
+	JustBeforeEach(func() {
+		req = releaseSchedulingGates{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)
+
+		pods := &corev1.PodList{}
+		Expect(k8sClient.List(context.TODO(), pods)).To(Succeed())
+
+		gatedPods = 0
+		for idx := range pods.Items {
+			if hasStagedCreationSchedulingGate(&pods.Items[idx]) {
+				gatedPods++
+			}
+		}
+	})
+
+	When("all previously released Pods are scheduled", func() {
+		It("should release the next wave", func() {
+			Expect(req).NotTo(BeNil())
+			Expect(req.delayedRequeue).To(BeTrue())
+			Expect(gatedPods).To(Equal(1))
+		})
+	})
+
+	When("a previously released Pod is not yet scheduled", func() {
+		BeforeEach(func() {
+			pods := &corev1.PodList{}
+			Expect(k8sClient.List(context.TODO(), pods)).To(Succeed())
+
+			for idx := range pods.Items {
+				pod := &pods.Items[idx]
+				if hasStagedCreationSchedulingGate(pod) {
+					continue
+				}
+
+				pod.Spec.NodeName = ""
+				Expect(k8sClient.Update(context.TODO(), pod)).To(Succeed())
+				break
+			}
+		})
+
+		It("should not release any Pods", func() {
+			Expect(req).NotTo(BeNil())
+			Expect(req.delayedRequeue).To(BeTrue())
+			Expect(gatedPods).To(Equal(3))
+		})
+	})
+
+	When("the staged Pod creation is disabled", func() {
+		BeforeEach(func() {
+			cluster.Spec.AutomationOptions.StagedPodCreation = nil
+		})
+
+		It("should release all Pods", func() {
+			Expect(req).To(BeNil())
+			Expect(gatedPods).To(BeZero())
+		})
+	})
+})
@@ -0,0 +1,134 @@
+/*
+ * reload_knobs.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+)
+
+// reloadKnobs provides a reconciliation step for applying changed knobs, that fdbserver can reload without a restart,
+// to the running processes.
+type reloadKnobs struct{}
+
+// reconcile runs the reconciler's work.
+func (reloadKnobs) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, _ *fdbv1beta2.FoundationDBStatus, logger logr.Logger) *requeue {
+	if !cluster.UseKnobHotReload() {
+		return nil
+	}
+
+	pendingProcessGroups := make([]*fdbv1beta2.ProcessGroupStatus, 0)
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if processGroup.GetConditionTime(fdbv1beta2.PendingKnobReload) == nil {
+			continue
+		}
+
+		pendingProcessGroups = append(pendingProcessGroups, processGroup)
+	}
+
+	if len(pendingProcessGroups) == 0 {
+		return nil
+	}
+
+	originalStatus := cluster.Status.DeepCopy()
+	knobs, uniform := getClusterWideReloadableKnobs(cluster)
+	if uniform {
+		changedKnobs := map[string]string{}
+		for knob, value := range knobs {
+			if reloadedValue, ok := cluster.Status.ReloadedKnobs[knob]; ok && reloadedValue == value {
+				continue
+			}
+
+			changedKnobs[knob] = value
+		}
+
+		if len(changedKnobs) > 0 {
+			adminClient, err := r.DatabaseClientProvider.GetAdminClient(cluster, r)
+			if err != nil {
+				return &requeue{curError: err, delayedRequeue: true}
+			}
+			defer adminClient.Close()
+
+			logger.Info("Reloading knobs", "knobs", changedKnobs)
+			err = adminClient.ReloadKnobs(changedKnobs)
+			if err != nil {
+				return &requeue{curError: err, delayedRequeue: true}
+			}
+
+			if cluster.Status.ReloadedKnobs == nil {
+				cluster.Status.ReloadedKnobs = map[string]string{}
+			}
+
+			for knob, value := range changedKnobs {
+				cluster.Status.ReloadedKnobs[knob] = value
+			}
+		}
+	} else {
+		// The knobs are applied to all processes, if the process classes use different values the processes must
+		// be restarted.
+		logger.Info("Reloadable knobs have different values for the process classes, processes will be restarted")
+	}
+
+	for _, processGroup := range pendingProcessGroups {
+		processGroup.UpdateCondition(fdbv1beta2.PendingKnobReload, false)
+		if !uniform {
+			processGroup.UpdateCondition(fdbv1beta2.IncorrectCommandLine, true)
+		}
+	}
+
+	if !equality.Semantic.DeepEqual(cluster.Status, *originalStatus) {
+		err := r.updateOrApply(ctx, cluster)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+	}
+
+	return nil
+}
+
+// getClusterWideReloadableKnobs returns the reloadable knobs of all process classes of the cluster. The returned bool
+// is false if a reloadable knob is not defined with the same value for all process classes.
+func getClusterWideReloadableKnobs(cluster *fdbv1beta2.FoundationDBCluster) (map[string]string, bool) {
+	processClasses := map[fdbv1beta2.ProcessClass]fdbv1beta2.None{}
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		processClasses[processGroup.ProcessClass] = fdbv1beta2.None{}
+	}
+
+	var knobs map[string]string
+	for processClass := range processClasses {
+		classKnobs := internal.GetReloadableKnobs(cluster, processClass)
+		if knobs == nil {
+			knobs = classKnobs
+			continue
+		}
+
+		if !equality.Semantic.DeepEqual(knobs, classKnobs) {
+			return nil, false
+		}
+	}
+
+	return knobs, true
+}
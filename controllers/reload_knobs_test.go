@@ -0,0 +1,123 @@
+/*
+ * reload_knobs_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient/mock"
+	"k8s.io/utils/pointer"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("reload_knobs", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var adminClient *mock.AdminClient
+	var pendingProcessGroups []*fdbv1beta2.ProcessGroupStatus
+	var requeue *requeue
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		Expect(setupClusterForTest(cluster)).NotTo(HaveOccurred())
+
+		var err error
+		adminClient, err = mock.NewMockAdminClientUncast(cluster, k8sClient)
+		Expect(err).NotTo(HaveOccurred())
+
+		cluster.Spec.Version = "7.1.25"
+		cluster.Spec.AutomationOptions.UseKnobHotReload = pointer.Bool(true)
+		generalSettings := cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral]
+		generalSettings.CustomParameters = fdbv1beta2.FoundationDBCustomParameters{"knob_min_trace_severity=20"}
+		cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral] = generalSettings
+
+		pendingProcessGroups = internal.PickProcessGroups(cluster, fdbv1beta2.ProcessClassStorage, 2)
+		for _, processGroup := range pendingProcessGroups {
+			processGroup.UpdateCondition(fdbv1beta2.PendingKnobReload, true)
+		}
+	})
+
+	JustBeforeEach(func() {
+		requeue = reloadKnobs{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)
+	})
+
+	When("the knob hot reload is disabled", func() {
+		BeforeEach(func() {
+			cluster.Spec.AutomationOptions.UseKnobHotReload = nil
+		})
+
+		It("should not reload any knobs", func() {
+			Expect(requeue).To(BeNil())
+			Expect(adminClient.ReloadedKnobs).To(BeEmpty())
+			Expect(cluster.Status.ReloadedKnobs).To(BeEmpty())
+			for _, processGroup := range pendingProcessGroups {
+				Expect(processGroup.GetConditionTime(fdbv1beta2.PendingKnobReload)).NotTo(BeNil())
+			}
+		})
+	})
+
+	When("the knob hot reload is enabled", func() {
+		It("should reload the knobs", func() {
+			Expect(requeue).To(BeNil())
+			Expect(adminClient.ReloadedKnobs).To(Equal(map[string]string{"knob_min_trace_severity": "20"}))
+			Expect(cluster.Status.ReloadedKnobs).To(Equal(map[string]string{"knob_min_trace_severity": "20"}))
+			for _, processGroup := range pendingProcessGroups {
+				Expect(processGroup.GetConditionTime(fdbv1beta2.PendingKnobReload)).To(BeNil())
+				Expect(processGroup.GetConditionTime(fdbv1beta2.IncorrectCommandLine)).To(BeNil())
+			}
+		})
+
+		When("the knobs were already reloaded", func() {
+			BeforeEach(func() {
+				cluster.Status.ReloadedKnobs = map[string]string{"knob_min_trace_severity": "20"}
+			})
+
+			It("should not reload the knobs again", func() {
+				Expect(requeue).To(BeNil())
+				Expect(adminClient.ReloadedKnobs).To(BeEmpty())
+				for _, processGroup := range pendingProcessGroups {
+					Expect(processGroup.GetConditionTime(fdbv1beta2.PendingKnobReload)).To(BeNil())
+				}
+			})
+		})
+
+		When("a process class uses a different value for a reloadable knob", func() {
+			BeforeEach(func() {
+				cluster.Spec.Processes[fdbv1beta2.ProcessClassStorage] = fdbv1beta2.ProcessSettings{
+					CustomParameters: fdbv1beta2.FoundationDBCustomParameters{"knob_min_trace_severity=10"},
+				}
+			})
+
+			It("should restart the processes instead of reloading the knobs", func() {
+				Expect(requeue).To(BeNil())
+				Expect(adminClient.ReloadedKnobs).To(BeEmpty())
+				Expect(cluster.Status.ReloadedKnobs).To(BeEmpty())
+				for _, processGroup := range pendingProcessGroups {
+					Expect(processGroup.GetConditionTime(fdbv1beta2.PendingKnobReload)).To(BeNil())
+					Expect(processGroup.GetConditionTime(fdbv1beta2.IncorrectCommandLine)).NotTo(BeNil())
+				}
+			})
+		})
+	})
+})
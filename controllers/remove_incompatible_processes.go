@@ -106,7 +106,7 @@ func processIncompatibleProcesses(ctx context.Context, r *FoundationDBClusterRec
 	}
 
 	// Do an unsafe update of the Pods since they are not reachable anyway
-	return r.PodLifecycleManager.UpdatePods(ctx, r, cluster, incompatiblePods, true)
+	return r.updatePods(ctx, logger, cluster, incompatiblePods, true)
 }
 
 // parseIncompatibleConnections parses the incompatible connections string slice to a map and removes all false reported incompatible processes.
@@ -29,9 +29,9 @@ import (
 	"time"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
-	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal/buggify"
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal/removals"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal/replacements"
 	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbstatus"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -66,6 +66,18 @@ func (u removeProcessGroups) reconcile(ctx context.Context, r *FoundationDBClust
 
 	coordinators := fdbstatus.GetCoordinatorsFromStatus(status)
 	allExcluded, newExclusions, processGroupsToRemove := r.getProcessGroupsToRemove(logger, cluster, remainingMap, coordinators)
+	// Healthy process groups can be kept as warm standbys instead of being removed.
+	processGroupsToRemove, standbyChanged := updateStandbyProcessGroups(logger, cluster, processGroupsToRemove)
+	if standbyChanged {
+		err = r.updateOrApply(ctx, cluster)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+
+		// The new exclusions are already persisted with the standby changes.
+		newExclusions = false
+	}
+
 	// If no process groups are marked to remove we have to check if all process groups are excluded.
 	if len(processGroupsToRemove) == 0 {
 		if !allExcluded {
@@ -89,6 +101,12 @@ func (u removeProcessGroups) reconcile(ctx context.Context, r *FoundationDBClust
 		return nil
 	}
 
+	// With the Surge replacement strategy the process groups are only removed once their replacements are ready.
+	processGroupsToRemove = replacements.FilterSurgeRemovals(logger, cluster, status, processGroupsToRemove)
+	if len(processGroupsToRemove) == 0 {
+		return &requeue{message: "Removals are waiting for the replacements to be ready", delayedRequeue: true}
+	}
+
 	// We don't use the "cached" of the cluster status from the CRD to minimize the window between data loss (e.g. a node
 	// or a set of Pods is not reachable anymore). We still end up with the risk to actually query the FDB cluster and after that
 	// query the cluster gets into a degraded state.
@@ -129,14 +147,75 @@ func (u removeProcessGroups) reconcile(ctx context.Context, r *FoundationDBClust
 
 	// This will return a map of the newly removed ProcessGroups and the ProcessGroups with the ResourcesTerminating condition
 	removedProcessGroups := r.removeProcessGroups(ctx, logger, cluster, zoneRemovals, zonedRemovals[removals.TerminatingZone])
+	var ghostProcessGroups []fdbv1beta2.ProcessGroupID
+	if cluster.ShouldVerifyProcessRemoval() && len(removedProcessGroups) > 0 {
+		// Fetch the latest status to make sure the processes of the removed process groups are not reporting anymore.
+		status, err = adminClient.GetStatus()
+		if err != nil {
+			return &requeue{curError: err}
+		}
+
+		ghostProcessGroups = verifyProcessGroupRemoval(logger, cluster, removedProcessGroups, status)
+	}
+
 	err = includeProcessGroup(ctx, logger, r, cluster, removedProcessGroups, status)
 	if err != nil {
 		return &requeue{curError: err, delayedRequeue: true}
 	}
 
+	if len(ghostProcessGroups) > 0 {
+		err = r.updateOrApply(ctx, cluster)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+
+		return &requeue{message: fmt.Sprintf("processes of removed process groups are still reporting: %v", ghostProcessGroups), delay: 30 * time.Second, delayedRequeue: true}
+	}
+
 	return nil
 }
 
+// verifyProcessGroupRemoval checks if processes of the removed process groups are still reporting to the database, e.g.
+// because the same data was copied and the fdbserver process was started somewhere else. Those process groups will get
+// the GhostProcess condition and will be removed from the removedProcessGroups map, so their exclusion is kept and
+// they are not removed from the cluster status.
+func verifyProcessGroupRemoval(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, removedProcessGroups map[fdbv1beta2.ProcessGroupID]bool, status *fdbv1beta2.FoundationDBStatus) []fdbv1beta2.ProcessGroupID {
+	reportingAddresses := map[string][]string{}
+	for _, process := range status.Cluster.Processes {
+		processGroupID, ok := process.Locality[fdbv1beta2.FDBLocalityInstanceIDKey]
+		if ok {
+			reportingAddresses[processGroupID] = append(reportingAddresses[processGroupID], process.Address.String())
+		}
+
+		if process.Address.IPAddress != nil {
+			reportingAddresses[process.Address.IPAddress.String()] = append(reportingAddresses[process.Address.IPAddress.String()], process.Address.String())
+		}
+	}
+
+	var ghostProcessGroups []fdbv1beta2.ProcessGroupID
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if !removedProcessGroups[processGroup.ProcessGroupID] {
+			continue
+		}
+
+		addresses := reportingAddresses[string(processGroup.ProcessGroupID)]
+		for _, address := range processGroup.Addresses {
+			addresses = append(addresses, reportingAddresses[address]...)
+		}
+
+		if len(addresses) == 0 {
+			continue
+		}
+
+		logger.Info("Processes of removed process group are still reporting", "processGroupID", processGroup.ProcessGroupID, "addresses", addresses)
+		processGroup.UpdateCondition(fdbv1beta2.GhostProcess, true)
+		delete(removedProcessGroups, processGroup.ProcessGroupID)
+		ghostProcessGroups = append(ghostProcessGroups, processGroup.ProcessGroupID)
+	}
+
+	return ghostProcessGroups
+}
+
 func removeProcessGroup(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus) error {
 	podName := processGroup.GetPodName(cluster)
 	var deletionError error
@@ -157,7 +236,7 @@ func removeProcessGroup(ctx context.Context, r *FoundationDBClusterReconciler, c
 		}
 
 		if pod.DeletionTimestamp.IsZero() {
-			err = r.PodLifecycleManager.DeletePod(ctx, r, pod)
+			err = r.deletePod(ctx, logr.FromContextOrDiscard(ctx), processGroup, pod)
 			if err != nil {
 				deletionError = fmt.Errorf("could not delete Pod: %w", err)
 			}
@@ -166,18 +245,27 @@ func removeProcessGroup(ctx context.Context, r *FoundationDBClusterReconciler, c
 
 	// TODO(johscheuer): https://github.com/FoundationDB/fdb-kubernetes-operator/issues/1638
 	pvcs := &corev1.PersistentVolumeClaimList{}
-	err = r.List(ctx, pvcs, internal.GetSinglePodListOptions(cluster, processGroup.ProcessGroupID)...)
+	err = r.List(ctx, pvcs, r.getPodListOptions(cluster, "", string(processGroup.ProcessGroupID))...)
 	if err != nil {
 		return err
 	}
-	if len(pvcs.Items) == 1 && pvcs.Items[0].DeletionTimestamp.IsZero() {
-		logr.FromContextOrDiscard(ctx).Info("Deleting pvc", "name", pvcs.Items[0].Name)
-		err = r.Delete(ctx, &pvcs.Items[0])
+	// A process group can have multiple PVCs if additional volume claims are defined.
+	for idx := range pvcs.Items {
+		if !pvcs.Items[idx].DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		if r.DisableDestructiveActions {
+			logr.FromContextOrDiscard(ctx).V(1).Info("PVC deletion requires a privileged user or controller", "name", pvcs.Items[idx].Name)
+			processGroup.UpdateCondition(fdbv1beta2.PVCDeletionRequired, true)
+			continue
+		}
+
+		logr.FromContextOrDiscard(ctx).Info("Deleting pvc", "name", pvcs.Items[idx].Name)
+		err = r.Delete(ctx, &pvcs.Items[idx])
 		if err != nil {
 			deletionError = errors.Join(deletionError, fmt.Errorf("could not delete PVC: %w", err))
 		}
-	} else if len(pvcs.Items) > 1 {
-		return fmt.Errorf("multiple PVCs found for cluster %s, processGroupID %s", cluster.Name, processGroup.ProcessGroupID)
 	}
 
 	service := &corev1.Service{}
@@ -219,21 +307,19 @@ func confirmRemoval(ctx context.Context, logger logr.Logger, r *FoundationDBClus
 
 	// TODO(johscheuer): https://github.com/FoundationDB/fdb-kubernetes-operator/issues/1638
 	pvcs := &corev1.PersistentVolumeClaimList{}
-	err = r.List(ctx, pvcs, internal.GetSinglePodListOptions(cluster, processGroup.ProcessGroupID)...)
+	err = r.List(ctx, pvcs, r.getPodListOptions(cluster, "", string(processGroup.ProcessGroupID))...)
 	if err != nil {
 		return false, canBeIncluded, err
 	}
 
-	if len(pvcs.Items) == 1 {
-		if pvcs.Items[0].DeletionTimestamp == nil {
-			logger.Info("Waiting for volume claim to get torn down", "processGroupID", processGroup.ProcessGroupID, "pvc", pvcs.Items[0].Name)
+	for _, pvc := range pvcs.Items {
+		if pvc.DeletionTimestamp == nil {
+			logger.Info("Waiting for volume claim to get torn down", "processGroupID", processGroup.ProcessGroupID, "pvc", pvc.Name)
 			return false, false, nil
 		}
 
 		// PVC is in terminating state so we don't want to block but we also don't want to include it
 		canBeIncluded = false
-	} else if len(pvcs.Items) > 1 {
-		return false, false, fmt.Errorf("multiple PVCs found for cluster %s, processGroupID %s", cluster.Name, processGroup.ProcessGroupID)
 	}
 
 	service := &corev1.Service{}
@@ -383,7 +469,7 @@ func (r *FoundationDBClusterReconciler) getProcessGroupsToRemove(logger logr.Log
 
 		excluded, err := processGroup.AllAddressesExcluded(logger, remainingMap)
 		if !excluded || err != nil {
-			logger.Info("Incomplete exclusion still present in removeProcessGroups step", "processGroupID", processGroup.ProcessGroupID, "error", err)
+			logger.Info("Incomplete exclusion still present in removeProcessGroups step", "processGroupID", processGroup.ProcessGroupID, "pendingProcesses", processGroup.GetPendingExclusions(), "error", err)
 			allExcluded = false
 			continue
 		}
@@ -397,6 +483,77 @@ func (r *FoundationDBClusterReconciler) getProcessGroupsToRemove(logger logr.Log
 	return allExcluded, newExclusions, processGroupsToRemove
 }
 
+// updateStandbyProcessGroups keeps healthy process groups as warm standbys, as long as the standby budget of the
+// cluster allows it. Standby process groups that are not healthy anymore or exceed the budget will be released. The
+// returned slice contains all process groups that should be removed and the bool reports if the status was changed.
+func updateStandbyProcessGroups(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, processGroupsToRemove []*fdbv1beta2.ProcessGroupStatus) ([]*fdbv1beta2.ProcessGroupStatus, bool) {
+	maxStandby := cluster.GetMaxStandbyProcessGroups()
+	remaining := make([]*fdbv1beta2.ProcessGroupStatus, 0, len(processGroupsToRemove))
+	candidates := make([]*fdbv1beta2.ProcessGroupStatus, 0, len(processGroupsToRemove))
+	standbyCount := 0
+	changed := false
+
+	// Existing standby process groups are preferred over new standby process groups.
+	for _, processGroup := range processGroupsToRemove {
+		if !processGroup.IsStandby() {
+			candidates = append(candidates, processGroup)
+			continue
+		}
+
+		if standbyCount < maxStandby && isStandbyEligible(cluster, processGroup) {
+			standbyCount++
+			continue
+		}
+
+		logger.Info("Releasing standby process group", "processGroupID", processGroup.ProcessGroupID)
+		processGroup.ReleaseStandby()
+		remaining = append(remaining, processGroup)
+		changed = true
+	}
+
+	for _, processGroup := range candidates {
+		if standbyCount < maxStandby && isStandbyEligible(cluster, processGroup) {
+			logger.Info("Keeping process group as standby", "processGroupID", processGroup.ProcessGroupID)
+			processGroup.MarkAsStandby()
+			standbyCount++
+			changed = true
+			continue
+		}
+
+		remaining = append(remaining, processGroup)
+	}
+
+	return remaining, changed
+}
+
+// isStandbyEligible returns true if the process group can be kept as a warm standby. Only healthy process groups that
+// were not explicitly requested to be removed and didn't fail are eligible.
+func isStandbyEligible(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus) bool {
+	if processGroup.ExclusionSkipped {
+		return false
+	}
+
+	for _, processGroupID := range cluster.Spec.ProcessGroupsToRemove {
+		if processGroupID == processGroup.ProcessGroupID {
+			return false
+		}
+	}
+
+	if processGroup.RemovalReason != nil && processGroup.RemovalReason.Type == fdbv1beta2.RemovalReasonProcessGroupFailed {
+		return false
+	}
+
+	// The processes of a standby process group are excluded, all other conditions indicate an unhealthy or outdated
+	// process group.
+	for _, condition := range processGroup.ProcessGroupConditions {
+		if condition.ProcessGroupConditionType != fdbv1beta2.ProcessIsMarkedAsExcluded {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (r *FoundationDBClusterReconciler) removeProcessGroups(ctx context.Context, logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, processGroupsToRemove []*fdbv1beta2.ProcessGroupStatus, terminatingProcessGroups []*fdbv1beta2.ProcessGroupStatus) map[fdbv1beta2.ProcessGroupID]bool {
 	processGroupNames := make([]fdbv1beta2.ProcessGroupID, len(processGroupsToRemove))
 	for i, processGroup := range processGroupsToRemove {
@@ -404,6 +561,12 @@ func (r *FoundationDBClusterReconciler) removeProcessGroups(ctx context.Context,
 	}
 
 	r.Recorder.Event(cluster, corev1.EventTypeNormal, "RemovingProcesses", fmt.Sprintf("Removing process groups: %v", processGroupNames))
+	if len(processGroupNames) > 0 {
+		err := r.recordActiveOperation(ctx, logger, cluster, fdbv1beta2.ActiveOperationTypeDeletion, "removeProcessGroups", processGroupNames)
+		if err != nil {
+			logger.Error(err, "Error during recording of the active operation")
+		}
+	}
 
 	processGroups := append(processGroupsToRemove, terminatingProcessGroups...)
 	for _, processGroup := range processGroups {
@@ -414,6 +577,13 @@ func (r *FoundationDBClusterReconciler) removeProcessGroups(ctx context.Context,
 		}
 	}
 
+	if r.DisableDestructiveActions {
+		err := r.reportRequiredDeletions(ctx, logger, cluster, processGroups)
+		if err != nil {
+			logger.Error(err, "Error during report of required deletions")
+		}
+	}
+
 	removedProcessGroups := make(map[fdbv1beta2.ProcessGroupID]bool)
 	// We have to check if the currently removed process groups are completely removed.
 	// In addition, we have to check if one of the terminating process groups has been cleaned up.
@@ -425,6 +595,12 @@ func (r *FoundationDBClusterReconciler) removeProcessGroups(ctx context.Context,
 		}
 
 		if removed {
+			firstRemoval := processGroup.RemovalPhases.GetTimestamp(fdbv1beta2.RemovalPhaseRemoved) == nil
+			processGroup.MarkAsRemoved()
+			if firstRemoval {
+				replacements.RecordCompletedReplacement(cluster, processGroup)
+			}
+			logger.Info("Process group removed", "processGroupID", processGroup.ProcessGroupID, "removalPhaseDurations", processGroup.GetRemovalPhaseDurations())
 			// Pods that are stuck in terminating shouldn't block reconciliation, but we also
 			// don't want to include them since they have an unknown state.
 			removedProcessGroups[processGroup.ProcessGroupID] = include
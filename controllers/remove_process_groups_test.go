@@ -23,6 +23,8 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"net"
+
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -156,6 +158,27 @@ var _ = Describe("remove_process_groups", func() {
 				})
 			})
 
+			When("destructive actions are disabled", func() {
+				BeforeEach(func() {
+					clusterReconciler.DisableDestructiveActions = true
+				})
+
+				AfterEach(func() {
+					clusterReconciler.DisableDestructiveActions = false
+				})
+
+				It("should not delete the Pod and the PVC and request the deletion", func() {
+					pod := &corev1.Pod{}
+					Expect(k8sClient.Get(context.Background(), ctrlClient.ObjectKey{Name: removedProcessGroup.GetPodName(cluster), Namespace: cluster.Namespace}, pod)).NotTo(HaveOccurred())
+					Expect(pod.DeletionTimestamp.IsZero()).To(BeTrue())
+
+					processGroup := fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, removedProcessGroup.ProcessGroupID)
+					Expect(processGroup).NotTo(BeNil())
+					Expect(processGroup.GetConditionTime(fdbv1beta2.PodDeletionRequired)).NotTo(BeNil())
+					Expect(processGroup.GetConditionTime(fdbv1beta2.PVCDeletionRequired)).NotTo(BeNil())
+				})
+			})
+
 			When("using the default setting of EnforceFullReplicationForDeletion", func() {
 				When("the cluster is fully replicated", func() {
 					It("should successfully remove that process group", func() {
@@ -672,4 +695,181 @@ var _ = Describe("remove_process_groups", func() {
 			})
 		})
 	})
+
+	When("verifying the removal of process groups", func() {
+		var removedProcessGroups map[fdbv1beta2.ProcessGroupID]bool
+		var status *fdbv1beta2.FoundationDBStatus
+		var ghostProcessGroups []fdbv1beta2.ProcessGroupID
+
+		BeforeEach(func() {
+			cluster = &fdbv1beta2.FoundationDBCluster{
+				Status: fdbv1beta2.FoundationDBClusterStatus{
+					ProcessGroups: []*fdbv1beta2.ProcessGroupStatus{
+						{ProcessGroupID: "storage-1", ProcessClass: "storage", Addresses: []string{"1.1.1.1"}},
+						{ProcessGroupID: "storage-2", ProcessClass: "storage", Addresses: []string{"1.1.1.2"}},
+						{ProcessGroupID: "storage-3", ProcessClass: "storage", Addresses: []string{"1.1.1.3"}},
+					},
+				},
+			}
+
+			removedProcessGroups = map[fdbv1beta2.ProcessGroupID]bool{
+				"storage-1": true,
+				"storage-2": true,
+			}
+
+			status = &fdbv1beta2.FoundationDBStatus{
+				Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+					Processes: map[fdbv1beta2.ProcessGroupID]fdbv1beta2.FoundationDBStatusProcessInfo{
+						"storage-3": {
+							Address: fdbv1beta2.ProcessAddress{IPAddress: net.ParseIP("1.1.1.3"), Port: 4501},
+							Locality: map[string]string{
+								fdbv1beta2.FDBLocalityInstanceIDKey: "storage-3",
+							},
+						},
+					},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			ghostProcessGroups = verifyProcessGroupRemoval(logr.Discard(), cluster, removedProcessGroups, status)
+		})
+
+		When("no processes of the removed process groups are reporting", func() {
+			It("should not report any ghost process groups", func() {
+				Expect(ghostProcessGroups).To(BeEmpty())
+				Expect(removedProcessGroups).To(HaveLen(2))
+				for _, processGroup := range cluster.Status.ProcessGroups {
+					Expect(processGroup.GetConditionTime(fdbv1beta2.GhostProcess)).To(BeNil())
+				}
+			})
+		})
+
+		When("a process with the locality of a removed process group is reporting from a different address", func() {
+			BeforeEach(func() {
+				status.Cluster.Processes["storage-1"] = fdbv1beta2.FoundationDBStatusProcessInfo{
+					Address: fdbv1beta2.ProcessAddress{IPAddress: net.ParseIP("1.1.2.1"), Port: 4501},
+					Locality: map[string]string{
+						fdbv1beta2.FDBLocalityInstanceIDKey: "storage-1",
+					},
+				}
+			})
+
+			It("should mark the process group with the GhostProcess condition", func() {
+				Expect(ghostProcessGroups).To(ConsistOf(fdbv1beta2.ProcessGroupID("storage-1")))
+				Expect(removedProcessGroups).To(HaveLen(1))
+				Expect(removedProcessGroups).NotTo(HaveKey(fdbv1beta2.ProcessGroupID("storage-1")))
+				Expect(cluster.Status.ProcessGroups[0].GetConditionTime(fdbv1beta2.GhostProcess)).NotTo(BeNil())
+			})
+		})
+
+		When("a process with a different locality is reporting from the address of a removed process group", func() {
+			BeforeEach(func() {
+				status.Cluster.Processes["storage-4"] = fdbv1beta2.FoundationDBStatusProcessInfo{
+					Address: fdbv1beta2.ProcessAddress{IPAddress: net.ParseIP("1.1.1.2"), Port: 4501},
+					Locality: map[string]string{
+						fdbv1beta2.FDBLocalityInstanceIDKey: "storage-4",
+					},
+				}
+			})
+
+			It("should mark the process group with the GhostProcess condition", func() {
+				Expect(ghostProcessGroups).To(ConsistOf(fdbv1beta2.ProcessGroupID("storage-2")))
+				Expect(removedProcessGroups).To(HaveLen(1))
+				Expect(cluster.Status.ProcessGroups[1].GetConditionTime(fdbv1beta2.GhostProcess)).NotTo(BeNil())
+			})
+		})
+	})
+
+	When("updating the standby process groups", func() {
+		var processGroupsToRemove, remaining []*fdbv1beta2.ProcessGroupStatus
+		var changed bool
+
+		BeforeEach(func() {
+			cluster = internal.CreateDefaultCluster()
+			processGroupsToRemove = []*fdbv1beta2.ProcessGroupStatus{
+				fdbv1beta2.NewProcessGroupStatus("storage-1", fdbv1beta2.ProcessClassStorage, nil),
+				fdbv1beta2.NewProcessGroupStatus("storage-2", fdbv1beta2.ProcessClassStorage, nil),
+				fdbv1beta2.NewProcessGroupStatus("stateless-1", fdbv1beta2.ProcessClassStateless, nil),
+			}
+
+			for _, processGroup := range processGroupsToRemove {
+				processGroup.MarkForRemoval()
+				processGroup.SetExclude()
+				processGroup.UpdateCondition(fdbv1beta2.ProcessIsMarkedAsExcluded, true)
+			}
+		})
+
+		JustBeforeEach(func() {
+			remaining, changed = updateStandbyProcessGroups(globalControllerLogger, cluster, processGroupsToRemove)
+		})
+
+		When("no standby budget is defined", func() {
+			It("should remove all process groups", func() {
+				Expect(changed).To(BeFalse())
+				Expect(remaining).To(Equal(processGroupsToRemove))
+			})
+		})
+
+		When("a standby budget is defined", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.Replacements.MaxStandbyProcessGroups = pointer.Int(1)
+			})
+
+			It("should keep the first process group as standby", func() {
+				Expect(changed).To(BeTrue())
+				Expect(processGroupsToRemove[0].IsStandby()).To(BeTrue())
+				Expect(remaining).To(ConsistOf(processGroupsToRemove[1], processGroupsToRemove[2]))
+			})
+
+			When("the first process group failed", func() {
+				BeforeEach(func() {
+					processGroupsToRemove[0].RemovalReason = &fdbv1beta2.RemovalReason{Type: fdbv1beta2.RemovalReasonProcessGroupFailed}
+				})
+
+				It("should keep the second process group as standby", func() {
+					Expect(changed).To(BeTrue())
+					Expect(processGroupsToRemove[1].IsStandby()).To(BeTrue())
+					Expect(remaining).To(ConsistOf(processGroupsToRemove[0], processGroupsToRemove[2]))
+				})
+			})
+
+			When("the first process group should be removed based on the spec", func() {
+				BeforeEach(func() {
+					cluster.Spec.ProcessGroupsToRemove = []fdbv1beta2.ProcessGroupID{"storage-1"}
+				})
+
+				It("should keep the second process group as standby", func() {
+					Expect(changed).To(BeTrue())
+					Expect(processGroupsToRemove[1].IsStandby()).To(BeTrue())
+					Expect(remaining).To(ConsistOf(processGroupsToRemove[0], processGroupsToRemove[2]))
+				})
+			})
+
+			When("the last process group is already a standby", func() {
+				BeforeEach(func() {
+					processGroupsToRemove[2].MarkAsStandby()
+				})
+
+				It("should keep the existing standby", func() {
+					Expect(changed).To(BeFalse())
+					Expect(processGroupsToRemove[2].IsStandby()).To(BeTrue())
+					Expect(remaining).To(ConsistOf(processGroupsToRemove[0], processGroupsToRemove[1]))
+				})
+
+				When("the standby process group is failing", func() {
+					BeforeEach(func() {
+						processGroupsToRemove[2].UpdateCondition(fdbv1beta2.MissingProcesses, true)
+					})
+
+					It("should release the standby and keep another process group", func() {
+						Expect(changed).To(BeTrue())
+						Expect(processGroupsToRemove[2].IsStandby()).To(BeFalse())
+						Expect(processGroupsToRemove[0].IsStandby()).To(BeTrue())
+						Expect(remaining).To(ConsistOf(processGroupsToRemove[1], processGroupsToRemove[2]))
+					})
+				})
+			})
+		})
+	})
 })
@@ -28,6 +28,7 @@ import (
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -37,12 +38,17 @@ type removeServices struct{}
 
 // reconcile runs the reconciler's work.
 func (u removeServices) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, _ *fdbv1beta2.FoundationDBStatus, logger logr.Logger) *requeue {
+	err := removeProcessClassServices(ctx, logger, cluster, r)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
 	if cluster.NeedsHeadlessService() {
 		return nil
 	}
 
 	existingService := &corev1.Service{}
-	err := r.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.Name}, existingService)
+	err = r.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.Name}, existingService)
 	if err != nil {
 		if k8serrors.IsNotFound(err) {
 			return nil
@@ -58,3 +64,32 @@ func (u removeServices) reconcile(ctx context.Context, r *FoundationDBClusterRec
 
 	return nil
 }
+
+// removeProcessClassServices deletes the headless Services of process classes that are not defined in
+// processClassHeadlessServices anymore.
+func removeProcessClassServices(ctx context.Context, logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, r *FoundationDBClusterReconciler) error {
+	desiredServices := make(map[string]fdbv1beta2.None, len(cluster.Spec.Routing.ProcessClassHeadlessServices))
+	for _, processClass := range cluster.Spec.Routing.ProcessClassHeadlessServices {
+		desiredServices[internal.GetProcessClassServiceName(cluster, processClass)] = fdbv1beta2.None{}
+	}
+
+	services := &corev1.ServiceList{}
+	err := r.List(ctx, services, client.InNamespace(cluster.Namespace), client.MatchingLabels(cluster.GetMatchLabels()), client.HasLabels{fdbv1beta2.ProcessClassServiceLabel})
+	if err != nil {
+		return err
+	}
+
+	for idx := range services.Items {
+		if _, ok := desiredServices[services.Items[idx].Name]; ok {
+			continue
+		}
+
+		logger.V(1).Info("Deleting service", "name", services.Items[idx].Name, "processClass", services.Items[idx].Labels[fdbv1beta2.ProcessClassServiceLabel])
+		err = r.Delete(ctx, &services.Items[idx])
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
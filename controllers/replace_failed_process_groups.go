@@ -44,6 +44,15 @@ func (c replaceFailedProcessGroups) reconcile(ctx context.Context, r *Foundation
 		return nil
 	}
 
+	if cluster.ReplacementsPaused() {
+		logger.Info("Skipping replaceFailedProcessGroups reconciler as replacements are paused")
+		return nil
+	}
+
+	if req := r.checkSafetyInterlock(ctx, logger, cluster, "replacements"); req != nil {
+		return req
+	}
+
 	// If the status is not cached, we have to fetch it.
 	if status == nil {
 		adminClient, err := r.DatabaseClientProvider.GetAdminClient(cluster, r)
@@ -67,10 +76,24 @@ func (c replaceFailedProcessGroups) reconcile(ctx context.Context, r *Foundation
 
 	// Only replace process groups without an address, if the cluster has the desired fault tolerance and is available.
 	hasDesiredFaultTolerance := fdbstatus.HasDesiredFaultToleranceFromStatus(logger, status, cluster)
-	hasReplacement, hasMoreFailedProcesses := replacements.ReplaceFailedProcessGroups(logger, cluster, status, hasDesiredFaultTolerance)
-	// If the reconciler replaced at least one process group we want to update the status and requeue.
-	if hasReplacement {
-		err := r.updateOrApply(ctx, cluster)
+	hasCanceledRemovals := replacements.CancelRecoveredReplacements(logger, cluster)
+	hasReplacementLoopChanges := replacements.UpdateReplacementLoops(logger, cluster)
+	protection, err := replacements.NewProtection(ctx, r, cluster, r.getPodListOptions(cluster, "", "")...)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	globalBudget, err := r.getGlobalReplacementBudget(logger, cluster)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	hasReplacement, hasMoreFailedProcesses := replacements.ReplaceFailedProcessGroups(logger, cluster, status, hasDesiredFaultTolerance, protection, globalBudget)
+	recordProtectedReplacements(r, cluster, protection)
+	// If the reconciler replaced at least one process group, canceled a removal, detected a replacement loop or changed
+	// the replacement approval state we want to update the status and requeue.
+	if hasReplacement || hasCanceledRemovals || hasReplacementLoopChanges {
+		err = r.updateOrApply(ctx, cluster)
 		if err != nil {
 			return &requeue{curError: err}
 		}
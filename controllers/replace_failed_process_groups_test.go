@@ -235,6 +235,20 @@ var _ = Describe("replace_failed_process_groups", func() {
 						}
 					})
 
+					When("replacements are paused", func() {
+						BeforeEach(func() {
+							cluster.Spec.AutomationOptions.Paused.Replacements = pointer.Bool(true)
+						})
+
+						It("should return nil", func() {
+							Expect(result).To(BeNil())
+						})
+
+						It("should not mark the process group for removal", func() {
+							Expect(getRemovedProcessGroupIDs(cluster)).To(ConsistOf([]fdbv1beta2.ProcessGroupID{}))
+						})
+					})
+
 					When("EmptyMonitorConf is set to true", func() {
 						BeforeEach(func() {
 							cluster.Spec.Buggify.EmptyMonitorConf = true
@@ -539,6 +553,39 @@ var _ = Describe("replace_failed_process_groups", func() {
 				})
 			})
 
+			Context("with a Pod that has been stuck for longer than the failure detection window", func() {
+				BeforeEach(func() {
+					processGroup.ProcessGroupConditions = append(processGroup.ProcessGroupConditions, &fdbv1beta2.ProcessGroupCondition{
+						ProcessGroupConditionType: fdbv1beta2.PodStuck,
+						Timestamp:                 time.Now().Add(-15 * time.Minute).Unix(),
+					})
+					cluster.Spec.AutomationOptions.Replacements.FailureDetectionWindowSeconds = pointer.Int(600)
+				})
+
+				It("should requeue", func() {
+					Expect(result).NotTo(BeNil())
+					Expect(result.message).To(Equal("Removals have been updated in the cluster status"))
+				})
+
+				It("should mark the process group for removal", func() {
+					Expect(getRemovedProcessGroupIDs(cluster)).To(ConsistOf([]fdbv1beta2.ProcessGroupID{processGroup.ProcessGroupID}))
+				})
+
+				When("the detection of stuck Pods is disabled", func() {
+					BeforeEach(func() {
+						cluster.Spec.AutomationOptions.Replacements.FailureDetectionWindowSeconds = nil
+					})
+
+					It("should return nil", func() {
+						Expect(result).To(BeNil())
+					})
+
+					It("should not mark the process group for removal", func() {
+						Expect(getRemovedProcessGroupIDs(cluster)).To(ConsistOf([]fdbv1beta2.ProcessGroupID{}))
+					})
+				})
+			})
+
 			Context("with a process that has had an incorrect pod spec for a long time", func() {
 				BeforeEach(func() {
 					processGroup.ProcessGroupConditions = append(processGroup.ProcessGroupConditions, &fdbv1beta2.ProcessGroupCondition{
@@ -22,6 +22,9 @@ package controllers
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 
@@ -38,19 +41,93 @@ import (
 type replaceMisconfiguredProcessGroups struct{}
 
 // reconcile runs the reconciler's work.
-func (c replaceMisconfiguredProcessGroups) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, _ *fdbv1beta2.FoundationDBStatus, logger logr.Logger) *requeue {
+func (c replaceMisconfiguredProcessGroups) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, logger logr.Logger) *requeue {
+	if cluster.ReplacementsPaused() {
+		logger.Info("Skipping replaceMisconfiguredProcessGroups reconciler as replacements are paused")
+		return nil
+	}
+
+	if req := r.checkSafetyInterlock(ctx, logger, cluster, "replacements"); req != nil {
+		return req
+	}
+
+	if req := r.checkOperatorUpgradeObservation(logger, cluster, "replacements"); req != nil {
+		return req
+	}
+
+	// In dry-run mode no process group will be marked for removal, so the replacements don't have to be deferred.
+	if cluster.ReplacementsRequireFullReplication() && !cluster.ReplacementsDryRun() {
+		if req := checkDatabaseReadyForReplacements(r, cluster, status, logger); req != nil {
+			return req
+		}
+	}
+
 	// TODO(johscheuer): Remove the pvc map an make direct calls.
 	pvcs := &corev1.PersistentVolumeClaimList{}
-	err := r.List(ctx, pvcs, internal.GetPodListOptions(cluster, "", "")...)
+	err := r.List(ctx, pvcs, r.getPodListOptions(cluster, "", "")...)
 	if err != nil {
 		return &requeue{curError: err}
 	}
 
-	hasReplacements, err := replacements.ReplaceMisconfiguredProcessGroups(ctx, r.PodLifecycleManager, r, logger, cluster, internal.CreatePVCMap(cluster, pvcs), r.ReplaceOnSecurityContextChange)
+	protection, err := replacements.NewProtection(ctx, r, cluster, r.getPodListOptions(cluster, "", "")...)
 	if err != nil {
 		return &requeue{curError: err}
 	}
 
+	globalBudget, err := r.getGlobalReplacementBudget(logger, cluster)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	markedForRemoval := make(map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None)
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if processGroup.IsMarkedForRemoval() {
+			markedForRemoval[processGroup.ProcessGroupID] = fdbv1beta2.None{}
+		}
+	}
+
+	maintenanceZone := getCurrentMaintenanceZone(r, cluster, status, logger)
+	hasReplacements, err := replacements.ReplaceMisconfiguredProcessGroups(ctx, r.PodLifecycleManager, r, logger, cluster, internal.CreatePVCMap(cluster, pvcs), internal.CreateAdditionalPVCMap(cluster, pvcs), r.ReplaceOnSecurityContextChange, replacements.ReplacementOptions{
+		MaxConcurrentChecks: r.MaxConcurrentProcessGroupChecks,
+		Protection:          protection,
+		GlobalBudget:        globalBudget,
+		MaintenanceZone:     maintenanceZone,
+		PodSpecComparators:  r.PodSpecComparators,
+	})
+	recordProtectedReplacements(r, cluster, protection)
+	if err != nil {
+		var massReplacementErr *replacements.MassReplacementError
+		if errors.As(err, &massReplacementErr) {
+			logger.Info("Blocking mass replacement of misconfigured process groups", "replacements", massReplacementErr.Replacements, "processGroups", massReplacementErr.ProcessGroups, "threshold", massReplacementErr.Threshold)
+			r.Recorder.Event(cluster, corev1.EventTypeWarning, "MassReplacementBlocked", err.Error())
+			return &requeue{message: err.Error(), delayedRequeue: true}
+		}
+
+		return &requeue{curError: err}
+	}
+
+	if hasReplacements && cluster.ReplacementsDryRun() {
+		pendingReplacements := make([]fdbv1beta2.ProcessGroupID, 0, len(cluster.Status.DryRunReplacements))
+		for _, replacement := range cluster.Status.DryRunReplacements {
+			pendingReplacements = append(pendingReplacements, replacement.ProcessGroupID)
+		}
+
+		r.Recorder.Event(cluster, corev1.EventTypeNormal, "DryRunReplacements", fmt.Sprintf("Misconfigured process groups that would be replaced: %v", pendingReplacements))
+	}
+
+	recordReplacementEvents(r, cluster, markedForRemoval)
+
+	deferredReplacements := make([]fdbv1beta2.ProcessGroupID, 0)
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if processGroup.GetConditionTime(fdbv1beta2.ReplacementDeferredByDisruptionBudget) != nil {
+			deferredReplacements = append(deferredReplacements, processGroup.ProcessGroupID)
+		}
+	}
+
+	if hasReplacements && len(deferredReplacements) > 0 {
+		r.Recorder.Event(cluster, corev1.EventTypeWarning, "ReplacementsDeferredByDisruptionBudget", fmt.Sprintf("Replacements of misconfigured process groups are deferred by PodDisruptionBudgets: %v", deferredReplacements))
+	}
+
 	if hasReplacements {
 		err = r.updateOrApply(ctx, cluster)
 		if err != nil {
@@ -60,5 +137,98 @@ func (c replaceMisconfiguredProcessGroups) reconcile(ctx context.Context, r *Fou
 		logger.Info("Removals have been updated in the cluster status")
 	}
 
+	// PodDisruptionBudgets are not watched by the operator, so the operator must check again if the deferred
+	// replacements are allowed.
+	if len(deferredReplacements) > 0 {
+		return &requeue{message: "Replacements are deferred by PodDisruptionBudgets", delayedRequeue: true}
+	}
+
 	return nil
 }
+
+// recordReplacementEvents emits an event with the removal reason for every process group that was marked for removal
+// by the replacement of misconfigured process groups. For changed Pod specs the removal reason contains the diff between
+// the desired and the live Pod spec, if the diff is short enough.
+func recordReplacementEvents(r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, markedForRemoval map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None) {
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if !processGroup.IsMarkedForRemoval() || processGroup.RemovalReason == nil {
+			continue
+		}
+
+		if _, ok := markedForRemoval[processGroup.ProcessGroupID]; ok {
+			continue
+		}
+
+		r.Recorder.Event(cluster, corev1.EventTypeNormal, "ReplacingMisconfiguredProcessGroup", fmt.Sprintf("Replacing process group %s, reason %s: %s", processGroup.ProcessGroupID, processGroup.RemovalReason.Type, processGroup.RemovalReason.Message))
+	}
+}
+
+// recordProtectedReplacements emits an event if the replacement of protected process groups was skipped.
+func recordProtectedReplacements(r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, protection *replacements.Protection) {
+	skipped := protection.Skipped()
+	if len(skipped) == 0 {
+		return
+	}
+
+	r.Recorder.Event(cluster, corev1.EventTypeNormal, "ReplacementProtected", fmt.Sprintf("Skipped replacements of protected process groups: %v", skipped))
+}
+
+// getCurrentMaintenanceZone returns the maintenance zone that is currently set in FoundationDB. If the machine-readable
+// status can't be fetched an empty zone will be returned, so replacements are not blocked when the database is
+// unavailable.
+func getCurrentMaintenanceZone(r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, logger logr.Logger) fdbv1beta2.FaultDomain {
+	// In dry-run mode no process group will be marked for removal, so the maintenance zone is not required.
+	if cluster.ReplacementsDryRun() {
+		return ""
+	}
+
+	if status == nil {
+		adminClient, err := r.DatabaseClientProvider.GetAdminClient(cluster, r)
+		if err != nil {
+			logger.Info("could not fetch the current maintenance zone", "error", err.Error())
+			return ""
+		}
+		defer adminClient.Close()
+
+		status, err = adminClient.GetStatus()
+		if err != nil {
+			logger.Info("could not fetch the current maintenance zone", "error", err.Error())
+			return ""
+		}
+	}
+
+	return status.Cluster.MaintenanceZone
+}
+
+// checkDatabaseReadyForReplacements returns a requeue if the database is unavailable or not fully replicated, in this
+// case the replacements of misconfigured process groups are deferred. If the status is not cached, it will be fetched.
+func checkDatabaseReadyForReplacements(r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, logger logr.Logger) *requeue {
+	if status == nil {
+		adminClient, err := r.DatabaseClientProvider.GetAdminClient(cluster, r)
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+		defer adminClient.Close()
+
+		status, err = adminClient.GetStatus()
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+	}
+
+	var message string
+	if !status.Client.DatabaseStatus.Available {
+		message = "Replacements of misconfigured process groups are deferred, the database is not available"
+	} else if !status.Cluster.FullReplication {
+		message = "Replacements of misconfigured process groups are deferred, the database is not fully replicated"
+	}
+
+	if message == "" {
+		return nil
+	}
+
+	logger.Info(message)
+	r.Recorder.Event(cluster, corev1.EventTypeWarning, "ReplacementDeferred", message)
+
+	return &requeue{message: message, delayedRequeue: true, delay: 30 * time.Second}
+}
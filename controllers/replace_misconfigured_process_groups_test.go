@@ -0,0 +1,85 @@
+/*
+ * replace_misconfigured_process_groups_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2021 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("replace_misconfigured_process_groups", func() {
+	When("checking if the database is ready for replacements", func() {
+		var cluster *fdbv1beta2.FoundationDBCluster
+		var status *fdbv1beta2.FoundationDBStatus
+		var req *requeue
+
+		BeforeEach(func() {
+			cluster = internal.CreateDefaultCluster()
+			status = &fdbv1beta2.FoundationDBStatus{
+				Client: fdbv1beta2.FoundationDBStatusLocalClientInfo{
+					DatabaseStatus: fdbv1beta2.FoundationDBStatusClientDBStatus{
+						Available: true,
+					},
+				},
+				Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+					FullReplication: true,
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			req = checkDatabaseReadyForReplacements(clusterReconciler, cluster, status, globalControllerLogger)
+		})
+
+		When("the database is available and fully replicated", func() {
+			It("should not defer the replacements", func() {
+				Expect(req).To(BeNil())
+			})
+		})
+
+		When("the database is not available", func() {
+			BeforeEach(func() {
+				status.Client.DatabaseStatus.Available = false
+			})
+
+			It("should defer the replacements", func() {
+				Expect(req).NotTo(BeNil())
+				Expect(req.delayedRequeue).To(BeTrue())
+				Expect(req.message).To(ContainSubstring("the database is not available"))
+			})
+		})
+
+		When("the database is not fully replicated", func() {
+			BeforeEach(func() {
+				status.Cluster.FullReplication = false
+			})
+
+			It("should defer the replacements", func() {
+				Expect(req).NotTo(BeNil())
+				Expect(req.delayedRequeue).To(BeTrue())
+				Expect(req.message).To(ContainSubstring("the database is not fully replicated"))
+			})
+		})
+	})
+})
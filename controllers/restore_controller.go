@@ -22,6 +22,10 @@ package controllers
 
 import (
 	"context"
+	"fmt"
+	"time"
+
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbstatus"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -113,6 +117,50 @@ func (r *FoundationDBRestoreReconciler) adminClientForRestore(ctx context.Contex
 	return adminClient, nil
 }
 
+// waitForHealthyCluster checks if the destination cluster is healthy before the operator starts the restore, if the
+// restore is configured to wait for a healthy cluster. If the cluster is not healthy, the WaitingForHealthyCluster
+// field in the status will be set and a requeue will be returned.
+func (r *FoundationDBRestoreReconciler) waitForHealthyCluster(ctx context.Context, restore *fdbv1beta2.FoundationDBRestore) *requeue {
+	if !restore.ShouldWaitForHealthyCluster() {
+		restore.Status.WaitingForHealthyCluster = false
+		return nil
+	}
+
+	logger := globalControllerLogger.WithValues("namespace", restore.Namespace, "restore", restore.Name)
+	cluster := &fdbv1beta2.FoundationDBCluster{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: restore.Namespace, Name: restore.Spec.DestinationClusterName}, cluster)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	adminClient, err := r.adminClientForRestore(ctx, restore)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+	defer adminClient.Close()
+
+	status, err := adminClient.GetStatus()
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	healthErr := fdbstatus.CheckClusterHealth(logger, status, cluster)
+	if healthErr == nil {
+		restore.Status.WaitingForHealthyCluster = false
+		return nil
+	}
+
+	if !restore.Status.WaitingForHealthyCluster {
+		restore.Status.WaitingForHealthyCluster = true
+		err = r.updateOrApply(ctx, restore)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+	}
+
+	return &requeue{message: fmt.Sprintf("waiting for healthy cluster: %s", healthErr.Error()), delay: time.Minute}
+}
+
 // SetupWithManager prepares a reconciler for use.
 func (r *FoundationDBRestoreReconciler) SetupWithManager(mgr ctrl.Manager, maxConcurrentReconciles int, selector metav1.LabelSelector) error {
 	labelSelectorPredicate, err := predicate.LabelSelectorPredicate(selector)
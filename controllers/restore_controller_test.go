@@ -30,6 +30,7 @@ import (
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
 )
 
 func reloadRestore(restore *fdbv1beta2.FoundationDBRestore) error {
@@ -113,4 +114,63 @@ var _ = Describe("restore_controller", func() {
 			})
 		})
 	})
+
+	When("waiting for a healthy cluster", func() {
+		BeforeEach(func() {
+			err = k8sClient.Create(context.TODO(), cluster)
+			Expect(err).NotTo(HaveOccurred())
+
+			result, err := reconcileCluster(cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Requeue).To(BeFalse())
+
+			_, err = reloadCluster(cluster)
+			Expect(err).NotTo(HaveOccurred())
+
+			restore.Spec.WaitForHealthyCluster = pointer.Bool(true)
+			err = k8sClient.Create(context.TODO(), restore)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		When("the cluster is unavailable", func() {
+			BeforeEach(func() {
+				Expect(adminClient.FreezeStatus()).To(Succeed())
+				adminClient.FrozenStatus.Client.DatabaseStatus.Available = false
+
+				result, err := reconcileRestore(restore)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Requeue).To(BeTrue())
+
+				Expect(reloadRestore(restore)).To(Succeed())
+			})
+
+			It("should not start a restore", func() {
+				status, err := adminClient.GetRestoreStatus()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(status).To(Equal("\n"))
+				Expect(restore.Status.Running).To(BeFalse())
+			})
+
+			It("should report that the operator is waiting for a healthy cluster", func() {
+				Expect(restore.Status.WaitingForHealthyCluster).To(BeTrue())
+			})
+
+			When("the cluster becomes healthy again", func() {
+				BeforeEach(func() {
+					adminClient.UnfreezeStatus()
+
+					result, err := reconcileRestore(restore)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.Requeue).To(BeFalse())
+
+					Expect(reloadRestore(restore)).To(Succeed())
+				})
+
+				It("should start a restore", func() {
+					Expect(restore.Status.Running).To(BeTrue())
+					Expect(restore.Status.WaitingForHealthyCluster).To(BeFalse())
+				})
+			})
+		})
+	})
 })
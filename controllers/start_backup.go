@@ -42,6 +42,11 @@ func (s startBackup) reconcile(ctx context.Context, r *FoundationDBBackupReconci
 	}
 	defer adminClient.Close()
 
+	waitRequeue := r.waitForHealthyCluster(ctx, backup)
+	if waitRequeue != nil {
+		return waitRequeue
+	}
+
 	err = adminClient.StartBackup(backup.BackupURL(), backup.SnapshotPeriodSeconds())
 	if err != nil {
 		return &requeue{curError: err}
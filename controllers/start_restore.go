@@ -45,6 +45,11 @@ func (s startRestore) reconcile(ctx context.Context, r *FoundationDBRestoreRecon
 	}
 
 	if len(strings.TrimSpace(status)) == 0 {
+		waitRequeue := r.waitForHealthyCluster(ctx, restore)
+		if waitRequeue != nil {
+			return waitRequeue
+		}
+
 		err = adminClient.StartRestore(restore.BackupURL(), restore.Spec.KeyRanges)
 		if err != nil {
 			return &requeue{curError: err}
@@ -77,7 +77,12 @@ var _ = BeforeSuite(func() {
 	Expect(fdbv1beta2.AddToScheme(scheme.Scheme)).NotTo(HaveOccurred())
 
 	// +kubebuilder:scaffold:scheme
-	k8sClient = mockclient.NewMockClient(scheme.Scheme)
+	fieldIndexes := internal.GetFieldIndexes()
+	mockIndexes := make([]mockclient.Index, 0, len(fieldIndexes))
+	for _, index := range fieldIndexes {
+		mockIndexes = append(mockIndexes, mockclient.Index{Object: index.Object, Field: index.Field, ExtractValue: index.ExtractValue})
+	}
+	k8sClient = mockclient.NewMockClientWithIndexes(scheme.Scheme, mockIndexes...)
 
 	clusterReconciler = createTestClusterReconciler()
 
@@ -190,5 +195,6 @@ func createTestClusterReconciler() *FoundationDBClusterReconciler {
 		DatabaseClientProvider:       mock.DatabaseClientProvider{},
 		MaintenanceListStaleDuration: 4 * time.Hour,
 		MaintenanceListWaitDuration:  5 * time.Minute,
+		fieldIndexesAvailable:        true,
 	}
 }
@@ -67,6 +67,11 @@ func (u updateBackupAgents) reconcile(ctx context.Context, r *FoundationDBBackup
 	}
 
 	if needCreation && deployment != nil {
+		waitRequeue := r.waitForHealthyCluster(ctx, backup)
+		if waitRequeue != nil {
+			return waitRequeue
+		}
+
 		logger.V(1).Info("Creating deployment", "name", deployment.Name)
 		err = r.Create(ctx, deployment)
 		if err != nil {
@@ -78,6 +83,11 @@ func (u updateBackupAgents) reconcile(ctx context.Context, r *FoundationDBBackup
 		deployment.ObjectMeta.Annotations = existingDeployment.ObjectMeta.Annotations
 
 		if annotationChange || !reflect.DeepEqual(existingDeployment.ObjectMeta.Labels, deployment.ObjectMeta.Labels) {
+			waitRequeue := r.waitForHealthyCluster(ctx, backup)
+			if waitRequeue != nil {
+				return waitRequeue
+			}
+
 			err = r.Update(ctx, deployment)
 			if err != nil {
 				return &requeue{curError: err}
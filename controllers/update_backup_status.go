@@ -101,11 +101,17 @@ func (s updateBackupStatus) reconcile(ctx context.Context, r *FoundationDBBackup
 
 	backup.Status = status
 
-	_, err = backup.CheckReconciliation()
+	reconciled, err := backup.CheckReconciliation()
 	if err != nil {
 		return &requeue{curError: err}
 	}
 
+	// Keep the information that the operator is waiting for a healthy cluster until the backup is reconciled, the
+	// value will be updated by the sub-reconcilers that are waiting for a healthy cluster.
+	if !reconciled {
+		backup.Status.WaitingForHealthyCluster = originalStatus.WaitingForHealthyCluster
+	}
+
 	if !equality.Semantic.DeepEqual(backup.Status, *originalStatus) {
 		err = r.updateOrApply(ctx, backup)
 		if err != nil {
@@ -0,0 +1,118 @@
+/*
+ * update_connection_secret.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/go-logr/logr"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// updateConnectionSecret provides a reconciliation step for publishing the connection details of the cluster in a
+// Secret.
+type updateConnectionSecret struct{}
+
+// reconcile runs the reconciler's work.
+func (updateConnectionSecret) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, _ *fdbv1beta2.FoundationDBStatus, logger logr.Logger) *requeue {
+	err := r.updateConnectionSecret(ctx, logger, cluster)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	return nil
+}
+
+// updateConnectionSecret creates or updates the Secret with the connection details of the cluster. If the Secret
+// should not be published anymore, the Secret will be deleted if it was created by the operator.
+func (r *FoundationDBClusterReconciler) updateConnectionSecret(ctx context.Context, logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster) error {
+	secret, err := internal.GetConnectionSecret(cluster)
+	if err != nil {
+		return err
+	}
+
+	existing := &corev1.Secret{}
+	err = r.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.GetConnectionSecretName()}, existing)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return err
+		}
+
+		if secret == nil {
+			return nil
+		}
+
+		logger.V(1).Info("Creating connection Secret", "name", secret.Name)
+		return r.Create(ctx, secret)
+	}
+
+	if secret == nil {
+		// The Secret is only removed if publishing was disabled, if the connection string is not yet known the current
+		// Secret is kept.
+		if cluster.PublishConnectionSecret() || !metav1.IsControlledBy(existing, cluster) {
+			return nil
+		}
+
+		logger.V(1).Info("Deleting connection Secret", "name", existing.Name)
+		err = r.Delete(ctx, existing)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+
+		return nil
+	}
+
+	needsUpdate := false
+	if !equality.Semantic.DeepEqual(existing.Data, secret.Data) {
+		existing.Data = secret.Data
+		needsUpdate = true
+	}
+
+	if existing.Labels == nil {
+		existing.Labels = map[string]string{}
+	}
+
+	if existing.Annotations == nil {
+		existing.Annotations = map[string]string{}
+	}
+
+	if mergeLabelsInMetadata(&existing.ObjectMeta, secret.ObjectMeta) {
+		needsUpdate = true
+	}
+
+	if mergeAnnotations(&existing.ObjectMeta, secret.ObjectMeta) {
+		needsUpdate = true
+	}
+
+	if !needsUpdate {
+		return nil
+	}
+
+	logger.Info("Updating connection Secret", "name", existing.Name, "connectionString", cluster.Status.ConnectionString)
+	return r.Update(ctx, existing)
+}
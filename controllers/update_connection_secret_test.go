@@ -0,0 +1,130 @@
+/*
+ * update_connection_secret_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	"k8s.io/utils/pointer"
+
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrlClient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("update_connection_secret", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var requeue *requeue
+	var secret *corev1.Secret
+	var getErr error
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		Expect(k8sClient.Create(context.TODO(), cluster)).NotTo(HaveOccurred())
+
+		result, err := reconcileCluster(cluster)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Requeue).To(BeFalse())
+
+		_, err = reloadCluster(cluster)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(internal.NormalizeClusterSpec(cluster, internal.DeprecationOptions{})).NotTo(HaveOccurred())
+	})
+
+	JustBeforeEach(func() {
+		requeue = updateConnectionSecret{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)
+
+		secret = &corev1.Secret{}
+		getErr = k8sClient.Get(context.TODO(), ctrlClient.ObjectKey{Namespace: cluster.Namespace, Name: cluster.GetConnectionSecretName()}, secret)
+	})
+
+	When("the connection Secret is not enabled", func() {
+		It("should not create the Secret", func() {
+			Expect(requeue).To(BeNil())
+			Expect(k8serrors.IsNotFound(getErr)).To(BeTrue())
+		})
+	})
+
+	When("the connection Secret is enabled", func() {
+		BeforeEach(func() {
+			cluster.Spec.ConnectionSecret.Enabled = pointer.Bool(true)
+			cluster.Spec.ConnectionSecret.Labels = map[string]string{"team": "storage"}
+		})
+
+		It("should create the Secret with the connection details", func() {
+			Expect(requeue).To(BeNil())
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(secret.Name).To(Equal(cluster.Name + "-connection"))
+			Expect(secret.Labels).To(HaveKeyWithValue("team", "storage"))
+			Expect(secret.Data).To(Equal(map[string][]byte{
+				fdbv1beta2.SchemaVersionKey:    []byte("v1"),
+				fdbv1beta2.ConnectionStringKey: []byte(cluster.Status.ConnectionString),
+				fdbv1beta2.ClusterFileKey:      []byte(cluster.Status.ConnectionString),
+				fdbv1beta2.RunningVersionKey:   []byte(cluster.GetRunningVersion()),
+				fdbv1beta2.APIVersionKey:       []byte("620"),
+				fdbv1beta2.TLSEnabledKey:       []byte("false"),
+			}))
+		})
+
+		When("the connection string changes", func() {
+			JustBeforeEach(func() {
+				cluster.Status.ConnectionString = "test:abcd@127.0.0.1:4501"
+				requeue = updateConnectionSecret{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)
+				Expect(k8sClient.Get(context.TODO(), ctrlClient.ObjectKeyFromObject(secret), secret)).NotTo(HaveOccurred())
+			})
+
+			It("should update the Secret", func() {
+				Expect(requeue).To(BeNil())
+				Expect(string(secret.Data[fdbv1beta2.ConnectionStringKey])).To(Equal("test:abcd@127.0.0.1:4501"))
+				Expect(string(secret.Data[fdbv1beta2.ClusterFileKey])).To(Equal("test:abcd@127.0.0.1:4501"))
+			})
+		})
+
+		When("trusted CAs are defined", func() {
+			BeforeEach(func() {
+				cluster.Spec.TrustedCAs = []string{"ca-1", "ca-2"}
+			})
+
+			It("should add the CA file", func() {
+				Expect(requeue).To(BeNil())
+				Expect(string(secret.Data[fdbv1beta2.CaFileKey])).To(Equal("ca-1\nca-2"))
+			})
+		})
+
+		When("the connection Secret is disabled again", func() {
+			JustBeforeEach(func() {
+				cluster.Spec.ConnectionSecret.Enabled = pointer.Bool(false)
+				requeue = updateConnectionSecret{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)
+				getErr = k8sClient.Get(context.TODO(), ctrlClient.ObjectKeyFromObject(secret), &corev1.Secret{})
+			})
+
+			It("should delete the Secret", func() {
+				Expect(requeue).To(BeNil())
+				Expect(k8serrors.IsNotFound(getErr)).To(BeTrue())
+			})
+		})
+	})
+})
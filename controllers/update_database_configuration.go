@@ -41,7 +41,7 @@ import (
 type updateDatabaseConfiguration struct{}
 
 // reconcile runs the reconciler's work.
-func (u updateDatabaseConfiguration) reconcile(_ context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, logger logr.Logger) *requeue {
+func (u updateDatabaseConfiguration) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, logger logr.Logger) *requeue {
 	if !pointer.BoolDeref(cluster.Spec.AutomationOptions.ConfigureDatabase, true) {
 		return nil
 	}
@@ -61,6 +61,13 @@ func (u updateDatabaseConfiguration) reconcile(_ context.Context, r *FoundationD
 	}
 
 	initialConfig := !cluster.Status.Configured
+	// A cluster that is recovered from PVCs was configured before, so the operator must not configure a new database.
+	// The cluster will be marked as configured once the recovered database is available.
+	if initialConfig && cluster.ShouldRecoverFromPVCs() {
+		logger.Info("Skipping initial database configuration because the cluster is recovered from PVCs")
+		return &requeue{message: "Waiting for the recovered database to become available", delayedRequeue: true}
+	}
+
 	if !initialConfig && !status.Client.DatabaseStatus.Available {
 		logger.Info("Skipping database configuration change because database is unavailable")
 		return &requeue{message: "cluster is not available", delayedRequeue: true, delay: 5 * time.Second}
@@ -88,7 +95,17 @@ func (u updateDatabaseConfiguration) reconcile(_ context.Context, r *FoundationD
 		}
 		configurationString, _ := nextConfiguration.GetConfigurationString(cluster.Spec.Version)
 
+		if !initialConfig && cluster.ConfigurationChangesPaused() {
+			r.Recorder.Event(cluster, corev1.EventTypeNormal, "NeedsConfigurationChange",
+				fmt.Sprintf("Spec require configuration change to `%s`, but configuration changes are paused", configurationString))
+			return &requeue{message: "Configuration changes are paused", delayedRequeue: true}
+		}
+
 		if !initialConfig {
+			if req := r.checkSafetyInterlock(ctx, logger, cluster, "configuration changes"); req != nil {
+				return req
+			}
+
 			err = fdbstatus.ConfigurationChangeAllowed(status, runningVersion.SupportsRecoveryState() && r.EnableRecoveryState)
 			if err != nil {
 				logger.Info("Changing current configuration is not safe", "error", err, "current configuration", currentConfiguration, "desired configuration", desiredConfiguration)
@@ -126,6 +143,11 @@ func (u updateDatabaseConfiguration) reconcile(_ context.Context, r *FoundationD
 			return &requeue{message: "Requeuing for fetching the initial configuration from FDB cluster", delay: 1 * time.Second}
 		}
 
+		err = r.recordActiveOperation(ctx, logger, cluster, fdbv1beta2.ActiveOperationTypeConfigurationChange, "updateDatabaseConfiguration", nil)
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+
 		logger.Info("Configured database", "initialConfig", initialConfig)
 		if !equality.Semantic.DeepEqual(nextConfiguration, desiredConfiguration) {
 			return &requeue{message: "Requeuing for next stage of database configuration change", delayedRequeue: true}
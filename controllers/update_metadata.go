@@ -22,6 +22,8 @@ package controllers
 
 import (
 	"context"
+	"fmt"
+
 	"github.com/go-logr/logr"
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
@@ -38,11 +40,12 @@ type updateMetadata struct{}
 func (updateMetadata) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, _ *fdbv1beta2.FoundationDBStatus, logger logr.Logger) *requeue {
 	// TODO(johscheuer): Remove the use of the pvc map and directly make a get request.
 	pvcs := &corev1.PersistentVolumeClaimList{}
-	err := r.List(ctx, pvcs, internal.GetPodListOptions(cluster, "", "")...)
+	err := r.List(ctx, pvcs, r.getPodListOptions(cluster, "", "")...)
 	if err != nil {
 		return &requeue{curError: err}
 	}
 	pvcMap := internal.CreatePVCMap(cluster, pvcs)
+	additionalPVCMap := internal.CreateAdditionalPVCMap(cluster, pvcs)
 
 	var shouldRequeue bool
 	for _, processGroup := range cluster.Status.ProcessGroups {
@@ -64,6 +67,13 @@ func (updateMetadata) reconcile(ctx context.Context, r *FoundationDBClusterRecon
 			continue
 		}
 
+		err = updateAdditionalPVCMetadata(ctx, r, cluster, processGroup, additionalPVCMap[processGroup.ProcessGroupID])
+		if err != nil {
+			logger.Error(err, "Could not update additional PVC metadata",
+				"processGroupID", processGroup.ProcessGroupID)
+			shouldRequeue = true
+		}
+
 		pvc, ok := pvcMap[processGroup.ProcessGroupID]
 		if !ok {
 			logger.V(1).Info("Could not find PVC for process group ID",
@@ -71,6 +81,14 @@ func (updateMetadata) reconcile(ctx context.Context, r *FoundationDBClusterRecon
 			continue
 		}
 
+		err = checkResourceOwnership(r, cluster, "PVC", pvc.ObjectMeta)
+		if err != nil {
+			logger.Error(err, "Could not update PVC metadata",
+				"processGroupID", processGroup.ProcessGroupID)
+			shouldRequeue = true
+			continue
+		}
+
 		metadata := internal.GetPvcMetadata(cluster, processGroup.ProcessClass, processGroup.ProcessGroupID)
 		if metadata.Annotations == nil {
 			metadata.Annotations = make(map[string]string, 1)
@@ -93,20 +111,83 @@ func (updateMetadata) reconcile(ctx context.Context, r *FoundationDBClusterRecon
 	return nil
 }
 
+// updateAdditionalPVCMetadata updates the metadata of the additional PVCs of the process group if required.
+func updateAdditionalPVCMetadata(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus, pvcs []corev1.PersistentVolumeClaim) error {
+	if len(pvcs) == 0 {
+		return nil
+	}
+
+	desiredPVCs, err := internal.GetAdditionalPvcs(cluster, processGroup)
+	if err != nil {
+		return err
+	}
+
+	desiredMetadata := make(map[string]metav1.ObjectMeta, len(desiredPVCs))
+	for _, desiredPVC := range desiredPVCs {
+		metadata := desiredPVC.ObjectMeta
+		// The spec hash is only updated when the PVC is recreated.
+		delete(metadata.Annotations, fdbv1beta2.LastSpecKey)
+		desiredMetadata[desiredPVC.Name] = metadata
+	}
+
+	for idx := range pvcs {
+		metadata, ok := desiredMetadata[pvcs[idx].Name]
+		if !ok || metadataCorrect(metadata, &pvcs[idx].ObjectMeta) {
+			continue
+		}
+
+		err = r.Update(ctx, &pvcs[idx])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func updatePodMetadata(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus) error {
 	pod, err := r.PodLifecycleManager.GetPod(ctx, r, cluster, processGroup.GetPodName(cluster))
 	if err != nil {
 		return err
 	}
 
+	err = checkResourceOwnership(r, cluster, "Pod", pod.ObjectMeta)
+	if err != nil {
+		return err
+	}
+
 	desiredMetadata := internal.GetPodMetadata(cluster, processGroup.ProcessClass, processGroup.ProcessGroupID, "")
-	if !podMetadataCorrect(desiredMetadata, pod) {
+	needsUpdate := !podMetadataCorrect(desiredMetadata, pod)
+
+	if cluster.MigratePodSpecHashes() {
+		migrated, err := internal.MigratePodSpecHash(cluster, processGroup, pod)
+		if err != nil {
+			return err
+		}
+
+		needsUpdate = needsUpdate || migrated
+	}
+
+	if needsUpdate {
 		return r.PodLifecycleManager.UpdateMetadata(ctx, r, cluster, pod)
 	}
 
 	return nil
 }
 
+// checkResourceOwnership returns an error if the resource is claimed by a different cluster. Updating the metadata of
+// such a resource would take over the claim, so the conflict must be resolved manually.
+func checkResourceOwnership(r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, kind string, metadata metav1.ObjectMeta) error {
+	if cluster.GetResourceOwnership(metadata) != fdbv1beta2.ResourceConflict {
+		return nil
+	}
+
+	err := fmt.Errorf("%s %s is claimed by a different cluster", kind, metadata.Name)
+	r.Recorder.Event(cluster, corev1.EventTypeWarning, "ResourceOwnershipConflict", err.Error())
+
+	return err
+}
+
 func podMetadataCorrect(desiredMetadata metav1.ObjectMeta, pod *corev1.Pod) bool {
 	if desiredMetadata.Annotations == nil {
 		desiredMetadata.Annotations = make(map[string]string, 1)
@@ -21,11 +21,17 @@
 package controllers
 
 import (
+	"context"
+
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"k8s.io/utils/pointer"
+
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlClient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var _ = Describe("Update metadata", func() {
@@ -330,4 +336,60 @@ var _ = Describe("Update metadata", func() {
 			},
 		),
 	)
+
+	When("migrating the Pod spec hashes", func() {
+		var cluster *fdbv1beta2.FoundationDBCluster
+		var pod *corev1.Pod
+		var legacyHash string
+		var currentHash string
+
+		BeforeEach(func() {
+			cluster = internal.CreateDefaultCluster()
+			Expect(k8sClient.Create(context.TODO(), cluster)).NotTo(HaveOccurred())
+
+			result, err := reconcileCluster(cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Requeue).To(BeFalse())
+
+			_, err = reloadCluster(cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(internal.NormalizeClusterSpec(cluster, internal.DeprecationOptions{})).NotTo(HaveOccurred())
+
+			processGroup := cluster.Status.ProcessGroups[0]
+			pod = &corev1.Pod{}
+			Expect(k8sClient.Get(context.TODO(), ctrlClient.ObjectKey{Namespace: cluster.Namespace, Name: processGroup.GetPodName(cluster)}, pod)).NotTo(HaveOccurred())
+
+			currentHash = pod.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey]
+			spec, err := internal.GetPodSpec(cluster, processGroup)
+			Expect(err).NotTo(HaveOccurred())
+			legacyHash, err = internal.GetJSONHash(spec)
+			Expect(err).NotTo(HaveOccurred())
+			pod.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey] = legacyHash
+			delete(pod.ObjectMeta.Annotations, fdbv1beta2.LastSpecHashVersionKey)
+			Expect(k8sClient.Update(context.TODO(), pod)).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			Expect(updateMetadata{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)).To(BeNil())
+			Expect(k8sClient.Get(context.TODO(), ctrlClient.ObjectKeyFromObject(pod), pod)).NotTo(HaveOccurred())
+		})
+
+		When("the migration is disabled", func() {
+			It("should keep the legacy hash", func() {
+				Expect(pod.ObjectMeta.Annotations).To(HaveKeyWithValue(fdbv1beta2.LastSpecKey, legacyHash))
+				Expect(pod.ObjectMeta.Annotations).NotTo(HaveKey(fdbv1beta2.LastSpecHashVersionKey))
+			})
+		})
+
+		When("the migration is enabled", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.MigratePodSpecHashes = pointer.Bool(true)
+			})
+
+			It("should rewrite the hash with the current version", func() {
+				Expect(pod.ObjectMeta.Annotations).To(HaveKeyWithValue(fdbv1beta2.LastSpecKey, currentHash))
+				Expect(pod.ObjectMeta.Annotations).To(HaveKeyWithValue(fdbv1beta2.LastSpecHashVersionKey, "2"))
+			})
+		})
+	})
 })
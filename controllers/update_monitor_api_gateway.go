@@ -0,0 +1,187 @@
+/*
+ * update_monitor_api_gateway.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/go-logr/logr"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// updateMonitorAPIGateway provides a reconciliation step for creating, updating and removing the Services, HTTPRoutes
+// and the Certificate that expose the API of the fdb-kubernetes-monitor through the Gateway API.
+type updateMonitorAPIGateway struct{}
+
+// reconcile runs the reconciler's work.
+func (u updateMonitorAPIGateway) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, _ *fdbv1beta2.FoundationDBStatus, logger logr.Logger) *requeue {
+	desiredNames := map[string]fdbv1beta2.None{}
+
+	if cluster.ExposeMonitorAPIThroughGateway() {
+		certificate, err := internal.GetMonitorAPICertificate(cluster)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+
+		if certificate != nil {
+			err = applyUnstructured(ctx, logger, r, certificate)
+			if err != nil {
+				return &requeue{curError: err, delayedRequeue: true}
+			}
+		}
+
+		for _, processGroup := range cluster.Status.ProcessGroups {
+			if processGroup.IsMarkedForRemoval() {
+				continue
+			}
+
+			service := internal.GetMonitorAPIService(cluster, processGroup)
+			desiredNames[service.Name] = fdbv1beta2.None{}
+
+			existingService := &corev1.Service{}
+			err = r.Get(ctx, client.ObjectKey{Namespace: service.Namespace, Name: service.Name}, existingService)
+			if err != nil {
+				if !k8serrors.IsNotFound(err) {
+					return &requeue{curError: err}
+				}
+
+				logger.V(1).Info("Creating monitor API service", "name", service.Name, "processGroupID", processGroup.ProcessGroupID)
+				err = r.Create(ctx, service)
+				if err != nil {
+					return &requeue{curError: err}
+				}
+			} else {
+				err = updateService(ctx, logger, cluster, r, existingService, service)
+				if err != nil {
+					return &requeue{curError: err}
+				}
+			}
+
+			route, err := internal.GetMonitorAPIRoute(cluster, processGroup)
+			if err != nil {
+				return &requeue{curError: err}
+			}
+
+			err = applyUnstructured(ctx, logger, r, route)
+			if err != nil {
+				return &requeue{curError: err, delayedRequeue: true}
+			}
+		}
+	}
+
+	// The Services and HTTPRoutes share the same name, so the HTTPRoutes can be deleted based on the Services without
+	// listing the HTTPRoutes, which would fail if the Gateway API is not installed.
+	services := &corev1.ServiceList{}
+	err := r.List(ctx, services, client.InNamespace(cluster.Namespace), client.MatchingLabels(cluster.GetMatchLabels()), client.HasLabels{fdbv1beta2.MonitorAPILabel})
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	for idx := range services.Items {
+		service := &services.Items[idx]
+		if _, ok := desiredNames[service.Name]; ok {
+			continue
+		}
+
+		if !metav1.IsControlledBy(service, cluster) {
+			continue
+		}
+
+		route := &unstructured.Unstructured{}
+		route.SetGroupVersionKind(internal.HTTPRouteGVK)
+		route.SetNamespace(service.Namespace)
+		route.SetName(service.Name)
+		err = deleteUnstructured(ctx, logger, r, route)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+
+		logger.V(1).Info("Deleting monitor API service", "name", service.Name)
+		err = r.Delete(ctx, service)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return &requeue{curError: err}
+		}
+	}
+
+	return nil
+}
+
+// applyUnstructured creates the provided object or updates the spec and the metadata of the existing object if the
+// hash of the spec in the LastSpecKey annotation differs.
+func applyUnstructured(ctx context.Context, logger logr.Logger, r *FoundationDBClusterReconciler, desired *unstructured.Unstructured) error {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(desired.GroupVersionKind())
+	err := r.Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return err
+		}
+
+		logger.V(1).Info("Creating object", "kind", desired.GetKind(), "name", desired.GetName())
+		return r.Create(ctx, desired)
+	}
+
+	if existing.GetAnnotations()[fdbv1beta2.LastSpecKey] == desired.GetAnnotations()[fdbv1beta2.LastSpecKey] {
+		return nil
+	}
+
+	existing.Object["spec"] = desired.Object["spec"]
+	annotations := existing.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	for key, value := range desired.GetAnnotations() {
+		annotations[key] = value
+	}
+	existing.SetAnnotations(annotations)
+
+	labels := existing.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	for key, value := range desired.GetLabels() {
+		labels[key] = value
+	}
+	existing.SetLabels(labels)
+
+	logger.Info("Updating object", "kind", desired.GetKind(), "name", desired.GetName())
+	return r.Update(ctx, existing)
+}
+
+// deleteUnstructured deletes the provided object. If the object or the API of the object doesn't exist, no error will
+// be returned.
+func deleteUnstructured(ctx context.Context, logger logr.Logger, r *FoundationDBClusterReconciler, object *unstructured.Unstructured) error {
+	logger.V(1).Info("Deleting object", "kind", object.GetKind(), "name", object.GetName())
+	err := r.Delete(ctx, object)
+	if err == nil || k8serrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		return nil
+	}
+
+	return err
+}
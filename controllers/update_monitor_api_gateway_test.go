@@ -0,0 +1,132 @@
+/*
+ * update_monitor_api_gateway_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	"k8s.io/utils/pointer"
+
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ctrlClient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("update_monitor_api_gateway", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var requeue *requeue
+	var services []corev1.Service
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		Expect(k8sClient.Create(context.TODO(), cluster)).NotTo(HaveOccurred())
+
+		result, err := reconcileCluster(cluster)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Requeue).To(BeFalse())
+
+		_, err = reloadCluster(cluster)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(internal.NormalizeClusterSpec(cluster, internal.DeprecationOptions{})).NotTo(HaveOccurred())
+	})
+
+	JustBeforeEach(func() {
+		requeue = updateMonitorAPIGateway{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)
+
+		serviceList := &corev1.ServiceList{}
+		Expect(k8sClient.List(context.TODO(), serviceList, ctrlClient.HasLabels{fdbv1beta2.MonitorAPILabel})).NotTo(HaveOccurred())
+		services = serviceList.Items
+	})
+
+	When("the monitor API gateway is not enabled", func() {
+		It("should not create any services", func() {
+			Expect(requeue).To(BeNil())
+			Expect(services).To(BeEmpty())
+		})
+	})
+
+	When("the monitor API gateway is enabled", func() {
+		var processGroup *fdbv1beta2.ProcessGroupStatus
+
+		BeforeEach(func() {
+			cluster.Spec.Routing.MonitorAPIGateway = &fdbv1beta2.MonitorAPIGatewayConfig{
+				Enabled: pointer.Bool(true),
+				ParentRefs: []fdbv1beta2.GatewayParentReference{
+					{
+						Name: "gateway",
+					},
+				},
+			}
+			processGroup = cluster.Status.ProcessGroups[0]
+		})
+
+		It("should create a service and a route for every process group", func() {
+			Expect(requeue).To(BeNil())
+			Expect(services).To(HaveLen(len(cluster.Status.ProcessGroups)))
+
+			route := &unstructured.Unstructured{}
+			route.SetGroupVersionKind(internal.HTTPRouteGVK)
+			Expect(k8sClient.Get(context.TODO(), ctrlClient.ObjectKey{Namespace: cluster.Namespace, Name: internal.GetMonitorAPIResourceName(cluster, processGroup)}, route)).To(Succeed())
+			Expect(route.GetAnnotations()).To(HaveKey(fdbv1beta2.LastSpecKey))
+		})
+
+		When("a process group is marked for removal", func() {
+			JustBeforeEach(func() {
+				processGroup.MarkForRemoval()
+				requeue = updateMonitorAPIGateway{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)
+			})
+
+			It("should delete the service and the route of the process group", func() {
+				Expect(requeue).To(BeNil())
+
+				serviceList := &corev1.ServiceList{}
+				Expect(k8sClient.List(context.TODO(), serviceList, ctrlClient.HasLabels{fdbv1beta2.MonitorAPILabel})).NotTo(HaveOccurred())
+				Expect(serviceList.Items).To(HaveLen(len(cluster.Status.ProcessGroups) - 1))
+
+				route := &unstructured.Unstructured{}
+				route.SetGroupVersionKind(internal.HTTPRouteGVK)
+				err := k8sClient.Get(context.TODO(), ctrlClient.ObjectKey{Namespace: cluster.Namespace, Name: internal.GetMonitorAPIResourceName(cluster, processGroup)}, route)
+				Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+
+		When("the monitor API gateway is disabled again", func() {
+			JustBeforeEach(func() {
+				cluster.Spec.Routing.MonitorAPIGateway.Enabled = pointer.Bool(false)
+				requeue = updateMonitorAPIGateway{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)
+			})
+
+			It("should delete all services", func() {
+				Expect(requeue).To(BeNil())
+
+				serviceList := &corev1.ServiceList{}
+				Expect(k8sClient.List(context.TODO(), serviceList, ctrlClient.HasLabels{fdbv1beta2.MonitorAPILabel})).NotTo(HaveOccurred())
+				Expect(serviceList.Items).To(BeEmpty())
+			})
+		})
+	})
+})
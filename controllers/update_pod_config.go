@@ -102,7 +102,10 @@ func (updatePodConfig) reconcile(ctx context.Context, r *FoundationDBClusterReco
 		// can restart fdbserver processes. Since the ConfigMap itself won't change during the upgrade we have to run the updatePodDynamicConf
 		// to make sure all process groups have the required files ready. In the future we will use a different condition to indicate that a
 		// process group si ready to be restarted.
-		if pod.ObjectMeta.Annotations[fdbv1beta2.LastConfigMapKey] == configMapHash && !cluster.IsBeingUpgradedWithVersionIncompatibleVersion() {
+		//
+		// The LastConfigMapKey annotation is set during the creation of the Pod, so we only skip the update once the
+		// sidecar or the fdb-kubernetes-monitor has confirmed that the configuration was loaded.
+		if pod.ObjectMeta.Annotations[fdbv1beta2.LastConfigMapKey] == configMapHash && processGroup.HasLoadedMonitorConf(pod.UID, configMapHash) && !cluster.IsBeingUpgradedWithVersionIncompatibleVersion() {
 			continue
 		}
 
@@ -142,9 +145,14 @@ func (updatePodConfig) reconcile(ctx context.Context, r *FoundationDBClusterReco
 				allSynced = false
 				curLogger.Error(err, "Update Pod metadata")
 				errs = append(errs, err)
+				continue
 			}
 		}
 
+		// The sidecar or the fdb-kubernetes-monitor has confirmed that the configuration was loaded, so the processes of
+		// this process group can be restarted.
+		processGroup.SetLoadedMonitorConf(pod.UID, configMapHash)
+		processGroup.UpdateCondition(fdbv1beta2.IncorrectConfigMap, false)
 		processGroup.UpdateCondition(fdbv1beta2.SidecarUnreachable, false)
 	}
 
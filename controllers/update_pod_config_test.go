@@ -63,6 +63,25 @@ var _ = Describe("updatePodConfig", func() {
 		})
 	})
 
+	When("the monitor conf hash of a process group was not confirmed", func() {
+		var processGroup *fdbv1beta2.ProcessGroupStatus
+		var configMapHash string
+
+		BeforeEach(func() {
+			processGroup = internal.PickProcessGroups(cluster, fdbv1beta2.ProcessClassStorage, 1)[0]
+			configMapHash = processGroup.MonitorConfHash
+			Expect(configMapHash).NotTo(BeEmpty())
+			processGroup.MonitorConfHash = ""
+			processGroup.UpdateCondition(fdbv1beta2.IncorrectConfigMap, true)
+		})
+
+		It("should record the confirmed hash", func() {
+			Expect(req).To(BeNil())
+			Expect(processGroup.MonitorConfHash).To(Equal(configMapHash))
+			Expect(processGroup.GetConditionTime(fdbv1beta2.IncorrectConfigMap)).To(BeNil())
+		})
+	})
+
 	When("a Pod is stuck in Pending", func() {
 		BeforeEach(func() {
 			pod.Status.Phase = corev1.PodPending
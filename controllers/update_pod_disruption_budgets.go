@@ -0,0 +1,132 @@
+/*
+ * update_pod_disruption_budgets.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/go-logr/logr"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// updatePodDisruptionBudgets provides a reconciliation step for creating, updating and removing the
+// PodDisruptionBudgets that are managed by the operator.
+type updatePodDisruptionBudgets struct{}
+
+// reconcile runs the reconciler's work.
+func (u updatePodDisruptionBudgets) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, _ *fdbv1beta2.FoundationDBStatus, logger logr.Logger) *requeue {
+	pdbs, err := internal.GetPodDisruptionBudgets(cluster)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	desiredNames := make(map[string]fdbv1beta2.None, len(pdbs))
+	for _, pdb := range pdbs {
+		desiredNames[pdb.Name] = fdbv1beta2.None{}
+
+		existingPdb := &policyv1.PodDisruptionBudget{}
+		err = r.Get(ctx, client.ObjectKey{Namespace: pdb.Namespace, Name: pdb.Name}, existingPdb)
+		if err != nil {
+			if !k8serrors.IsNotFound(err) {
+				return &requeue{curError: err}
+			}
+
+			logger.V(1).Info("Creating PodDisruptionBudget", "name", pdb.Name, "minAvailable", pdb.Spec.MinAvailable.String())
+			err = r.Create(ctx, pdb)
+			if err != nil {
+				return &requeue{curError: err}
+			}
+
+			continue
+		}
+
+		err = updatePodDisruptionBudget(ctx, logger, r, existingPdb, pdb)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+	}
+
+	existingPdbs := &policyv1.PodDisruptionBudgetList{}
+	err = r.List(ctx, existingPdbs, client.InNamespace(cluster.Namespace), client.MatchingLabels(cluster.GetMatchLabels()))
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	for idx := range existingPdbs.Items {
+		existingPdb := &existingPdbs.Items[idx]
+		if _, ok := desiredNames[existingPdb.Name]; ok {
+			continue
+		}
+
+		// Only remove PodDisruptionBudgets that were created by the operator for this cluster.
+		if !metav1.IsControlledBy(existingPdb, cluster) {
+			continue
+		}
+
+		logger.V(1).Info("Deleting PodDisruptionBudget", "name", existingPdb.Name)
+		err = r.Delete(ctx, existingPdb)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return &requeue{curError: err}
+		}
+	}
+
+	return nil
+}
+
+// updatePodDisruptionBudget updates the spec and the metadata of an existing PodDisruptionBudget if they differ from
+// the desired PodDisruptionBudget.
+func updatePodDisruptionBudget(ctx context.Context, logger logr.Logger, r *FoundationDBClusterReconciler, currentPdb *policyv1.PodDisruptionBudget, desiredPdb *policyv1.PodDisruptionBudget) error {
+	needsUpdate := false
+	if !equality.Semantic.DeepEqual(currentPdb.Spec, desiredPdb.Spec) {
+		currentPdb.Spec = desiredPdb.Spec
+		needsUpdate = true
+	}
+
+	if currentPdb.Labels == nil {
+		currentPdb.Labels = map[string]string{}
+	}
+
+	if currentPdb.Annotations == nil {
+		currentPdb.Annotations = map[string]string{}
+	}
+
+	if mergeLabelsInMetadata(&currentPdb.ObjectMeta, desiredPdb.ObjectMeta) {
+		needsUpdate = true
+	}
+
+	if mergeAnnotations(&currentPdb.ObjectMeta, desiredPdb.ObjectMeta) {
+		needsUpdate = true
+	}
+
+	if !needsUpdate {
+		return nil
+	}
+
+	logger.Info("Updating PodDisruptionBudget", "name", currentPdb.Name, "minAvailable", desiredPdb.Spec.MinAvailable.String())
+	return r.Update(ctx, currentPdb)
+}
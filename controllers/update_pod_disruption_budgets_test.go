@@ -0,0 +1,150 @@
+/*
+ * update_pod_disruption_budgets_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	"k8s.io/utils/pointer"
+
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlClient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("update_pod_disruption_budgets", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var requeue *requeue
+	var pdbs map[string]policyv1.PodDisruptionBudget
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		Expect(k8sClient.Create(context.TODO(), cluster)).NotTo(HaveOccurred())
+
+		result, err := reconcileCluster(cluster)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Requeue).To(BeFalse())
+
+		_, err = reloadCluster(cluster)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(internal.NormalizeClusterSpec(cluster, internal.DeprecationOptions{})).NotTo(HaveOccurred())
+	})
+
+	JustBeforeEach(func() {
+		requeue = updatePodDisruptionBudgets{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)
+
+		pdbList := &policyv1.PodDisruptionBudgetList{}
+		Expect(k8sClient.List(context.TODO(), pdbList)).NotTo(HaveOccurred())
+		pdbs = make(map[string]policyv1.PodDisruptionBudget, len(pdbList.Items))
+		for _, pdb := range pdbList.Items {
+			pdbs[pdb.Name] = pdb
+		}
+	})
+
+	When("the PodDisruptionBudgets are not enabled", func() {
+		It("should not create any PodDisruptionBudgets", func() {
+			Expect(requeue).To(BeNil())
+			Expect(pdbs).To(BeEmpty())
+		})
+	})
+
+	When("the PodDisruptionBudgets are enabled", func() {
+		var counts fdbv1beta2.ProcessCounts
+
+		BeforeEach(func() {
+			cluster.Spec.PodDisruptionBudgets.Enabled = pointer.Bool(true)
+
+			var err error
+			counts, err = cluster.GetProcessCountsWithDefaults()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should create a PodDisruptionBudget for every process class", func() {
+			Expect(requeue).To(BeNil())
+			Expect(pdbs).To(HaveLen(len(counts.Map())))
+
+			storagePdb, ok := pdbs[internal.GetPodDisruptionBudgetName(cluster, fdbv1beta2.ProcessClassStorage)]
+			Expect(ok).To(BeTrue())
+			Expect(storagePdb.Spec.MinAvailable.IntValue()).To(Equal(counts.Storage - cluster.DesiredFaultTolerance()))
+			Expect(storagePdb.Spec.Selector.MatchLabels).To(Equal(internal.GetPodMatchLabels(cluster, fdbv1beta2.ProcessClassStorage, "")))
+			Expect(metav1.IsControlledBy(&storagePdb, cluster)).To(BeTrue())
+
+			clusterControllerPdb, ok := pdbs[internal.GetPodDisruptionBudgetName(cluster, fdbv1beta2.ProcessClassClusterController)]
+			Expect(ok).To(BeTrue())
+			Expect(clusterControllerPdb.Name).To(Equal(cluster.Name + "-cluster-controller"))
+			Expect(clusterControllerPdb.Spec.MinAvailable.IntValue()).To(Equal(0))
+		})
+
+		When("the allowed unavailable Pods for the storage process class are changed", func() {
+			JustBeforeEach(func() {
+				cluster.Spec.PodDisruptionBudgets.MaxUnavailable = map[fdbv1beta2.ProcessClass]int{
+					fdbv1beta2.ProcessClassStorage: 2,
+				}
+				requeue = updatePodDisruptionBudgets{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)
+			})
+
+			It("should update the minAvailable of the PodDisruptionBudget", func() {
+				Expect(requeue).To(BeNil())
+
+				pdb := &policyv1.PodDisruptionBudget{}
+				Expect(k8sClient.Get(context.TODO(), ctrlClient.ObjectKey{Namespace: cluster.Namespace, Name: internal.GetPodDisruptionBudgetName(cluster, fdbv1beta2.ProcessClassStorage)}, pdb)).To(Succeed())
+				Expect(pdb.Spec.MinAvailable.IntValue()).To(Equal(counts.Storage - 2))
+			})
+		})
+
+		When("the PodDisruptionBudgets are disabled again", func() {
+			JustBeforeEach(func() {
+				cluster.Spec.PodDisruptionBudgets.Enabled = pointer.Bool(false)
+				requeue = updatePodDisruptionBudgets{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)
+			})
+
+			It("should delete the PodDisruptionBudgets", func() {
+				Expect(requeue).To(BeNil())
+
+				pdbList := &policyv1.PodDisruptionBudgetList{}
+				Expect(k8sClient.List(context.TODO(), pdbList)).NotTo(HaveOccurred())
+				Expect(pdbList.Items).To(BeEmpty())
+			})
+		})
+
+		When("a PodDisruptionBudget exists that is not managed by the operator", func() {
+			BeforeEach(func() {
+				Expect(k8sClient.Create(context.TODO(), &policyv1.PodDisruptionBudget{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "custom",
+						Namespace: cluster.Namespace,
+						Labels:    cluster.GetMatchLabels(),
+					},
+				})).To(Succeed())
+			})
+
+			It("should not delete the PodDisruptionBudget", func() {
+				Expect(requeue).To(BeNil())
+				Expect(pdbs).To(HaveKey("custom"))
+			})
+		})
+	})
+})
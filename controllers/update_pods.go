@@ -28,6 +28,7 @@ import (
 	"time"
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbstatus"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/podmanager"
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal/replacements"
 
@@ -35,6 +36,9 @@ import (
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // updatePods provides a reconciliation step for recreating pods with new pod
@@ -45,17 +49,95 @@ type updatePods struct{}
 func (updatePods) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, logger logr.Logger) *requeue {
 	// TODO(johscheuer): Remove the pvc map an make direct calls.
 	pvcs := &corev1.PersistentVolumeClaimList{}
-	err := r.List(ctx, pvcs, internal.GetPodListOptions(cluster, "", "")...)
+	err := r.List(ctx, pvcs, r.getPodListOptions(cluster, "", "")...)
 	if err != nil {
 		return &requeue{curError: err}
 	}
 
-	updates, err := getPodsToUpdate(ctx, logger, r, cluster, internal.CreatePVCMap(cluster, pvcs))
+	if cluster.UpdateImageRegistryInPlace() {
+		imageUpdates, err := getPodsWithImageRegistryChanges(ctx, logger, r, cluster)
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+
+		if len(imageUpdates) > 0 {
+			if cluster.PodUpdatesPaused() {
+				return &requeue{message: "Pod updates are paused", delayedRequeue: true}
+			}
+
+			if req := r.checkSafetyInterlock(ctx, logger, cluster, "Pod updates"); req != nil {
+				return req
+			}
+
+			if req := r.checkOperatorUpgradeObservation(logger, cluster, "Pod updates"); req != nil {
+				return req
+			}
+
+			for pod, images := range imageUpdates {
+				logger.Info("Update images of Pod in place", "pod", pod.Name, "images", images)
+				err = updateImagesInPlace(ctx, r, cluster, pod, images)
+				if err != nil {
+					return &requeue{curError: err, delayedRequeue: true}
+				}
+			}
+
+			return &requeue{message: "Images of Pods were updated in place", delayedRequeue: true, delay: podSchedulingDelayDuration}
+		}
+	}
+
+	if cluster.Spec.AutomationOptions.PodUpdateStrategy == fdbv1beta2.PodUpdateStrategyInPlaceResize {
+		pendingResizes, err := updatePendingResizes(ctx, logger, r, cluster)
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+
+		// Only start the next batch of resizes once all started resizes are applied.
+		if pendingResizes > 0 {
+			return &requeue{message: fmt.Sprintf("Waiting for the in-place resize of %d Pods", pendingResizes), delayedRequeue: true, delay: podSchedulingDelayDuration}
+		}
+
+		resizes, resources, err := getPodsWithResourceChanges(ctx, logger, r, cluster)
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+
+		if len(resizes) > 0 {
+			if cluster.PodUpdatesPaused() {
+				return &requeue{message: "Pod updates are paused", delayedRequeue: true}
+			}
+
+			if req := r.checkSafetyInterlock(ctx, logger, cluster, "Pod updates"); req != nil {
+				return req
+			}
+
+			if req := r.checkOperatorUpgradeObservation(logger, cluster, "Pod updates"); req != nil {
+				return req
+			}
+
+			return resizePodsInPlace(ctx, r, cluster, resizes, resources, logger, status)
+		}
+	}
+
+	updates, err := getPodsToUpdate(ctx, logger, r, cluster, internal.CreatePVCMap(cluster, pvcs), internal.CreateAdditionalPVCMap(cluster, pvcs))
 	if err != nil {
 		return &requeue{curError: err, delay: podSchedulingDelayDuration, delayedRequeue: true}
 	}
 
 	if len(updates) > 0 {
+		if cluster.PodUpdatesPaused() {
+			r.Recorder.Event(cluster, corev1.EventTypeNormal,
+				"NeedsPodsDeletion", "Spec require deleting some pods, but Pod updates are paused")
+			return &requeue{message: "Pod updates are paused", delayedRequeue: true}
+		}
+
+		if req := r.checkSafetyInterlock(ctx, logger, cluster, "Pod updates"); req != nil {
+			return req
+		}
+
+		if req := r.checkOperatorUpgradeObservation(logger, cluster, "Pod updates"); req != nil {
+			return req
+		}
+
 		if cluster.Spec.AutomationOptions.PodUpdateStrategy == fdbv1beta2.PodUpdateStrategyReplacement {
 			logger.Info("Requeuing reconciliation to replace pods")
 			return &requeue{message: "Requeueing reconciliation to replace pods"}
@@ -89,6 +171,382 @@ func (updatePods) reconcile(ctx context.Context, r *FoundationDBClusterReconcile
 	return deletePodsForUpdates(ctx, r, cluster, updates, logger, status, adminClient)
 }
 
+// getPodsWithImageRegistryChanges returns the Pods where only the registry of the container images has changed
+// together with the desired images per container name.
+func getPodsWithImageRegistryChanges(ctx context.Context, logger logr.Logger, reconciler *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster) (map[*corev1.Pod]map[string]string, error) {
+	imageUpdates := map[*corev1.Pod]map[string]string{}
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if processGroup.IsMarkedForRemoval() || cluster.SkipProcessGroup(processGroup) {
+			continue
+		}
+
+		pod, err := reconciler.PodLifecycleManager.GetPod(ctx, reconciler, cluster, processGroup.GetPodName(cluster))
+		if err != nil {
+			continue
+		}
+
+		if !pod.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		images, err := internal.GetImageRegistryChanges(cluster, processGroup, pod)
+		if err != nil {
+			logger.V(1).Info("Skip process group, error checking for image registry changes",
+				"processGroupID", processGroup.ProcessGroupID,
+				"error", err.Error())
+			continue
+		}
+
+		if len(images) == 0 {
+			continue
+		}
+
+		imageUpdates[pod] = images
+	}
+
+	return imageUpdates, nil
+}
+
+// updateImagesInPlace updates the images of the Pod containers and the spec hash annotation of the Pod.
+func updateImagesInPlace(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, pod *corev1.Pod, images map[string]string) error {
+	for idx, container := range pod.Spec.InitContainers {
+		if image, ok := images[container.Name]; ok {
+			pod.Spec.InitContainers[idx].Image = image
+		}
+	}
+
+	for idx, container := range pod.Spec.Containers {
+		if image, ok := images[container.Name]; ok {
+			pod.Spec.Containers[idx].Image = image
+		}
+	}
+
+	processGroupID := podmanager.GetProcessGroupID(cluster, pod)
+	specHash, err := internal.GetPodSpecHash(cluster, fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, processGroupID), nil)
+	if err != nil {
+		return err
+	}
+
+	internal.SetPodSpecHashAnnotations(pod, specHash)
+
+	return r.Update(ctx, pod)
+}
+
+// getPodsWithResourceChanges returns the Pods where only the CPU or memory resources of the containers have changed
+// together with the desired resources per container name. Only process groups whose process class should be resized in
+// getPodsWithResourceChanges returns the Pods where only the CPU or memory resources of the containers have changed,
+// grouped by their fault domain, together with the desired resources per container name for each Pod name.
+func getPodsWithResourceChanges(ctx context.Context, logger logr.Logger, reconciler *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster) (map[string][]*corev1.Pod, map[string]map[string]corev1.ResourceRequirements, error) {
+	resizes := map[string][]*corev1.Pod{}
+	resourceUpdates := map[string]map[string]corev1.ResourceRequirements{}
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if !cluster.ResizeInPlace(processGroup.ProcessClass) {
+			continue
+		}
+
+		if processGroup.IsMarkedForRemoval() || cluster.SkipProcessGroup(processGroup) {
+			continue
+		}
+
+		pod, err := reconciler.PodLifecycleManager.GetPod(ctx, reconciler, cluster, processGroup.GetPodName(cluster))
+		if err != nil {
+			continue
+		}
+
+		if !pod.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		resources, err := internal.GetResourceChanges(cluster, processGroup, pod)
+		if err != nil {
+			logger.V(1).Info("Skip process group, error checking for resource changes",
+				"processGroupID", processGroup.ProcessGroupID,
+				"error", err.Error())
+			continue
+		}
+
+		if len(resources) == 0 {
+			continue
+		}
+
+		// Process groups without a fault domain are resized one by one.
+		zone := string(processGroup.FaultDomain)
+		if zone == "" || reconciler.InSimulation {
+			zone = string(processGroup.ProcessGroupID)
+		}
+
+		resizes[zone] = append(resizes[zone], pod)
+		resourceUpdates[pod.Name] = resources
+	}
+
+	return resizes, resourceUpdates, nil
+}
+
+// resizePodsInPlace resizes the containers of the next batch of Pods in place. The batches are selected like the
+// batches of Pod deletions, based on the deletion mode of the cluster.
+func resizePodsInPlace(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, resizes map[string][]*corev1.Pod, resources map[string]map[string]corev1.ResourceRequirements, logger logr.Logger, status *fdbv1beta2.FoundationDBStatus) *requeue {
+	deletionMode := r.PodLifecycleManager.GetDeletionMode(cluster)
+	if deletionMode == fdbv1beta2.PodUpdateModeNone {
+		r.Recorder.Event(cluster, corev1.EventTypeNormal,
+			"NeedsPodsResize", "Spec require resizing some pods, but updating pods is disabled")
+		return &requeue{message: "Pod updates are disabled"}
+	}
+
+	adminClient, err := r.getDatabaseClientProvider().GetAdminClient(cluster, r.Client)
+	if err != nil {
+		return &requeue{curError: err, delayedRequeue: true}
+	}
+	defer adminClient.Close()
+
+	// If the status is not cached, we have to fetch it.
+	if status == nil {
+		status, err = adminClient.GetStatus()
+		if err != nil {
+			return &requeue{curError: err}
+		}
+	}
+
+	zone, batch, err := getPodsToDelete(cluster, deletionMode, resizes, string(status.Cluster.MaintenanceZone))
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	if len(batch) == 0 {
+		return &requeue{message: "Reconciliation requires resizing pods, but cannot resize any Pods", delay: podSchedulingDelayDuration}
+	}
+
+	ready, err := r.PodLifecycleManager.CanDeletePods(context.WithValue(logr.NewContext(ctx, logger), fdbstatus.StatusContextKey{}, status), adminClient, cluster)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+	if !ready {
+		return &requeue{message: "Reconciliation requires resizing pods, but resizing is currently not safe", delay: podSchedulingDelayDuration}
+	}
+
+	for _, pod := range batch {
+		logger.Info("Resize containers of Pod in place", "zone", zone, "pod", pod.Name, "resources", resources[pod.Name])
+		err = resizeContainersInPlace(ctx, r, cluster, pod, resources[pod.Name])
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+	}
+
+	r.Recorder.Event(cluster, corev1.EventTypeNormal, "ResizingPods", fmt.Sprintf("Resizing the containers of %d Pods in zone %s in place", len(batch), zone))
+
+	return &requeue{message: "Containers of Pods are resized in place", delayedRequeue: true, delay: podSchedulingDelayDuration}
+}
+
+// resizeContainersInPlace updates the resources of the Pod containers. The new spec hash is stored in the pending
+// resize annotation until Kubernetes has applied the resize, see updatePendingResizes.
+func resizeContainersInPlace(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, pod *corev1.Pod, resources map[string]corev1.ResourceRequirements) error {
+	for idx, container := range pod.Spec.Containers {
+		if containerResources, ok := resources[container.Name]; ok {
+			pod.Spec.Containers[idx].Resources = containerResources
+		}
+	}
+
+	processGroupID := podmanager.GetProcessGroupID(cluster, pod)
+	specHash, err := internal.GetPodSpecHash(cluster, fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, processGroupID), nil)
+	if err != nil {
+		return err
+	}
+
+	if pod.ObjectMeta.Annotations == nil {
+		pod.ObjectMeta.Annotations = map[string]string{}
+	}
+	pod.ObjectMeta.Annotations[fdbv1beta2.PendingResizeSpecKey] = specHash
+
+	return r.Update(ctx, pod)
+}
+
+// updatePendingResizes checks the in-place resizes that were started by the operator. Pods where the resize was
+// applied get the spec hash of the resize as their current spec hash. The method returns the number of Pods where the
+// resize is still pending.
+func updatePendingResizes(ctx context.Context, logger logr.Logger, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster) (int, error) {
+	var pendingResizes int
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		pod, err := r.PodLifecycleManager.GetPod(ctx, r, cluster, processGroup.GetPodName(cluster))
+		if err != nil {
+			continue
+		}
+
+		specHash, ok := pod.ObjectMeta.Annotations[fdbv1beta2.PendingResizeSpecKey]
+		if !ok {
+			continue
+		}
+
+		state, message, err := getPodResizeState(ctx, r, pod)
+		if err != nil {
+			return pendingResizes, err
+		}
+
+		if state == "" {
+			logger.Info("In-place resize of Pod was applied", "processGroupID", processGroup.ProcessGroupID, "pod", pod.Name)
+			delete(pod.ObjectMeta.Annotations, fdbv1beta2.PendingResizeSpecKey)
+			internal.SetPodSpecHashAnnotations(pod, specHash)
+			err = r.Update(ctx, pod)
+			if err != nil {
+				return pendingResizes, err
+			}
+
+			continue
+		}
+
+		logger.Info("In-place resize of Pod is pending", "processGroupID", processGroup.ProcessGroupID, "pod", pod.Name, "state", state, "message", message)
+		if state == podResizeStateInfeasible || state == podResizeStateDeferred {
+			r.Recorder.Event(cluster, corev1.EventTypeWarning, "ResizePending", fmt.Sprintf("In-place resize of Pod %s is %s: %s", pod.Name, state, message))
+		}
+
+		pendingResizes++
+	}
+
+	return pendingResizes, nil
+}
+
+const (
+	// podResizeStateDeferred is reported if the resize is possible but cannot be applied right now.
+	podResizeStateDeferred = "Deferred"
+	// podResizeStateInfeasible is reported if the node cannot apply the resize.
+	podResizeStateInfeasible = "Infeasible"
+	// podResizeStateInProgress is reported if the resize is accepted and the kubelet is applying it.
+	podResizeStateInProgress = "InProgress"
+	// podResizePendingCondition is the Pod condition that Kubernetes 1.33+ uses to report a resize that was not
+	// accepted yet.
+	podResizePendingCondition = "PodResizePending"
+	// podResizeInProgressCondition is the Pod condition that Kubernetes 1.33+ uses to report a resize that is
+	// being applied.
+	podResizeInProgressCondition = "PodResizeInProgress"
+)
+
+// podResizeStatus contains the fields of the Pod status that report the state of an in-place resize and that are not
+// part of the typed Pod API that we use.
+type podResizeStatus struct {
+	// Resize is used by Kubernetes versions before 1.33 to report the state of the resize.
+	Resize string `json:"resize,omitempty"`
+	// ContainerStatuses contains the resources that are applied to the containers.
+	ContainerStatuses []struct {
+		Name      string                       `json:"name"`
+		Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+	} `json:"containerStatuses,omitempty"`
+}
+
+// getPodResizeState returns the state of the in-place resize of the provided Pod and the message reported by Kubernetes.
+// An empty state means that the resources of the Pod spec are applied to the containers. Kubernetes 1.33 and newer
+// report the state with Pod conditions, older versions with the status.resize field. Until the kubelet reports the
+// state, the resources in the container statuses are compared with the resources of the Pod spec.
+func getPodResizeState(ctx context.Context, r client.Reader, pod *corev1.Pod) (string, string, error) {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+
+		if condition.Type == podResizePendingCondition {
+			return condition.Reason, condition.Message, nil
+		}
+
+		if condition.Type == podResizeInProgressCondition {
+			return podResizeStateInProgress, condition.Message, nil
+		}
+	}
+
+	unstructuredPod := &unstructured.Unstructured{}
+	unstructuredPod.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Pod"))
+	err := r.Get(ctx, client.ObjectKeyFromObject(pod), unstructuredPod)
+	if err != nil {
+		return "", "", err
+	}
+
+	rawStatus, _, err := unstructured.NestedMap(unstructuredPod.Object, "status")
+	if err != nil {
+		return "", "", err
+	}
+
+	status := &podResizeStatus{}
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(rawStatus, status)
+	if err != nil {
+		return "", "", err
+	}
+
+	if status.Resize != "" {
+		return status.Resize, "", nil
+	}
+
+	desiredResources := make(map[string]corev1.ResourceRequirements, len(pod.Spec.Containers))
+	for _, container := range pod.Spec.Containers {
+		desiredResources[container.Name] = container.Resources
+	}
+
+	for _, containerStatus := range status.ContainerStatuses {
+		desired, ok := desiredResources[containerStatus.Name]
+		if !ok || containerStatus.Resources == nil {
+			continue
+		}
+
+		if !cpuAndMemoryEqual(containerStatus.Resources.Requests, desired.Requests) || !cpuAndMemoryEqual(containerStatus.Resources.Limits, desired.Limits) {
+			return podResizeStateInProgress, fmt.Sprintf("resources of container %s are not yet applied", containerStatus.Name), nil
+		}
+	}
+
+	return "", "", nil
+}
+
+// cpuAndMemoryEqual returns true if the CPU and memory quantities of both resource lists are equal.
+func cpuAndMemoryEqual(current corev1.ResourceList, desired corev1.ResourceList) bool {
+	for _, resourceName := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+		currentQuantity, currentOk := current[resourceName]
+		desiredQuantity, desiredOk := desired[resourceName]
+		if currentOk != desiredOk {
+			return false
+		}
+
+		if currentOk && currentQuantity.Cmp(desiredQuantity) != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// pinCoordinatorIPs marks the process groups of the provided coordinator Pods to use a pinned public IP. The returned
+// bool reports if any process group was changed.
+func pinCoordinatorIPs(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, pods []*corev1.Pod) (bool, error) {
+	connectionString, err := fdbv1beta2.ParseConnectionString(cluster.Status.ConnectionString)
+	if err != nil {
+		return false, err
+	}
+
+	coordinators := make(map[string]fdbv1beta2.None, len(connectionString.Coordinators))
+	for _, coordinator := range connectionString.Coordinators {
+		address, err := fdbv1beta2.ParseProcessAddress(coordinator)
+		if err != nil {
+			return false, err
+		}
+
+		coordinators[address.MachineAddress()] = fdbv1beta2.None{}
+	}
+
+	changed := false
+	for _, pod := range pods {
+		processGroup := fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, internal.GetProcessGroupIDFromMeta(cluster, pod.ObjectMeta))
+		if processGroup == nil || processGroup.PublicIPPinned {
+			continue
+		}
+
+		for _, address := range processGroup.Addresses {
+			if _, ok := coordinators[address]; !ok {
+				continue
+			}
+
+			logger.Info("Pinning public IP of coordinator", "processGroupID", processGroup.ProcessGroupID, "address", address)
+			processGroup.PublicIPPinned = true
+			changed = true
+			break
+		}
+	}
+
+	return changed, nil
+}
+
 // processGroupIsUnavailable returns true if the process group is unavailable.
 func processGroupIsUnavailable(processGroupStatus *fdbv1beta2.ProcessGroupStatus) bool {
 	// If the Process Group has Pods is pending state, we count it as unavailable.
@@ -137,7 +595,7 @@ func getFaultDomainsWithUnavailablePods(ctx context.Context, logger logr.Logger,
 }
 
 // getPodsToUpdate returns a map of Zone to Pods mapping. The map has the fault domain as key and all Pods in that fault domain will be present as a slice of *corev1.Pod.
-func getPodsToUpdate(ctx context.Context, logger logr.Logger, reconciler *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, pvcMap map[fdbv1beta2.ProcessGroupID]corev1.PersistentVolumeClaim) (map[string][]*corev1.Pod, error) {
+func getPodsToUpdate(ctx context.Context, logger logr.Logger, reconciler *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, pvcMap map[fdbv1beta2.ProcessGroupID]corev1.PersistentVolumeClaim, additionalPVCMap map[fdbv1beta2.ProcessGroupID][]corev1.PersistentVolumeClaim) (map[string][]*corev1.Pod, error) {
 	updates := make(map[string][]*corev1.Pod)
 
 	faultDomainsWithUnavailablePods := getFaultDomainsWithUnavailablePods(ctx, logger, reconciler, cluster)
@@ -178,15 +636,19 @@ func getPodsToUpdate(ctx context.Context, logger logr.Logger, reconciler *Founda
 			continue
 		}
 
-		if cluster.NeedsReplacement(processGroup) {
-			logger.V(1).Info("Skip process group for deletion, requires a replacement",
-				"processGroupID", processGroup.ProcessGroupID)
-			continue
-		}
+		// Process groups that were reassigned to a different process class will be updated by deleting the Pod, even
+		// if the PodUpdateStrategy would require a replacement.
+		needsReplacement := cluster.NeedsReplacement(processGroup) && processGroup.GetConditionTime(fdbv1beta2.ProcessClassReassignment) == nil
 
 		pod, err := reconciler.PodLifecycleManager.GetPod(ctx, reconciler, cluster, processGroup.GetPodName(cluster))
 		// If a Pod is not found ignore it for now.
 		if err != nil {
+			if needsReplacement {
+				logger.V(1).Info("Skip process group for deletion, requires a replacement",
+					"processGroupID", processGroup.ProcessGroupID)
+				continue
+			}
+
 			logger.V(1).Info("Could not find Pod for process group ID",
 				"processGroupID", processGroup.ProcessGroupID)
 
@@ -206,11 +668,36 @@ func getPodsToUpdate(ctx context.Context, logger logr.Logger, reconciler *Founda
 			continue
 		}
 
+		if cluster.SkipProcessGroupForImageTypeMigration(processGroup, internal.GetImageType(pod)) {
+			logger.V(1).Info("Skip process group for update, the process group is not part of the fault domain that is currently migrated to a different image type",
+				"processGroupID", processGroup.ProcessGroupID,
+				"faultDomain", processGroup.FaultDomain)
+			continue
+		}
+
+		// Changes of the RuntimeClass will be rolled out by recreating the Pod, even if the PodUpdateStrategy would
+		// require a replacement.
+		if needsReplacement {
+			runtimeClassNameChanged, err := replacements.RuntimeClassNameChanged(cluster, processGroup, pod)
+			if err != nil {
+				logger.Info("Skipping Pod due to error checking the runtimeClassName",
+					"processGroupID", processGroup.ProcessGroupID,
+					"error", err.Error())
+				continue
+			}
+
+			if !runtimeClassNameChanged {
+				logger.V(1).Info("Skip process group for deletion, requires a replacement",
+					"processGroupID", processGroup.ProcessGroupID)
+				continue
+			}
+		}
+
 		if shouldRequeueDueToTerminatingPod(pod, cluster, processGroup.ProcessGroupID) {
 			return nil, fmt.Errorf("cluster has Pod %s that is pending deletion", pod.Name)
 		}
 
-		specHash, err := internal.GetPodSpecHash(cluster, processGroup, nil)
+		specHash, err := internal.GetMatchingPodSpecHash(cluster, processGroup, nil, pod.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey])
 		if err != nil {
 			logger.Info("Skipping Pod due to error generating spec hash",
 				"processGroupID", processGroup.ProcessGroupID,
@@ -223,7 +710,7 @@ func getPodsToUpdate(ctx context.Context, logger logr.Logger, reconciler *Founda
 			continue
 		}
 
-		needsRemoval, err := replacements.ProcessGroupNeedsRemoval(ctx, reconciler.PodLifecycleManager, reconciler, logger, cluster, processGroup, pvcMap, reconciler.ReplaceOnSecurityContextChange)
+		removalReason, err := replacements.ProcessGroupNeedsRemoval(ctx, reconciler.PodLifecycleManager, reconciler, logger, cluster, processGroup, pvcMap, additionalPVCMap, reconciler.ReplaceOnSecurityContextChange, reconciler.PodSpecComparators...)
 		// Do not update the Pod if unable to determine if it needs to be removed.
 		if err != nil {
 			logger.V(1).Info("Skip process group, error checking if it requires a removal",
@@ -231,9 +718,10 @@ func getPodsToUpdate(ctx context.Context, logger logr.Logger, reconciler *Founda
 				"error", err.Error())
 			continue
 		}
-		if needsRemoval {
+		if removalReason != nil {
 			logger.V(1).Info("Skip process group for deletion, requires a removal",
-				"processGroupID", processGroup.ProcessGroupID)
+				"processGroupID", processGroup.ProcessGroupID,
+				"reason", removalReason.Message)
 			continue
 		}
 
@@ -432,10 +920,35 @@ func deletePodsForUpdates(ctx context.Context, r *FoundationDBClusterReconciler,
 	logger.Info("Deleting pods", "zone", zone, "count", len(deletions), "deletionMode", string(cluster.Spec.AutomationOptions.DeletionMode))
 	r.Recorder.Event(cluster, corev1.EventTypeNormal, "UpdatingPods", fmt.Sprintf("Recreating pods in zone %s", zone))
 
-	err = r.PodLifecycleManager.UpdatePods(logr.NewContext(ctx, logger), r, cluster, deletions, false)
+	err = r.updatePods(ctx, logger, cluster, deletions, false)
 	if err != nil {
 		return &requeue{curError: err}
 	}
 
+	processGroupIDs := make([]fdbv1beta2.ProcessGroupID, 0, len(deletions))
+	for _, pod := range deletions {
+		processGroupIDs = append(processGroupIDs, internal.GetProcessGroupIDFromMeta(cluster, pod.ObjectMeta))
+	}
+
+	err = r.recordActiveOperation(ctx, logger, cluster, fdbv1beta2.ActiveOperationTypeDeletion, "updatePods", processGroupIDs)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	// Pin the public IPs of the recreated coordinators, this must happen before the Pods are created again.
+	if cluster.PinCoordinatorIPs() {
+		pinned, err := pinCoordinatorIPs(logger, cluster, deletions)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+
+		if pinned {
+			err = r.updateOrApply(ctx, cluster)
+			if err != nil {
+				return &requeue{curError: err}
+			}
+		}
+	}
+
 	return &requeue{message: "Pods need to be recreated", delayedRequeue: true}
 }
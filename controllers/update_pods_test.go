@@ -238,6 +238,52 @@ var _ = Describe("update_pods", func() {
 		})
 	})
 
+	When("pinning the public IPs of coordinators", func() {
+		var cluster *fdbv1beta2.FoundationDBCluster
+		var pods []*corev1.Pod
+		var changed bool
+
+		BeforeEach(func() {
+			cluster = internal.CreateDefaultCluster()
+			cluster.Spec.Routing.PinCoordinatorIPs = pointer.Bool(true)
+			cluster.Status.ConnectionString = "test:abcd@1.1.1.1:4501,1.1.1.2:4501,1.1.1.3:4501"
+			pods = nil
+			for idx, processGroupID := range []fdbv1beta2.ProcessGroupID{"storage-1", "storage-2"} {
+				cluster.Status.ProcessGroups = append(cluster.Status.ProcessGroups, fdbv1beta2.NewProcessGroupStatus(processGroupID, fdbv1beta2.ProcessClassStorage, []string{fmt.Sprintf("1.1.1.%d", idx+3)}))
+				pods = append(pods, &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{
+							fdbv1beta2.FDBProcessGroupIDLabel: string(processGroupID),
+						},
+					},
+				})
+			}
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			changed, err = pinCoordinatorIPs(globalControllerLogger, cluster, pods)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should only pin the public IP of the coordinator", func() {
+			Expect(changed).To(BeTrue())
+			Expect(cluster.Status.ProcessGroups[0].PublicIPPinned).To(BeTrue())
+			Expect(cluster.Status.ProcessGroups[1].PublicIPPinned).To(BeFalse())
+			Expect(cluster.GetPublicIPSourceForProcessGroup(cluster.Status.ProcessGroups[0])).To(Equal(fdbv1beta2.PublicIPSourceService))
+		})
+
+		When("the public IP is already pinned", func() {
+			BeforeEach(func() {
+				cluster.Status.ProcessGroups[0].PublicIPPinned = true
+			})
+
+			It("should not change the process groups", func() {
+				Expect(changed).To(BeFalse())
+			})
+		})
+	})
+
 	When("getting the fault domains with unavailable Pods", func() {
 		var cluster *fdbv1beta2.FoundationDBCluster
 		var processGroupsWithFaultDomains map[fdbv1beta2.FaultDomain]fdbv1beta2.None
@@ -318,6 +364,7 @@ var _ = Describe("update_pods", func() {
 		var cluster *fdbv1beta2.FoundationDBCluster
 		var updates map[string][]*corev1.Pod
 		var pvcMap map[fdbv1beta2.ProcessGroupID]corev1.PersistentVolumeClaim
+		var additionalPVCMap map[fdbv1beta2.ProcessGroupID][]corev1.PersistentVolumeClaim
 		var err error
 
 		BeforeEach(func() {
@@ -333,10 +380,11 @@ var _ = Describe("update_pods", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			pvcMap = internal.CreatePVCMap(cluster, allPvcs)
+			additionalPVCMap = internal.CreateAdditionalPVCMap(cluster, allPvcs)
 		})
 
 		JustBeforeEach(func() {
-			updates, err = getPodsToUpdate(context.Background(), globalControllerLogger, clusterReconciler, cluster, pvcMap)
+			updates, err = getPodsToUpdate(context.Background(), globalControllerLogger, clusterReconciler, cluster, pvcMap, additionalPVCMap)
 		})
 
 		When("the cluster has no changes", func() {
@@ -498,4 +546,58 @@ var _ = Describe("update_pods", func() {
 			})
 		})
 	})
+
+	When("checking the pending in-place resizes", func() {
+		var cluster *fdbv1beta2.FoundationDBCluster
+		var pod *corev1.Pod
+		var pendingResizes int
+		var err error
+
+		BeforeEach(func() {
+			cluster = internal.CreateDefaultCluster()
+			Expect(k8sClient.Create(context.TODO(), cluster)).NotTo(HaveOccurred())
+			result, err := reconcileCluster(cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Requeue).To(BeFalse())
+			Expect(k8sClient.Get(context.TODO(), ctrlClient.ObjectKeyFromObject(cluster), cluster)).NotTo(HaveOccurred())
+
+			processGroup := internal.PickProcessGroups(cluster, fdbv1beta2.ProcessClassStorage, 1)[0]
+			pod = &corev1.Pod{}
+			Expect(k8sClient.Get(context.TODO(), ctrlClient.ObjectKey{Namespace: cluster.Namespace, Name: processGroup.GetPodName(cluster)}, pod)).NotTo(HaveOccurred())
+			pod.Annotations[fdbv1beta2.PendingResizeSpecKey] = "resized"
+		})
+
+		JustBeforeEach(func() {
+			Expect(k8sClient.Update(context.TODO(), pod)).NotTo(HaveOccurred())
+			pendingResizes, err = updatePendingResizes(context.Background(), globalControllerLogger, clusterReconciler, cluster)
+			Expect(k8sClient.Get(context.TODO(), ctrlClient.ObjectKeyFromObject(pod), pod)).NotTo(HaveOccurred())
+		})
+
+		When("the resize was applied", func() {
+			It("should update the spec hash of the Pod", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pendingResizes).To(BeZero())
+				Expect(pod.Annotations).NotTo(HaveKey(fdbv1beta2.PendingResizeSpecKey))
+				Expect(pod.Annotations).To(HaveKeyWithValue(fdbv1beta2.LastSpecKey, "resized"))
+			})
+		})
+
+		When("the resize is infeasible", func() {
+			BeforeEach(func() {
+				pod.Status.Conditions = append(pod.Status.Conditions, corev1.PodCondition{
+					Type:    podResizePendingCondition,
+					Status:  corev1.ConditionTrue,
+					Reason:  podResizeStateInfeasible,
+					Message: "Node didn't have enough capacity",
+				})
+			})
+
+			It("should report the resize as pending and not update the spec hash of the Pod", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pendingResizes).To(Equal(1))
+				Expect(pod.Annotations).To(HaveKeyWithValue(fdbv1beta2.PendingResizeSpecKey, "resized"))
+				Expect(pod.Annotations).NotTo(HaveKeyWithValue(fdbv1beta2.LastSpecKey, "resized"))
+			})
+		})
+	})
 })
@@ -27,7 +27,10 @@ import (
 	"sort"
 	"time"
 
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal/clustertemplate"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal/connectionstring"
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal/locality"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal/replacements"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/podmanager"
@@ -40,6 +43,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/pointer"
 )
@@ -54,6 +58,12 @@ func (updateStatus) reconcile(ctx context.Context, r *FoundationDBClusterReconci
 	clusterStatus := fdbv1beta2.FoundationDBClusterStatus{}
 	clusterStatus.Generations.Reconciled = cluster.Status.Generations.Reconciled
 	clusterStatus.ProcessGroups = cluster.Status.ProcessGroups
+	// The coordinator quorum loss is managed by the recoverCoordinatorQuorum reconciler.
+	clusterStatus.CoordinatorQuorumLoss = cluster.Status.CoordinatorQuorumLoss
+	// The reloaded knobs are managed by the reloadKnobs reconciler.
+	clusterStatus.ReloadedKnobs = cluster.Status.ReloadedKnobs
+	// The active operations are recorded by the sub-reconcilers that start them.
+	clusterStatus.ActiveOperations = cluster.Status.ActiveOperations
 	// Initialize with the current desired storage servers per Pod
 	clusterStatus.StorageServersPerDisk = []int{cluster.GetStorageServersPerPod()}
 	clusterStatus.LogServersPerDisk = []int{cluster.GetLogServersPerPod()}
@@ -105,6 +115,14 @@ func (updateStatus) reconcile(ctx context.Context, r *FoundationDBClusterReconci
 	// If we saw at least once that the cluster was configured, we assume that the cluster is always configured.
 	clusterStatus.Configured = cluster.Status.Configured || (databaseStatus.Client.DatabaseStatus.Available && databaseStatus.Cluster.Layers.Error != "configurationMissing")
 
+	// Only write status snapshots for configured clusters, otherwise the snapshot would be based on a dummy status.
+	if r.StatusSnapshotWriter != nil && clusterStatus.Configured {
+		err = r.StatusSnapshotWriter.Write(cluster, databaseStatus)
+		if err != nil {
+			logger.Error(err, "could not write status snapshot")
+		}
+	}
+
 	if cluster.Spec.MainContainer.EnableTLS {
 		clusterStatus.RequiredAddresses.TLS = true
 	} else {
@@ -130,6 +148,10 @@ func (updateStatus) reconcile(ctx context.Context, r *FoundationDBClusterReconci
 		clusterStatus.Health.Healthy = databaseStatus.Client.DatabaseStatus.Healthy
 		clusterStatus.Health.FullReplication = databaseStatus.Cluster.FullReplication
 		clusterStatus.Health.DataMovementPriority = databaseStatus.Cluster.Data.MovingData.HighestPriority
+		clusterStatus.Health.FaultTolerance = databaseStatus.Cluster.FaultTolerance.MaxZoneFailuresWithoutLosingData
+		if databaseStatus.Cluster.FaultTolerance.MaxZoneFailuresWithoutLosingAvailability < clusterStatus.Health.FaultTolerance {
+			clusterStatus.Health.FaultTolerance = databaseStatus.Cluster.FaultTolerance.MaxZoneFailuresWithoutLosingAvailability
+		}
 		currentMaintenanceZone = databaseStatus.Cluster.MaintenanceZone
 	}
 
@@ -151,6 +173,11 @@ func (updateStatus) reconcile(ctx context.Context, r *FoundationDBClusterReconci
 		return &requeue{curError: fmt.Errorf("update_status skipped due to error in validateProcessGroups: %w", err)}
 	}
 
+	// The removal phases are derived from the conditions, so they must be updated after the process groups are validated.
+	for _, processGroup := range clusterStatus.ProcessGroups {
+		processGroup.UpdateRemovalPhases()
+	}
+
 	existingConfigMap := &corev1.ConfigMap{}
 	err = r.Get(ctx, types.NamespacedName{Namespace: configMap.Namespace, Name: configMap.Name}, existingConfigMap)
 	if err != nil && k8serrors.IsNotFound(err) {
@@ -172,11 +199,21 @@ func (updateStatus) reconcile(ctx context.Context, r *FoundationDBClusterReconci
 		clusterStatus.RunningVersion = cluster.Spec.Version
 	}
 
-	clusterStatus.ConnectionString = cluster.Status.ConnectionString
-	if clusterStatus.ConnectionString == "" {
-		clusterStatus.ConnectionString = existingConfigMap.Data[fdbv1beta2.ClusterFileKey]
+	clusterStatus.Template, err = clustertemplate.GetStatus(ctx, r, cluster)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	if cluster.Spec.Profile != "" {
+		version, err := fdbv1beta2.ParseFdbVersion(cluster.Spec.Version)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+
+		clusterStatus.TuningProfile = cluster.GetTuningProfileStatus(version)
 	}
 
+	clusterStatus.ConnectionString = r.resolveConnectionString(logger, cluster, databaseStatus, existingConfigMap)
 	if clusterStatus.ConnectionString == "" {
 		clusterStatus.ConnectionString = cluster.Spec.SeedConnectionString
 	}
@@ -237,12 +274,20 @@ func (updateStatus) reconcile(ctx context.Context, r *FoundationDBClusterReconci
 		return clusterStatus.ProcessGroups[i].ProcessGroupID < clusterStatus.ProcessGroups[j].ProcessGroupID
 	})
 
+	updateScaleStatus(cluster, &clusterStatus)
+
 	cluster.Status = clusterStatus
 	reconciled, err := cluster.CheckReconciliation(logger)
 	if err != nil {
 		return &requeue{curError: err}
 	}
 
+	// The completion of configuration changes is based on the generations, so the completed operations can only be
+	// removed once the reconciliation was checked.
+	cluster.Status.ActiveOperations = getActiveOperations(cluster)
+
+	setReconciliationConditions(cluster, originalStatus, reconciled)
+
 	if reconciled {
 		// Once the cluster is reconciled the operator will release any pending locks for this cluster.
 		lockErr := r.releaseLock(logger, cluster)
@@ -270,6 +315,51 @@ func (updateStatus) reconcile(ctx context.Context, r *FoundationDBClusterReconci
 	return nil
 }
 
+// resolveConnectionString returns the connection string of the cluster based on the following priority order: the
+// connection string reported by the cluster, the connection string in the cluster status, the cached connection string
+// and the connection string in the ConfigMap. The connection string reported by the cluster is only considered if the
+// connection string cache is enabled, otherwise the cluster status already contains the connection string that was
+// fetched from the cluster. If any of those sources diverges, the divergence will be recorded and the cached connection
+// string will be invalidated.
+func (r *FoundationDBClusterReconciler) resolveConnectionString(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, databaseStatus *fdbv1beta2.FoundationDBStatus, configMap *corev1.ConfigMap) string {
+	candidates := make([]connectionstring.Candidate, 0, 4)
+	if r.ConnectionStringCache != nil {
+		candidates = append(candidates, connectionstring.Candidate{
+			Source:           connectionstring.SourceLive,
+			ConnectionString: databaseStatus.Cluster.ConnectionString,
+		})
+	}
+
+	cachedConnectionString, _ := r.ConnectionStringCache.Get(cluster)
+	candidates = append(candidates,
+		connectionstring.Candidate{
+			Source:           connectionstring.SourceStatus,
+			ConnectionString: cluster.Status.ConnectionString,
+		},
+		connectionstring.Candidate{
+			Source:           connectionstring.SourceCache,
+			ConnectionString: cachedConnectionString,
+		},
+		connectionstring.Candidate{
+			Source:           connectionstring.SourceConfigMap,
+			ConnectionString: configMap.Data[fdbv1beta2.ClusterFileKey],
+		},
+	)
+
+	connectionString, divergences := connectionstring.Resolve(candidates...)
+	if len(divergences) == 0 {
+		return connectionString
+	}
+
+	logger.Info("Detected diverging connection strings", "connectionString", connectionString, "divergingSources", divergences)
+	for _, source := range divergences {
+		connectionStringDivergences.WithLabelValues(cluster.Namespace, cluster.Name, string(source)).Inc()
+	}
+	r.ConnectionStringCache.Invalidate(cluster)
+
+	return connectionString
+}
+
 // containsAll determines if one map contains all the keys and matching values
 // from another map.
 func containsAll(current map[string]string, desired map[string]string) bool {
@@ -345,7 +435,7 @@ func checkAndSetProcessStatus(logger logr.Logger, r *FoundationDBClusterReconcil
 		return nil
 	}
 
-	var excluded, hasIncorrectCommandLine, hasMissingProcesses, sidecarUnreachable bool
+	var excluded, hasIncorrectCommandLine, hasPendingKnobReload, hasIncorrectStorageEngine, hasMissingProcesses, sidecarUnreachable bool
 	var substitutions map[string]string
 	var err error
 
@@ -366,6 +456,7 @@ func checkAndSetProcessStatus(logger logr.Logger, r *FoundationDBClusterReconcil
 
 	versionCompatibleUpgrade := cluster.VersionCompatibleUpgradeInProgress()
 	imageType := internal.GetImageType(pod)
+	reportedProcesses := make([]fdbv1beta2.FoundationDBStatusProcessInfo, 0, processCount)
 	for processNumber := 1; processNumber <= processCount; processNumber++ {
 		// If the process status is present under the process group ID take that information, otherwise check if there
 		// is information available under the process_id.
@@ -379,6 +470,7 @@ func checkAndSetProcessStatus(logger logr.Logger, r *FoundationDBClusterReconcil
 			continue
 		}
 
+		reportedProcesses = append(reportedProcesses, processStatus...)
 		for _, process := range processStatus {
 			// Check if the process is reporting any messages, those will normally include error messages.
 			if len(process.Messages) > 0 {
@@ -389,6 +481,10 @@ func checkAndSetProcessStatus(logger logr.Logger, r *FoundationDBClusterReconcil
 				excluded = process.Excluded
 			}
 
+			if !hasIncorrectStorageEngine {
+				hasIncorrectStorageEngine = runsIncorrectStorageEngine(cluster, process)
+			}
+
 			if len(substitutions) == 0 {
 				continue
 			}
@@ -405,6 +501,22 @@ func checkAndSetProcessStatus(logger logr.Logger, r *FoundationDBClusterReconcil
 				versionMatch = process.Version == cluster.Spec.Version || process.Version == fmt.Sprintf("%s-PRERELEASE", cluster.Spec.Version)
 			}
 
+			// If only knobs that can be reloaded without a restart have changed, the process will be updated by the
+			// reloadKnobs reconciler instead of being restarted.
+			if versionMatch && !cluster.Spec.Buggify.EmptyMonitorConf && cluster.UseKnobHotReload() {
+				knobs, reloadable := internal.GetReloadableKnobChanges(cluster, commandLine, process.CommandLine)
+				if reloadable {
+					if len(knobs) > 0 {
+						logger.Info("PendingKnobReload",
+							"knobs", knobs,
+							"processGroupID", processGroupStatus.ProcessGroupID)
+						hasPendingKnobReload = true
+					}
+
+					continue
+				}
+			}
+
 			// If the `EmptyMonitorConf` is set, the commandline is by definition wrong since there should be no running processes.
 			if !(commandLine == process.CommandLine && versionMatch && !cluster.Spec.Buggify.EmptyMonitorConf) {
 				logger.Info("IncorrectProcess",
@@ -418,6 +530,8 @@ func checkAndSetProcessStatus(logger logr.Logger, r *FoundationDBClusterReconcil
 		}
 	}
 
+	processGroupStatus.UpdateProcessInformation(reportedProcesses)
+	processGroupStatus.UpdateExclusionProgress(reportedProcesses)
 	processGroupStatus.UpdateCondition(fdbv1beta2.MissingProcesses, hasMissingProcesses)
 	processGroupStatus.UpdateCondition(fdbv1beta2.SidecarUnreachable, sidecarUnreachable)
 	// If the processes are absent, we are not able to determine the state of the processes, therefore we won't change it.
@@ -425,15 +539,38 @@ func checkAndSetProcessStatus(logger logr.Logger, r *FoundationDBClusterReconcil
 		return nil
 	}
 	processGroupStatus.UpdateCondition(fdbv1beta2.ProcessIsMarkedAsExcluded, excluded)
+	processGroupStatus.UpdateCondition(fdbv1beta2.IncorrectStorageEngine, hasIncorrectStorageEngine)
 	// If the sidecar is unreachable we are not able to compute the desired commandline.
 	if sidecarUnreachable {
 		return nil
 	}
 	processGroupStatus.UpdateCondition(fdbv1beta2.IncorrectCommandLine, hasIncorrectCommandLine)
+	processGroupStatus.UpdateCondition(fdbv1beta2.PendingKnobReload, hasPendingKnobReload && !hasIncorrectCommandLine)
 
 	return nil
 }
 
+// runsIncorrectStorageEngine returns true if the storage engine migration is enabled and the process has a storage role
+// that is running with a different storage engine than the storage engine that is configured in the database.
+func runsIncorrectStorageEngine(cluster *fdbv1beta2.FoundationDBCluster, process fdbv1beta2.FoundationDBStatusProcessInfo) bool {
+	configuredStorageEngine := cluster.Status.DatabaseConfiguration.StorageEngine
+	if !cluster.MigrateStorageEngine() || configuredStorageEngine == "" {
+		return false
+	}
+
+	for _, role := range process.Roles {
+		if role.Role != string(fdbv1beta2.ProcessRoleStorage) || role.StorageMetadata.StorageEngine == "" {
+			continue
+		}
+
+		if role.StorageMetadata.StorageEngine != configuredStorageEngine {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Validate and set progressGroup's status
 func validateProcessGroups(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBClusterStatus, processMap map[fdbv1beta2.ProcessGroupID][]fdbv1beta2.FoundationDBStatusProcessInfo, configMap *corev1.ConfigMap, pvcs *corev1.PersistentVolumeClaimList, logger logr.Logger, maintenanceZone fdbv1beta2.FaultDomain) error {
 	processGroupsWithoutExclusion := make(map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None, len(cluster.Spec.ProcessGroupsToRemoveWithoutExclusion))
@@ -442,12 +579,16 @@ func validateProcessGroups(ctx context.Context, r *FoundationDBClusterReconciler
 	}
 
 	pvcMap := internal.CreatePVCMap(cluster, pvcs)
+	additionalPVCMap := internal.CreateAdditionalPVCMap(cluster, pvcs)
 
 	disableTaintFeature := cluster.IsTaintFeatureDisabled()
 	if disableTaintFeature {
 		logger.Info("Disable taint feature", "Disabled", disableTaintFeature)
 	}
 
+	imageTypes := make(map[fdbv1beta2.ProcessGroupID]fdbv1beta2.ImageType, len(status.ProcessGroups))
+	validations := make([]processGroupValidation, 0, len(status.ProcessGroups))
+
 	for _, processGroup := range status.ProcessGroups {
 		// If the process group should be removed mark it for removal.
 		if cluster.ProcessGroupIsBeingRemoved(processGroup.ProcessGroupID) {
@@ -470,6 +611,18 @@ func validateProcessGroups(ctx context.Context, r *FoundationDBClusterReconciler
 			continue
 		}
 
+		var pvc *corev1.PersistentVolumeClaim
+		pvcValue, pvcExists := pvcMap[processGroup.ProcessGroupID]
+		if pvcExists {
+			pvc = &pvcValue
+		}
+
+		// The volume check must be done before the Pod is fetched, as the Pod will be deleted if the node is removed.
+		err := updateVolumeNodeCondition(ctx, r, pvc, processGroup, logger)
+		if err != nil {
+			return err
+		}
+
 		pod, podError := r.PodLifecycleManager.GetPod(ctx, r, cluster, processGroup.GetPodName(cluster))
 		if podError != nil {
 			// If the process group is not being removed and the Pod is not set we need to put it into
@@ -483,7 +636,9 @@ func validateProcessGroups(ctx context.Context, r *FoundationDBClusterReconciler
 					processGroup.UpdateCondition(fdbv1beta2.MissingPod, true)
 				}
 
+				processGroup.UpdateCondition(fdbv1beta2.PodDeletionRequired, false)
 				processGroup.UpdateCondition(fdbv1beta2.IncorrectCommandLine, false)
+				processGroup.UpdateCondition(fdbv1beta2.PendingKnobReload, false)
 				continue
 			}
 
@@ -491,6 +646,11 @@ func validateProcessGroups(ctx context.Context, r *FoundationDBClusterReconciler
 			continue
 		}
 		processGroup.UpdateCondition(fdbv1beta2.MissingPod, false)
+		// If the Pod was created after the PodDeletionRequired condition was added, the Pod was deleted by a privileged
+		// user or controller.
+		if deletionRequired := processGroup.GetConditionTime(fdbv1beta2.PodDeletionRequired); deletionRequired != nil && pod.CreationTimestamp.Unix() > *deletionRequired {
+			processGroup.UpdateCondition(fdbv1beta2.PodDeletionRequired, false)
+		}
 		processGroup.AddAddresses(podmanager.GetPublicIPs(pod, logger), processGroup.IsMarkedForRemoval() || !status.Health.Available)
 
 		// This handles the case where the Pod has a DeletionTimestamp and should be deleted.
@@ -532,6 +692,10 @@ func validateProcessGroups(ctx context.Context, r *FoundationDBClusterReconciler
 			status.ImageTypes = append(status.ImageTypes, imageType)
 		}
 
+		if !processGroup.IsMarkedForRemoval() {
+			imageTypes[processGroup.ProcessGroupID] = imageType
+		}
+
 		if pod.ObjectMeta.DeletionTimestamp.IsZero() && status.HasListenIPsForAllPods {
 			hasPodIP := false
 			for _, container := range pod.Spec.Containers {
@@ -558,32 +722,344 @@ func validateProcessGroups(ctx context.Context, r *FoundationDBClusterReconciler
 			return err
 		}
 
-		var pvc *corev1.PersistentVolumeClaim
-		pvcValue, pvcExists := pvcMap[processGroup.ProcessGroupID]
-		if pvcExists {
-			pvc = &pvcValue
+		validations = append(validations, processGroupValidation{
+			processGroup:   processGroup,
+			pod:            pod,
+			pvc:            pvc,
+			additionalPVCs: additionalPVCMap[processGroup.ProcessGroupID],
+			configMapHash:  configMapHash,
+		})
+	}
+
+	// The validation of the process groups only modifies the status of the validated process group, so the validations
+	// can be done concurrently.
+	err := internal.RunParallel(len(validations), r.MaxConcurrentProcessGroupChecks, func(idx int) error {
+		validation := validations[idx]
+		return validateProcessGroup(ctx, r, cluster, validation.pod, validation.pvc, validation.additionalPVCs, validation.configMapHash, validation.processGroup, disableTaintFeature, logger)
+	})
+	if err != nil {
+		return err
+	}
+
+	status.ImageTypeMigration = getImageTypeMigrationStatus(cluster, status.ProcessGroups, imageTypes)
+	status.StorageClassMigrations = getStorageClassMigrationStatus(cluster, status.ProcessGroups, pvcMap)
+	status.StorageEngineMigration = getStorageEngineMigrationStatus(cluster, status.ProcessGroups)
+	status.ProcessGroupIDPrefixMigration = replacements.GetProcessGroupIDPrefixMigrationStatus(cluster, status.ProcessGroups)
+
+	return nil
+}
+
+// updateScaleStatus updates the status fields that are used by the scale subresource for the stateless processes.
+func updateScaleStatus(cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBClusterStatus) {
+	status.StatelessProcessGroups = 0
+	for _, processGroup := range status.ProcessGroups {
+		if processGroup.ProcessClass != fdbv1beta2.ProcessClassStateless || processGroup.IsMarkedForRemoval() {
+			continue
 		}
 
-		err = validateProcessGroup(ctx, r, cluster, pod, pvc, configMapHash, processGroup, disableTaintFeature, logger)
-		if err != nil {
+		status.StatelessProcessGroups++
+	}
+
+	status.StatelessSelector = labels.SelectorFromSet(internal.GetPodMatchLabels(cluster, fdbv1beta2.ProcessClassStateless, "")).String()
+}
+
+// imageTypeMigrationHealthConditions are the conditions that mark a migrated process group as unhealthy. For the
+// unified image the SidecarUnreachable condition is set if the API of the fdb-kubernetes-monitor is not reachable.
+var imageTypeMigrationHealthConditions = []fdbv1beta2.ProcessGroupConditionType{
+	fdbv1beta2.MissingPod,
+	fdbv1beta2.PodPending,
+	fdbv1beta2.PodFailing,
+	fdbv1beta2.MissingProcesses,
+	fdbv1beta2.SidecarUnreachable,
+}
+
+// getImageTypeMigrationStatus returns the progress of the image type migration based on the image types of the Pods. If
+// all process groups are running with the desired image type nil will be returned. The fault domain that is currently
+// migrated will be kept until all of its process groups are migrated, the next fault domain is only selected if all
+// migrated process groups are healthy.
+func getImageTypeMigrationStatus(cluster *fdbv1beta2.FoundationDBCluster, processGroups []*fdbv1beta2.ProcessGroupStatus, imageTypes map[fdbv1beta2.ProcessGroupID]fdbv1beta2.ImageType) *fdbv1beta2.ImageTypeMigrationStatus {
+	migrationStatus := &fdbv1beta2.ImageTypeMigrationStatus{
+		TargetImageType: cluster.DesiredImageType(),
+		Healthy:         true,
+	}
+	pendingFaultDomains := map[fdbv1beta2.FaultDomain]fdbv1beta2.None{}
+
+	for _, processGroup := range processGroups {
+		if processGroup.IsMarkedForRemoval() {
+			continue
+		}
+
+		imageType, ok := imageTypes[processGroup.ProcessGroupID]
+		if ok && imageType != migrationStatus.TargetImageType {
+			migrationStatus.PendingProcessGroups++
+			pendingFaultDomains[processGroup.GetImageTypeMigrationFaultDomain()] = fdbv1beta2.None{}
+			continue
+		}
+
+		migrationStatus.MigratedProcessGroups++
+		for _, conditionType := range imageTypeMigrationHealthConditions {
+			if processGroup.GetConditionTime(conditionType) != nil {
+				migrationStatus.Healthy = false
+				break
+			}
+		}
+	}
+
+	if migrationStatus.PendingProcessGroups == 0 {
+		return nil
+	}
+
+	if cluster.Status.ImageTypeMigration != nil {
+		if _, ok := pendingFaultDomains[cluster.Status.ImageTypeMigration.FaultDomain]; ok {
+			migrationStatus.FaultDomain = cluster.Status.ImageTypeMigration.FaultDomain
+			return migrationStatus
+		}
+	}
+
+	if !migrationStatus.Healthy {
+		return migrationStatus
+	}
+
+	for faultDomain := range pendingFaultDomains {
+		if migrationStatus.FaultDomain == "" || faultDomain < migrationStatus.FaultDomain {
+			migrationStatus.FaultDomain = faultDomain
+		}
+	}
+
+	return migrationStatus
+}
+
+// getStorageEngineMigrationStatus returns the progress of the storage engine migration based on the IncorrectStorageEngine
+// condition of the storage process groups. If the migration is disabled or all storage process groups are running
+// with the configured storage engine nil will be returned.
+func getStorageEngineMigrationStatus(cluster *fdbv1beta2.FoundationDBCluster, processGroups []*fdbv1beta2.ProcessGroupStatus) *fdbv1beta2.StorageEngineMigrationStatus {
+	if !cluster.MigrateStorageEngine() {
+		return nil
+	}
+
+	migrationStatus := &fdbv1beta2.StorageEngineMigrationStatus{
+		TargetStorageEngine: cluster.Status.DatabaseConfiguration.StorageEngine,
+	}
+
+	for _, processGroup := range processGroups {
+		if processGroup.ProcessClass != fdbv1beta2.ProcessClassStorage {
+			continue
+		}
+
+		if processGroup.IsMarkedForRemoval() {
+			if processGroup.RemovalReason != nil && processGroup.RemovalReason.Type == fdbv1beta2.RemovalReasonStorageEngineChanged {
+				migrationStatus.MigratingProcessGroups++
+			}
+			continue
+		}
+
+		if processGroup.GetConditionTime(fdbv1beta2.IncorrectStorageEngine) != nil {
+			migrationStatus.PendingProcessGroups++
+			continue
+		}
+
+		migrationStatus.MigratedProcessGroups++
+	}
+
+	if migrationStatus.PendingProcessGroups == 0 && migrationStatus.MigratingProcessGroups == 0 {
+		return nil
+	}
+
+	return migrationStatus
+}
+
+// getStorageClassMigrationStatus returns the progress of the storage class migration for every process class that has
+// process groups with a PVC that uses a different storage class than the desired one. Process groups that are marked
+// for removal are ignored.
+func getStorageClassMigrationStatus(cluster *fdbv1beta2.FoundationDBCluster, processGroups []*fdbv1beta2.ProcessGroupStatus, pvcMap map[fdbv1beta2.ProcessGroupID]corev1.PersistentVolumeClaim) []fdbv1beta2.StorageClassMigrationStatus {
+	migrations := map[fdbv1beta2.ProcessClass]*fdbv1beta2.StorageClassMigrationStatus{}
+	for _, processGroup := range processGroups {
+		if processGroup.IsMarkedForRemoval() {
+			continue
+		}
+
+		pvc, ok := pvcMap[processGroup.ProcessGroupID]
+		if !ok {
+			continue
+		}
+
+		migration, ok := migrations[processGroup.ProcessClass]
+		if !ok {
+			migration = &fdbv1beta2.StorageClassMigrationStatus{
+				ProcessClass:           processGroup.ProcessClass,
+				TargetStorageClassName: internal.GetStorageClassName(cluster, processGroup),
+			}
+			migrations[processGroup.ProcessClass] = migration
+		}
+
+		if internal.StorageClassChanged(cluster, processGroup, &pvc) {
+			migration.PendingProcessGroups++
+			continue
+		}
+
+		migration.MigratedProcessGroups++
+	}
+
+	var migrationStatus []fdbv1beta2.StorageClassMigrationStatus
+	for _, migration := range migrations {
+		if migration.PendingProcessGroups == 0 {
+			continue
+		}
+
+		migrationStatus = append(migrationStatus, *migration)
+	}
+
+	sort.Slice(migrationStatus, func(i, j int) bool {
+		return migrationStatus[i].ProcessClass < migrationStatus[j].ProcessClass
+	})
+
+	return migrationStatus
+}
+
+// updateVolumeNodeCondition updates the VolumeNodeName of the process group and checks if the node that the volume is
+// bound to still exists.
+func updateVolumeNodeCondition(ctx context.Context, r *FoundationDBClusterReconciler, pvc *corev1.PersistentVolumeClaim, processGroup *fdbv1beta2.ProcessGroupStatus, logger logr.Logger) error {
+	processGroup.VolumeNodeName = internal.GetVolumeNodeName(pvc)
+	if processGroup.VolumeNodeName == "" {
+		processGroup.UpdateCondition(fdbv1beta2.VolumeNodeMissing, false)
+		return nil
+	}
+
+	err := r.Get(ctx, client.ObjectKey{Name: processGroup.VolumeNodeName}, &corev1.Node{})
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
 			return err
 		}
+
+		logger.Info("node of the volume is missing", "processGroupID", processGroup.ProcessGroupID, "nodeName", processGroup.VolumeNodeName)
+		processGroup.UpdateCondition(fdbv1beta2.VolumeNodeMissing, true)
+		return nil
+	}
+
+	processGroup.UpdateCondition(fdbv1beta2.VolumeNodeMissing, false)
+	return nil
+}
+
+// updateVolumeTopologyConflictCondition checks if the desired Pod spec of a process group with an outdated Pod can be
+// scheduled on any node that satisfies the node affinity of the persistent volume.
+func updateVolumeTopologyConflictCondition(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, pvc *corev1.PersistentVolumeClaim, processGroup *fdbv1beta2.ProcessGroupStatus, incorrectPod bool, logger logr.Logger) error {
+	if !cluster.ReplaceOnVolumeTopologyConflict() || !incorrectPod || pvc == nil {
+		processGroup.UpdateCondition(fdbv1beta2.VolumeTopologyConflict, false)
+		return nil
 	}
 
+	spec, err := internal.GetPodSpec(cluster, processGroup)
+	if err != nil {
+		return err
+	}
+
+	conflict, err := internal.GetVolumeTopologyConflict(ctx, r, spec, pvc)
+	if err != nil {
+		return err
+	}
+
+	if conflict != "" {
+		logger.Info("desired Pod spec conflicts with the volume topology", "processGroupID", processGroup.ProcessGroupID, "conflict", conflict)
+	}
+
+	processGroup.UpdateCondition(fdbv1beta2.VolumeTopologyConflict, conflict != "")
 	return nil
 }
 
+// diskQualificationFailed returns true if the disk qualification container of the Pod has rejected a volume.
+func diskQualificationFailed(pod *corev1.Pod) bool {
+	for _, status := range pod.Status.InitContainerStatuses {
+		if status.Name != fdbv1beta2.DiskQualificationContainerName {
+			continue
+		}
+
+		// Once the disk qualification has succeeded, the volumes will not be checked again.
+		if status.State.Terminated != nil {
+			return status.State.Terminated.ExitCode == internal.DiskQualificationFailedExitCode
+		}
+
+		// If the container is restarted by the kubelet, the result of the last run must be checked.
+		if status.LastTerminationState.Terminated != nil {
+			return status.LastTerminationState.Terminated.ExitCode == internal.DiskQualificationFailedExitCode
+		}
+	}
+
+	return false
+}
+
+// stuckContainerReasons are the waiting reasons of a container that indicate that the container can't be started.
+var stuckContainerReasons = map[string]fdbv1beta2.None{
+	"CrashLoopBackOff": {},
+	"ImagePullBackOff": {},
+	"ErrImagePull":     {},
+}
+
+// podIsStuck returns true if the Pod is in the Pending phase or if at least one container of the Pod is waiting
+// because it can't be started.
+func podIsStuck(pod *corev1.Pod) bool {
+	if pod.Status.Phase == corev1.PodPending {
+		return true
+	}
+
+	for _, statuses := range [][]corev1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+		for _, status := range statuses {
+			if status.State.Waiting == nil {
+				continue
+			}
+
+			if _, ok := stuckContainerReasons[status.State.Waiting.Reason]; ok {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// additionalPVCsIncorrect returns true if one of the desired additional PVCs of the process group is missing or has
+// incorrect metadata.
+func additionalPVCsIncorrect(cluster *fdbv1beta2.FoundationDBCluster, processGroupStatus *fdbv1beta2.ProcessGroupStatus, currentAdditionalPVCs []corev1.PersistentVolumeClaim, logger logr.Logger) (bool, error) {
+	desiredPVCs, err := internal.GetAdditionalPvcs(cluster, processGroupStatus)
+	if err != nil {
+		return false, err
+	}
+
+	currentPVCs := make(map[string]corev1.PersistentVolumeClaim, len(currentAdditionalPVCs))
+	for _, pvc := range currentAdditionalPVCs {
+		currentPVCs[pvc.Name] = pvc
+	}
+
+	for _, desiredPVC := range desiredPVCs {
+		currentPVC, ok := currentPVCs[desiredPVC.Name]
+		if !ok || !metadataMatches(currentPVC.ObjectMeta, desiredPVC.ObjectMeta) {
+			logger.Info("ValidateProcessGroup found incorrect additional PVC", "DesiredPVC", desiredPVC.Name, "found", ok)
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// processGroupValidation contains the resources that are required to validate a single process group.
+type processGroupValidation struct {
+	processGroup   *fdbv1beta2.ProcessGroupStatus
+	pod            *corev1.Pod
+	pvc            *corev1.PersistentVolumeClaim
+	additionalPVCs []corev1.PersistentVolumeClaim
+	configMapHash  string
+}
+
 // validateProcessGroup runs specific checks for the status of a process group.
 // returns failing, incorrect, error
 func validateProcessGroup(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster,
-	pod *corev1.Pod, currentPVC *corev1.PersistentVolumeClaim, configMapHash string, processGroupStatus *fdbv1beta2.ProcessGroupStatus,
+	pod *corev1.Pod, currentPVC *corev1.PersistentVolumeClaim, currentAdditionalPVCs []corev1.PersistentVolumeClaim, configMapHash string, processGroupStatus *fdbv1beta2.ProcessGroupStatus,
 	disableTaintFeature bool, logger logr.Logger) error {
 	if pod == nil {
 		processGroupStatus.UpdateCondition(fdbv1beta2.MissingPod, true)
 		return nil
 	}
 
-	specHash, err := internal.GetPodSpecHash(cluster, processGroupStatus, nil)
+	specHash, err := internal.GetMatchingPodSpecHash(cluster, processGroupStatus, nil, pod.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey])
 	if err != nil {
 		return err
 	}
@@ -598,6 +1074,15 @@ func validateProcessGroup(ctx context.Context, r *FoundationDBClusterReconciler,
 	}
 
 	processGroupStatus.UpdateCondition(fdbv1beta2.IncorrectPodSpec, incorrectPod)
+	err = updateVolumeTopologyConflictCondition(ctx, r, cluster, currentPVC, processGroupStatus, incorrectPod, logger)
+	if err != nil {
+		return err
+	}
+
+	// Once the Pod is updated, the process class reassignment is done.
+	if !incorrectPod {
+		processGroupStatus.UpdateCondition(fdbv1beta2.ProcessClassReassignment, false)
+	}
 
 	// If we do a cluster version incompatible upgrade we use the fdbv1beta2.IncorrectConfigMap to signal when the operator
 	// can restart fdbserver processes. Since the ConfigMap itself won't change during the upgrade we have to run the updatePodDynamicConf
@@ -611,7 +1096,9 @@ func validateProcessGroup(ctx context.Context, r *FoundationDBClusterReconciler,
 			synced = false
 		}
 	} else {
-		synced = pod.ObjectMeta.Annotations[fdbv1beta2.LastConfigMapKey] == configMapHash
+		// The annotation alone is not sufficient as it will be set during Pod creation, the current Pod of the process
+		// group must also have confirmed that the monitor has loaded the configuration.
+		synced = pod.ObjectMeta.Annotations[fdbv1beta2.LastConfigMapKey] == configMapHash && processGroupStatus.HasLoadedMonitorConf(pod.UID, configMapHash)
 	}
 
 	processGroupStatus.UpdateCondition(fdbv1beta2.IncorrectConfigMap, !synced)
@@ -629,7 +1116,16 @@ func validateProcessGroup(ctx context.Context, r *FoundationDBClusterReconciler,
 		logger.Info("ValidateProcessGroup found incorrectPVC", "CurrentPVC", currentPVC, "DesiredPVC", desiredPvc)
 	}
 
+	if !incorrectPVC {
+		incorrectPVC, err = additionalPVCsIncorrect(cluster, processGroupStatus, currentAdditionalPVCs, logger)
+		if err != nil {
+			return err
+		}
+	}
+
 	processGroupStatus.UpdateCondition(fdbv1beta2.MissingPVC, incorrectPVC)
+	processGroupStatus.UpdateCondition(fdbv1beta2.DiskQualificationFailed, diskQualificationFailed(pod))
+	processGroupStatus.UpdateCondition(fdbv1beta2.PodStuck, cluster.DetectStuckPods() && podIsStuck(pod))
 
 	if pod.Status.Phase == corev1.PodPending {
 		processGroupStatus.UpdateCondition(fdbv1beta2.PodPending, true)
@@ -656,7 +1152,7 @@ func validateProcessGroup(ctx context.Context, r *FoundationDBClusterReconciler,
 			logger.Info("Delete Pod that is stuck in NodeAffinity",
 				"processGroupID", processGroupStatus.ProcessGroupID)
 
-			err = r.PodLifecycleManager.DeletePod(logr.NewContext(ctx, logger), r, pod)
+			err = r.deletePod(ctx, logger, processGroupStatus, pod)
 			if err != nil {
 				return err
 			}
@@ -676,6 +1172,15 @@ func validateProcessGroup(ctx context.Context, r *FoundationDBClusterReconciler,
 		processGroupStatus.UpdateCondition(fdbv1beta2.NodeTaintReplacing, false)
 	}
 
+	if cluster.ReplaceProcessGroupsOnNodeDrain() {
+		err = updateNodeDrainCondition(ctx, r, cluster, pod, processGroupStatus, logger.WithValues("Pod", pod.Name, "nodeName", pod.Spec.NodeName, "processGroupID", processGroupStatus.ProcessGroupID))
+		if err != nil {
+			return err
+		}
+	} else {
+		processGroupStatus.UpdateCondition(fdbv1beta2.NodeDraining, false)
+	}
+
 	return nil
 }
 
@@ -771,6 +1276,61 @@ func checkIfNodeHasTaintsAndUpdateConditions(logger logr.Logger, taints []corev1
 	return hasMatchingTaint
 }
 
+// updateNodeDrainCondition checks if the node of the Pod is being drained and updates the NodeDraining condition of the
+// process group accordingly.
+func updateNodeDrainCondition(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster,
+	pod *corev1.Pod, processGroup *fdbv1beta2.ProcessGroupStatus, logger logr.Logger) error {
+	if pod.Spec.NodeName == "" {
+		processGroup.UpdateCondition(fdbv1beta2.NodeDraining, false)
+		return nil
+	}
+
+	node := &corev1.Node{}
+	err := r.Get(ctx, client.ObjectKey{Name: pod.Spec.NodeName}, node)
+	if err != nil {
+		// If the node is already removed, the process group will be detected as failed.
+		if k8serrors.IsNotFound(err) {
+			processGroup.UpdateCondition(fdbv1beta2.NodeDraining, false)
+			return nil
+		}
+
+		return fmt.Errorf("get pod %s node %s fails with error :%w", pod.Name, pod.Spec.NodeName, err)
+	}
+
+	draining, reason := nodeIsDraining(cluster, node)
+	if draining && processGroup.GetConditionTime(fdbv1beta2.NodeDraining) == nil {
+		logger.Info("Add NodeDraining condition", "reason", reason)
+	}
+
+	processGroup.UpdateCondition(fdbv1beta2.NodeDraining, draining)
+
+	return nil
+}
+
+// nodeIsDraining returns true and the detected signal if the node is cordoned or has one of the configured drain taints
+// or annotations.
+func nodeIsDraining(cluster *fdbv1beta2.FoundationDBCluster, node *corev1.Node) (bool, string) {
+	if cluster.DetectCordonedNodes() && node.Spec.Unschedulable {
+		return true, "node is cordoned"
+	}
+
+	for _, key := range cluster.GetNodeDrainTaintKeys() {
+		for _, taint := range node.Spec.Taints {
+			if taint.Key == key {
+				return true, fmt.Sprintf("node has taint %s", key)
+			}
+		}
+	}
+
+	for _, key := range cluster.GetNodeDrainAnnotations() {
+		if _, ok := node.Annotations[key]; ok {
+			return true, fmt.Sprintf("node has annotation %s", key)
+		}
+	}
+
+	return false, ""
+}
+
 func refreshProcessGroupStatus(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBClusterStatus) (*corev1.PersistentVolumeClaimList, error) {
 	knownProcessGroups := map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None{}
 
@@ -780,7 +1340,7 @@ func refreshProcessGroupStatus(ctx context.Context, r *FoundationDBClusterReconc
 
 	// Track all created resources this will ensure that we catch all resources that are created by the operator
 	// even if the process group is currently missing for some reasons.
-	pods, err := r.PodLifecycleManager.GetPods(ctx, r, cluster, internal.GetPodListOptions(cluster, "", "")...)
+	pods, err := r.PodLifecycleManager.GetPods(ctx, r, cluster, r.getPodListOptions(cluster, "", "")...)
 	if err != nil {
 		return nil, err
 	}
@@ -797,7 +1357,7 @@ func refreshProcessGroupStatus(ctx context.Context, r *FoundationDBClusterReconc
 	}
 
 	pvcs := &corev1.PersistentVolumeClaimList{}
-	err = r.List(ctx, pvcs, internal.GetPodListOptions(cluster, "", "")...)
+	err = r.List(ctx, pvcs, r.getPodListOptions(cluster, "", "")...)
 	if err != nil {
 		return nil, err
 	}
@@ -35,6 +35,7 @@ import (
 	"k8s.io/utils/pointer"
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal/connectionstring"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	. "github.com/onsi/ginkgo/v2"
@@ -98,7 +99,7 @@ var _ = Describe("update_status", func() {
 				globalControllerLogger.Info("Taint node", "Node name", pod.Name, "Node taints", node.Spec.Taints)
 				Expect(k8sClient.Update(context.TODO(), node)).NotTo(HaveOccurred())
 
-				err = validateProcessGroup(context.TODO(), clusterReconciler, cluster, pod, pvc, pod.ObjectMeta.Annotations[fdbv1beta2.LastConfigMapKey], pickedProcessGroup, cluster.IsTaintFeatureDisabled(), logger)
+				err = validateProcessGroup(context.TODO(), clusterReconciler, cluster, pod, pvc, nil, pod.ObjectMeta.Annotations[fdbv1beta2.LastConfigMapKey], pickedProcessGroup, cluster.IsTaintFeatureDisabled(), logger)
 				Expect(err).NotTo(HaveOccurred())
 			})
 
@@ -169,7 +170,7 @@ var _ = Describe("update_status", func() {
 						},
 					}
 
-					err = validateProcessGroup(context.TODO(), clusterReconciler, cluster, pod, pvc, pod.ObjectMeta.Annotations[fdbv1beta2.LastConfigMapKey], pickedProcessGroup, cluster.IsTaintFeatureDisabled(), logger)
+					err = validateProcessGroup(context.TODO(), clusterReconciler, cluster, pod, pvc, nil, pod.ObjectMeta.Annotations[fdbv1beta2.LastConfigMapKey], pickedProcessGroup, cluster.IsTaintFeatureDisabled(), logger)
 					Expect(err).NotTo(HaveOccurred())
 					Expect(pickedProcessGroup.ProcessGroupConditions).To(HaveLen(2))
 					Expect(pickedProcessGroup.GetCondition(fdbv1beta2.NodeTaintDetected)).NotTo(Equal(nil))
@@ -230,7 +231,7 @@ var _ = Describe("update_status", func() {
 						},
 					}
 
-					err = validateProcessGroup(context.TODO(), clusterReconciler, cluster, pod, pvc, pod.ObjectMeta.Annotations[fdbv1beta2.LastConfigMapKey], pickedProcessGroup, cluster.IsTaintFeatureDisabled(), logger)
+					err = validateProcessGroup(context.TODO(), clusterReconciler, cluster, pod, pvc, nil, pod.ObjectMeta.Annotations[fdbv1beta2.LastConfigMapKey], pickedProcessGroup, cluster.IsTaintFeatureDisabled(), logger)
 					Expect(err).NotTo(HaveOccurred())
 					Expect(pickedProcessGroup.ProcessGroupConditions).To(HaveLen(2))
 					Expect(pickedProcessGroup.GetCondition(fdbv1beta2.NodeTaintDetected)).NotTo(BeNil())
@@ -260,7 +261,7 @@ var _ = Describe("update_status", func() {
 					Expect(k8sClient.Update(context.TODO(), node)).NotTo(HaveOccurred())
 					globalControllerLogger.Info("Remove node taint", "Node name", pod.Name, "Node taints", node.Spec.Taints, "Now", time.Now())
 
-					Expect(validateProcessGroup(context.TODO(), clusterReconciler, cluster, pod, pvc, pod.ObjectMeta.Annotations[fdbv1beta2.LastConfigMapKey], pickedProcessGroup, cluster.IsTaintFeatureDisabled(), logger)).NotTo(HaveOccurred())
+					Expect(validateProcessGroup(context.TODO(), clusterReconciler, cluster, pod, pvc, nil, pod.ObjectMeta.Annotations[fdbv1beta2.LastConfigMapKey], pickedProcessGroup, cluster.IsTaintFeatureDisabled(), logger)).NotTo(HaveOccurred())
 					Expect(pickedProcessGroup.ProcessGroupConditions).To(BeEmpty())
 				})
 			})
@@ -317,7 +318,7 @@ var _ = Describe("update_status", func() {
 			})
 
 			It("should be added to the failing Pods", func() {
-				Expect(validateProcessGroup(context.TODO(), clusterReconciler, cluster, nil, nil, "", pickedProcessGroup, cluster.IsTaintFeatureDisabled(), logger)).NotTo(HaveOccurred())
+				Expect(validateProcessGroup(context.TODO(), clusterReconciler, cluster, nil, nil, nil, "", pickedProcessGroup, cluster.IsTaintFeatureDisabled(), logger)).NotTo(HaveOccurred())
 				Expect(pickedProcessGroup.ProcessGroupConditions).To(HaveLen(1))
 				Expect(pickedProcessGroup.ProcessGroupConditions[0].ProcessGroupConditionType).To(Equal(fdbv1beta2.MissingPod))
 			})
@@ -397,6 +398,35 @@ var _ = Describe("update_status", func() {
 			})
 		})
 
+		When("the monitor of a process group has not confirmed the current configuration", func() {
+			BeforeEach(func() {
+				pickedProcessGroup.MonitorConfHash = ""
+			})
+
+			It("should get the IncorrectConfigMap condition", func() {
+				err := validateProcessGroups(context.TODO(), clusterReconciler, cluster, &cluster.Status, processMap, configMap, allPvcs, logger, "")
+				Expect(err).NotTo(HaveOccurred())
+
+				incorrectProcesses := fdbv1beta2.FilterByCondition(cluster.Status.ProcessGroups, fdbv1beta2.IncorrectConfigMap, false)
+				Expect(incorrectProcesses).To(ConsistOf([]fdbv1beta2.ProcessGroupID{pickedProcessGroup.ProcessGroupID}))
+				Expect(cluster.Status.ProcessGroups).To(HaveLen(17))
+			})
+		})
+
+		When("the configuration was confirmed by a previous Pod of the process group", func() {
+			BeforeEach(func() {
+				pickedProcessGroup.MonitorConfPodUID = "previous-pod"
+			})
+
+			It("should get the IncorrectConfigMap condition", func() {
+				err := validateProcessGroups(context.TODO(), clusterReconciler, cluster, &cluster.Status, processMap, configMap, allPvcs, logger, "")
+				Expect(err).NotTo(HaveOccurred())
+
+				incorrectProcesses := fdbv1beta2.FilterByCondition(cluster.Status.ProcessGroups, fdbv1beta2.IncorrectConfigMap, false)
+				Expect(incorrectProcesses).To(ConsistOf([]fdbv1beta2.ProcessGroupID{pickedProcessGroup.ProcessGroupID}))
+			})
+		})
+
 		When("a process group is not reporting to the cluster", func() {
 			BeforeEach(func() {
 				adminClient.MockMissingProcessGroup(pickedProcessGroup.ProcessGroupID, true)
@@ -436,6 +466,25 @@ var _ = Describe("update_status", func() {
 				Expect(incorrectPods).To(Equal([]fdbv1beta2.ProcessGroupID{pickedProcessGroup.ProcessGroupID}))
 				Expect(cluster.Status.ProcessGroups).To(HaveLen(17))
 			})
+
+			When("the process groups are checked concurrently", func() {
+				BeforeEach(func() {
+					clusterReconciler.MaxConcurrentProcessGroupChecks = 4
+				})
+
+				AfterEach(func() {
+					clusterReconciler.MaxConcurrentProcessGroupChecks = 0
+				})
+
+				It("should get a condition assigned", func() {
+					err := validateProcessGroups(context.TODO(), clusterReconciler, cluster, &cluster.Status, processMap, configMap, allPvcs, logger, "")
+					Expect(err).NotTo(HaveOccurred())
+
+					incorrectPods := fdbv1beta2.FilterByCondition(cluster.Status.ProcessGroups, fdbv1beta2.IncorrectPodSpec, false)
+					Expect(incorrectPods).To(Equal([]fdbv1beta2.ProcessGroupID{pickedProcessGroup.ProcessGroupID}))
+					Expect(cluster.Status.ProcessGroups).To(HaveLen(17))
+				})
+			})
 		})
 
 		When("the Pod is marked for deletion but still reporting to the cluster", func() {
@@ -638,6 +687,132 @@ var _ = Describe("update_status", func() {
 				Expect(pendingCount).To(BeNumerically("==", 1))
 			})
 		})
+
+		When("the disk qualification rejected a volume", func() {
+			BeforeEach(func() {
+				storagePod.Status.Phase = corev1.PodPending
+				storagePod.Status.InitContainerStatuses = []corev1.ContainerStatus{
+					{
+						Name: fdbv1beta2.DiskQualificationContainerName,
+						State: corev1.ContainerState{
+							Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+						},
+						LastTerminationState: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{ExitCode: internal.DiskQualificationFailedExitCode},
+						},
+					},
+				}
+				Expect(k8sClient.Update(context.TODO(), storagePod)).NotTo(HaveOccurred())
+			})
+
+			It("should mark the process group as disk qualification failed", func() {
+				Expect(validateProcessGroups(context.TODO(), clusterReconciler, cluster, &cluster.Status, processMap, configMap, allPvcs, logger, "")).NotTo(HaveOccurred())
+
+				failedProcessGroups := fdbv1beta2.FilterByCondition(cluster.Status.ProcessGroups, fdbv1beta2.DiskQualificationFailed, false)
+				Expect(failedProcessGroups).To(Equal([]fdbv1beta2.ProcessGroupID{pickedProcessGroup.ProcessGroupID}))
+			})
+		})
+
+		When("the disk qualification failed for a different reason", func() {
+			BeforeEach(func() {
+				storagePod.Status.Phase = corev1.PodPending
+				storagePod.Status.InitContainerStatuses = []corev1.ContainerStatus{
+					{
+						Name: fdbv1beta2.DiskQualificationContainerName,
+						State: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{ExitCode: 127},
+						},
+					},
+				}
+				Expect(k8sClient.Update(context.TODO(), storagePod)).NotTo(HaveOccurred())
+			})
+
+			It("should not mark the process group as disk qualification failed", func() {
+				Expect(validateProcessGroups(context.TODO(), clusterReconciler, cluster, &cluster.Status, processMap, configMap, allPvcs, logger, "")).NotTo(HaveOccurred())
+
+				failedProcessGroups := fdbv1beta2.FilterByCondition(cluster.Status.ProcessGroups, fdbv1beta2.DiskQualificationFailed, false)
+				Expect(failedProcessGroups).To(BeEmpty())
+			})
+		})
+
+		When("a container of a process group is in the ImagePullBackOff state", func() {
+			BeforeEach(func() {
+				storagePod.Status.Phase = corev1.PodRunning
+				storagePod.Status.ContainerStatuses = []corev1.ContainerStatus{
+					{
+						Name: fdbv1beta2.MainContainerName,
+						State: corev1.ContainerState{
+							Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"},
+						},
+					},
+				}
+				Expect(k8sClient.Update(context.TODO(), storagePod)).NotTo(HaveOccurred())
+			})
+
+			When("the detection of stuck Pods is disabled", func() {
+				It("should not mark the process group as stuck", func() {
+					Expect(validateProcessGroups(context.TODO(), clusterReconciler, cluster, &cluster.Status, processMap, configMap, allPvcs, logger, "")).NotTo(HaveOccurred())
+
+					stuckProcessGroups := fdbv1beta2.FilterByCondition(cluster.Status.ProcessGroups, fdbv1beta2.PodStuck, false)
+					Expect(stuckProcessGroups).To(BeEmpty())
+				})
+			})
+
+			When("the detection of stuck Pods is enabled", func() {
+				BeforeEach(func() {
+					cluster.Spec.AutomationOptions.Replacements.FailureDetectionWindowSeconds = pointer.Int(600)
+				})
+
+				It("should mark the process group as stuck", func() {
+					Expect(validateProcessGroups(context.TODO(), clusterReconciler, cluster, &cluster.Status, processMap, configMap, allPvcs, logger, "")).NotTo(HaveOccurred())
+
+					stuckProcessGroups := fdbv1beta2.FilterByCondition(cluster.Status.ProcessGroups, fdbv1beta2.PodStuck, false)
+					Expect(stuckProcessGroups).To(Equal([]fdbv1beta2.ProcessGroupID{pickedProcessGroup.ProcessGroupID}))
+				})
+			})
+		})
+
+		When("the volume of a process group is bound to a node", func() {
+			var nodeName string
+
+			JustBeforeEach(func() {
+				desiredPvc, err := internal.GetPvc(cluster, pickedProcessGroup)
+				Expect(err).NotTo(HaveOccurred())
+
+				pvc := &corev1.PersistentVolumeClaim{}
+				Expect(k8sClient.Get(context.TODO(), ctrlClient.ObjectKeyFromObject(desiredPvc), pvc)).NotTo(HaveOccurred())
+				pvc.Annotations["volume.kubernetes.io/selected-node"] = nodeName
+				Expect(k8sClient.Update(context.TODO(), pvc)).NotTo(HaveOccurred())
+
+				allPvcs = &corev1.PersistentVolumeClaimList{}
+				Expect(clusterReconciler.List(context.TODO(), allPvcs, internal.GetPodListOptions(cluster, "", "")...)).NotTo(HaveOccurred())
+			})
+
+			When("the node exists", func() {
+				BeforeEach(func() {
+					nodeName = "local-volume-node"
+					Expect(k8sClient.Create(context.TODO(), &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}})).NotTo(HaveOccurred())
+				})
+
+				It("should set the volume node name without a condition", func() {
+					Expect(validateProcessGroups(context.TODO(), clusterReconciler, cluster, &cluster.Status, processMap, configMap, allPvcs, logger, "")).NotTo(HaveOccurred())
+					Expect(pickedProcessGroup.VolumeNodeName).To(Equal(nodeName))
+					Expect(fdbv1beta2.FilterByCondition(cluster.Status.ProcessGroups, fdbv1beta2.VolumeNodeMissing, false)).To(BeEmpty())
+				})
+			})
+
+			When("the node was removed", func() {
+				BeforeEach(func() {
+					nodeName = "removed-node"
+				})
+
+				It("should mark the process group with the VolumeNodeMissing condition", func() {
+					Expect(validateProcessGroups(context.TODO(), clusterReconciler, cluster, &cluster.Status, processMap, configMap, allPvcs, logger, "")).NotTo(HaveOccurred())
+					Expect(pickedProcessGroup.VolumeNodeName).To(Equal(nodeName))
+					Expect(fdbv1beta2.FilterByCondition(cluster.Status.ProcessGroups, fdbv1beta2.VolumeNodeMissing, false)).To(ConsistOf(pickedProcessGroup.ProcessGroupID))
+				})
+			})
+		})
 	})
 
 	Describe("Reconcile", func() {
@@ -733,6 +908,50 @@ var _ = Describe("update_status", func() {
 		}, "0", "7.1.15"),
 		Entry("when the versionMap is empty", map[string]int{}, "7.1.15", "7.1.15"))
 
+	DescribeTable("when checking if a node is being drained", func(options *fdbv1beta2.NodeDrainReplacementOptions, node *corev1.Node, expected bool) {
+		cluster := &fdbv1beta2.FoundationDBCluster{
+			Spec: fdbv1beta2.FoundationDBClusterSpec{
+				AutomationOptions: fdbv1beta2.FoundationDBClusterAutomationOptions{
+					Replacements: fdbv1beta2.AutomaticReplacementOptions{
+						NodeDrain: options,
+					},
+				},
+			},
+		}
+
+		draining, _ := nodeIsDraining(cluster, node)
+		Expect(draining).To(Equal(expected))
+	},
+		Entry("when the node is schedulable",
+			&fdbv1beta2.NodeDrainReplacementOptions{Enabled: pointer.Bool(true)},
+			&corev1.Node{},
+			false),
+		Entry("when the node is cordoned",
+			&fdbv1beta2.NodeDrainReplacementOptions{Enabled: pointer.Bool(true)},
+			&corev1.Node{Spec: corev1.NodeSpec{Unschedulable: true}},
+			true),
+		Entry("when the node is cordoned and cordoned nodes are ignored",
+			&fdbv1beta2.NodeDrainReplacementOptions{Enabled: pointer.Bool(true), DetectCordonedNodes: pointer.Bool(false)},
+			&corev1.Node{Spec: corev1.NodeSpec{Unschedulable: true}},
+			false),
+		Entry("when the node has the Karpenter disruption taint",
+			&fdbv1beta2.NodeDrainReplacementOptions{Enabled: pointer.Bool(true)},
+			&corev1.Node{Spec: corev1.NodeSpec{Taints: []corev1.Taint{{Key: "karpenter.sh/disruption", Value: "disrupting", Effect: corev1.TaintEffectNoSchedule}}}},
+			true),
+		Entry("when the node has the cluster-autoscaler taint",
+			&fdbv1beta2.NodeDrainReplacementOptions{Enabled: pointer.Bool(true)},
+			&corev1.Node{Spec: corev1.NodeSpec{Taints: []corev1.Taint{{Key: "ToBeDeletedByClusterAutoscaler", Effect: corev1.TaintEffectNoSchedule}}}},
+			true),
+		Entry("when the node has a different taint",
+			&fdbv1beta2.NodeDrainReplacementOptions{Enabled: pointer.Bool(true)},
+			&corev1.Node{Spec: corev1.NodeSpec{Taints: []corev1.Taint{{Key: "example.org/maintenance", Effect: corev1.TaintEffectNoSchedule}}}},
+			false),
+		Entry("when the node has a configured drain annotation",
+			&fdbv1beta2.NodeDrainReplacementOptions{Enabled: pointer.Bool(true), Annotations: []string{"example.org/drain"}},
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"example.org/drain": "true"}}},
+			true),
+	)
+
 	When("updating the fault domains based on the cluster status", func() {
 		var processes map[fdbv1beta2.ProcessGroupID][]fdbv1beta2.FoundationDBStatusProcessInfo
 		var status fdbv1beta2.FoundationDBClusterStatus
@@ -898,4 +1117,300 @@ var _ = Describe("update_status", func() {
 			})
 		})
 	})
+
+	When("updating the scale status", func() {
+		var cluster *fdbv1beta2.FoundationDBCluster
+		var status *fdbv1beta2.FoundationDBClusterStatus
+
+		BeforeEach(func() {
+			cluster = &fdbv1beta2.FoundationDBCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+				},
+			}
+
+			status = &fdbv1beta2.FoundationDBClusterStatus{
+				ProcessGroups: []*fdbv1beta2.ProcessGroupStatus{
+					{ProcessGroupID: "stateless-1", ProcessClass: fdbv1beta2.ProcessClassStateless},
+					{ProcessGroupID: "stateless-2", ProcessClass: fdbv1beta2.ProcessClassStateless},
+					{ProcessGroupID: "stateless-3", ProcessClass: fdbv1beta2.ProcessClassStateless, RemovalTimestamp: &metav1.Time{Time: time.Now()}},
+					{ProcessGroupID: "storage-1", ProcessClass: fdbv1beta2.ProcessClassStorage},
+				},
+			}
+
+			updateScaleStatus(cluster, status)
+		})
+
+		It("should update the scale status", func() {
+			Expect(status.StatelessProcessGroups).To(Equal(2))
+			Expect(status.StatelessSelector).To(Equal("foundationdb.org/fdb-cluster-name=test,foundationdb.org/fdb-process-class=stateless"))
+		})
+	})
+
+	When("resolving the connection string", func() {
+		var cluster *fdbv1beta2.FoundationDBCluster
+		var databaseStatus *fdbv1beta2.FoundationDBStatus
+		var configMap *corev1.ConfigMap
+		var reconciler *FoundationDBClusterReconciler
+		var connectionString string
+
+		BeforeEach(func() {
+			cluster = &fdbv1beta2.FoundationDBCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "test",
+					Namespace:  "test",
+					UID:        "test-uid",
+					Generation: 1,
+				},
+				Status: fdbv1beta2.FoundationDBClusterStatus{
+					ConnectionString: "test:abc@127.0.0.1:4500",
+				},
+			}
+			databaseStatus = &fdbv1beta2.FoundationDBStatus{
+				Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+					ConnectionString: "test:abc@127.0.0.1:4500",
+				},
+			}
+			configMap = &corev1.ConfigMap{
+				Data: map[string]string{
+					fdbv1beta2.ClusterFileKey: "test:abc@127.0.0.1:4500",
+				},
+			}
+			reconciler = createTestClusterReconciler()
+		})
+
+		JustBeforeEach(func() {
+			connectionString = reconciler.resolveConnectionString(logr.Discard(), cluster, databaseStatus, configMap)
+		})
+
+		When("the connection string cache is disabled", func() {
+			When("the live connection string diverges", func() {
+				BeforeEach(func() {
+					databaseStatus.Cluster.ConnectionString = "test:def@127.0.0.2:4500"
+				})
+
+				It("should use the connection string from the status", func() {
+					Expect(connectionString).To(Equal("test:abc@127.0.0.1:4500"))
+				})
+			})
+
+			When("the status has no connection string", func() {
+				BeforeEach(func() {
+					cluster.Status.ConnectionString = ""
+				})
+
+				It("should use the connection string from the ConfigMap", func() {
+					Expect(connectionString).To(Equal("test:abc@127.0.0.1:4500"))
+				})
+			})
+		})
+
+		When("the connection string cache is enabled", func() {
+			BeforeEach(func() {
+				reconciler.ConnectionStringCache = connectionstring.NewCache()
+				reconciler.ConnectionStringCache.Set(cluster, "test:abc@127.0.0.1:4500")
+			})
+
+			When("all connection strings match", func() {
+				It("should keep the cached connection string", func() {
+					Expect(connectionString).To(Equal("test:abc@127.0.0.1:4500"))
+					_, ok := reconciler.ConnectionStringCache.Get(cluster)
+					Expect(ok).To(BeTrue())
+				})
+			})
+
+			When("the live connection string diverges", func() {
+				BeforeEach(func() {
+					databaseStatus.Cluster.ConnectionString = "test:def@127.0.0.2:4500"
+				})
+
+				It("should use the live connection string and invalidate the cache", func() {
+					Expect(connectionString).To(Equal("test:def@127.0.0.2:4500"))
+					_, ok := reconciler.ConnectionStringCache.Get(cluster)
+					Expect(ok).To(BeFalse())
+				})
+			})
+		})
+	})
+
+	When("getting the image type migration status", func() {
+		var cluster *fdbv1beta2.FoundationDBCluster
+		var processGroups []*fdbv1beta2.ProcessGroupStatus
+		var imageTypes map[fdbv1beta2.ProcessGroupID]fdbv1beta2.ImageType
+		var migrationStatus *fdbv1beta2.ImageTypeMigrationStatus
+
+		BeforeEach(func() {
+			imageType := fdbv1beta2.ImageTypeUnified
+			cluster = &fdbv1beta2.FoundationDBCluster{
+				Spec: fdbv1beta2.FoundationDBClusterSpec{
+					ImageType: &imageType,
+				},
+			}
+
+			processGroups = []*fdbv1beta2.ProcessGroupStatus{
+				{ProcessGroupID: "storage-1", FaultDomain: "zone-a"},
+				{ProcessGroupID: "storage-2", FaultDomain: "zone-b"},
+				{ProcessGroupID: "storage-3", FaultDomain: "zone-c"},
+			}
+
+			imageTypes = map[fdbv1beta2.ProcessGroupID]fdbv1beta2.ImageType{
+				"storage-1": fdbv1beta2.ImageTypeUnified,
+				"storage-2": fdbv1beta2.ImageTypeSplit,
+				"storage-3": fdbv1beta2.ImageTypeSplit,
+			}
+		})
+
+		JustBeforeEach(func() {
+			migrationStatus = getImageTypeMigrationStatus(cluster, processGroups, imageTypes)
+		})
+
+		When("all process groups are migrated", func() {
+			BeforeEach(func() {
+				imageTypes["storage-2"] = fdbv1beta2.ImageTypeUnified
+				imageTypes["storage-3"] = fdbv1beta2.ImageTypeUnified
+			})
+
+			It("should return nil", func() {
+				Expect(migrationStatus).To(BeNil())
+			})
+		})
+
+		When("the migrated process groups are healthy", func() {
+			It("should select the next fault domain", func() {
+				Expect(migrationStatus).To(Equal(&fdbv1beta2.ImageTypeMigrationStatus{
+					TargetImageType:       fdbv1beta2.ImageTypeUnified,
+					FaultDomain:           "zone-b",
+					MigratedProcessGroups: 1,
+					PendingProcessGroups:  2,
+					Healthy:               true,
+				}))
+			})
+		})
+
+		When("a migrated process group is unhealthy", func() {
+			BeforeEach(func() {
+				processGroups[0].UpdateCondition(fdbv1beta2.SidecarUnreachable, true)
+			})
+
+			It("should wait before selecting the next fault domain", func() {
+				Expect(migrationStatus).NotTo(BeNil())
+				Expect(migrationStatus.Healthy).To(BeFalse())
+				Expect(migrationStatus.FaultDomain).To(BeEmpty())
+				Expect(migrationStatus.PendingProcessGroups).To(Equal(2))
+			})
+
+			When("a fault domain is currently migrated", func() {
+				BeforeEach(func() {
+					cluster.Status.ImageTypeMigration = &fdbv1beta2.ImageTypeMigrationStatus{
+						FaultDomain: "zone-c",
+					}
+				})
+
+				It("should keep the current fault domain", func() {
+					Expect(migrationStatus).NotTo(BeNil())
+					Expect(migrationStatus.Healthy).To(BeFalse())
+					Expect(migrationStatus.FaultDomain).To(Equal(fdbv1beta2.FaultDomain("zone-c")))
+				})
+			})
+		})
+
+		When("the pending process groups have no fault domain", func() {
+			BeforeEach(func() {
+				processGroups[1].FaultDomain = ""
+				processGroups[2].FaultDomain = ""
+			})
+
+			It("should migrate the process groups one by one", func() {
+				Expect(migrationStatus).NotTo(BeNil())
+				Expect(migrationStatus.Healthy).To(BeTrue())
+				Expect(migrationStatus.FaultDomain).To(Equal(fdbv1beta2.FaultDomain("storage-2")))
+				Expect(migrationStatus.PendingProcessGroups).To(Equal(2))
+			})
+		})
+
+		When("the migration is rolled back", func() {
+			BeforeEach(func() {
+				imageType := fdbv1beta2.ImageTypeSplit
+				cluster.Spec.ImageType = &imageType
+			})
+
+			It("should migrate the process groups back to the split image", func() {
+				Expect(migrationStatus).To(Equal(&fdbv1beta2.ImageTypeMigrationStatus{
+					TargetImageType:       fdbv1beta2.ImageTypeSplit,
+					FaultDomain:           "zone-a",
+					MigratedProcessGroups: 2,
+					PendingProcessGroups:  1,
+					Healthy:               true,
+				}))
+			})
+		})
+	})
+
+	When("getting the storage class migration status", func() {
+		var cluster *fdbv1beta2.FoundationDBCluster
+		var processGroups []*fdbv1beta2.ProcessGroupStatus
+		var pvcMap map[fdbv1beta2.ProcessGroupID]corev1.PersistentVolumeClaim
+		var migrationStatus []fdbv1beta2.StorageClassMigrationStatus
+
+		BeforeEach(func() {
+			cluster = &fdbv1beta2.FoundationDBCluster{
+				Spec: fdbv1beta2.FoundationDBClusterSpec{
+					Processes: map[fdbv1beta2.ProcessClass]fdbv1beta2.ProcessSettings{
+						fdbv1beta2.ProcessClassGeneral: {
+							VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+								Spec: corev1.PersistentVolumeClaimSpec{
+									StorageClassName: pointer.String("fast"),
+								},
+							},
+						},
+					},
+				},
+			}
+
+			processGroups = []*fdbv1beta2.ProcessGroupStatus{
+				{ProcessGroupID: "storage-1", ProcessClass: fdbv1beta2.ProcessClassStorage},
+				{ProcessGroupID: "storage-2", ProcessClass: fdbv1beta2.ProcessClassStorage},
+				{ProcessGroupID: "log-1", ProcessClass: fdbv1beta2.ProcessClassLog},
+			}
+
+			pvcMap = map[fdbv1beta2.ProcessGroupID]corev1.PersistentVolumeClaim{
+				"storage-1": {Spec: corev1.PersistentVolumeClaimSpec{StorageClassName: pointer.String("fast")}},
+				"storage-2": {Spec: corev1.PersistentVolumeClaimSpec{StorageClassName: pointer.String("slow")}},
+				"log-1":     {Spec: corev1.PersistentVolumeClaimSpec{StorageClassName: pointer.String("fast")}},
+			}
+		})
+
+		JustBeforeEach(func() {
+			migrationStatus = getStorageClassMigrationStatus(cluster, processGroups, pvcMap)
+		})
+
+		It("should report the progress of the process classes with pending migrations", func() {
+			Expect(migrationStatus).To(ConsistOf(fdbv1beta2.StorageClassMigrationStatus{
+				ProcessClass:           fdbv1beta2.ProcessClassStorage,
+				TargetStorageClassName: "fast",
+				MigratedProcessGroups:  1,
+				PendingProcessGroups:   1,
+			}))
+		})
+
+		When("the pending process group is marked for removal", func() {
+			BeforeEach(func() {
+				processGroups[1].MarkForRemoval()
+			})
+
+			It("should not report any migration", func() {
+				Expect(migrationStatus).To(BeEmpty())
+			})
+		})
+
+		When("no storage class is defined", func() {
+			BeforeEach(func() {
+				cluster.Spec.Processes = nil
+			})
+
+			It("should not report any migration", func() {
+				Expect(migrationStatus).To(BeEmpty())
+			})
+		})
+	})
 })
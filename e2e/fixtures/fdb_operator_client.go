@@ -122,6 +122,14 @@ rules:
   - get
   - update
   - patch
+- apiGroups:
+  - apps.foundationdb.org
+  resources:
+  - foundationdbclustertemplates
+  verbs:
+  - get
+  - list
+  - watch
 - apiGroups:
   - admissionregistration.k8s.io
   resources:
@@ -33,6 +33,7 @@ import (
 	"os/exec"
 	"path"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -92,10 +93,24 @@ type cliAdminClient struct {
 
 	// timeout defines the timeout that should be used for interacting with FDB.
 	timeout time.Duration
+
+	// pool is used to share the machine-readable status with other admin clients for the same cluster. If nil, the
+	// status will not be shared.
+	pool *connectionPool
 }
 
 // NewCliAdminClient generates an Admin client for a cluster
 func NewCliAdminClient(cluster *fdbv1beta2.FoundationDBCluster, _ client.Client, log logr.Logger) (fdbadminclient.AdminClient, error) {
+	adminClient, err := newCliAdminClient(cluster, log, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return adminClient, nil
+}
+
+// newCliAdminClient generates an Admin client for a cluster that uses the provided pool to share the status.
+func newCliAdminClient(cluster *fdbv1beta2.FoundationDBCluster, log logr.Logger, pool *connectionPool) (*cliAdminClient, error) {
 	clusterFile, err := createClusterFile(cluster)
 	if err != nil {
 		return nil, err
@@ -111,6 +126,7 @@ func NewCliAdminClient(cluster *fdbv1beta2.FoundationDBCluster, _ client.Client,
 			cluster: cluster,
 			logger:  logger,
 		},
+		pool: pool,
 	}, nil
 }
 
@@ -271,6 +287,11 @@ func (client *cliAdminClient) runCommand(command cliCommand) (string, error) {
 	}
 	client.log.Info("Command completed", "output", debugOutput)
 
+	// Make sure that other admin clients don't reuse a status that was fetched before the cluster was modified.
+	if client.pool != nil && command.command != "status json" {
+		client.pool.invalidateStatus(client.Cluster)
+	}
+
 	return outputString, nil
 }
 
@@ -313,6 +334,26 @@ func (client *cliAdminClient) getStatus() (*fdbv1beta2.FoundationDBStatus, error
 
 // GetStatus gets the database's status
 func (client *cliAdminClient) GetStatus() (*fdbv1beta2.FoundationDBStatus, error) {
+	if client.pool != nil {
+		return client.pool.getStatus(client.Cluster, client.fetchStatus)
+	}
+
+	return client.fetchStatus()
+}
+
+// fetchStatus fetches the database's status from the database.
+func (client *cliAdminClient) fetchStatus() (*fdbv1beta2.FoundationDBStatus, error) {
+	loaded, err := clientLibraryIsLoaded(client.Cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	// If no matching client library is loaded, fdbcli is used to fetch the status in a separate process.
+	if !loaded {
+		client.log.V(1).Info("no client library loaded for the running version, fetching status with fdbcli", "version", client.Cluster.GetRunningVersion())
+		return client.getStatus()
+	}
+
 	startTime := time.Now()
 	// This will call directly the database and fetch the status information from the system key space.
 	status, err := getStatusFromDB(client.fdbLibClient, client.log, client.getTimeout())
@@ -676,6 +717,36 @@ func (client *cliAdminClient) SetKnobs(knobs []string) {
 	client.knobs = knobs
 }
 
+// ReloadKnobs updates the provided knobs in the configuration database, so that the running fdbserver processes
+// reload them without a restart.
+func (client *cliAdminClient) ReloadKnobs(knobs map[string]string) error {
+	if len(knobs) == 0 {
+		return nil
+	}
+
+	_, err := client.runCommand(cliCommand{command: getReloadKnobsCommand(knobs)})
+	return err
+}
+
+// getReloadKnobsCommand returns the fdbcli command to set the provided knobs in a single configuration database
+// transaction. The knobs are sorted by name and the "knob_" prefix is removed, as fdbcli expects the plain knob name.
+func getReloadKnobsCommand(knobs map[string]string) string {
+	names := make([]string, 0, len(knobs))
+	for name := range knobs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	commands := make([]string, 0, len(knobs)+2)
+	commands = append(commands, "begin")
+	for _, name := range names {
+		commands = append(commands, fmt.Sprintf("setknob %s %s", strings.TrimPrefix(name, "knob_"), knobs[name]))
+	}
+	commands = append(commands, "commit")
+
+	return strings.Join(commands, "; ")
+}
+
 // WithValues will update the logger used by the current AdminClient to contain the provided key value pairs. The provided
 // arguments must be even.
 func (client *cliAdminClient) WithValues(keysAndValues ...interface{}) {
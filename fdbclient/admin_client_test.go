@@ -826,6 +826,49 @@ protocol fdb00b071010000`,
 		})
 	})
 
+	When("running a command with a shared connection pool", func() {
+		var pool *connectionPool
+		var cluster *fdbv1beta2.FoundationDBCluster
+
+		BeforeEach(func() {
+			tmpDir := GinkgoT().TempDir()
+			GinkgoT().Setenv("FDB_BINARY_DIR", tmpDir)
+
+			binaryDir := path.Join(tmpDir, "6.3")
+			Expect(os.MkdirAll(binaryDir, 0700)).NotTo(HaveOccurred())
+			_, err := os.Create(path.Join(binaryDir, fdbcliStr))
+			Expect(err).NotTo(HaveOccurred())
+
+			cluster = &fdbv1beta2.FoundationDBCluster{
+				Spec: fdbv1beta2.FoundationDBClusterSpec{
+					Version: "6.3.25",
+				},
+			}
+			pool = newConnectionPool(logr.Discard(), 0, time.Hour)
+			_, err = pool.getStatus(cluster, func() (*fdbv1beta2.FoundationDBStatus, error) {
+				return &fdbv1beta2.FoundationDBStatus{}, nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			cliClient := &cliAdminClient{
+				Cluster:         cluster,
+				clusterFilePath: "test",
+				log:             logr.Discard(),
+				cmdRunner: &mockCommandRunner{
+					mockedError:  nil,
+					mockedOutput: []string{""},
+				},
+				pool: pool,
+			}
+
+			Expect(cliClient.ResetMaintenanceMode()).NotTo(HaveOccurred())
+		})
+
+		It("should invalidate the shared status", func() {
+			Expect(pool.getEntry(cluster).status).To(BeNil())
+		})
+	})
+
 	When("checking if processes can safely be removed", func() {
 		var mockRunner *mockCommandRunner
 		var mockFdbClient *mockFdbLibClient
@@ -1167,6 +1210,25 @@ protocol fdb00b071010000`,
 		})
 	})
 
+	DescribeTable("getting the command to reload knobs", func(knobs map[string]string, expected string) {
+		Expect(getReloadKnobsCommand(knobs)).To(Equal(expected))
+	},
+		Entry("a single knob",
+			map[string]string{
+				"knob_min_trace_severity": "20",
+			},
+			"begin; setknob min_trace_severity 20; commit",
+		),
+		Entry("multiple knobs",
+			map[string]string{
+				"knob_target_bytes_per_tlog":       "2400000000",
+				"knob_min_trace_severity":          "20",
+				"knob_spring_bytes_storage_server": "100000000",
+			},
+			"begin; setknob min_trace_severity 20; setknob spring_bytes_storage_server 100000000; setknob target_bytes_per_tlog 2400000000; commit",
+		),
+	)
+
 	When("getting the version from the reachable coordinators", func() {
 		var mockRunner *mockCommandRunner
 		var version, previousBinary, newBinary, quorumReachableStatus, quorumNotReachableStatus string
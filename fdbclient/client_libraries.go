@@ -0,0 +1,173 @@
+/*
+ * client_libraries.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fdbclient
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	"sync"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/go-logr/logr"
+)
+
+const (
+	clientLibraryPrefix = "libfdb_c_"
+	clientLibrarySuffix = ".so"
+)
+
+// clientLibraryRegistry keeps track of the client libraries that are loaded by the multi-version client of the operator.
+type clientLibraryRegistry struct {
+	// lock protects the versions of the registry.
+	lock sync.RWMutex
+	// versions contains the versions of the loaded client libraries. If nil, all client libraries are assumed to be
+	// loaded.
+	versions []fdbv1beta2.Version
+}
+
+// loadedClientLibraries contains the client libraries that are loaded by the operator process.
+var loadedClientLibraries = &clientLibraryRegistry{}
+
+// SetLoadedClientLibraryVersions defines the versions of the client libraries that are loaded by the multi-version
+// client. Clusters running a version that is not protocol compatible with any of the loaded client libraries will be
+// accessed with the version specific fdbcli binaries, which run in a separate process. If this method is never called,
+// all client libraries are assumed to be loaded.
+func SetLoadedClientLibraryVersions(versions []fdbv1beta2.Version) {
+	loadedClientLibraries.lock.Lock()
+	defer loadedClientLibraries.lock.Unlock()
+
+	loadedClientLibraries.versions = versions
+}
+
+// clientLibraryIsLoaded returns true if a loaded client library is protocol compatible with the running version of the
+// cluster.
+func clientLibraryIsLoaded(cluster *fdbv1beta2.FoundationDBCluster) (bool, error) {
+	loadedClientLibraries.lock.RLock()
+	defer loadedClientLibraries.lock.RUnlock()
+
+	if loadedClientLibraries.versions == nil {
+		return true, nil
+	}
+
+	version, err := fdbv1beta2.ParseFdbVersion(cluster.GetRunningVersion())
+	if err != nil {
+		return false, err
+	}
+
+	for _, loadedVersion := range loadedClientLibraries.versions {
+		if loadedVersion.IsProtocolCompatible(version) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// GetClientLibraryFileName returns the file name of the client library for the provided version.
+func GetClientLibraryFileName(version fdbv1beta2.Version) string {
+	return fmt.Sprintf("%s%s%s", clientLibraryPrefix, version, clientLibrarySuffix)
+}
+
+// parseClientLibraryFileName returns the version of the client library file. If the file is not a versioned client
+// library, false will be returned.
+func parseClientLibraryFileName(fileName string) (fdbv1beta2.Version, bool) {
+	if !strings.HasPrefix(fileName, clientLibraryPrefix) || !strings.HasSuffix(fileName, clientLibrarySuffix) {
+		return fdbv1beta2.Version{}, false
+	}
+
+	version, err := fdbv1beta2.ParseFdbVersion(strings.TrimSuffix(strings.TrimPrefix(fileName, clientLibraryPrefix), clientLibrarySuffix))
+	if err != nil {
+		return fdbv1beta2.Version{}, false
+	}
+
+	return version, true
+}
+
+// LinkClientLibraries links the client libraries from the library store directory, that are protocol compatible with
+// one of the provided versions, into the external client directory. All other client libraries will be removed from
+// the external client directory, so they will not be loaded by the multi-version client. This method must be called
+// before the FDB network is started. The versions of the linked client libraries are returned.
+func LinkClientLibraries(log logr.Logger, storeDir string, externalClientDir string, versions []fdbv1beta2.Version) ([]fdbv1beta2.Version, error) {
+	storeEntries, err := os.ReadDir(storeDir)
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.MkdirAll(externalClientDir, os.ModeDir|os.ModePerm)
+	if err != nil {
+		return nil, err
+	}
+
+	externalClientEntries, err := os.ReadDir(externalClientDir)
+	if err != nil {
+		return nil, err
+	}
+
+	// Remove all client libraries from the external client directory to make sure only the required client libraries
+	// are loaded.
+	for _, entry := range externalClientEntries {
+		if _, ok := parseClientLibraryFileName(entry.Name()); !ok {
+			continue
+		}
+
+		err = os.Remove(path.Join(externalClientDir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	linkedVersions := make([]fdbv1beta2.Version, 0, len(versions))
+	for _, entry := range storeEntries {
+		libraryVersion, ok := parseClientLibraryFileName(entry.Name())
+		if !ok {
+			continue
+		}
+
+		required := false
+		for _, version := range versions {
+			if libraryVersion.IsProtocolCompatible(version) {
+				required = true
+				break
+			}
+		}
+
+		if !required {
+			log.V(1).Info("Skip client library that is not required by any cluster", "version", libraryVersion.String())
+			continue
+		}
+
+		currentPath := path.Join(storeDir, entry.Name())
+		newPath := path.Join(externalClientDir, entry.Name())
+		log.Info("Linking FDB client library", "currentPath", currentPath, "newPath", newPath)
+		err = os.Symlink(currentPath, newPath)
+		if err != nil && !errors.Is(err, fs.ErrExist) {
+			return nil, err
+		}
+
+		linkedVersions = append(linkedVersions, libraryVersion)
+	}
+
+	return linkedVersions, nil
+}
@@ -0,0 +1,131 @@
+/*
+ * client_libraries_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fdbclient
+
+import (
+	"os"
+	"path"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("client_libraries", func() {
+	When("linking the client libraries", func() {
+		var storeDir, externalClientDir string
+		var linkedVersions []fdbv1beta2.Version
+		var err error
+
+		BeforeEach(func() {
+			storeDir = GinkgoT().TempDir()
+			externalClientDir = GinkgoT().TempDir()
+
+			for _, versionString := range []string{"7.1.25", "7.1.57", "7.3.27"} {
+				version, err := fdbv1beta2.ParseFdbVersion(versionString)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(os.WriteFile(path.Join(storeDir, GetClientLibraryFileName(version)), []byte(versionString), 0644)).To(Succeed())
+			}
+
+			// A client library that is not required anymore.
+			Expect(os.WriteFile(path.Join(externalClientDir, "libfdb_c_6.3.25.so"), nil, 0644)).To(Succeed())
+			// A file that is not a client library.
+			Expect(os.WriteFile(path.Join(externalClientDir, "fdbcli"), nil, 0644)).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			version, parseErr := fdbv1beta2.ParseFdbVersion("7.1.33")
+			Expect(parseErr).NotTo(HaveOccurred())
+			linkedVersions, err = LinkClientLibraries(logr.Discard(), storeDir, externalClientDir, []fdbv1beta2.Version{version})
+		})
+
+		It("should only link the required client libraries", func() {
+			Expect(err).NotTo(HaveOccurred())
+
+			entries, err := os.ReadDir(externalClientDir)
+			Expect(err).NotTo(HaveOccurred())
+			names := make([]string, 0, len(entries))
+			for _, entry := range entries {
+				names = append(names, entry.Name())
+			}
+
+			Expect(names).To(ConsistOf("fdbcli", "libfdb_c_7.1.25.so", "libfdb_c_7.1.57.so"))
+			Expect(linkedVersions).To(HaveLen(2))
+
+			content, err := os.ReadFile(path.Join(externalClientDir, "libfdb_c_7.1.57.so"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal("7.1.57"))
+		})
+	})
+
+	When("checking if the client library is loaded", func() {
+		var cluster *fdbv1beta2.FoundationDBCluster
+
+		BeforeEach(func() {
+			cluster = &fdbv1beta2.FoundationDBCluster{
+				Spec: fdbv1beta2.FoundationDBClusterSpec{
+					Version: "7.1.25",
+				},
+			}
+		})
+
+		AfterEach(func() {
+			SetLoadedClientLibraryVersions(nil)
+		})
+
+		When("no loaded versions are defined", func() {
+			It("should assume that the client library is loaded", func() {
+				Expect(clientLibraryIsLoaded(cluster)).To(BeTrue())
+			})
+		})
+
+		When("a protocol compatible version is loaded", func() {
+			BeforeEach(func() {
+				version, err := fdbv1beta2.ParseFdbVersion("7.1.57")
+				Expect(err).NotTo(HaveOccurred())
+				SetLoadedClientLibraryVersions([]fdbv1beta2.Version{version})
+			})
+
+			It("should report that the client library is loaded", func() {
+				Expect(clientLibraryIsLoaded(cluster)).To(BeTrue())
+			})
+		})
+
+		When("no protocol compatible version is loaded", func() {
+			BeforeEach(func() {
+				cluster.Status.RunningVersion = "6.3.25"
+				version, err := fdbv1beta2.ParseFdbVersion("7.1.57")
+				Expect(err).NotTo(HaveOccurred())
+				SetLoadedClientLibraryVersions([]fdbv1beta2.Version{version})
+			})
+
+			It("should report that the client library is not loaded", func() {
+				Expect(clientLibraryIsLoaded(cluster)).To(BeFalse())
+			})
+
+			It("should not open the database", func() {
+				_, err := getFDBDatabase(cluster)
+				Expect(err).To(MatchError(ContainSubstring("no client library for version 6.3.25 is loaded")))
+			})
+		})
+	})
+})
@@ -85,6 +85,15 @@ func parseMachineReadableStatus(logger logr.Logger, contents []byte, checkForPro
 
 // getFDBDatabase opens an FDB database.
 func getFDBDatabase(cluster *fdbv1beta2.FoundationDBCluster) (fdb.Database, error) {
+	loaded, err := clientLibraryIsLoaded(cluster)
+	if err != nil {
+		return fdb.Database{}, err
+	}
+
+	if !loaded {
+		return fdb.Database{}, fmt.Errorf("no client library for version %s is loaded, the operator must be restarted to load the client library", cluster.GetRunningVersion())
+	}
+
 	clusterFile, err := createClusterFile(cluster)
 	if err != nil {
 		return fdb.Database{}, err
@@ -147,6 +156,8 @@ func getStatusFromDB(libClient fdbLibClient, logger logr.Logger, timeout time.Du
 type realDatabaseClientProvider struct {
 	// log implementation for logging output
 	log logr.Logger
+	// pool is shared by all admin clients created by this provider. If nil, every admin client is independent.
+	pool *connectionPool
 }
 
 // GetLockClient generates a client for working with locks through the database.
@@ -157,7 +168,16 @@ func (p *realDatabaseClientProvider) GetLockClient(cluster *fdbv1beta2.Foundatio
 // GetAdminClient generates a client for performing administrative actions
 // against the database.
 func (p *realDatabaseClientProvider) GetAdminClient(cluster *fdbv1beta2.FoundationDBCluster, kubernetesClient client.Client) (fdbadminclient.AdminClient, error) {
-	return NewCliAdminClient(cluster, kubernetesClient, p.log)
+	if p.pool == nil {
+		return NewCliAdminClient(cluster, kubernetesClient, p.log)
+	}
+
+	adminClient, err := newCliAdminClient(cluster, p.log, p.pool)
+	if err != nil {
+		return nil, err
+	}
+
+	return adminClient, nil
 }
 
 // NewDatabaseClientProvider generates a client provider for talking to real
@@ -167,3 +187,16 @@ func NewDatabaseClientProvider(log logr.Logger) fdbadminclient.DatabaseClientPro
 		log: log.WithName("fdbclient"),
 	}
 }
+
+// NewSharedDatabaseClientProvider generates a client provider for talking to real databases that should be shared
+// between multiple controllers. All admin clients for the same cluster share the requests for the machine-readable
+// status, a fetched status will be reused for the statusCacheDuration. The shared state of a cluster will be evicted if
+// no admin client was requested for the cluster for the idleTimeout.
+func NewSharedDatabaseClientProvider(log logr.Logger, idleTimeout time.Duration, statusCacheDuration time.Duration) fdbadminclient.DatabaseClientProvider {
+	logger := log.WithName("fdbclient")
+
+	return &realDatabaseClientProvider{
+		log:  logger,
+		pool: newConnectionPool(logger.WithName("pool"), idleTimeout, statusCacheDuration),
+	}
+}
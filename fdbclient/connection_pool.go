@@ -0,0 +1,138 @@
+/*
+ * connection_pool.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fdbclient
+
+import (
+	"sync"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// connectionPool shares the machine-readable status of a FoundationDB cluster between all admin clients that are
+// created by the same DatabaseClientProvider, e.g. the admin clients of the cluster, backup and restore controllers.
+// Concurrent status requests for the same cluster will be served by a single request against the database. The entries
+// of the pool are keyed by the UID of the cluster and will be evicted if they were not used for the idle timeout.
+type connectionPool struct {
+	// lock protects the entries of the pool.
+	lock sync.Mutex
+	// entries contains the pool entry for every cluster.
+	entries map[types.UID]*connectionPoolEntry
+	// idleTimeout defines after which duration an unused entry will be evicted. A value of 0 disables the eviction.
+	idleTimeout time.Duration
+	// statusCacheDuration defines how long a fetched status will be reused for new requests.
+	statusCacheDuration time.Duration
+	// now returns the current time, this allows to modify the time in tests.
+	now func() time.Time
+	// log implementation for logging output
+	log logr.Logger
+}
+
+// connectionPoolEntry contains the shared state for a single cluster.
+type connectionPoolEntry struct {
+	// statusLock serializes the status requests for the cluster and protects the cached status.
+	statusLock sync.Mutex
+	// status is the last fetched status.
+	status *fdbv1beta2.FoundationDBStatus
+	// statusFetchedAt is the time when the request for the last status completed.
+	statusFetchedAt time.Time
+	// connectionString is the connection string that was used to fetch the last status.
+	connectionString string
+	// lastUsed is the last time the entry was requested, this field is protected by the lock of the pool.
+	lastUsed time.Time
+}
+
+// newConnectionPool creates a new connectionPool.
+func newConnectionPool(log logr.Logger, idleTimeout time.Duration, statusCacheDuration time.Duration) *connectionPool {
+	return &connectionPool{
+		entries:             map[types.UID]*connectionPoolEntry{},
+		idleTimeout:         idleTimeout,
+		statusCacheDuration: statusCacheDuration,
+		now:                 time.Now,
+		log:                 log,
+	}
+}
+
+// getEntry returns the entry for the provided cluster and evicts all entries that exceeded the idle timeout.
+func (pool *connectionPool) getEntry(cluster *fdbv1beta2.FoundationDBCluster) *connectionPoolEntry {
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+
+	now := pool.now()
+	if pool.idleTimeout > 0 {
+		for uid, entry := range pool.entries {
+			if now.Sub(entry.lastUsed) > pool.idleTimeout {
+				pool.log.V(1).Info("Evict idle connection pool entry", "uid", uid, "lastUsed", entry.lastUsed.String())
+				delete(pool.entries, uid)
+			}
+		}
+	}
+
+	entry, ok := pool.entries[cluster.UID]
+	if !ok {
+		entry = &connectionPoolEntry{}
+		pool.entries[cluster.UID] = entry
+	}
+	entry.lastUsed = now
+
+	return entry
+}
+
+// getStatus returns the status of the provided cluster. If another request for the status completed after this call
+// was made or within the status cache duration, the status of this request will be reused. Otherwise, the status will
+// be fetched with the provided function.
+func (pool *connectionPool) getStatus(cluster *fdbv1beta2.FoundationDBCluster, fetchStatus func() (*fdbv1beta2.FoundationDBStatus, error)) (*fdbv1beta2.FoundationDBStatus, error) {
+	requestTime := pool.now()
+	entry := pool.getEntry(cluster)
+
+	entry.statusLock.Lock()
+	defer entry.statusLock.Unlock()
+
+	if entry.status != nil && entry.connectionString == cluster.Status.ConnectionString && !entry.statusFetchedAt.Before(requestTime.Add(-pool.statusCacheDuration)) {
+		pool.log.V(1).Info("Reuse shared machine-readable status", "namespace", cluster.Namespace, "cluster", cluster.Name, "fetchedAt", entry.statusFetchedAt.String())
+		return entry.status.DeepCopy(), nil
+	}
+
+	status, err := fetchStatus()
+	if err != nil {
+		entry.status = nil
+		return nil, err
+	}
+
+	entry.status = status
+	entry.statusFetchedAt = pool.now()
+	entry.connectionString = cluster.Status.ConnectionString
+
+	return status.DeepCopy(), nil
+}
+
+// invalidateStatus removes the shared status of the provided cluster, this should be called after a command that
+// modifies the cluster was executed.
+func (pool *connectionPool) invalidateStatus(cluster *fdbv1beta2.FoundationDBCluster) {
+	entry := pool.getEntry(cluster)
+
+	entry.statusLock.Lock()
+	defer entry.statusLock.Unlock()
+
+	entry.status = nil
+}
@@ -0,0 +1,173 @@
+/*
+ * connection_pool_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fdbclient
+
+import (
+	"fmt"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("connection_pool", func() {
+	var pool *connectionPool
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var currentTime time.Time
+	var fetchCalls int
+
+	fetchStatus := func() (*fdbv1beta2.FoundationDBStatus, error) {
+		fetchCalls++
+		status := &fdbv1beta2.FoundationDBStatus{}
+		status.Cluster.Generation = fetchCalls
+
+		return status, nil
+	}
+
+	BeforeEach(func() {
+		fetchCalls = 0
+		currentTime = time.Now()
+		pool = newConnectionPool(logr.Discard(), 10*time.Minute, 0)
+		pool.now = func() time.Time {
+			return currentTime
+		}
+		cluster = &fdbv1beta2.FoundationDBCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "test",
+				UID:       "test-uid",
+			},
+			Status: fdbv1beta2.FoundationDBClusterStatus{
+				ConnectionString: "test:test@127.0.0.1:4500",
+			},
+		}
+	})
+
+	When("fetching the status", func() {
+		var status *fdbv1beta2.FoundationDBStatus
+
+		JustBeforeEach(func() {
+			var err error
+			status, err = pool.getStatus(cluster, fetchStatus)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.Cluster.Generation).To(Equal(1))
+		})
+
+		It("should fetch the status", func() {
+			Expect(fetchCalls).To(Equal(1))
+			Expect(pool.entries).To(HaveKey(cluster.UID))
+		})
+
+		When("the status is requested again at the same time", func() {
+			It("should reuse the status", func() {
+				newStatus, err := pool.getStatus(cluster, fetchStatus)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(newStatus).To(Equal(status))
+				Expect(newStatus).NotTo(BeIdenticalTo(status))
+				Expect(fetchCalls).To(Equal(1))
+			})
+		})
+
+		When("the status is requested again later", func() {
+			It("should fetch a new status", func() {
+				currentTime = currentTime.Add(time.Second)
+				newStatus, err := pool.getStatus(cluster, fetchStatus)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(newStatus.Cluster.Generation).To(Equal(2))
+				Expect(fetchCalls).To(Equal(2))
+			})
+
+			When("a status cache duration is defined", func() {
+				BeforeEach(func() {
+					pool.statusCacheDuration = time.Minute
+				})
+
+				It("should reuse the status", func() {
+					currentTime = currentTime.Add(time.Second)
+					newStatus, err := pool.getStatus(cluster, fetchStatus)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(newStatus.Cluster.Generation).To(Equal(1))
+					Expect(fetchCalls).To(Equal(1))
+				})
+
+				When("the connection string has changed", func() {
+					It("should fetch a new status", func() {
+						cluster.Status.ConnectionString = "test:test@127.0.0.2:4500"
+						newStatus, err := pool.getStatus(cluster, fetchStatus)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(newStatus.Cluster.Generation).To(Equal(2))
+					})
+				})
+
+				When("the status was invalidated", func() {
+					It("should fetch a new status", func() {
+						pool.invalidateStatus(cluster)
+						newStatus, err := pool.getStatus(cluster, fetchStatus)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(newStatus.Cluster.Generation).To(Equal(2))
+					})
+				})
+			})
+		})
+
+		When("the status request fails", func() {
+			It("should not reuse the previous status", func() {
+				_, err := pool.getStatus(cluster, func() (*fdbv1beta2.FoundationDBStatus, error) {
+					return nil, fmt.Errorf("test error")
+				})
+				Expect(err).To(HaveOccurred())
+
+				newStatus, err := pool.getStatus(cluster, fetchStatus)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(newStatus.Cluster.Generation).To(Equal(2))
+			})
+		})
+
+		When("another cluster is requested after the idle timeout", func() {
+			It("should evict the idle entry", func() {
+				currentTime = currentTime.Add(11 * time.Minute)
+				otherCluster := cluster.DeepCopy()
+				otherCluster.UID = "other-uid"
+				pool.getEntry(otherCluster)
+				Expect(pool.entries).NotTo(HaveKey(cluster.UID))
+				Expect(pool.entries).To(HaveKey(otherCluster.UID))
+			})
+
+			When("the eviction is disabled", func() {
+				BeforeEach(func() {
+					pool.idleTimeout = 0
+				})
+
+				It("should keep the idle entry", func() {
+					currentTime = currentTime.Add(11 * time.Minute)
+					otherCluster := cluster.DeepCopy()
+					otherCluster.UID = "other-uid"
+					pool.getEntry(otherCluster)
+					Expect(pool.entries).To(HaveKey(cluster.UID))
+					Expect(pool.entries).To(HaveKey(otherCluster.UID))
+				})
+			})
+		})
+	})
+})
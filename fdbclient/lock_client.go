@@ -21,6 +21,7 @@
 package fdbclient
 
 import (
+	"bytes"
 	"fmt"
 	"time"
 
@@ -301,6 +302,100 @@ func (client *realLockClient) UpdateDenyList(locks []fdbv1beta2.LockDenyListEntr
 	return err
 }
 
+// UpdateReplacements refreshes the leases of the provided in-flight replacements of the current operator instance
+// in the global replacement budget and removes all other replacements of the current operator instance.
+func (client *realLockClient) UpdateReplacements(processGroupIDs []fdbv1beta2.ProcessGroupID, leaseDuration time.Duration) error {
+	if client.disableLocks {
+		return nil
+	}
+
+	_, err := client.database.Transact(func(tr fdb.Transaction) (interface{}, error) {
+		err := tr.Options().SetAccessSystemKeys()
+		if err != nil {
+			return nil, err
+		}
+
+		keyRange, err := fdb.PrefixRange([]byte(fmt.Sprintf("%s/replacements/%s/", client.cluster.GetLockPrefix(), client.cluster.GetLockID())))
+		if err != nil {
+			return nil, err
+		}
+
+		tr.ClearRange(keyRange)
+		expiry := tuple.Tuple{time.Now().Add(leaseDuration).Unix()}.Pack()
+		for _, processGroupID := range processGroupIDs {
+			tr.Set(client.getReplacementKey(processGroupID), expiry)
+		}
+
+		return nil, nil
+	})
+
+	return err
+}
+
+// ReserveReplacement registers the replacement of the process group in the global replacement budget, if less
+// than maxReplacements replacements of all operator instances are in flight. Replacements with an expired lease
+// are not counted. The returned bool will be true if the replacement was registered.
+func (client *realLockClient) ReserveReplacement(processGroupID fdbv1beta2.ProcessGroupID, maxReplacements int, leaseDuration time.Duration) (bool, error) {
+	if client.disableLocks {
+		return true, nil
+	}
+
+	reserved, err := client.database.Transact(func(tr fdb.Transaction) (interface{}, error) {
+		err := tr.Options().SetAccessSystemKeys()
+		if err != nil {
+			return false, err
+		}
+
+		keyRange, err := fdb.PrefixRange([]byte(fmt.Sprintf("%s/replacements/", client.cluster.GetLockPrefix())))
+		if err != nil {
+			return false, err
+		}
+
+		now := time.Now()
+		key := client.getReplacementKey(processGroupID)
+		inFlight := 0
+		for _, kv := range tr.GetRange(keyRange, fdb.RangeOptions{}).GetSliceOrPanic() {
+			if bytes.Equal(kv.Key, key) {
+				continue
+			}
+
+			expiryTuple, err := tuple.Unpack(kv.Value)
+			if err != nil || len(expiryTuple) < 1 {
+				return false, invalidLockValue{key: kv.Key, value: kv.Value}
+			}
+
+			expiry, valid := expiryTuple[0].(int64)
+			if !valid {
+				return false, invalidLockValue{key: kv.Key, value: kv.Value}
+			}
+
+			if expiry >= now.Unix() {
+				inFlight++
+			}
+		}
+
+		if inFlight >= maxReplacements {
+			client.log.Info("Global replacement budget is exhausted", "namespace", client.cluster.Namespace, "cluster", client.cluster.Name, "processGroupID", processGroupID, "inFlight", inFlight, "maxReplacements", maxReplacements)
+			return false, nil
+		}
+
+		tr.Set(key, tuple.Tuple{now.Add(leaseDuration).Unix()}.Pack())
+
+		return true, nil
+	})
+
+	if reserved == nil {
+		return false, err
+	}
+
+	return reserved.(bool), err
+}
+
+// getReplacementKey defines the key of the replacement of a process group in the global replacement budget.
+func (client *realLockClient) getReplacementKey(processGroupID fdbv1beta2.ProcessGroupID) fdb.Key {
+	return fdb.Key(fmt.Sprintf("%s/replacements/%s/%s", client.cluster.GetLockPrefix(), client.cluster.GetLockID(), processGroupID))
+}
+
 // getDenyListKeyRange defines a key range containing the full deny list.
 func (client *realLockClient) getDenyListKeyRange() (fdb.KeyRange, error) {
 	keyPrefix := []byte(fmt.Sprintf("%s/denyList/", client.cluster.GetLockPrefix()))
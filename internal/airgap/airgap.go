@@ -0,0 +1,257 @@
+/*
+ * airgap.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package airgap
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// MainContainerImageConfigsKey defines the key in the image config ConfigMap that contains the image configs for
+	// the main container.
+	MainContainerImageConfigsKey = "mainContainerImageConfigs"
+
+	// SidecarContainerImageConfigsKey defines the key in the image config ConfigMap that contains the image configs
+	// for the sidecar container.
+	SidecarContainerImageConfigsKey = "sidecarContainerImageConfigs"
+
+	// defaultRegistry is the registry that will be used by the container runtime if an image contains no registry.
+	defaultRegistry = "docker.io"
+)
+
+// Options defines the settings for operating the operator in an air-gapped environment.
+type Options struct {
+	// Enabled defines if the operator runs in air-gapped mode. In air-gapped mode all images must be pulled from one
+	// of the allowed registries and the operator must not depend on endpoints outside the Kubernetes cluster.
+	Enabled bool
+	// Registries is a comma separated list of registries, optionally with a repository path, from which images are
+	// allowed to be pulled in air-gapped mode.
+	Registries string
+	// ImageConfigMap defines the ConfigMap, in the format "namespace/name" or "name", that provides the image configs
+	// in air-gapped mode. If no namespace is provided, the namespace of the cluster will be used.
+	ImageConfigMap string
+}
+
+// GetRegistries returns the allowed registries.
+func (options Options) GetRegistries() []string {
+	var registries []string
+	for _, registry := range strings.Split(options.Registries, ",") {
+		registry = strings.TrimSuffix(strings.TrimSpace(registry), "/")
+		if registry == "" {
+			continue
+		}
+
+		registries = append(registries, registry)
+	}
+
+	return registries
+}
+
+// getImageConfigMapName returns the namespaced name of the image config ConfigMap for the provided cluster.
+func (options Options) getImageConfigMapName(cluster *fdbv1beta2.FoundationDBCluster) types.NamespacedName {
+	namespace, name, found := strings.Cut(options.ImageConfigMap, "/")
+	if !found {
+		return types.NamespacedName{Namespace: cluster.Namespace, Name: namespace}
+	}
+
+	return types.NamespacedName{Namespace: namespace, Name: name}
+}
+
+// LoadImageConfigs adds the image configs from the image config ConfigMap to the in-memory spec of the cluster. The
+// image configs of the ConfigMap are added after the image configs of the cluster spec, so the image configs of the
+// cluster spec take precedence. This method must be called before the cluster spec is normalized, otherwise the
+// default image configs, that reference public registries, would take precedence.
+func LoadImageConfigs(ctx context.Context, reader client.Reader, options Options, cluster *fdbv1beta2.FoundationDBCluster) error {
+	if !options.Enabled || options.ImageConfigMap == "" {
+		return nil
+	}
+
+	configMapName := options.getImageConfigMapName(cluster)
+	configMap := &corev1.ConfigMap{}
+	err := reader.Get(ctx, configMapName, configMap)
+	if err != nil {
+		return fmt.Errorf("could not load image configs from ConfigMap %s: %w", configMapName.String(), err)
+	}
+
+	mainContainerImageConfigs, err := parseImageConfigs(configMap, MainContainerImageConfigsKey)
+	if err != nil {
+		return err
+	}
+
+	sidecarContainerImageConfigs, err := parseImageConfigs(configMap, SidecarContainerImageConfigsKey)
+	if err != nil {
+		return err
+	}
+
+	cluster.Spec.MainContainer.ImageConfigs = append(cluster.Spec.MainContainer.ImageConfigs, mainContainerImageConfigs...)
+	cluster.Spec.SidecarContainer.ImageConfigs = append(cluster.Spec.SidecarContainer.ImageConfigs, sidecarContainerImageConfigs...)
+
+	return nil
+}
+
+// parseImageConfigs parses the image configs stored in the provided key of the ConfigMap.
+func parseImageConfigs(configMap *corev1.ConfigMap, key string) ([]fdbv1beta2.ImageConfig, error) {
+	data, ok := configMap.Data[key]
+	if !ok {
+		return nil, nil
+	}
+
+	var imageConfigs []fdbv1beta2.ImageConfig
+	err := yaml.Unmarshal([]byte(data), &imageConfigs)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse %s from ConfigMap %s/%s: %w", key, configMap.Namespace, configMap.Name, err)
+	}
+
+	return imageConfigs, nil
+}
+
+// Validate returns an error if the cluster would require access to resources outside the Kubernetes cluster. All
+// images of the Pods, for the running and the desired version, must be pulled from one of the allowed registries and
+// the safety interlock endpoint must be served inside the Kubernetes cluster.
+func Validate(cluster *fdbv1beta2.FoundationDBCluster, options Options) error {
+	if !options.Enabled {
+		return nil
+	}
+
+	images, err := getImages(cluster)
+	if err != nil {
+		return err
+	}
+
+	registries := options.GetRegistries()
+	var validations []string
+	for _, image := range images {
+		if !imageAllowed(image, registries) {
+			validations = append(validations, fmt.Sprintf("image %s is not pulled from one of the allowed registries %v", image, registries))
+		}
+	}
+
+	if cluster.UseSafetyInterlock() && !isClusterLocalURL(cluster.Spec.AutomationOptions.SafetyInterlock.URL) {
+		validations = append(validations, fmt.Sprintf("safety interlock URL %s is not served inside the Kubernetes cluster", cluster.Spec.AutomationOptions.SafetyInterlock.URL))
+	}
+
+	if len(validations) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("cluster requires access outside of the air-gapped environment: %s", strings.Join(validations, ", "))
+}
+
+// getImages returns the sorted images of all containers of the Pods of the cluster for the running version and, if a
+// different version is desired, for the desired version.
+func getImages(cluster *fdbv1beta2.FoundationDBCluster) ([]string, error) {
+	clusters := []*fdbv1beta2.FoundationDBCluster{cluster}
+	if cluster.Status.RunningVersion != "" && cluster.Status.RunningVersion != cluster.Spec.Version {
+		desired := cluster.DeepCopy()
+		desired.Status.RunningVersion = desired.Spec.Version
+		clusters = append(clusters, desired)
+	}
+
+	counts, err := cluster.GetProcessCountsWithDefaults()
+	if err != nil {
+		return nil, err
+	}
+
+	images := map[string]fdbv1beta2.None{}
+	for _, current := range clusters {
+		for processClass, count := range counts.Map() {
+			if count == 0 {
+				continue
+			}
+
+			processGroup := fdbv1beta2.NewProcessGroupStatus(fdbv1beta2.ProcessGroupID(fmt.Sprintf("%s-1", processClass)), processClass, nil)
+			spec, err := internal.GetPodSpec(current, processGroup)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, container := range append(spec.InitContainers, spec.Containers...) {
+				images[container.Image] = fdbv1beta2.None{}
+			}
+		}
+	}
+
+	result := make([]string, 0, len(images))
+	for image := range images {
+		result = append(result, image)
+	}
+	sort.Strings(result)
+
+	return result, nil
+}
+
+// imageAllowed returns true if the image is pulled from one of the allowed registries. Images without an explicit
+// registry are pulled from Docker Hub and are only allowed if Docker Hub is an allowed registry.
+func imageAllowed(image string, registries []string) bool {
+	if !hasRegistry(image) {
+		image = defaultRegistry + "/" + image
+	}
+
+	for _, registry := range registries {
+		if strings.HasPrefix(image, registry+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasRegistry returns true if the image contains a registry, following the rules of the container runtimes: the first
+// component of the image name is a registry, if it contains a "." or a ":" or is "localhost".
+func hasRegistry(image string) bool {
+	component, _, found := strings.Cut(image, "/")
+	if !found {
+		return false
+	}
+
+	return strings.ContainsAny(component, ".:") || component == "localhost"
+}
+
+// isClusterLocalURL returns true if the host of the URL is a Kubernetes service or a single-label host name.
+func isClusterLocalURL(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+
+	host := parsed.Hostname()
+	if host == "" || net.ParseIP(host) != nil {
+		return false
+	}
+
+	if !strings.Contains(host, ".") {
+		return true
+	}
+
+	return strings.HasSuffix(host, ".svc") || strings.Contains(host, ".svc.")
+}
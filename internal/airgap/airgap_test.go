@@ -0,0 +1,191 @@
+/*
+ * airgap_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package airgap
+
+import (
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("airgap", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var options Options
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		options = Options{
+			Enabled:        true,
+			Registries:     "registry.local:5000/foundationdb",
+			ImageConfigMap: "fdb-images",
+		}
+	})
+
+	When("loading the image configs", func() {
+		var err error
+
+		JustBeforeEach(func() {
+			err = LoadImageConfigs(context.Background(), k8sClient, options, cluster)
+		})
+
+		When("the ConfigMap exists", func() {
+			BeforeEach(func() {
+				Expect(k8sClient.Create(context.Background(), &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: cluster.Namespace,
+						Name:      "fdb-images",
+					},
+					Data: map[string]string{
+						MainContainerImageConfigsKey:    "- baseImage: registry.local:5000/foundationdb/foundationdb\n",
+						SidecarContainerImageConfigsKey: "- baseImage: registry.local:5000/foundationdb/foundationdb-kubernetes-sidecar\n  tagSuffix: \"-1\"\n",
+					},
+				})).To(Succeed())
+			})
+
+			It("should add the image configs", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cluster.Spec.MainContainer.ImageConfigs).To(ConsistOf(fdbv1beta2.ImageConfig{BaseImage: "registry.local:5000/foundationdb/foundationdb"}))
+				Expect(cluster.Spec.SidecarContainer.ImageConfigs).To(ConsistOf(fdbv1beta2.ImageConfig{BaseImage: "registry.local:5000/foundationdb/foundationdb-kubernetes-sidecar", TagSuffix: "-1"}))
+			})
+
+			It("should pass the validation after the cluster spec is normalized", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(internal.NormalizeClusterSpec(cluster, internal.DeprecationOptions{})).To(Succeed())
+				Expect(Validate(cluster, options)).To(Succeed())
+			})
+
+			When("the cluster spec defines image configs", func() {
+				BeforeEach(func() {
+					cluster.Spec.MainContainer.ImageConfigs = []fdbv1beta2.ImageConfig{{BaseImage: "registry.local:5000/foundationdb/custom"}}
+				})
+
+				It("should keep the image configs of the cluster spec first", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(cluster.Spec.MainContainer.ImageConfigs).To(Equal([]fdbv1beta2.ImageConfig{
+						{BaseImage: "registry.local:5000/foundationdb/custom"},
+						{BaseImage: "registry.local:5000/foundationdb/foundationdb"},
+					}))
+				})
+			})
+		})
+
+		When("the ConfigMap is missing", func() {
+			It("should return an error", func() {
+				Expect(err).To(MatchError(ContainSubstring("could not load image configs from ConfigMap my-ns/fdb-images")))
+			})
+		})
+
+		When("the air-gapped mode is disabled", func() {
+			BeforeEach(func() {
+				options.Enabled = false
+			})
+
+			It("should not load the image configs", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cluster.Spec.MainContainer.ImageConfigs).To(BeEmpty())
+			})
+		})
+	})
+
+	When("validating the cluster", func() {
+		BeforeEach(func() {
+			Expect(internal.NormalizeClusterSpec(cluster, internal.DeprecationOptions{})).To(Succeed())
+		})
+
+		When("the default images are used", func() {
+			It("should return an error for the public images", func() {
+				err := Validate(cluster, options)
+				Expect(err).To(MatchError(ContainSubstring("image foundationdb/foundationdb:" + cluster.Spec.Version + " is not pulled from one of the allowed registries")))
+			})
+
+			When("Docker Hub is an allowed registry", func() {
+				BeforeEach(func() {
+					options.Registries = "docker.io/foundationdb"
+				})
+
+				It("should pass the validation", func() {
+					Expect(Validate(cluster, options)).To(Succeed())
+				})
+			})
+
+			When("the air-gapped mode is disabled", func() {
+				BeforeEach(func() {
+					options.Enabled = false
+				})
+
+				It("should pass the validation", func() {
+					Expect(Validate(cluster, options)).To(Succeed())
+				})
+			})
+		})
+
+		When("a safety interlock endpoint is defined", func() {
+			BeforeEach(func() {
+				options.Registries = "docker.io/foundationdb"
+			})
+
+			DescribeTable("validating the endpoint",
+				func(url string, expected bool) {
+					cluster.Spec.AutomationOptions.SafetyInterlock.URL = url
+					err := Validate(cluster, options)
+					if expected {
+						Expect(err).NotTo(HaveOccurred())
+						return
+					}
+
+					Expect(err).To(MatchError(ContainSubstring("safety interlock URL " + url + " is not served inside the Kubernetes cluster")))
+				},
+				Entry("a service in the same namespace", "http://freeze-api/status", true),
+				Entry("a service in a different namespace", "http://freeze-api.ops.svc:8080/status", true),
+				Entry("a fully qualified service", "http://freeze-api.ops.svc.cluster.local/status", true),
+				Entry("an external endpoint", "https://freeze.example.com/status", false),
+				Entry("an IP address", "http://10.0.0.1/status", false),
+			)
+		})
+	})
+
+	DescribeTable("checking if an image is allowed",
+		func(image string, registries []string, expected bool) {
+			Expect(imageAllowed(image, registries)).To(Equal(expected))
+		},
+		Entry("image from an allowed registry", "registry.local:5000/foundationdb/foundationdb:7.1.26", []string{"registry.local:5000"}, true),
+		Entry("image from an allowed repository", "registry.local:5000/foundationdb/foundationdb:7.1.26", []string{"registry.local:5000/foundationdb"}, true),
+		Entry("image from a different repository", "registry.local:5000/other/foundationdb:7.1.26", []string{"registry.local:5000/foundationdb"}, false),
+		Entry("image from a registry with the same prefix", "registry.local.example.com/foundationdb/foundationdb:7.1.26", []string{"registry.local"}, false),
+		Entry("image without a registry", "foundationdb/foundationdb:7.1.26", []string{"registry.local"}, false),
+		Entry("image without a registry and Docker Hub allowed", "foundationdb/foundationdb:7.1.26", []string{"docker.io"}, true),
+		Entry("image with an explicit Docker Hub registry", "docker.io/foundationdb/foundationdb:7.1.26", []string{"docker.io"}, true),
+		Entry("image from localhost", "localhost/foundationdb:7.1.26", []string{"localhost"}, true),
+	)
+
+	DescribeTable("parsing the allowed registries",
+		func(registries string, expected []string) {
+			Expect(Options{Registries: registries}.GetRegistries()).To(Equal(expected))
+		},
+		Entry("no registries", "", nil),
+		Entry("multiple registries", "registry.local, registry.backup:5000/fdb/", []string{"registry.local", "registry.backup:5000/fdb"}),
+	)
+})
@@ -0,0 +1,184 @@
+/*
+ * clustertemplate.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package clustertemplate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Resolve merges the spec of the FoundationDBClusterTemplate that is referenced in the templateRef of the cluster under
+// the spec of the cluster. Only the in-memory representation of the cluster is modified.
+func Resolve(ctx context.Context, reader client.Reader, cluster *fdbv1beta2.FoundationDBCluster) error {
+	_, err := resolve(ctx, reader, cluster)
+	return err
+}
+
+// GetStatus returns the information about the FoundationDBClusterTemplate that is merged into the spec of the cluster.
+// The spec of the provided cluster could already be resolved, so the stored cluster is used to determine the inherited
+// fields. If the cluster doesn't reference a template nil will be returned.
+func GetStatus(ctx context.Context, reader client.Reader, cluster *fdbv1beta2.FoundationDBCluster) (*fdbv1beta2.ClusterTemplateStatus, error) {
+	if cluster.Spec.TemplateRef == nil {
+		return nil, nil
+	}
+
+	storedCluster := &fdbv1beta2.FoundationDBCluster{}
+	err := reader.Get(ctx, client.ObjectKeyFromObject(cluster), storedCluster)
+	if err != nil {
+		return nil, err
+	}
+
+	return resolve(ctx, reader, storedCluster)
+}
+
+// resolve merges the referenced template into the spec of the cluster and returns the information about the merged
+// template.
+func resolve(ctx context.Context, reader client.Reader, cluster *fdbv1beta2.FoundationDBCluster) (*fdbv1beta2.ClusterTemplateStatus, error) {
+	if cluster.Spec.TemplateRef == nil {
+		return nil, nil
+	}
+
+	template := &fdbv1beta2.FoundationDBClusterTemplate{}
+	err := reader.Get(ctx, types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Spec.TemplateRef.Name}, template)
+	if err != nil {
+		return nil, fmt.Errorf("could not load cluster template %s/%s: %w", cluster.Namespace, cluster.Spec.TemplateRef.Name, err)
+	}
+
+	resolvedSpec, inheritedFields, err := MergeSpec(template.Spec, cluster.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("could not merge cluster template %s/%s: %w", cluster.Namespace, cluster.Spec.TemplateRef.Name, err)
+	}
+
+	cluster.Spec = resolvedSpec
+
+	return &fdbv1beta2.ClusterTemplateStatus{
+		Name:            template.Name,
+		Generation:      template.Generation,
+		InheritedFields: inheritedFields,
+	}, nil
+}
+
+// MergeSpec deep-merges the template spec under the local spec and returns the resolved spec and the sorted paths of
+// the fields that are inherited from the template. Objects are merged field by field, for all other values the value
+// of the local spec takes precedence unless it is empty.
+func MergeSpec(template fdbv1beta2.FoundationDBClusterSpec, local fdbv1beta2.FoundationDBClusterSpec) (fdbv1beta2.FoundationDBClusterSpec, []string, error) {
+	resolvedSpec := fdbv1beta2.FoundationDBClusterSpec{}
+
+	templateValues, err := toMap(template)
+	if err != nil {
+		return resolvedSpec, nil, err
+	}
+
+	localValues, err := toMap(local)
+	if err != nil {
+		return resolvedSpec, nil, err
+	}
+
+	var inheritedFields []string
+	merged := mergeValues(templateValues, localValues, "", &inheritedFields)
+	sort.Strings(inheritedFields)
+
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return resolvedSpec, nil, err
+	}
+
+	err = json.Unmarshal(data, &resolvedSpec)
+	if err != nil {
+		return resolvedSpec, nil, err
+	}
+
+	return resolvedSpec, inheritedFields, nil
+}
+
+// toMap converts the spec into its generic JSON representation.
+func toMap(spec fdbv1beta2.FoundationDBClusterSpec) (map[string]interface{}, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	// Use json.Number to prevent a loss of precision for large integers.
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	values := map[string]interface{}{}
+	err = decoder.Decode(&values)
+
+	return values, err
+}
+
+// mergeValues merges the template values under the local values and records the paths of the inherited fields.
+func mergeValues(template map[string]interface{}, local map[string]interface{}, path string, inheritedFields *[]string) map[string]interface{} {
+	merged := make(map[string]interface{}, len(template)+len(local))
+	for key, value := range local {
+		merged[key] = value
+	}
+
+	for key, templateValue := range template {
+		if isEmpty(templateValue) {
+			continue
+		}
+
+		fieldPath := key
+		if path != "" {
+			fieldPath = path + "." + key
+		}
+
+		localValue := local[key]
+		if isEmpty(localValue) {
+			merged[key] = templateValue
+			*inheritedFields = append(*inheritedFields, fieldPath)
+			continue
+		}
+
+		templateObject, templateIsObject := templateValue.(map[string]interface{})
+		localObject, localIsObject := localValue.(map[string]interface{})
+		if templateIsObject && localIsObject {
+			merged[key] = mergeValues(templateObject, localObject, fieldPath, inheritedFields)
+		}
+	}
+
+	return merged
+}
+
+// isEmpty returns true if the value is not set or empty.
+func isEmpty(value interface{}) bool {
+	switch typedValue := value.(type) {
+	case nil:
+		return true
+	case string:
+		return typedValue == ""
+	case map[string]interface{}:
+		return len(typedValue) == 0
+	case []interface{}:
+		return len(typedValue) == 0
+	}
+
+	return false
+}
@@ -0,0 +1,169 @@
+/*
+ * clustertemplate_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package clustertemplate
+
+import (
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("clustertemplate", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+	})
+
+	When("resolving the cluster template", func() {
+		var err error
+
+		JustBeforeEach(func() {
+			err = Resolve(context.Background(), k8sClient, cluster)
+		})
+
+		When("no template is referenced", func() {
+			It("should not modify the spec", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cluster.Spec).To(Equal(internal.CreateDefaultCluster().Spec))
+			})
+		})
+
+		When("the referenced template doesn't exist", func() {
+			BeforeEach(func() {
+				cluster.Spec.TemplateRef = &fdbv1beta2.ClusterTemplateReference{Name: "base"}
+			})
+
+			It("should return an error", func() {
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		When("the referenced template exists", func() {
+			BeforeEach(func() {
+				Expect(k8sClient.Create(context.Background(), &fdbv1beta2.FoundationDBClusterTemplate{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: cluster.Namespace,
+						Name:      "base",
+					},
+					Spec: fdbv1beta2.FoundationDBClusterSpec{
+						Version:           "7.1.4",
+						FaultDomain:       fdbv1beta2.FoundationDBClusterFaultDomain{Key: "topology.kubernetes.io/zone"},
+						AutomationOptions: fdbv1beta2.FoundationDBClusterAutomationOptions{MaxConcurrentReplacements: pointer.Int(2)},
+					},
+				})).To(Succeed())
+
+				cluster.Spec.TemplateRef = &fdbv1beta2.ClusterTemplateReference{Name: "base"}
+				cluster.Spec.FaultDomain = fdbv1beta2.FoundationDBClusterFaultDomain{}
+				Expect(k8sClient.Create(context.Background(), cluster.DeepCopy())).To(Succeed())
+			})
+
+			It("should merge the template into the spec", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cluster.Spec.Version).To(Equal(fdbv1beta2.Versions.Default.String()))
+				Expect(cluster.Spec.FaultDomain.Key).To(Equal("topology.kubernetes.io/zone"))
+				Expect(cluster.Spec.AutomationOptions.MaxConcurrentReplacements).To(Equal(pointer.Int(2)))
+				Expect(cluster.Spec.TemplateRef).To(Equal(&fdbv1beta2.ClusterTemplateReference{Name: "base"}))
+			})
+
+			It("should report the inherited fields based on the stored cluster", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(GetStatus(context.Background(), k8sClient, cluster)).To(Equal(&fdbv1beta2.ClusterTemplateStatus{
+					Name:       "base",
+					Generation: 1,
+					InheritedFields: []string{
+						"automationOptions.maxConcurrentReplacements",
+						"faultDomain",
+					},
+				}))
+			})
+		})
+	})
+
+	When("merging the specs", func() {
+		var template, local, resolved fdbv1beta2.FoundationDBClusterSpec
+		var inheritedFields []string
+
+		BeforeEach(func() {
+			template = fdbv1beta2.FoundationDBClusterSpec{
+				Version: "7.1.4",
+				Processes: map[fdbv1beta2.ProcessClass]fdbv1beta2.ProcessSettings{
+					fdbv1beta2.ProcessClassGeneral: {
+						CustomParameters: fdbv1beta2.FoundationDBCustomParameters{"knob_disable_posix_kernel_aio=1"},
+						PodTemplate: &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								NodeSelector: map[string]string{"pool": "fdb"},
+							},
+						},
+					},
+				},
+				ProcessCounts: fdbv1beta2.ProcessCounts{
+					Storage: 5,
+					Log:     3,
+				},
+			}
+			local = fdbv1beta2.FoundationDBClusterSpec{
+				Version: "7.1.25",
+				Processes: map[fdbv1beta2.ProcessClass]fdbv1beta2.ProcessSettings{
+					fdbv1beta2.ProcessClassGeneral: {
+						CustomParameters: fdbv1beta2.FoundationDBCustomParameters{"knob_max_trace_lines=10000"},
+					},
+				},
+				ProcessCounts: fdbv1beta2.ProcessCounts{
+					Storage: 10,
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			resolved, inheritedFields, err = MergeSpec(template, local)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should give the local values precedence", func() {
+			Expect(resolved.Version).To(Equal("7.1.25"))
+			Expect(resolved.ProcessCounts.Storage).To(Equal(10))
+		})
+
+		It("should replace lists instead of merging them", func() {
+			Expect(resolved.Processes[fdbv1beta2.ProcessClassGeneral].CustomParameters).To(ConsistOf(fdbv1beta2.FoundationDBCustomParameter("knob_max_trace_lines=10000")))
+		})
+
+		It("should merge the objects field by field", func() {
+			Expect(resolved.ProcessCounts.Log).To(Equal(3))
+			Expect(resolved.Processes[fdbv1beta2.ProcessClassGeneral].PodTemplate.Spec.NodeSelector).To(Equal(map[string]string{"pool": "fdb"}))
+		})
+
+		It("should report the inherited fields", func() {
+			Expect(inheritedFields).To(Equal([]string{
+				"processCounts.log",
+				"processes.general.podTemplate",
+			}))
+		})
+	})
+})
@@ -38,16 +38,9 @@ func GetConfigMap(cluster *fdbv1beta2.FoundationDBCluster) (*corev1.ConfigMap, e
 	data[fdbv1beta2.ClusterFileKey] = connectionString
 	data[fdbv1beta2.RunningVersionKey] = cluster.Status.RunningVersion
 
-	var caFile strings.Builder
-	for _, ca := range cluster.Spec.TrustedCAs {
-		if caFile.Len() > 0 {
-			caFile.WriteString("\n")
-		}
-		caFile.WriteString(ca)
-	}
-
-	if caFile.Len() > 0 {
-		data[fdbv1beta2.CaFileKey] = caFile.String()
+	caFile := getCAFile(cluster)
+	if caFile != "" {
+		data[fdbv1beta2.CaFileKey] = caFile
 	}
 
 	desiredCountStruct, err := cluster.GetProcessCountsWithDefaults()
@@ -122,6 +115,19 @@ func GetConfigMap(cluster *fdbv1beta2.FoundationDBCluster) (*corev1.ConfigMap, e
 	}, nil
 }
 
+// getCAFile returns the trusted CAs of the cluster in PEM format.
+func getCAFile(cluster *fdbv1beta2.FoundationDBCluster) string {
+	var caFile strings.Builder
+	for _, ca := range cluster.Spec.TrustedCAs {
+		if caFile.Len() > 0 {
+			caFile.WriteString("\n")
+		}
+		caFile.WriteString(ca)
+	}
+
+	return caFile.String()
+}
+
 func getConfigMapMetadata(cluster *fdbv1beta2.FoundationDBCluster) metav1.ObjectMeta {
 	var metadata metav1.ObjectMeta
 	if cluster.Spec.ConfigMap != nil {
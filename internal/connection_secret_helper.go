@@ -0,0 +1,71 @@
+/*
+ * connection_secret_helper.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	"strconv"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetConnectionSecret builds the Secret that contains the connection details of the cluster. The keys of the Secret
+// are stable for a schema version, so that infrastructure-as-code tools can consume them as an output. If the Secret
+// should not be published or the cluster has no connection string yet, nil will be returned.
+func GetConnectionSecret(cluster *fdbv1beta2.FoundationDBCluster) (*corev1.Secret, error) {
+	if !cluster.PublishConnectionSecret() || cluster.Status.ConnectionString == "" {
+		return nil, nil
+	}
+
+	version, err := fdbv1beta2.ParseFdbVersion(cluster.GetRunningVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := GetObjectMetadata(cluster, &metav1.ObjectMeta{
+		Labels:      cluster.Spec.ConnectionSecret.Labels,
+		Annotations: cluster.Spec.ConnectionSecret.Annotations,
+	}, "", "")
+	metadata.Name = cluster.GetConnectionSecretName()
+	metadata.OwnerReferences = BuildOwnerReference(cluster.TypeMeta, cluster.ObjectMeta)
+	addPropagatedMetadata(cluster, &metadata)
+
+	data := map[string][]byte{
+		fdbv1beta2.SchemaVersionKey:    []byte(fdbv1beta2.ConnectionSecretSchemaVersion),
+		fdbv1beta2.ConnectionStringKey: []byte(cluster.Status.ConnectionString),
+		fdbv1beta2.ClusterFileKey:      []byte(cluster.Status.ConnectionString),
+		fdbv1beta2.RunningVersionKey:   []byte(version.String()),
+		fdbv1beta2.APIVersionKey:       []byte(strconv.Itoa(version.Major*100 + version.Minor*10)),
+		fdbv1beta2.TLSEnabledKey:       []byte(strconv.FormatBool(cluster.Spec.MainContainer.EnableTLS)),
+	}
+
+	caFile := getCAFile(cluster)
+	if caFile != "" {
+		data[fdbv1beta2.CaFileKey] = []byte(caFile)
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metadata,
+		Type:       corev1.SecretTypeOpaque,
+		Data:       data,
+	}, nil
+}
@@ -0,0 +1,153 @@
+/*
+ * connection_string.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package connectionstring
+
+import (
+	"sync"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Source defines where a connection string was read from.
+type Source string
+
+const (
+	// SourceLive represents the connection string reported by the machine-readable status of the cluster.
+	SourceLive Source = "live"
+	// SourceStatus represents the connection string in the status of the FoundationDBCluster resource.
+	SourceStatus Source = "status"
+	// SourceCache represents the connection string in the Cache.
+	SourceCache Source = "cache"
+	// SourceConfigMap represents the connection string in the ConfigMap of the cluster.
+	SourceConfigMap Source = "configmap"
+)
+
+// Candidate is a connection string read from a specific source.
+type Candidate struct {
+	// Source defines where the connection string was read from.
+	Source Source
+	// ConnectionString is the value read from the source, an empty value means that the source has no connection string.
+	ConnectionString string
+}
+
+// Resolve returns the connection string of the first candidate that has a connection string, so the candidates must be
+// provided in their priority order. In addition, all sources with a connection string that diverges from the returned
+// connection string are returned.
+func Resolve(candidates ...Candidate) (string, []Source) {
+	var resolved string
+	var divergences []Source
+
+	for _, candidate := range candidates {
+		if candidate.ConnectionString == "" {
+			continue
+		}
+
+		if resolved == "" {
+			resolved = candidate.ConnectionString
+			continue
+		}
+
+		if candidate.ConnectionString != resolved {
+			divergences = append(divergences, candidate.Source)
+		}
+	}
+
+	return resolved, divergences
+}
+
+// Cache keeps the last verified connection string of every cluster, so the operator doesn't have to fetch the
+// connection string from the cluster during every reconciliation. An entry is only valid for the generation of the
+// FoundationDBCluster resource and the connection string in its status that were present when the entry was added. All
+// methods of the Cache are safe to be called on a nil Cache, which behaves like an empty Cache.
+type Cache struct {
+	// lock protects the entries of the cache.
+	lock sync.RWMutex
+	// entries contains the cached connection string for every cluster, keyed by the UID of the cluster.
+	entries map[types.UID]cacheEntry
+}
+
+// cacheEntry contains the cached connection string of a single cluster.
+type cacheEntry struct {
+	// connectionString is the cached connection string.
+	connectionString string
+	// generation is the generation of the FoundationDBCluster resource when the entry was added.
+	generation int64
+	// statusConnectionString is the connection string in the status of the FoundationDBCluster resource when the entry
+	// was added.
+	statusConnectionString string
+}
+
+// NewCache creates a new empty Cache.
+func NewCache() *Cache {
+	return &Cache{
+		entries: map[types.UID]cacheEntry{},
+	}
+}
+
+// Get returns the cached connection string for the provided cluster. If no valid entry exists, false will be returned.
+func (cache *Cache) Get(cluster *fdbv1beta2.FoundationDBCluster) (string, bool) {
+	if cache == nil {
+		return "", false
+	}
+
+	cache.lock.RLock()
+	defer cache.lock.RUnlock()
+
+	entry, ok := cache.entries[cluster.UID]
+	if !ok {
+		return "", false
+	}
+
+	if entry.generation != cluster.Generation || entry.statusConnectionString != cluster.Status.ConnectionString {
+		return "", false
+	}
+
+	return entry.connectionString, true
+}
+
+// Set adds the connection string for the provided cluster to the cache.
+func (cache *Cache) Set(cluster *fdbv1beta2.FoundationDBCluster, connectionString string) {
+	if cache == nil || connectionString == "" {
+		return
+	}
+
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	cache.entries[cluster.UID] = cacheEntry{
+		connectionString:       connectionString,
+		generation:             cluster.Generation,
+		statusConnectionString: cluster.Status.ConnectionString,
+	}
+}
+
+// Invalidate removes the cached connection string for the provided cluster.
+func (cache *Cache) Invalidate(cluster *fdbv1beta2.FoundationDBCluster) {
+	if cache == nil {
+		return
+	}
+
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	delete(cache.entries, cluster.UID)
+}
@@ -0,0 +1,146 @@
+/*
+ * connection_string_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package connectionstring
+
+import (
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("connection_string", func() {
+	When("resolving the connection string", func() {
+		DescribeTable("should return the connection string with the highest priority and the divergences",
+			func(candidates []Candidate, expectedConnectionString string, expectedDivergences []Source) {
+				connectionString, divergences := Resolve(candidates...)
+				Expect(connectionString).To(Equal(expectedConnectionString))
+				Expect(divergences).To(Equal(expectedDivergences))
+			},
+			Entry("no candidates",
+				nil,
+				"",
+				nil,
+			),
+			Entry("all candidates match",
+				[]Candidate{
+					{Source: SourceLive, ConnectionString: "test:abc@127.0.0.1:4500"},
+					{Source: SourceStatus, ConnectionString: "test:abc@127.0.0.1:4500"},
+					{Source: SourceConfigMap, ConnectionString: "test:abc@127.0.0.1:4500"},
+				},
+				"test:abc@127.0.0.1:4500",
+				nil,
+			),
+			Entry("the first candidate is empty",
+				[]Candidate{
+					{Source: SourceLive, ConnectionString: ""},
+					{Source: SourceStatus, ConnectionString: "test:abc@127.0.0.1:4500"},
+					{Source: SourceConfigMap, ConnectionString: "test:abc@127.0.0.1:4500"},
+				},
+				"test:abc@127.0.0.1:4500",
+				nil,
+			),
+			Entry("the ConfigMap diverges",
+				[]Candidate{
+					{Source: SourceLive, ConnectionString: "test:def@127.0.0.2:4500"},
+					{Source: SourceStatus, ConnectionString: "test:def@127.0.0.2:4500"},
+					{Source: SourceCache, ConnectionString: "test:abc@127.0.0.1:4500"},
+					{Source: SourceConfigMap, ConnectionString: "test:abc@127.0.0.1:4500"},
+				},
+				"test:def@127.0.0.2:4500",
+				[]Source{SourceCache, SourceConfigMap},
+			),
+		)
+	})
+
+	When("using the cache", func() {
+		var cache *Cache
+		var cluster *fdbv1beta2.FoundationDBCluster
+
+		BeforeEach(func() {
+			cache = NewCache()
+			cluster = &fdbv1beta2.FoundationDBCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "test",
+					Namespace:  "test",
+					UID:        "test-uid",
+					Generation: 1,
+				},
+				Status: fdbv1beta2.FoundationDBClusterStatus{
+					ConnectionString: "test:abc@127.0.0.1:4500",
+				},
+			}
+
+			cache.Set(cluster, "test:abc@127.0.0.1:4500")
+		})
+
+		It("should return the cached connection string", func() {
+			connectionString, ok := cache.Get(cluster)
+			Expect(ok).To(BeTrue())
+			Expect(connectionString).To(Equal("test:abc@127.0.0.1:4500"))
+		})
+
+		When("the generation of the cluster changed", func() {
+			BeforeEach(func() {
+				cluster.Generation = 2
+			})
+
+			It("should not return the cached connection string", func() {
+				_, ok := cache.Get(cluster)
+				Expect(ok).To(BeFalse())
+			})
+		})
+
+		When("the connection string in the status changed", func() {
+			BeforeEach(func() {
+				cluster.Status.ConnectionString = "test:def@127.0.0.2:4500"
+			})
+
+			It("should not return the cached connection string", func() {
+				_, ok := cache.Get(cluster)
+				Expect(ok).To(BeFalse())
+			})
+		})
+
+		When("the entry was invalidated", func() {
+			BeforeEach(func() {
+				cache.Invalidate(cluster)
+			})
+
+			It("should not return the cached connection string", func() {
+				_, ok := cache.Get(cluster)
+				Expect(ok).To(BeFalse())
+			})
+		})
+
+		When("the cache is nil", func() {
+			BeforeEach(func() {
+				cache = nil
+				cache.Set(cluster, "test:abc@127.0.0.1:4500")
+			})
+
+			It("should not return a connection string", func() {
+				_, ok := cache.Get(cluster)
+				Expect(ok).To(BeFalse())
+			})
+		})
+	})
+})
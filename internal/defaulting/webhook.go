@@ -0,0 +1,77 @@
+/*
+ * webhook.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package defaulting
+
+import (
+	"context"
+	"fmt"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:webhook:path=/mutate-apps-foundationdb-org-v1beta2-foundationdbcluster,mutating=true,failurePolicy=fail,sideEffects=None,groups=apps.foundationdb.org,resources=foundationdbclusters,verbs=create;update,versions=v1beta2,name=mfoundationdbcluster.kb.io,admissionReviewVersions=v1
+
+// ClusterDefaulter applies the static defaults of the operator to FoundationDBClusters when they are created or
+// updated.
+type ClusterDefaulter struct {
+	deprecationOptions internal.DeprecationOptions
+}
+
+var _ admission.CustomDefaulter = &ClusterDefaulter{}
+
+// NewClusterDefaulter creates a new ClusterDefaulter that applies the defaults based on the provided deprecation
+// options.
+func NewClusterDefaulter(deprecationOptions internal.DeprecationOptions) *ClusterDefaulter {
+	return &ClusterDefaulter{
+		deprecationOptions: deprecationOptions,
+	}
+}
+
+// SetupWebhookWithManager registers the mutating webhook for FoundationDBClusters.
+func (defaulter *ClusterDefaulter) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&fdbv1beta2.FoundationDBCluster{}).
+		WithDefaulter(defaulter).
+		Complete()
+}
+
+// Default applies the static defaults of the operator to the fields of the provided FoundationDBCluster that are unset
+// and validates the custom parameters. Only defaults that don't depend on other fields of the spec are stored, e.g. the
+// future defaults, all other defaults are applied during the reconciliation. Otherwise the stored defaults would not be
+// updated when the fields they are derived from change and tools like Argo CD and Flux would observe a difference
+// between the applied and the stored spec. Clusters that reference a cluster template are not modified, as the defaults
+// would be stored in the spec of the cluster and would take precedence over the values of the template.
+func (defaulter *ClusterDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	cluster, ok := obj.(*fdbv1beta2.FoundationDBCluster)
+	if !ok {
+		return fmt.Errorf("expected a FoundationDBCluster but got %T", obj)
+	}
+
+	if cluster.Spec.TemplateRef != nil {
+		return nil
+	}
+
+	return internal.ApplyStaticDefaults(cluster, defaulter.deprecationOptions)
+}
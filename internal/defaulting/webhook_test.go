@@ -0,0 +1,142 @@
+/*
+ * webhook_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package defaulting
+
+import (
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("cluster defaulting webhook", func() {
+	var defaulter *ClusterDefaulter
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var err error
+
+	BeforeEach(func() {
+		defaulter = NewClusterDefaulter(internal.DeprecationOptions{})
+		cluster = &fdbv1beta2.FoundationDBCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "test-ns",
+			},
+			Spec: fdbv1beta2.FoundationDBClusterSpec{
+				Version: fdbv1beta2.Versions.Default.String(),
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		err = defaulter.Default(context.Background(), cluster)
+	})
+
+	When("the cluster doesn't reference a template", func() {
+		It("should not store the defaults that are derived from other fields", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cluster.Spec.MainContainer.ImageConfigs).To(BeEmpty())
+			Expect(cluster.Spec.SidecarContainer.ImageConfigs).To(BeEmpty())
+			Expect(cluster.Spec.Processes).To(BeEmpty())
+		})
+
+		When("the future defaults are used", func() {
+			BeforeEach(func() {
+				defaulter = NewClusterDefaulter(internal.DeprecationOptions{UseFutureDefaults: true})
+			})
+
+			It("should apply the future defaults", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cluster.Spec.ImageType).NotTo(BeNil())
+				Expect(*cluster.Spec.ImageType).To(Equal(fdbv1beta2.ImageTypeUnified))
+				Expect(cluster.Spec.AutomationOptions.UseLocalitiesForExclusion).To(Equal(pointer.Bool(true)))
+				Expect(cluster.Spec.Routing.UseDNSInClusterFile).To(Equal(pointer.Bool(true)))
+				Expect(cluster.Spec.MainContainer.ImageConfigs).To(BeEmpty())
+			})
+
+			It("should not change an already defaulted cluster", func() {
+				defaulted := cluster.DeepCopy()
+				Expect(defaulter.Default(context.Background(), cluster)).To(Succeed())
+				Expect(cluster).To(Equal(defaulted))
+			})
+
+			When("the image type is set", func() {
+				BeforeEach(func() {
+					imageType := fdbv1beta2.ImageTypeSplit
+					cluster.Spec.ImageType = &imageType
+				})
+
+				It("should not modify the image type", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(cluster.Spec.ImageType).NotTo(BeNil())
+					Expect(*cluster.Spec.ImageType).To(Equal(fdbv1beta2.ImageTypeSplit))
+				})
+			})
+		})
+
+		When("the deprecated crash loop field is set", func() {
+			BeforeEach(func() {
+				cluster.Spec.Buggify.CrashLoop = []fdbv1beta2.ProcessGroupID{"storage-1"}
+			})
+
+			It("should not store the crash loop containers", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cluster.Spec.Buggify.CrashLoopContainers).To(BeEmpty())
+			})
+		})
+
+		When("the custom parameters are invalid", func() {
+			BeforeEach(func() {
+				cluster.Spec.Processes = map[fdbv1beta2.ProcessClass]fdbv1beta2.ProcessSettings{
+					fdbv1beta2.ProcessClassGeneral: {
+						CustomParameters: fdbv1beta2.FoundationDBCustomParameters{"datadir=/tmp"},
+					},
+				}
+			})
+
+			It("should return an error", func() {
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	When("the cluster references a template", func() {
+		BeforeEach(func() {
+			cluster.Spec.TemplateRef = &fdbv1beta2.ClusterTemplateReference{Name: "base"}
+		})
+
+		It("should not modify the cluster", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cluster.Spec.MainContainer.ImageConfigs).To(BeEmpty())
+			Expect(cluster.Spec.Processes).To(BeEmpty())
+		})
+	})
+
+	When("the object is not a FoundationDBCluster", func() {
+		It("should return an error", func() {
+			Expect(defaulter.Default(context.Background(), &corev1.Pod{})).To(MatchError("expected a FoundationDBCluster but got *v1.Pod"))
+		})
+	})
+})
@@ -44,22 +44,13 @@ type DeprecationOptions struct {
 // future-proof form, by applying any implicit defaults and moving configuration
 // from deprecated fields into fully-supported fields.
 func NormalizeClusterSpec(cluster *fdbv1beta2.FoundationDBCluster, options DeprecationOptions) error {
-	// Validate customParameters
-	for processClass := range cluster.Spec.Processes {
-		if setting, ok := cluster.Spec.Processes[processClass]; ok {
-			if setting.CustomParameters == nil {
-				continue
-			}
-
-			err := setting.CustomParameters.ValidateCustomParameters()
-			if err != nil {
-				return err
-			}
-		}
+	err := validateCustomParameters(cluster)
+	if err != nil {
+		return err
 	}
 
 	if !options.OnlyShowChanges {
-		err := updateFutureDefaults(cluster, options)
+		err = updateFutureDefaults(cluster, options)
 		if err != nil {
 			return err
 		}
@@ -69,6 +60,11 @@ func NormalizeClusterSpec(cluster *fdbv1beta2.FoundationDBCluster, options Depre
 			template.Spec.Containers, _ = ensureContainerPresent(template.Spec.Containers, fdbv1beta2.MainContainerName, 0)
 
 			template.Spec.Containers = customizeContainerFromList(template.Spec.Containers, fdbv1beta2.MainContainerName, func(container *corev1.Container) {
+				if container.Resources.Requests == nil {
+					// The tuning profile defines its own default resources.
+					container.Resources.Requests = cluster.GetTuningProfileResources()
+				}
+
 				if container.Resources.Requests == nil {
 					// See: https://apple.github.io/foundationdb/configuration.html#system-requirements
 					container.Resources.Requests = corev1.ResourceList{
@@ -134,6 +130,40 @@ func NormalizeClusterSpec(cluster *fdbv1beta2.FoundationDBCluster, options Depre
 	return nil
 }
 
+// ApplyStaticDefaults validates the custom parameters and applies the defaults that don't depend on any other field of
+// the cluster spec, e.g. the future defaults. Those defaults can be stored in the spec, as they never change for an
+// existing cluster. All other defaults, e.g. the resources of the tuning profile, the image configs for the image type or
+// the crash loop containers of the deprecated crash loop field, are derived from other fields and are only applied by
+// NormalizeClusterSpec during the reconciliation.
+func ApplyStaticDefaults(cluster *fdbv1beta2.FoundationDBCluster, options DeprecationOptions) error {
+	err := validateCustomParameters(cluster)
+	if err != nil {
+		return err
+	}
+
+	if options.OnlyShowChanges {
+		return nil
+	}
+
+	return updateFutureDefaults(cluster, options)
+}
+
+// validateCustomParameters validates the custom parameters of all process classes.
+func validateCustomParameters(cluster *fdbv1beta2.FoundationDBCluster) error {
+	for _, setting := range cluster.Spec.Processes {
+		if setting.CustomParameters == nil {
+			continue
+		}
+
+		err := setting.CustomParameters.ValidateCustomParameters()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func updateImageConfigs(spec *fdbv1beta2.FoundationDBClusterSpec, useUnifiedImage bool) {
 	if useUnifiedImage {
 		ensureImageConfigPresent(&spec.MainContainer.ImageConfigs, fdbv1beta2.ImageConfig{BaseImage: fdbv1beta2.FoundationDBKubernetesBaseImage})
@@ -166,7 +196,8 @@ func ensurePodTemplatePresent(spec *fdbv1beta2.FoundationDBClusterSpec) {
 	spec.Processes[fdbv1beta2.ProcessClassGeneral] = generalSettings
 }
 
-// updatePodTemplates updates all of the pod templates in the cluster spec.
+// updatePodTemplates updates all of the pod templates in the cluster spec,
+// including the pod templates of the storage pools.
 // This will also ensure that the general settings contain a pod template, so
 // the customization function is guaranteed to be called at least once.
 func updatePodTemplates(spec *fdbv1beta2.FoundationDBClusterSpec, customizer func(*corev1.PodTemplateSpec)) {
@@ -177,6 +208,13 @@ func updatePodTemplates(spec *fdbv1beta2.FoundationDBClusterSpec, customizer fun
 		}
 		customizer(settings.PodTemplate)
 	})
+
+	for idx := range spec.StoragePools {
+		if spec.StoragePools[idx].PodTemplate == nil {
+			continue
+		}
+		customizer(spec.StoragePools[idx].PodTemplate)
+	}
 }
 
 // updateProcessSettings runs a customization function on all of the process
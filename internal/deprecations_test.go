@@ -162,6 +162,24 @@ var _ = Describe("[internal] deprecations", func() {
 					})
 				})
 
+				Context("with a tuning profile", func() {
+					BeforeEach(func() {
+						cluster.Spec.Profile = fdbv1beta2.TuningProfileThroughput
+					})
+
+					It("should use the resources of the profile", func() {
+						generalProcessConfig, present := spec.Processes[fdbv1beta2.ProcessClassGeneral]
+						Expect(present).To(BeTrue())
+						containers := generalProcessConfig.PodTemplate.Spec.Containers
+						Expect(containers[0].Name).To(Equal(fdbv1beta2.MainContainerName))
+						Expect(containers[0].Resources.Requests).To(Equal(corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("2"),
+							corev1.ResourceMemory: resource.MustParse("16Gi"),
+						}))
+						Expect(containers[0].Resources.Limits).To(Equal(containers[0].Resources.Requests))
+					})
+				})
+
 				Context("with explicit resource requests for the sidecar", func() {
 					BeforeEach(func() {
 						spec.Processes = map[fdbv1beta2.ProcessClass]fdbv1beta2.ProcessSettings{
@@ -250,6 +268,34 @@ var _ = Describe("[internal] deprecations", func() {
 					Expect(cluster.UseUnifiedImage()).To(BeFalse())
 				})
 
+				Context("with a Pod template for a storage pool", func() {
+					BeforeEach(func() {
+						spec.StoragePools = []fdbv1beta2.StoragePool{
+							{
+								Name:  "fast",
+								Count: 3,
+								PodTemplate: &corev1.PodTemplateSpec{
+									Spec: corev1.PodSpec{
+										NodeSelector: map[string]string{"disk": "nvme"},
+									},
+								},
+							},
+						}
+					})
+
+					It("should have the containers defined in the Pod template of the storage pool", func() {
+						containers := spec.StoragePools[0].PodTemplate.Spec.Containers
+						Expect(containers).To(HaveLen(2))
+						Expect(containers[0].Name).To(Equal(fdbv1beta2.MainContainerName))
+						Expect(containers[0].Resources.Requests).To(Equal(corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("1"),
+							corev1.ResourceMemory: resource.MustParse("8Gi"),
+						}))
+						Expect(containers[1].Name).To(Equal(fdbv1beta2.SidecarContainerName))
+						Expect(spec.StoragePools[0].PodTemplate.Spec.NodeSelector).To(Equal(map[string]string{"disk": "nvme"}))
+					})
+				})
+
 				Context("with unified images enabled", func() {
 					BeforeEach(func() {
 						imageType := fdbv1beta2.ImageTypeUnified
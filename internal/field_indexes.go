@@ -0,0 +1,141 @@
+/*
+ * field_indexes.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ClusterNameIndex is the name of the field index that contains the name of the cluster an object belongs to.
+	ClusterNameIndex = "fdb.clusterName"
+	// ProcessClassIndex is the name of the field index that contains the cluster name and the process class of an
+	// object in the format "<cluster>/<process class>".
+	ProcessClassIndex = "fdb.processClass"
+	// ProcessGroupIDIndex is the name of the field index that contains the cluster name and the process group ID of an
+	// object in the format "<cluster>/<process group ID>".
+	ProcessGroupIDIndex = "fdb.processGroupID"
+)
+
+// FieldIndex defines a field index for a specific object type.
+type FieldIndex struct {
+	// Object is the object type that will be indexed.
+	Object client.Object
+	// Field is the name of the index.
+	Field string
+	// ExtractValue returns the indexed values of an object.
+	ExtractValue client.IndexerFunc
+}
+
+// GetFieldIndexes returns the field indexes for all objects that are created per process group. The indexes are based
+// on the default labels, clusters that use a custom label config will be listed with the label selectors.
+func GetFieldIndexes() []FieldIndex {
+	indexes := make([]FieldIndex, 0, 6)
+	for _, object := range []client.Object{&corev1.Pod{}, &corev1.PersistentVolumeClaim{}} {
+		indexes = append(indexes,
+			FieldIndex{Object: object, Field: ClusterNameIndex, ExtractValue: getClusterNameIndexValue},
+			FieldIndex{Object: object, Field: ProcessClassIndex, ExtractValue: getLabelIndexValue(fdbv1beta2.FDBProcessClassLabel)},
+			FieldIndex{Object: object, Field: ProcessGroupIDIndex, ExtractValue: getLabelIndexValue(fdbv1beta2.FDBProcessGroupIDLabel)},
+		)
+	}
+
+	return indexes
+}
+
+// SetupFieldIndexes registers all field indexes from GetFieldIndexes with the provided indexer.
+func SetupFieldIndexes(ctx context.Context, indexer client.FieldIndexer) error {
+	for _, index := range GetFieldIndexes() {
+		err := indexer.IndexField(ctx, index.Object, index.Field, index.ExtractValue)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getClusterNameIndexValue returns the value of the cluster label of the object.
+func getClusterNameIndexValue(object client.Object) []string {
+	clusterName, ok := object.GetLabels()[fdbv1beta2.FDBClusterLabel]
+	if !ok {
+		return nil
+	}
+
+	return []string{clusterName}
+}
+
+// getLabelIndexValue returns an indexer function that returns the cluster name and the value of the provided label.
+func getLabelIndexValue(label string) client.IndexerFunc {
+	return func(object client.Object) []string {
+		labels := object.GetLabels()
+		clusterName, ok := labels[fdbv1beta2.FDBClusterLabel]
+		if !ok {
+			return nil
+		}
+
+		value, ok := labels[label]
+		if !ok {
+			return nil
+		}
+
+		return []string{clusterName + "/" + value}
+	}
+}
+
+// usesDefaultLabels returns true if the cluster uses the labels that are used by the field indexes.
+func usesDefaultLabels(cluster *fdbv1beta2.FoundationDBCluster) bool {
+	matchLabels := cluster.GetMatchLabels()
+	if len(matchLabels) != 1 || matchLabels[fdbv1beta2.FDBClusterLabel] != cluster.Name {
+		return false
+	}
+
+	return cluster.GetProcessClassLabel() == fdbv1beta2.FDBProcessClassLabel && cluster.GetProcessGroupIDLabel() == fdbv1beta2.FDBProcessGroupIDLabel
+}
+
+// GetIndexedPodListOptions returns the listOptions to list Pods or PVCs with the field indexes from GetFieldIndexes.
+// If the indexes are not available or the cluster uses a custom label config, the label based listOptions from
+// GetPodListOptions will be returned.
+func GetIndexedPodListOptions(cluster *fdbv1beta2.FoundationDBCluster, indexesAvailable bool, processClass fdbv1beta2.ProcessClass, id string) []client.ListOption {
+	if !indexesAvailable || !usesDefaultLabels(cluster) {
+		return GetPodListOptions(cluster, processClass, id)
+	}
+
+	options := []client.ListOption{client.InNamespace(cluster.Namespace)}
+	if id != "" {
+		options = append(options, client.MatchingFields{ProcessGroupIDIndex: cluster.Name + "/" + id})
+		// Only a single field selector is supported by the cache, so the process class must be matched with the label.
+		if processClass != "" {
+			options = append(options, client.MatchingLabels{fdbv1beta2.FDBProcessClassLabel: string(processClass)})
+		}
+
+		return options
+	}
+
+	if processClass != "" {
+		return append(options, client.MatchingFields{ProcessClassIndex: cluster.Name + "/" + string(processClass)})
+	}
+
+	return append(options, client.MatchingFields{ClusterNameIndex: cluster.Name})
+}
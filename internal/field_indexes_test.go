@@ -0,0 +1,160 @@
+/*
+ * field_indexes_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("field_indexes", func() {
+	When("extracting the index values", func() {
+		var indexes map[string]client.IndexerFunc
+
+		BeforeEach(func() {
+			indexes = map[string]client.IndexerFunc{}
+			for _, index := range GetFieldIndexes() {
+				if _, ok := index.Object.(*corev1.Pod); !ok {
+					continue
+				}
+
+				indexes[index.Field] = index.ExtractValue
+			}
+		})
+
+		It("should create the indexes for Pods and PVCs", func() {
+			Expect(GetFieldIndexes()).To(HaveLen(6))
+			Expect(indexes).To(HaveLen(3))
+		})
+
+		DescribeTable("should return the expected values",
+			func(labels map[string]string, field string, expected []string) {
+				pod := &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "test-storage-1",
+						Labels: labels,
+					},
+				}
+
+				Expect(indexes[field](pod)).To(Equal(expected))
+			},
+			Entry("the cluster name",
+				map[string]string{
+					fdbv1beta2.FDBClusterLabel: "test",
+				},
+				ClusterNameIndex,
+				[]string{"test"},
+			),
+			Entry("the process class",
+				map[string]string{
+					fdbv1beta2.FDBClusterLabel:      "test",
+					fdbv1beta2.FDBProcessClassLabel: string(fdbv1beta2.ProcessClassStorage),
+				},
+				ProcessClassIndex,
+				[]string{"test/storage"},
+			),
+			Entry("the process group ID",
+				map[string]string{
+					fdbv1beta2.FDBClusterLabel:        "test",
+					fdbv1beta2.FDBProcessGroupIDLabel: "storage-1",
+				},
+				ProcessGroupIDIndex,
+				[]string{"test/storage-1"},
+			),
+			Entry("the process group ID without the cluster label",
+				map[string]string{
+					fdbv1beta2.FDBProcessGroupIDLabel: "storage-1",
+				},
+				ProcessGroupIDIndex,
+				nil,
+			),
+			Entry("the process class without the process class label",
+				map[string]string{
+					fdbv1beta2.FDBClusterLabel: "test",
+				},
+				ProcessClassIndex,
+				nil,
+			),
+		)
+	})
+
+	When("getting the list options", func() {
+		var cluster *fdbv1beta2.FoundationDBCluster
+
+		BeforeEach(func() {
+			cluster = CreateDefaultCluster()
+		})
+
+		When("the field indexes are not available", func() {
+			It("should return the label based list options", func() {
+				Expect(GetIndexedPodListOptions(cluster, false, "", "storage-1")).To(Equal(GetPodListOptions(cluster, "", "storage-1")))
+			})
+		})
+
+		When("the field indexes are available", func() {
+			It("should use the cluster name index", func() {
+				Expect(GetIndexedPodListOptions(cluster, true, "", "")).To(ConsistOf(
+					client.InNamespace(cluster.Namespace),
+					client.MatchingFields{ClusterNameIndex: cluster.Name},
+				))
+			})
+
+			It("should use the process class index", func() {
+				Expect(GetIndexedPodListOptions(cluster, true, fdbv1beta2.ProcessClassStorage, "")).To(ConsistOf(
+					client.InNamespace(cluster.Namespace),
+					client.MatchingFields{ProcessClassIndex: cluster.Name + "/storage"},
+				))
+			})
+
+			It("should use the process group ID index", func() {
+				Expect(GetIndexedPodListOptions(cluster, true, fdbv1beta2.ProcessClassStorage, "storage-1")).To(ConsistOf(
+					client.InNamespace(cluster.Namespace),
+					client.MatchingFields{ProcessGroupIDIndex: cluster.Name + "/storage-1"},
+					client.MatchingLabels{fdbv1beta2.FDBProcessClassLabel: string(fdbv1beta2.ProcessClassStorage)},
+				))
+			})
+
+			When("the cluster uses custom match labels", func() {
+				BeforeEach(func() {
+					cluster.Spec.LabelConfig.MatchLabels = map[string]string{"custom": "label"}
+				})
+
+				It("should return the label based list options", func() {
+					Expect(GetIndexedPodListOptions(cluster, true, "", "")).To(Equal(GetPodListOptions(cluster, "", "")))
+				})
+			})
+
+			When("the cluster uses a custom process group ID label", func() {
+				BeforeEach(func() {
+					cluster.Spec.LabelConfig.ProcessGroupIDLabels = []string{"custom-id"}
+				})
+
+				It("should return the label based list options", func() {
+					Expect(GetIndexedPodListOptions(cluster, true, "", "storage-1")).To(Equal(GetPodListOptions(cluster, "", "storage-1")))
+				})
+			})
+		})
+	})
+})
@@ -0,0 +1,35 @@
+//go:build boringcrypto
+
+/*
+ * fips_boringcrypto.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fips
+
+import (
+	"crypto/boring"
+	// Restrict all TLS connections of the operator to FIPS approved settings.
+	_ "crypto/tls/fipsonly"
+)
+
+// Enabled returns true if the operator was built with FIPS 140 validated cryptography and the validated module is
+// in use.
+func Enabled() bool {
+	return boring.Enabled()
+}
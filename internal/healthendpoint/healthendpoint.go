@@ -0,0 +1,231 @@
+/*
+ * healthendpoint.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package healthendpoint
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient"
+	"github.com/go-logr/logr"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// PathPrefix is the prefix of the path of the health endpoints. The health endpoint of a cluster is served at
+// /health/<namespace>/<cluster>.
+const PathPrefix = "/health/"
+
+// Response is the response of the health endpoint of a cluster.
+type Response struct {
+	// Namespace of the FoundationDBCluster.
+	Namespace string `json:"namespace"`
+	// Cluster is the name of the FoundationDBCluster.
+	Cluster string `json:"cluster"`
+	// Healthy reports if the cluster meets all the requirements of the health endpoint.
+	Healthy bool `json:"healthy"`
+	// Available reports if the database is available.
+	Available bool `json:"available"`
+	// FullReplication reports if the database is fully replicated.
+	FullReplication bool `json:"fullReplication"`
+	// FaultTolerance is the number of zones that can fail without losing data or availability.
+	FaultTolerance int `json:"faultTolerance"`
+	// MinimumFaultTolerance is the minimum fault tolerance for the cluster to be reported as healthy.
+	MinimumFaultTolerance int `json:"minimumFaultTolerance"`
+	// Message describes why the cluster is not healthy.
+	Message string `json:"message,omitempty"`
+}
+
+// NewResponse evaluates the machine-readable status against the requirements defined in the health endpoint settings
+// of the cluster.
+func NewResponse(cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus) Response {
+	response := Response{
+		Namespace:             cluster.Namespace,
+		Cluster:               cluster.Name,
+		Available:             status.Client.DatabaseStatus.Available,
+		FullReplication:       status.Cluster.FullReplication,
+		FaultTolerance:        status.Cluster.FaultTolerance.MaxZoneFailuresWithoutLosingData,
+		MinimumFaultTolerance: cluster.GetHealthEndpointMinimumFaultTolerance(),
+	}
+
+	if status.Cluster.FaultTolerance.MaxZoneFailuresWithoutLosingAvailability < response.FaultTolerance {
+		response.FaultTolerance = status.Cluster.FaultTolerance.MaxZoneFailuresWithoutLosingAvailability
+	}
+
+	var messages []string
+	if !response.Available {
+		messages = append(messages, "database is not available")
+	}
+
+	if response.FaultTolerance < response.MinimumFaultTolerance {
+		messages = append(messages, fmt.Sprintf("fault tolerance %d is below the minimum of %d", response.FaultTolerance, response.MinimumFaultTolerance))
+	}
+
+	if cluster.HealthEndpointRequiresFullReplication() && !response.FullReplication {
+		messages = append(messages, "database is not fully replicated")
+	}
+
+	response.Healthy = len(messages) == 0
+	response.Message = strings.Join(messages, ", ")
+
+	return response
+}
+
+// Server serves the health endpoints of all clusters that have the health endpoint enabled. The machine-readable
+// status is fetched through the provided DatabaseClientProvider, so the status cache of a shared provider limits the
+// requests against the database if the endpoint is polled frequently.
+type Server struct {
+	log                    logr.Logger
+	address                string
+	client                 client.Client
+	databaseClientProvider fdbadminclient.DatabaseClientProvider
+}
+
+var _ manager.Runnable = &Server{}
+var _ manager.LeaderElectionRunnable = &Server{}
+
+// NewServer creates a new Server that serves the health endpoints on the provided address.
+func NewServer(log logr.Logger, address string, client client.Client, databaseClientProvider fdbadminclient.DatabaseClientProvider) *Server {
+	return &Server{
+		log:                    log,
+		address:                address,
+		client:                 client,
+		databaseClientProvider: databaseClientProvider,
+	}
+}
+
+// NeedLeaderElection returns false, so every operator replica serves the health endpoints.
+func (server *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// Start serves the health endpoints until the context is cancelled.
+func (server *Server) Start(ctx context.Context) error {
+	mux := http.NewServeMux()
+	mux.Handle(PathPrefix, server)
+
+	httpServer := &http.Server{
+		Addr:              server.address,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			server.log.Error(err, "could not shutdown health endpoint server")
+		}
+	}()
+
+	server.log.Info("Starting health endpoint server", "address", server.address)
+	err := httpServer.ListenAndServe()
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+
+	return err
+}
+
+// ServeHTTP serves the health endpoint of the cluster defined in the request path. If the cluster is healthy the
+// status code will be 200, otherwise 503. Clusters that don't exist or that don't have the health endpoint enabled
+// will return 404.
+func (server *Server) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet && request.Method != http.MethodHead {
+		http.Error(writer, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	namespace, name, ok := strings.Cut(strings.TrimPrefix(request.URL.Path, PathPrefix), "/")
+	if !ok || namespace == "" || name == "" || strings.Contains(name, "/") {
+		http.Error(writer, fmt.Sprintf("path must have the format %s<namespace>/<cluster>", PathPrefix), http.StatusNotFound)
+		return
+	}
+
+	cluster := &fdbv1beta2.FoundationDBCluster{}
+	err := server.client.Get(request.Context(), client.ObjectKey{Namespace: namespace, Name: name}, cluster)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			http.Error(writer, "cluster not found", http.StatusNotFound)
+			return
+		}
+
+		server.log.Error(err, "could not fetch cluster for health endpoint", "namespace", namespace, "cluster", name)
+		http.Error(writer, "could not fetch cluster", http.StatusInternalServerError)
+		return
+	}
+
+	if !cluster.HealthEndpointEnabled() {
+		http.Error(writer, "health endpoint is not enabled for this cluster", http.StatusNotFound)
+		return
+	}
+
+	response, err := server.getResponse(cluster)
+	if err != nil {
+		server.log.Info("could not fetch machine-readable status for health endpoint", "namespace", namespace, "cluster", name, "error", err.Error())
+		response = Response{
+			Namespace:             namespace,
+			Cluster:               name,
+			MinimumFaultTolerance: cluster.GetHealthEndpointMinimumFaultTolerance(),
+			Message:               "could not fetch machine-readable status",
+		}
+	}
+
+	statusCode := http.StatusOK
+	if !response.Healthy {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(statusCode)
+	if request.Method == http.MethodHead {
+		return
+	}
+
+	err = json.NewEncoder(writer).Encode(response)
+	if err != nil {
+		server.log.Error(err, "could not write health endpoint response", "namespace", namespace, "cluster", name)
+	}
+}
+
+// getResponse fetches the machine-readable status of the cluster and evaluates it.
+func (server *Server) getResponse(cluster *fdbv1beta2.FoundationDBCluster) (Response, error) {
+	adminClient, err := server.databaseClientProvider.GetAdminClient(cluster, server.client)
+	if err != nil {
+		return Response{}, err
+	}
+	defer adminClient.Close()
+
+	status, err := adminClient.GetStatus()
+	if err != nil {
+		return Response{}, err
+	}
+
+	return NewResponse(cluster, status), nil
+}
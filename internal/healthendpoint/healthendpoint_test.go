@@ -0,0 +1,211 @@
+/*
+ * healthendpoint_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package healthendpoint
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient/mock"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("health endpoint", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var status *fdbv1beta2.FoundationDBStatus
+
+	BeforeEach(func() {
+		cluster = &fdbv1beta2.FoundationDBCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "test-ns",
+			},
+			Spec: fdbv1beta2.FoundationDBClusterSpec{
+				Monitoring: fdbv1beta2.ClusterMonitoringOptions{
+					HealthEndpoint: fdbv1beta2.HealthEndpointOptions{
+						Enabled: pointer.Bool(true),
+					},
+				},
+			},
+		}
+
+		status = &fdbv1beta2.FoundationDBStatus{
+			Client: fdbv1beta2.FoundationDBStatusLocalClientInfo{
+				DatabaseStatus: fdbv1beta2.FoundationDBStatusClientDBStatus{
+					Available: true,
+				},
+			},
+			Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+				FullReplication: true,
+				FaultTolerance: fdbv1beta2.FaultTolerance{
+					MaxZoneFailuresWithoutLosingData:         2,
+					MaxZoneFailuresWithoutLosingAvailability: 1,
+				},
+			},
+		}
+	})
+
+	DescribeTable("evaluating the machine-readable status",
+		func(options fdbv1beta2.HealthEndpointOptions, modify func(*fdbv1beta2.FoundationDBStatus), expectedHealthy bool, expectedMessage string) {
+			cluster.Spec.Monitoring.HealthEndpoint = options
+			if modify != nil {
+				modify(status)
+			}
+
+			response := NewResponse(cluster, status)
+			Expect(response.Healthy).To(Equal(expectedHealthy))
+			Expect(response.Message).To(Equal(expectedMessage))
+			Expect(response.FaultTolerance).To(Equal(1))
+		},
+		Entry("an available cluster without requirements",
+			fdbv1beta2.HealthEndpointOptions{},
+			nil,
+			true,
+			"",
+		),
+		Entry("an unavailable cluster",
+			fdbv1beta2.HealthEndpointOptions{},
+			func(status *fdbv1beta2.FoundationDBStatus) {
+				status.Client.DatabaseStatus.Available = false
+			},
+			false,
+			"database is not available",
+		),
+		Entry("a cluster that meets the minimum fault tolerance",
+			fdbv1beta2.HealthEndpointOptions{MinimumFaultTolerance: pointer.Int(1)},
+			nil,
+			true,
+			"",
+		),
+		Entry("a cluster below the minimum fault tolerance",
+			fdbv1beta2.HealthEndpointOptions{MinimumFaultTolerance: pointer.Int(2)},
+			nil,
+			false,
+			"fault tolerance 1 is below the minimum of 2",
+		),
+		Entry("a cluster that is not fully replicated without requiring full replication",
+			fdbv1beta2.HealthEndpointOptions{},
+			func(status *fdbv1beta2.FoundationDBStatus) {
+				status.Cluster.FullReplication = false
+			},
+			true,
+			"",
+		),
+		Entry("a cluster that is not fully replicated with requiring full replication",
+			fdbv1beta2.HealthEndpointOptions{RequireFullReplication: pointer.Bool(true)},
+			func(status *fdbv1beta2.FoundationDBStatus) {
+				status.Cluster.FullReplication = false
+			},
+			false,
+			"database is not fully replicated",
+		),
+	)
+
+	When("serving the health endpoint", func() {
+		var recorder *httptest.ResponseRecorder
+		var path string
+
+		BeforeEach(func() {
+			recorder = httptest.NewRecorder()
+			path = PathPrefix + "test-ns/test"
+		})
+
+		JustBeforeEach(func() {
+			adminClient, err := mock.NewMockAdminClientUncast(cluster, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			adminClient.FrozenStatus = status
+
+			server := NewServer(logr.Discard(), ":0", k8sClient, mock.DatabaseClientProvider{})
+			server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		})
+
+		AfterEach(func() {
+			mock.ClearMockAdminClients()
+		})
+
+		When("the cluster doesn't exist", func() {
+			It("should return not found", func() {
+				Expect(recorder.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		When("the cluster exists", func() {
+			BeforeEach(func() {
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+			})
+
+			When("the cluster is healthy", func() {
+				It("should return the response with status code 200", func() {
+					Expect(recorder.Code).To(Equal(http.StatusOK))
+
+					response := Response{}
+					Expect(json.Unmarshal(recorder.Body.Bytes(), &response)).To(Succeed())
+					Expect(response).To(Equal(Response{
+						Namespace:       "test-ns",
+						Cluster:         "test",
+						Healthy:         true,
+						Available:       true,
+						FullReplication: true,
+						FaultTolerance:  1,
+					}))
+				})
+			})
+
+			When("the cluster is unavailable", func() {
+				BeforeEach(func() {
+					status.Client.DatabaseStatus.Available = false
+				})
+
+				It("should return status code 503", func() {
+					Expect(recorder.Code).To(Equal(http.StatusServiceUnavailable))
+				})
+			})
+
+			When("the path is invalid", func() {
+				BeforeEach(func() {
+					path = PathPrefix + "test-ns"
+				})
+
+				It("should return not found", func() {
+					Expect(recorder.Code).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		When("the health endpoint is not enabled for the cluster", func() {
+			BeforeEach(func() {
+				cluster.Spec.Monitoring.HealthEndpoint.Enabled = nil
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+			})
+
+			It("should return not found", func() {
+				Expect(recorder.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+})
@@ -0,0 +1,236 @@
+/*
+ * interlock.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interlock
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/go-logr/logr"
+)
+
+// maxResponseSize defines the maximum size of the response body that will be read from the endpoint.
+const maxResponseSize = 64 * 1024
+
+// Checker checks if an external freeze is active for a cluster.
+type Checker interface {
+	// IsFrozen returns true and the reason if destructive actions should be deferred for the provided cluster.
+	IsFrozen(ctx context.Context, logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster) (bool, string)
+}
+
+// freezeResponse represents the expected response of the safety interlock endpoint.
+type freezeResponse struct {
+	// Frozen defines whether a freeze is active.
+	Frozen bool `json:"frozen"`
+	// Reason provides a human-readable explanation of the freeze.
+	Reason string `json:"reason,omitempty"`
+}
+
+// cacheEntry stores the result of a previous request to an endpoint.
+type cacheEntry struct {
+	response  *freezeResponse
+	err       error
+	timestamp time.Time
+}
+
+// HTTPChecker implements the Checker interface by querying an HTTP endpoint. The results will be cached per URL, so
+// multiple clusters that use the same endpoint will share the results. Only endpoints that match one of the allowed
+// URLs will be queried, so the URL in the cluster spec cannot be used to send requests to arbitrary endpoints.
+type HTTPChecker struct {
+	client      *http.Client
+	allowedURLs []*url.URL
+	cache       map[string]cacheEntry
+	lock        sync.Mutex
+	now         func() time.Time
+}
+
+// NewHTTPChecker creates a new HTTPChecker. The allowedURLs define the URL prefixes that can be used as endpoint, if
+// no URLs are allowed, no endpoint will be queried and the failure policy of the cluster applies.
+func NewHTTPChecker(allowedURLs []string) *HTTPChecker {
+	parsedURLs := make([]*url.URL, 0, len(allowedURLs))
+	for _, allowedURL := range allowedURLs {
+		parsedURL, err := url.Parse(allowedURL)
+		if err != nil || parsedURL.Host == "" {
+			continue
+		}
+
+		parsedURLs = append(parsedURLs, parsedURL)
+	}
+
+	return &HTTPChecker{
+		client: &http.Client{
+			// Redirects are not followed, otherwise an allowed endpoint could forward the request to any other
+			// endpoint.
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		allowedURLs: parsedURLs,
+		cache:       map[string]cacheEntry{},
+		now:         time.Now,
+	}
+}
+
+// GetAllowedURLs returns the allowed URLs from the provided comma separated list.
+func GetAllowedURLs(allowedURLs string) []string {
+	var urls []string
+	for _, allowedURL := range strings.Split(allowedURLs, ",") {
+		allowedURL = strings.TrimSpace(allowedURL)
+		if allowedURL == "" {
+			continue
+		}
+
+		urls = append(urls, allowedURL)
+	}
+
+	return urls
+}
+
+// IsFrozen returns true and the reason if destructive actions should be deferred for the provided cluster. If the
+// endpoint cannot be queried the failure policy of the cluster defines the result.
+func (checker *HTTPChecker) IsFrozen(ctx context.Context, logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster) (bool, string) {
+	if !cluster.UseSafetyInterlock() {
+		return false, ""
+	}
+
+	url := cluster.Spec.AutomationOptions.SafetyInterlock.URL
+	response, err := checker.getResponse(ctx, url, cluster.GetSafetyInterlockCacheDuration(), cluster.GetSafetyInterlockTimeout())
+	if err != nil {
+		policy := cluster.GetSafetyInterlockFailurePolicy()
+		logger.Info("could not query safety interlock endpoint", "url", url, "error", err.Error(), "failurePolicy", policy)
+		if policy == fdbv1beta2.SafetyInterlockFailurePolicyOpen {
+			return false, ""
+		}
+
+		return true, fmt.Sprintf("safety interlock endpoint could not be queried: %s", err.Error())
+	}
+
+	if !response.Frozen {
+		return false, ""
+	}
+
+	if response.Reason == "" {
+		return true, "freeze is active"
+	}
+
+	return true, fmt.Sprintf("freeze is active: %s", response.Reason)
+}
+
+// isAllowed returns true if the provided URL has the same scheme and host as one of the allowed URLs and its path
+// starts with the path of the allowed URL.
+func (checker *HTTPChecker) isAllowed(rawURL string) bool {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil || parsedURL.User != nil {
+		return false
+	}
+
+	for _, allowedURL := range checker.allowedURLs {
+		if !strings.EqualFold(parsedURL.Scheme, allowedURL.Scheme) || !strings.EqualFold(parsedURL.Host, allowedURL.Host) {
+			continue
+		}
+
+		if strings.HasPrefix(parsedURL.Path, allowedURL.Path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getResponse returns the cached response for the provided URL or queries the endpoint if the cache entry is missing
+// or older than the cache duration. The lock is only held to access the cache, so a slow endpoint doesn't block the
+// checks for other endpoints.
+func (checker *HTTPChecker) getResponse(ctx context.Context, url string, cacheDuration time.Duration, timeout time.Duration) (*freezeResponse, error) {
+	checker.lock.Lock()
+	entry, ok := checker.cache[url]
+	checker.lock.Unlock()
+
+	if ok && checker.now().Sub(entry.timestamp) < cacheDuration {
+		return entry.response, entry.err
+	}
+
+	response, err := checker.query(ctx, url, timeout)
+	timestamp := checker.now()
+
+	checker.lock.Lock()
+	defer checker.lock.Unlock()
+
+	// A concurrent request could have stored a more recent result in the meantime, in this case the more recent
+	// result is kept.
+	current, ok := checker.cache[url]
+	if ok && current.timestamp.After(timestamp) {
+		return response, err
+	}
+
+	checker.cache[url] = cacheEntry{
+		response:  response,
+		err:       err,
+		timestamp: timestamp,
+	}
+
+	return response, err
+}
+
+// query performs the GET request against the endpoint and parses the response.
+func (checker *HTTPChecker) query(ctx context.Context, endpoint string, timeout time.Duration) (*freezeResponse, error) {
+	if !checker.isAllowed(endpoint) {
+		return nil, fmt.Errorf("URL %s is not allowed by the operator", endpoint)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(timeoutCtx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := checker.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, err
+	}
+
+	response := &freezeResponse{}
+	err = json.Unmarshal(body, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
@@ -0,0 +1,253 @@
+/*
+ * interlock_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interlock
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("interlock", func() {
+	When("checking if a freeze is active", func() {
+		var server *httptest.Server
+		// The handler and the checker are called from different goroutines, so the shared state is accessed with
+		// atomics.
+		var statusCode atomic.Int32
+		var body atomic.Value
+		var requests atomic.Int32
+		var checker *HTTPChecker
+		var cluster *fdbv1beta2.FoundationDBCluster
+		var currentTime atomic.Int64
+		var frozen bool
+		var reason string
+
+		BeforeEach(func() {
+			statusCode.Store(http.StatusOK)
+			body.Store(`{"frozen": false}`)
+			requests.Store(0)
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				requests.Add(1)
+				if statusCode.Load() == http.StatusFound {
+					w.Header().Set("Location", "/redirected")
+				}
+				w.WriteHeader(int(statusCode.Load()))
+				_, _ = w.Write([]byte(body.Load().(string)))
+			}))
+
+			currentTime.Store(time.Now().UnixNano())
+			checker = NewHTTPChecker([]string{server.URL})
+			checker.now = func() time.Time {
+				return time.Unix(0, currentTime.Load())
+			}
+
+			cluster = &fdbv1beta2.FoundationDBCluster{
+				Spec: fdbv1beta2.FoundationDBClusterSpec{
+					AutomationOptions: fdbv1beta2.FoundationDBClusterAutomationOptions{
+						SafetyInterlock: fdbv1beta2.SafetyInterlockOptions{
+							URL: server.URL,
+						},
+					},
+				},
+			}
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		JustBeforeEach(func() {
+			frozen, reason = checker.IsFrozen(context.Background(), GinkgoLogr, cluster)
+		})
+
+		When("no URL is defined", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.SafetyInterlock.URL = ""
+			})
+
+			It("should not be frozen", func() {
+				Expect(frozen).To(BeFalse())
+				Expect(requests.Load()).To(BeZero())
+			})
+		})
+
+		When("no freeze is active", func() {
+			It("should not be frozen", func() {
+				Expect(frozen).To(BeFalse())
+				Expect(reason).To(BeEmpty())
+				Expect(requests.Load()).To(BeNumerically("==", 1))
+			})
+		})
+
+		When("a freeze is active", func() {
+			BeforeEach(func() {
+				body.Store(`{"frozen": true, "reason": "incident"}`)
+			})
+
+			It("should be frozen", func() {
+				Expect(frozen).To(BeTrue())
+				Expect(reason).To(Equal("freeze is active: incident"))
+			})
+
+			When("the freeze is lifted within the cache duration", func() {
+				It("should use the cached result", func() {
+					body.Store(`{"frozen": false}`)
+					currentTime.Add(int64(30 * time.Second))
+					frozen, _ = checker.IsFrozen(context.Background(), GinkgoLogr, cluster)
+					Expect(frozen).To(BeTrue())
+					Expect(requests.Load()).To(BeNumerically("==", 1))
+				})
+			})
+
+			When("the freeze is lifted after the cache duration", func() {
+				It("should query the endpoint again", func() {
+					body.Store(`{"frozen": false}`)
+					currentTime.Add(int64(61 * time.Second))
+					frozen, _ = checker.IsFrozen(context.Background(), GinkgoLogr, cluster)
+					Expect(frozen).To(BeFalse())
+					Expect(requests.Load()).To(BeNumerically("==", 2))
+				})
+			})
+		})
+
+		When("another endpoint is slow", func() {
+			It("should not wait for the slow endpoint", func() {
+				started := make(chan struct{})
+				release := make(chan struct{})
+				slowServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					close(started)
+					<-release
+					_, _ = w.Write([]byte(`{"frozen": true}`))
+				}))
+
+				slowURL, err := url.Parse(slowServer.URL)
+				Expect(err).NotTo(HaveOccurred())
+				checker.allowedURLs = append(checker.allowedURLs, slowURL)
+				slowCluster := cluster.DeepCopy()
+				slowCluster.Spec.AutomationOptions.SafetyInterlock.URL = slowServer.URL
+				slowDone := make(chan struct{})
+				go func() {
+					defer GinkgoRecover()
+					defer close(slowDone)
+					_, _ = checker.IsFrozen(context.Background(), GinkgoLogr, slowCluster)
+				}()
+				// The slow request must be finished before the next test modifies the shared state.
+				DeferCleanup(func() {
+					close(release)
+					Eventually(slowDone).Should(BeClosed())
+					slowServer.Close()
+				})
+				Eventually(started).Should(BeClosed())
+
+				result := make(chan bool, 1)
+				currentTime.Add(int64(61 * time.Second))
+				go func() {
+					defer GinkgoRecover()
+					isFrozen, _ := checker.IsFrozen(context.Background(), GinkgoLogr, cluster)
+					result <- isFrozen
+				}()
+				Eventually(result).Should(Receive(BeFalse()))
+				Expect(requests.Load()).To(BeNumerically("==", 2))
+			})
+		})
+
+		When("the URL is not allowed", func() {
+			BeforeEach(func() {
+				checker.allowedURLs = NewHTTPChecker([]string{server.URL + "/freeze"}).allowedURLs
+			})
+
+			It("should not query the endpoint and be frozen with the default failure policy", func() {
+				Expect(frozen).To(BeTrue())
+				Expect(reason).To(Equal("safety interlock endpoint could not be queried: URL " + server.URL + " is not allowed by the operator"))
+				Expect(requests.Load()).To(BeZero())
+			})
+
+			When("the path of the URL starts with the path of the allowed URL", func() {
+				BeforeEach(func() {
+					cluster.Spec.AutomationOptions.SafetyInterlock.URL = server.URL + "/freeze/status"
+				})
+
+				It("should query the endpoint", func() {
+					Expect(frozen).To(BeFalse())
+					Expect(requests.Load()).To(BeNumerically("==", 1))
+				})
+			})
+		})
+
+		When("the endpoint redirects the request", func() {
+			BeforeEach(func() {
+				statusCode.Store(http.StatusFound)
+			})
+
+			It("should not follow the redirect and be frozen with the default failure policy", func() {
+				Expect(frozen).To(BeTrue())
+				Expect(reason).To(Equal("safety interlock endpoint could not be queried: unexpected status code 302"))
+				Expect(requests.Load()).To(BeNumerically("==", 1))
+			})
+		})
+
+		When("the endpoint returns an error", func() {
+			BeforeEach(func() {
+				statusCode.Store(http.StatusInternalServerError)
+			})
+
+			It("should be frozen with the default failure policy", func() {
+				Expect(frozen).To(BeTrue())
+				Expect(reason).To(Equal("safety interlock endpoint could not be queried: unexpected status code 500"))
+			})
+
+			When("the failure policy is Open", func() {
+				BeforeEach(func() {
+					cluster.Spec.AutomationOptions.SafetyInterlock.FailurePolicy = fdbv1beta2.SafetyInterlockFailurePolicyOpen
+				})
+
+				It("should not be frozen", func() {
+					Expect(frozen).To(BeFalse())
+				})
+			})
+		})
+
+		When("the endpoint returns an invalid body", func() {
+			BeforeEach(func() {
+				body.Store("frozen")
+			})
+
+			It("should be frozen with the default failure policy", func() {
+				Expect(frozen).To(BeTrue())
+			})
+		})
+	})
+
+	DescribeTable("getting the allowed URLs", func(allowedURLs string, expected []string) {
+		Expect(GetAllowedURLs(allowedURLs)).To(Equal(expected))
+	},
+		Entry("no allowed URLs", "", nil),
+		Entry("a single allowed URL", "https://freeze.example.com/api", []string{"https://freeze.example.com/api"}),
+		Entry("multiple allowed URLs with spaces", "https://freeze.example.com/api, http://freeze.local ,", []string{"https://freeze.example.com/api", "http://freeze.local"}),
+	)
+})
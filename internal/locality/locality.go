@@ -266,7 +266,9 @@ func ChooseDistributedProcesses(cluster *fdbv1beta2.FoundationDBCluster, process
 
 // GetHardLimits returns the distribution of localities.
 func GetHardLimits(cluster *fdbv1beta2.FoundationDBCluster) map[string]int {
-	if cluster.Spec.DatabaseConfiguration.UsableRegions <= 1 {
+	// Clusters with a single region that spread their coordinators across the data centers use the same hard limits
+	// as multi-region clusters.
+	if cluster.Spec.DatabaseConfiguration.UsableRegions <= 1 && !cluster.UseDataCenterCoordinators() {
 		// For the three_data_hall redundancy mode we will recruit 9 coordinators and those hard limits are only used
 		// for selecting coordinators. We want to make sure we select coordinators across as many fault domains as possible.
 		if cluster.Spec.DatabaseConfiguration.RedundancyMode == fdbv1beta2.RedundancyModeThreeDataHall {
@@ -870,6 +870,64 @@ var _ = Describe("Localities", func() {
 				fdbv1beta2.FDBLocalityDCIDKey:   3,
 			},
 		),
+		Entry("cluster with one usable region and 3 DCs",
+			&fdbv1beta2.FoundationDBCluster{
+				Spec: fdbv1beta2.FoundationDBClusterSpec{
+					DatabaseConfiguration: fdbv1beta2.DatabaseConfiguration{
+						Regions: []fdbv1beta2.Region{
+							{
+								DataCenters: []fdbv1beta2.DataCenter{
+									{
+										ID: "dc1",
+									},
+									{
+										ID:        "dc2",
+										Satellite: 1,
+									},
+									{
+										ID:        "dc3",
+										Satellite: 1,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			map[string]int{
+				fdbv1beta2.FDBLocalityZoneIDKey: 1,
+			},
+		),
+		Entry("cluster with one usable region and 3 DCs that spreads the coordinators across the DCs",
+			&fdbv1beta2.FoundationDBCluster{
+				Spec: fdbv1beta2.FoundationDBClusterSpec{
+					SpreadCoordinatorsAcrossDataCenters: pointer.Bool(true),
+					DatabaseConfiguration: fdbv1beta2.DatabaseConfiguration{
+						Regions: []fdbv1beta2.Region{
+							{
+								DataCenters: []fdbv1beta2.DataCenter{
+									{
+										ID: "dc1",
+									},
+									{
+										ID:        "dc2",
+										Satellite: 1,
+									},
+									{
+										ID:        "dc3",
+										Satellite: 1,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			map[string]int{
+				fdbv1beta2.FDBLocalityZoneIDKey: 1,
+				fdbv1beta2.FDBLocalityDCIDKey:   3,
+			},
+		),
 		Entry("default cluster with one usable region and three data hall",
 			&fdbv1beta2.FoundationDBCluster{
 				Spec: fdbv1beta2.FoundationDBClusterSpec{
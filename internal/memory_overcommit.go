@@ -0,0 +1,196 @@
+/*
+ * memory_overcommit.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	monitorapi "github.com/apple/foundationdb/fdbkubernetesmonitor/api"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// defaultProcessMemory is the memory limit of a fdbserver process if the memory knob is not defined.
+const defaultProcessMemory int64 = 8 << 30
+
+// processMemorySuffixes are the suffixes that fdbserver supports for the memory knob, with their multipliers.
+var processMemorySuffixes = []struct {
+	suffix     string
+	multiplier int64
+}{
+	{"KiB", 1 << 10},
+	{"MiB", 1 << 20},
+	{"GiB", 1 << 30},
+	{"TiB", 1 << 40},
+	{"KB", 1000},
+	{"MB", 1000 * 1000},
+	{"GB", 1000 * 1000 * 1000},
+	{"TB", 1000 * 1000 * 1000 * 1000},
+	{"B", 1},
+}
+
+// NodeMemoryCommitment represents the memory of a node that is committed to FoundationDB Pods.
+type NodeMemoryCommitment struct {
+	// Node is the node the memory is committed on.
+	Node *corev1.Node
+	// Committed is the sum of the memory limits of all FoundationDB Pods that run on the node.
+	Committed int64
+}
+
+// parseProcessMemory parses the value of the memory knob with the suffixes that are supported by fdbserver.
+func parseProcessMemory(value string) (int64, error) {
+	value = strings.TrimSpace(value)
+	multiplier := int64(1)
+	for _, suffix := range processMemorySuffixes {
+		if strings.HasSuffix(value, suffix.suffix) {
+			value = strings.TrimSuffix(value, suffix.suffix)
+			multiplier = suffix.multiplier
+			break
+		}
+	}
+
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	return parsed * multiplier, nil
+}
+
+// GetPodMemoryFromKnobs returns the memory that the fdbserver processes of a Pod of the provided process class can
+// use, based on the memory knob of the processes and the number of processes per Pod. If the memory knob is not
+// defined the fdbserver default of 8GiB per process is used.
+func GetPodMemoryFromKnobs(cluster *fdbv1beta2.FoundationDBCluster, processClass fdbv1beta2.ProcessClass) int64 {
+	serversPerPod := cluster.GetDesiredServersPerPod(processClass)
+	processMemory := defaultProcessMemory
+
+	config := GetMonitorProcessConfiguration(cluster, processClass, serversPerPod, cluster.DesiredImageType())
+	for _, argument := range config.Arguments {
+		if argument.ArgumentType != monitorapi.ConcatenateArgumentType || len(argument.Values) != 2 {
+			continue
+		}
+
+		if argument.Values[0].ArgumentType != monitorapi.LiteralArgumentType || argument.Values[0].Value != "--memory=" {
+			continue
+		}
+
+		memory, err := parseProcessMemory(argument.Values[1].Value)
+		if err == nil {
+			processMemory = memory
+		}
+	}
+
+	return processMemory * int64(serversPerPod)
+}
+
+// getPodMemoryLimit returns the sum of the memory limits of all containers of the Pod. If a container defines no
+// memory limit, the memory request is used.
+func getPodMemoryLimit(pod *corev1.Pod) int64 {
+	var memory int64
+	for _, container := range pod.Spec.Containers {
+		if limit, ok := container.Resources.Limits[corev1.ResourceMemory]; ok {
+			memory += limit.Value()
+			continue
+		}
+
+		if request, ok := container.Resources.Requests[corev1.ResourceMemory]; ok {
+			memory += request.Value()
+		}
+	}
+
+	return memory
+}
+
+// GetNodeMemoryCommitments returns the memory that is committed to FoundationDB Pods for every node. All Pods with the
+// process class label of the cluster are considered, including the Pods of other clusters that use the same label.
+func GetNodeMemoryCommitments(ctx context.Context, reader client.Reader, cluster *fdbv1beta2.FoundationDBCluster) ([]NodeMemoryCommitment, error) {
+	nodes := &corev1.NodeList{}
+	err := reader.List(ctx, nodes)
+	if err != nil {
+		return nil, err
+	}
+
+	pods := &corev1.PodList{}
+	err = reader.List(ctx, pods, client.HasLabels{cluster.GetProcessClassLabel()})
+	if err != nil {
+		return nil, err
+	}
+
+	committed := make(map[string]int64, len(nodes.Items))
+	for idx := range pods.Items {
+		pod := &pods.Items[idx]
+		if pod.Spec.NodeName == "" || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+
+		committed[pod.Spec.NodeName] += getPodMemoryLimit(pod)
+	}
+
+	commitments := make([]NodeMemoryCommitment, 0, len(nodes.Items))
+	for idx := range nodes.Items {
+		node := &nodes.Items[idx]
+		commitments = append(commitments, NodeMemoryCommitment{Node: node, Committed: committed[node.Name]})
+	}
+
+	return commitments, nil
+}
+
+// GetMemoryOvercommitConflict checks if a Pod with the provided spec for the process group can be scheduled on any
+// node without committing more than the allowed percentage of the allocatable memory of the node. If no such node
+// exists, a message describing the conflict is returned. If no node matches the node constraints of the Pod spec, the
+// conflict can't be determined and an empty message is returned.
+func GetMemoryOvercommitConflict(cluster *fdbv1beta2.FoundationDBCluster, commitments []NodeMemoryCommitment, spec *corev1.PodSpec, processGroup *fdbv1beta2.ProcessGroupStatus) string {
+	podMemory := GetPodMemoryFromKnobs(cluster, processGroup.ProcessClass)
+	ratio := int64(cluster.GetMemoryOvercommitRatioPercentage())
+
+	candidates := 0
+	for _, commitment := range commitments {
+		node := commitment.Node
+		if node.Spec.Unschedulable || !podSpecMatchesNode(spec, node) {
+			continue
+		}
+
+		// If the volume is bound to a node, the Pod can only be scheduled on this node.
+		if processGroup.VolumeNodeName != "" && processGroup.VolumeNodeName != node.Name {
+			continue
+		}
+
+		allocatable, ok := node.Status.Allocatable[corev1.ResourceMemory]
+		if !ok {
+			continue
+		}
+
+		candidates++
+		if commitment.Committed+podMemory <= allocatable.Value()*ratio/100 {
+			return ""
+		}
+	}
+
+	if candidates == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("none of the %d candidate nodes has %d bytes of memory left for the %s processes with an overcommit ratio of %d%%", candidates, podMemory, processGroup.ProcessClass, ratio)
+}
@@ -0,0 +1,180 @@
+/*
+ * memory_overcommit_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("memory_overcommit", func() {
+	DescribeTable("parsing the process memory", func(value string, expected int64, expectedErr bool) {
+		memory, err := parseProcessMemory(value)
+		if expectedErr {
+			Expect(err).To(HaveOccurred())
+			return
+		}
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(memory).To(Equal(expected))
+	},
+		Entry("without a suffix", "8321499136", int64(8321499136), false),
+		Entry("with bytes", "1024B", int64(1024), false),
+		Entry("with KiB", "4KiB", int64(4096), false),
+		Entry("with MB", "2MB", int64(2000000), false),
+		Entry("with GiB", "6GiB", int64(6<<30), false),
+		Entry("with GB", "8GB", int64(8000000000), false),
+		Entry("with TiB", "1TiB", int64(1<<40), false),
+		Entry("with an invalid value", "many", int64(0), true),
+	)
+
+	When("getting the Pod memory from the knobs", func() {
+		var cluster *fdbv1beta2.FoundationDBCluster
+
+		BeforeEach(func() {
+			cluster = CreateDefaultCluster()
+		})
+
+		It("uses the fdbserver default if no memory knob is defined", func() {
+			Expect(GetPodMemoryFromKnobs(cluster, fdbv1beta2.ProcessClassStorage)).To(Equal(int64(8 << 30)))
+		})
+
+		When("the memory knob is defined", func() {
+			BeforeEach(func() {
+				cluster.Spec.Processes = map[fdbv1beta2.ProcessClass]fdbv1beta2.ProcessSettings{
+					fdbv1beta2.ProcessClassGeneral: {CustomParameters: fdbv1beta2.FoundationDBCustomParameters{"memory=6GiB"}},
+				}
+			})
+
+			It("uses the memory knob", func() {
+				Expect(GetPodMemoryFromKnobs(cluster, fdbv1beta2.ProcessClassStorage)).To(Equal(int64(6 << 30)))
+			})
+
+			When("multiple storage servers are running per Pod", func() {
+				BeforeEach(func() {
+					cluster.Spec.StorageServersPerPod = 2
+				})
+
+				It("multiplies the memory with the number of processes", func() {
+					Expect(GetPodMemoryFromKnobs(cluster, fdbv1beta2.ProcessClassStorage)).To(Equal(int64(12 << 30)))
+				})
+
+				It("doesn't multiply the memory of other process classes", func() {
+					Expect(GetPodMemoryFromKnobs(cluster, fdbv1beta2.ProcessClassLog)).To(Equal(int64(6 << 30)))
+				})
+			})
+		})
+	})
+
+	When("checking for a memory overcommit conflict", func() {
+		var cluster *fdbv1beta2.FoundationDBCluster
+		var commitments []NodeMemoryCommitment
+		var processGroup *fdbv1beta2.ProcessGroupStatus
+		var spec *corev1.PodSpec
+
+		newNode := func(name string, zone string, allocatable string) *corev1.Node {
+			return &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   name,
+					Labels: map[string]string{corev1.LabelTopologyZone: zone},
+				},
+				Status: corev1.NodeStatus{
+					Allocatable: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse(allocatable),
+					},
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			cluster = CreateDefaultCluster()
+			cluster.Spec.AutomationOptions.MemoryOvercommitProtection = &fdbv1beta2.MemoryOvercommitProtectionOptions{}
+			processGroup = &fdbv1beta2.ProcessGroupStatus{
+				ProcessGroupID: "storage-1",
+				ProcessClass:   fdbv1beta2.ProcessClassStorage,
+			}
+			spec = &corev1.PodSpec{}
+			commitments = []NodeMemoryCommitment{
+				{Node: newNode("node-1", "zone-a", "16Gi"), Committed: 12 << 30},
+				{Node: newNode("node-2", "zone-b", "16Gi"), Committed: 4 << 30},
+			}
+		})
+
+		It("doesn't report a conflict if a node has enough memory left", func() {
+			Expect(GetMemoryOvercommitConflict(cluster, commitments, spec, processGroup)).To(BeEmpty())
+		})
+
+		When("the Pod can only be scheduled on the node without enough memory", func() {
+			BeforeEach(func() {
+				spec.NodeSelector = map[string]string{corev1.LabelTopologyZone: "zone-a"}
+			})
+
+			It("reports a conflict", func() {
+				Expect(GetMemoryOvercommitConflict(cluster, commitments, spec, processGroup)).To(ContainSubstring("none of the 1 candidate nodes"))
+			})
+
+			When("the overcommit ratio allows enough memory", func() {
+				BeforeEach(func() {
+					cluster.Spec.AutomationOptions.MemoryOvercommitProtection.OvercommitRatioPercentage = pointer.Int(150)
+				})
+
+				It("doesn't report a conflict", func() {
+					Expect(GetMemoryOvercommitConflict(cluster, commitments, spec, processGroup)).To(BeEmpty())
+				})
+			})
+		})
+
+		When("the volume of the process group is bound to the node without enough memory", func() {
+			BeforeEach(func() {
+				processGroup.VolumeNodeName = "node-1"
+			})
+
+			It("reports a conflict", func() {
+				Expect(GetMemoryOvercommitConflict(cluster, commitments, spec, processGroup)).NotTo(BeEmpty())
+			})
+		})
+
+		When("the node with enough memory is unschedulable", func() {
+			BeforeEach(func() {
+				commitments[1].Node.Spec.Unschedulable = true
+			})
+
+			It("reports a conflict", func() {
+				Expect(GetMemoryOvercommitConflict(cluster, commitments, spec, processGroup)).NotTo(BeEmpty())
+			})
+		})
+
+		When("no node matches the node constraints of the Pod", func() {
+			BeforeEach(func() {
+				spec.NodeSelector = map[string]string{corev1.LabelTopologyZone: "zone-c"}
+			})
+
+			It("doesn't report a conflict", func() {
+				Expect(GetMemoryOvercommitConflict(cluster, commitments, spec, processGroup)).To(BeEmpty())
+			})
+		})
+	})
+})
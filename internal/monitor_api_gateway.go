@@ -0,0 +1,228 @@
+/*
+ * monitor_api_gateway.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	"fmt"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+var (
+	// HTTPRouteGVK is the GroupVersionKind of the Gateway API HTTPRoute.
+	HTTPRouteGVK = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "HTTPRoute"}
+	// CertificateGVK is the GroupVersionKind of the cert-manager Certificate.
+	CertificateGVK = schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}
+)
+
+// GetMonitorAPIResourceName returns the name of the Service and the HTTPRoute that expose the monitor API of the
+// provided process group.
+func GetMonitorAPIResourceName(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus) string {
+	return fmt.Sprintf("%s-monitor-api", processGroup.GetPodName(cluster))
+}
+
+// GetMonitorAPIPathPrefix returns the path prefix that is matched by the HTTPRoute of the provided process group.
+func GetMonitorAPIPathPrefix(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus) string {
+	return fmt.Sprintf("/%s/%s/%s", cluster.Namespace, cluster.Name, processGroup.ProcessGroupID)
+}
+
+// getMonitorAPIMetadata returns the metadata for the Service and the HTTPRoute of the provided process group.
+func getMonitorAPIMetadata(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus) metav1.ObjectMeta {
+	metadata := GetObjectMetadata(cluster, nil, processGroup.ProcessClass, processGroup.ProcessGroupID)
+	metadata.Name = GetMonitorAPIResourceName(cluster, processGroup)
+	metadata.Labels[fdbv1beta2.MonitorAPILabel] = "true"
+	metadata.OwnerReferences = BuildOwnerReference(cluster.TypeMeta, cluster.ObjectMeta)
+	addPropagatedMetadata(cluster, &metadata)
+
+	return metadata
+}
+
+// GetMonitorAPIService builds the Service that selects the Pod of the provided process group and exposes the API of
+// the fdb-kubernetes-monitor.
+func GetMonitorAPIService(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus) *corev1.Service {
+	port := cluster.GetMonitorAPIPort()
+
+	var ipFamilies []corev1.IPFamily
+	if cluster.IsPodIPFamily6() {
+		ipFamilies = []corev1.IPFamily{corev1.IPv6Protocol}
+	}
+
+	return &corev1.Service{
+		ObjectMeta: getMonitorAPIMetadata(cluster, processGroup),
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeClusterIP,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "monitor-api",
+					Protocol:   corev1.ProtocolTCP,
+					Port:       int32(port),
+					TargetPort: intstr.FromInt(port),
+				},
+			},
+			PublishNotReadyAddresses: true,
+			Selector:                 GetPodMatchLabels(cluster, "", string(processGroup.ProcessGroupID)),
+			IPFamilies:               ipFamilies,
+		},
+	}
+}
+
+// GetMonitorAPIRoute builds the HTTPRoute that forwards the requests for the path prefix of the provided process group
+// to the monitor API Service of the process group. The HTTPRoute is returned as unstructured object, so the operator
+// doesn't depend on the Gateway API types. The hash of the spec is stored in the LastSpecKey annotation.
+func GetMonitorAPIRoute(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus) (*unstructured.Unstructured, error) {
+	config := cluster.Spec.Routing.MonitorAPIGateway
+	if config == nil {
+		return nil, fmt.Errorf("monitorAPIGateway is not configured")
+	}
+
+	parentRefs := make([]interface{}, 0, len(config.ParentRefs))
+	for _, parentRef := range config.ParentRefs {
+		ref := map[string]interface{}{
+			"name": parentRef.Name,
+		}
+
+		if parentRef.Namespace != "" {
+			ref["namespace"] = parentRef.Namespace
+		}
+
+		if parentRef.SectionName != "" {
+			ref["sectionName"] = parentRef.SectionName
+		}
+
+		parentRefs = append(parentRefs, ref)
+	}
+
+	spec := map[string]interface{}{
+		"parentRefs": parentRefs,
+		"rules": []interface{}{
+			map[string]interface{}{
+				"matches": []interface{}{
+					map[string]interface{}{
+						"path": map[string]interface{}{
+							"type":  "PathPrefix",
+							"value": GetMonitorAPIPathPrefix(cluster, processGroup),
+						},
+					},
+				},
+				"filters": []interface{}{
+					map[string]interface{}{
+						"type": "URLRewrite",
+						"urlRewrite": map[string]interface{}{
+							"path": map[string]interface{}{
+								"type":               "ReplacePrefixMatch",
+								"replacePrefixMatch": "/",
+							},
+						},
+					},
+				},
+				"backendRefs": []interface{}{
+					map[string]interface{}{
+						"name": GetMonitorAPIResourceName(cluster, processGroup),
+						"port": int64(cluster.GetMonitorAPIPort()),
+					},
+				},
+			},
+		},
+	}
+
+	if config.Hostname != "" {
+		spec["hostnames"] = []interface{}{config.Hostname}
+	}
+
+	return newUnstructuredWithSpec(HTTPRouteGVK, getMonitorAPIMetadata(cluster, processGroup), spec)
+}
+
+// GetMonitorAPICertificateName returns the name of the Certificate and the Secret for the hostname of the monitor API
+// routes.
+func GetMonitorAPICertificateName(cluster *fdbv1beta2.FoundationDBCluster) string {
+	return fmt.Sprintf("%s-monitor-api", cluster.Name)
+}
+
+// GetMonitorAPICertificate builds the cert-manager Certificate for the hostname of the monitor API routes. If no
+// certificate issuer is defined, nil will be returned.
+func GetMonitorAPICertificate(cluster *fdbv1beta2.FoundationDBCluster) (*unstructured.Unstructured, error) {
+	config := cluster.Spec.Routing.MonitorAPIGateway
+	if config == nil || config.CertificateIssuer == nil {
+		return nil, nil
+	}
+
+	issuerKind := config.CertificateIssuer.Kind
+	if issuerKind == "" {
+		issuerKind = "Issuer"
+	}
+
+	issuerGroup := config.CertificateIssuer.Group
+	if issuerGroup == "" {
+		issuerGroup = CertificateGVK.Group
+	}
+
+	metadata := GetObjectMetadata(cluster, nil, "", "")
+	metadata.Name = GetMonitorAPICertificateName(cluster)
+	metadata.Labels[fdbv1beta2.MonitorAPILabel] = "true"
+	metadata.OwnerReferences = BuildOwnerReference(cluster.TypeMeta, cluster.ObjectMeta)
+	addPropagatedMetadata(cluster, &metadata)
+
+	spec := map[string]interface{}{
+		"secretName": fmt.Sprintf("%s-tls", GetMonitorAPICertificateName(cluster)),
+		"dnsNames":   []interface{}{config.Hostname},
+		"usages":     []interface{}{"server auth"},
+		"issuerRef": map[string]interface{}{
+			"name":  config.CertificateIssuer.Name,
+			"kind":  issuerKind,
+			"group": issuerGroup,
+		},
+	}
+
+	return newUnstructuredWithSpec(CertificateGVK, metadata, spec)
+}
+
+// newUnstructuredWithSpec creates an unstructured object with the provided metadata and spec. The hash of the spec is
+// stored in the LastSpecKey annotation, so changes can be detected without comparing the defaulted fields.
+func newUnstructuredWithSpec(gvk schema.GroupVersionKind, metadata metav1.ObjectMeta, spec map[string]interface{}) (*unstructured.Unstructured, error) {
+	specHash, err := GetJSONHash(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	if metadata.Annotations == nil {
+		metadata.Annotations = map[string]string{}
+	}
+	metadata.Annotations[fdbv1beta2.LastSpecKey] = specHash
+
+	object := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": spec,
+		},
+	}
+	object.SetGroupVersionKind(gvk)
+	object.SetName(metadata.Name)
+	object.SetNamespace(metadata.Namespace)
+	object.SetLabels(metadata.Labels)
+	object.SetAnnotations(metadata.Annotations)
+	object.SetOwnerReferences(metadata.OwnerReferences)
+
+	return object, nil
+}
@@ -0,0 +1,186 @@
+/*
+ * monitor_api_gateway_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("monitor_api_gateway", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var processGroup *fdbv1beta2.ProcessGroupStatus
+
+	BeforeEach(func() {
+		cluster = CreateDefaultCluster()
+		cluster.Spec.Routing.MonitorAPIGateway = &fdbv1beta2.MonitorAPIGatewayConfig{
+			Enabled: pointer.Bool(true),
+			ParentRefs: []fdbv1beta2.GatewayParentReference{
+				{
+					Name:        "gateway",
+					Namespace:   "gateway-ns",
+					SectionName: "https",
+				},
+			},
+			Hostname: "fdb.example.org",
+		}
+		processGroup = fdbv1beta2.NewProcessGroupStatus("storage-1", fdbv1beta2.ProcessClassStorage, nil)
+	})
+
+	When("getting the monitor API service", func() {
+		var service *corev1.Service
+
+		JustBeforeEach(func() {
+			service = GetMonitorAPIService(cluster, processGroup)
+		})
+
+		It("should select the Pod of the process group", func() {
+			Expect(service.Name).To(Equal("operator-test-1-storage-1-monitor-api"))
+			Expect(service.Namespace).To(Equal("my-ns"))
+			Expect(service.Labels).To(HaveKeyWithValue(fdbv1beta2.MonitorAPILabel, "true"))
+			Expect(service.Spec.Selector).To(Equal(map[string]string{
+				fdbv1beta2.FDBClusterLabel:        "operator-test-1",
+				fdbv1beta2.FDBProcessGroupIDLabel: "storage-1",
+			}))
+			Expect(service.Spec.Ports).To(HaveLen(1))
+			Expect(service.Spec.Ports[0].Port).To(BeNumerically("==", 8081))
+			Expect(service.Spec.Ports[0].TargetPort.IntValue()).To(Equal(8081))
+		})
+
+		When("a custom port is defined", func() {
+			BeforeEach(func() {
+				cluster.Spec.Routing.MonitorAPIGateway.Port = pointer.Int(9090)
+			})
+
+			It("should use the custom port", func() {
+				Expect(service.Spec.Ports[0].Port).To(BeNumerically("==", 9090))
+				Expect(service.Spec.Ports[0].TargetPort.IntValue()).To(Equal(9090))
+			})
+		})
+	})
+
+	When("getting the monitor API route", func() {
+		var route *unstructured.Unstructured
+
+		JustBeforeEach(func() {
+			var err error
+			route, err = GetMonitorAPIRoute(cluster, processGroup)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should route the path prefix of the process group to the service", func() {
+			Expect(route.GroupVersionKind()).To(Equal(HTTPRouteGVK))
+			Expect(route.GetName()).To(Equal("operator-test-1-storage-1-monitor-api"))
+			Expect(route.GetNamespace()).To(Equal("my-ns"))
+			Expect(route.GetLabels()).To(HaveKeyWithValue(fdbv1beta2.MonitorAPILabel, "true"))
+			Expect(route.GetAnnotations()).To(HaveKey(fdbv1beta2.LastSpecKey))
+
+			hostnames, _, err := unstructured.NestedStringSlice(route.Object, "spec", "hostnames")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hostnames).To(ConsistOf("fdb.example.org"))
+
+			parentRefs, _, err := unstructured.NestedSlice(route.Object, "spec", "parentRefs")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parentRefs).To(ConsistOf(map[string]interface{}{
+				"name":        "gateway",
+				"namespace":   "gateway-ns",
+				"sectionName": "https",
+			}))
+
+			rules, _, err := unstructured.NestedSlice(route.Object, "spec", "rules")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rules).To(HaveLen(1))
+			rule := rules[0].(map[string]interface{})
+			pathPrefix, _, err := unstructured.NestedString(rule["matches"].([]interface{})[0].(map[string]interface{}), "path", "value")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pathPrefix).To(Equal("/my-ns/operator-test-1/storage-1"))
+			Expect(rule["backendRefs"]).To(ConsistOf(map[string]interface{}{
+				"name": "operator-test-1-storage-1-monitor-api",
+				"port": int64(8081),
+			}))
+		})
+
+		When("the hostname changes", func() {
+			var previousHash string
+
+			BeforeEach(func() {
+				previousRoute, err := GetMonitorAPIRoute(cluster, processGroup)
+				Expect(err).NotTo(HaveOccurred())
+				previousHash = previousRoute.GetAnnotations()[fdbv1beta2.LastSpecKey]
+				cluster.Spec.Routing.MonitorAPIGateway.Hostname = "fdb-2.example.org"
+			})
+
+			It("should change the spec hash", func() {
+				Expect(route.GetAnnotations()[fdbv1beta2.LastSpecKey]).NotTo(Equal(previousHash))
+			})
+		})
+	})
+
+	When("getting the monitor API certificate", func() {
+		var certificate *unstructured.Unstructured
+
+		JustBeforeEach(func() {
+			var err error
+			certificate, err = GetMonitorAPICertificate(cluster)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		When("no certificate issuer is defined", func() {
+			It("should not return a certificate", func() {
+				Expect(certificate).To(BeNil())
+			})
+		})
+
+		When("a certificate issuer is defined", func() {
+			BeforeEach(func() {
+				cluster.Spec.Routing.MonitorAPIGateway.CertificateIssuer = &fdbv1beta2.CertificateIssuerReference{
+					Name: "issuer",
+				}
+			})
+
+			It("should return a certificate for the hostname", func() {
+				Expect(certificate).NotTo(BeNil())
+				Expect(certificate.GroupVersionKind()).To(Equal(CertificateGVK))
+				Expect(certificate.GetName()).To(Equal("operator-test-1-monitor-api"))
+
+				secretName, _, err := unstructured.NestedString(certificate.Object, "spec", "secretName")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(secretName).To(Equal("operator-test-1-monitor-api-tls"))
+
+				dnsNames, _, err := unstructured.NestedStringSlice(certificate.Object, "spec", "dnsNames")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(dnsNames).To(ConsistOf("fdb.example.org"))
+
+				issuerRef, _, err := unstructured.NestedStringMap(certificate.Object, "spec", "issuerRef")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(issuerRef).To(Equal(map[string]string{
+					"name":  "issuer",
+					"kind":  "Issuer",
+					"group": "cert-manager.io",
+				}))
+			})
+		})
+	})
+})
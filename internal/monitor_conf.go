@@ -232,7 +232,9 @@ func GetMonitorProcessConfiguration(cluster *fdbv1beta2.FoundationDBCluster, pro
 
 	podSettings := cluster.GetProcessSettings(processClass)
 	var hasDCIDLocality, hasDataHallLocality bool
+	customParameterNames := make(map[string]fdbv1beta2.None, len(podSettings.CustomParameters))
 	for _, argument := range podSettings.CustomParameters {
+		customParameterNames[strings.TrimSpace(strings.Split(string(argument), "=")[0])] = fdbv1beta2.None{}
 		if strings.HasPrefix(string(argument), "locality_"+fdbv1beta2.FDBLocalityDCIDKey) {
 			hasDCIDLocality = true
 		}
@@ -247,6 +249,40 @@ func GetMonitorProcessConfiguration(cluster *fdbv1beta2.FoundationDBCluster, pro
 		})
 	}
 
+	// Add the knobs for the enabled feature flags, the validation ensures that those knobs are not defined in the
+	// custom parameters.
+	for _, knob := range cluster.GetFeatureFlagKnobs(fdbv1beta2.Version{Version: version}) {
+		configuration.Arguments = append(configuration.Arguments, monitorapi.Argument{
+			ArgumentType: monitorapi.ConcatenateArgumentType,
+			Values:       generateMonitorArgumentFromCustomParameter(knob),
+		})
+	}
+
+	// Add the memory knobs derived from the container resources, knobs that are defined in the custom parameters take
+	// precedence.
+	for _, knob := range cluster.GetDerivedMemoryKnobs(processClass, processCount) {
+		if _, ok := customParameterNames[strings.Split(string(knob), "=")[0]]; ok {
+			continue
+		}
+
+		configuration.Arguments = append(configuration.Arguments, monitorapi.Argument{
+			ArgumentType: monitorapi.ConcatenateArgumentType,
+			Values:       generateMonitorArgumentFromCustomParameter(knob),
+		})
+	}
+
+	// Add the knobs for the tuning profile, knobs that are defined in the custom parameters take precedence.
+	for _, knob := range cluster.GetTuningProfileKnobs(fdbv1beta2.Version{Version: version}) {
+		if _, ok := customParameterNames[strings.Split(string(knob), "=")[0]]; ok {
+			continue
+		}
+
+		configuration.Arguments = append(configuration.Arguments, monitorapi.Argument{
+			ArgumentType: monitorapi.ConcatenateArgumentType,
+			Values:       generateMonitorArgumentFromCustomParameter(knob),
+		})
+	}
+
 	if cluster.Spec.DataCenter != "" && !hasDCIDLocality {
 		configuration.Arguments = append(configuration.Arguments, monitorapi.Argument{Value: getKnobParameterWithValue(fdbv1beta2.FDBLocalityDCIDKey, cluster.Spec.DataCenter, true)})
 	}
@@ -265,6 +301,104 @@ func GetMonitorProcessConfiguration(cluster *fdbv1beta2.FoundationDBCluster, pro
 	return configuration
 }
 
+// GetReloadableKnobs returns the knobs of the monitor configuration of the provided process class that can be reloaded
+// without a restart by the fdbserver version of the cluster spec.
+func GetReloadableKnobs(cluster *fdbv1beta2.FoundationDBCluster, processClass fdbv1beta2.ProcessClass) map[string]string {
+	version, err := fdbv1beta2.ParseFdbVersion(cluster.Spec.Version)
+	if err != nil {
+		return nil
+	}
+
+	knobs := map[string]string{}
+	config := GetMonitorProcessConfiguration(cluster, processClass, cluster.GetDesiredServersPerPod(processClass), cluster.DesiredImageType())
+	for _, argument := range config.Arguments {
+		if argument.ArgumentType != monitorapi.ConcatenateArgumentType || len(argument.Values) != 2 {
+			continue
+		}
+
+		if argument.Values[0].ArgumentType != monitorapi.LiteralArgumentType || argument.Values[1].ArgumentType != monitorapi.LiteralArgumentType {
+			continue
+		}
+
+		knob := strings.TrimSuffix(strings.TrimPrefix(argument.Values[0].Value, "--"), "=")
+		if !fdbv1beta2.IsReloadableKnob(knob, version) {
+			continue
+		}
+
+		knobs[knob] = argument.Values[1].Value
+	}
+
+	return knobs
+}
+
+// GetReloadableKnobChanges compares the expected command line with the command line of a running process and returns
+// the knobs that must be reloaded to bring the process in sync with the expected command line. Knobs that were already
+// reloaded with the expected value, based on the reloaded knobs in the cluster status, are not returned. The returned
+// bool is false if the command lines differ in any argument that requires a restart, e.g. a knob that is not
+// reloadable or an argument that was removed.
+func GetReloadableKnobChanges(cluster *fdbv1beta2.FoundationDBCluster, expected string, actual string) (map[string]string, bool) {
+	version, err := fdbv1beta2.ParseFdbVersion(cluster.Spec.Version)
+	if err != nil {
+		return nil, false
+	}
+
+	expectedBinary, expectedArguments := parseCommandLineArguments(expected)
+	actualBinary, actualArguments := parseCommandLineArguments(actual)
+	if expectedBinary != actualBinary {
+		return nil, false
+	}
+
+	for name := range actualArguments {
+		if _, ok := expectedArguments[name]; !ok {
+			return nil, false
+		}
+	}
+
+	changes := map[string]string{}
+	for name, value := range expectedArguments {
+		reloadable := fdbv1beta2.IsReloadableKnob(name, version)
+		reloadedValue, reloaded := cluster.Status.ReloadedKnobs[name]
+
+		if actualValue, ok := actualArguments[name]; ok && actualValue == value {
+			// The knob could have been reloaded with a different value, e.g. if a knob change was reverted.
+			if reloadable && reloaded && reloadedValue != value {
+				changes[name] = value
+			}
+
+			continue
+		}
+
+		if !reloadable {
+			return nil, false
+		}
+
+		if reloaded && reloadedValue == value {
+			continue
+		}
+
+		changes[name] = value
+	}
+
+	return changes, true
+}
+
+// parseCommandLineArguments splits the command line of a fdbserver process into the binary and a map of the
+// arguments with their values.
+func parseCommandLineArguments(commandLine string) (string, map[string]string) {
+	fields := strings.Fields(commandLine)
+	if len(fields) == 0 {
+		return "", nil
+	}
+
+	arguments := make(map[string]string, len(fields)-1)
+	for _, field := range fields[1:] {
+		name, value, _ := strings.Cut(strings.TrimPrefix(field, "--"), "=")
+		arguments[name] = value
+	}
+
+	return fields[0], arguments
+}
+
 // Generate the monitor API configuration based on the provided custom parameter
 func generateMonitorArgumentFromCustomParameter(argument fdbv1beta2.FoundationDBCustomParameter) []monitorapi.Argument {
 	splitArgument := strings.Split(string(argument), "=")
@@ -31,6 +31,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/utils/pointer"
 )
 
@@ -171,6 +172,27 @@ var _ = Describe("monitor_conf", func() {
 					},
 				}))
 			})
+
+			When("running multiple log processes", func() {
+				It("adds a process ID argument and includes the process number in the data directory", func() {
+					config := GetMonitorProcessConfiguration(cluster, fdbv1beta2.ProcessClassLog, 2, fdbv1beta2.ImageTypeUnified)
+					Expect(config.Arguments).To(HaveLen(baseArgumentLength + 1))
+					Expect(config.Arguments[3]).To(Equal(monitorapi.Argument{Value: "--class=log"}))
+					Expect(config.Arguments[6]).To(Equal(monitorapi.Argument{
+						ArgumentType: monitorapi.ConcatenateArgumentType,
+						Values: []monitorapi.Argument{
+							{Value: "--datadir=/var/fdb/data/"},
+							{ArgumentType: monitorapi.ProcessNumberArgumentType},
+						},
+					}))
+					Expect(config.Arguments[7]).To(Equal(monitorapi.Argument{ArgumentType: monitorapi.ConcatenateArgumentType, Values: []monitorapi.Argument{
+						{Value: "--locality_process_id="},
+						{ArgumentType: monitorapi.EnvironmentArgumentType, Source: fdbv1beta2.EnvNameInstanceID},
+						{Value: "-"},
+						{ArgumentType: monitorapi.ProcessNumberArgumentType},
+					}}))
+				})
+			})
 		})
 
 		When("the public IP comes from the pod", func() {
@@ -461,6 +483,207 @@ var _ = Describe("monitor_conf", func() {
 				Expect(config.Arguments[10]).To(Equal(monitorapi.Argument{Value: "--locality_data_hall=dh01"}))
 			})
 		})
+
+		When("the spec has feature flags enabled", func() {
+			BeforeEach(func() {
+				cluster.Spec.FeatureFlags.EnableGrayFailureDetection = pointer.Bool(true)
+			})
+
+			When("the version supports the feature", func() {
+				BeforeEach(func() {
+					cluster.Spec.Version = fdbv1beta2.Versions.SupportsGrayFailureDetection.String()
+				})
+
+				It("adds the knob for the feature flag", func() {
+					config := GetMonitorProcessConfiguration(cluster, fdbv1beta2.ProcessClassStorage, 1, fdbv1beta2.ImageTypeUnified)
+					Expect(config.Arguments).To(HaveLen(baseArgumentLength + 1))
+					Expect(config.Arguments[10]).To(Equal(monitorapi.Argument{
+						ArgumentType: monitorapi.ConcatenateArgumentType,
+						Values: []monitorapi.Argument{
+							{
+								ArgumentType: monitorapi.LiteralArgumentType,
+								Value:        "--knob_enable_worker_health_monitor=",
+							},
+							{
+								ArgumentType: monitorapi.LiteralArgumentType,
+								Value:        "true",
+							},
+						}}))
+				})
+			})
+
+			When("the version doesn't support the feature", func() {
+				It("doesn't add the knob for the feature flag", func() {
+					config := GetMonitorProcessConfiguration(cluster, fdbv1beta2.ProcessClassStorage, 1, fdbv1beta2.ImageTypeUnified)
+					Expect(config.Arguments).To(HaveLen(baseArgumentLength))
+				})
+			})
+		})
+
+		When("the spec has a tuning profile", func() {
+			BeforeEach(func() {
+				cluster.Spec.Profile = fdbv1beta2.TuningProfileMixed
+			})
+
+			When("the version supports all knobs of the profile", func() {
+				BeforeEach(func() {
+					cluster.Spec.Version = "7.1.25"
+				})
+
+				It("adds the knobs of the profile", func() {
+					config := GetMonitorProcessConfiguration(cluster, fdbv1beta2.ProcessClassStorage, 1, fdbv1beta2.ImageTypeUnified)
+					Expect(config.Arguments).To(HaveLen(baseArgumentLength + 2))
+					Expect(config.Arguments[10]).To(Equal(monitorapi.Argument{
+						ArgumentType: monitorapi.ConcatenateArgumentType,
+						Values: []monitorapi.Argument{
+							{
+								ArgumentType: monitorapi.LiteralArgumentType,
+								Value:        "--knob_commit_transaction_batch_interval_max=",
+							},
+							{
+								ArgumentType: monitorapi.LiteralArgumentType,
+								Value:        "0.010",
+							},
+						}}))
+					Expect(config.Arguments[11]).To(Equal(monitorapi.Argument{
+						ArgumentType: monitorapi.ConcatenateArgumentType,
+						Values: []monitorapi.Argument{
+							{
+								ArgumentType: monitorapi.LiteralArgumentType,
+								Value:        "--knob_storage_fetch_keys_rate_limit=",
+							},
+							{
+								ArgumentType: monitorapi.LiteralArgumentType,
+								Value:        "50000000",
+							},
+						}}))
+				})
+
+				When("a knob of the profile is defined in the custom parameters", func() {
+					BeforeEach(func() {
+						cluster.Spec.Processes = map[fdbv1beta2.ProcessClass]fdbv1beta2.ProcessSettings{
+							fdbv1beta2.ProcessClassGeneral: {
+								CustomParameters: fdbv1beta2.FoundationDBCustomParameters{
+									"knob_commit_transaction_batch_interval_max=0.001",
+								},
+							},
+						}
+					})
+
+					It("only adds the custom parameter", func() {
+						config := GetMonitorProcessConfiguration(cluster, fdbv1beta2.ProcessClassStorage, 1, fdbv1beta2.ImageTypeUnified)
+						Expect(config.Arguments).To(HaveLen(baseArgumentLength + 2))
+						Expect(config.Arguments[10].Values[1].Value).To(Equal("0.001"))
+						Expect(config.Arguments[11].Values[0].Value).To(Equal("--knob_storage_fetch_keys_rate_limit="))
+					})
+				})
+			})
+
+			When("the version doesn't support all knobs of the profile", func() {
+				BeforeEach(func() {
+					cluster.Spec.Version = "6.3.24"
+				})
+
+				It("only adds the supported knobs", func() {
+					config := GetMonitorProcessConfiguration(cluster, fdbv1beta2.ProcessClassStorage, 1, fdbv1beta2.ImageTypeUnified)
+					Expect(config.Arguments).To(HaveLen(baseArgumentLength + 1))
+					Expect(config.Arguments[10].Values[0].Value).To(Equal("--knob_commit_transaction_batch_interval_max="))
+				})
+			})
+		})
+		When("the derivation of the memory knobs is enabled", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.DeriveMemoryKnobs = pointer.Bool(true)
+				cluster.Spec.Processes = map[fdbv1beta2.ProcessClass]fdbv1beta2.ProcessSettings{
+					fdbv1beta2.ProcessClassGeneral: {
+						PodTemplate: &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name: fdbv1beta2.MainContainerName,
+										Resources: corev1.ResourceRequirements{
+											Requests: corev1.ResourceList{
+												corev1.ResourceMemory: resource.MustParse("4Gi"),
+											},
+											Limits: corev1.ResourceList{
+												corev1.ResourceMemory: resource.MustParse("8Gi"),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+			})
+
+			It("adds the memory knobs based on the memory limit", func() {
+				config := GetMonitorProcessConfiguration(cluster, fdbv1beta2.ProcessClassStorage, 1, fdbv1beta2.ImageTypeUnified)
+				Expect(config.Arguments).To(HaveLen(baseArgumentLength + 2))
+				Expect(config.Arguments[10]).To(Equal(monitorapi.Argument{
+					ArgumentType: monitorapi.ConcatenateArgumentType,
+					Values: []monitorapi.Argument{
+						{
+							ArgumentType: monitorapi.LiteralArgumentType,
+							Value:        "--memory=",
+						},
+						{
+							ArgumentType: monitorapi.LiteralArgumentType,
+							Value:        "7462505677",
+						},
+					}}))
+				Expect(config.Arguments[11]).To(Equal(monitorapi.Argument{
+					ArgumentType: monitorapi.ConcatenateArgumentType,
+					Values: []monitorapi.Argument{
+						{
+							ArgumentType: monitorapi.LiteralArgumentType,
+							Value:        "--cache_memory=",
+						},
+						{
+							ArgumentType: monitorapi.LiteralArgumentType,
+							Value:        "1865626419",
+						},
+					}}))
+			})
+
+			When("multiple processes are running in the Pod", func() {
+				It("splits the memory between the processes", func() {
+					config := GetMonitorProcessConfiguration(cluster, fdbv1beta2.ProcessClassStorage, 2, fdbv1beta2.ImageTypeUnified)
+					// The process ID locality is added for multiple processes, so the memory knobs are moved by one.
+					Expect(config.Arguments).To(HaveLen(baseArgumentLength + 3))
+					Expect(config.Arguments[11].Values[0].Value).To(Equal("--memory="))
+					Expect(config.Arguments[11].Values[1].Value).To(Equal("3731252838"))
+					Expect(config.Arguments[12].Values[0].Value).To(Equal("--cache_memory="))
+					Expect(config.Arguments[12].Values[1].Value).To(Equal("932813209"))
+				})
+			})
+
+			When("the memory is defined in the custom parameters", func() {
+				BeforeEach(func() {
+					settings := cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral]
+					settings.CustomParameters = fdbv1beta2.FoundationDBCustomParameters{"memory=6GiB"}
+					cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral] = settings
+				})
+
+				It("only adds the derived cache memory", func() {
+					config := GetMonitorProcessConfiguration(cluster, fdbv1beta2.ProcessClassStorage, 1, fdbv1beta2.ImageTypeUnified)
+					Expect(config.Arguments).To(HaveLen(baseArgumentLength + 2))
+					Expect(config.Arguments[10].Values[1].Value).To(Equal("6GiB"))
+					Expect(config.Arguments[11].Values[0].Value).To(Equal("--cache_memory="))
+				})
+			})
+
+			When("the main container has no memory resources", func() {
+				BeforeEach(func() {
+					cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral].PodTemplate.Spec.Containers[0].Resources = corev1.ResourceRequirements{}
+				})
+
+				It("doesn't add the memory knobs", func() {
+					config := GetMonitorProcessConfiguration(cluster, fdbv1beta2.ProcessClassStorage, 1, fdbv1beta2.ImageTypeUnified)
+					Expect(config.Arguments).To(HaveLen(baseArgumentLength))
+				})
+			})
+		})
 	})
 
 	Describe("GetStartCommand", func() {
@@ -536,6 +759,35 @@ var _ = Describe("monitor_conf", func() {
 				})
 			})
 
+			When("feature flags are enabled", func() {
+				BeforeEach(func() {
+					cluster.Spec.Version = fdbv1beta2.Versions.SupportsGrayFailureDetection.String()
+					cluster.Spec.FeatureFlags.EnableGrayFailureDetection = pointer.Bool(true)
+				})
+
+				It("should add the knobs of the feature flags to the start command", func() {
+					substitutions, err := GetSubstitutionsFromClusterAndPod(logr.Discard(), cluster, pod)
+					Expect(err).NotTo(HaveOccurred())
+					command, err = GetStartCommandWithSubstitutions(cluster, processClass, substitutions, 1, 1, cluster.DesiredImageType())
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(command).To(Equal(strings.Join([]string{
+						"/usr/bin/fdbserver",
+						"--class=storage",
+						"--cluster_file=/var/fdb/data/fdb.cluster",
+						"--datadir=/var/fdb/data",
+						"--knob_enable_worker_health_monitor=true",
+						fmt.Sprintf("--locality_instance_id=%s", processGroupID),
+						fmt.Sprintf("--locality_machineid=%s-%s", cluster.Name, processGroupID),
+						fmt.Sprintf("--locality_zoneid=%s-%s", cluster.Name, processGroupID),
+						"--logdir=/var/log/fdb-trace-logs",
+						"--loggroup=" + cluster.Name,
+						fmt.Sprintf("--public_address=%s:4501", address),
+						"--seed_cluster_file=/var/dynamic-conf/fdb.cluster",
+					}, " ")))
+				})
+			})
+
 			When("multiple storage servers per Pod are defined", func() {
 				It("should substitute the variables in the start command", func() {
 					substitutions, err := GetSubstitutionsFromClusterAndPod(logr.Discard(), cluster, pod)
@@ -1354,4 +1606,106 @@ var _ = Describe("monitor_conf", func() {
 		})
 	})
 
+	Describe("GetReloadableKnobChanges", func() {
+		var baseCommandLine = "/usr/bin/fdbserver --class=storage --knob_disable_posix_kernel_aio=1"
+
+		BeforeEach(func() {
+			cluster.Spec.Version = "7.1.25"
+		})
+
+		DescribeTable("comparing the command lines",
+			func(expected string, actual string, reloadedKnobs map[string]string, expectedChanges map[string]string, expectedReloadable bool) {
+				cluster.Status.ReloadedKnobs = reloadedKnobs
+				changes, reloadable := GetReloadableKnobChanges(cluster, expected, actual)
+				Expect(reloadable).To(Equal(expectedReloadable))
+				Expect(changes).To(Equal(expectedChanges))
+			},
+			Entry("identical command lines",
+				baseCommandLine+" --knob_min_trace_severity=20",
+				baseCommandLine+" --knob_min_trace_severity=20",
+				nil,
+				map[string]string{},
+				true,
+			),
+			Entry("a changed reloadable knob",
+				baseCommandLine+" --knob_min_trace_severity=20",
+				baseCommandLine+" --knob_min_trace_severity=10",
+				nil,
+				map[string]string{"knob_min_trace_severity": "20"},
+				true,
+			),
+			Entry("an added reloadable knob",
+				baseCommandLine+" --knob_min_trace_severity=20",
+				baseCommandLine,
+				nil,
+				map[string]string{"knob_min_trace_severity": "20"},
+				true,
+			),
+			Entry("a changed reloadable knob that was already reloaded",
+				baseCommandLine+" --knob_min_trace_severity=20",
+				baseCommandLine+" --knob_min_trace_severity=10",
+				map[string]string{"knob_min_trace_severity": "20"},
+				map[string]string{},
+				true,
+			),
+			Entry("a reverted reloadable knob",
+				baseCommandLine+" --knob_min_trace_severity=10",
+				baseCommandLine+" --knob_min_trace_severity=10",
+				map[string]string{"knob_min_trace_severity": "20"},
+				map[string]string{"knob_min_trace_severity": "10"},
+				true,
+			),
+			Entry("a removed reloadable knob",
+				baseCommandLine,
+				baseCommandLine+" --knob_min_trace_severity=10",
+				nil,
+				nil,
+				false,
+			),
+			Entry("a changed knob that requires a restart",
+				"/usr/bin/fdbserver --class=storage --knob_disable_posix_kernel_aio=0 --knob_min_trace_severity=20",
+				baseCommandLine+" --knob_min_trace_severity=10",
+				nil,
+				nil,
+				false,
+			),
+			Entry("a different binary",
+				"/usr/bin/fdbserver-7.1 --class=storage --knob_disable_posix_kernel_aio=1",
+				baseCommandLine,
+				nil,
+				nil,
+				false,
+			),
+		)
+
+		When("the version doesn't support reloading the knob", func() {
+			BeforeEach(func() {
+				cluster.Spec.Version = "6.3.24"
+			})
+
+			It("requires a restart", func() {
+				changes, reloadable := GetReloadableKnobChanges(cluster, baseCommandLine+" --knob_min_trace_severity=20", baseCommandLine+" --knob_min_trace_severity=10")
+				Expect(reloadable).To(BeFalse())
+				Expect(changes).To(BeNil())
+			})
+		})
+	})
+
+	Describe("GetReloadableKnobs", func() {
+		BeforeEach(func() {
+			cluster.Spec.Version = "7.1.25"
+			cluster.Spec.Processes = map[fdbv1beta2.ProcessClass]fdbv1beta2.ProcessSettings{
+				fdbv1beta2.ProcessClassGeneral: {
+					CustomParameters: fdbv1beta2.FoundationDBCustomParameters{
+						"knob_min_trace_severity=20",
+						"knob_disable_posix_kernel_aio=1",
+					},
+				},
+			}
+		})
+
+		It("only returns the reloadable knobs", func() {
+			Expect(GetReloadableKnobs(cluster, fdbv1beta2.ProcessClassStorage)).To(Equal(map[string]string{"knob_min_trace_severity": "20"}))
+		})
+	})
 })
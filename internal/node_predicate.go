@@ -75,3 +75,52 @@ func (n NodeTaintChangedPredicate) Update(event event.UpdateEvent) bool {
 func (n NodeTaintChangedPredicate) Generic(_ event.GenericEvent) bool {
 	return false
 }
+
+var _ predicate.Predicate = (*NodeDrainChangedPredicate)(nil)
+
+// NodeDrainChangedPredicate filters events before enqueuing the keys. Only if the node was cordoned or uncordoned or
+// the node annotations have changed a reconciliation will be triggered.
+type NodeDrainChangedPredicate struct {
+	Logger logr.Logger
+}
+
+// Create implements Predicate.
+func (n NodeDrainChangedPredicate) Create(_ event.CreateEvent) bool {
+	return false
+}
+
+// Delete implements Predicate.
+func (n NodeDrainChangedPredicate) Delete(_ event.DeleteEvent) bool {
+	return false
+}
+
+// Update returns true if the Update event should be processed. This is the case if the node was cordoned or
+// uncordoned or if the annotations of the node have changed, as those could signal a drain of the node.
+func (n NodeDrainChangedPredicate) Update(event event.UpdateEvent) bool {
+	if event.ObjectOld == nil || event.ObjectNew == nil {
+		return false
+	}
+
+	oldNode, ok := event.ObjectOld.(*corev1.Node)
+	if !ok {
+		return false
+	}
+
+	newNode, ok := event.ObjectNew.(*corev1.Node)
+	if !ok {
+		return false
+	}
+
+	unschedulableChanged := oldNode.Spec.Unschedulable != newNode.Spec.Unschedulable
+	annotationsChanged := !equality.Semantic.DeepEqual(oldNode.Annotations, newNode.Annotations)
+	if unschedulableChanged || annotationsChanged {
+		n.Logger.V(1).Info("Node drain signals have changed", "node", oldNode.Name, "unschedulable", newNode.Spec.Unschedulable, "annotationsChanged", annotationsChanged)
+	}
+
+	return unschedulableChanged || annotationsChanged
+}
+
+// Generic implements Predicate.
+func (n NodeDrainChangedPredicate) Generic(_ event.GenericEvent) bool {
+	return false
+}
@@ -0,0 +1,74 @@
+/*
+ * parallel_helper.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// RunParallel calls work for every index from 0 to count-1 with at most maxConcurrency concurrent calls. If
+// maxConcurrency is less than 2, the work is done serially in the order of the indexes. Once a call returned an error no
+// further calls will be started, the returned error contains the errors of all calls that were started. The work
+// function must only modify state that is specific to the provided index.
+func RunParallel(count int, maxConcurrency int, work func(idx int) error) error {
+	if maxConcurrency < 2 || count < 2 {
+		for idx := 0; idx < count; idx++ {
+			err := work(idx)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if maxConcurrency > count {
+		maxConcurrency = count
+	}
+
+	errs := make([]error, count)
+	indexes := make(chan int)
+	var failed atomic.Bool
+	var wg sync.WaitGroup
+
+	for worker := 0; worker < maxConcurrency; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range indexes {
+				errs[idx] = work(idx)
+				if errs[idx] != nil {
+					failed.Store(true)
+				}
+			}
+		}()
+	}
+
+	for idx := 0; idx < count && !failed.Load(); idx++ {
+		indexes <- idx
+	}
+	close(indexes)
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
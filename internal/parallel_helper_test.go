@@ -0,0 +1,98 @@
+/*
+ * parallel_helper_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("parallel_helper", func() {
+	When("running the work serially", func() {
+		It("should call the work in order and stop at the first error", func() {
+			var calls []int
+			err := RunParallel(5, 1, func(idx int) error {
+				calls = append(calls, idx)
+				if idx == 2 {
+					return errors.New("failed")
+				}
+
+				return nil
+			})
+
+			Expect(err).To(MatchError("failed"))
+			Expect(calls).To(Equal([]int{0, 1, 2}))
+		})
+	})
+
+	When("running the work concurrently", func() {
+		It("should call the work for every index", func() {
+			results := make([]int, 20)
+			err := RunParallel(len(results), 4, func(idx int) error {
+				results[idx] = idx * 2
+				return nil
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			for idx, result := range results {
+				Expect(result).To(Equal(idx * 2))
+			}
+		})
+
+		It("should not exceed the maximum concurrency", func() {
+			var running, maxRunning atomic.Int32
+			err := RunParallel(20, 3, func(_ int) error {
+				current := running.Add(1)
+				defer running.Add(-1)
+
+				for {
+					observed := maxRunning.Load()
+					if current <= observed || maxRunning.CompareAndSwap(observed, current) {
+						break
+					}
+				}
+
+				time.Sleep(5 * time.Millisecond)
+				return nil
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(maxRunning.Load()).To(BeNumerically("<=", 3))
+			Expect(maxRunning.Load()).To(BeNumerically(">", 1))
+		})
+
+		It("should return the errors of the work", func() {
+			err := RunParallel(4, 4, func(idx int) error {
+				if idx == 1 {
+					return errors.New("failed")
+				}
+
+				return nil
+			})
+
+			Expect(err).To(MatchError(ContainSubstring("failed")))
+		})
+	})
+})
@@ -107,7 +107,10 @@ func NewFdbPodClient(cluster *fdbv1beta2.FoundationDBCluster, pod *corev1.Pod, l
 
 	useTLS := podHasSidecarTLS(pod)
 
-	var tlsConfig = &tls.Config{}
+	var tlsConfig = &tls.Config{
+		MinVersion:   cluster.GetMinimumTLSVersion().GetVersionID(),
+		CipherSuites: cluster.GetTLSCipherSuiteIDs(),
+	}
 	if useTLS {
 		certFile := os.Getenv(fdbv1beta2.EnvNameTLSCert)
 		keyFile := os.Getenv(fdbv1beta2.EnvNameTLSKeyFile)
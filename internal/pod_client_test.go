@@ -21,6 +21,7 @@
 package internal
 
 import (
+	"crypto/tls"
 	"net/http"
 	"net/url"
 	"time"
@@ -64,6 +65,33 @@ var _ = Describe("pod_client", func() {
 		})
 	})
 
+	When("TLS options are defined", func() {
+		var podClient *realFdbPodSidecarClient
+
+		BeforeEach(func() {
+			cluster.Spec.SidecarContainer.EnableTLS = false
+			minimumVersion := fdbv1beta2.TLSVersion13
+			cluster.Spec.TLSOptions = fdbv1beta2.TLSOptions{
+				MinimumVersion: &minimumVersion,
+				CipherSuites:   []string{"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"},
+			}
+
+			pod, err := GetPod(cluster, GetProcessGroup(cluster, fdbv1beta2.ProcessClassStorage, 1))
+			Expect(err).NotTo(HaveOccurred())
+			pod.Status.PodIP = "1.1.1.1"
+
+			client, err := NewFdbPodClient(cluster, pod, GinkgoLogr, time.Second, time.Second)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(client).To(BeAssignableToTypeOf(&realFdbPodSidecarClient{}))
+			podClient = client.(*realFdbPodSidecarClient)
+		})
+
+		It("should apply the TLS options to the TLS config", func() {
+			Expect(podClient.tlsConfig.MinVersion).To(Equal(uint16(tls.VersionTLS13)))
+			Expect(podClient.tlsConfig.CipherSuites).To(ConsistOf(tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384))
+		})
+	})
+
 	When("generating a request", func() {
 		var retryClient *retryablehttp.Client
 		var target url.URL
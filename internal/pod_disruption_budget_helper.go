@@ -0,0 +1,84 @@
+/*
+ * pod_disruption_budget_helper.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	"fmt"
+	"sort"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// GetPodDisruptionBudgetName returns the name of the PodDisruptionBudget for the provided process class.
+func GetPodDisruptionBudgetName(cluster *fdbv1beta2.FoundationDBCluster, processClass fdbv1beta2.ProcessClass) string {
+	return fmt.Sprintf("%s-%s", cluster.Name, processClass.GetProcessClassForPodName())
+}
+
+// GetPodDisruptionBudgets builds the PodDisruptionBudgets for all process classes of the cluster with a desired
+// process count. The minAvailable of every PodDisruptionBudget is the desired process count minus the allowed
+// unavailable Pods for the process class.
+func GetPodDisruptionBudgets(cluster *fdbv1beta2.FoundationDBCluster) ([]*policyv1.PodDisruptionBudget, error) {
+	if !cluster.ManagePodDisruptionBudgets() {
+		return nil, nil
+	}
+
+	counts, err := cluster.GetProcessCountsWithDefaults()
+	if err != nil {
+		return nil, err
+	}
+
+	pdbs := make([]*policyv1.PodDisruptionBudget, 0)
+	for processClass, count := range counts.Map() {
+		if count <= 0 {
+			continue
+		}
+
+		minAvailable := count - cluster.GetPodDisruptionBudgetMaxUnavailable(processClass)
+		if minAvailable < 0 {
+			minAvailable = 0
+		}
+
+		pdb := &policyv1.PodDisruptionBudget{
+			ObjectMeta: GetObjectMetadata(cluster, nil, processClass, ""),
+		}
+		pdb.ObjectMeta.Name = GetPodDisruptionBudgetName(cluster, processClass)
+		pdb.ObjectMeta.OwnerReferences = BuildOwnerReference(cluster.TypeMeta, cluster.ObjectMeta)
+		addPropagatedMetadata(cluster, &pdb.ObjectMeta)
+
+		minAvailableValue := intstr.FromInt(minAvailable)
+		pdb.Spec.MinAvailable = &minAvailableValue
+		pdb.Spec.Selector = &metav1.LabelSelector{
+			MatchLabels: GetPodMatchLabels(cluster, processClass, ""),
+		}
+
+		pdbs = append(pdbs, pdb)
+	}
+
+	// Sort the PodDisruptionBudgets by name to have a stable order.
+	sort.Slice(pdbs, func(i, j int) bool {
+		return pdbs[i].Name < pdbs[j].Name
+	})
+
+	return pdbs, nil
+}
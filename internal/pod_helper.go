@@ -21,12 +21,14 @@
 package internal
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"net"
 	"strconv"
+	"strings"
 
 	"github.com/go-logr/logr"
 
@@ -34,6 +36,8 @@ import (
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -98,17 +102,157 @@ func GetProcessGroupIDFromMeta(cluster *fdbv1beta2.FoundationDBCluster, metadata
 	return fdbv1beta2.ProcessGroupID(metadata.Labels[cluster.GetProcessGroupIDLabel()])
 }
 
-// GetPodSpecHash builds the hash of the expected spec for a pod.
+// GetPodSpecHash builds the hash of the expected spec for a pod with the current hash version.
 func GetPodSpecHash(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus, spec *corev1.PodSpec) (string, error) {
-	var err error
-	if spec == nil {
-		spec, err = GetPodSpec(cluster, processGroup)
-		if err != nil {
-			return "", err
+	return getPodSpecHash(cluster, processGroup, spec, currentPodSpecHashVersion)
+}
+
+// GetMatchingPodSpecHash builds the hash of the expected spec for a pod with the hash version of the provided current
+// hash. This allows to compare the hash of a Pod that was created by an older operator version, without updating the
+// Pod only because the hash computation has changed.
+func GetMatchingPodSpecHash(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus, spec *corev1.PodSpec, currentHash string) (string, error) {
+	return getPodSpecHash(cluster, processGroup, spec, GetPodSpecHashVersion(currentHash))
+}
+
+// GetImageRegistryChanges returns the desired images per container name, if the only difference between the desired
+// Pod spec and the current Pod is the registry of the container images. If any other part of the Pod spec has changed,
+// nil will be returned.
+func GetImageRegistryChanges(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus, pod *corev1.Pod) (map[string]string, error) {
+	spec, err := GetPodSpec(cluster, processGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	currentImages := make(map[string]string, len(pod.Spec.InitContainers)+len(pod.Spec.Containers))
+	for _, container := range pod.Spec.InitContainers {
+		currentImages[container.Name] = container.Image
+	}
+
+	for _, container := range pod.Spec.Containers {
+		currentImages[container.Name] = container.Image
+	}
+
+	changes := map[string]string{}
+	for _, containers := range [][]corev1.Container{spec.InitContainers, spec.Containers} {
+		for idx := range containers {
+			container := &containers[idx]
+			currentImage, ok := currentImages[container.Name]
+			if !ok || currentImage == container.Image {
+				continue
+			}
+
+			if trimImageRegistry(currentImage) != trimImageRegistry(container.Image) {
+				return nil, nil
+			}
+
+			// Use the current image to verify that the rest of the Pod spec is unchanged.
+			changes[container.Name] = container.Image
+			container.Image = currentImage
+		}
+	}
+
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	specHash, err := GetMatchingPodSpecHash(cluster, processGroup, spec, pod.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey])
+	if err != nil {
+		return nil, err
+	}
+
+	if pod.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey] != specHash {
+		return nil, nil
+	}
+
+	return changes, nil
+}
+
+// GetResourceChanges returns the desired resources per container name if only the CPU or memory resources of the
+// containers have changed. If any other part of the Pod spec has changed, no changes will be returned.
+func GetResourceChanges(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus, pod *corev1.Pod) (map[string]corev1.ResourceRequirements, error) {
+	spec, err := GetPodSpec(cluster, processGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	currentResources := make(map[string]corev1.ResourceRequirements, len(pod.Spec.Containers))
+	for _, container := range pod.Spec.Containers {
+		currentResources[container.Name] = container.Resources
+	}
+
+	changes := map[string]corev1.ResourceRequirements{}
+	for idx := range spec.Containers {
+		container := &spec.Containers[idx]
+		current, ok := currentResources[container.Name]
+		if !ok || equality.Semantic.DeepEqual(current, container.Resources) {
+			continue
 		}
+
+		if !onlyCPUAndMemoryChanged(current.Requests, container.Resources.Requests) ||
+			!onlyCPUAndMemoryChanged(current.Limits, container.Resources.Limits) ||
+			!equality.Semantic.DeepEqual(current.Claims, container.Resources.Claims) {
+			return nil, nil
+		}
+
+		// Use the current resources to verify that the rest of the Pod spec is unchanged.
+		changes[container.Name] = container.Resources
+		container.Resources = current
+	}
+
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	specHash, err := GetMatchingPodSpecHash(cluster, processGroup, spec, pod.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey])
+	if err != nil {
+		return nil, err
+	}
+
+	if pod.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey] != specHash {
+		return nil, nil
+	}
+
+	return changes, nil
+}
+
+// onlyCPUAndMemoryChanged returns true if the resource lists only differ in the quantities of the CPU and memory
+// resources. Adding or removing a resource can change the QoS class of the Pod, which cannot be done in place.
+func onlyCPUAndMemoryChanged(current corev1.ResourceList, desired corev1.ResourceList) bool {
+	if len(current) != len(desired) {
+		return false
+	}
+
+	for name, quantity := range desired {
+		currentQuantity, ok := current[name]
+		if !ok {
+			return false
+		}
+
+		if name == corev1.ResourceCPU || name == corev1.ResourceMemory {
+			continue
+		}
+
+		if currentQuantity.Cmp(quantity) != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// trimImageRegistry removes the registry host from the image reference, e.g. registry.example.com/foundationdb/foundationdb:7.1.26
+// will be trimmed to foundationdb/foundationdb:7.1.26.
+func trimImageRegistry(image string) string {
+	registry, remainder, found := strings.Cut(image, "/")
+	if !found {
+		return image
+	}
+
+	if strings.ContainsAny(registry, ".:") || registry == "localhost" {
+		return remainder
 	}
 
-	return GetJSONHash(spec)
+	return image
 }
 
 // GetJSONHash serializes an object to JSON and takes a hash of the resulting
@@ -198,7 +342,11 @@ func GetPvcMetadata(cluster *fdbv1beta2.FoundationDBCluster, processClass fdbv1b
 		customMetadata = nil
 	}
 
-	return GetObjectMetadata(cluster, customMetadata, processClass, id)
+	metadata := GetObjectMetadata(cluster, customMetadata, processClass, id)
+	addPropagatedMetadata(cluster, &metadata)
+	addClaimAnnotation(cluster, &metadata)
+
+	return metadata
 }
 
 // GetSidecarImage returns the expected sidecar image for a specific process class
@@ -236,3 +384,19 @@ func GetPublicIPSource(pod *corev1.Pod) (fdbv1beta2.PublicIPSource, error) {
 	}
 	return fdbv1beta2.PublicIPSource(source), nil
 }
+
+// StorageClassAllowsVolumeExpansion returns true if the storage class of the PVC allows volume expansion.
+func StorageClassAllowsVolumeExpansion(ctx context.Context, kubeClient client.Reader, pvc *corev1.PersistentVolumeClaim) (bool, error) {
+	storageClassName := pointer.StringDeref(pvc.Spec.StorageClassName, "")
+	if storageClassName == "" {
+		return false, nil
+	}
+
+	storageClass := &storagev1.StorageClass{}
+	err := kubeClient.Get(ctx, client.ObjectKey{Name: storageClassName}, storageClass)
+	if err != nil {
+		return false, err
+	}
+
+	return pointer.BoolDeref(storageClass.AllowVolumeExpansion, false), nil
+}
@@ -34,8 +34,29 @@ import (
 	"k8s.io/utils/pointer"
 )
 
-// GetProcessGroupIDFromPodName returns the process group ID for a given Pod name.
-func GetProcessGroupIDFromPodName(cluster *fdbv1beta2.FoundationDBCluster, podName string) fdbv1beta2.ProcessGroupID {
+// CreateCustomPodNameMap creates a map with the custom Pod name of a process group as key and the process group ID as
+// value. Only process groups that have a Pod name stored in their status are part of the map.
+func CreateCustomPodNameMap(cluster *fdbv1beta2.FoundationDBCluster) map[string]fdbv1beta2.ProcessGroupID {
+	customPodNames := make(map[string]fdbv1beta2.ProcessGroupID)
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if processGroup.PodName == "" {
+			continue
+		}
+
+		customPodNames[processGroup.PodName] = processGroup.ProcessGroupID
+	}
+
+	return customPodNames
+}
+
+// GetProcessGroupIDFromPodName returns the process group ID for a given Pod name. Pod names with a custom Pod name
+// prefix can't be parsed, so those are looked up in the customPodNames map, which can be created with
+// CreateCustomPodNameMap.
+func GetProcessGroupIDFromPodName(cluster *fdbv1beta2.FoundationDBCluster, customPodNames map[string]fdbv1beta2.ProcessGroupID, podName string) fdbv1beta2.ProcessGroupID {
+	if processGroupID, ok := customPodNames[podName]; ok {
+		return processGroupID
+	}
+
 	tmpName := strings.ReplaceAll(podName, cluster.Name, "")[1:]
 
 	if cluster.Spec.ProcessGroupIDPrefix != "" {
@@ -77,6 +98,7 @@ func GetService(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta
 	metadata := GetObjectMetadata(cluster, nil, processGroup.ProcessClass, processGroup.ProcessGroupID)
 	metadata.Name = processGroup.GetPodName(cluster)
 	metadata.OwnerReferences = owner
+	addPropagatedMetadata(cluster, &metadata)
 
 	processesPerPod := 1
 	if processGroup.ProcessClass == fdbv1beta2.ProcessClassStorage {
@@ -116,10 +138,13 @@ func GetPod(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.Pr
 	metadata.Name = processGroup.GetPodName(cluster)
 	metadata.OwnerReferences = owner
 
-	return &corev1.Pod{
+	pod := &corev1.Pod{
 		ObjectMeta: metadata,
 		Spec:       *spec,
-	}, nil
+	}
+	SetPodSpecHashAnnotations(pod, specHash)
+
+	return pod, nil
 }
 
 // GetImage returns the image for container
@@ -239,6 +264,12 @@ func configureContainersForUnifiedImages(cluster *fdbv1beta2.FoundationDBCluster
 		corev1.VolumeMount{Name: "fdb-trace-logs", MountPath: "/var/log/fdb-trace-logs"},
 	)
 
+	// The entrypoint of the unified image must be specified explicitly if the process monitor is wrapped.
+	startCommand := cluster.GetProcessSettings(processGroup.ProcessClass).StartCommand
+	if startCommand != nil && len(startCommand.Wrapper) > 0 {
+		mainContainer.Command = append(append([]string{}, startCommand.Wrapper...), fdbKubernetesMonitorPath)
+	}
+
 	for _, crashObjs := range cluster.Spec.Buggify.CrashLoopContainers {
 		for _, pid := range crashObjs.Targets {
 			if pid == processGroup.ProcessGroupID || pid == "*" {
@@ -302,6 +333,26 @@ func setAffinityForFaultDomain(cluster *fdbv1beta2.FoundationDBCluster, podSpec
 	}
 }
 
+// RelaxPodAntiAffinity converts all required pod anti-affinity terms of the provided PodSpec into preferred terms. A Pod
+// with a volume that is bound to a node can only be scheduled on this node, a required pod anti-affinity could prevent
+// the Pod from ever being scheduled.
+func RelaxPodAntiAffinity(podSpec *corev1.PodSpec) {
+	if podSpec.Affinity == nil || podSpec.Affinity.PodAntiAffinity == nil {
+		return
+	}
+
+	antiAffinity := podSpec.Affinity.PodAntiAffinity
+	for _, term := range antiAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+		antiAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(antiAffinity.PreferredDuringSchedulingIgnoredDuringExecution,
+			corev1.WeightedPodAffinityTerm{
+				Weight:          100,
+				PodAffinityTerm: term,
+			})
+	}
+
+	antiAffinity.RequiredDuringSchedulingIgnoredDuringExecution = nil
+}
+
 func configureVolumesForContainers(cluster *fdbv1beta2.FoundationDBCluster, podSpec *corev1.PodSpec, volumeClaimTemplate *corev1.PersistentVolumeClaim, podName string, processClass fdbv1beta2.ProcessClass) {
 	useUnifiedImage := cluster.UseUnifiedImage()
 	monitorConfKey := GetConfigMapMonitorConfEntry(processClass, cluster.DesiredImageType(), cluster.GetDesiredServersPerPod(processClass))
@@ -364,6 +415,24 @@ func configureVolumesForContainers(cluster *fdbv1beta2.FoundationDBCluster, podS
 	podSpec.Volumes = append(podSpec.Volumes, volumes...)
 }
 
+// configureAdditionalVolumes adds the additional volumes of the process class to the Pod and mounts them into the
+// main container.
+func configureAdditionalVolumes(cluster *fdbv1beta2.FoundationDBCluster, podSpec *corev1.PodSpec, mainContainer *corev1.Container, podName string, processClass fdbv1beta2.ProcessClass) {
+	if !processClass.IsStateful() {
+		return
+	}
+
+	for _, volume := range getAdditionalVolumes(cluster, processClass) {
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: volume.name,
+			VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: getAdditionalPvcName(podName, volume.name),
+			}},
+		})
+		mainContainer.VolumeMounts = append(mainContainer.VolumeMounts, corev1.VolumeMount{Name: volume.name, MountPath: volume.mountPath})
+	}
+}
+
 func configureNoSchedule(podSpec *corev1.PodSpec, processGroupID fdbv1beta2.ProcessGroupID, noSchedules []fdbv1beta2.ProcessGroupID) {
 	for _, noSchedulePID := range noSchedules {
 		if processGroupID != noSchedulePID {
@@ -394,9 +463,46 @@ func configureNoSchedule(podSpec *corev1.PodSpec, processGroupID fdbv1beta2.Proc
 	}
 }
 
+// configureZoneAffinity adds a required node affinity for the zone that is encoded in the process group ID, if the
+// ZoneEncoded allocation strategy is used.
+func configureZoneAffinity(cluster *fdbv1beta2.FoundationDBCluster, podSpec *corev1.PodSpec, processGroupID fdbv1beta2.ProcessGroupID) {
+	zone, ok := cluster.GetZoneForProcessGroupID(processGroupID)
+	if !ok {
+		return
+	}
+
+	if podSpec.Affinity == nil {
+		podSpec.Affinity = &corev1.Affinity{}
+	}
+
+	if podSpec.Affinity.NodeAffinity == nil {
+		podSpec.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+
+	if podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{}
+	}
+
+	requirement := corev1.NodeSelectorRequirement{
+		Key:      cluster.GetProcessGroupIDZoneLabel(),
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   []string{zone},
+	}
+
+	nodeSelector := podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if len(nodeSelector.NodeSelectorTerms) == 0 {
+		nodeSelector.NodeSelectorTerms = []corev1.NodeSelectorTerm{{}}
+	}
+
+	// The node selector terms are ORed, so the requirement must be added to every term.
+	for idx := range nodeSelector.NodeSelectorTerms {
+		nodeSelector.NodeSelectorTerms[idx].MatchExpressions = append(nodeSelector.NodeSelectorTerms[idx].MatchExpressions, requirement)
+	}
+}
+
 // GetPodSpec builds a pod spec for a FoundationDB pod
 func GetPodSpec(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus) (*corev1.PodSpec, error) {
-	processSettings := cluster.GetProcessSettings(processGroup.ProcessClass)
+	processSettings := cluster.GetProcessSettingsForProcessGroup(processGroup)
 	podSpec := processSettings.PodTemplate.Spec.DeepCopy()
 	useUnifiedImage := cluster.UseUnifiedImage()
 
@@ -438,7 +544,8 @@ func GetPodSpec(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta
 	} else {
 		mainContainer.Command = []string{"sh", "-c"}
 
-		args := "fdbmonitor --conffile /var/dynamic-conf/fdbmonitor.conf" +
+		args := getStartCommandWrapperPrefix(processSettings.StartCommand) +
+			"fdbmonitor --conffile /var/dynamic-conf/fdbmonitor.conf" +
 			" --lockfile /var/dynamic-conf/fdbmonitor.lockfile" +
 			" --loggroup " + logGroup +
 			" >> /var/log/fdb-trace-logs/fdbmonitor-$(date '+%Y-%m-%d').log 2>&1"
@@ -479,12 +586,23 @@ func GetPodSpec(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta
 	ensureSecurityContextIsPresent(sidecarContainer)
 	setAffinityForFaultDomain(cluster, podSpec, processGroup.ProcessClass)
 	configureVolumesForContainers(cluster, podSpec, processSettings.VolumeClaimTemplate, podName, processGroup.ProcessClass)
+	configureAdditionalVolumes(cluster, podSpec, mainContainer, podName, processGroup.ProcessClass)
 	configureNoSchedule(podSpec, processGroup.ProcessGroupID, cluster.Spec.Buggify.NoSchedule)
+	configureZoneAffinity(cluster, podSpec, processGroup.ProcessGroupID)
+
+	if processSettings.RuntimeClassName != nil {
+		podSpec.RuntimeClassName = pointer.String(*processSettings.RuntimeClassName)
+	}
+
+	configureShutdown(podSpec, processSettings.Shutdown, sidecarContainer)
+	configureStartCommandEnv(processSettings.StartCommand, mainContainer)
 
 	if !useUnifiedImage {
 		replaceContainers(podSpec.InitContainers, initContainer)
 	}
 	replaceContainers(podSpec.Containers, mainContainer, sidecarContainer)
+	configureDiskQualification(cluster, podSpec, mainContainer, processGroup.ProcessClass)
+	configureKernelSettings(cluster, podSpec, processGroup.ProcessClass, mainContainer.Image)
 
 	headlessService := GetHeadlessService(cluster)
 
@@ -496,6 +614,232 @@ func GetPodSpec(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta
 	return podSpec, nil
 }
 
+// DiskQualificationFailedExitCode is the exit code of the disk qualification container if a volume performed below the
+// defined thresholds.
+const DiskQualificationFailedExitCode int32 = 3
+
+// diskQualificationScript runs a random read and write benchmark with fio on every directory passed as argument. The
+// benchmark is only run once for every newly provisioned volume, volumes that already contain data are skipped. The
+// IOPS and the completion latency are read from the terse output of fio, where field 8 and 49 contain the read and
+// write IOPS and field 16 and 57 contain the mean read and write completion latency in microseconds.
+const diskQualificationScript = `set -e
+for dir in "$@"; do
+  marker="${dir}/.fdb-disk-qualified"
+  if [ -f "${marker}" ]; then
+    continue
+  fi
+  if ls -A "${dir}" | grep -v -x -e "lost+found" | grep -q .; then
+    touch "${marker}"
+    continue
+  fi
+  result=$(fio --name=disk-qualification --directory="${dir}" --filename=.fdb-disk-qualification --size=256M --rw=randrw --bs=4k --direct=1 --ioengine=libaio --iodepth=32 --runtime="${FDB_DISK_QUALIFICATION_RUNTIME_SECONDS}" --time_based --output-format=terse --terse-version=3)
+  rm -f "${dir}/.fdb-disk-qualification"
+  iops=$(echo "${result}" | awk -F';' '{printf "%d", $8 + $49}')
+  latency=$(echo "${result}" | awk -F';' '{printf "%d", ($16 > $57) ? $16 : $57}')
+  echo "volume ${dir}: iops=${iops} latency=${latency}us"
+  if [ -n "${FDB_DISK_QUALIFICATION_MIN_IOPS}" ] && [ "${iops}" -lt "${FDB_DISK_QUALIFICATION_MIN_IOPS}" ]; then
+    echo "volume ${dir} is below the minimum of ${FDB_DISK_QUALIFICATION_MIN_IOPS} IOPS"
+    exit 3
+  fi
+  if [ -n "${FDB_DISK_QUALIFICATION_MAX_LATENCY_US}" ] && [ "${latency}" -gt "${FDB_DISK_QUALIFICATION_MAX_LATENCY_US}" ]; then
+    echo "volume ${dir} is above the maximum latency of ${FDB_DISK_QUALIFICATION_MAX_LATENCY_US}us"
+    exit 3
+  fi
+  touch "${marker}"
+done`
+
+// configureDiskQualification adds the init container that runs the disk benchmark on the volumes of the process group,
+// if the disk qualification is defined for the process class.
+func configureDiskQualification(cluster *fdbv1beta2.FoundationDBCluster, podSpec *corev1.PodSpec, mainContainer *corev1.Container, processClass fdbv1beta2.ProcessClass) {
+	if !processClass.IsStateful() {
+		return
+	}
+
+	settings := cluster.GetProcessSettings(processClass).DiskQualification
+	if settings == nil {
+		return
+	}
+
+	container := corev1.Container{Name: fdbv1beta2.DiskQualificationContainerName}
+	containerIndex := -1
+	for index, initContainer := range podSpec.InitContainers {
+		if initContainer.Name == fdbv1beta2.DiskQualificationContainerName {
+			container = initContainer
+			containerIndex = index
+			break
+		}
+	}
+
+	directories := []string{"/var/fdb/data"}
+	container.VolumeMounts = []corev1.VolumeMount{{Name: "data", MountPath: "/var/fdb/data"}}
+	for _, volume := range getAdditionalVolumes(cluster, processClass) {
+		directories = append(directories, volume.mountPath)
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{Name: volume.name, MountPath: volume.mountPath})
+	}
+
+	container.Image = settings.Image
+	container.Command = []string{"sh", "-c", diskQualificationScript, "disk-qualification"}
+	container.Args = directories
+	container.Env = []corev1.EnvVar{
+		{Name: "FDB_DISK_QUALIFICATION_RUNTIME_SECONDS", Value: strconv.Itoa(settings.GetRuntimeSeconds())},
+	}
+	if settings.MinIOPS != nil {
+		container.Env = append(container.Env, corev1.EnvVar{Name: "FDB_DISK_QUALIFICATION_MIN_IOPS", Value: strconv.Itoa(*settings.MinIOPS)})
+	}
+	if settings.MaxLatencyMicroseconds != nil {
+		container.Env = append(container.Env, corev1.EnvVar{Name: "FDB_DISK_QUALIFICATION_MAX_LATENCY_US", Value: strconv.Itoa(*settings.MaxLatencyMicroseconds)})
+	}
+
+	// The benchmark should write the files with the same user as the fdbserver processes.
+	if container.SecurityContext == nil && mainContainer.SecurityContext != nil {
+		container.SecurityContext = mainContainer.SecurityContext.DeepCopy()
+	}
+
+	if containerIndex >= 0 {
+		podSpec.InitContainers[containerIndex] = container
+		return
+	}
+
+	// The volumes must be qualified before any other container uses them.
+	podSpec.InitContainers = append([]corev1.Container{container}, podSpec.InitContainers...)
+}
+
+// disableTransparentHugePagesScript disables transparent huge pages on the node.
+const disableTransparentHugePagesScript = "echo never > /sys/kernel/mm/transparent_hugepage/enabled && echo never > /sys/kernel/mm/transparent_hugepage/defrag"
+
+// configureKernelSettings sets the sysctls for the Pod and adds the privileged init container to disable transparent
+// huge pages if requested in the kernel settings of the process class.
+func configureKernelSettings(cluster *fdbv1beta2.FoundationDBCluster, podSpec *corev1.PodSpec, processClass fdbv1beta2.ProcessClass, image string) {
+	sysctls := cluster.GetSysctls(processClass)
+	if len(sysctls) > 0 {
+		if podSpec.SecurityContext == nil {
+			podSpec.SecurityContext = &corev1.PodSecurityContext{}
+		}
+
+		// The sysctls from the kernel settings take precedence over the sysctls defined in the Pod template.
+		names := make(map[string]fdbv1beta2.None, len(sysctls))
+		for _, sysctl := range sysctls {
+			names[sysctl.Name] = fdbv1beta2.None{}
+		}
+
+		mergedSysctls := make([]corev1.Sysctl, 0, len(podSpec.SecurityContext.Sysctls)+len(sysctls))
+		for _, sysctl := range podSpec.SecurityContext.Sysctls {
+			if _, ok := names[sysctl.Name]; ok {
+				continue
+			}
+
+			mergedSysctls = append(mergedSysctls, sysctl)
+		}
+
+		podSpec.SecurityContext.Sysctls = append(mergedSysctls, sysctls...)
+	}
+
+	if !cluster.DisableTransparentHugePages(processClass) {
+		return
+	}
+
+	container := corev1.Container{Name: fdbv1beta2.KernelTuningContainerName}
+	containerIndex := -1
+	for index, initContainer := range podSpec.InitContainers {
+		if initContainer.Name == fdbv1beta2.KernelTuningContainerName {
+			container = initContainer
+			containerIndex = index
+			break
+		}
+	}
+
+	if container.Image == "" {
+		container.Image = image
+	}
+	container.Command = []string{"sh", "-c"}
+	container.Args = []string{disableTransparentHugePagesScript}
+	if container.SecurityContext == nil {
+		container.SecurityContext = &corev1.SecurityContext{}
+	}
+	// Writing to /sys requires root, the Pod security context might define a non-root user for the other containers.
+	container.SecurityContext.Privileged = pointer.Bool(true)
+	container.SecurityContext.RunAsUser = pointer.Int64(0)
+	container.SecurityContext.RunAsNonRoot = pointer.Bool(false)
+
+	if containerIndex >= 0 {
+		podSpec.InitContainers[containerIndex] = container
+		return
+	}
+
+	// The kernel settings must be applied before any other container is started.
+	podSpec.InitContainers = append([]corev1.Container{container}, podSpec.InitContainers...)
+}
+
+// configureShutdown sets the termination grace period and the preStop hook of the sidecar container based on the
+// shutdown settings of the process class.
+func configureShutdown(podSpec *corev1.PodSpec, shutdown *fdbv1beta2.ShutdownSettings, sidecarContainer *corev1.Container) {
+	if shutdown == nil {
+		return
+	}
+
+	if shutdown.TerminationGracePeriodSeconds != nil {
+		podSpec.TerminationGracePeriodSeconds = pointer.Int64(*shutdown.TerminationGracePeriodSeconds)
+	}
+
+	// Delaying the shutdown of the sidecar makes sure that the fdbserver processes receive SIGTERM and have time to
+	// write their last trace events before the sidecar is stopped.
+	if shutdown.SidecarShutdownDelaySeconds != nil && *shutdown.SidecarShutdownDelaySeconds > 0 {
+		sidecarContainer.Lifecycle = setPreStopCommand(sidecarContainer.Lifecycle, []string{"sleep", strconv.Itoa(*shutdown.SidecarShutdownDelaySeconds)})
+	}
+}
+
+// fdbKubernetesMonitorPath is the path of the fdb-kubernetes-monitor binary, the entrypoint of the unified image.
+const fdbKubernetesMonitorPath = "/usr/bin/fdb-kubernetes-monitor"
+
+// getStartCommandWrapperPrefix returns the shell quoted wrapper command, followed by a space, that wraps fdbmonitor in
+// the main container of the split image. If no wrapper is defined an empty string is returned.
+func getStartCommandWrapperPrefix(startCommand *fdbv1beta2.StartCommandSettings) string {
+	if startCommand == nil || len(startCommand.Wrapper) == 0 {
+		return ""
+	}
+
+	var prefix strings.Builder
+	for _, argument := range startCommand.Wrapper {
+		prefix.WriteString(shellQuote(argument))
+		prefix.WriteString(" ")
+	}
+
+	return prefix.String()
+}
+
+// shellQuote quotes the value, so that the shell passes it as a single argument without any expansion.
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'"'"'`) + "'"
+}
+
+// configureStartCommandEnv adds the environment variables of the start command settings to the main container. The
+// fdbserver processes inherit the environment of the process monitor. Environment variables that are already defined
+// in the main container take precedence.
+func configureStartCommandEnv(startCommand *fdbv1beta2.StartCommandSettings, mainContainer *corev1.Container) {
+	if startCommand == nil {
+		return
+	}
+
+	for _, env := range startCommand.Env {
+		extendEnv(mainContainer, corev1.EnvVar{Name: env.Name, Value: env.Value})
+	}
+}
+
+// setPreStopCommand sets the preStop hook of the provided lifecycle to execute the command.
+func setPreStopCommand(lifecycle *corev1.Lifecycle, command []string) *corev1.Lifecycle {
+	if lifecycle == nil {
+		lifecycle = &corev1.Lifecycle{}
+	}
+
+	lifecycle.PreStop = &corev1.LifecycleHandler{
+		Exec: &corev1.ExecAction{
+			Command: command,
+		},
+	}
+
+	return lifecycle
+}
+
 // configureSidecarContainerForCluster sets up a sidecar container for a sidecar
 // in the FDB cluster.
 func configureSidecarContainerForCluster(cluster *fdbv1beta2.FoundationDBCluster, podName string, container *corev1.Container, initMode bool, processGroupID fdbv1beta2.ProcessGroupID, fdbVersion string) error {
@@ -664,8 +1008,7 @@ func configureSidecarContainer(container *corev1.Container, initMode bool, proce
 func getEnvForMonitorConfigSubstitution(cluster *fdbv1beta2.FoundationDBCluster, processGroupID fdbv1beta2.ProcessGroupID) []corev1.EnvVar {
 	env := make([]corev1.EnvVar, 0)
 
-	publicIPSource := cluster.Spec.Routing.PublicIPSource
-	usePublicIPFromService := publicIPSource != nil && *publicIPSource == fdbv1beta2.PublicIPSourceService
+	usePublicIPFromService := getPublicIPSource(cluster, processGroupID) == fdbv1beta2.PublicIPSourceService
 
 	var publicIPKey string
 	if usePublicIPFromService {
@@ -739,7 +1082,7 @@ func GetPvc(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.Pr
 		return nil, nil
 	}
 
-	processSettings := cluster.GetProcessSettings(processGroup.ProcessClass)
+	processSettings := cluster.GetProcessSettingsForProcessGroup(processGroup)
 	var pvc *corev1.PersistentVolumeClaim
 	if processSettings.VolumeClaimTemplate != nil {
 		pvc = processSettings.VolumeClaimTemplate.DeepCopy()
@@ -755,6 +1098,109 @@ func GetPvc(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.Pr
 		pvc.ObjectMeta.Name = fmt.Sprintf("%s-%s", name, pvc.ObjectMeta.Name)
 	}
 
+	err := setPvcDefaults(pvc)
+	if err != nil {
+		return nil, err
+	}
+
+	return pvc, nil
+}
+
+// GetStorageClassName returns the storage class name of the volume claim template of the provided process group. If
+// no storage class is defined, an empty string will be returned and the default storage class will be used.
+func GetStorageClassName(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus) string {
+	processSettings := cluster.GetProcessSettingsForProcessGroup(processGroup)
+	if processSettings.VolumeClaimTemplate == nil {
+		return ""
+	}
+
+	return pointer.StringDeref(processSettings.VolumeClaimTemplate.Spec.StorageClassName, "")
+}
+
+// StorageClassChanged returns true if the PVC is using a different storage class than the one defined for the
+// provided process group. If no storage class is defined, the storage class of the PVC is not checked, as the default
+// storage class is assigned by Kubernetes.
+func StorageClassChanged(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus, pvc *corev1.PersistentVolumeClaim) bool {
+	desiredStorageClassName := GetStorageClassName(cluster, processGroup)
+	if desiredStorageClassName == "" {
+		return false
+	}
+
+	return pointer.StringDeref(pvc.Spec.StorageClassName, "") != desiredStorageClassName
+}
+
+// GetPVCStorageExpansion returns the desired storage request of the PVC, if the only change of the PVC spec is an
+// increased storage request. Otherwise, nil will be returned.
+func GetPVCStorageExpansion(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus, pvc *corev1.PersistentVolumeClaim) (*resource.Quantity, error) {
+	desiredPVC, err := GetPvc(cluster, processGroup)
+	if err != nil || desiredPVC == nil {
+		return nil, err
+	}
+
+	desiredSize, ok := desiredPVC.Spec.Resources.Requests[corev1.ResourceStorage]
+	if !ok {
+		return nil, nil
+	}
+
+	currentSize, ok := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	if !ok || desiredSize.Cmp(currentSize) <= 0 {
+		return nil, nil
+	}
+
+	// Use the current storage request to verify that the rest of the PVC spec is unchanged.
+	desiredPVC.Spec.Resources.Requests[corev1.ResourceStorage] = currentSize
+	specHash, err := GetJSONHash(desiredPVC.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	if pvc.Annotations[fdbv1beta2.LastSpecKey] != specHash {
+		return nil, nil
+	}
+
+	return &desiredSize, nil
+}
+
+// GetAdditionalPvcs builds the additional persistent volume claims for a FoundationDB process group.
+func GetAdditionalPvcs(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus) ([]*corev1.PersistentVolumeClaim, error) {
+	if !processGroup.ProcessClass.IsStateful() {
+		return nil, nil
+	}
+
+	volumes := getAdditionalVolumes(cluster, processGroup.ProcessClass)
+	if len(volumes) == 0 {
+		return nil, nil
+	}
+
+	podName := processGroup.GetPodName(cluster)
+	pvcs := make([]*corev1.PersistentVolumeClaim, 0, len(volumes))
+	for _, volume := range volumes {
+		var pvc *corev1.PersistentVolumeClaim
+		if volume.volumeClaimTemplate != nil {
+			pvc = volume.volumeClaimTemplate.DeepCopy()
+		} else {
+			pvc = &corev1.PersistentVolumeClaim{}
+		}
+
+		pvc.ObjectMeta = GetObjectMetadata(cluster, &pvc.ObjectMeta, processGroup.ProcessClass, processGroup.ProcessGroupID)
+		addPropagatedMetadata(cluster, &pvc.ObjectMeta)
+		pvc.ObjectMeta.Name = getAdditionalPvcName(podName, volume.name)
+		pvc.ObjectMeta.Labels[fdbv1beta2.FDBVolumeNameLabel] = volume.name
+
+		err := setPvcDefaults(pvc)
+		if err != nil {
+			return nil, err
+		}
+
+		pvcs = append(pvcs, pvc)
+	}
+
+	return pvcs, nil
+}
+
+// setPvcDefaults sets the default access mode and storage request, if they are not defined, and adds the hash of the
+// PVC spec as annotation.
+func setPvcDefaults(pvc *corev1.PersistentVolumeClaim) error {
 	if pvc.Spec.AccessModes == nil {
 		pvc.Spec.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
 	}
@@ -770,7 +1216,7 @@ func GetPvc(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.Pr
 
 	specHash, err := GetJSONHash(pvc.Spec)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	if pvc.ObjectMeta.Annotations == nil {
@@ -778,7 +1224,7 @@ func GetPvc(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.Pr
 	}
 	pvc.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey] = specHash
 
-	return pvc, nil
+	return nil
 }
 
 // replaceContainers overwrites the containers in a list with new containers
@@ -1037,12 +1483,36 @@ func GetPodMetadata(cluster *fdbv1beta2.FoundationDBCluster, processClass fdbv1b
 		metadata.Annotations = make(map[string]string)
 	}
 	metadata.Annotations[fdbv1beta2.LastSpecKey] = specHash
-	metadata.Annotations[fdbv1beta2.PublicIPSourceAnnotation] = string(cluster.GetPublicIPSource())
+	metadata.Annotations[fdbv1beta2.PublicIPSourceAnnotation] = string(getPublicIPSource(cluster, id))
 	metadata.Annotations[fdbv1beta2.ImageTypeAnnotation] = string(cluster.DesiredImageType())
+	addClaimAnnotation(cluster, &metadata)
 
 	return metadata
 }
 
+// addClaimAnnotation adds the ClusterClaimAnnotation to the metadata if the cluster should claim its resources.
+func addClaimAnnotation(cluster *fdbv1beta2.FoundationDBCluster, metadata *metav1.ObjectMeta) {
+	if !cluster.ShouldClaimResources() {
+		return
+	}
+
+	if metadata.Annotations == nil {
+		metadata.Annotations = make(map[string]string, 1)
+	}
+
+	metadata.Annotations[fdbv1beta2.ClusterClaimAnnotation] = cluster.Name
+}
+
+// getPublicIPSource returns the PublicIPSource for the process group, this will be PublicIPSourceService if the public
+// IP of the process group is pinned.
+func getPublicIPSource(cluster *fdbv1beta2.FoundationDBCluster, processGroupID fdbv1beta2.ProcessGroupID) fdbv1beta2.PublicIPSource {
+	if !cluster.PinCoordinatorIPs() {
+		return cluster.GetPublicIPSource()
+	}
+
+	return cluster.GetPublicIPSourceForProcessGroup(fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, processGroupID))
+}
+
 // GetObjectMetadata returns the ObjectMetadata for a process
 func GetObjectMetadata(cluster *fdbv1beta2.FoundationDBCluster, base *metav1.ObjectMeta, processClass fdbv1beta2.ProcessClass, id fdbv1beta2.ProcessGroupID) metav1.ObjectMeta {
 	var metadata *metav1.ObjectMeta
@@ -1069,6 +1539,34 @@ func GetObjectMetadata(cluster *fdbv1beta2.FoundationDBCluster, base *metav1.Obj
 	return *metadata
 }
 
+// addPropagatedMetadata adds the propagated labels and annotations of the cluster to the provided metadata. Labels and
+// annotations that are already present will not be overwritten.
+func addPropagatedMetadata(cluster *fdbv1beta2.FoundationDBCluster, metadata *metav1.ObjectMeta) {
+	if len(cluster.Spec.PropagatedMetadata.Labels) > 0 && metadata.Labels == nil {
+		metadata.Labels = make(map[string]string, len(cluster.Spec.PropagatedMetadata.Labels))
+	}
+
+	for label, value := range cluster.Spec.PropagatedMetadata.Labels {
+		if _, ok := metadata.Labels[label]; ok {
+			continue
+		}
+
+		metadata.Labels[label] = value
+	}
+
+	if len(cluster.Spec.PropagatedMetadata.Annotations) > 0 && metadata.Annotations == nil {
+		metadata.Annotations = make(map[string]string, len(cluster.Spec.PropagatedMetadata.Annotations))
+	}
+
+	for annotation, value := range cluster.Spec.PropagatedMetadata.Annotations {
+		if _, ok := metadata.Annotations[annotation]; ok {
+			continue
+		}
+
+		metadata.Annotations[annotation] = value
+	}
+}
+
 // GetPodDNSName determines the fully qualified DNS name for a pod.
 func GetPodDNSName(cluster *fdbv1beta2.FoundationDBCluster, podName string) string {
 	return fmt.Sprintf("%s.%s.%s.svc.%s", podName, cluster.Name, cluster.Namespace, cluster.GetDNSDomain())
@@ -140,6 +140,7 @@ var _ = Describe("pod_models", func() {
 					Expect(pod.ObjectMeta.Annotations).To(Equal(map[string]string{
 						"fdb-annotation":                    "value1",
 						fdbv1beta2.LastSpecKey:              hash,
+						fdbv1beta2.LastSpecHashVersionKey:   "2",
 						fdbv1beta2.PublicIPSourceAnnotation: "pod",
 						fdbv1beta2.ImageTypeAnnotation:      string(fdbv1beta2.ImageTypeSplit),
 					}))
@@ -2280,6 +2281,209 @@ var _ = Describe("pod_models", func() {
 			})
 		})
 
+		Context("with a runtimeClassName", func() {
+			BeforeEach(func() {
+				cluster.Spec.Processes = map[fdbv1beta2.ProcessClass]fdbv1beta2.ProcessSettings{
+					fdbv1beta2.ProcessClassGeneral: {
+						PodTemplate: &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								RuntimeClassName: pointer.String("runc"),
+							},
+						},
+					},
+					fdbv1beta2.ProcessClassStorage: {
+						RuntimeClassName: pointer.String("gvisor"),
+					},
+				}
+				err = NormalizeClusterSpec(cluster, DeprecationOptions{})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should use the runtimeClassName of the process class", func() {
+				spec, err = GetPodSpec(cluster, GetProcessGroup(cluster, fdbv1beta2.ProcessClassStorage, 1))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(spec.RuntimeClassName).To(Equal(pointer.String("gvisor")))
+			})
+
+			It("should use the runtimeClassName of the Pod template for other process classes", func() {
+				spec, err = GetPodSpec(cluster, GetProcessGroup(cluster, fdbv1beta2.ProcessClassLog, 1))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(spec.RuntimeClassName).To(Equal(pointer.String("runc")))
+			})
+		})
+
+		Context("with kernel settings", func() {
+			BeforeEach(func() {
+				processSettings := cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral]
+				processSettings.PodTemplate.Spec.SecurityContext = &corev1.PodSecurityContext{
+					Sysctls: []corev1.Sysctl{
+						{Name: "net.core.somaxconn", Value: "1024"},
+						{Name: "net.ipv4.tcp_fin_timeout", Value: "30"},
+					},
+				}
+				processSettings.KernelSettings = &fdbv1beta2.KernelSettings{
+					Sysctls: []corev1.Sysctl{
+						{Name: "net.core.somaxconn", Value: "4096"},
+					},
+					DisableTransparentHugePages: pointer.Bool(true),
+				}
+				cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral] = processSettings
+				err = NormalizeClusterSpec(cluster, DeprecationOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				spec, err = GetPodSpec(cluster, GetProcessGroup(cluster, fdbv1beta2.ProcessClassStorage, 1))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should merge the sysctls with the sysctls of the Pod template", func() {
+				Expect(spec.SecurityContext.Sysctls).To(Equal([]corev1.Sysctl{
+					{Name: "net.ipv4.tcp_fin_timeout", Value: "30"},
+					{Name: "net.core.somaxconn", Value: "4096"},
+				}))
+			})
+
+			It("should add the kernel tuning init container as first init container", func() {
+				Expect(spec.InitContainers).To(HaveLen(2))
+				kernelTuningContainer := spec.InitContainers[0]
+				Expect(kernelTuningContainer.Name).To(Equal(fdbv1beta2.KernelTuningContainerName))
+				Expect(kernelTuningContainer.Image).To(Equal(spec.Containers[0].Image))
+				Expect(kernelTuningContainer.Command).To(Equal([]string{"sh", "-c"}))
+				Expect(kernelTuningContainer.Args).To(Equal([]string{disableTransparentHugePagesScript}))
+				Expect(kernelTuningContainer.SecurityContext.Privileged).To(Equal(pointer.Bool(true)))
+				Expect(kernelTuningContainer.SecurityContext.RunAsUser).To(Equal(pointer.Int64(0)))
+				Expect(kernelTuningContainer.SecurityContext.RunAsNonRoot).To(Equal(pointer.Bool(false)))
+				Expect(spec.InitContainers[1].Name).To(Equal(fdbv1beta2.InitContainerName))
+			})
+		})
+
+		Context("with disk qualification settings", func() {
+			BeforeEach(func() {
+				processSettings := cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral]
+				processSettings.DiskQualification = &fdbv1beta2.DiskQualificationSettings{
+					Image:                  "example.com/fio:3.36",
+					MinIOPS:                pointer.Int(3000),
+					MaxLatencyMicroseconds: pointer.Int(2000),
+				}
+				processSettings.AdditionalVolumeClaims = []fdbv1beta2.AdditionalVolumeClaim{
+					{Name: "spill", MountPath: "/var/fdb/spill"},
+				}
+				cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral] = processSettings
+				err = NormalizeClusterSpec(cluster, DeprecationOptions{})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should add the disk qualification init container as first init container", func() {
+				spec, err = GetPodSpec(cluster, GetProcessGroup(cluster, fdbv1beta2.ProcessClassStorage, 1))
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(spec.InitContainers).To(HaveLen(2))
+				diskQualificationContainer := spec.InitContainers[0]
+				Expect(diskQualificationContainer.Name).To(Equal(fdbv1beta2.DiskQualificationContainerName))
+				Expect(diskQualificationContainer.Image).To(Equal("example.com/fio:3.36"))
+				Expect(diskQualificationContainer.Command).To(Equal([]string{"sh", "-c", diskQualificationScript, "disk-qualification"}))
+				Expect(diskQualificationContainer.Args).To(Equal([]string{"/var/fdb/data", "/var/fdb/spill"}))
+				Expect(diskQualificationContainer.Env).To(Equal([]corev1.EnvVar{
+					{Name: "FDB_DISK_QUALIFICATION_RUNTIME_SECONDS", Value: "30"},
+					{Name: "FDB_DISK_QUALIFICATION_MIN_IOPS", Value: "3000"},
+					{Name: "FDB_DISK_QUALIFICATION_MAX_LATENCY_US", Value: "2000"},
+				}))
+				Expect(diskQualificationContainer.VolumeMounts).To(Equal([]corev1.VolumeMount{
+					{Name: "data", MountPath: "/var/fdb/data"},
+					{Name: "spill", MountPath: "/var/fdb/spill"},
+				}))
+				Expect(diskQualificationContainer.SecurityContext).To(Equal(spec.Containers[0].SecurityContext))
+				Expect(spec.InitContainers[1].Name).To(Equal(fdbv1beta2.InitContainerName))
+			})
+
+			It("should not add the disk qualification init container for stateless processes", func() {
+				spec, err = GetPodSpec(cluster, GetProcessGroup(cluster, fdbv1beta2.ProcessClassStateless, 1))
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(spec.InitContainers).To(HaveLen(1))
+				Expect(spec.InitContainers[0].Name).To(Equal(fdbv1beta2.InitContainerName))
+			})
+		})
+
+		Context("with shutdown settings", func() {
+			BeforeEach(func() {
+				processSettings := cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral]
+				processSettings.Shutdown = &fdbv1beta2.ShutdownSettings{
+					TerminationGracePeriodSeconds: pointer.Int64(120),
+					SidecarShutdownDelaySeconds:   pointer.Int(60),
+				}
+				cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral] = processSettings
+				err = NormalizeClusterSpec(cluster, DeprecationOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				spec, err = GetPodSpec(cluster, GetProcessGroup(cluster, fdbv1beta2.ProcessClassLog, 1))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should set the termination grace period", func() {
+				Expect(spec.TerminationGracePeriodSeconds).To(Equal(pointer.Int64(120)))
+			})
+
+			It("should delay the shutdown of the sidecar container", func() {
+				sidecarContainer := spec.Containers[1]
+				Expect(sidecarContainer.Name).To(Equal(fdbv1beta2.SidecarContainerName))
+				Expect(sidecarContainer.Lifecycle).NotTo(BeNil())
+				Expect(sidecarContainer.Lifecycle.PreStop.Exec.Command).To(Equal([]string{"sleep", "60"}))
+			})
+		})
+
+		Context("with start command settings", func() {
+			BeforeEach(func() {
+				processSettings := cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral]
+				processSettings.StartCommand = &fdbv1beta2.StartCommandSettings{
+					Wrapper: []string{"numactl", "--interleave=all"},
+					Env: []fdbv1beta2.StartCommandEnvVar{
+						{Name: "LD_PRELOAD", Value: "/usr/lib/libshim.so"},
+					},
+				}
+				cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral] = processSettings
+			})
+
+			JustBeforeEach(func() {
+				err = NormalizeClusterSpec(cluster, DeprecationOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				spec, err = GetPodSpec(cluster, GetProcessGroup(cluster, fdbv1beta2.ProcessClassLog, 1))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			When("using the split image", func() {
+				It("should wrap fdbmonitor with the wrapper command", func() {
+					mainContainer := spec.Containers[0]
+					Expect(mainContainer.Name).To(Equal(fdbv1beta2.MainContainerName))
+					Expect(mainContainer.Command).To(Equal([]string{"sh", "-c"}))
+					Expect(mainContainer.Args).To(Equal([]string{
+						"'numactl' '--interleave=all' fdbmonitor --conffile /var/dynamic-conf/fdbmonitor.conf" +
+							" --lockfile /var/dynamic-conf/fdbmonitor.lockfile" +
+							" --loggroup operator-test-1" +
+							" >> /var/log/fdb-trace-logs/fdbmonitor-$(date '+%Y-%m-%d').log 2>&1",
+					}))
+				})
+
+				It("should add the environment variables to the main container", func() {
+					Expect(spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: "LD_PRELOAD", Value: "/usr/lib/libshim.so"}))
+				})
+			})
+
+			When("using the unified image", func() {
+				BeforeEach(func() {
+					imageType := fdbv1beta2.ImageTypeUnified
+					cluster.Spec.ImageType = &imageType
+				})
+
+				It("should wrap the fdb-kubernetes-monitor with the wrapper command", func() {
+					mainContainer := spec.Containers[0]
+					Expect(mainContainer.Name).To(Equal(fdbv1beta2.MainContainerName))
+					Expect(mainContainer.Command).To(Equal([]string{"numactl", "--interleave=all", "/usr/bin/fdb-kubernetes-monitor"}))
+				})
+
+				It("should add the environment variables to the main container", func() {
+					Expect(spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: "LD_PRELOAD", Value: "/usr/lib/libshim.so"}))
+				})
+			})
+		})
+
 		Context("with a custom security context", func() {
 			BeforeEach(func() {
 
@@ -2614,6 +2818,34 @@ var _ = Describe("pod_models", func() {
 			})
 		})
 
+		Context("with propagated metadata", func() {
+			BeforeEach(func() {
+				cluster.Spec.PropagatedMetadata = fdbv1beta2.PropagatedMetadata{
+					Labels: map[string]string{
+						"cost-center":                   "fdb",
+						fdbv1beta2.FDBProcessClassLabel: "custom",
+					},
+					Annotations: map[string]string{
+						"example.com/team": "storage",
+					},
+				}
+				service, err = GetService(cluster, GetProcessGroup(cluster, fdbv1beta2.ProcessClassStorage, 1))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should add the propagated metadata without overwriting the operator labels", func() {
+				Expect(service.ObjectMeta.Labels).To(Equal(map[string]string{
+					fdbv1beta2.FDBClusterLabel:        cluster.Name,
+					fdbv1beta2.FDBProcessClassLabel:   string(fdbv1beta2.ProcessClassStorage),
+					fdbv1beta2.FDBProcessGroupIDLabel: "storage-1",
+					"cost-center":                     "fdb",
+				}))
+				Expect(service.ObjectMeta.Annotations).To(Equal(map[string]string{
+					"example.com/team": "storage",
+				}))
+			})
+		})
+
 		Context("with podIPFamily 6", func() {
 			BeforeEach(func() {
 				cluster.Spec.Routing.PodIPFamily = pointer.Int(6)
@@ -2718,6 +2950,45 @@ var _ = Describe("pod_models", func() {
 			})
 		})
 
+		Context("with a storage process group of a storage pool", func() {
+			BeforeEach(func() {
+				cluster.Spec.StoragePools = []fdbv1beta2.StoragePool{
+					{
+						Name:  "dense",
+						Count: 3,
+						VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+							Spec: corev1.PersistentVolumeClaimSpec{
+								StorageClassName: pointer.String("dense"),
+								Resources: corev1.ResourceRequirements{
+									Requests: corev1.ResourceList{
+										corev1.ResourceStorage: resource.MustParse("1Ti"),
+									},
+								},
+							},
+						},
+					},
+				}
+
+				processGroup := GetProcessGroup(cluster, fdbv1beta2.ProcessClassStorage, 1)
+				processGroup.StoragePool = "dense"
+				pvc, err = GetPvc(cluster, processGroup)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should use the volume claim template of the storage pool", func() {
+				Expect(pvc.Name).To(Equal(fmt.Sprintf("%s-storage-1-data", cluster.Name)))
+				Expect(pvc.Spec).To(Equal(corev1.PersistentVolumeClaimSpec{
+					AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+					StorageClassName: pointer.String("dense"),
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceStorage: resource.MustParse("1Ti"),
+						},
+					},
+				}))
+			})
+		})
+
 		Context("with custom resource labels", func() {
 			BeforeEach(func() {
 				cluster.Spec.LabelConfig = fdbv1beta2.LabelConfig{
@@ -2744,6 +3015,47 @@ var _ = Describe("pod_models", func() {
 			})
 		})
 
+		Context("with propagated metadata", func() {
+			var initialPVC *corev1.PersistentVolumeClaim
+
+			BeforeEach(func() {
+				initialPVC, err = GetPvc(cluster, GetProcessGroup(cluster, fdbv1beta2.ProcessClassStorage, 1))
+				Expect(err).NotTo(HaveOccurred())
+
+				cluster.Spec.PropagatedMetadata = fdbv1beta2.PropagatedMetadata{
+					Labels: map[string]string{
+						"cost-center": "fdb",
+					},
+					Annotations: map[string]string{
+						"example.com/team": "storage",
+					},
+				}
+				pvc, err = GetPvc(cluster, GetProcessGroup(cluster, fdbv1beta2.ProcessClassStorage, 1))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should add the propagated metadata to the PVC", func() {
+				Expect(pvc.ObjectMeta.Labels).To(HaveKeyWithValue("cost-center", "fdb"))
+				Expect(pvc.ObjectMeta.Annotations).To(HaveKeyWithValue("example.com/team", "storage"))
+			})
+
+			It("should not change the spec hash of the PVC", func() {
+				Expect(pvc.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey]).To(Equal(initialPVC.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey]))
+			})
+		})
+
+		Context("with claimed resources", func() {
+			BeforeEach(func() {
+				cluster.Spec.LabelConfig.ClaimResources = pointer.Bool(true)
+				pvc, err = GetPvc(cluster, GetProcessGroup(cluster, fdbv1beta2.ProcessClassStorage, 1))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should add the claim annotation to the PVC", func() {
+				Expect(pvc.ObjectMeta.Annotations).To(HaveKeyWithValue(fdbv1beta2.ClusterClaimAnnotation, cluster.Name))
+			})
+		})
+
 		Context("with a custom storage size", func() {
 			BeforeEach(func() {
 				cluster.Spec.Processes = map[fdbv1beta2.ProcessClass]fdbv1beta2.ProcessSettings{fdbv1beta2.ProcessClassGeneral: {VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
@@ -2889,6 +3201,120 @@ var _ = Describe("pod_models", func() {
 		})
 	})
 
+	Describe("GetAdditionalPvcs", func() {
+		var pvcs []*corev1.PersistentVolumeClaim
+
+		Context("with a basic storage process group", func() {
+			BeforeEach(func() {
+				pvcs, err = GetAdditionalPvcs(cluster, GetProcessGroup(cluster, fdbv1beta2.ProcessClassStorage, 1))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should not return any PVCs", func() {
+				Expect(pvcs).To(BeEmpty())
+			})
+		})
+
+		When("additional volume claims are defined", func() {
+			var spec *corev1.PodSpec
+
+			BeforeEach(func() {
+				generalSettings := cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral]
+				generalSettings.AdditionalVolumeClaims = []fdbv1beta2.AdditionalVolumeClaim{
+					{
+						Name:      "spill",
+						MountPath: "/var/fdb/spill",
+						VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+							Spec: corev1.PersistentVolumeClaimSpec{
+								Resources: corev1.ResourceRequirements{
+									Requests: corev1.ResourceList{
+										corev1.ResourceStorage: resource.MustParse("32G"),
+									},
+								},
+							},
+						},
+					},
+					{
+						Name:      "server",
+						MountPath: "/var/fdb/data",
+						PerServer: pointer.Bool(true),
+					},
+				}
+				cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral] = generalSettings
+				cluster.Spec.StorageServersPerPod = 2
+			})
+
+			When("getting the PVCs for a storage process group", func() {
+				BeforeEach(func() {
+					pvcs, err = GetAdditionalPvcs(cluster, GetProcessGroup(cluster, fdbv1beta2.ProcessClassStorage, 1))
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("should return one PVC per volume and server", func() {
+					Expect(pvcs).To(HaveLen(3))
+					Expect(pvcs[0].Name).To(Equal(fmt.Sprintf("%s-storage-1-spill", cluster.Name)))
+					Expect(pvcs[0].Spec.Resources.Requests).To(HaveKeyWithValue(corev1.ResourceStorage, resource.MustParse("32G")))
+					Expect(pvcs[1].Name).To(Equal(fmt.Sprintf("%s-storage-1-server-1", cluster.Name)))
+					Expect(pvcs[1].Spec.Resources.Requests).To(HaveKeyWithValue(corev1.ResourceStorage, resource.MustParse("128G")))
+					Expect(pvcs[2].Name).To(Equal(fmt.Sprintf("%s-storage-1-server-2", cluster.Name)))
+				})
+
+				It("should set the metadata on the PVCs", func() {
+					Expect(pvcs[0].Namespace).To(Equal("my-ns"))
+					Expect(pvcs[0].ObjectMeta.Labels).To(Equal(map[string]string{
+						fdbv1beta2.FDBClusterLabel:        cluster.Name,
+						fdbv1beta2.FDBProcessClassLabel:   string(fdbv1beta2.ProcessClassStorage),
+						fdbv1beta2.FDBProcessGroupIDLabel: "storage-1",
+						fdbv1beta2.FDBVolumeNameLabel:     "spill",
+					}))
+					Expect(pvcs[0].ObjectMeta.Annotations).To(HaveKey(fdbv1beta2.LastSpecKey))
+					Expect(pvcs[0].ObjectMeta.Annotations[fdbv1beta2.LastSpecKey]).NotTo(Equal(pvcs[1].ObjectMeta.Annotations[fdbv1beta2.LastSpecKey]))
+				})
+			})
+
+			When("getting the Pod spec for a storage process group", func() {
+				BeforeEach(func() {
+					spec, err = GetPodSpec(cluster, GetProcessGroup(cluster, fdbv1beta2.ProcessClassStorage, 1))
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("should add the volumes", func() {
+					Expect(spec.Volumes).To(ContainElements(
+						corev1.Volume{Name: "spill", VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: fmt.Sprintf("%s-storage-1-spill", cluster.Name),
+						}}},
+						corev1.Volume{Name: "server-1", VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: fmt.Sprintf("%s-storage-1-server-1", cluster.Name),
+						}}},
+						corev1.Volume{Name: "server-2", VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: fmt.Sprintf("%s-storage-1-server-2", cluster.Name),
+						}}},
+					))
+				})
+
+				It("should mount the volumes in the main container", func() {
+					Expect(spec.Containers[0].Name).To(Equal(fdbv1beta2.MainContainerName))
+					Expect(spec.Containers[0].VolumeMounts).To(ContainElements(
+						corev1.VolumeMount{Name: "spill", MountPath: "/var/fdb/spill"},
+						corev1.VolumeMount{Name: "server-1", MountPath: "/var/fdb/data/1"},
+						corev1.VolumeMount{Name: "server-2", MountPath: "/var/fdb/data/2"},
+					))
+				})
+			})
+
+			When("getting the PVCs for a stateless process group", func() {
+				BeforeEach(func() {
+					pvcs, err = GetAdditionalPvcs(cluster, GetProcessGroup(cluster, fdbv1beta2.ProcessClassStateless, 1))
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("should not return any PVCs", func() {
+					Expect(pvcs).To(BeEmpty())
+				})
+			})
+		})
+	})
+
 	Describe("GetHeadlessService", func() {
 		var service *corev1.Service
 		var enabled = true
@@ -2988,6 +3414,49 @@ var _ = Describe("pod_models", func() {
 		})
 	})
 
+	Describe("GetProcessClassHeadlessService", func() {
+		var service *corev1.Service
+
+		BeforeEach(func() {
+			cluster.Spec.StorageServersPerPod = 2
+			service = GetProcessClassHeadlessService(cluster, fdbv1beta2.ProcessClassStorage)
+		})
+
+		It("should set the metadata on the service", func() {
+			Expect(service.ObjectMeta.Namespace).To(Equal("my-ns"))
+			Expect(service.ObjectMeta.Name).To(Equal("operator-test-1-storage"))
+			Expect(service.ObjectMeta.Labels).To(HaveKeyWithValue(fdbv1beta2.FDBClusterLabel, "operator-test-1"))
+			Expect(service.ObjectMeta.Labels).To(HaveKeyWithValue(fdbv1beta2.FDBProcessClassLabel, string(fdbv1beta2.ProcessClassStorage)))
+			Expect(service.ObjectMeta.Labels).To(HaveKeyWithValue(fdbv1beta2.ProcessClassServiceLabel, string(fdbv1beta2.ProcessClassStorage)))
+		})
+
+		It("should select all Pods of the process class", func() {
+			Expect(service.Spec.ClusterIP).To(Equal(corev1.ClusterIPNone))
+			Expect(service.Spec.PublishNotReadyAddresses).To(BeTrue())
+			Expect(service.Spec.Selector).To(Equal(map[string]string{
+				fdbv1beta2.FDBClusterLabel:      "operator-test-1",
+				fdbv1beta2.FDBProcessClassLabel: string(fdbv1beta2.ProcessClassStorage),
+			}))
+		})
+
+		It("should expose the ports of all storage servers", func() {
+			Expect(service.Spec.Ports).To(HaveLen(4))
+			Expect(service.Spec.Ports[0].Name).To(Equal("tls"))
+			Expect(service.Spec.Ports[0].TargetPort.IntValue()).To(BeNumerically("==", service.Spec.Ports[0].Port))
+			Expect(service.Spec.Ports[3].Name).To(Equal("non-tls-2"))
+		})
+
+		When("the process class contains an underscore", func() {
+			BeforeEach(func() {
+				service = GetProcessClassHeadlessService(cluster, fdbv1beta2.ProcessClassClusterController)
+			})
+
+			It("should use a valid service name", func() {
+				Expect(service.ObjectMeta.Name).To(Equal("operator-test-1-cluster-controller"))
+			})
+		})
+	})
+
 	When("getting the backup deployment", func() {
 		var backup *fdbv1beta2.FoundationDBBackup
 		var deployment *appsv1.Deployment
@@ -3541,8 +4010,45 @@ var _ = Describe("pod_models", func() {
 		})
 	})
 
+	Describe("RelaxPodAntiAffinity", func() {
+		var podSpec *corev1.PodSpec
+		var term corev1.PodAffinityTerm
+
+		BeforeEach(func() {
+			term = corev1.PodAffinityTerm{
+				TopologyKey:   corev1.LabelHostname,
+				LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{fdbv1beta2.FDBClusterLabel: cluster.Name}},
+			}
+
+			podSpec = &corev1.PodSpec{
+				Affinity: &corev1.Affinity{
+					PodAntiAffinity: &corev1.PodAntiAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{term},
+					},
+				},
+			}
+		})
+
+		It("converts the required terms into preferred terms", func() {
+			RelaxPodAntiAffinity(podSpec)
+			Expect(podSpec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution).To(BeEmpty())
+			Expect(podSpec.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution).To(ConsistOf(corev1.WeightedPodAffinityTerm{
+				Weight:          100,
+				PodAffinityTerm: term,
+			}))
+		})
+
+		When("no affinity is defined", func() {
+			It("doesn't modify the PodSpec", func() {
+				podSpec = &corev1.PodSpec{}
+				RelaxPodAntiAffinity(podSpec)
+				Expect(podSpec.Affinity).To(BeNil())
+			})
+		})
+	})
+
 	DescribeTable("getting the process group ID from the Pod name", func(cluster *fdbv1beta2.FoundationDBCluster, podName string, expected fdbv1beta2.ProcessGroupID) {
-		Expect(GetProcessGroupIDFromPodName(cluster, podName)).To(Equal(expected))
+		Expect(GetProcessGroupIDFromPodName(cluster, CreateCustomPodNameMap(cluster), podName)).To(Equal(expected))
 	},
 		Entry("cluster without prefix", &fdbv1beta2.FoundationDBCluster{
 			ObjectMeta: metav1.ObjectMeta{
@@ -3556,7 +4062,21 @@ var _ = Describe("pod_models", func() {
 			Spec: fdbv1beta2.FoundationDBClusterSpec{
 				ProcessGroupIDPrefix: "prefix",
 			},
-		}, "test-storage-1", fdbv1beta2.ProcessGroupID("prefix-storage-1")))
+		}, "test-storage-1", fdbv1beta2.ProcessGroupID("prefix-storage-1")),
+		Entry("process group with a custom Pod name", &fdbv1beta2.FoundationDBCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test",
+			},
+			Status: fdbv1beta2.FoundationDBClusterStatus{
+				ProcessGroups: []*fdbv1beta2.ProcessGroupStatus{
+					{
+						ProcessGroupID: "storage-1",
+						ProcessClass:   fdbv1beta2.ProcessClassStorage,
+						PodName:        "test-ss-1",
+					},
+				},
+			},
+		}, "test-ss-1", fdbv1beta2.ProcessGroupID("storage-1")))
 
 	Describe("ContainsPod", func() {
 		var pod1, pod2 *corev1.Pod
@@ -3574,4 +4094,175 @@ var _ = Describe("pod_models", func() {
 			Expect(ContainsPod(cluster, *pod2)).To(BeFalse())
 		})
 	})
+
+	Describe("GetImageRegistryChanges", func() {
+		var pod *corev1.Pod
+		var processGroup *fdbv1beta2.ProcessGroupStatus
+		var changes map[string]string
+
+		BeforeEach(func() {
+			processGroup = GetProcessGroup(cluster, fdbv1beta2.ProcessClassStorage, 1)
+			pod, err = GetPod(cluster, processGroup)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			changes, err = GetImageRegistryChanges(cluster, processGroup, pod)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		When("the images are unchanged", func() {
+			It("should not return any changes", func() {
+				Expect(changes).To(BeEmpty())
+			})
+		})
+
+		When("only the registry of the main container image has changed", func() {
+			BeforeEach(func() {
+				cluster.Spec.MainContainer.ImageConfigs = append([]fdbv1beta2.ImageConfig{{BaseImage: "registry.example.com/foundationdb/foundationdb"}}, cluster.Spec.MainContainer.ImageConfigs...)
+			})
+
+			It("should return the new image of the main container", func() {
+				Expect(changes).To(Equal(map[string]string{
+					fdbv1beta2.MainContainerName: fmt.Sprintf("registry.example.com/foundationdb/foundationdb:%s", cluster.Spec.Version),
+				}))
+			})
+
+			When("the Pod spec has other changes", func() {
+				BeforeEach(func() {
+					cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral].PodTemplate.Spec.NodeSelector = map[string]string{
+						"disk": "ssd",
+					}
+				})
+
+				It("should not return any changes", func() {
+					Expect(changes).To(BeNil())
+				})
+			})
+		})
+
+		When("the repository of the main container image has changed", func() {
+			BeforeEach(func() {
+				cluster.Spec.MainContainer.ImageConfigs = append([]fdbv1beta2.ImageConfig{{BaseImage: "registry.example.com/custom/foundationdb"}}, cluster.Spec.MainContainer.ImageConfigs...)
+			})
+
+			It("should not return any changes", func() {
+				Expect(changes).To(BeNil())
+			})
+		})
+	})
+
+	Describe("GetResourceChanges", func() {
+		var pod *corev1.Pod
+		var processGroup *fdbv1beta2.ProcessGroupStatus
+		var changes map[string]corev1.ResourceRequirements
+		var mainContainer *corev1.Container
+
+		BeforeEach(func() {
+			processGroup = GetProcessGroup(cluster, fdbv1beta2.ProcessClassStorage, 1)
+			pod, err = GetPod(cluster, processGroup)
+			Expect(err).NotTo(HaveOccurred())
+
+			containers := cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral].PodTemplate.Spec.Containers
+			for idx := range containers {
+				if containers[idx].Name == fdbv1beta2.MainContainerName {
+					mainContainer = &containers[idx]
+				}
+			}
+			Expect(mainContainer).NotTo(BeNil())
+		})
+
+		JustBeforeEach(func() {
+			changes, err = GetResourceChanges(cluster, processGroup, pod)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		When("the resources are unchanged", func() {
+			It("should not return any changes", func() {
+				Expect(changes).To(BeEmpty())
+			})
+		})
+
+		When("the CPU and memory resources of the main container have changed", func() {
+			BeforeEach(func() {
+				mainContainer.Resources = corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("2"),
+						corev1.ResourceMemory: resource.MustParse("16Gi"),
+					},
+					Limits: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("2"),
+						corev1.ResourceMemory: resource.MustParse("16Gi"),
+					},
+				}
+			})
+
+			It("should return the new resources of the main container", func() {
+				Expect(changes).To(HaveLen(1))
+				Expect(changes).To(HaveKey(fdbv1beta2.MainContainerName))
+				mainContainerResources := changes[fdbv1beta2.MainContainerName]
+				Expect(mainContainerResources.Requests.Cpu().String()).To(Equal("2"))
+				Expect(mainContainerResources.Requests.Memory().String()).To(Equal("16Gi"))
+			})
+
+			When("the Pod spec has other changes", func() {
+				BeforeEach(func() {
+					cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral].PodTemplate.Spec.NodeSelector = map[string]string{
+						"disk": "ssd",
+					}
+				})
+
+				It("should not return any changes", func() {
+					Expect(changes).To(BeNil())
+				})
+			})
+		})
+
+		When("a resource other than CPU and memory was added to the main container", func() {
+			BeforeEach(func() {
+				requests := mainContainer.Resources.Requests.DeepCopy()
+				requests[corev1.ResourceEphemeralStorage] = resource.MustParse("16Gi")
+				mainContainer.Resources.Requests = requests
+			})
+
+			It("should not return any changes", func() {
+				Expect(changes).To(BeNil())
+			})
+		})
+	})
+
+	DescribeTable("checking if only the CPU and memory resources have changed", func(current corev1.ResourceList, desired corev1.ResourceList, expected bool) {
+		Expect(onlyCPUAndMemoryChanged(current, desired)).To(Equal(expected))
+	},
+		Entry("identical resources",
+			corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+			corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+			true),
+		Entry("changed CPU and memory",
+			corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), corev1.ResourceMemory: resource.MustParse("1Gi")},
+			corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2"), corev1.ResourceMemory: resource.MustParse("2Gi")},
+			true),
+		Entry("added memory",
+			corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+			corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), corev1.ResourceMemory: resource.MustParse("2Gi")},
+			false),
+		Entry("removed memory",
+			corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), corev1.ResourceMemory: resource.MustParse("1Gi")},
+			corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+			false),
+		Entry("changed ephemeral storage",
+			corev1.ResourceList{corev1.ResourceEphemeralStorage: resource.MustParse("1Gi")},
+			corev1.ResourceList{corev1.ResourceEphemeralStorage: resource.MustParse("2Gi")},
+			false),
+	)
+
+	DescribeTable("trimming the image registry", func(image string, expected string) {
+		Expect(trimImageRegistry(image)).To(Equal(expected))
+	},
+		Entry("image without registry", "foundationdb/foundationdb:7.1.26", "foundationdb/foundationdb:7.1.26"),
+		Entry("image with registry", "registry.example.com/foundationdb/foundationdb:7.1.26", "foundationdb/foundationdb:7.1.26"),
+		Entry("image with registry and port", "registry:5000/foundationdb/foundationdb:7.1.26", "foundationdb/foundationdb:7.1.26"),
+		Entry("image with localhost registry", "localhost/foundationdb/foundationdb:7.1.26", "foundationdb/foundationdb:7.1.26"),
+		Entry("image without repository", "foundationdb:7.1.26", "foundationdb:7.1.26"),
+	)
 })
@@ -0,0 +1,233 @@
+/*
+ * pod_spec_hash.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	// legacyPodSpecHashVersion is the version of Pod spec hashes without a version prefix. Those hashes are taken from
+	// the Pod spec without any normalization.
+	legacyPodSpecHashVersion = 1
+	// currentPodSpecHashVersion is the version of the Pod spec hashes for newly created or updated Pods. Those hashes
+	// are taken from the normalized Pod spec and are prefixed with the version, e.g. "v2:<hash>".
+	currentPodSpecHashVersion = 2
+	// defaultTerminationGracePeriodSeconds is the termination grace period that Kubernetes uses if none is specified.
+	defaultTerminationGracePeriodSeconds = 30
+)
+
+// GetPodSpecHashVersion returns the version of the hash algorithm that was used to compute the provided Pod spec hash.
+func GetPodSpecHashVersion(hash string) int {
+	prefix, _, found := strings.Cut(hash, ":")
+	if !found || !strings.HasPrefix(prefix, "v") {
+		return legacyPodSpecHashVersion
+	}
+
+	version, err := strconv.Atoi(strings.TrimPrefix(prefix, "v"))
+	if err != nil {
+		return legacyPodSpecHashVersion
+	}
+
+	return version
+}
+
+// SetPodSpecHashAnnotations sets the provided spec hash and the version of the hash algorithm that was used to compute
+// it as annotations on the Pod.
+func SetPodSpecHashAnnotations(pod *corev1.Pod, specHash string) {
+	if pod.ObjectMeta.Annotations == nil {
+		pod.ObjectMeta.Annotations = make(map[string]string, 2)
+	}
+
+	pod.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey] = specHash
+	pod.ObjectMeta.Annotations[fdbv1beta2.LastSpecHashVersionKey] = strconv.Itoa(GetPodSpecHashVersion(specHash))
+}
+
+// MigratePodSpecHash rewrites the spec hash annotations of the provided Pod with the current hash version, if the
+// stored hash was computed with a different hash version and the Pod spec hasn't changed. Pods with a changed spec are
+// not modified, those must be updated by the operator. The returned bool reports if the annotations were changed, in
+// this case the caller must persist the Pod metadata.
+func MigratePodSpecHash(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus, pod *corev1.Pod) (bool, error) {
+	currentHash, ok := pod.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey]
+	if !ok || currentHash == "" {
+		return false, nil
+	}
+
+	version := GetPodSpecHashVersion(currentHash)
+	if version == currentPodSpecHashVersion {
+		// Pods created before the version annotation was introduced only need the version annotation.
+		if pod.ObjectMeta.Annotations[fdbv1beta2.LastSpecHashVersionKey] == strconv.Itoa(version) {
+			return false, nil
+		}
+
+		SetPodSpecHashAnnotations(pod, currentHash)
+		return true, nil
+	}
+
+	spec, err := GetPodSpec(cluster, processGroup)
+	if err != nil {
+		return false, err
+	}
+
+	matchingHash, err := getPodSpecHash(cluster, processGroup, spec, version)
+	if err != nil {
+		return false, err
+	}
+
+	// The spec has changed, so the Pod must be updated and will get the new hash during the update.
+	if matchingHash != currentHash {
+		return false, nil
+	}
+
+	specHash, err := getPodSpecHash(cluster, processGroup, spec, currentPodSpecHashVersion)
+	if err != nil {
+		return false, err
+	}
+
+	SetPodSpecHashAnnotations(pod, specHash)
+	return true, nil
+}
+
+// getPodSpecHash builds the hash of the expected spec for a pod with the provided hash version. Unknown versions, e.g.
+// from a newer operator version, will be hashed with the current version.
+func getPodSpecHash(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus, spec *corev1.PodSpec, version int) (string, error) {
+	var err error
+	if spec == nil {
+		spec, err = GetPodSpec(cluster, processGroup)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if version == legacyPodSpecHashVersion {
+		return GetJSONHash(spec)
+	}
+
+	normalizedSpec := spec.DeepCopy()
+	normalizePodSpec(normalizedSpec)
+	specHash, err := GetJSONHash(normalizedSpec)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("v%d:%s", currentPodSpecHashVersion, specHash), nil
+}
+
+// normalizePodSpec removes fields that are set to the Kubernetes defaults and brings fields without a semantic order
+// and resource quantities into a canonical form, so that semantically equal Pod specs result in the same hash.
+func normalizePodSpec(spec *corev1.PodSpec) {
+	if spec.RestartPolicy == corev1.RestartPolicyAlways {
+		spec.RestartPolicy = ""
+	}
+
+	if spec.DNSPolicy == corev1.DNSClusterFirst {
+		spec.DNSPolicy = ""
+	}
+
+	if spec.SchedulerName == corev1.DefaultSchedulerName {
+		spec.SchedulerName = ""
+	}
+
+	if spec.TerminationGracePeriodSeconds != nil && *spec.TerminationGracePeriodSeconds == defaultTerminationGracePeriodSeconds {
+		spec.TerminationGracePeriodSeconds = nil
+	}
+
+	if spec.SecurityContext != nil && equality.Semantic.DeepEqual(*spec.SecurityContext, corev1.PodSecurityContext{}) {
+		spec.SecurityContext = nil
+	}
+
+	sort.SliceStable(spec.Volumes, func(i, j int) bool {
+		return spec.Volumes[i].Name < spec.Volumes[j].Name
+	})
+
+	// The order of the init containers defines the order in which they are started, so only the main containers are
+	// sorted.
+	sort.SliceStable(spec.Containers, func(i, j int) bool {
+		return spec.Containers[i].Name < spec.Containers[j].Name
+	})
+
+	for idx := range spec.InitContainers {
+		normalizeContainer(&spec.InitContainers[idx])
+	}
+
+	for idx := range spec.Containers {
+		normalizeContainer(&spec.Containers[idx])
+	}
+}
+
+// normalizeContainer removes fields that are set to the Kubernetes defaults and brings fields without a semantic order
+// and resource quantities into a canonical form. The order of the environment variables is kept, as variables can
+// reference previously defined variables.
+func normalizeContainer(container *corev1.Container) {
+	if container.TerminationMessagePath == corev1.TerminationMessagePathDefault {
+		container.TerminationMessagePath = ""
+	}
+
+	if container.TerminationMessagePolicy == corev1.TerminationMessageReadFile {
+		container.TerminationMessagePolicy = ""
+	}
+
+	if container.SecurityContext != nil && equality.Semantic.DeepEqual(*container.SecurityContext, corev1.SecurityContext{}) {
+		container.SecurityContext = nil
+	}
+
+	for idx := range container.Ports {
+		if container.Ports[idx].Protocol == corev1.ProtocolTCP {
+			container.Ports[idx].Protocol = ""
+		}
+	}
+
+	sort.SliceStable(container.Ports, func(i, j int) bool {
+		if container.Ports[i].ContainerPort != container.Ports[j].ContainerPort {
+			return container.Ports[i].ContainerPort < container.Ports[j].ContainerPort
+		}
+
+		return container.Ports[i].Protocol < container.Ports[j].Protocol
+	})
+
+	sort.SliceStable(container.VolumeMounts, func(i, j int) bool {
+		return container.VolumeMounts[i].MountPath < container.VolumeMounts[j].MountPath
+	})
+
+	normalizeResourceList(container.Resources.Limits)
+	normalizeResourceList(container.Resources.Requests)
+}
+
+// normalizeResourceList converts all quantities into their canonical decimal form, e.g. "1Gi" and "1073741824" or
+// "1000m" and "1" result in the same quantity.
+func normalizeResourceList(resources corev1.ResourceList) {
+	for name, quantity := range resources {
+		normalized, err := resource.ParseQuantity(quantity.AsDec().String())
+		if err != nil {
+			continue
+		}
+
+		resources[name] = normalized
+	}
+}
@@ -0,0 +1,247 @@
+/*
+ * pod_spec_hash_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	"strings"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("pod_spec_hash", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var processGroup *fdbv1beta2.ProcessGroupStatus
+	var spec *corev1.PodSpec
+
+	BeforeEach(func() {
+		cluster = CreateDefaultCluster()
+		Expect(NormalizeClusterSpec(cluster, DeprecationOptions{})).To(Succeed())
+		processGroup = fdbv1beta2.NewProcessGroupStatus("storage-1", fdbv1beta2.ProcessClassStorage, nil)
+
+		var err error
+		spec, err = GetPodSpec(cluster, processGroup)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	DescribeTable("getting the hash version", func(hash string, expected int) {
+		Expect(GetPodSpecHashVersion(hash)).To(Equal(expected))
+	},
+		Entry("legacy hash", "f0c8a45ea6c3dd26c2dc2b5f3c699f38d613dab273d0f8a6eae6abd9a9569063", legacyPodSpecHashVersion),
+		Entry("empty hash", "", legacyPodSpecHashVersion),
+		Entry("current hash", "v2:f0c8a45ea6c3dd26c2dc2b5f3c699f38d613dab273d0f8a6eae6abd9a9569063", currentPodSpecHashVersion),
+		Entry("future hash", "v3:f0c8a45ea6c3dd26c2dc2b5f3c699f38d613dab273d0f8a6eae6abd9a9569063", 3),
+		Entry("invalid version", "vx:f0c8a45ea6c3dd26c2dc2b5f3c699f38d613dab273d0f8a6eae6abd9a9569063", legacyPodSpecHashVersion),
+	)
+
+	When("getting the Pod spec hash", func() {
+		It("should prefix the hash with the current version", func() {
+			specHash, err := GetPodSpecHash(cluster, processGroup, spec)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(strings.HasPrefix(specHash, "v2:")).To(BeTrue())
+			Expect(GetPodSpecHashVersion(specHash)).To(Equal(currentPodSpecHashVersion))
+		})
+
+		It("should not modify the provided spec", func() {
+			original := spec.DeepCopy()
+			_, err := GetPodSpecHash(cluster, processGroup, spec)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(spec).To(Equal(original))
+		})
+	})
+
+	When("getting the matching Pod spec hash", func() {
+		It("should use the legacy hash for a legacy hash", func() {
+			legacyHash, err := GetJSONHash(spec)
+			Expect(err).NotTo(HaveOccurred())
+
+			specHash, err := GetMatchingPodSpecHash(cluster, processGroup, spec, legacyHash)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(specHash).To(Equal(legacyHash))
+		})
+
+		It("should use the current version for a current hash", func() {
+			currentHash, err := GetPodSpecHash(cluster, processGroup, spec)
+			Expect(err).NotTo(HaveOccurred())
+
+			specHash, err := GetMatchingPodSpecHash(cluster, processGroup, spec, currentHash)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(specHash).To(Equal(currentHash))
+		})
+
+		It("should use the current version for an unknown version", func() {
+			currentHash, err := GetPodSpecHash(cluster, processGroup, spec)
+			Expect(err).NotTo(HaveOccurred())
+
+			specHash, err := GetMatchingPodSpecHash(cluster, processGroup, spec, "v3:abc")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(specHash).To(Equal(currentHash))
+		})
+	})
+
+	When("migrating the Pod spec hash", func() {
+		var pod *corev1.Pod
+		var currentHash string
+		var migrated bool
+
+		BeforeEach(func() {
+			var err error
+			currentHash, err = GetPodSpecHash(cluster, processGroup, spec)
+			Expect(err).NotTo(HaveOccurred())
+
+			pod = &corev1.Pod{}
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			migrated, err = MigratePodSpecHash(cluster, processGroup, pod)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		When("the Pod has no spec hash", func() {
+			It("should not migrate the hash", func() {
+				Expect(migrated).To(BeFalse())
+				Expect(pod.ObjectMeta.Annotations).To(BeEmpty())
+			})
+		})
+
+		When("the Pod has a legacy hash and the spec is unchanged", func() {
+			BeforeEach(func() {
+				legacyHash, err := GetJSONHash(spec)
+				Expect(err).NotTo(HaveOccurred())
+				pod.ObjectMeta.Annotations = map[string]string{
+					fdbv1beta2.LastSpecKey: legacyHash,
+				}
+			})
+
+			It("should rewrite the hash with the current version", func() {
+				Expect(migrated).To(BeTrue())
+				Expect(pod.ObjectMeta.Annotations).To(Equal(map[string]string{
+					fdbv1beta2.LastSpecKey:            currentHash,
+					fdbv1beta2.LastSpecHashVersionKey: "2",
+				}))
+			})
+		})
+
+		When("the Pod has a legacy hash and the spec has changed", func() {
+			BeforeEach(func() {
+				pod.ObjectMeta.Annotations = map[string]string{
+					fdbv1beta2.LastSpecKey: "f0c8a45ea6c3dd26c2dc2b5f3c699f38d613dab273d0f8a6eae6abd9a9569063",
+				}
+			})
+
+			It("should not migrate the hash", func() {
+				Expect(migrated).To(BeFalse())
+				Expect(pod.ObjectMeta.Annotations).To(Equal(map[string]string{
+					fdbv1beta2.LastSpecKey: "f0c8a45ea6c3dd26c2dc2b5f3c699f38d613dab273d0f8a6eae6abd9a9569063",
+				}))
+			})
+		})
+
+		When("the Pod has a current hash without the version annotation", func() {
+			BeforeEach(func() {
+				pod.ObjectMeta.Annotations = map[string]string{
+					fdbv1beta2.LastSpecKey: currentHash,
+				}
+			})
+
+			It("should add the version annotation", func() {
+				Expect(migrated).To(BeTrue())
+				Expect(pod.ObjectMeta.Annotations).To(Equal(map[string]string{
+					fdbv1beta2.LastSpecKey:            currentHash,
+					fdbv1beta2.LastSpecHashVersionKey: "2",
+				}))
+			})
+		})
+
+		When("the Pod has a current hash with the version annotation", func() {
+			BeforeEach(func() {
+				pod.ObjectMeta.Annotations = map[string]string{
+					fdbv1beta2.LastSpecKey:            currentHash,
+					fdbv1beta2.LastSpecHashVersionKey: "2",
+				}
+			})
+
+			It("should not change the Pod", func() {
+				Expect(migrated).To(BeFalse())
+			})
+		})
+	})
+
+	When("the Pod specs are semantically equal", func() {
+		var changedSpec *corev1.PodSpec
+
+		BeforeEach(func() {
+			changedSpec = spec.DeepCopy()
+		})
+
+		AfterEach(func() {
+			specHash, err := GetPodSpecHash(cluster, processGroup, spec)
+			Expect(err).NotTo(HaveOccurred())
+			changedHash, err := GetPodSpecHash(cluster, processGroup, changedSpec)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changedHash).To(Equal(specHash))
+		})
+
+		It("should ignore defaulted fields", func() {
+			changedSpec.RestartPolicy = corev1.RestartPolicyAlways
+			changedSpec.DNSPolicy = corev1.DNSClusterFirst
+			changedSpec.SchedulerName = corev1.DefaultSchedulerName
+			changedSpec.TerminationGracePeriodSeconds = pointer.Int64(30)
+			changedSpec.Containers[0].TerminationMessagePath = corev1.TerminationMessagePathDefault
+			changedSpec.Containers[0].TerminationMessagePolicy = corev1.TerminationMessageReadFile
+		})
+
+		It("should ignore the order of the volumes and containers", func() {
+			Expect(len(changedSpec.Volumes)).To(BeNumerically(">", 1))
+			Expect(len(changedSpec.Containers)).To(BeNumerically(">", 1))
+			changedSpec.Volumes[0], changedSpec.Volumes[1] = changedSpec.Volumes[1], changedSpec.Volumes[0]
+			changedSpec.Containers[0], changedSpec.Containers[1] = changedSpec.Containers[1], changedSpec.Containers[0]
+		})
+
+		It("should ignore the format of resource quantities", func() {
+			spec.Containers[0].Resources.Requests = corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1"),
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+			}
+			changedSpec.Containers[0].Resources.Requests = corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1000m"),
+				corev1.ResourceMemory: resource.MustParse("1073741824"),
+			}
+		})
+	})
+
+	When("the Pod specs are semantically different", func() {
+		It("should return a different hash", func() {
+			specHash, err := GetPodSpecHash(cluster, processGroup, spec)
+			Expect(err).NotTo(HaveOccurred())
+
+			spec.InitContainers = append(spec.InitContainers, corev1.Container{Name: "test"})
+			changedHash, err := GetPodSpecHash(cluster, processGroup, spec)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changedHash).NotTo(Equal(specHash))
+		})
+	})
+})
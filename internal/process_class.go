@@ -22,6 +22,7 @@ package internal
 
 import (
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"k8s.io/apimachinery/pkg/api/equality"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -34,3 +35,20 @@ func ProcessClassFromLabels(cluster *fdbv1beta2.FoundationDBCluster, labels map[
 func GetProcessClassFromMeta(cluster *fdbv1beta2.FoundationDBCluster, metadata v1.ObjectMeta) fdbv1beta2.ProcessClass {
 	return ProcessClassFromLabels(cluster, metadata.Labels)
 }
+
+// ProcessClassesAreCompatible returns true if a process group of the current process class can be reassigned to the
+// desired process class without being replaced. This is only the case if both process classes are stateless and
+// the resulting Pods would use the same resources and scheduling constraints.
+func ProcessClassesAreCompatible(cluster *fdbv1beta2.FoundationDBCluster, current fdbv1beta2.ProcessClass, desired fdbv1beta2.ProcessClass) bool {
+	// Stateful process classes have a volume with data that is specific to the process class, so those process groups
+	// must be replaced.
+	if current.IsStateful() || desired.IsStateful() {
+		return false
+	}
+
+	if cluster.GetDesiredServersPerPod(current) != cluster.GetDesiredServersPerPod(desired) {
+		return false
+	}
+
+	return equality.Semantic.DeepEqual(cluster.GetProcessSettings(current).PodTemplate, cluster.GetProcessSettings(desired).PodTemplate)
+}
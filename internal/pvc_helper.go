@@ -21,14 +21,37 @@
 package internal
 
 import (
+	"fmt"
+	"path"
+	"strconv"
+
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	corev1 "k8s.io/api/core/v1"
 )
 
+// selectedNodeAnnotation is set by the scheduler on PVCs that use a storage class with the WaitForFirstConsumer
+// binding mode, e.g. local persistent volumes. The annotation contains the name of the node the volume is provisioned on.
+const selectedNodeAnnotation = "volume.kubernetes.io/selected-node"
+
+// GetVolumeNodeName returns the name of the node that the PVC is bound to. If the PVC is not bound to a specific node,
+// an empty string will be returned.
+func GetVolumeNodeName(pvc *corev1.PersistentVolumeClaim) string {
+	if pvc == nil {
+		return ""
+	}
+
+	return pvc.Annotations[selectedNodeAnnotation]
+}
+
 // CreatePVCMap creates a map with the process group ID as a key and the according PVC as a value
 func CreatePVCMap(cluster *fdbv1beta2.FoundationDBCluster, pvcs *corev1.PersistentVolumeClaimList) map[fdbv1beta2.ProcessGroupID]corev1.PersistentVolumeClaim {
 	pvcMap := make(map[fdbv1beta2.ProcessGroupID]corev1.PersistentVolumeClaim, len(pvcs.Items))
 	for _, pvc := range pvcs.Items {
+		// Additional PVCs are tracked in a separate map.
+		if _, ok := pvc.Labels[fdbv1beta2.FDBVolumeNameLabel]; ok {
+			continue
+		}
+
 		processGroupID := GetProcessGroupIDFromMeta(cluster, pvc.ObjectMeta)
 		if processGroupID == "" {
 			continue
@@ -39,3 +62,71 @@ func CreatePVCMap(cluster *fdbv1beta2.FoundationDBCluster, pvcs *corev1.Persiste
 
 	return pvcMap
 }
+
+// CreateAdditionalPVCMap creates a map with the process group ID as a key and the according additional PVCs as a value.
+// The additional PVCs are identified by the FDBVolumeNameLabel.
+func CreateAdditionalPVCMap(cluster *fdbv1beta2.FoundationDBCluster, pvcs *corev1.PersistentVolumeClaimList) map[fdbv1beta2.ProcessGroupID][]corev1.PersistentVolumeClaim {
+	pvcMap := make(map[fdbv1beta2.ProcessGroupID][]corev1.PersistentVolumeClaim)
+	for _, pvc := range pvcs.Items {
+		if _, ok := pvc.Labels[fdbv1beta2.FDBVolumeNameLabel]; !ok {
+			continue
+		}
+
+		processGroupID := GetProcessGroupIDFromMeta(cluster, pvc.ObjectMeta)
+		if processGroupID == "" {
+			continue
+		}
+
+		pvcMap[processGroupID] = append(pvcMap[processGroupID], pvc)
+	}
+
+	return pvcMap
+}
+
+// additionalVolume represents a single additional volume of a process group.
+type additionalVolume struct {
+	// name of the volume, this name is also used as suffix for the PVC name.
+	name string
+	// mountPath where the volume is mounted in the main container.
+	mountPath string
+	// volumeClaimTemplate used to create the PVC for this volume.
+	volumeClaimTemplate *corev1.PersistentVolumeClaim
+}
+
+// getAdditionalVolumes returns the additional volumes for the provided process class. If a volume claim uses per
+// server volumes, one volume will be returned for every server in the Pod.
+func getAdditionalVolumes(cluster *fdbv1beta2.FoundationDBCluster, processClass fdbv1beta2.ProcessClass) []additionalVolume {
+	claims := cluster.GetProcessSettings(processClass).AdditionalVolumeClaims
+	if len(claims) == 0 {
+		return nil
+	}
+
+	serversPerPod := cluster.GetDesiredServersPerPod(processClass)
+	volumes := make([]additionalVolume, 0, len(claims))
+	for _, claim := range claims {
+		if !claim.UsePerServerVolumes() {
+			volumes = append(volumes, additionalVolume{
+				name:                claim.Name,
+				mountPath:           claim.MountPath,
+				volumeClaimTemplate: claim.VolumeClaimTemplate,
+			})
+			continue
+		}
+
+		// The data directories of the fdbserver processes are numbered starting with 1.
+		for serverNumber := 1; serverNumber <= serversPerPod; serverNumber++ {
+			volumes = append(volumes, additionalVolume{
+				name:                fmt.Sprintf("%s-%d", claim.Name, serverNumber),
+				mountPath:           path.Join(claim.MountPath, strconv.Itoa(serverNumber)),
+				volumeClaimTemplate: claim.VolumeClaimTemplate,
+			})
+		}
+	}
+
+	return volumes
+}
+
+// getAdditionalPvcName returns the name of the PVC for an additional volume.
+func getAdditionalPvcName(podName string, volumeName string) string {
+	return fmt.Sprintf("%s-%s", podName, volumeName)
+}
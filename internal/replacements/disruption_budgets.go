@@ -0,0 +1,180 @@
+/*
+ * disruption_budgets.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replacements
+
+import (
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/podmanager"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// disruptionBudget contains the remaining disruptions of a single PodDisruptionBudget.
+type disruptionBudget struct {
+	// name of the PodDisruptionBudget.
+	name string
+	// selector of the PodDisruptionBudget.
+	selector labels.Selector
+	// remaining defines how many additional disruptions are allowed.
+	remaining int32
+}
+
+// disruptionBudgetTracker keeps track of the disruptions that are still allowed by the PodDisruptionBudgets in the
+// namespace of the cluster.
+type disruptionBudgetTracker struct {
+	// budgets contains all PodDisruptionBudgets that select at least one Pod of the cluster.
+	budgets []*disruptionBudget
+	// podLabels contains the labels of the Pods of the cluster, keyed by the process group ID.
+	podLabels map[fdbv1beta2.ProcessGroupID]labels.Set
+}
+
+// newDisruptionBudgetTracker creates a disruptionBudgetTracker for the PodDisruptionBudgets in the namespace of the
+// cluster. The disruptions allowed by a PodDisruptionBudget already take the unavailable Pods into account, in addition
+// every process group that is marked for removal and still has a Pod counts as a disruption, as the Pod will be removed.
+// If the status of a PodDisruptionBudget is outdated, no disruptions are allowed.
+func newDisruptionBudgetTracker(ctx context.Context, reader client.Reader, log logr.Logger, cluster *fdbv1beta2.FoundationDBCluster) (*disruptionBudgetTracker, error) {
+	tracker := &disruptionBudgetTracker{
+		podLabels: map[fdbv1beta2.ProcessGroupID]labels.Set{},
+	}
+
+	pdbs := &policyv1.PodDisruptionBudgetList{}
+	err := reader.List(ctx, pdbs, client.InNamespace(cluster.Namespace))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pdbs.Items) == 0 {
+		return tracker, nil
+	}
+
+	pods := &corev1.PodList{}
+	err = reader.List(ctx, pods, internal.GetPodListOptions(cluster, "", "")...)
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := range pods.Items {
+		pod := &pods.Items[idx]
+		tracker.podLabels[podmanager.GetProcessGroupID(cluster, pod)] = pod.Labels
+	}
+
+	for _, pdb := range pdbs.Items {
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil {
+			log.Info("Ignoring PodDisruptionBudget with invalid selector", "podDisruptionBudget", pdb.Name, "error", err.Error())
+			continue
+		}
+
+		budget := &disruptionBudget{
+			name:      pdb.Name,
+			selector:  selector,
+			remaining: pdb.Status.DisruptionsAllowed,
+		}
+
+		if pdb.Status.ObservedGeneration < pdb.Generation {
+			budget.remaining = 0
+		}
+
+		selectsCluster := false
+		for _, podLabels := range tracker.podLabels {
+			if selector.Matches(podLabels) {
+				selectsCluster = true
+				break
+			}
+		}
+
+		if !selectsCluster {
+			continue
+		}
+
+		for _, processGroup := range cluster.Status.ProcessGroups {
+			if !processGroup.IsMarkedForRemoval() {
+				continue
+			}
+
+			podLabels, ok := tracker.podLabels[processGroup.ProcessGroupID]
+			if ok && selector.Matches(podLabels) {
+				budget.remaining--
+			}
+		}
+
+		tracker.budgets = append(tracker.budgets, budget)
+	}
+
+	return tracker, nil
+}
+
+// disruptionAllowed returns true if all PodDisruptionBudgets that select the Pod of the process group allow an
+// additional disruption. If the disruption is not allowed, the name of the blocking PodDisruptionBudget is returned.
+func (tracker *disruptionBudgetTracker) disruptionAllowed(processGroup *fdbv1beta2.ProcessGroupStatus) (bool, string) {
+	podLabels, ok := tracker.podLabels[processGroup.ProcessGroupID]
+	if !ok {
+		return true, ""
+	}
+
+	for _, budget := range tracker.budgets {
+		if budget.remaining <= 0 && budget.selector.Matches(podLabels) {
+			return false, budget.name
+		}
+	}
+
+	return true, ""
+}
+
+// consumeDisruption reduces the remaining disruptions of all PodDisruptionBudgets that select the Pod of the process
+// group.
+func (tracker *disruptionBudgetTracker) consumeDisruption(processGroup *fdbv1beta2.ProcessGroupStatus) {
+	podLabels, ok := tracker.podLabels[processGroup.ProcessGroupID]
+	if !ok {
+		return
+	}
+
+	for _, budget := range tracker.budgets {
+		if budget.selector.Matches(podLabels) {
+			budget.remaining--
+		}
+	}
+}
+
+// updateDeferredReplacements sets the ReplacementDeferredByDisruptionBudget condition for all provided process groups
+// and removes the condition from all other process groups. The returned bool reports if any condition was changed.
+func updateDeferredReplacements(cluster *fdbv1beta2.FoundationDBCluster, deferred map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None) bool {
+	changed := false
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		_, isDeferred := deferred[processGroup.ProcessGroupID]
+		hasCondition := processGroup.GetConditionTime(fdbv1beta2.ReplacementDeferredByDisruptionBudget) != nil
+		if isDeferred == hasCondition {
+			continue
+		}
+
+		processGroup.UpdateCondition(fdbv1beta2.ReplacementDeferredByDisruptionBudget, isDeferred)
+		changed = true
+	}
+
+	return changed
+}
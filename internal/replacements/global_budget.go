@@ -0,0 +1,93 @@
+/*
+ * global_budget.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replacements
+
+import (
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient"
+	"github.com/go-logr/logr"
+)
+
+// GlobalBudget limits the concurrent replacements of all operator instances that manage the same database. All methods
+// of the GlobalBudget are safe to be called on a nil GlobalBudget, which doesn't limit any replacements.
+type GlobalBudget struct {
+	// lockClient is used to store the in-flight replacements in the database.
+	lockClient fdbadminclient.LockClient
+	// maxReplacements defines how many process groups can be concurrently replaced by all operator instances.
+	maxReplacements int
+	// leaseDuration defines how long the in-flight replacements of an operator instance are counted.
+	leaseDuration time.Duration
+	// logger is used to log failures to reserve replacements.
+	logger logr.Logger
+}
+
+// NewGlobalBudget creates a new GlobalBudget for the cluster and refreshes the in-flight replacements of the current
+// operator instance, e.g. process groups that are marked for removal but not yet excluded. If the cluster defines no
+// global replacement budget or the locking system is disabled, nil will be returned.
+func NewGlobalBudget(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, lockClient fdbadminclient.LockClient) (*GlobalBudget, error) {
+	if !cluster.UseGlobalReplacementBudget() {
+		return nil, nil
+	}
+
+	if lockClient.Disabled() {
+		logger.Info("Global replacement budget is ignored, because the locking system is disabled")
+		return nil, nil
+	}
+
+	budget := &GlobalBudget{
+		lockClient:      lockClient,
+		maxReplacements: *cluster.Spec.AutomationOptions.Replacements.GlobalBudget.MaxConcurrentReplacements,
+		leaseDuration:   cluster.GetGlobalReplacementBudgetLeaseDuration(),
+		logger:          logger,
+	}
+
+	var inFlight []fdbv1beta2.ProcessGroupID
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if processGroup.IsMarkedForRemoval() && !processGroup.IsExcluded() {
+			inFlight = append(inFlight, processGroup.ProcessGroupID)
+		}
+	}
+
+	err := lockClient.UpdateReplacements(inFlight, budget.leaseDuration)
+	if err != nil {
+		return nil, err
+	}
+
+	return budget, nil
+}
+
+// Reserve returns true if the process group can be replaced within the global replacement budget. If true is returned,
+// the replacement is counted in the global replacement budget.
+func (budget *GlobalBudget) Reserve(processGroup *fdbv1beta2.ProcessGroupStatus) bool {
+	if budget == nil {
+		return true
+	}
+
+	reserved, err := budget.lockClient.ReserveReplacement(processGroup.ProcessGroupID, budget.maxReplacements, budget.leaseDuration)
+	if err != nil {
+		budget.logger.Error(err, "could not reserve replacement in global replacement budget", "processGroupID", processGroup.ProcessGroupID)
+		return false
+	}
+
+	return reserved
+}
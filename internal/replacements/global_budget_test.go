@@ -0,0 +1,126 @@
+/*
+ * global_budget_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replacements
+
+import (
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient/mock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("global_budget", func() {
+	var primary, remote *fdbv1beta2.FoundationDBCluster
+	var primaryLockClient, remoteLockClient *mock.LockClient
+
+	newCluster := func(name string, prefix string) *fdbv1beta2.FoundationDBCluster {
+		cluster := internal.CreateDefaultCluster()
+		cluster.Name = name
+		cluster.Spec.ProcessGroupIDPrefix = prefix
+		cluster.Spec.LockOptions.DisableLocks = pointer.Bool(false)
+		cluster.Spec.AutomationOptions.Replacements.GlobalBudget = &fdbv1beta2.GlobalReplacementBudgetOptions{
+			MaxConcurrentReplacements: pointer.Int(2),
+		}
+
+		for _, processGroupID := range []fdbv1beta2.ProcessGroupID{"storage-1", "storage-2", "storage-3"} {
+			processGroup := fdbv1beta2.NewProcessGroupStatus(fdbv1beta2.ProcessGroupID(prefix)+"-"+processGroupID, fdbv1beta2.ProcessClassStorage, nil)
+			cluster.Status.ProcessGroups = append(cluster.Status.ProcessGroups, processGroup)
+		}
+
+		return cluster
+	}
+
+	BeforeEach(func() {
+		mock.ClearMockLockClients()
+		primary = newCluster("primary", "dc1")
+		remote = newCluster("remote", "dc2")
+		primaryLockClient = mock.NewMockLockClientUncast(primary)
+		remoteLockClient = mock.NewMockLockClientUncast(remote)
+	})
+
+	When("the remote operator instance has an in-flight replacement", func() {
+		var budget *GlobalBudget
+
+		BeforeEach(func() {
+			remote.Status.ProcessGroups[0].MarkForRemoval()
+			remoteBudget, err := NewGlobalBudget(GinkgoLogr, remote, remoteLockClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(remoteBudget).NotTo(BeNil())
+
+			budget, err = NewGlobalBudget(GinkgoLogr, primary, primaryLockClient)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should only allow one additional replacement", func() {
+			Expect(budget.Reserve(primary.Status.ProcessGroups[0])).To(BeTrue())
+			Expect(budget.Reserve(primary.Status.ProcessGroups[1])).To(BeFalse())
+			Expect(primaryLockClient.GetReplacements()).To(ConsistOf(fdbv1beta2.ProcessGroupID("dc1-storage-1"), fdbv1beta2.ProcessGroupID("dc2-storage-1")))
+		})
+
+		It("should allow to reserve the same replacement again", func() {
+			Expect(budget.Reserve(primary.Status.ProcessGroups[0])).To(BeTrue())
+			Expect(budget.Reserve(primary.Status.ProcessGroups[0])).To(BeTrue())
+		})
+
+		When("the remote replacement is completed", func() {
+			BeforeEach(func() {
+				remote.Status.ProcessGroups[0].SetExclude()
+				_, err := NewGlobalBudget(GinkgoLogr, remote, remoteLockClient)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should allow two replacements", func() {
+				Expect(budget.Reserve(primary.Status.ProcessGroups[0])).To(BeTrue())
+				Expect(budget.Reserve(primary.Status.ProcessGroups[1])).To(BeTrue())
+				Expect(budget.Reserve(primary.Status.ProcessGroups[2])).To(BeFalse())
+			})
+		})
+
+		When("the lease of the remote replacement is expired", func() {
+			BeforeEach(func() {
+				Expect(remoteLockClient.UpdateReplacements([]fdbv1beta2.ProcessGroupID{"dc2-storage-1"}, -1*time.Minute)).To(Succeed())
+			})
+
+			It("should allow two replacements", func() {
+				Expect(budget.Reserve(primary.Status.ProcessGroups[0])).To(BeTrue())
+				Expect(budget.Reserve(primary.Status.ProcessGroups[1])).To(BeTrue())
+			})
+		})
+	})
+
+	When("no global budget is defined", func() {
+		BeforeEach(func() {
+			primary.Spec.AutomationOptions.Replacements.GlobalBudget = nil
+		})
+
+		It("should not limit the replacements", func() {
+			budget, err := NewGlobalBudget(GinkgoLogr, primary, primaryLockClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(budget).To(BeNil())
+			Expect(budget.Reserve(primary.Status.ProcessGroups[0])).To(BeTrue())
+		})
+	})
+
+})
@@ -0,0 +1,196 @@
+/*
+ * metrics.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replacements
+
+import (
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// unknownRemovalReason is used as reason label for process groups that are marked for removal without a removal reason,
+// e.g. process groups that were added to the processGroupsToRemove list.
+const unknownRemovalReason = "Unknown"
+
+// deferralReason defines why the replacement of a process group was deferred.
+type deferralReason string
+
+const (
+	// deferralReasonApprovalRequired is used if the replacement must be approved.
+	deferralReasonApprovalRequired deferralReason = "ApprovalRequired"
+	// deferralReasonReplacementLimit is used if the limit of concurrent replacements is reached.
+	deferralReasonReplacementLimit deferralReason = "ReplacementLimit"
+	// deferralReasonProcessClassLimit is used if the limit of concurrent replacements of the process class is reached.
+	deferralReasonProcessClassLimit deferralReason = "ProcessClassLimit"
+	// deferralReasonFaultDomain is used if the process group is not in the fault domain that is currently replaced.
+	deferralReasonFaultDomain deferralReason = "FaultDomain"
+	// deferralReasonStorageClassMigrationLimit is used if the limit of concurrent storage class migrations is reached.
+	deferralReasonStorageClassMigrationLimit deferralReason = "StorageClassMigrationLimit"
+	// deferralReasonNodeVersionSkewLimit is used if the limit of concurrent replacements of process groups on
+	// outdated nodes is reached.
+	deferralReasonNodeVersionSkewLimit deferralReason = "NodeVersionSkewLimit"
+	// deferralReasonPodDisruptionBudget is used if the replacement would exceed a PodDisruptionBudget.
+	deferralReasonPodDisruptionBudget deferralReason = "PodDisruptionBudget"
+	// deferralReasonGlobalBudget is used if the global replacement budget is exhausted.
+	deferralReasonGlobalBudget deferralReason = "GlobalBudget"
+	// deferralReasonProtected is used if the process group is protected.
+	deferralReasonProtected deferralReason = "Protected"
+	// deferralReasonReplacementLoop is used if a replacement loop was detected for the process class.
+	deferralReasonReplacementLoop deferralReason = "ReplacementLoop"
+	// deferralReasonReplacementBackoff is used if the replacement backoff of the process class has not elapsed.
+	deferralReasonReplacementBackoff deferralReason = "ReplacementBackoff"
+	// deferralReasonFaultTolerance is used if a process group without addresses can't be replaced, because the
+	// cluster doesn't have the desired fault tolerance.
+	deferralReasonFaultTolerance deferralReason = "FaultTolerance"
+	// deferralReasonMaintenanceZone is used if the process group is in the current maintenance zone.
+	deferralReasonMaintenanceZone deferralReason = "MaintenanceZone"
+)
+
+var metricLabels = []string{"namespace", "name", "process_class", "reason"}
+
+var (
+	// replacementsStarted counts the process groups that were marked for removal by the operator.
+	replacementsStarted = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "fdb_operator_replacements_started_total",
+			Help: "the count of process groups that were marked for removal because they must be replaced.",
+		},
+		metricLabels,
+	)
+
+	// replacementsCompleted counts the replaced process groups whose resources were removed.
+	replacementsCompleted = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "fdb_operator_replacements_completed_total",
+			Help: "the count of replaced process groups whose resources were removed.",
+		},
+		metricLabels,
+	)
+
+	// replacementDuration observes the time from the detection of a replacement until its completion.
+	replacementDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "fdb_operator_replacement_duration_seconds",
+			Help:    "the time in seconds from the detection of a replacement until the resources of the replaced process group were removed.",
+			Buckets: prometheus.ExponentialBuckets(60, 2, 12),
+		},
+		metricLabels,
+	)
+
+	// replacementsDeferred counts how often the replacement of a process group was deferred.
+	replacementsDeferred = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "fdb_operator_replacements_deferred_total",
+			Help: "the count of reconciliations where the replacement of a process group was deferred.",
+		},
+		metricLabels,
+	)
+)
+
+// Collectors returns the metric collectors of the replacements, those must be registered by the operator.
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		replacementsStarted,
+		replacementsCompleted,
+		replacementDuration,
+		replacementsDeferred,
+	}
+}
+
+// getRemovalReasonLabel returns the reason label for the removal reason of the process group.
+func getRemovalReasonLabel(processGroup *fdbv1beta2.ProcessGroupStatus) string {
+	if processGroup.RemovalReason == nil {
+		return unknownRemovalReason
+	}
+
+	return string(processGroup.RemovalReason.Type)
+}
+
+// recordStartedReplacement records that the process group was marked for removal.
+func recordStartedReplacement(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus) {
+	replacementsStarted.WithLabelValues(cluster.Namespace, cluster.Name, string(processGroup.ProcessClass), getRemovalReasonLabel(processGroup)).Inc()
+}
+
+// recordDeferredReplacements records that the replacements of the process groups were deferred.
+func recordDeferredReplacements(cluster *fdbv1beta2.FoundationDBCluster, reason deferralReason, processGroups ...*fdbv1beta2.ProcessGroupStatus) {
+	for _, processGroup := range processGroups {
+		replacementsDeferred.WithLabelValues(cluster.Namespace, cluster.Name, string(processGroup.ProcessClass), string(reason)).Inc()
+	}
+}
+
+// RecordCompletedReplacement records that the resources of the replaced process group were removed and observes the
+// time from the detection of the replacement until the removal. Process groups without a removal reason were not
+// replaced by the operator and will be ignored.
+func RecordCompletedReplacement(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus) {
+	removed := processGroup.RemovalPhases.GetTimestamp(fdbv1beta2.RemovalPhaseRemoved)
+	if processGroup.RemovalReason == nil || removed == nil {
+		return
+	}
+
+	labels := []string{cluster.Namespace, cluster.Name, string(processGroup.ProcessClass), getRemovalReasonLabel(processGroup)}
+	replacementsCompleted.WithLabelValues(labels...).Inc()
+
+	detected := getReplacementDetectionTime(processGroup)
+	if detected.IsZero() {
+		return
+	}
+
+	replacementDuration.WithLabelValues(labels...).Observe(removed.Sub(detected).Seconds())
+}
+
+// getReplacementDetectionTime returns the time when the need for the replacement of the process group was detected.
+// This is the time of the earliest condition of the process group, e.g. the IncorrectPodSpec or the PodFailing
+// condition, or the time when the process group was marked for removal, if no condition is older.
+func getReplacementDetectionTime(processGroup *fdbv1beta2.ProcessGroupStatus) time.Time {
+	if processGroup.RemovalTimestamp.IsZero() {
+		return time.Time{}
+	}
+
+	detected := processGroup.RemovalTimestamp.Time
+	for _, condition := range processGroup.ProcessGroupConditions {
+		conditionTime := time.Unix(condition.Timestamp, 0)
+		if conditionTime.Before(detected) {
+			detected = conditionTime
+		}
+	}
+
+	return detected
+}
+
+// GetPendingReplacements returns the count of process groups per process class and removal reason that are marked for
+// removal and whose resources are not yet removed.
+func GetPendingReplacements(cluster *fdbv1beta2.FoundationDBCluster) map[fdbv1beta2.ProcessClass]map[string]int {
+	pending := map[fdbv1beta2.ProcessClass]map[string]int{}
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if !processGroup.IsMarkedForRemoval() || processGroup.RemovalPhases.GetTimestamp(fdbv1beta2.RemovalPhaseRemoved) != nil {
+			continue
+		}
+
+		if _, ok := pending[processGroup.ProcessClass]; !ok {
+			pending[processGroup.ProcessClass] = map[string]int{}
+		}
+
+		pending[processGroup.ProcessClass][getRemovalReasonLabel(processGroup)]++
+	}
+
+	return pending
+}
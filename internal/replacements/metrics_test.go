@@ -0,0 +1,136 @@
+/*
+ * metrics_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replacements
+
+import (
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("replacement metrics", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var now time.Time
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		now = time.Now().Truncate(time.Second)
+		cluster.Status.ProcessGroups = []*fdbv1beta2.ProcessGroupStatus{
+			{
+				ProcessGroupID:   "storage-1",
+				ProcessClass:     fdbv1beta2.ProcessClassStorage,
+				RemovalTimestamp: &metav1.Time{Time: now.Add(-10 * time.Minute)},
+				RemovalReason:    newRemovalReason(fdbv1beta2.RemovalReasonProcessGroupFailed, "process group has failed"),
+				ProcessGroupConditions: []*fdbv1beta2.ProcessGroupCondition{
+					{
+						ProcessGroupConditionType: fdbv1beta2.MissingProcesses,
+						Timestamp:                 now.Add(-30 * time.Minute).Unix(),
+					},
+				},
+			},
+			{
+				ProcessGroupID:   "storage-2",
+				ProcessClass:     fdbv1beta2.ProcessClassStorage,
+				RemovalTimestamp: &metav1.Time{Time: now.Add(-5 * time.Minute)},
+			},
+			{
+				ProcessGroupID: "log-1",
+				ProcessClass:   fdbv1beta2.ProcessClassLog,
+			},
+		}
+	})
+
+	When("getting the detection time of a replacement", func() {
+		It("should return the time of the earliest condition", func() {
+			Expect(getReplacementDetectionTime(cluster.Status.ProcessGroups[0])).To(BeTemporally("==", now.Add(-30*time.Minute)))
+		})
+
+		It("should return the removal timestamp if no condition is older", func() {
+			Expect(getReplacementDetectionTime(cluster.Status.ProcessGroups[1])).To(BeTemporally("==", now.Add(-5*time.Minute)))
+		})
+
+		It("should return the zero time if the process group is not marked for removal", func() {
+			Expect(getReplacementDetectionTime(cluster.Status.ProcessGroups[2])).To(BeZero())
+		})
+	})
+
+	When("getting the pending replacements", func() {
+		It("should return the process groups marked for removal by process class and reason", func() {
+			Expect(GetPendingReplacements(cluster)).To(Equal(map[fdbv1beta2.ProcessClass]map[string]int{
+				fdbv1beta2.ProcessClassStorage: {
+					string(fdbv1beta2.RemovalReasonProcessGroupFailed): 1,
+					unknownRemovalReason: 1,
+				},
+			}))
+		})
+
+		When("the resources of a process group were removed", func() {
+			BeforeEach(func() {
+				cluster.Status.ProcessGroups[0].MarkAsRemoved()
+			})
+
+			It("should not count the process group as pending", func() {
+				Expect(GetPendingReplacements(cluster)).To(Equal(map[fdbv1beta2.ProcessClass]map[string]int{
+					fdbv1beta2.ProcessClassStorage: {
+						unknownRemovalReason: 1,
+					},
+				}))
+			})
+		})
+	})
+
+	When("recording a completed replacement", func() {
+		var completedBefore float64
+
+		BeforeEach(func() {
+			completedBefore = testutil.ToFloat64(replacementsCompleted.WithLabelValues(cluster.Namespace, cluster.Name, string(fdbv1beta2.ProcessClassStorage), string(fdbv1beta2.RemovalReasonProcessGroupFailed)))
+		})
+
+		It("should only count replaced process groups whose resources were removed", func() {
+			RecordCompletedReplacement(cluster, cluster.Status.ProcessGroups[0])
+			Expect(testutil.ToFloat64(replacementsCompleted.WithLabelValues(cluster.Namespace, cluster.Name, string(fdbv1beta2.ProcessClassStorage), string(fdbv1beta2.RemovalReasonProcessGroupFailed)))).To(Equal(completedBefore))
+
+			cluster.Status.ProcessGroups[0].MarkAsRemoved()
+			RecordCompletedReplacement(cluster, cluster.Status.ProcessGroups[0])
+			Expect(testutil.ToFloat64(replacementsCompleted.WithLabelValues(cluster.Namespace, cluster.Name, string(fdbv1beta2.ProcessClassStorage), string(fdbv1beta2.RemovalReasonProcessGroupFailed)))).To(Equal(completedBefore + 1))
+		})
+
+		It("should ignore process groups without a removal reason", func() {
+			cluster.Status.ProcessGroups[1].MarkAsRemoved()
+			RecordCompletedReplacement(cluster, cluster.Status.ProcessGroups[1])
+			Expect(testutil.ToFloat64(replacementsCompleted.WithLabelValues(cluster.Namespace, cluster.Name, string(fdbv1beta2.ProcessClassStorage), unknownRemovalReason))).To(BeZero())
+		})
+	})
+
+	When("recording deferred replacements", func() {
+		It("should count every deferred process group", func() {
+			counter := replacementsDeferred.WithLabelValues(cluster.Namespace, cluster.Name, string(fdbv1beta2.ProcessClassStorage), string(deferralReasonReplacementLimit))
+			before := testutil.ToFloat64(counter)
+			recordDeferredReplacements(cluster, deferralReasonReplacementLimit, cluster.Status.ProcessGroups[:2]...)
+			Expect(testutil.ToFloat64(counter)).To(Equal(before + 2))
+		})
+	})
+})
@@ -0,0 +1,119 @@
+/*
+ * node_version_skew.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replacements
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	utilversion "k8s.io/apimachinery/pkg/util/version"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// processGroupNeedsRemovalForNodeVersion returns a removal reason if the node of the Pod runs an older version than
+// defined in the nodeVersionSkew settings of the cluster.
+func processGroupNeedsRemovalForNodeVersion(ctx context.Context, reader client.Reader, log logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, pod *corev1.Pod) (*fdbv1beta2.RemovalReason, error) {
+	if !cluster.UseNodeVersionSkewReplacements() || pod.Spec.NodeName == "" {
+		return nil, nil
+	}
+
+	node := &corev1.Node{}
+	err := reader.Get(ctx, client.ObjectKey{Name: pod.Spec.NodeName}, node)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			log.V(1).Info("Could not find node of Pod", "pod", pod.Name, "nodeName", pod.Spec.NodeName)
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	outdated := getOutdatedNodeVersions(log, cluster.Spec.AutomationOptions.Replacements.NodeVersionSkew, node)
+	if len(outdated) == 0 {
+		return nil, nil
+	}
+
+	return newRemovalReason(fdbv1beta2.RemovalReasonNodeVersionSkew, fmt.Sprintf("node %s is outdated: %s", node.Name, strings.Join(outdated, ", "))), nil
+}
+
+// getOutdatedNodeVersions returns a description of every version of the node that is older than the minimum version.
+func getOutdatedNodeVersions(log logr.Logger, options *fdbv1beta2.NodeVersionSkewOptions, node *corev1.Node) []string {
+	var outdated []string
+
+	if options.MinimumKubeletVersion != "" && isOutdatedVersion(log, node.Status.NodeInfo.KubeletVersion, options.MinimumKubeletVersion) {
+		outdated = append(outdated, fmt.Sprintf("kubelet version %s is older than %s", node.Status.NodeInfo.KubeletVersion, options.MinimumKubeletVersion))
+	}
+
+	if options.MinimumKernelVersion != "" && isOutdatedVersion(log, node.Status.NodeInfo.KernelVersion, options.MinimumKernelVersion) {
+		outdated = append(outdated, fmt.Sprintf("kernel version %s is older than %s", node.Status.NodeInfo.KernelVersion, options.MinimumKernelVersion))
+	}
+
+	if options.VersionLabel != "" && options.MinimumLabelVersion != "" {
+		labelVersion, ok := node.Labels[options.VersionLabel]
+		if !ok {
+			outdated = append(outdated, fmt.Sprintf("label %s is missing", options.VersionLabel))
+		} else if isOutdatedVersion(log, labelVersion, options.MinimumLabelVersion) {
+			outdated = append(outdated, fmt.Sprintf("label %s version %s is older than %s", options.VersionLabel, labelVersion, options.MinimumLabelVersion))
+		}
+	}
+
+	return outdated
+}
+
+// isOutdatedVersion returns true if the current version is older than the minimum version. If one of the versions
+// cannot be parsed, the version is not treated as outdated.
+func isOutdatedVersion(log logr.Logger, current string, minimum string) bool {
+	minimumVersion, err := utilversion.ParseGeneric(minimum)
+	if err != nil {
+		log.Info("Could not parse minimum node version", "version", minimum, "error", err.Error())
+		return false
+	}
+
+	currentVersion, err := utilversion.ParseGeneric(current)
+	if err != nil {
+		log.Info("Could not parse node version", "version", current, "error", err.Error())
+		return false
+	}
+
+	return !currentVersion.AtLeast(minimumVersion)
+}
+
+// getRemainingNodeVersionSkewReplacements returns the number of process groups that can be replaced because their node
+// is outdated, minus the in-flight replacements because of outdated nodes.
+func getRemainingNodeVersionSkewReplacements(cluster *fdbv1beta2.FoundationDBCluster) int {
+	remaining := cluster.GetMaxConcurrentNodeVersionSkewReplacements()
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if !processGroup.IsMarkedForRemoval() || processGroup.IsExcluded() {
+			continue
+		}
+
+		if processGroup.RemovalReason != nil && processGroup.RemovalReason.Type == fdbv1beta2.RemovalReasonNodeVersionSkew {
+			remaining--
+		}
+	}
+
+	return remaining
+}
@@ -0,0 +1,110 @@
+/*
+ * node_version_skew_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replacements
+
+import (
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("node_version_skew", func() {
+	DescribeTable("getting the outdated node versions", func(options *fdbv1beta2.NodeVersionSkewOptions, expected []string) {
+		node := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "node-1",
+				Labels: map[string]string{
+					"node.example.com/image-version": "2024.9.1",
+				},
+			},
+			Status: corev1.NodeStatus{
+				NodeInfo: corev1.NodeSystemInfo{
+					KubeletVersion: "v1.28.3",
+					KernelVersion:  "5.15.0-1051-azure",
+				},
+			},
+		}
+
+		Expect(getOutdatedNodeVersions(GinkgoLogr, options, node)).To(Equal(expected))
+	},
+		Entry("the node runs the minimum versions",
+			&fdbv1beta2.NodeVersionSkewOptions{
+				MinimumKubeletVersion: "v1.28.3",
+				MinimumKernelVersion:  "5.15.0",
+				VersionLabel:          "node.example.com/image-version",
+				MinimumLabelVersion:   "2024.9.1",
+			},
+			nil,
+		),
+		Entry("the kubelet is outdated",
+			&fdbv1beta2.NodeVersionSkewOptions{
+				MinimumKubeletVersion: "v1.29.0",
+			},
+			[]string{"kubelet version v1.28.3 is older than v1.29.0"},
+		),
+		Entry("the kernel and the label version are outdated",
+			&fdbv1beta2.NodeVersionSkewOptions{
+				MinimumKernelVersion: "6.1",
+				VersionLabel:         "node.example.com/image-version",
+				MinimumLabelVersion:  "2024.10.1",
+			},
+			[]string{"kernel version 5.15.0-1051-azure is older than 6.1", "label node.example.com/image-version version 2024.9.1 is older than 2024.10.1"},
+		),
+		Entry("the version label is missing",
+			&fdbv1beta2.NodeVersionSkewOptions{
+				VersionLabel:        "node.example.com/os-version",
+				MinimumLabelVersion: "1.0",
+			},
+			[]string{"label node.example.com/os-version is missing"},
+		),
+		Entry("the minimum version cannot be parsed",
+			&fdbv1beta2.NodeVersionSkewOptions{
+				MinimumKubeletVersion: "latest",
+			},
+			nil,
+		),
+	)
+
+	When("process groups are replaced because of outdated nodes", func() {
+		var cluster *fdbv1beta2.FoundationDBCluster
+
+		BeforeEach(func() {
+			cluster = &fdbv1beta2.FoundationDBCluster{}
+			cluster.Spec.AutomationOptions.Replacements.NodeVersionSkew = &fdbv1beta2.NodeVersionSkewOptions{
+				MinimumKubeletVersion: "v1.29.0",
+			}
+
+			for _, processGroupID := range []fdbv1beta2.ProcessGroupID{"storage-1", "storage-2", "storage-3"} {
+				processGroup := fdbv1beta2.NewProcessGroupStatus(processGroupID, fdbv1beta2.ProcessClassStorage, nil)
+				cluster.Status.ProcessGroups = append(cluster.Status.ProcessGroups, processGroup)
+			}
+
+			cluster.Status.ProcessGroups[0].MarkForRemovalWithReason(&fdbv1beta2.RemovalReason{Type: fdbv1beta2.RemovalReasonNodeVersionSkew})
+			cluster.Status.ProcessGroups[1].MarkForRemovalWithReason(&fdbv1beta2.RemovalReason{Type: fdbv1beta2.RemovalReasonPodSpecChanged})
+		})
+
+		It("should count the in-flight replacements", func() {
+			Expect(getRemainingNodeVersionSkewReplacements(cluster)).To(BeZero())
+		})
+	})
+})
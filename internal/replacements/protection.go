@@ -0,0 +1,113 @@
+/*
+ * protection.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replacements
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Protection contains the process groups that are protected against automatic replacements and records the protected
+// process groups that would have been replaced. All methods of the Protection are safe to be called on a nil
+// Protection, which protects no process groups.
+type Protection struct {
+	// protected contains the IDs of the protected process groups.
+	protected map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None
+	// lock protects the skipped process groups.
+	lock sync.Mutex
+	// skipped contains the IDs of the protected process groups that would have been replaced.
+	skipped map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None
+}
+
+// NewProtection creates a new Protection for the process groups in spec.protectedProcessGroups and the process groups
+// whose Pod has the foundationdb.org/replacement-protected annotation set to "true". The provided listOptions are used
+// to list the Pods of the cluster.
+func NewProtection(ctx context.Context, reader client.Reader, cluster *fdbv1beta2.FoundationDBCluster, listOptions ...client.ListOption) (*Protection, error) {
+	protection := &Protection{
+		protected: map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None{},
+		skipped:   map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None{},
+	}
+
+	for _, processGroupID := range cluster.Spec.ProtectedProcessGroups {
+		protection.protected[processGroupID] = fdbv1beta2.None{}
+	}
+
+	pods := &corev1.PodList{}
+	err := reader.List(ctx, pods, listOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pod := range pods.Items {
+		if pod.Annotations[fdbv1beta2.ReplacementProtectedAnnotation] != "true" {
+			continue
+		}
+
+		protection.protected[internal.GetProcessGroupIDFromMeta(cluster, pod.ObjectMeta)] = fdbv1beta2.None{}
+	}
+
+	return protection, nil
+}
+
+// Skip returns true if the process group is protected against replacements. The process group will be recorded as
+// skipped.
+func (protection *Protection) Skip(processGroup *fdbv1beta2.ProcessGroupStatus) bool {
+	if protection == nil {
+		return false
+	}
+
+	if _, ok := protection.protected[processGroup.ProcessGroupID]; !ok {
+		return false
+	}
+
+	protection.lock.Lock()
+	defer protection.lock.Unlock()
+	protection.skipped[processGroup.ProcessGroupID] = fdbv1beta2.None{}
+
+	return true
+}
+
+// Skipped returns the sorted IDs of the protected process groups that would have been replaced.
+func (protection *Protection) Skipped() []fdbv1beta2.ProcessGroupID {
+	if protection == nil {
+		return nil
+	}
+
+	protection.lock.Lock()
+	defer protection.lock.Unlock()
+
+	skipped := make([]fdbv1beta2.ProcessGroupID, 0, len(protection.skipped))
+	for processGroupID := range protection.skipped {
+		skipped = append(skipped, processGroupID)
+	}
+
+	sort.Slice(skipped, func(i, j int) bool {
+		return skipped[i] < skipped[j]
+	})
+
+	return skipped
+}
@@ -0,0 +1,73 @@
+/*
+ * protection_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replacements
+
+import (
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("protection", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var protection *Protection
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		Expect(internal.NormalizeClusterSpec(cluster, internal.DeprecationOptions{})).To(Succeed())
+		cluster.Spec.ProtectedProcessGroups = []fdbv1beta2.ProcessGroupID{"storage-1"}
+
+		for _, processGroupID := range []fdbv1beta2.ProcessGroupID{"storage-2", "storage-3"} {
+			processGroup := fdbv1beta2.NewProcessGroupStatus(processGroupID, fdbv1beta2.ProcessClassStorage, nil)
+			pod, err := internal.GetPod(cluster, processGroup)
+			Expect(err).NotTo(HaveOccurred())
+			if processGroupID == "storage-2" {
+				pod.Annotations[fdbv1beta2.ReplacementProtectedAnnotation] = "true"
+			}
+
+			Expect(k8sClient.Create(context.Background(), pod)).To(Succeed())
+		}
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		protection, err = NewProtection(context.Background(), k8sClient, cluster, internal.GetPodListOptions(cluster, "", "")...)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should protect the process groups from the spec and the annotation", func() {
+		Expect(protection.Skip(&fdbv1beta2.ProcessGroupStatus{ProcessGroupID: "storage-1"})).To(BeTrue())
+		Expect(protection.Skip(&fdbv1beta2.ProcessGroupStatus{ProcessGroupID: "storage-2"})).To(BeTrue())
+		Expect(protection.Skip(&fdbv1beta2.ProcessGroupStatus{ProcessGroupID: "storage-3"})).To(BeFalse())
+		Expect(protection.Skipped()).To(Equal([]fdbv1beta2.ProcessGroupID{"storage-1", "storage-2"}))
+	})
+
+	When("no protection is provided", func() {
+		It("should not protect any process group", func() {
+			var noProtection *Protection
+			Expect(noProtection.Skip(&fdbv1beta2.ProcessGroupStatus{ProcessGroupID: "storage-1"})).To(BeFalse())
+			Expect(noProtection.Skipped()).To(BeEmpty())
+		})
+	})
+})
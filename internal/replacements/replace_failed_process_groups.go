@@ -26,6 +26,7 @@ import (
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // getReplacementInformation will return the maximum allowed replacements for process group based replacements and the
@@ -40,13 +41,110 @@ func getReplacementInformation(cluster *fdbv1beta2.FoundationDBCluster, maxRepla
 		if processGroupStatus.IsMarkedForRemoval() && !processGroupStatus.IsExcluded() {
 			// Count all removals that are in-flight.
 			removalCount++
-			faultDomains[processGroupStatus.FaultDomain] = fdbv1beta2.None{}
+			faultDomains[getReplacementFaultDomain(cluster, processGroupStatus)] = fdbv1beta2.None{}
 		}
 	}
 
 	return maxReplacements - removalCount, faultDomains
 }
 
+// getReplacementFaultDomain returns the fault domain of the process group that is used to limit the replacements. If
+// the fault domain of the process group is not yet known, the fault domain will be derived from the fault domain key
+// of the cluster if possible.
+func getReplacementFaultDomain(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus) fdbv1beta2.FaultDomain {
+	if processGroup.FaultDomain != "" {
+		return processGroup.FaultDomain
+	}
+
+	switch cluster.Spec.FaultDomain.Key {
+	case fdbv1beta2.NoneFaultDomainKey:
+		// Every Pod is its own fault domain.
+		return fdbv1beta2.FaultDomain(processGroup.ProcessGroupID)
+	case "foundationdb.org/kubernetes-cluster":
+		// All Pods of this Kubernetes cluster share the same fault domain.
+		return fdbv1beta2.FaultDomain(cluster.Spec.FaultDomain.Value)
+	}
+
+	return processGroup.FaultDomain
+}
+
+// getRemainingReplacementsPerClass returns the number of replacements that are allowed for every process class with
+// a limit in maxConcurrentPerClass, minus the in-flight replacements of that process class. Process classes without a
+// limit are not part of the returned map.
+func getRemainingReplacementsPerClass(cluster *fdbv1beta2.FoundationDBCluster) map[fdbv1beta2.ProcessClass]int {
+	limits := cluster.Spec.AutomationOptions.Replacements.MaxConcurrentPerClass
+	if len(limits) == 0 {
+		return nil
+	}
+
+	remaining := make(map[fdbv1beta2.ProcessClass]int, len(limits))
+	for processClass, limit := range limits {
+		remaining[processClass] = limit
+	}
+
+	for _, processGroupStatus := range cluster.Status.ProcessGroups {
+		if !processGroupStatus.IsMarkedForRemoval() || processGroupStatus.IsExcluded() {
+			continue
+		}
+
+		if _, ok := remaining[processGroupStatus.ProcessClass]; ok {
+			remaining[processGroupStatus.ProcessClass]--
+		}
+	}
+
+	return remaining
+}
+
+// classRemovalAllowed will return true if another process group of the provided process class can be replaced.
+func classRemovalAllowed(remainingPerClass map[fdbv1beta2.ProcessClass]int, processClass fdbv1beta2.ProcessClass) bool {
+	remaining, ok := remainingPerClass[processClass]
+	return !ok || remaining > 0
+}
+
+// consumeClassRemoval reduces the remaining replacements of the provided process class, if the process class has a
+// limit.
+func consumeClassRemoval(remainingPerClass map[fdbv1beta2.ProcessClass]int, processClass fdbv1beta2.ProcessClass) {
+	if _, ok := remainingPerClass[processClass]; ok {
+		remainingPerClass[processClass]--
+	}
+}
+
+// stuckPodNeedsReplacement returns the PodStuck condition and the time since when the Pod is stuck, if the Pod of the
+// process group is stuck for longer than the failure detection window. Otherwise an empty condition and 0 will be
+// returned.
+func stuckPodNeedsReplacement(processGroup *fdbv1beta2.ProcessGroupStatus, failureDetectionWindowSeconds int) (fdbv1beta2.ProcessGroupConditionType, int64) {
+	if processGroup.IsMarkedForRemoval() {
+		return "", 0
+	}
+
+	stuckTime := processGroup.GetConditionTime(fdbv1beta2.PodStuck)
+	if stuckTime == nil {
+		return "", 0
+	}
+
+	if *stuckTime < time.Now().Add(-1*time.Duration(failureDetectionWindowSeconds)*time.Second).Unix() {
+		return fdbv1beta2.PodStuck, *stuckTime
+	}
+
+	return "", 0
+}
+
+// drainingNodeNeedsReplacement returns the NodeDraining condition and the time since when the node of the process
+// group is being drained. Those process groups are replaced without waiting for the failure detection time, so they
+// can be excluded before the Pod is evicted. Otherwise an empty condition and 0 will be returned.
+func drainingNodeNeedsReplacement(processGroup *fdbv1beta2.ProcessGroupStatus) (fdbv1beta2.ProcessGroupConditionType, int64) {
+	if processGroup.IsMarkedForRemoval() {
+		return "", 0
+	}
+
+	drainTime := processGroup.GetConditionTime(fdbv1beta2.NodeDraining)
+	if drainTime == nil {
+		return "", 0
+	}
+
+	return fdbv1beta2.NodeDraining, *drainTime
+}
+
 // removalAllowed will return true if the removal is allowed based on the clusters automatic replacement configuration.
 func removalAllowed(cluster *fdbv1beta2.FoundationDBCluster, maxReplacements int, faultDomainsWithReplacements map[fdbv1beta2.FaultDomain]fdbv1beta2.None, faultDomain fdbv1beta2.FaultDomain) bool {
 	if !cluster.FaultDomainBasedReplacements() {
@@ -119,9 +217,10 @@ func nodeTaintReplacementsAllowed(logger logr.Logger, cluster *fdbv1beta2.Founda
 }
 
 // ReplaceFailedProcessGroups flags failed processes groups for removal. The first return value will indicate if any
-// new Process Group was removed and the second return value will indicate if there are more Process Groups that
+// new Process Group was removed or if the PendingReplacementApproval condition of a Process Group was changed and the
+// second return value will indicate if there are more Process Groups that
 // needs a replacement, but the operator is not allowed to replace those as the limit is reached.
-func ReplaceFailedProcessGroups(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, hasDesiredFaultTolerance bool) (bool, bool) {
+func ReplaceFailedProcessGroups(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, hasDesiredFaultTolerance bool, protection *Protection, globalBudget *GlobalBudget) (bool, bool) {
 	// Automatic replacements are disabled or set to 0, so we don't have to check anything further
 	if !cluster.GetEnableAutomaticReplacements() || cluster.GetMaxConcurrentAutomaticReplacements() == 0 {
 		return false, false
@@ -139,11 +238,19 @@ func ReplaceFailedProcessGroups(logger logr.Logger, cluster *fdbv1beta2.Foundati
 	}
 
 	maxReplacements, faultDomainsWithReplacements := getReplacementInformation(cluster, cluster.GetMaxConcurrentAutomaticReplacements())
+	remainingPerClass := getRemainingReplacementsPerClass(cluster)
+	detectReplacementLoops := cluster.DetectReplacementLoops()
+	now := time.Now()
+	recentReplacements := getRecentFailedReplacements(cluster, now)
+	lastReplacements := getLastFailedReplacements(cluster)
 	hasReplacement := false
 	hasMoreFailedProcesses := false
 	localitiesUsedForExclusion := cluster.UseLocalitiesForExclusion()
 	failureDetectionTimeSeconds := cluster.GetFailureDetectionTimeSeconds()
 	taintReplacementTimeSeconds := cluster.GetTaintReplacementTimeSeconds()
+	detectStuckPods := cluster.DetectStuckPods()
+	failureDetectionWindowSeconds := cluster.GetFailureDetectionWindowSeconds()
+	replaceOnNodeDrain := cluster.ReplaceProcessGroupsOnNodeDrain()
 	// If the operator should not replace any process groups because of the NodeTaintReplacing condition, we simply set
 	// the replacement time to max int.
 	taintReplacementsAllowed, err := nodeTaintReplacementsAllowed(logger, cluster)
@@ -172,6 +279,14 @@ func ReplaceFailedProcessGroups(logger logr.Logger, cluster *fdbv1beta2.Foundati
 		}
 
 		failureCondition, failureTime := processGroup.NeedsReplacement(failureDetectionTimeSeconds, taintReplacementTimeSeconds)
+		if failureTime == 0 && detectStuckPods {
+			failureCondition, failureTime = stuckPodNeedsReplacement(processGroup, failureDetectionWindowSeconds)
+		}
+
+		if failureTime == 0 && replaceOnNodeDrain {
+			failureCondition, failureTime = drainingNodeNeedsReplacement(processGroup)
+		}
+
 		if failureTime == 0 {
 			continue
 		}
@@ -181,6 +296,48 @@ func ReplaceFailedProcessGroups(logger logr.Logger, cluster *fdbv1beta2.Foundati
 			continue
 		}
 
+		if protection.Skip(processGroup) {
+			logger.Info("Detected replace process group but cannot replace it because the process group is protected",
+				"processGroupID", processGroup.ProcessGroupID,
+				"failureCondition", failureCondition)
+			recordDeferredReplacements(cluster, deferralReasonProtected, processGroup)
+			continue
+		}
+
+		if detectReplacementLoops && inReplacementLoop(cluster, processGroup, recentReplacements) {
+			logger.Info("Detected replace process group but cannot replace it because of a replacement loop",
+				"processGroupID", processGroup.ProcessGroupID,
+				"processClass", processGroup.ProcessClass,
+				"failureCondition", failureCondition,
+				"recentReplacements", recentReplacements[processGroup.ProcessClass])
+			recordDeferredReplacements(cluster, deferralReasonReplacementLoop, processGroup)
+			continue
+		}
+
+		if detectReplacementLoops {
+			remainingBackoff := getRemainingReplacementBackoff(cluster, lastReplacements[processGroup.ProcessClass], recentReplacements[processGroup.ProcessClass], now)
+			if remainingBackoff > 0 {
+				logger.Info("Detected replace process group but cannot replace it because of the replacement backoff",
+					"processGroupID", processGroup.ProcessGroupID,
+					"processClass", processGroup.ProcessClass,
+					"failureCondition", failureCondition,
+					"remainingBackoff", remainingBackoff.String())
+				hasMoreFailedProcesses = true
+				recordDeferredReplacements(cluster, deferralReasonReplacementBackoff, processGroup)
+				continue
+			}
+		}
+
+		approved, approvalChanged := replacementApproved(logger, cluster, processGroup)
+		if approvalChanged {
+			hasReplacement = true
+		}
+
+		if !approved {
+			recordDeferredReplacements(cluster, deferralReasonApprovalRequired, processGroup)
+			continue
+		}
+
 		skipExclusion := false
 		// Only if localities are not used for exclusions we should be skipping the exclusion.
 		// Skipping the exclusion could lead to a race condition, which can be prevented if
@@ -192,6 +349,7 @@ func ReplaceFailedProcessGroups(logger logr.Logger, cluster *fdbv1beta2.Foundati
 					"Skip process group with missing address",
 					"processGroupID", processGroup.ProcessGroupID,
 					"failureTime", time.Unix(failureTime, 0).UTC().String())
+				recordDeferredReplacements(cluster, deferralReasonFaultTolerance, processGroup)
 				continue
 			}
 
@@ -205,31 +363,237 @@ func ReplaceFailedProcessGroups(logger logr.Logger, cluster *fdbv1beta2.Foundati
 				"failureTime", time.Unix(failureTime, 0).UTC().String())
 		}
 
+		// We are not allowed to replace additional process groups of this process class, but process groups of other
+		// process classes might still be replaced.
+		if !classRemovalAllowed(remainingPerClass, processGroup.ProcessClass) {
+			hasMoreFailedProcesses = true
+			logger.Info("Detected replace process group but cannot replace it because we hit the replacement limit of the process class",
+				"processGroupID", processGroup.ProcessGroupID,
+				"processClass", processGroup.ProcessClass,
+				"failureCondition", failureCondition,
+				"reason", fmt.Sprintf("automatic replacement detected failure time: %s", time.Unix(failureTime, 0).UTC().String()))
+			recordDeferredReplacements(cluster, deferralReasonProcessClassLimit, processGroup)
+			continue
+		}
+
 		// We are not allowed to replace additional process groups.
-		if !removalAllowed(cluster, maxReplacements, faultDomainsWithReplacements, processGroup.FaultDomain) {
+		faultDomain := getReplacementFaultDomain(cluster, processGroup)
+		if !removalAllowed(cluster, maxReplacements, faultDomainsWithReplacements, faultDomain) {
 			// If there are more processes that should be replaced but we hit the replace limit, we want to make sure
 			// the controller queues another reconciliation to eventually replace this failed process group.
 			hasMoreFailedProcesses = true
 			logger.Info("Detected replace process group but cannot replace it because we hit the replacement limit",
 				"processGroupID", processGroup.ProcessGroupID,
 				"failureCondition", failureCondition,
-				"faultDomain", processGroup.FaultDomain,
+				"faultDomain", faultDomain,
 				"reason", fmt.Sprintf("automatic replacement detected failure time: %s", time.Unix(failureTime, 0).UTC().String()))
+			recordDeferredReplacements(cluster, deferralReasonReplacementLimit, processGroup)
 			continue
 		}
 
+		if !globalBudget.Reserve(processGroup) {
+			hasMoreFailedProcesses = true
+			logger.Info("Detected replace process group but cannot replace it because we hit the limit of the global replacement budget",
+				"processGroupID", processGroup.ProcessGroupID,
+				"failureCondition", failureCondition,
+				"reason", fmt.Sprintf("automatic replacement detected failure time: %s", time.Unix(failureTime, 0).UTC().String()))
+			recordDeferredReplacements(cluster, deferralReasonGlobalBudget, processGroup)
+			break
+		}
+
 		logger.Info("Replace process group",
 			"processGroupID", processGroup.ProcessGroupID,
 			"failureCondition", failureCondition,
-			"faultDomain", processGroup.FaultDomain,
+			"faultDomain", faultDomain,
 			"reason", fmt.Sprintf("automatic replacement detected failure time: %s", time.Unix(failureTime, 0).UTC().String()))
 
-		processGroup.MarkForRemoval()
+		removalReasonType := fdbv1beta2.RemovalReasonProcessGroupFailed
+		if failureCondition == fdbv1beta2.NodeDraining {
+			removalReasonType = fdbv1beta2.RemovalReasonNodeDraining
+		}
+
+		processGroup.MarkForRemovalWithReason(&fdbv1beta2.RemovalReason{
+			Type:    removalReasonType,
+			Message: fmt.Sprintf("process group has condition %s since %s", failureCondition, time.Unix(failureTime, 0).UTC().String()),
+		})
+		recordStartedReplacement(cluster, processGroup)
 		hasReplacement = true
 		processGroup.ExclusionSkipped = skipExclusion
 		maxReplacements--
-		faultDomainsWithReplacements[processGroup.FaultDomain] = fdbv1beta2.None{}
+		consumeClassRemoval(remainingPerClass, processGroup.ProcessClass)
+		faultDomainsWithReplacements[faultDomain] = fdbv1beta2.None{}
+
+		// Replacements because of a node drain are expected and must not be counted as replacement loops.
+		if detectReplacementLoops && removalReasonType == fdbv1beta2.RemovalReasonProcessGroupFailed {
+			cluster.Status.FailedReplacementHistory = append(cluster.Status.FailedReplacementHistory, fdbv1beta2.FailedReplacement{
+				ProcessGroupID: processGroup.ProcessGroupID,
+				ProcessClass:   processGroup.ProcessClass,
+				Timestamp:      metav1.Time{Time: now},
+			})
+			recentReplacements[processGroup.ProcessClass]++
+			lastReplacements[processGroup.ProcessClass] = now
+		}
 	}
 
 	return hasReplacement, hasMoreFailedProcesses
 }
+
+// UpdateReplacementLoops updates the ReplacementLoop condition of the failed process groups. A failed process group
+// gets the condition if the limit of replacements for its process class within the replacement loop detection window
+// is reached. The condition is kept until it is acknowledged with the foundationdb.org/acknowledge-replacement-loop
+// annotation or the process group recovers. The returned bool indicates if any condition was changed.
+func UpdateReplacementLoops(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster) bool {
+	detectReplacementLoops := cluster.DetectReplacementLoops()
+	if !detectReplacementLoops {
+		cluster.Status.FailedReplacementHistory = nil
+	}
+
+	recentReplacements := getRecentFailedReplacements(cluster, time.Now())
+	changed := false
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		inLoop := detectReplacementLoops && !processGroup.IsMarkedForRemoval() && processGroup.HasFailureCondition() && inReplacementLoop(cluster, processGroup, recentReplacements)
+		hasCondition := processGroup.GetConditionTime(fdbv1beta2.ReplacementLoop) != nil
+		if inLoop == hasCondition {
+			continue
+		}
+
+		if inLoop {
+			logger.Info("Detected replacement loop for process group",
+				"processGroupID", processGroup.ProcessGroupID,
+				"processClass", processGroup.ProcessClass,
+				"recentReplacements", recentReplacements[processGroup.ProcessClass])
+		}
+
+		processGroup.UpdateCondition(fdbv1beta2.ReplacementLoop, inLoop)
+		changed = true
+	}
+
+	return changed
+}
+
+// getRecentFailedReplacements removes all failed replacements outside of the replacement loop detection window from
+// the cluster status and returns the number of replacements per process class that were not acknowledged.
+func getRecentFailedReplacements(cluster *fdbv1beta2.FoundationDBCluster, now time.Time) map[fdbv1beta2.ProcessClass]int {
+	windowStart := now.Add(-time.Duration(cluster.GetReplacementLoopWindowSeconds()) * time.Second)
+	acknowledgementTime := cluster.GetReplacementLoopAcknowledgementTime()
+	recentReplacements := map[fdbv1beta2.ProcessClass]int{}
+
+	history := make([]fdbv1beta2.FailedReplacement, 0, len(cluster.Status.FailedReplacementHistory))
+	for _, replacement := range cluster.Status.FailedReplacementHistory {
+		if replacement.Timestamp.Time.Before(windowStart) {
+			continue
+		}
+
+		history = append(history, replacement)
+		if !replacement.Timestamp.Time.After(acknowledgementTime) {
+			continue
+		}
+
+		recentReplacements[replacement.ProcessClass]++
+	}
+
+	if len(history) == 0 {
+		history = nil
+	}
+
+	cluster.Status.FailedReplacementHistory = history
+
+	return recentReplacements
+}
+
+// getLastFailedReplacements returns the time of the last replacement of a failed process group per process class that
+// was not acknowledged.
+func getLastFailedReplacements(cluster *fdbv1beta2.FoundationDBCluster) map[fdbv1beta2.ProcessClass]time.Time {
+	acknowledgementTime := cluster.GetReplacementLoopAcknowledgementTime()
+	lastReplacements := map[fdbv1beta2.ProcessClass]time.Time{}
+	for _, replacement := range cluster.Status.FailedReplacementHistory {
+		if !replacement.Timestamp.Time.After(acknowledgementTime) {
+			continue
+		}
+
+		if replacement.Timestamp.Time.After(lastReplacements[replacement.ProcessClass]) {
+			lastReplacements[replacement.ProcessClass] = replacement.Timestamp.Time
+		}
+	}
+
+	return lastReplacements
+}
+
+// getRemainingReplacementBackoff returns how long the operator must wait until the next failed process group of a
+// process class can be replaced. The backoff grows exponentially with the number of recent replacements of the process
+// class.
+func getRemainingReplacementBackoff(cluster *fdbv1beta2.FoundationDBCluster, lastReplacement time.Time, recentReplacements int, now time.Time) time.Duration {
+	if lastReplacement.IsZero() {
+		return 0
+	}
+
+	remaining := lastReplacement.Add(cluster.GetReplacementLoopBackoff(recentReplacements)).Sub(now)
+	if remaining < 0 {
+		return 0
+	}
+
+	return remaining
+}
+
+// inReplacementLoop returns true if the process group should not be replaced because of a replacement loop. This is
+// the case if the process group has the ReplacementLoop condition that was not acknowledged yet or if the limit of
+// replacements for its process class is reached.
+func inReplacementLoop(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus, recentReplacements map[fdbv1beta2.ProcessClass]int) bool {
+	loopDetected := processGroup.GetConditionTime(fdbv1beta2.ReplacementLoop)
+	if loopDetected != nil && cluster.GetReplacementLoopAcknowledgementTime().Unix() < *loopDetected {
+		return true
+	}
+
+	return recentReplacements[processGroup.ProcessClass] >= cluster.GetReplacementLoopMaxReplacements()
+}
+
+// CancelRecoveredReplacements cancels the removal of process groups that were automatically replaced because they
+// failed and have recovered since. Only process groups that were marked for removal within the grace period and whose
+// exclusion has not been started are considered. The returned bool indicates if the removal of at least one process
+// group was canceled.
+func CancelRecoveredReplacements(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster) bool {
+	if !cluster.CancelReplacementsOnRecovery() {
+		return false
+	}
+
+	gracePeriod := time.Duration(cluster.GetCancelOnRecoveryGracePeriodSeconds()) * time.Second
+	hasCanceledRemovals := false
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if !removalCancelable(processGroup, gracePeriod) {
+			continue
+		}
+
+		logger.Info("Cancel removal of recovered process group",
+			"processGroupID", processGroup.ProcessGroupID,
+			"removalTimestamp", processGroup.RemovalTimestamp.UTC().String())
+		processGroup.CancelRemoval()
+		hasCanceledRemovals = true
+	}
+
+	return hasCanceledRemovals
+}
+
+// removalCancelable returns true if the process group was marked for removal because it failed, has recovered since
+// and the removal can still be safely canceled.
+func removalCancelable(processGroup *fdbv1beta2.ProcessGroupStatus, gracePeriod time.Duration) bool {
+	if !processGroup.IsMarkedForRemoval() || processGroup.IsStandby() {
+		return false
+	}
+
+	// Only automatic replacements of failed process groups are canceled, other replacements must be done even if the
+	// process group is healthy.
+	if processGroup.RemovalReason == nil || processGroup.RemovalReason.Type != fdbv1beta2.RemovalReasonProcessGroupFailed {
+		return false
+	}
+
+	// If the exclusion was started, completed or skipped, the removal must be completed.
+	if processGroup.IsExcluded() || processGroup.GetConditionTime(fdbv1beta2.ProcessIsMarkedAsExcluded) != nil {
+		return false
+	}
+
+	if time.Since(processGroup.RemovalTimestamp.Time) > gracePeriod {
+		return false
+	}
+
+	return !processGroup.HasFailureCondition()
+}
@@ -21,9 +21,16 @@
 package replacements
 
 import (
+	"context"
+	"strconv"
+	"time"
+
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient/mock"
+	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 )
 
@@ -260,4 +267,726 @@ var _ = Describe("replace_failed_process_groups", func() {
 			true,
 		),
 	)
+
+	DescribeTable("getting the fault domain of a process group for replacements", func(faultDomain fdbv1beta2.FoundationDBClusterFaultDomain, processGroup *fdbv1beta2.ProcessGroupStatus, expected fdbv1beta2.FaultDomain) {
+		cluster := &fdbv1beta2.FoundationDBCluster{
+			Spec: fdbv1beta2.FoundationDBClusterSpec{
+				FaultDomain: faultDomain,
+			},
+		}
+
+		Expect(getReplacementFaultDomain(cluster, processGroup)).To(Equal(expected))
+	},
+		Entry("the fault domain of the process group is known",
+			fdbv1beta2.FoundationDBClusterFaultDomain{},
+			&fdbv1beta2.ProcessGroupStatus{ProcessGroupID: "storage-1", FaultDomain: "zone-1"},
+			fdbv1beta2.FaultDomain("zone-1"),
+		),
+		Entry("the fault domain of the process group is unknown and the default fault domain key is used",
+			fdbv1beta2.FoundationDBClusterFaultDomain{},
+			&fdbv1beta2.ProcessGroupStatus{ProcessGroupID: "storage-1"},
+			fdbv1beta2.FaultDomain(""),
+		),
+		Entry("the fault domain of the process group is unknown and the none fault domain key is used",
+			fdbv1beta2.FoundationDBClusterFaultDomain{Key: fdbv1beta2.NoneFaultDomainKey},
+			&fdbv1beta2.ProcessGroupStatus{ProcessGroupID: "storage-1"},
+			fdbv1beta2.FaultDomain("storage-1"),
+		),
+		Entry("the fault domain of the process group is unknown and the kubernetes cluster fault domain key is used",
+			fdbv1beta2.FoundationDBClusterFaultDomain{Key: "foundationdb.org/kubernetes-cluster", Value: "kc-1"},
+			&fdbv1beta2.ProcessGroupStatus{ProcessGroupID: "storage-1"},
+			fdbv1beta2.FaultDomain("kc-1"),
+		),
+	)
+
+	DescribeTable("checking if a process group with a stuck Pod needs a replacement", func(processGroup *fdbv1beta2.ProcessGroupStatus, expectedCondition fdbv1beta2.ProcessGroupConditionType, expectReplacement bool) {
+		condition, failureTime := stuckPodNeedsReplacement(processGroup, 600)
+		Expect(condition).To(Equal(expectedCondition))
+		if expectReplacement {
+			Expect(failureTime).NotTo(BeZero())
+		} else {
+			Expect(failureTime).To(BeZero())
+		}
+	},
+		Entry("the Pod is not stuck",
+			&fdbv1beta2.ProcessGroupStatus{ProcessGroupID: "storage-1"},
+			fdbv1beta2.ProcessGroupConditionType(""),
+			false,
+		),
+		Entry("the Pod is stuck for less than the failure detection window",
+			&fdbv1beta2.ProcessGroupStatus{
+				ProcessGroupID: "storage-1",
+				ProcessGroupConditions: []*fdbv1beta2.ProcessGroupCondition{
+					{
+						ProcessGroupConditionType: fdbv1beta2.PodStuck,
+						Timestamp:                 time.Now().Add(-1 * time.Minute).Unix(),
+					},
+				},
+			},
+			fdbv1beta2.ProcessGroupConditionType(""),
+			false,
+		),
+		Entry("the Pod is stuck for longer than the failure detection window",
+			&fdbv1beta2.ProcessGroupStatus{
+				ProcessGroupID: "storage-1",
+				ProcessGroupConditions: []*fdbv1beta2.ProcessGroupCondition{
+					{
+						ProcessGroupConditionType: fdbv1beta2.PodStuck,
+						Timestamp:                 time.Now().Add(-1 * time.Hour).Unix(),
+					},
+				},
+			},
+			fdbv1beta2.PodStuck,
+			true,
+		),
+		Entry("the process group is already marked for removal",
+			&fdbv1beta2.ProcessGroupStatus{
+				ProcessGroupID:   "storage-1",
+				RemovalTimestamp: &metav1.Time{Time: time.Now()},
+				ProcessGroupConditions: []*fdbv1beta2.ProcessGroupCondition{
+					{
+						ProcessGroupConditionType: fdbv1beta2.PodStuck,
+						Timestamp:                 time.Now().Add(-1 * time.Hour).Unix(),
+					},
+				},
+			},
+			fdbv1beta2.ProcessGroupConditionType(""),
+			false,
+		),
+	)
+
+	DescribeTable("getting the remaining replacements per process class", func(cluster *fdbv1beta2.FoundationDBCluster, expected map[fdbv1beta2.ProcessClass]int) {
+		Expect(getRemainingReplacementsPerClass(cluster)).To(Equal(expected))
+	},
+		Entry("no limits are defined",
+			&fdbv1beta2.FoundationDBCluster{},
+			nil,
+		),
+		Entry("limits are defined and no replacements are in-flight",
+			&fdbv1beta2.FoundationDBCluster{
+				Spec: fdbv1beta2.FoundationDBClusterSpec{
+					AutomationOptions: fdbv1beta2.FoundationDBClusterAutomationOptions{
+						Replacements: fdbv1beta2.AutomaticReplacementOptions{
+							MaxConcurrentPerClass: map[fdbv1beta2.ProcessClass]int{
+								fdbv1beta2.ProcessClassStorage:   1,
+								fdbv1beta2.ProcessClassStateless: 5,
+							},
+						},
+					},
+				},
+			},
+			map[fdbv1beta2.ProcessClass]int{
+				fdbv1beta2.ProcessClassStorage:   1,
+				fdbv1beta2.ProcessClassStateless: 5,
+			},
+		),
+		Entry("limits are defined and replacements are in-flight",
+			&fdbv1beta2.FoundationDBCluster{
+				Spec: fdbv1beta2.FoundationDBClusterSpec{
+					AutomationOptions: fdbv1beta2.FoundationDBClusterAutomationOptions{
+						Replacements: fdbv1beta2.AutomaticReplacementOptions{
+							MaxConcurrentPerClass: map[fdbv1beta2.ProcessClass]int{
+								fdbv1beta2.ProcessClassStorage:   1,
+								fdbv1beta2.ProcessClassStateless: 5,
+							},
+						},
+					},
+				},
+				Status: fdbv1beta2.FoundationDBClusterStatus{
+					ProcessGroups: []*fdbv1beta2.ProcessGroupStatus{
+						{
+							ProcessGroupID:   "storage-1",
+							ProcessClass:     fdbv1beta2.ProcessClassStorage,
+							RemovalTimestamp: &metav1.Time{Time: time.Now()},
+						},
+						{
+							ProcessGroupID:   "stateless-1",
+							ProcessClass:     fdbv1beta2.ProcessClassStateless,
+							RemovalTimestamp: &metav1.Time{Time: time.Now()},
+						},
+						{
+							ProcessGroupID:     "stateless-2",
+							ProcessClass:       fdbv1beta2.ProcessClassStateless,
+							RemovalTimestamp:   &metav1.Time{Time: time.Now()},
+							ExclusionTimestamp: &metav1.Time{Time: time.Now()},
+						},
+						{
+							ProcessGroupID:   "log-1",
+							ProcessClass:     fdbv1beta2.ProcessClassLog,
+							RemovalTimestamp: &metav1.Time{Time: time.Now()},
+						},
+					},
+				},
+			},
+			map[fdbv1beta2.ProcessClass]int{
+				fdbv1beta2.ProcessClassStorage:   0,
+				fdbv1beta2.ProcessClassStateless: 4,
+			},
+		),
+	)
+
+	When("replacing failed process groups with a limit for the process class", func() {
+		var cluster *fdbv1beta2.FoundationDBCluster
+		var hasReplacement, hasMoreFailedProcesses bool
+
+		BeforeEach(func() {
+			failureTime := time.Now().Add(-3 * time.Hour).Unix()
+			cluster = &fdbv1beta2.FoundationDBCluster{
+				Spec: fdbv1beta2.FoundationDBClusterSpec{
+					AutomationOptions: fdbv1beta2.FoundationDBClusterAutomationOptions{
+						Replacements: fdbv1beta2.AutomaticReplacementOptions{
+							Enabled:                   pointer.Bool(true),
+							MaxConcurrentReplacements: pointer.Int(5),
+							MaxConcurrentPerClass: map[fdbv1beta2.ProcessClass]int{
+								fdbv1beta2.ProcessClassStorage: 1,
+							},
+						},
+					},
+				},
+			}
+
+			processClasses := map[fdbv1beta2.ProcessGroupID]fdbv1beta2.ProcessClass{
+				"storage-1":   fdbv1beta2.ProcessClassStorage,
+				"storage-2":   fdbv1beta2.ProcessClassStorage,
+				"stateless-1": fdbv1beta2.ProcessClassStateless,
+				"stateless-2": fdbv1beta2.ProcessClassStateless,
+			}
+			for _, processGroupID := range []fdbv1beta2.ProcessGroupID{"storage-1", "storage-2", "stateless-1", "stateless-2"} {
+				processGroup := fdbv1beta2.NewProcessGroupStatus(processGroupID, processClasses[processGroupID], []string{"1.1.1.1"})
+				processGroup.ProcessGroupConditions = []*fdbv1beta2.ProcessGroupCondition{
+					{
+						ProcessGroupConditionType: fdbv1beta2.MissingProcesses,
+						Timestamp:                 failureTime,
+					},
+				}
+				cluster.Status.ProcessGroups = append(cluster.Status.ProcessGroups, processGroup)
+			}
+
+			hasReplacement, hasMoreFailedProcesses = ReplaceFailedProcessGroups(logr.Discard(), cluster, &fdbv1beta2.FoundationDBStatus{}, true, nil, nil)
+		})
+
+		It("should only replace one storage process group", func() {
+			Expect(hasReplacement).To(BeTrue())
+			Expect(hasMoreFailedProcesses).To(BeTrue())
+
+			var removed []fdbv1beta2.ProcessGroupID
+			for _, processGroup := range cluster.Status.ProcessGroups {
+				if processGroup.IsMarkedForRemoval() {
+					removed = append(removed, processGroup.ProcessGroupID)
+				}
+			}
+
+			Expect(removed).To(ConsistOf(fdbv1beta2.ProcessGroupID("storage-1"), fdbv1beta2.ProcessGroupID("stateless-1"), fdbv1beta2.ProcessGroupID("stateless-2")))
+		})
+	})
+
+	DescribeTable("canceling the removal of recovered process groups", func(cancelOnRecovery *fdbv1beta2.CancelOnRecoveryOptions, processGroup *fdbv1beta2.ProcessGroupStatus, expected bool) {
+		cluster := &fdbv1beta2.FoundationDBCluster{
+			Spec: fdbv1beta2.FoundationDBClusterSpec{
+				AutomationOptions: fdbv1beta2.FoundationDBClusterAutomationOptions{
+					Replacements: fdbv1beta2.AutomaticReplacementOptions{
+						CancelOnRecovery: cancelOnRecovery,
+					},
+				},
+			},
+			Status: fdbv1beta2.FoundationDBClusterStatus{
+				ProcessGroups: []*fdbv1beta2.ProcessGroupStatus{processGroup},
+			},
+		}
+
+		Expect(CancelRecoveredReplacements(GinkgoLogr, cluster)).To(Equal(expected))
+		Expect(processGroup.IsMarkedForRemoval()).To(Equal(!expected))
+	},
+		Entry("cancel on recovery is disabled",
+			nil,
+			&fdbv1beta2.ProcessGroupStatus{
+				ProcessGroupID:   "storage-1",
+				RemovalTimestamp: &metav1.Time{Time: time.Now()},
+				RemovalReason:    &fdbv1beta2.RemovalReason{Type: fdbv1beta2.RemovalReasonProcessGroupFailed},
+			},
+			false,
+		),
+		Entry("the process group recovered within the grace period",
+			&fdbv1beta2.CancelOnRecoveryOptions{Enabled: pointer.Bool(true)},
+			&fdbv1beta2.ProcessGroupStatus{
+				ProcessGroupID:   "storage-1",
+				RemovalTimestamp: &metav1.Time{Time: time.Now()},
+				RemovalReason:    &fdbv1beta2.RemovalReason{Type: fdbv1beta2.RemovalReasonProcessGroupFailed},
+			},
+			true,
+		),
+		Entry("the process group is still failing",
+			&fdbv1beta2.CancelOnRecoveryOptions{Enabled: pointer.Bool(true)},
+			&fdbv1beta2.ProcessGroupStatus{
+				ProcessGroupID:   "storage-1",
+				RemovalTimestamp: &metav1.Time{Time: time.Now()},
+				RemovalReason:    &fdbv1beta2.RemovalReason{Type: fdbv1beta2.RemovalReasonProcessGroupFailed},
+				ProcessGroupConditions: []*fdbv1beta2.ProcessGroupCondition{
+					fdbv1beta2.NewProcessGroupCondition(fdbv1beta2.MissingProcesses),
+				},
+			},
+			false,
+		),
+		Entry("the process group recovered after the grace period",
+			&fdbv1beta2.CancelOnRecoveryOptions{Enabled: pointer.Bool(true), GracePeriodSeconds: pointer.Int(60)},
+			&fdbv1beta2.ProcessGroupStatus{
+				ProcessGroupID:   "storage-1",
+				RemovalTimestamp: &metav1.Time{Time: time.Now().Add(-5 * time.Minute)},
+				RemovalReason:    &fdbv1beta2.RemovalReason{Type: fdbv1beta2.RemovalReasonProcessGroupFailed},
+			},
+			false,
+		),
+		Entry("the process group was replaced because it was misconfigured",
+			&fdbv1beta2.CancelOnRecoveryOptions{Enabled: pointer.Bool(true)},
+			&fdbv1beta2.ProcessGroupStatus{
+				ProcessGroupID:   "storage-1",
+				RemovalTimestamp: &metav1.Time{Time: time.Now()},
+				RemovalReason:    &fdbv1beta2.RemovalReason{Type: fdbv1beta2.RemovalReasonNodeSelectorChanged},
+			},
+			false,
+		),
+		Entry("the exclusion of the process group was started",
+			&fdbv1beta2.CancelOnRecoveryOptions{Enabled: pointer.Bool(true)},
+			&fdbv1beta2.ProcessGroupStatus{
+				ProcessGroupID:   "storage-1",
+				RemovalTimestamp: &metav1.Time{Time: time.Now()},
+				RemovalReason:    &fdbv1beta2.RemovalReason{Type: fdbv1beta2.RemovalReasonProcessGroupFailed},
+				ProcessGroupConditions: []*fdbv1beta2.ProcessGroupCondition{
+					fdbv1beta2.NewProcessGroupCondition(fdbv1beta2.ProcessIsMarkedAsExcluded),
+				},
+			},
+			false,
+		),
+		Entry("the exclusion of the process group was skipped",
+			&fdbv1beta2.CancelOnRecoveryOptions{Enabled: pointer.Bool(true)},
+			&fdbv1beta2.ProcessGroupStatus{
+				ProcessGroupID:   "storage-1",
+				RemovalTimestamp: &metav1.Time{Time: time.Now()},
+				RemovalReason:    &fdbv1beta2.RemovalReason{Type: fdbv1beta2.RemovalReasonProcessGroupFailed},
+				ExclusionSkipped: true,
+			},
+			false,
+		),
+	)
+
+	When("detecting replacement loops", func() {
+		var cluster *fdbv1beta2.FoundationDBCluster
+		var failureTime int64
+
+		BeforeEach(func() {
+			failureTime = time.Now().Add(-3 * time.Hour).Unix()
+			cluster = &fdbv1beta2.FoundationDBCluster{
+				Spec: fdbv1beta2.FoundationDBClusterSpec{
+					AutomationOptions: fdbv1beta2.FoundationDBClusterAutomationOptions{
+						Replacements: fdbv1beta2.AutomaticReplacementOptions{
+							MaxConcurrentReplacements: pointer.Int(5),
+							ReplacementLoopDetection: &fdbv1beta2.ReplacementLoopDetectionOptions{
+								MaxReplacements: pointer.Int(2),
+							},
+						},
+					},
+				},
+				Status: fdbv1beta2.FoundationDBClusterStatus{
+					FailedReplacementHistory: []fdbv1beta2.FailedReplacement{
+						{
+							ProcessGroupID: "storage-10",
+							ProcessClass:   fdbv1beta2.ProcessClassStorage,
+							Timestamp:      metav1.Time{Time: time.Now().Add(-2 * time.Hour)},
+						},
+						{
+							ProcessGroupID: "storage-11",
+							ProcessClass:   fdbv1beta2.ProcessClassStorage,
+							Timestamp:      metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+						},
+					},
+				},
+			}
+
+			processClasses := map[fdbv1beta2.ProcessGroupID]fdbv1beta2.ProcessClass{
+				"storage-1": fdbv1beta2.ProcessClassStorage,
+				"storage-2": fdbv1beta2.ProcessClassStorage,
+				"log-1":     fdbv1beta2.ProcessClassLog,
+			}
+
+			for _, processGroupID := range []fdbv1beta2.ProcessGroupID{"storage-1", "storage-2", "log-1"} {
+				processGroup := fdbv1beta2.NewProcessGroupStatus(processGroupID, processClasses[processGroupID], []string{"1.1.1.1"})
+				processGroup.ProcessGroupConditions = []*fdbv1beta2.ProcessGroupCondition{
+					{
+						ProcessGroupConditionType: fdbv1beta2.MissingProcesses,
+						Timestamp:                 failureTime,
+					},
+				}
+				cluster.Status.ProcessGroups = append(cluster.Status.ProcessGroups, processGroup)
+			}
+		})
+
+		When("replacing the failed process groups", func() {
+			var hasReplacement bool
+
+			JustBeforeEach(func() {
+				hasReplacement, _ = ReplaceFailedProcessGroups(GinkgoLogr, cluster, &fdbv1beta2.FoundationDBStatus{}, true, nil, nil)
+			})
+
+			It("should only replace one storage process group", func() {
+				Expect(hasReplacement).To(BeTrue())
+				var replaced []fdbv1beta2.ProcessGroupID
+				for _, processGroup := range cluster.Status.ProcessGroups {
+					if processGroup.IsMarkedForRemoval() {
+						replaced = append(replaced, processGroup.ProcessGroupID)
+					}
+				}
+
+				Expect(replaced).To(ConsistOf(fdbv1beta2.ProcessGroupID("storage-1"), fdbv1beta2.ProcessGroupID("log-1")))
+				Expect(cluster.Status.FailedReplacementHistory).To(HaveLen(3))
+			})
+
+			When("a replacement backoff is configured", func() {
+				BeforeEach(func() {
+					cluster.Spec.AutomationOptions.Replacements.ReplacementLoopDetection.BackoffSeconds = pointer.Int(900)
+				})
+
+				It("should only replace process groups of process classes without a backoff", func() {
+					Expect(hasReplacement).To(BeTrue())
+					var replaced []fdbv1beta2.ProcessGroupID
+					for _, processGroup := range cluster.Status.ProcessGroups {
+						if processGroup.IsMarkedForRemoval() {
+							replaced = append(replaced, processGroup.ProcessGroupID)
+						}
+					}
+
+					Expect(replaced).To(ConsistOf(fdbv1beta2.ProcessGroupID("log-1")))
+					Expect(cluster.Status.FailedReplacementHistory).To(HaveLen(2))
+				})
+			})
+
+			When("the replacement loop was acknowledged", func() {
+				BeforeEach(func() {
+					cluster.Annotations = map[string]string{
+						fdbv1beta2.ReplacementLoopAcknowledgementAnnotation: strconv.FormatInt(time.Now().Add(-time.Minute).Unix(), 10),
+					}
+				})
+
+				It("should replace all failed process groups", func() {
+					Expect(hasReplacement).To(BeTrue())
+					for _, processGroup := range cluster.Status.ProcessGroups {
+						Expect(processGroup.IsMarkedForRemoval()).To(BeTrue())
+					}
+				})
+			})
+		})
+
+		DescribeTable("getting the remaining replacement backoff", func(backoffSeconds int, lastReplacement time.Duration, recentReplacements int, expected time.Duration) {
+			now := time.Now()
+			cluster.Spec.AutomationOptions.Replacements.ReplacementLoopDetection.BackoffSeconds = pointer.Int(backoffSeconds)
+			Expect(getRemainingReplacementBackoff(cluster, now.Add(-lastReplacement), recentReplacements, now)).To(Equal(expected))
+		},
+			Entry("no backoff is configured", 0, time.Minute, 1, time.Duration(0)),
+			Entry("the backoff has passed", 60, 2*time.Minute, 1, time.Duration(0)),
+			Entry("the backoff after the first replacement", 60, 30*time.Second, 1, 30*time.Second),
+			Entry("the backoff is doubled with every replacement", 60, 30*time.Second, 3, 210*time.Second),
+			Entry("the backoff is capped at the window", 600, 0*time.Second, 10, time.Hour),
+		)
+
+		When("updating the replacement loop conditions", func() {
+			var changed bool
+
+			BeforeEach(func() {
+				cluster.Status.FailedReplacementHistory = append(cluster.Status.FailedReplacementHistory, fdbv1beta2.FailedReplacement{
+					ProcessGroupID: "storage-12",
+					ProcessClass:   fdbv1beta2.ProcessClassStorage,
+					Timestamp:      metav1.Time{Time: time.Now().Add(-5 * time.Minute)},
+				})
+			})
+
+			JustBeforeEach(func() {
+				changed = UpdateReplacementLoops(GinkgoLogr, cluster)
+			})
+
+			It("should add the condition to the failed storage process groups", func() {
+				Expect(changed).To(BeTrue())
+				Expect(cluster.Status.ProcessGroups[0].GetConditionTime(fdbv1beta2.ReplacementLoop)).NotTo(BeNil())
+				Expect(cluster.Status.ProcessGroups[1].GetConditionTime(fdbv1beta2.ReplacementLoop)).NotTo(BeNil())
+				Expect(cluster.Status.ProcessGroups[2].GetConditionTime(fdbv1beta2.ReplacementLoop)).To(BeNil())
+				Expect(cluster.Status.FailedReplacementHistory).To(HaveLen(2))
+			})
+
+			When("the replacement loop was acknowledged", func() {
+				BeforeEach(func() {
+					cluster.Status.ProcessGroups[0].ProcessGroupConditions = append(cluster.Status.ProcessGroups[0].ProcessGroupConditions, &fdbv1beta2.ProcessGroupCondition{
+						ProcessGroupConditionType: fdbv1beta2.ReplacementLoop,
+						Timestamp:                 time.Now().Add(-time.Minute).Unix(),
+					})
+					cluster.Annotations = map[string]string{
+						fdbv1beta2.ReplacementLoopAcknowledgementAnnotation: strconv.FormatInt(time.Now().Unix(), 10),
+					}
+				})
+
+				It("should remove the condition", func() {
+					Expect(changed).To(BeTrue())
+					for _, processGroup := range cluster.Status.ProcessGroups {
+						Expect(processGroup.GetConditionTime(fdbv1beta2.ReplacementLoop)).To(BeNil())
+					}
+				})
+			})
+
+			When("the replacement loop detection is disabled", func() {
+				BeforeEach(func() {
+					cluster.Spec.AutomationOptions.Replacements.ReplacementLoopDetection = nil
+				})
+
+				It("should not add any condition", func() {
+					Expect(changed).To(BeFalse())
+					Expect(cluster.Status.FailedReplacementHistory).To(BeEmpty())
+					for _, processGroup := range cluster.Status.ProcessGroups {
+						Expect(processGroup.GetConditionTime(fdbv1beta2.ReplacementLoop)).To(BeNil())
+					}
+				})
+			})
+		})
+	})
+
+	When("process groups run on drained nodes", func() {
+		var cluster *fdbv1beta2.FoundationDBCluster
+		var hasReplacement, hasMoreFailedProcesses bool
+
+		BeforeEach(func() {
+			cluster = &fdbv1beta2.FoundationDBCluster{
+				Spec: fdbv1beta2.FoundationDBClusterSpec{
+					AutomationOptions: fdbv1beta2.FoundationDBClusterAutomationOptions{
+						Replacements: fdbv1beta2.AutomaticReplacementOptions{
+							Enabled:                   pointer.Bool(true),
+							MaxConcurrentReplacements: pointer.Int(1),
+							ReplacementLoopDetection: &fdbv1beta2.ReplacementLoopDetectionOptions{
+								MaxReplacements: pointer.Int(1),
+							},
+							NodeDrain: &fdbv1beta2.NodeDrainReplacementOptions{
+								Enabled: pointer.Bool(true),
+							},
+						},
+					},
+				},
+			}
+
+			for _, processGroupID := range []fdbv1beta2.ProcessGroupID{"storage-1", "storage-2", "storage-3"} {
+				processGroup := fdbv1beta2.NewProcessGroupStatus(processGroupID, fdbv1beta2.ProcessClassStorage, []string{"1.1.1.1"})
+				cluster.Status.ProcessGroups = append(cluster.Status.ProcessGroups, processGroup)
+			}
+
+			cluster.Status.ProcessGroups[0].UpdateCondition(fdbv1beta2.NodeDraining, true)
+			cluster.Status.ProcessGroups[1].UpdateCondition(fdbv1beta2.NodeDraining, true)
+		})
+
+		JustBeforeEach(func() {
+			hasReplacement, hasMoreFailedProcesses = ReplaceFailedProcessGroups(GinkgoLogr, cluster, &fdbv1beta2.FoundationDBStatus{}, true, nil, nil)
+		})
+
+		It("should replace one process group without waiting for the failure detection time", func() {
+			Expect(hasReplacement).To(BeTrue())
+			Expect(hasMoreFailedProcesses).To(BeTrue())
+			Expect(cluster.Status.ProcessGroups[0].IsMarkedForRemoval()).To(BeTrue())
+			Expect(cluster.Status.ProcessGroups[0].RemovalReason).NotTo(BeNil())
+			Expect(cluster.Status.ProcessGroups[0].RemovalReason.Type).To(Equal(fdbv1beta2.RemovalReasonNodeDraining))
+			Expect(cluster.Status.ProcessGroups[1].IsMarkedForRemoval()).To(BeFalse())
+			Expect(cluster.Status.ProcessGroups[2].IsMarkedForRemoval()).To(BeFalse())
+		})
+
+		It("should not count the replacement as replacement of a failed process group", func() {
+			Expect(cluster.Status.FailedReplacementHistory).To(BeEmpty())
+		})
+
+		When("the node drain replacements are disabled", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.Replacements.NodeDrain.Enabled = pointer.Bool(false)
+			})
+
+			It("should not replace any process group", func() {
+				Expect(hasReplacement).To(BeFalse())
+				Expect(hasMoreFailedProcesses).To(BeFalse())
+				for _, processGroup := range cluster.Status.ProcessGroups {
+					Expect(processGroup.IsMarkedForRemoval()).To(BeFalse())
+				}
+			})
+		})
+	})
+
+	When("a process group is protected", func() {
+		var cluster *fdbv1beta2.FoundationDBCluster
+		var protection *Protection
+		var hasReplacement bool
+
+		BeforeEach(func() {
+			cluster = &fdbv1beta2.FoundationDBCluster{
+				Spec: fdbv1beta2.FoundationDBClusterSpec{
+					AutomationOptions: fdbv1beta2.FoundationDBClusterAutomationOptions{
+						Replacements: fdbv1beta2.AutomaticReplacementOptions{
+							MaxConcurrentReplacements: pointer.Int(5),
+						},
+					},
+					ProtectedProcessGroups: []fdbv1beta2.ProcessGroupID{"storage-1"},
+				},
+			}
+
+			for _, processGroupID := range []fdbv1beta2.ProcessGroupID{"storage-1", "storage-2"} {
+				processGroup := fdbv1beta2.NewProcessGroupStatus(processGroupID, fdbv1beta2.ProcessClassStorage, []string{"1.1.1.1"})
+				processGroup.ProcessGroupConditions = []*fdbv1beta2.ProcessGroupCondition{
+					{
+						ProcessGroupConditionType: fdbv1beta2.MissingProcesses,
+						Timestamp:                 time.Now().Add(-3 * time.Hour).Unix(),
+					},
+				}
+				cluster.Status.ProcessGroups = append(cluster.Status.ProcessGroups, processGroup)
+			}
+
+			var err error
+			protection, err = NewProtection(context.Background(), k8sClient, cluster)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			hasReplacement, _ = ReplaceFailedProcessGroups(GinkgoLogr, cluster, &fdbv1beta2.FoundationDBStatus{}, true, protection, nil)
+		})
+
+		It("should only replace the unprotected process group", func() {
+			Expect(hasReplacement).To(BeTrue())
+			Expect(cluster.Status.ProcessGroups[0].IsMarkedForRemoval()).To(BeFalse())
+			Expect(cluster.Status.ProcessGroups[1].IsMarkedForRemoval()).To(BeTrue())
+			Expect(protection.Skipped()).To(ConsistOf(fdbv1beta2.ProcessGroupID("storage-1")))
+		})
+	})
+
+	When("a global replacement budget is defined", func() {
+		var cluster *fdbv1beta2.FoundationDBCluster
+		var hasReplacement, hasMoreFailedProcesses bool
+
+		BeforeEach(func() {
+			mock.ClearMockLockClients()
+			cluster = &fdbv1beta2.FoundationDBCluster{
+				Spec: fdbv1beta2.FoundationDBClusterSpec{
+					AutomationOptions: fdbv1beta2.FoundationDBClusterAutomationOptions{
+						Replacements: fdbv1beta2.AutomaticReplacementOptions{
+							MaxConcurrentReplacements: pointer.Int(5),
+							GlobalBudget: &fdbv1beta2.GlobalReplacementBudgetOptions{
+								MaxConcurrentReplacements: pointer.Int(1),
+							},
+						},
+					},
+					LockOptions: fdbv1beta2.LockOptions{
+						DisableLocks: pointer.Bool(false),
+					},
+				},
+			}
+
+			for _, processGroupID := range []fdbv1beta2.ProcessGroupID{"storage-1", "storage-2"} {
+				processGroup := fdbv1beta2.NewProcessGroupStatus(processGroupID, fdbv1beta2.ProcessClassStorage, []string{"1.1.1.1"})
+				processGroup.ProcessGroupConditions = []*fdbv1beta2.ProcessGroupCondition{
+					{
+						ProcessGroupConditionType: fdbv1beta2.MissingProcesses,
+						Timestamp:                 time.Now().Add(-3 * time.Hour).Unix(),
+					},
+				}
+				cluster.Status.ProcessGroups = append(cluster.Status.ProcessGroups, processGroup)
+			}
+		})
+
+		JustBeforeEach(func() {
+			globalBudget, err := NewGlobalBudget(GinkgoLogr, cluster, mock.NewMockLockClientUncast(cluster))
+			Expect(err).NotTo(HaveOccurred())
+			hasReplacement, hasMoreFailedProcesses = ReplaceFailedProcessGroups(GinkgoLogr, cluster, &fdbv1beta2.FoundationDBStatus{}, true, nil, globalBudget)
+		})
+
+		It("should only replace one process group", func() {
+			Expect(hasReplacement).To(BeTrue())
+			Expect(hasMoreFailedProcesses).To(BeTrue())
+			Expect(cluster.Status.ProcessGroups[0].IsMarkedForRemoval()).To(BeTrue())
+			Expect(cluster.Status.ProcessGroups[1].IsMarkedForRemoval()).To(BeFalse())
+		})
+
+		When("another operator instance exhausted the global replacement budget", func() {
+			BeforeEach(func() {
+				remote := cluster.DeepCopy()
+				remote.Name = "remote"
+				remote.Spec.ProcessGroupIDPrefix = "remote"
+				Expect(mock.NewMockLockClientUncast(remote).UpdateReplacements([]fdbv1beta2.ProcessGroupID{"remote-storage-1"}, time.Hour)).To(Succeed())
+			})
+
+			It("should not replace any process group", func() {
+				Expect(hasReplacement).To(BeFalse())
+				Expect(hasMoreFailedProcesses).To(BeTrue())
+			})
+		})
+	})
+
+	When("replacements require an approval", func() {
+		var cluster *fdbv1beta2.FoundationDBCluster
+		var hasReplacement bool
+
+		BeforeEach(func() {
+			cluster = &fdbv1beta2.FoundationDBCluster{
+				Spec: fdbv1beta2.FoundationDBClusterSpec{
+					AutomationOptions: fdbv1beta2.FoundationDBClusterAutomationOptions{
+						Replacements: fdbv1beta2.AutomaticReplacementOptions{
+							MaxConcurrentReplacements: pointer.Int(5),
+							RequireApproval:           pointer.Bool(true),
+						},
+					},
+				},
+			}
+
+			for _, processGroupID := range []fdbv1beta2.ProcessGroupID{"storage-1", "storage-2"} {
+				processGroup := fdbv1beta2.NewProcessGroupStatus(processGroupID, fdbv1beta2.ProcessClassStorage, []string{"1.1.1.1"})
+				processGroup.ProcessGroupConditions = []*fdbv1beta2.ProcessGroupCondition{
+					{
+						ProcessGroupConditionType: fdbv1beta2.MissingProcesses,
+						Timestamp:                 time.Now().Add(-3 * time.Hour).Unix(),
+					},
+				}
+				cluster.Status.ProcessGroups = append(cluster.Status.ProcessGroups, processGroup)
+			}
+		})
+
+		JustBeforeEach(func() {
+			hasReplacement, _ = ReplaceFailedProcessGroups(GinkgoLogr, cluster, &fdbv1beta2.FoundationDBStatus{}, true, nil, nil)
+		})
+
+		When("no replacement was approved", func() {
+			It("should add the PendingReplacementApproval condition", func() {
+				Expect(hasReplacement).To(BeTrue())
+				for _, processGroup := range cluster.Status.ProcessGroups {
+					Expect(processGroup.IsMarkedForRemoval()).To(BeFalse())
+					Expect(processGroup.GetConditionTime(fdbv1beta2.PendingReplacementApproval)).NotTo(BeNil())
+				}
+			})
+		})
+
+		When("a replacement was approved in the spec", func() {
+			BeforeEach(func() {
+				cluster.Spec.ApprovedReplacements = []fdbv1beta2.ProcessGroupID{"storage-1"}
+				cluster.Status.ProcessGroups[0].UpdateCondition(fdbv1beta2.PendingReplacementApproval, true)
+			})
+
+			It("should only replace the approved process group", func() {
+				Expect(hasReplacement).To(BeTrue())
+				Expect(cluster.Status.ProcessGroups[0].IsMarkedForRemoval()).To(BeTrue())
+				Expect(cluster.Status.ProcessGroups[0].GetConditionTime(fdbv1beta2.PendingReplacementApproval)).To(BeNil())
+				Expect(cluster.Status.ProcessGroups[1].IsMarkedForRemoval()).To(BeFalse())
+				Expect(cluster.Status.ProcessGroups[1].GetConditionTime(fdbv1beta2.PendingReplacementApproval)).NotTo(BeNil())
+			})
+		})
+
+		When("the replacements were approved with the annotation", func() {
+			BeforeEach(func() {
+				cluster.Annotations = map[string]string{
+					fdbv1beta2.ApprovedReplacementsAnnotation: "storage-1, storage-2",
+				}
+			})
+
+			It("should replace all process groups", func() {
+				Expect(hasReplacement).To(BeTrue())
+				for _, processGroup := range cluster.Status.ProcessGroups {
+					Expect(processGroup.IsMarkedForRemoval()).To(BeTrue())
+					Expect(processGroup.GetConditionTime(fdbv1beta2.PendingReplacementApproval)).To(BeNil())
+				}
+			})
+		})
+	})
 })
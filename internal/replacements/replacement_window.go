@@ -0,0 +1,93 @@
+/*
+ * replacement_window.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replacements
+
+import (
+	"fmt"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/robfig/cron/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// replacementBudgetDuration defines the duration for which the maxReplacementsPerHour is enforced.
+const replacementBudgetDuration = time.Hour
+
+// IsInReplacementWindow returns true if the provided time is inside one of the allowed replacement windows of the
+// cluster. If no windows are defined, replacements are always allowed. If the time is outside of all windows, the
+// start of the next window will be returned.
+func IsInReplacementWindow(cluster *fdbv1beta2.FoundationDBCluster, now time.Time) (bool, time.Time, error) {
+	windows := cluster.Spec.AutomationOptions.Replacements.AllowedWindows
+	if len(windows) == 0 {
+		return true, now, nil
+	}
+
+	var nextWindow time.Time
+	for _, window := range windows {
+		schedule, err := cron.ParseStandard(window.Schedule)
+		if err != nil {
+			return false, time.Time{}, fmt.Errorf("could not parse schedule %s of replacement window: %w", window.Schedule, err)
+		}
+
+		// The window is currently open if it was opened within the last durationMinutes.
+		duration := time.Duration(window.DurationMinutes) * time.Minute
+		if !schedule.Next(now.Add(-duration)).After(now) {
+			return true, now, nil
+		}
+
+		next := schedule.Next(now)
+		if nextWindow.IsZero() || next.Before(nextWindow) {
+			nextWindow = next
+		}
+	}
+
+	return false, nextWindow, nil
+}
+
+// getRemainingReplacementBudget returns the number of replacements that are allowed based on the
+// maxReplacementsPerHour and the replacements in the replacement history. If maxReplacementsPerHour is unset, the
+// provided maxReplacements will be returned. The replacement history will be pruned to the entries that are inside the
+// budget duration.
+func getRemainingReplacementBudget(cluster *fdbv1beta2.FoundationDBCluster, maxReplacements int, now time.Time) int {
+	maxReplacementsPerHour := cluster.Spec.AutomationOptions.Replacements.MaxReplacementsPerHour
+	if maxReplacementsPerHour == nil {
+		cluster.Status.ReplacementHistory = nil
+		return maxReplacements
+	}
+
+	history := make([]metav1.Time, 0, len(cluster.Status.ReplacementHistory))
+	for _, timestamp := range cluster.Status.ReplacementHistory {
+		if now.Sub(timestamp.Time) >= replacementBudgetDuration {
+			continue
+		}
+
+		history = append(history, timestamp)
+	}
+	cluster.Status.ReplacementHistory = history
+
+	remaining := *maxReplacementsPerHour - len(history)
+	if remaining < maxReplacements {
+		return remaining
+	}
+
+	return maxReplacements
+}
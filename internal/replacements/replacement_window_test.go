@@ -0,0 +1,166 @@
+/*
+ * replacement_window_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replacements
+
+import (
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("replacement_window", func() {
+	// 2024-01-03 is a Wednesday.
+	now := time.Date(2024, 1, 3, 2, 30, 0, 0, time.UTC)
+
+	DescribeTable("checking if the time is inside a replacement window",
+		func(windows []fdbv1beta2.ReplacementWindow, expectedInWindow bool, expectedNextWindow time.Time) {
+			cluster := &fdbv1beta2.FoundationDBCluster{
+				Spec: fdbv1beta2.FoundationDBClusterSpec{
+					AutomationOptions: fdbv1beta2.FoundationDBClusterAutomationOptions{
+						Replacements: fdbv1beta2.AutomaticReplacementOptions{
+							AllowedWindows: windows,
+						},
+					},
+				},
+			}
+
+			inWindow, nextWindow, err := IsInReplacementWindow(cluster, now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(inWindow).To(Equal(expectedInWindow))
+			Expect(nextWindow).To(BeTemporally("==", expectedNextWindow))
+		},
+		Entry("no windows are defined",
+			nil,
+			true,
+			now,
+		),
+		Entry("the window is open",
+			[]fdbv1beta2.ReplacementWindow{
+				{Schedule: "0 2 * * 1-5", DurationMinutes: 60},
+			},
+			true,
+			now,
+		),
+		Entry("the window is already closed",
+			[]fdbv1beta2.ReplacementWindow{
+				{Schedule: "0 2 * * 1-5", DurationMinutes: 15},
+			},
+			false,
+			time.Date(2024, 1, 4, 2, 0, 0, 0, time.UTC),
+		),
+		Entry("the window only opens on weekends",
+			[]fdbv1beta2.ReplacementWindow{
+				{Schedule: "0 2 * * 0,6", DurationMinutes: 60},
+			},
+			false,
+			time.Date(2024, 1, 6, 2, 0, 0, 0, time.UTC),
+		),
+		Entry("multiple windows where one is open",
+			[]fdbv1beta2.ReplacementWindow{
+				{Schedule: "0 2 * * 0,6", DurationMinutes: 60},
+				{Schedule: "0 1 * * *", DurationMinutes: 120},
+			},
+			true,
+			now,
+		),
+		Entry("multiple windows where none is open",
+			[]fdbv1beta2.ReplacementWindow{
+				{Schedule: "0 2 * * 0,6", DurationMinutes: 60},
+				{Schedule: "0 22 * * *", DurationMinutes: 120},
+			},
+			false,
+			time.Date(2024, 1, 3, 22, 0, 0, 0, time.UTC),
+		),
+	)
+
+	When("the schedule is invalid", func() {
+		It("should return an error", func() {
+			cluster := &fdbv1beta2.FoundationDBCluster{
+				Spec: fdbv1beta2.FoundationDBClusterSpec{
+					AutomationOptions: fdbv1beta2.FoundationDBClusterAutomationOptions{
+						Replacements: fdbv1beta2.AutomaticReplacementOptions{
+							AllowedWindows: []fdbv1beta2.ReplacementWindow{
+								{Schedule: "every night", DurationMinutes: 60},
+							},
+						},
+					},
+				},
+			}
+
+			_, _, err := IsInReplacementWindow(cluster, now)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	DescribeTable("getting the remaining replacement budget",
+		func(maxReplacementsPerHour *int, history []metav1.Time, expectedBudget int, expectedHistory int) {
+			cluster := &fdbv1beta2.FoundationDBCluster{
+				Spec: fdbv1beta2.FoundationDBClusterSpec{
+					AutomationOptions: fdbv1beta2.FoundationDBClusterAutomationOptions{
+						Replacements: fdbv1beta2.AutomaticReplacementOptions{
+							MaxReplacementsPerHour: maxReplacementsPerHour,
+						},
+					},
+				},
+				Status: fdbv1beta2.FoundationDBClusterStatus{
+					ReplacementHistory: history,
+				},
+			}
+
+			Expect(getRemainingReplacementBudget(cluster, 5, now)).To(Equal(expectedBudget))
+			Expect(cluster.Status.ReplacementHistory).To(HaveLen(expectedHistory))
+		},
+		Entry("the maximum replacements per hour is unset",
+			nil,
+			[]metav1.Time{{Time: now.Add(-time.Minute)}},
+			5,
+			0,
+		),
+		Entry("the budget is lower than the maximum replacements",
+			pointer.Int(3),
+			[]metav1.Time{{Time: now.Add(-time.Minute)}},
+			2,
+			1,
+		),
+		Entry("the budget is higher than the maximum replacements",
+			pointer.Int(10),
+			nil,
+			5,
+			0,
+		),
+		Entry("old replacements are not counted",
+			pointer.Int(3),
+			[]metav1.Time{{Time: now.Add(-time.Minute)}, {Time: now.Add(-2 * time.Hour)}},
+			2,
+			1,
+		),
+		Entry("the budget is used up",
+			pointer.Int(1),
+			[]metav1.Time{{Time: now.Add(-time.Minute)}, {Time: now.Add(-2 * time.Minute)}},
+			-1,
+			2,
+		),
+	)
+})
@@ -22,15 +22,20 @@ package replacements
 
 import (
 	"context"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -39,209 +44,1005 @@ import (
 	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/podmanager"
 )
 
+// ReplacementOptions defines the options for replacing misconfigured process groups. The zero value checks the process
+// groups serially without any protection, global budget or maintenance zone.
+type ReplacementOptions struct {
+	// MaxConcurrentChecks defines how many process groups are checked concurrently, values below 2 check serially.
+	MaxConcurrentChecks int
+	// Protection defines the process groups that must not be replaced.
+	Protection *Protection
+	// GlobalBudget limits the replacements across clusters, nil disables the global budget.
+	GlobalBudget *GlobalBudget
+	// MaintenanceZone defers the replacements of process groups in this fault domain until the maintenance is done.
+	MaintenanceZone fdbv1beta2.FaultDomain
+	// PodSpecComparators are used as additional checks if a Pod spec has drifted.
+	PodSpecComparators []podmanager.PodSpecComparator
+}
+
 // ReplaceMisconfiguredProcessGroups checks if the cluster has any misconfigured process groups that must be replaced.
-func ReplaceMisconfiguredProcessGroups(ctx context.Context, podManager podmanager.PodLifecycleManager, client client.Client, log logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, pvcMap map[fdbv1beta2.ProcessGroupID]corev1.PersistentVolumeClaim, replaceOnSecurityContextChange bool) (bool, error) {
-	hasReplacements := false
+// If the replacements are running in dry-run mode, the misconfigured process groups will only be recorded in the
+// cluster status. Replacements of process groups in the provided maintenance zone are deferred until the
+// maintenance is done. The returned bool reports if the status of the cluster was changed.
+func ReplaceMisconfiguredProcessGroups(ctx context.Context, podManager podmanager.PodLifecycleManager, client client.Client, log logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, pvcMap map[fdbv1beta2.ProcessGroupID]corev1.PersistentVolumeClaim, additionalPVCMap map[fdbv1beta2.ProcessGroupID][]corev1.PersistentVolumeClaim, replaceOnSecurityContextChange bool, options ReplacementOptions) (bool, error) {
+	if cluster.ReplacementsDryRun() {
+		candidates, removalReasons := getReplacementCandidates(ctx, podManager, client, log, cluster, pvcMap, additionalPVCMap, replaceOnSecurityContextChange, options)
+		return recordDryRunReplacements(log, cluster, candidates, removalReasons), nil
+	}
+
+	// Remove the recorded replacements from a previous dry-run.
+	hasReplacements := recordDryRunReplacements(log, cluster, nil, nil)
+	// Remove the PendingReplacementApproval conditions if replacements don't require an approval anymore.
+	if !cluster.ReplacementsRequireApproval() && clearReplacementApprovals(cluster, nil) {
+		hasReplacements = true
+	}
+	// Remove the ReplacementDeferredByDisruptionBudget conditions if PodDisruptionBudgets are not respected anymore.
+	if !cluster.ReplacementsRespectPodDisruptionBudgets() && updateDeferredReplacements(cluster, nil) {
+		hasReplacements = true
+	}
+
+	now := time.Now()
+	inWindow, nextWindow, err := IsInReplacementWindow(cluster, now)
+	if err != nil {
+		return hasReplacements, err
+	}
+
+	if !inWindow {
+		log.Info("Skipping replacements of misconfigured process groups, outside of the allowed replacement windows", "nextWindow", nextWindow)
+		return hasReplacements, nil
+	}
+
+	maxReplacements, faultDomainsWithReplacements := getReplacementInformation(cluster, cluster.GetMaxConcurrentReplacements())
+	maxReplacements = getRemainingReplacementBudget(cluster, maxReplacements, now)
+	if maxReplacements <= 0 && cluster.GetMassReplacementThresholdPercentage() <= 0 {
+		log.Info("Early abort, reached limit of concurrent replacements")
+		return hasReplacements, nil
+	}
+
+	remainingPerClass := getRemainingReplacementsPerClass(cluster)
+	remainingStorageClassMigrations, limitStorageClassMigrations := getRemainingStorageClassMigrations(cluster)
+	remainingNodeVersionSkewReplacements := getRemainingNodeVersionSkewReplacements(cluster)
+	// All process groups must be checked to make sure the process groups with the highest priority are replaced first.
+	replacementCandidates, removalReasons := getReplacementCandidates(ctx, podManager, client, log, cluster, pvcMap, additionalPVCMap, replaceOnSecurityContextChange, options)
+	replacementCandidates, securityContextChanged := filterTransientSecurityContextChanges(log, cluster, replacementCandidates, removalReasons, now)
+	if securityContextChanged {
+		hasReplacements = true
+	}
+
+	if clearReplacementApprovals(cluster, replacementCandidates) {
+		hasReplacements = true
+	}
+
+	if cluster.GetMassReplacementThresholdPercentage() > 0 && isMassReplacement(cluster, len(replacementCandidates)) && !cluster.MassReplacementApproved() {
+		return hasReplacements, &MassReplacementError{
+			Replacements:  len(replacementCandidates),
+			ProcessGroups: len(cluster.Status.ProcessGroups),
+			Threshold:     cluster.GetMassReplacementThresholdPercentage(),
+		}
+	}
+
+	replacementCandidates = deferReplacementsInMaintenanceZone(log, cluster, replacementCandidates, options.MaintenanceZone)
+	prioritizeReplacementCandidates(cluster, replacementCandidates)
+	batchByFaultDomain := cluster.ReplacementsBatchedByFaultDomain()
+	var batchFaultDomain fdbv1beta2.FaultDomain
+	if batchByFaultDomain {
+		var batchAllowed bool
+		batchFaultDomain, batchAllowed = getReplacementBatchFaultDomain(cluster, replacementCandidates, faultDomainsWithReplacements)
+		if !batchAllowed {
+			log.Info("Skipping replacements of misconfigured process groups, replacements in multiple fault domains are ongoing", "faultDomains", len(faultDomainsWithReplacements))
+			return hasReplacements, nil
+		}
+	}
+
+	var disruptionBudgets *disruptionBudgetTracker
+	deferredReplacements := map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None{}
+	if cluster.ReplacementsRespectPodDisruptionBudgets() {
+		disruptionBudgets, err = newDisruptionBudgetTracker(ctx, client, log, cluster)
+		if err != nil {
+			return hasReplacements, err
+		}
+	}
+
+	for idx, processGroup := range replacementCandidates {
+		approved, approvalChanged := replacementApproved(log, cluster, processGroup)
+		if approvalChanged {
+			hasReplacements = true
+		}
+
+		if !approved {
+			recordDeferredReplacements(cluster, deferralReasonApprovalRequired, processGroup)
+			continue
+		}
 
-	maxReplacements, _ := getReplacementInformation(cluster, cluster.GetMaxConcurrentReplacements())
-	for _, processGroup := range cluster.Status.ProcessGroups {
 		if maxReplacements <= 0 {
 			log.Info("Early abort, reached limit of concurrent replacements")
+			recordDeferredReplacements(cluster, deferralReasonReplacementLimit, replacementCandidates[idx:]...)
 			break
 		}
 
-		if processGroup.IsMarkedForRemoval() {
+		if batchByFaultDomain {
+			faultDomain := getReplacementFaultDomain(cluster, processGroup)
+			if faultDomain != batchFaultDomain {
+				log.V(1).Info("Skipping replacement, process group is not in the fault domain that is currently replaced", "processGroupID", processGroup.ProcessGroupID, "faultDomain", faultDomain, "batchFaultDomain", batchFaultDomain)
+				recordDeferredReplacements(cluster, deferralReasonFaultDomain, processGroup)
+				continue
+			}
+		}
+
+		if !classRemovalAllowed(remainingPerClass, processGroup.ProcessClass) {
+			log.Info("Skipping replacement, reached limit of concurrent replacements for process class", "processGroupID", processGroup.ProcessGroupID, "processClass", processGroup.ProcessClass)
+			recordDeferredReplacements(cluster, deferralReasonProcessClassLimit, processGroup)
 			continue
 		}
 
-		needsRemoval, err := ProcessGroupNeedsRemoval(ctx, podManager, client, log, cluster, processGroup, pvcMap, replaceOnSecurityContextChange)
+		isStorageClassMigration := removalReasons[processGroup.ProcessGroupID].Type == fdbv1beta2.RemovalReasonStorageClassChanged
+		if isStorageClassMigration && limitStorageClassMigrations && remainingStorageClassMigrations <= 0 {
+			log.Info("Skipping replacement, reached limit of concurrent storage class migrations", "processGroupID", processGroup.ProcessGroupID)
+			recordDeferredReplacements(cluster, deferralReasonStorageClassMigrationLimit, processGroup)
+			continue
+		}
 
-		// Do not mark for removal if there is an error
-		if err != nil {
+		isNodeVersionSkew := removalReasons[processGroup.ProcessGroupID].Type == fdbv1beta2.RemovalReasonNodeVersionSkew
+		if isNodeVersionSkew && remainingNodeVersionSkewReplacements <= 0 {
+			log.Info("Skipping replacement, reached limit of concurrent replacements of process groups on outdated nodes", "processGroupID", processGroup.ProcessGroupID)
+			recordDeferredReplacements(cluster, deferralReasonNodeVersionSkewLimit, processGroup)
 			continue
 		}
 
-		if needsRemoval {
-			processGroup.MarkForRemoval()
-			hasReplacements = true
-			maxReplacements--
+		if disruptionBudgets != nil {
+			allowed, pdbName := disruptionBudgets.disruptionAllowed(processGroup)
+			if !allowed {
+				log.Info("Deferring replacement, the replacement would exceed the PodDisruptionBudget", "processGroupID", processGroup.ProcessGroupID, "podDisruptionBudget", pdbName)
+				deferredReplacements[processGroup.ProcessGroupID] = fdbv1beta2.None{}
+				recordDeferredReplacements(cluster, deferralReasonPodDisruptionBudget, processGroup)
+				continue
+			}
+		}
+
+		if !options.GlobalBudget.Reserve(processGroup) {
+			log.Info("Early abort, reached limit of the global replacement budget", "processGroupID", processGroup.ProcessGroupID)
+			recordDeferredReplacements(cluster, deferralReasonGlobalBudget, replacementCandidates[idx:]...)
+			break
+		}
+
+		if disruptionBudgets != nil {
+			disruptionBudgets.consumeDisruption(processGroup)
+		}
+
+		processGroup.MarkForRemovalWithReason(removalReasons[processGroup.ProcessGroupID])
+		recordStartedReplacement(cluster, processGroup)
+		hasReplacements = true
+		maxReplacements--
+		consumeClassRemoval(remainingPerClass, processGroup.ProcessClass)
+		if isStorageClassMigration {
+			remainingStorageClassMigrations--
+		}
+		if isNodeVersionSkew {
+			remainingNodeVersionSkewReplacements--
+		}
+
+		if cluster.Spec.AutomationOptions.Replacements.MaxReplacementsPerHour != nil {
+			cluster.Status.ReplacementHistory = append(cluster.Status.ReplacementHistory, metav1.Time{Time: now})
 		}
 	}
 
+	if disruptionBudgets != nil && updateDeferredReplacements(cluster, deferredReplacements) {
+		hasReplacements = true
+	}
+
 	return hasReplacements, nil
 }
 
-// ProcessGroupNeedsRemoval checks if a process group needs to be removed.
-func ProcessGroupNeedsRemoval(ctx context.Context, podManager podmanager.PodLifecycleManager, client client.Client, log logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus, pvcMap map[fdbv1beta2.ProcessGroupID]corev1.PersistentVolumeClaim, replaceOnSecurityContextChange bool) (bool, error) {
+// getRemainingStorageClassMigrations returns the number of process groups that can be replaced because the storage
+// class of their PVC has changed, minus the in-flight storage class migrations. The returned bool will be false if
+// storage class migrations are not limited.
+func getRemainingStorageClassMigrations(cluster *fdbv1beta2.FoundationDBCluster) (int, bool) {
+	limit := cluster.Spec.AutomationOptions.Replacements.MaxConcurrentStorageClassMigrations
+	if limit == nil {
+		return 0, false
+	}
+
+	remaining := *limit
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if !processGroup.IsMarkedForRemoval() || processGroup.IsExcluded() {
+			continue
+		}
+
+		if processGroup.RemovalReason != nil && processGroup.RemovalReason.Type == fdbv1beta2.RemovalReasonStorageClassChanged {
+			remaining--
+		}
+	}
+
+	return remaining, true
+}
+
+// getReplacementCandidates returns the misconfigured process groups that should be replaced and the reasons for their
+// replacement.
+func getReplacementCandidates(ctx context.Context, podManager podmanager.PodLifecycleManager, client client.Client, log logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, pvcMap map[fdbv1beta2.ProcessGroupID]corev1.PersistentVolumeClaim, additionalPVCMap map[fdbv1beta2.ProcessGroupID][]corev1.PersistentVolumeClaim, replaceOnSecurityContextChange bool, options ReplacementOptions) ([]*fdbv1beta2.ProcessGroupStatus, map[fdbv1beta2.ProcessGroupID]*fdbv1beta2.RemovalReason) {
+	prefixMigrationProcessClass, prefixMigrationFaultDomain, prefixMigrationAllowed := getProcessGroupIDPrefixMigrationStep(cluster)
+
+	processGroups := make([]*fdbv1beta2.ProcessGroupStatus, 0, len(cluster.Status.ProcessGroups))
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if !isReplacementCandidate(cluster, processGroup, prefixMigrationProcessClass, prefixMigrationFaultDomain, prefixMigrationAllowed) {
+			continue
+		}
+
+		processGroups = append(processGroups, processGroup)
+	}
+
+	// The checks are independent of each other, so they can be done concurrently. The results are stored by index to
+	// keep the order of the cluster status.
+	results := make([]*fdbv1beta2.RemovalReason, len(processGroups))
+	_ = internal.RunParallel(len(processGroups), options.MaxConcurrentChecks, func(idx int) error {
+		removalReason, err := ProcessGroupNeedsRemoval(ctx, podManager, client, log, cluster, processGroups[idx], pvcMap, additionalPVCMap, replaceOnSecurityContextChange, options.PodSpecComparators...)
+		// Do not mark for removal if there is an error
+		if err == nil {
+			results[idx] = removalReason
+		}
+
+		return nil
+	})
+
+	replacementCandidates := make([]*fdbv1beta2.ProcessGroupStatus, 0)
+	removalReasons := map[fdbv1beta2.ProcessGroupID]*fdbv1beta2.RemovalReason{}
+	for idx, processGroup := range processGroups {
+		if results[idx] == nil {
+			continue
+		}
+
+		if skipForImageTypeMigration(ctx, podManager, client, cluster, processGroup) {
+			log.V(1).Info("Skipping replacement, the process group is not part of the fault domain that is currently migrated to a different image type", "processGroupID", processGroup.ProcessGroupID, "reason", results[idx].Type)
+			continue
+		}
+
+		if options.Protection.Skip(processGroup) {
+			log.Info("Skipping replacement, process group is protected", "processGroupID", processGroup.ProcessGroupID, "reason", results[idx].Type)
+			continue
+		}
+
+		replacementCandidates = append(replacementCandidates, processGroup)
+		removalReasons[processGroup.ProcessGroupID] = results[idx]
+	}
+
+	return replacementCandidates, removalReasons
+}
+
+// isReplacementCandidate returns false if the process group must not be checked for a replacement, e.g. because it is
+// already marked for removal.
+func isReplacementCandidate(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus, prefixMigrationProcessClass fdbv1beta2.ProcessClass, prefixMigrationFaultDomain fdbv1beta2.FaultDomain, prefixMigrationAllowed bool) bool {
+	if processGroup.IsMarkedForRemoval() {
+		return false
+	}
+
+	// During a managed migration of the process group ID prefix, only the process groups of the currently migrated
+	// process class in the currently migrated fault domain will be replaced.
+	if cluster.UseManagedProcessGroupIDPrefixMigration() && !hasDesiredProcessGroupID(cluster, processGroup) {
+		if !prefixMigrationAllowed || processGroup.ProcessClass != prefixMigrationProcessClass || processGroup.FaultDomain != prefixMigrationFaultDomain {
+			return false
+		}
+	}
+
+	return true
+}
+
+// skipForImageTypeMigration returns true if the process group is running with an image type that must be migrated and
+// the process group is not part of the fault domain that is currently migrated. A process group without a Pod will be
+// recreated with the target image type, so it is not skipped.
+func skipForImageTypeMigration(ctx context.Context, podManager podmanager.PodLifecycleManager, client client.Client, cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus) bool {
+	if cluster.Status.ImageTypeMigration == nil {
+		return false
+	}
+
+	pod, err := podManager.GetPod(ctx, client, cluster, processGroup.GetPodName(cluster))
+	if err != nil {
+		return false
+	}
+
+	return cluster.SkipProcessGroupForImageTypeMigration(processGroup, internal.GetImageType(pod))
+}
+
+// deferReplacementsInMaintenanceZone returns the replacement candidates that are not in the provided maintenance zone.
+// Replacing process groups in the maintenance zone would add disruptions to the zone that is currently under
+// maintenance, so those replacements are deferred until the maintenance zone is reset. Replacements in other fault
+// domains can continue.
+func deferReplacementsInMaintenanceZone(log logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, candidates []*fdbv1beta2.ProcessGroupStatus, maintenanceZone fdbv1beta2.FaultDomain) []*fdbv1beta2.ProcessGroupStatus {
+	if maintenanceZone == "" {
+		return candidates
+	}
+
+	remaining := make([]*fdbv1beta2.ProcessGroupStatus, 0, len(candidates))
+	for _, processGroup := range candidates {
+		if processGroup.FaultDomain == maintenanceZone {
+			log.Info("Deferring replacement, process group is in the current maintenance zone", "processGroupID", processGroup.ProcessGroupID, "maintenanceZone", maintenanceZone)
+			recordDeferredReplacements(cluster, deferralReasonMaintenanceZone, processGroup)
+			continue
+		}
+
+		remaining = append(remaining, processGroup)
+	}
+
+	return remaining
+}
+
+// prioritizeReplacementCandidates sorts the replacement candidates by their priority. Failing process groups are
+// replaced before healthy process groups, afterwards the process classes are ordered based on the PriorityOrder of the
+// cluster. A process group is only considered failing if its failure condition is older than the failure detection
+// window, otherwise the initial conditions of every new process group would count as failures. The sort is stable, so
+// process groups with the same priority keep the order of the cluster status.
+func prioritizeReplacementCandidates(cluster *fdbv1beta2.FoundationDBCluster, candidates []*fdbv1beta2.ProcessGroupStatus) {
+	priorityOrder := cluster.Spec.AutomationOptions.Replacements.PriorityOrder
+	classPriority := make(map[fdbv1beta2.ProcessClass]int, len(priorityOrder))
+	for idx, processClass := range priorityOrder {
+		classPriority[processClass] = idx
+	}
+
+	getClassPriority := func(processClass fdbv1beta2.ProcessClass) int {
+		if priority, ok := classPriority[processClass]; ok {
+			return priority
+		}
+
+		// If no explicit order is defined, stateless process classes are replaced before stateful process classes.
+		if len(priorityOrder) == 0 && !processClass.IsStateful() {
+			return -1
+		}
+
+		return len(priorityOrder)
+	}
+
+	failureDetectionTime := cluster.GetFailureDetectionTimeSeconds()
+	taintReplacementTime := cluster.GetTaintReplacementTimeSeconds()
+	failing := make(map[fdbv1beta2.ProcessGroupID]bool, len(candidates))
+	for _, candidate := range candidates {
+		failureCondition, _ := candidate.NeedsReplacement(failureDetectionTime, taintReplacementTime)
+		failing[candidate.ProcessGroupID] = failureCondition != ""
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		iFailing := failing[candidates[i].ProcessGroupID]
+		jFailing := failing[candidates[j].ProcessGroupID]
+		if iFailing != jFailing {
+			return iFailing
+		}
+
+		return getClassPriority(candidates[i].ProcessClass) < getClassPriority(candidates[j].ProcessClass)
+	})
+}
+
+// getReplacementBatchFaultDomain returns the fault domain in which misconfigured process groups can be replaced. If
+// replacements are ongoing in a single fault domain, only process groups of this fault domain can be replaced until
+// those replacements are done. Otherwise the fault domain of the replacement candidate with the highest priority is
+// returned. The returned bool will be false if replacements are ongoing in multiple fault domains, in this case no
+// further process groups should be replaced.
+func getReplacementBatchFaultDomain(cluster *fdbv1beta2.FoundationDBCluster, candidates []*fdbv1beta2.ProcessGroupStatus, faultDomainsWithReplacements map[fdbv1beta2.FaultDomain]fdbv1beta2.None) (fdbv1beta2.FaultDomain, bool) {
+	if len(faultDomainsWithReplacements) > 1 {
+		return "", false
+	}
+
+	for faultDomain := range faultDomainsWithReplacements {
+		return faultDomain, true
+	}
+
+	if len(candidates) == 0 {
+		return "", true
+	}
+
+	return getReplacementFaultDomain(cluster, candidates[0]), true
+}
+
+// recordDryRunReplacements records the provided candidates in the dryRunReplacements field of the cluster status. The
+// replacements are reported in the cluster status and not as process group conditions, as those would prevent the
+// cluster from being reconciled. The returned bool reports if the status was changed.
+func recordDryRunReplacements(log logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, candidates []*fdbv1beta2.ProcessGroupStatus, removalReasons map[fdbv1beta2.ProcessGroupID]*fdbv1beta2.RemovalReason) bool {
+	recorded := make(map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None, len(cluster.Status.DryRunReplacements))
+	for _, replacement := range cluster.Status.DryRunReplacements {
+		recorded[replacement.ProcessGroupID] = fdbv1beta2.None{}
+	}
+
+	var dryRunReplacements []fdbv1beta2.DryRunReplacement
+	for _, processGroup := range candidates {
+		replacement := fdbv1beta2.DryRunReplacement{
+			ProcessGroupID: processGroup.ProcessGroupID,
+		}
+
+		if reason, ok := removalReasons[processGroup.ProcessGroupID]; ok && reason != nil {
+			replacement.Reason = *reason
+		}
+
+		if _, ok := recorded[processGroup.ProcessGroupID]; !ok {
+			log.Info("Dry-run: process group would be replaced",
+				"processGroupID", processGroup.ProcessGroupID,
+				"reason", replacement.Reason.Message)
+		}
+
+		dryRunReplacements = append(dryRunReplacements, replacement)
+	}
+
+	if equality.Semantic.DeepEqual(cluster.Status.DryRunReplacements, dryRunReplacements) {
+		return false
+	}
+
+	cluster.Status.DryRunReplacements = dryRunReplacements
+	return true
+}
+
+// replacementApproved returns true if the replacement of the process group doesn't require an approval or was
+// approved. If the replacement is not approved, the PendingReplacementApproval condition will be added to the process
+// group, otherwise the condition will be removed. The second return value reports if the condition was changed.
+func replacementApproved(log logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus) (bool, bool) {
+	approved := !cluster.ReplacementsRequireApproval() || cluster.ReplacementApproved(processGroup.ProcessGroupID)
+	hasCondition := processGroup.GetConditionTime(fdbv1beta2.PendingReplacementApproval) != nil
+	if approved != hasCondition {
+		return approved, false
+	}
+
+	if !approved {
+		log.Info("Replacement of process group requires approval", "processGroupID", processGroup.ProcessGroupID)
+	}
+
+	processGroup.UpdateCondition(fdbv1beta2.PendingReplacementApproval, !approved)
+
+	return approved, true
+}
+
+// clearReplacementApprovals removes the PendingReplacementApproval condition from all process groups that are not
+// replacement candidates and have no failure condition. If replacements don't require an approval the condition
+// is removed from all process groups. The returned bool reports if any condition was changed.
+func clearReplacementApprovals(cluster *fdbv1beta2.FoundationDBCluster, candidates []*fdbv1beta2.ProcessGroupStatus) bool {
+	pending := make(map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None, len(candidates))
+	if cluster.ReplacementsRequireApproval() {
+		for _, processGroup := range candidates {
+			pending[processGroup.ProcessGroupID] = fdbv1beta2.None{}
+		}
+	}
+
+	changed := false
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if processGroup.GetConditionTime(fdbv1beta2.PendingReplacementApproval) == nil {
+			continue
+		}
+
+		if _, ok := pending[processGroup.ProcessGroupID]; ok {
+			continue
+		}
+
+		if cluster.ReplacementsRequireApproval() && !processGroup.IsMarkedForRemoval() && processGroup.HasFailureCondition() {
+			continue
+		}
+
+		processGroup.UpdateCondition(fdbv1beta2.PendingReplacementApproval, false)
+		changed = true
+	}
+
+	return changed
+}
+
+// MassReplacementError is returned if more misconfigured process groups should be replaced than allowed by the
+// mass replacement threshold and the mass replacement was not approved.
+type MassReplacementError struct {
+	// Replacements is the number of process groups that should be replaced.
+	Replacements int
+	// ProcessGroups is the number of process groups of the cluster.
+	ProcessGroups int
+	// Threshold is the percentage of process groups that can be replaced without an approval.
+	Threshold int
+}
+
+// Error returns the error message of the MassReplacementError.
+func (err *MassReplacementError) Error() string {
+	return fmt.Sprintf("replacing %d of %d process groups exceeds the mass replacement threshold of %d%%, the replacement must be approved with the %s annotation", err.Replacements, err.ProcessGroups, err.Threshold, fdbv1beta2.MassReplacementApprovalAnnotation)
+}
+
+// isMassReplacement returns true if the number of replacements exceeds the mass replacement threshold of the cluster.
+func isMassReplacement(cluster *fdbv1beta2.FoundationDBCluster, replacements int) bool {
+	return replacements*100 > cluster.GetMassReplacementThresholdPercentage()*len(cluster.Status.ProcessGroups)
+}
+
+// hasDesiredProcessGroupID returns true if the process group ID matches the process group ID that would be generated
+// with the current processGroupIDPrefix.
+func hasDesiredProcessGroupID(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus) bool {
+	idNum, err := processGroup.ProcessGroupID.GetIDNumber()
+	if err != nil {
+		return true
+	}
+
+	_, desiredProcessGroupID := cluster.GetProcessGroupID(processGroup.GetProcessGroupIDProcessClass(), idNum)
+	return processGroup.ProcessGroupID == desiredProcessGroupID
+}
+
+// getProcessGroupIDPrefixMigrationStep returns the process class and the fault domain that should be migrated to the
+// new process group ID prefix and if the migration is allowed to proceed. The migration is only allowed if the new
+// prefix was confirmed and the migration is not paused.
+func getProcessGroupIDPrefixMigrationStep(cluster *fdbv1beta2.FoundationDBCluster) (fdbv1beta2.ProcessClass, fdbv1beta2.FaultDomain, bool) {
+	if !cluster.UseManagedProcessGroupIDPrefixMigration() || !cluster.ProcessGroupIDPrefixMigrationConfirmed() || cluster.ProcessGroupIDPrefixMigrationPaused() {
+		return "", "", false
+	}
+
+	return getPendingProcessGroupIDPrefixMigrationStep(cluster, cluster.Status.ProcessGroups)
+}
+
+// getPendingProcessGroupIDPrefixMigrationStep returns the process class and the fault domain of the process groups with
+// a different prefix that should be migrated next. A process class and fault domain that have process groups with a
+// different prefix that are already marked for removal will be migrated first, otherwise the first process class based
+// on the migration order and the first fault domain of this process class in sorted order will be picked. If all
+// process groups use the current prefix, false will be returned.
+func getPendingProcessGroupIDPrefixMigrationStep(cluster *fdbv1beta2.FoundationDBCluster, processGroups []*fdbv1beta2.ProcessGroupStatus) (fdbv1beta2.ProcessClass, fdbv1beta2.FaultDomain, bool) {
+	processClasses := make([]fdbv1beta2.ProcessClass, 0)
+	faultDomains := map[fdbv1beta2.ProcessClass]fdbv1beta2.FaultDomain{}
+	for _, processGroup := range processGroups {
+		if hasDesiredProcessGroupID(cluster, processGroup) {
+			continue
+		}
+
+		if processGroup.IsMarkedForRemoval() {
+			return processGroup.ProcessClass, processGroup.FaultDomain, true
+		}
+
+		faultDomain, ok := faultDomains[processGroup.ProcessClass]
+		if !ok {
+			processClasses = append(processClasses, processGroup.ProcessClass)
+		}
+
+		if !ok || processGroup.FaultDomain < faultDomain {
+			faultDomains[processGroup.ProcessClass] = processGroup.FaultDomain
+		}
+	}
+
+	if len(processClasses) == 0 {
+		return "", "", false
+	}
+
+	processClass := cluster.GetProcessGroupIDPrefixMigrationOrder(processClasses)[0]
+	return processClass, faultDomains[processClass], true
+}
+
+// GetProcessGroupIDPrefixMigrationStatus returns the progress of the managed migration to the current process group ID
+// prefix. If the managed migration is disabled or all process groups use the current prefix nil will be returned.
+func GetProcessGroupIDPrefixMigrationStatus(cluster *fdbv1beta2.FoundationDBCluster, processGroups []*fdbv1beta2.ProcessGroupStatus) *fdbv1beta2.ProcessGroupIDPrefixMigrationStatus {
+	if !cluster.UseManagedProcessGroupIDPrefixMigration() {
+		return nil
+	}
+
+	processClass, faultDomain, pending := getPendingProcessGroupIDPrefixMigrationStep(cluster, processGroups)
+	if !pending {
+		return nil
+	}
+
+	migrationStatus := &fdbv1beta2.ProcessGroupIDPrefixMigrationStatus{
+		TargetPrefix:         cluster.Spec.ProcessGroupIDPrefix,
+		ProcessClass:         processClass,
+		FaultDomain:          faultDomain,
+		PendingProcessGroups: map[fdbv1beta2.ProcessClass]int{},
+		Confirmed:            cluster.ProcessGroupIDPrefixMigrationConfirmed(),
+		Paused:               cluster.ProcessGroupIDPrefixMigrationPaused(),
+	}
+
+	for _, processGroup := range processGroups {
+		if !hasDesiredProcessGroupID(cluster, processGroup) {
+			migrationStatus.PendingProcessGroups[processGroup.ProcessClass]++
+			continue
+		}
+
+		if !processGroup.IsMarkedForRemoval() {
+			migrationStatus.MigratedProcessGroups++
+		}
+	}
+
+	return migrationStatus
+}
+
+// ProcessGroupNeedsRemoval checks if a process group needs to be removed and returns the reason for the removal. If
+// the process group doesn't need to be removed, the returned reason is nil. The comparators are used as additional
+// checks if the Pod has drifted from the desired Pod spec.
+func ProcessGroupNeedsRemoval(ctx context.Context, podManager podmanager.PodLifecycleManager, client client.Client, log logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus, pvcMap map[fdbv1beta2.ProcessGroupID]corev1.PersistentVolumeClaim, additionalPVCMap map[fdbv1beta2.ProcessGroupID][]corev1.PersistentVolumeClaim, replaceOnSecurityContextChange bool, comparators ...podmanager.PodSpecComparator) (*fdbv1beta2.RemovalReason, error) {
+	pod, podErr := podManager.GetPod(ctx, client, cluster, processGroup.GetPodName(cluster))
+
+	return processGroupNeedsRemoval(ctx, client, log, cluster, processGroup, pod, podErr, pvcMap, additionalPVCMap, replaceOnSecurityContextChange, comparators)
+}
+
+// processGroupNeedsRemoval checks if a process group needs to be removed based on the provided Pod and PVCs. The
+// reader will be used to fetch the storage class of the PVC.
+func processGroupNeedsRemoval(ctx context.Context, reader client.Reader, log logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus, pod *corev1.Pod, podErr error, pvcMap map[fdbv1beta2.ProcessGroupID]corev1.PersistentVolumeClaim, additionalPVCMap map[fdbv1beta2.ProcessGroupID][]corev1.PersistentVolumeClaim, replaceOnSecurityContextChange bool, comparators []podmanager.PodSpecComparator) (*fdbv1beta2.RemovalReason, error) {
 	// TODO(johscheuer): Fix how we fetch the pvc to make better use of the controller runtime cache.
 	pvc, hasPVC := pvcMap[processGroup.ProcessGroupID]
-	pod, podErr := podManager.GetPod(ctx, client, cluster, processGroup.GetPodName(cluster))
 	if hasPVC {
-		needsPVCRemoval, err := processGroupNeedsRemovalForPVC(cluster, pvc, log, processGroup)
+		pvcRemovalReason, err := processGroupNeedsRemovalForPVC(cluster, pvc, log, processGroup)
 		if err != nil {
-			return false, err
+			return nil, err
 		}
 
-		if needsPVCRemoval && podErr == nil {
-			return true, nil
+		// If the PVC can be expanded online, the PVC will be updated by the add PVCs reconciler.
+		if pvcRemovalReason != nil && cluster.UseOnlinePVCExpansion() {
+			canBeExpanded, err := PVCCanBeExpandedOnline(ctx, reader, cluster, processGroup, &pvc)
+			if err != nil {
+				return nil, err
+			}
+
+			if canBeExpanded {
+				log.Info("Skip process group for replacement, PVC will be expanded online",
+					"processGroupID", processGroup.ProcessGroupID,
+					"pvc", pvc.Name)
+				pvcRemovalReason = nil
+			}
+		}
+
+		if pvcRemovalReason != nil && podErr == nil {
+			return pvcRemovalReason, nil
 		}
 	} else if processGroup.ProcessClass.IsStateful() {
 		log.V(1).Info("Could not find PVC for process group ID",
 			"processGroupID", processGroup.ProcessGroupID)
 	}
 
+	if podErr == nil {
+		additionalPVCRemovalReason, err := processGroupNeedsRemovalForAdditionalPVCs(cluster, log, processGroup, additionalPVCMap[processGroup.ProcessGroupID])
+		if err != nil {
+			return nil, err
+		}
+
+		if additionalPVCRemovalReason != nil {
+			return additionalPVCRemovalReason, nil
+		}
+	}
+
 	if podErr != nil {
 		log.V(1).Info("Could not find Pod for process group ID",
 			"processGroupID", processGroup.ProcessGroupID)
-		return false, podErr
+		return nil, podErr
 	}
 
-	return processGroupNeedsRemovalForPod(cluster, pod, processGroup, log, replaceOnSecurityContextChange)
+	podRemovalReason, err := processGroupNeedsRemovalForPod(cluster, pod, processGroup, log, replaceOnSecurityContextChange, comparators...)
+	if err != nil || podRemovalReason != nil {
+		return podRemovalReason, err
+	}
+
+	if hasPVC {
+		topologyRemovalReason, err := processGroupNeedsRemovalForVolumeTopology(ctx, reader, log, cluster, processGroup, pod, &pvc)
+		if err != nil || topologyRemovalReason != nil {
+			return topologyRemovalReason, err
+		}
+	}
+
+	return processGroupNeedsRemovalForNodeVersion(ctx, reader, log, cluster, pod)
 }
 
-func processGroupNeedsRemovalForPVC(cluster *fdbv1beta2.FoundationDBCluster, pvc corev1.PersistentVolumeClaim, log logr.Logger, processGroup *fdbv1beta2.ProcessGroupStatus) (bool, error) {
+func processGroupNeedsRemovalForPVC(cluster *fdbv1beta2.FoundationDBCluster, pvc corev1.PersistentVolumeClaim, log logr.Logger, processGroup *fdbv1beta2.ProcessGroupStatus) (*fdbv1beta2.RemovalReason, error) {
 	processGroupID := internal.GetProcessGroupIDFromMeta(cluster, pvc.ObjectMeta)
 	logger := log.WithValues("namespace", cluster.Namespace, "cluster", cluster.Name, "pvc", pvc.Name, "processGroupID", processGroupID)
 
-	ownedByCluster := !cluster.ShouldFilterOnOwnerReferences()
-	if !ownedByCluster {
-		for _, ownerReference := range pvc.OwnerReferences {
-			if ownerReference.UID == cluster.UID {
-				ownedByCluster = true
-				break
-			}
-		}
+	ownership := cluster.GetResourceOwnership(pvc.ObjectMeta)
+	if ownership == fdbv1beta2.ResourceConflict {
+		logger.Info("Ignoring PVC that is claimed by a different cluster", "claim", pvc.Annotations[fdbv1beta2.ClusterClaimAnnotation])
+		return nil, nil
 	}
-	if !ownedByCluster {
+	if ownership != fdbv1beta2.ResourceOwned {
 		logger.Info("Ignoring PVC that is not owned by the cluster")
-		return false, nil
+		return nil, nil
 	}
 
 	desiredPVC, err := internal.GetPvc(cluster, processGroup)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 	pvcHash, err := internal.GetJSONHash(desiredPVC.Spec)
 	if err != nil {
-		return false, err
+		return nil, err
+	}
+
+	if internal.StorageClassChanged(cluster, processGroup, &pvc) {
+		reason := newRemovalReason(fdbv1beta2.RemovalReasonStorageClassChanged, fmt.Sprintf("PVC storage class has changed from %s to %s", pointer.StringDeref(pvc.Spec.StorageClassName, ""), internal.GetStorageClassName(cluster, processGroup)))
+		logger.Info("Replace process group",
+			"reason", reason.Message)
+		return reason, nil
 	}
 
 	if pvc.Annotations[fdbv1beta2.LastSpecKey] != pvcHash {
+		reason := newRemovalReason(fdbv1beta2.RemovalReasonPVCChanged, fmt.Sprintf("PVC spec has changed from %s to %s", pvcHash, pvc.Annotations[fdbv1beta2.LastSpecKey]))
 		logger.Info("Replace process group",
-			"reason", fmt.Sprintf("PVC spec has changed from %s to %s", pvcHash, pvc.Annotations[fdbv1beta2.LastSpecKey]))
-		return true, nil
+			"reason", reason.Message)
+		return reason, nil
 	}
 	if pvc.Name != desiredPVC.Name {
+		reason := newRemovalReason(fdbv1beta2.RemovalReasonPVCChanged, fmt.Sprintf("PVC name has changed from %s to %s", desiredPVC.Name, pvc.Name))
 		logger.Info("Replace process group",
-			"reason", fmt.Sprintf("PVC name has changed from %s to %s", desiredPVC.Name, pvc.Name))
-		return true, nil
+			"reason", reason.Message)
+		return reason, nil
 	}
 
-	return false, nil
+	return nil, nil
 }
 
-func processGroupNeedsRemovalForPod(cluster *fdbv1beta2.FoundationDBCluster, pod *corev1.Pod, processGroup *fdbv1beta2.ProcessGroupStatus, log logr.Logger, replaceOnSecurityContextChange bool) (bool, error) {
+// processGroupNeedsRemovalForVolumeTopology checks if the Pod of the process group must be updated and the desired Pod
+// spec can't be scheduled on any node that satisfies the node affinity of the persistent volume. Recreating the Pod in
+// this case would leave the Pod pending, so the process group must be replaced and the data moved to a new volume.
+func processGroupNeedsRemovalForVolumeTopology(ctx context.Context, reader client.Reader, log logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus, pod *corev1.Pod, pvc *corev1.PersistentVolumeClaim) (*fdbv1beta2.RemovalReason, error) {
+	if !cluster.ReplaceOnVolumeTopologyConflict() {
+		return nil, nil
+	}
+
+	spec, err := internal.GetPodSpec(cluster, processGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	specHash, err := internal.GetMatchingPodSpecHash(cluster, processGroup, spec, pod.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey])
+	if err != nil {
+		return nil, err
+	}
+
+	if pod.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey] == specHash {
+		return nil, nil
+	}
+
+	conflict, err := internal.GetVolumeTopologyConflict(ctx, reader, spec, pvc)
+	if err != nil || conflict == "" {
+		return nil, err
+	}
+
+	reason := newRemovalReason(fdbv1beta2.RemovalReasonVolumeTopologyConflict, conflict)
+	log.Info("Replace process group",
+		"processGroupID", processGroup.ProcessGroupID,
+		"reason", reason.Message)
+
+	return reason, nil
+}
+
+// PVCCanBeExpandedOnline returns true if the only change of the PVC spec is an increased storage request and the
+// storage class of the PVC allows volume expansion.
+func PVCCanBeExpandedOnline(ctx context.Context, kubeClient client.Reader, cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus, pvc *corev1.PersistentVolumeClaim) (bool, error) {
+	desiredSize, err := internal.GetPVCStorageExpansion(cluster, processGroup, pvc)
+	if err != nil || desiredSize == nil {
+		return false, err
+	}
+
+	return internal.StorageClassAllowsVolumeExpansion(ctx, kubeClient, pvc)
+}
+
+// processGroupNeedsRemovalForAdditionalPVCs checks if the spec of one of the additional PVCs of the process group has
+// changed. Additional PVCs that don't exist yet will be ignored, as they will be created by the operator.
+func processGroupNeedsRemovalForAdditionalPVCs(cluster *fdbv1beta2.FoundationDBCluster, log logr.Logger, processGroup *fdbv1beta2.ProcessGroupStatus, additionalPVCs []corev1.PersistentVolumeClaim) (*fdbv1beta2.RemovalReason, error) {
+	desiredPVCs, err := internal.GetAdditionalPvcs(cluster, processGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, desiredPVC := range desiredPVCs {
+		idx := slices.IndexFunc(additionalPVCs, func(pvc corev1.PersistentVolumeClaim) bool {
+			return pvc.Name == desiredPVC.Name
+		})
+		if idx < 0 {
+			continue
+		}
+
+		pvc := additionalPVCs[idx]
+		if pvc.Annotations[fdbv1beta2.LastSpecKey] != desiredPVC.Annotations[fdbv1beta2.LastSpecKey] {
+			reason := newRemovalReason(fdbv1beta2.RemovalReasonPVCChanged, fmt.Sprintf("spec of additional PVC %s has changed from %s to %s", pvc.Name, pvc.Annotations[fdbv1beta2.LastSpecKey], desiredPVC.Annotations[fdbv1beta2.LastSpecKey]))
+			log.Info("Replace process group",
+				"namespace", cluster.Namespace, "cluster", cluster.Name, "processGroupID", processGroup.ProcessGroupID, "pvc", pvc.Name,
+				"reason", reason.Message)
+			return reason, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func processGroupNeedsRemovalForPod(cluster *fdbv1beta2.FoundationDBCluster, pod *corev1.Pod, processGroup *fdbv1beta2.ProcessGroupStatus, log logr.Logger, replaceOnSecurityContextChange bool, comparators ...podmanager.PodSpecComparator) (*fdbv1beta2.RemovalReason, error) {
 	if pod == nil {
-		return false, nil
+		return nil, nil
 	}
 
 	logger := log.WithValues("namespace", cluster.Namespace, "cluster", cluster.Name, "processGroupID", processGroup.ProcessGroupID)
 
 	if processGroup.IsMarkedForRemoval() {
-		return false, nil
+		return nil, nil
 	}
 
 	idNum, err := processGroup.ProcessGroupID.GetIDNumber()
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
-	_, desiredProcessGroupID := cluster.GetProcessGroupID(processGroup.ProcessClass, idNum)
+	_, desiredProcessGroupID := cluster.GetProcessGroupID(processGroup.GetProcessGroupIDProcessClass(), idNum)
 	if processGroup.ProcessGroupID != desiredProcessGroupID {
+		reason := newRemovalReason(fdbv1beta2.RemovalReasonProcessGroupIDChanged, fmt.Sprintf("expect process group ID: %s", desiredProcessGroupID))
 		logger.Info("Replace process group",
-			"reason", fmt.Sprintf("expect process group ID: %s", desiredProcessGroupID))
-		return true, nil
+			"reason", reason.Message)
+		return reason, nil
+	}
+
+	if cluster.GetProcessGroupIDAllocationStrategy() == fdbv1beta2.ProcessGroupIDAllocationZoneEncoded {
+		if _, ok := cluster.GetZoneForProcessGroupID(processGroup.ProcessGroupID); !ok {
+			reason := newRemovalReason(fdbv1beta2.RemovalReasonProcessGroupIDChanged, "process group ID doesn't encode a configured zone")
+			logger.Info("Replace process group",
+				"reason", reason.Message)
+			return reason, nil
+		}
 	}
 
 	ipSource, err := internal.GetPublicIPSource(pod)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
-	if ipSource != cluster.GetPublicIPSource() {
+	desiredIPSource := cluster.GetPublicIPSourceForProcessGroup(processGroup)
+	if ipSource != desiredIPSource && cluster.ReplaceOnPublicIPSourceChange() {
+		reason := newRemovalReason(fdbv1beta2.RemovalReasonPublicIPSourceChanged, fmt.Sprintf("publicIP source has changed from %s to %s", ipSource, desiredIPSource))
 		logger.Info("Replace process group",
-			"reason", fmt.Sprintf("publicIP source has changed from %s to %s", ipSource, cluster.GetPublicIPSource()))
-		return true, nil
+			"reason", reason.Message)
+		return reason, nil
 	}
 	serversPerPod, err := internal.GetServersPerPodForPod(pod, processGroup.ProcessClass)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
 	desiredServersPerPod := cluster.GetDesiredServersPerPod(processGroup.ProcessClass)
 	// Replace the process group if the expected servers differ from the desired servers
+	// The data layout of the volume and the process ID locality depend on the servers per Pod, so the change is always
+	// rolled out with a replacement.
 	if serversPerPod != desiredServersPerPod {
+		reason := newRemovalReason(fdbv1beta2.RemovalReasonServersPerPodChanged, fmt.Sprintf("serversPerPod has changed from current: %d to desired: %d", serversPerPod, desiredServersPerPod))
 		logger.Info("Replace process group",
 			"serversPerPod", serversPerPod,
 			"desiredServersPerPod", desiredServersPerPod,
-			"reason", fmt.Sprintf("serversPerPod has changed from current: %d to desired: %d", serversPerPod, desiredServersPerPod))
-		return true, nil
+			"reason", reason.Message)
+		return reason, nil
 	}
 
-	spec, err := internal.GetPodSpec(cluster, processGroup)
+	// Process groups that were reassigned to a compatible process class will be updated by the update pods reconciler.
+	// The reassignment itself must not trigger a replacement, so the Pod is compared against the desired spec of the
+	// process class it was created for. All other changes are detected as usual.
+	specProcessGroup := getProcessGroupForPodSpec(cluster, processGroup, pod)
+	if specProcessGroup.ProcessClass != processGroup.ProcessClass {
+		logger.V(1).Info("Compare Pod with the spec of the previous process class, process class was reassigned",
+			"podProcessClass", specProcessGroup.ProcessClass)
+	}
+
+	spec, err := internal.GetPodSpec(cluster, specProcessGroup)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
-	specHash, err := internal.GetPodSpecHash(cluster, processGroup, spec)
+	specHash, err := internal.GetMatchingPodSpecHash(cluster, specProcessGroup, spec, pod.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey])
 	if err != nil {
-		return false, err
+		return nil, err
+	}
+
+	for _, comparator := range comparators {
+		result, message := comparator.ComparePodSpec(cluster, specProcessGroup, spec, pod)
+		if result == podmanager.PodSpecComparisonMatches {
+			logger.V(1).Info("Skip process group for replacement, custom comparator reported a matching Pod spec",
+				"comparator", fmt.Sprintf("%T", comparator))
+			return nil, nil
+		}
+
+		if result == podmanager.PodSpecComparisonDrifted {
+			reason := newRemovalReason(fdbv1beta2.RemovalReasonPodSpecChanged, message)
+			logger.Info("Replace process group",
+				"comparator", fmt.Sprintf("%T", comparator),
+				"reason", reason.Message)
+			return reason, nil
+		}
+	}
+
+	// If only the CPU or memory resources have changed, the containers will be resized in place by the update pods
+	// reconciler.
+	if cluster.ResizeInPlace(processGroup.ProcessClass) {
+		if _, ok := pod.ObjectMeta.Annotations[fdbv1beta2.PendingResizeSpecKey]; ok {
+			logger.Info("Skip process group for replacement, containers are being resized in place",
+				"reason", "resize is pending")
+			return nil, nil
+		}
+
+		resourceChanges, err := internal.GetResourceChanges(cluster, specProcessGroup, pod)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(resourceChanges) > 0 {
+			logger.Info("Skip process group for replacement, containers will be resized in place",
+				"reason", "resources have changed")
+			return nil, nil
+		}
 	}
 
 	if pointer.BoolDeref(cluster.Spec.ReplaceInstancesWhenResourcesChange, false) {
 		if resourcesNeedsReplacement(spec.Containers, pod.Spec.Containers) {
+			reason := newRemovalReason(fdbv1beta2.RemovalReasonResourcesChanged, "Resource requests have changed")
 			logger.Info("Replace process group",
-				"reason", "Resource requests have changed")
-			return true, nil
+				"reason", reason.Message)
+			return reason, nil
 		}
 
 		if resourcesNeedsReplacement(spec.InitContainers, pod.Spec.InitContainers) {
+			reason := newRemovalReason(fdbv1beta2.RemovalReasonResourcesChanged, "Resource requests have changed")
 			logger.Info("Replace process group",
-				"reason", "Resource requests have changed")
-			return true, nil
+				"reason", reason.Message)
+			return reason, nil
 		}
 	}
 
 	if pod.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey] == specHash {
-		return false, nil
+		return nil, nil
+	}
+
+	expectedNodeSelector := cluster.GetProcessSettingsForProcessGroup(specProcessGroup).PodTemplate.Spec.NodeSelector
+	if cluster.ReplaceOnNodeSelectorChange() && !equality.Semantic.DeepEqual(pod.Spec.NodeSelector, expectedNodeSelector) {
+		reason := newRemovalReason(fdbv1beta2.RemovalReasonNodeSelectorChanged, fmt.Sprintf("nodeSelector has changed from %s to %s", pod.Spec.NodeSelector, expectedNodeSelector))
+		logger.Info("Replace process group",
+			"reason", reason.Message)
+		return reason, nil
 	}
 
-	expectedNodeSelector := cluster.GetProcessSettings(processGroup.ProcessClass).PodTemplate.Spec.NodeSelector
-	if !equality.Semantic.DeepEqual(pod.Spec.NodeSelector, expectedNodeSelector) {
+	if cluster.ReplaceOnServiceAccountChange() && getServiceAccountName(&pod.Spec) != getServiceAccountName(spec) {
+		reason := newRemovalReason(fdbv1beta2.RemovalReasonServiceAccountChanged, fmt.Sprintf("serviceAccountName has changed from %s to %s", getServiceAccountName(&pod.Spec), getServiceAccountName(spec)))
 		logger.Info("Replace process group",
-			"reason", fmt.Sprintf("nodeSelector has changed from %s to %s", pod.Spec.NodeSelector, expectedNodeSelector))
-		return true, nil
+			"reason", reason.Message)
+		return reason, nil
+	}
+
+	if cluster.ReplaceOnImagePullSecretsChange() {
+		missingSecrets := getMissingImagePullSecrets(spec, &pod.Spec)
+		if len(missingSecrets) > 0 {
+			reason := newRemovalReason(fdbv1beta2.RemovalReasonImagePullSecretsChanged, fmt.Sprintf("imagePullSecrets %v are missing", missingSecrets))
+			logger.Info("Replace process group",
+				"reason", reason.Message)
+			return reason, nil
+		}
 	}
 
 	// If the image type is changed from split to unified and only a single storage server per pod is used, we have to perform
 	// a replacement as the disk layout has changed.
 	if cluster.GetStorageServersPerPod() == 1 && internal.GetImageType(pod) != cluster.DesiredImageType() {
+		reason := newRemovalReason(fdbv1beta2.RemovalReasonImageTypeChanged, "imageType has been changed and only a single storage server per Pod is used")
 		logger.Info("Replace process group",
-			"reason", "imageType has been changed and only a single storage server per Pod is used")
-		return true, nil
+			"reason", reason.Message)
+		return reason, nil
+	}
+
+	// A change of the RuntimeClass requires the Pod to be recreated, but the process group can keep its data, so the
+	// Pod will be recreated by the update pods reconciler. This only applies if the RuntimeClass is the only change.
+	runtimeClassNameChanged, err := RuntimeClassNameChanged(cluster, specProcessGroup, pod)
+	if err != nil {
+		return nil, err
+	}
+
+	if runtimeClassNameChanged {
+		logger.Info("Skip process group for replacement, Pod will be recreated",
+			"reason", fmt.Sprintf("runtimeClassName has changed from %s to %s", pointer.StringDeref(pod.Spec.RuntimeClassName, ""), pointer.StringDeref(cluster.GetRuntimeClassName(processGroup.ProcessClass), "")))
+		return nil, nil
+	}
+
+	// If only the image registry has changed, the images will be updated in place by the update pods reconciler.
+	if cluster.UpdateImageRegistryInPlace() {
+		imageChanges, err := internal.GetImageRegistryChanges(cluster, specProcessGroup, pod)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(imageChanges) > 0 {
+			logger.Info("Skip process group for replacement, images will be updated in place",
+				"reason", "image registry has changed")
+			return nil, nil
+		}
 	}
 
 	if cluster.NeedsReplacement(processGroup) {
-		jsonSpec, err := json.Marshal(spec)
+		specDiff, err := GetPodSpecDiff(spec, &pod.Spec)
 		if err != nil {
-			return false, err
+			return nil, err
+		}
+
+		message := fmt.Sprintf("specHash has changed from %s to %s", pod.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey], specHash)
+		// Only add the diff to the removal reason if the message stays within the allowed length.
+		if messageWithDiff := fmt.Sprintf("%s, diff: %s", message, specDiff); len(messageWithDiff) <= maxRemovalReasonMessageLength {
+			message = messageWithDiff
 		}
 
 		logger.Info("Replace process group",
 			"reason", "specHash has changed",
 			"desiredSpecHash", specHash,
 			"currentSpecHash", pod.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey],
-			"desiredSpec", base64.StdEncoding.EncodeToString(jsonSpec),
+			"specDiff", specDiff,
 		)
-		return true, nil
+		return newRemovalReason(fdbv1beta2.RemovalReasonPodSpecChanged, message), nil
 	}
 
 	// Some k8s instances have security context vetting which may edit the spec automatically.
@@ -249,11 +1050,152 @@ func processGroupNeedsRemovalForPod(cluster *fdbv1beta2.FoundationDBCluster, pod
 	// to constantly be seen as having a security context change, hence we want to feature guard this
 	// and also guard on the spec hash below
 	// https://kubernetes.io/blog/2021/04/06/podsecuritypolicy-deprecation-past-present-and-future/
-	if replaceOnSecurityContextChange {
-		return fileSecurityContextChanged(spec, &pod.Spec, logger), nil
+	if replaceOnSecurityContextChange && cluster.ReplaceOnSecurityContextChange() && fileSecurityContextChanged(spec, &pod.Spec, logger) {
+		return newRemovalReason(fdbv1beta2.RemovalReasonSecurityContextChanged, "file security context has changed"), nil
+	}
+
+	return nil, nil
+}
+
+// getProcessGroupForPodSpec returns the process group that should be used to generate the desired spec of the Pod. If
+// the process group was reassigned to a different process class and the Pod wasn't recreated yet, a copy of the process
+// group with the process class of the Pod will be returned. The process class of the Pod is read from the mounted
+// monitor conf, as the labels of the Pod will be updated before the Pod is recreated.
+func getProcessGroupForPodSpec(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus, pod *corev1.Pod) *fdbv1beta2.ProcessGroupStatus {
+	if processGroup.GetConditionTime(fdbv1beta2.ProcessClassReassignment) == nil {
+		return processGroup
+	}
+
+	podProcessClass := getMonitorConfProcessClass(pod)
+	if podProcessClass == "" || podProcessClass == processGroup.ProcessClass {
+		return processGroup
+	}
+
+	if !internal.ProcessClassesAreCompatible(cluster, podProcessClass, processGroup.ProcessClass) {
+		return processGroup
+	}
+
+	podProcessGroup := processGroup.DeepCopy()
+	podProcessGroup.ProcessClass = podProcessClass
+
+	return podProcessGroup
+}
+
+// getMonitorConfProcessClass returns the process class of the monitor conf that is mounted from the ConfigMap into the
+// Pod. If the Pod has no monitor conf mounted an empty process class will be returned.
+func getMonitorConfProcessClass(pod *corev1.Pod) fdbv1beta2.ProcessClass {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.Name != "config-map" || volume.ConfigMap == nil {
+			continue
+		}
+
+		for _, item := range volume.ConfigMap.Items {
+			if item.Path != "fdbmonitor.conf" && item.Path != "config.json" {
+				continue
+			}
+
+			processClass := strings.TrimPrefix(item.Key, "fdbmonitor-conf-")
+			processClass = strings.TrimSuffix(processClass, "-json")
+			if idx := strings.Index(processClass, "-density-"); idx >= 0 {
+				processClass = processClass[:idx]
+			}
+
+			return fdbv1beta2.ProcessClass(processClass)
+		}
+	}
+
+	return ""
+}
+
+// maxRemovalReasonMessageLength is the maximum length of the message of a RemovalReason.
+const maxRemovalReasonMessageLength = 1024
+
+// GetPodSpecDiff returns a JSON strategic merge patch that contains the changes that are required to get from the
+// current Pod spec of the live Pod to the desired Pod spec. Fields that are only set in the current Pod spec, e.g.
+// because they were defaulted by Kubernetes, are reported as deletions.
+func GetPodSpecDiff(desired *corev1.PodSpec, current *corev1.PodSpec) (string, error) {
+	desiredJSON, err := json.Marshal(desired)
+	if err != nil {
+		return "", err
+	}
+
+	currentJSON, err := json.Marshal(current)
+	if err != nil {
+		return "", err
+	}
+
+	diff, err := strategicpatch.CreateTwoWayMergePatch(currentJSON, desiredJSON, corev1.PodSpec{})
+	if err != nil {
+		return "", err
+	}
+
+	return string(diff), nil
+}
+
+// newRemovalReason returns a new RemovalReason with the provided type and message.
+func newRemovalReason(reasonType fdbv1beta2.RemovalReasonType, message string) *fdbv1beta2.RemovalReason {
+	return &fdbv1beta2.RemovalReason{
+		Type:    reasonType,
+		Message: message,
+	}
+}
+
+// RuntimeClassNameChanged returns true if the runtimeClassName is the only difference between the desired Pod spec and
+// the Pod spec that was used to create the Pod. The comparison is based on the spec hash of the Pod, so a
+// runtimeClassName that was set by an admission controller is not reported as a change.
+func RuntimeClassNameChanged(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus, pod *corev1.Pod) (bool, error) {
+	spec, err := internal.GetPodSpec(cluster, processGroup)
+	if err != nil {
+		return false, err
+	}
+
+	currentHash := pod.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey]
+	specHash, err := internal.GetMatchingPodSpecHash(cluster, processGroup, spec, currentHash)
+	if err != nil {
+		return false, err
+	}
+
+	if specHash == currentHash || pointer.StringDeref(pod.Spec.RuntimeClassName, "") == pointer.StringDeref(spec.RuntimeClassName, "") {
+		return false, nil
+	}
+
+	// Use the current runtimeClassName to verify that the rest of the Pod spec is unchanged.
+	spec.RuntimeClassName = pod.Spec.RuntimeClassName
+	specHash, err = internal.GetMatchingPodSpecHash(cluster, processGroup, spec, currentHash)
+	if err != nil {
+		return false, err
+	}
+
+	return specHash == currentHash, nil
+}
+
+// getServiceAccountName returns the service account name of the Pod spec. Kubernetes uses the default service account
+// if no service account name is specified.
+func getServiceAccountName(spec *corev1.PodSpec) string {
+	if spec.ServiceAccountName == "" {
+		return "default"
+	}
+
+	return spec.ServiceAccountName
+}
+
+// getMissingImagePullSecrets returns the names of the desired image pull secrets that are not present in the current
+// Pod spec. Additional image pull secrets in the current Pod spec are ignored, as they might be added by the
+// service account admission controller.
+func getMissingImagePullSecrets(desired, current *corev1.PodSpec) []string {
+	currentSecrets := make(map[string]fdbv1beta2.None, len(current.ImagePullSecrets))
+	for _, secret := range current.ImagePullSecrets {
+		currentSecrets[secret.Name] = fdbv1beta2.None{}
+	}
+
+	var missingSecrets []string
+	for _, secret := range desired.ImagePullSecrets {
+		if _, ok := currentSecrets[secret.Name]; !ok {
+			missingSecrets = append(missingSecrets, secret.Name)
+		}
 	}
 
-	return false, nil
+	return missingSecrets
 }
 
 func resourcesNeedsReplacement(desired []corev1.Container, current []corev1.Container) bool {
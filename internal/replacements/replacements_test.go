@@ -23,6 +23,7 @@ package replacements
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/podmanager"
 	ctrlClient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -39,6 +40,8 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -61,11 +64,18 @@ var _ = Describe("replace_misconfigured_pods", func() {
 		var pod *corev1.Pod
 		var processGroup *fdbv1beta2.ProcessGroupStatus
 		var needsRemoval bool
+		var removalReason *fdbv1beta2.RemovalReason
 		var err error
+		var comparators []podmanager.PodSpecComparator
 		replaceOnSecurityContextChange := true
 
+		BeforeEach(func() {
+			comparators = nil
+		})
+
 		JustBeforeEach(func() {
-			needsRemoval, err = processGroupNeedsRemovalForPod(cluster, pod, processGroup, log, replaceOnSecurityContextChange)
+			removalReason, err = processGroupNeedsRemovalForPod(cluster, pod, processGroup, log, replaceOnSecurityContextChange, comparators...)
+			needsRemoval = removalReason != nil
 		})
 
 		When("a storage Pod is checked", func() {
@@ -114,6 +124,35 @@ var _ = Describe("replace_misconfigured_pods", func() {
 				})
 			})
 
+			When("zone encoded process group IDs are used", func() {
+				BeforeEach(func() {
+					strategy := fdbv1beta2.ProcessGroupIDAllocationZoneEncoded
+					cluster.Spec.AutomationOptions.ProcessGroupIDAllocation = &fdbv1beta2.ProcessGroupIDAllocationOptions{
+						Strategy: &strategy,
+						Zones:    []string{"zone-a", "zone-b"},
+					}
+				})
+
+				When("the process group ID encodes a configured zone", func() {
+					It("should not need a removal", func() {
+						Expect(needsRemoval).To(BeFalse())
+						Expect(err).NotTo(HaveOccurred())
+					})
+				})
+
+				When("the process group ID doesn't encode a configured zone", func() {
+					BeforeEach(func() {
+						processGroup.ProcessGroupID = "storage-3001"
+					})
+
+					It("should need a removal", func() {
+						Expect(needsRemoval).To(BeTrue())
+						Expect(err).NotTo(HaveOccurred())
+						Expect(removalReason.Type).To(Equal(fdbv1beta2.RemovalReasonProcessGroupIDChanged))
+					})
+				})
+			})
+
 			When("process group ID prefix changes", func() {
 				BeforeEach(func() {
 					// Change the process group ID should trigger a removal
@@ -123,6 +162,7 @@ var _ = Describe("replace_misconfigured_pods", func() {
 				It("should need a removal", func() {
 					Expect(needsRemoval).To(BeTrue())
 					Expect(err).NotTo(HaveOccurred())
+					Expect(removalReason.Type).To(Equal(fdbv1beta2.RemovalReasonProcessGroupIDChanged))
 				})
 			})
 
@@ -135,6 +175,23 @@ var _ = Describe("replace_misconfigured_pods", func() {
 				It("should need a removal", func() {
 					Expect(needsRemoval).To(BeTrue())
 					Expect(err).NotTo(HaveOccurred())
+					Expect(removalReason).To(Equal(&fdbv1beta2.RemovalReason{
+						Type:    fdbv1beta2.RemovalReasonPublicIPSourceChanged,
+						Message: "publicIP source has changed from pod to service",
+					}))
+				})
+
+				When("the replacement trigger for the public IP source is disabled", func() {
+					BeforeEach(func() {
+						cluster.Spec.ReplacementTriggerPolicy = &fdbv1beta2.ReplacementTriggerPolicy{
+							PublicIPSourceChanged: pointer.Bool(false),
+						}
+					})
+
+					It("should not need a removal", func() {
+						Expect(needsRemoval).To(BeFalse())
+						Expect(err).NotTo(HaveOccurred())
+					})
 				})
 			})
 
@@ -164,6 +221,29 @@ var _ = Describe("replace_misconfigured_pods", func() {
 				})
 			})
 
+			When("the Pod has a spec hash of the legacy hash version", func() {
+				BeforeEach(func() {
+					pod.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey], err = internal.GetJSONHash(pod.Spec)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("should not need a removal", func() {
+					Expect(needsRemoval).To(BeFalse())
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+
+			When("the logServersPerPod is changed for a non log class process group", func() {
+				BeforeEach(func() {
+					cluster.Spec.LogServersPerPod = 2
+				})
+
+				It("should not need a removal", func() {
+					Expect(needsRemoval).To(BeFalse())
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+
 			When("the storageServersPerPod is changed for a storage class process group", func() {
 				BeforeEach(func() {
 					cluster.Spec.StorageServersPerPod = 2
@@ -173,6 +253,19 @@ var _ = Describe("replace_misconfigured_pods", func() {
 					Expect(needsRemoval).To(BeTrue())
 					Expect(err).NotTo(HaveOccurred())
 				})
+
+				When("the replacement trigger for the servers per Pod is disabled", func() {
+					BeforeEach(func() {
+						cluster.Spec.ReplacementTriggerPolicy = &fdbv1beta2.ReplacementTriggerPolicy{
+							ServersPerPodChanged: pointer.Bool(false),
+						}
+					})
+
+					It("should need a removal", func() {
+						Expect(needsRemoval).To(BeTrue())
+						Expect(err).NotTo(HaveOccurred())
+					})
+				})
 			})
 
 			When("the nodeSelector changes", func() {
@@ -186,6 +279,133 @@ var _ = Describe("replace_misconfigured_pods", func() {
 					Expect(needsRemoval).To(BeTrue())
 					Expect(err).NotTo(HaveOccurred())
 				})
+
+				When("a custom comparator reports a matching Pod spec", func() {
+					var comparator *testPodSpecComparator
+
+					BeforeEach(func() {
+						comparator = &testPodSpecComparator{result: podmanager.PodSpecComparisonMatches}
+						comparators = []podmanager.PodSpecComparator{
+							comparator,
+							&testPodSpecComparator{result: podmanager.PodSpecComparisonDrifted},
+						}
+					})
+
+					It("should not need a removal", func() {
+						Expect(needsRemoval).To(BeFalse())
+						Expect(err).NotTo(HaveOccurred())
+						Expect(comparator.calls).To(Equal(1))
+					})
+				})
+
+				When("a custom comparator is undecided", func() {
+					BeforeEach(func() {
+						comparators = []podmanager.PodSpecComparator{
+							&testPodSpecComparator{result: podmanager.PodSpecComparisonUndecided},
+						}
+					})
+
+					It("should need a removal because of the changed nodeSelector", func() {
+						Expect(needsRemoval).To(BeTrue())
+						Expect(err).NotTo(HaveOccurred())
+						Expect(removalReason.Type).To(Equal(fdbv1beta2.RemovalReasonNodeSelectorChanged))
+					})
+				})
+
+				When("the replacement trigger for the nodeSelector is disabled", func() {
+					BeforeEach(func() {
+						cluster.Spec.ReplacementTriggerPolicy = &fdbv1beta2.ReplacementTriggerPolicy{
+							NodeSelectorChanged: pointer.Bool(false),
+						}
+					})
+
+					It("should not need a removal", func() {
+						Expect(needsRemoval).To(BeFalse())
+						Expect(err).NotTo(HaveOccurred())
+					})
+
+					When("PodUpdateStrategyReplacement is set", func() {
+						BeforeEach(func() {
+							cluster.Spec.AutomationOptions.PodUpdateStrategy = fdbv1beta2.PodUpdateStrategyReplacement
+						})
+
+						It("should need a removal because of the changed spec", func() {
+							Expect(needsRemoval).To(BeTrue())
+							Expect(err).NotTo(HaveOccurred())
+							Expect(removalReason.Type).To(Equal(fdbv1beta2.RemovalReasonPodSpecChanged))
+						})
+					})
+				})
+			})
+
+			When("the service account changes", func() {
+				BeforeEach(func() {
+					cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral].PodTemplate.Spec.ServiceAccountName = "fdb-rotated"
+				})
+
+				It("should not need a removal", func() {
+					Expect(needsRemoval).To(BeFalse())
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				When("the replacement trigger for the service account is enabled", func() {
+					BeforeEach(func() {
+						cluster.Spec.ReplacementTriggerPolicy = &fdbv1beta2.ReplacementTriggerPolicy{
+							ServiceAccountChanged: pointer.Bool(true),
+						}
+					})
+
+					It("should need a removal because of the changed service account", func() {
+						Expect(needsRemoval).To(BeTrue())
+						Expect(err).NotTo(HaveOccurred())
+						Expect(removalReason.Type).To(Equal(fdbv1beta2.RemovalReasonServiceAccountChanged))
+						Expect(removalReason.Message).To(Equal("serviceAccountName has changed from default to fdb-rotated"))
+					})
+				})
+			})
+
+			When("an image pull secret is added", func() {
+				BeforeEach(func() {
+					cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral].PodTemplate.Spec.ImagePullSecrets = []corev1.LocalObjectReference{
+						{Name: "registry-rotated"},
+					}
+				})
+
+				It("should not need a removal", func() {
+					Expect(needsRemoval).To(BeFalse())
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				When("the replacement trigger for the image pull secrets is enabled", func() {
+					BeforeEach(func() {
+						cluster.Spec.ReplacementTriggerPolicy = &fdbv1beta2.ReplacementTriggerPolicy{
+							ImagePullSecretsChanged: pointer.Bool(true),
+						}
+					})
+
+					It("should need a removal because of the missing image pull secret", func() {
+						Expect(needsRemoval).To(BeTrue())
+						Expect(err).NotTo(HaveOccurred())
+						Expect(removalReason.Type).To(Equal(fdbv1beta2.RemovalReasonImagePullSecretsChanged))
+						Expect(removalReason.Message).To(Equal("imagePullSecrets [registry-rotated] are missing"))
+					})
+				})
+			})
+
+			When("a custom comparator reports a drifted Pod spec", func() {
+				BeforeEach(func() {
+					comparators = []podmanager.PodSpecComparator{
+						&testPodSpecComparator{result: podmanager.PodSpecComparisonUndecided},
+						&testPodSpecComparator{result: podmanager.PodSpecComparisonDrifted, message: "custom drift"},
+					}
+				})
+
+				It("should need a removal", func() {
+					Expect(needsRemoval).To(BeTrue())
+					Expect(err).NotTo(HaveOccurred())
+					Expect(removalReason.Type).To(Equal(fdbv1beta2.RemovalReasonPodSpecChanged))
+					Expect(removalReason.Message).To(Equal("custom drift"))
+				})
 			})
 
 			When("the nodeSelector doesn't match but the PodSpecHash matches", func() {
@@ -288,7 +508,8 @@ var _ = Describe("replace_misconfigured_pods", func() {
 				})
 
 				JustBeforeEach(func() {
-					needsRemoval, err = processGroupNeedsRemovalForPVC(cluster, *pvc, log, processGroup)
+					removalReason, err = processGroupNeedsRemovalForPVC(cluster, *pvc, log, processGroup)
+					needsRemoval = removalReason != nil
 				})
 
 				When("PVC name doesn't match", func() {
@@ -319,142 +540,396 @@ var _ = Describe("replace_misconfigured_pods", func() {
 						Expect(needsRemoval).To(BeTrue())
 					})
 				})
-			})
-
-			When("replacement for resource changes is activated", func() {
-				BeforeEach(func() {
-					cluster.Spec.ReplaceInstancesWhenResourcesChange = pointer.Bool(true)
-				})
 
-				When("the memory is increased", func() {
+				When("the storage class has changed", func() {
 					BeforeEach(func() {
-						newMemory, err := resource.ParseQuantity("1Ti")
-						Expect(err).NotTo(HaveOccurred())
-						cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral].PodTemplate.Spec.Containers[0].Resources = corev1.ResourceRequirements{
-							Requests: corev1.ResourceList{
-								corev1.ResourceMemory: newMemory,
+						pvc.Spec.StorageClassName = pointer.String("slow")
+						processSettings := cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral]
+						processSettings.VolumeClaimTemplate = &corev1.PersistentVolumeClaim{
+							Spec: corev1.PersistentVolumeClaimSpec{
+								StorageClassName: pointer.String("fast"),
 							},
 						}
+						cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral] = processSettings
 					})
 
-					It("should need a removal", func() {
-						Expect(needsRemoval).To(BeTrue())
+					It("should need a removal because of the storage class", func() {
 						Expect(err).NotTo(HaveOccurred())
+						Expect(needsRemoval).To(BeTrue())
+						Expect(removalReason.Type).To(Equal(fdbv1beta2.RemovalReasonStorageClassChanged))
 					})
 				})
+			})
 
-				When("the memory is decreased", func() {
-					BeforeEach(func() {
-						newMemory, err := resource.ParseQuantity("1Ki")
-						Expect(err).NotTo(HaveOccurred())
-						cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral].PodTemplate.Spec.Containers[0].Resources = corev1.ResourceRequirements{
-							Requests: corev1.ResourceList{
-								corev1.ResourceMemory: newMemory,
+			When("checking if the PVC can be expanded online", func() {
+				var pvc *corev1.PersistentVolumeClaim
+				var canBeExpanded bool
+
+				setStorageRequest := func(size string) {
+					processSettings := cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral]
+					processSettings.VolumeClaimTemplate = &corev1.PersistentVolumeClaim{
+						Spec: corev1.PersistentVolumeClaimSpec{
+							StorageClassName: pointer.String("expandable"),
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceStorage: resource.MustParse(size),
+								},
 							},
-						}
-					})
+						},
+					}
+					cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral] = processSettings
+				}
 
-					It("should not need a removal", func() {
-						Expect(needsRemoval).To(BeFalse())
-						Expect(err).NotTo(HaveOccurred())
-					})
+				BeforeEach(func() {
+					setStorageRequest("16Gi")
+					pvc, err = internal.GetPvc(cluster, processGroup)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(k8sClient.Create(context.Background(), &storagev1.StorageClass{
+						ObjectMeta:           metav1.ObjectMeta{Name: "expandable"},
+						Provisioner:          "test",
+						AllowVolumeExpansion: pointer.Bool(true),
+					})).To(Succeed())
 				})
 
-				When("the CPU is increased", func() {
+				JustBeforeEach(func() {
+					canBeExpanded, err = PVCCanBeExpandedOnline(context.Background(), k8sClient, cluster, processGroup, pvc)
+				})
+
+				When("the storage request was increased", func() {
 					BeforeEach(func() {
-						newCPU, err := resource.ParseQuantity("1000")
-						Expect(err).NotTo(HaveOccurred())
-						cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral].PodTemplate.Spec.Containers[0].Resources = corev1.ResourceRequirements{
-							Requests: corev1.ResourceList{
-								corev1.ResourceCPU: newCPU,
-							},
-						}
+						setStorageRequest("32Gi")
 					})
 
-					It("should need a removal", func() {
-						Expect(needsRemoval).To(BeTrue())
+					It("should be expanded online", func() {
 						Expect(err).NotTo(HaveOccurred())
+						Expect(canBeExpanded).To(BeTrue())
+					})
+
+					When("the storage class doesn't allow volume expansion", func() {
+						BeforeEach(func() {
+							storageClass := &storagev1.StorageClass{}
+							Expect(k8sClient.Get(context.Background(), ctrlClient.ObjectKey{Name: "expandable"}, storageClass)).To(Succeed())
+							storageClass.AllowVolumeExpansion = pointer.Bool(false)
+							Expect(k8sClient.Update(context.Background(), storageClass)).To(Succeed())
+						})
+
+						It("should not be expanded online", func() {
+							Expect(err).NotTo(HaveOccurred())
+							Expect(canBeExpanded).To(BeFalse())
+						})
 					})
 				})
 
-				When("the CPU is decreased", func() {
+				When("the storage request was decreased", func() {
 					BeforeEach(func() {
-						newCPU, err := resource.ParseQuantity("1m")
-						Expect(err).NotTo(HaveOccurred())
-						cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral].PodTemplate.Spec.Containers[0].Resources = corev1.ResourceRequirements{
-							Requests: corev1.ResourceList{
-								corev1.ResourceCPU: newCPU,
-							},
-						}
+						setStorageRequest("8Gi")
 					})
 
-					It("should not need a removal", func() {
-						Expect(needsRemoval).To(BeFalse())
+					It("should not be expanded online", func() {
 						Expect(err).NotTo(HaveOccurred())
+						Expect(canBeExpanded).To(BeFalse())
 					})
 				})
 
-				When("adding another sidecar", func() {
+				When("the storage request was increased and the PVC spec has other changes", func() {
 					BeforeEach(func() {
-						newCPU, err := resource.ParseQuantity("1000")
-						Expect(err).NotTo(HaveOccurred())
-						cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral].PodTemplate.Spec.Containers = append(cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral].PodTemplate.Spec.Containers,
-							corev1.Container{
-								Resources: corev1.ResourceRequirements{
-									Requests: corev1.ResourceList{
-										corev1.ResourceCPU: newCPU,
-									},
-								},
-							})
+						setStorageRequest("32Gi")
+						pvc.Annotations[fdbv1beta2.LastSpecKey] = "1"
 					})
 
-					It("should need a removal", func() {
-						Expect(needsRemoval).To(BeTrue())
+					It("should not be expanded online", func() {
 						Expect(err).NotTo(HaveOccurred())
+						Expect(canBeExpanded).To(BeFalse())
 					})
 				})
 			})
 
-			When("replacement for resource changes is deactivated", func() {
+			When("checking if the additional PVCs require a replacement", func() {
+				var additionalPVCs []corev1.PersistentVolumeClaim
+
 				BeforeEach(func() {
-					cluster.Spec.ReplaceInstancesWhenResourcesChange = pointer.Bool(false)
+					additionalPVCs = nil
+					generalSettings := cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral]
+					generalSettings.AdditionalVolumeClaims = []fdbv1beta2.AdditionalVolumeClaim{
+						{Name: "spill", MountPath: "/var/fdb/spill"},
+					}
+					cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral] = generalSettings
 				})
 
-				When("the memory is increased", func() {
-					BeforeEach(func() {
-						newMemory, err := resource.ParseQuantity("1Ti")
-						Expect(err).NotTo(HaveOccurred())
-						cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral].PodTemplate.Spec.Containers[0].Resources = corev1.ResourceRequirements{
-							Requests: corev1.ResourceList{
-								corev1.ResourceMemory: newMemory,
-							},
-						}
-					})
+				JustBeforeEach(func() {
+					removalReason, err = processGroupNeedsRemovalForAdditionalPVCs(cluster, log, processGroup, additionalPVCs)
+					needsRemoval = removalReason != nil
+				})
 
+				When("the additional PVC is missing", func() {
 					It("should not need a removal", func() {
-						Expect(needsRemoval).To(BeFalse())
 						Expect(err).NotTo(HaveOccurred())
+						Expect(needsRemoval).To(BeFalse())
 					})
 				})
 
-				When("the memory is decreased", func() {
+				When("the additional PVC matches", func() {
 					BeforeEach(func() {
-						newMemory, err := resource.ParseQuantity("1Ki")
+						pvcs, err := internal.GetAdditionalPvcs(cluster, processGroup)
 						Expect(err).NotTo(HaveOccurred())
-						cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral].PodTemplate.Spec.Containers[0].Resources = corev1.ResourceRequirements{
-							Requests: corev1.ResourceList{
-								corev1.ResourceMemory: newMemory,
-							},
-						}
+						Expect(pvcs).To(HaveLen(1))
+						additionalPVCs = append(additionalPVCs, *pvcs[0])
 					})
 
 					It("should not need a removal", func() {
-						Expect(needsRemoval).To(BeFalse())
 						Expect(err).NotTo(HaveOccurred())
+						Expect(needsRemoval).To(BeFalse())
 					})
 				})
 
-				When("the CPU is increased", func() {
+				When("the hash of the additional PVC doesn't match", func() {
+					BeforeEach(func() {
+						pvcs, err := internal.GetAdditionalPvcs(cluster, processGroup)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(pvcs).To(HaveLen(1))
+						pvcs[0].Annotations[fdbv1beta2.LastSpecKey] = "1"
+						additionalPVCs = append(additionalPVCs, *pvcs[0])
+					})
+
+					It("should need a removal", func() {
+						Expect(err).NotTo(HaveOccurred())
+						Expect(needsRemoval).To(BeTrue())
+						Expect(removalReason.Type).To(Equal(fdbv1beta2.RemovalReasonPVCChanged))
+					})
+				})
+			})
+
+			When("checking if the volume topology conflicts with the desired Pod spec", func() {
+				var pvc *corev1.PersistentVolumeClaim
+
+				BeforeEach(func() {
+					cluster.Spec.ReplacementTriggerPolicy = &fdbv1beta2.ReplacementTriggerPolicy{
+						VolumeTopologyConflict: pointer.Bool(true),
+					}
+
+					pvc, err = internal.GetPvc(cluster, processGroup)
+					Expect(err).NotTo(HaveOccurred())
+					pvc.Spec.VolumeName = "pv-1337"
+
+					Expect(k8sClient.Create(context.Background(), &corev1.PersistentVolume{
+						ObjectMeta: metav1.ObjectMeta{Name: "pv-1337"},
+						Spec: corev1.PersistentVolumeSpec{
+							NodeAffinity: &corev1.VolumeNodeAffinity{
+								Required: &corev1.NodeSelector{
+									NodeSelectorTerms: []corev1.NodeSelectorTerm{
+										{
+											MatchExpressions: []corev1.NodeSelectorRequirement{
+												{
+													Key:      corev1.LabelTopologyZone,
+													Operator: corev1.NodeSelectorOpIn,
+													Values:   []string{"zone-a"},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					})).To(Succeed())
+
+					for _, zone := range []string{"zone-a", "zone-b"} {
+						Expect(k8sClient.Create(context.Background(), &corev1.Node{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "node-" + zone,
+								Labels: map[string]string{
+									corev1.LabelTopologyZone: zone,
+								},
+							},
+						})).To(Succeed())
+					}
+				})
+
+				JustBeforeEach(func() {
+					removalReason, err = processGroupNeedsRemovalForVolumeTopology(context.Background(), k8sClient, log, cluster, processGroup, pod, pvc)
+					needsRemoval = removalReason != nil
+				})
+
+				When("the Pod spec is unchanged", func() {
+					It("should not need a removal", func() {
+						Expect(err).NotTo(HaveOccurred())
+						Expect(needsRemoval).To(BeFalse())
+					})
+				})
+
+				When("the node selector requires the zone of the volume", func() {
+					BeforeEach(func() {
+						cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral].PodTemplate.Spec.NodeSelector = map[string]string{
+							corev1.LabelTopologyZone: "zone-a",
+						}
+					})
+
+					It("should not need a removal", func() {
+						Expect(err).NotTo(HaveOccurred())
+						Expect(needsRemoval).To(BeFalse())
+					})
+				})
+
+				When("the node selector requires a different zone than the zone of the volume", func() {
+					BeforeEach(func() {
+						cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral].PodTemplate.Spec.NodeSelector = map[string]string{
+							corev1.LabelTopologyZone: "zone-b",
+						}
+					})
+
+					It("should need a removal", func() {
+						Expect(err).NotTo(HaveOccurred())
+						Expect(needsRemoval).To(BeTrue())
+						Expect(removalReason.Type).To(Equal(fdbv1beta2.RemovalReasonVolumeTopologyConflict))
+					})
+
+					When("the replacement trigger is disabled", func() {
+						BeforeEach(func() {
+							cluster.Spec.ReplacementTriggerPolicy = nil
+						})
+
+						It("should not need a removal", func() {
+							Expect(err).NotTo(HaveOccurred())
+							Expect(needsRemoval).To(BeFalse())
+						})
+					})
+				})
+			})
+
+			When("replacement for resource changes is activated", func() {
+				BeforeEach(func() {
+					cluster.Spec.ReplaceInstancesWhenResourcesChange = pointer.Bool(true)
+				})
+
+				When("the memory is increased", func() {
+					BeforeEach(func() {
+						newMemory, err := resource.ParseQuantity("1Ti")
+						Expect(err).NotTo(HaveOccurred())
+						cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral].PodTemplate.Spec.Containers[0].Resources = corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceMemory: newMemory,
+							},
+						}
+					})
+
+					It("should need a removal", func() {
+						Expect(needsRemoval).To(BeTrue())
+						Expect(err).NotTo(HaveOccurred())
+					})
+				})
+
+				When("the memory is decreased", func() {
+					BeforeEach(func() {
+						newMemory, err := resource.ParseQuantity("1Ki")
+						Expect(err).NotTo(HaveOccurred())
+						cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral].PodTemplate.Spec.Containers[0].Resources = corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceMemory: newMemory,
+							},
+						}
+					})
+
+					It("should not need a removal", func() {
+						Expect(needsRemoval).To(BeFalse())
+						Expect(err).NotTo(HaveOccurred())
+					})
+				})
+
+				When("the CPU is increased", func() {
+					BeforeEach(func() {
+						newCPU, err := resource.ParseQuantity("1000")
+						Expect(err).NotTo(HaveOccurred())
+						cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral].PodTemplate.Spec.Containers[0].Resources = corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU: newCPU,
+							},
+						}
+					})
+
+					It("should need a removal", func() {
+						Expect(needsRemoval).To(BeTrue())
+						Expect(err).NotTo(HaveOccurred())
+					})
+				})
+
+				When("the CPU is decreased", func() {
+					BeforeEach(func() {
+						newCPU, err := resource.ParseQuantity("1m")
+						Expect(err).NotTo(HaveOccurred())
+						cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral].PodTemplate.Spec.Containers[0].Resources = corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU: newCPU,
+							},
+						}
+					})
+
+					It("should not need a removal", func() {
+						Expect(needsRemoval).To(BeFalse())
+						Expect(err).NotTo(HaveOccurred())
+					})
+				})
+
+				When("adding another sidecar", func() {
+					BeforeEach(func() {
+						newCPU, err := resource.ParseQuantity("1000")
+						Expect(err).NotTo(HaveOccurred())
+						cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral].PodTemplate.Spec.Containers = append(cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral].PodTemplate.Spec.Containers,
+							corev1.Container{
+								Resources: corev1.ResourceRequirements{
+									Requests: corev1.ResourceList{
+										corev1.ResourceCPU: newCPU,
+									},
+								},
+							})
+					})
+
+					It("should need a removal", func() {
+						Expect(needsRemoval).To(BeTrue())
+						Expect(err).NotTo(HaveOccurred())
+					})
+				})
+			})
+
+			When("replacement for resource changes is deactivated", func() {
+				BeforeEach(func() {
+					cluster.Spec.ReplaceInstancesWhenResourcesChange = pointer.Bool(false)
+				})
+
+				When("the memory is increased", func() {
+					BeforeEach(func() {
+						newMemory, err := resource.ParseQuantity("1Ti")
+						Expect(err).NotTo(HaveOccurred())
+						cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral].PodTemplate.Spec.Containers[0].Resources = corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceMemory: newMemory,
+							},
+						}
+					})
+
+					It("should not need a removal", func() {
+						Expect(needsRemoval).To(BeFalse())
+						Expect(err).NotTo(HaveOccurred())
+					})
+				})
+
+				When("the memory is decreased", func() {
+					BeforeEach(func() {
+						newMemory, err := resource.ParseQuantity("1Ki")
+						Expect(err).NotTo(HaveOccurred())
+						cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral].PodTemplate.Spec.Containers[0].Resources = corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceMemory: newMemory,
+							},
+						}
+					})
+
+					It("should not need a removal", func() {
+						Expect(needsRemoval).To(BeFalse())
+						Expect(err).NotTo(HaveOccurred())
+					})
+				})
+
+				When("the CPU is increased", func() {
 					BeforeEach(func() {
 						newCPU, err := resource.ParseQuantity("1000")
 						Expect(err).NotTo(HaveOccurred())
@@ -505,6 +980,19 @@ var _ = Describe("replace_misconfigured_pods", func() {
 								Expect(needsRemoval).To(BeTrue())
 								Expect(err).NotTo(HaveOccurred())
 							})
+
+							When("the replacement trigger for the security context is disabled", func() {
+								BeforeEach(func() {
+									cluster.Spec.ReplacementTriggerPolicy = &fdbv1beta2.ReplacementTriggerPolicy{
+										SecurityContextChanged: pointer.Bool(false),
+									}
+								})
+
+								It("should *not* need a removal", func() {
+									Expect(needsRemoval).To(BeFalse())
+									Expect(err).NotTo(HaveOccurred())
+								})
+							})
 						})
 
 						When("replaceOnSecurityContextChange is false", func() {
@@ -526,155 +1014,1024 @@ var _ = Describe("replace_misconfigured_pods", func() {
 							pod.Spec.SecurityContext = &corev1.PodSecurityContext{FSGroup: pointer.Int64(1234)}
 						})
 
-						It("should not need a removal (guard against server-side defaults)", func() {
-							Expect(needsRemoval).To(BeFalse())
-							Expect(err).NotTo(HaveOccurred())
-						})
-					})
+						It("should not need a removal (guard against server-side defaults)", func() {
+							Expect(needsRemoval).To(BeFalse())
+							Expect(err).NotTo(HaveOccurred())
+						})
+					})
+				})
+			})
+		})
+
+		When("a log Pod is checked", func() {
+			BeforeEach(func() {
+				processGroupName := fmt.Sprintf("%s-%d", fdbv1beta2.ProcessClassLog, 1337)
+				processGroup = &fdbv1beta2.ProcessGroupStatus{
+					ProcessGroupID: fdbv1beta2.ProcessGroupID(processGroupName),
+					ProcessClass:   fdbv1beta2.ProcessClassLog,
+				}
+
+				pod = &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{
+							fdbv1beta2.FDBProcessGroupIDLabel: processGroupName,
+							fdbv1beta2.FDBProcessClassLabel:   string(processGroup.ProcessClass),
+						},
+						Annotations: map[string]string{},
+					},
+				}
+
+				spec, err := internal.GetPodSpec(cluster, processGroup)
+				Expect(err).NotTo(HaveOccurred())
+
+				pod.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey], err = internal.GetPodSpecHash(cluster, processGroup, spec)
+				Expect(err).NotTo(HaveOccurred())
+
+				pod.Spec = *spec
+				Expect(internal.NormalizeClusterSpec(cluster, deprecationOptions)).NotTo(HaveOccurred())
+			})
+
+			When("the storageServersPerPod is changed for a non storage class process group", func() {
+				BeforeEach(func() {
+					cluster.Spec.StorageServersPerPod = 2
+				})
+
+				It("should not need a removal", func() {
+					Expect(needsRemoval).To(BeFalse())
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+
+			When("the logServersPerPod is changed for a log class process group", func() {
+				BeforeEach(func() {
+					cluster.Spec.LogServersPerPod = 2
+				})
+
+				It("should need a removal", func() {
+					Expect(needsRemoval).To(BeTrue())
+					Expect(err).NotTo(HaveOccurred())
+					Expect(removalReason).To(Equal(&fdbv1beta2.RemovalReason{
+						Type:    fdbv1beta2.RemovalReasonServersPerPodChanged,
+						Message: "serversPerPod has changed from current: 1 to desired: 2",
+					}))
+				})
+
+				When("the replacement trigger for the servers per Pod is disabled", func() {
+					BeforeEach(func() {
+						cluster.Spec.ReplacementTriggerPolicy = &fdbv1beta2.ReplacementTriggerPolicy{
+							ServersPerPodChanged: pointer.Bool(false),
+						}
+					})
+
+					It("should need a removal", func() {
+						Expect(needsRemoval).To(BeTrue())
+						Expect(err).NotTo(HaveOccurred())
+						Expect(removalReason.Type).To(Equal(fdbv1beta2.RemovalReasonServersPerPodChanged))
+					})
+				})
+			})
+
+			When("PodUpdateStrategyTransactionReplacement is set and the PodSpecHash doesn't match for transaction", func() {
+				BeforeEach(func() {
+					pod.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey] = "-1"
+					cluster.Spec.AutomationOptions.PodUpdateStrategy = fdbv1beta2.PodUpdateStrategyTransactionReplacement
+				})
+
+				It("should need a removal", func() {
+					Expect(needsRemoval).To(BeTrue())
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+
+			When("PodUpdateStrategyTransactionReplacement is set and the runtimeClassName changes for transaction", func() {
+				BeforeEach(func() {
+					processSettings := cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral]
+					processSettings.RuntimeClassName = pointer.String("gvisor")
+					cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral] = processSettings
+					cluster.Spec.AutomationOptions.PodUpdateStrategy = fdbv1beta2.PodUpdateStrategyTransactionReplacement
+				})
+
+				It("should not need a removal", func() {
+					Expect(needsRemoval).To(BeFalse())
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				When("the tolerations change too", func() {
+					BeforeEach(func() {
+						processSettings := cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral]
+						processSettings.PodTemplate.Spec.Tolerations = []corev1.Toleration{{Key: "test", Operator: "Exists", Effect: "NoSchedule"}}
+						cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral] = processSettings
+					})
+
+					It("should need a removal", func() {
+						Expect(needsRemoval).To(BeTrue())
+						Expect(err).NotTo(HaveOccurred())
+					})
+				})
+			})
+
+			When("PodUpdateStrategyTransactionReplacement is set and the runtimeClassName was set by an admission controller", func() {
+				BeforeEach(func() {
+					pod.Spec.RuntimeClassName = pointer.String("gvisor")
+					cluster.Spec.AutomationOptions.PodUpdateStrategy = fdbv1beta2.PodUpdateStrategyTransactionReplacement
+				})
+
+				It("should not need a removal", func() {
+					Expect(needsRemoval).To(BeFalse())
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("should not report a change of the runtimeClassName", func() {
+					changed, err := RuntimeClassNameChanged(cluster, processGroup, pod)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(changed).To(BeFalse())
+				})
+			})
+
+			When("process group ID prefix changes", func() {
+				BeforeEach(func() {
+					// Change the process group ID should trigger a removal
+					cluster.Spec.ProcessGroupIDPrefix = "test"
+				})
+
+				It("should need a removal", func() {
+					Expect(needsRemoval).To(BeTrue())
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+		})
+
+		When("a reassigned Pod is checked", func() {
+			BeforeEach(func() {
+				processGroupName := fmt.Sprintf("%s-%d", fdbv1beta2.ProcessClassStateless, 1337)
+				processGroup = &fdbv1beta2.ProcessGroupStatus{
+					ProcessGroupID: fdbv1beta2.ProcessGroupID(processGroupName),
+					ProcessClass:   fdbv1beta2.ProcessClassStateless,
+				}
+
+				pod = &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{
+							fdbv1beta2.FDBProcessGroupIDLabel: processGroupName,
+							// The labels are updated to the new process class before the Pod is recreated.
+							fdbv1beta2.FDBProcessClassLabel: string(fdbv1beta2.ProcessClassProxy),
+						},
+						Annotations: map[string]string{},
+					},
+				}
+
+				spec, err := internal.GetPodSpec(cluster, processGroup)
+				Expect(err).NotTo(HaveOccurred())
+
+				pod.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey], err = internal.GetPodSpecHash(cluster, processGroup, spec)
+				Expect(err).NotTo(HaveOccurred())
+
+				pod.Spec = *spec
+				processGroup.ReassignProcessClass(fdbv1beta2.ProcessClassProxy)
+			})
+
+			When("only the process class was changed", func() {
+				It("should not need a removal", func() {
+					Expect(needsRemoval).To(BeFalse())
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+
+			When("the security context was changed", func() {
+				BeforeEach(func() {
+					processSettings := cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral]
+					processSettings.PodTemplate.Spec.SecurityContext = &corev1.PodSecurityContext{FSGroup: pointer.Int64(1234)}
+					cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral] = processSettings
+				})
+
+				It("should need a removal", func() {
+					Expect(needsRemoval).To(BeTrue())
+					Expect(err).NotTo(HaveOccurred())
+					Expect(removalReason.Type).To(Equal(fdbv1beta2.RemovalReasonPodSpecChanged))
+				})
+			})
+
+			When("the Pod was recreated with the new process class", func() {
+				BeforeEach(func() {
+					spec, err := internal.GetPodSpec(cluster, processGroup)
+					Expect(err).NotTo(HaveOccurred())
+
+					pod.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey], err = internal.GetPodSpecHash(cluster, processGroup, spec)
+					Expect(err).NotTo(HaveOccurred())
+
+					pod.Spec = *spec
+				})
+
+				It("should not need a removal", func() {
+					Expect(needsRemoval).To(BeFalse())
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("should use the process group for the Pod spec", func() {
+					Expect(getProcessGroupForPodSpec(cluster, processGroup, pod)).To(Equal(processGroup))
+				})
+			})
+
+			It("should use the previous process class for the Pod spec", func() {
+				Expect(getMonitorConfProcessClass(pod)).To(Equal(fdbv1beta2.ProcessClassStateless))
+				Expect(getProcessGroupForPodSpec(cluster, processGroup, pod).ProcessClass).To(Equal(fdbv1beta2.ProcessClassStateless))
+			})
+		})
+	})
+
+	DescribeTable("prioritizing the replacement candidates", func(priorityOrder []fdbv1beta2.ProcessClass, failing []fdbv1beta2.ProcessGroupID, expected []fdbv1beta2.ProcessGroupID) {
+		cluster.Spec.AutomationOptions.Replacements.PriorityOrder = priorityOrder
+
+		candidates := []*fdbv1beta2.ProcessGroupStatus{
+			fdbv1beta2.NewProcessGroupStatus("storage-1", fdbv1beta2.ProcessClassStorage, nil),
+			fdbv1beta2.NewProcessGroupStatus("log-1", fdbv1beta2.ProcessClassLog, nil),
+			fdbv1beta2.NewProcessGroupStatus("stateless-1", fdbv1beta2.ProcessClassStateless, nil),
+			fdbv1beta2.NewProcessGroupStatus("storage-2", fdbv1beta2.ProcessClassStorage, nil),
+			fdbv1beta2.NewProcessGroupStatus("stateless-2", fdbv1beta2.ProcessClassStateless, nil),
+		}
+
+		failingProcessGroups := map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None{}
+		for _, processGroupID := range failing {
+			failingProcessGroups[processGroupID] = fdbv1beta2.None{}
+		}
+
+		for _, candidate := range candidates {
+			if _, ok := failingProcessGroups[candidate.ProcessGroupID]; ok {
+				candidate.ProcessGroupConditions = append(candidate.ProcessGroupConditions, &fdbv1beta2.ProcessGroupCondition{
+					ProcessGroupConditionType: fdbv1beta2.PodFailing,
+					Timestamp:                 time.Now().Add(-3 * time.Hour).Unix(),
+				})
+			}
+		}
+
+		prioritizeReplacementCandidates(cluster, candidates)
+
+		result := make([]fdbv1beta2.ProcessGroupID, 0, len(candidates))
+		for _, candidate := range candidates {
+			result = append(result, candidate.ProcessGroupID)
+		}
+
+		Expect(result).To(Equal(expected))
+	},
+		Entry("no priority order and no failing process groups",
+			nil,
+			nil,
+			[]fdbv1beta2.ProcessGroupID{"stateless-1", "stateless-2", "storage-1", "log-1", "storage-2"},
+		),
+		Entry("no priority order and a failing storage process group",
+			nil,
+			[]fdbv1beta2.ProcessGroupID{"storage-2"},
+			[]fdbv1beta2.ProcessGroupID{"storage-2", "stateless-1", "stateless-2", "storage-1", "log-1"},
+		),
+		Entry("a priority order with the log process class",
+			[]fdbv1beta2.ProcessClass{fdbv1beta2.ProcessClassLog},
+			nil,
+			[]fdbv1beta2.ProcessGroupID{"log-1", "storage-1", "stateless-1", "storage-2", "stateless-2"},
+		),
+		Entry("a priority order with multiple process classes and a failing process group",
+			[]fdbv1beta2.ProcessClass{fdbv1beta2.ProcessClassStorage, fdbv1beta2.ProcessClassStateless},
+			[]fdbv1beta2.ProcessGroupID{"log-1"},
+			[]fdbv1beta2.ProcessGroupID{"log-1", "storage-1", "storage-2", "stateless-1", "stateless-2"},
+		),
+	)
+
+	When("using MaxConcurrentMisconfiguredReplacements", func() {
+		var pvcMap map[fdbv1beta2.ProcessGroupID]corev1.PersistentVolumeClaim
+
+		BeforeEach(func() {
+			pvcMap = map[fdbv1beta2.ProcessGroupID]corev1.PersistentVolumeClaim{}
+
+			for i := 0; i < 10; i++ {
+				_, id := cluster.GetProcessGroupID(fdbv1beta2.ProcessClassStorage, i)
+				processGroup := &fdbv1beta2.ProcessGroupStatus{
+					ProcessClass:   fdbv1beta2.ProcessClassStorage,
+					ProcessGroupID: id,
+				}
+				newPVC, err := internal.GetPvc(cluster, processGroup)
+				Expect(err).NotTo(HaveOccurred())
+				pvcMap[id] = *newPVC
+				newPod, err := internal.GetPod(cluster, processGroup)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(k8sClient.Create(context.Background(), newPod)).NotTo(HaveOccurred())
+				// Populate process groups
+				cluster.Status.ProcessGroups = append(cluster.Status.ProcessGroups, fdbv1beta2.NewProcessGroupStatus(id, fdbv1beta2.ProcessClassStorage, nil))
+			}
+
+			for i := 0; i < 1; i++ {
+				_, id := cluster.GetProcessGroupID(fdbv1beta2.ProcessClassTransaction, i)
+				processGroup := &fdbv1beta2.ProcessGroupStatus{
+					ProcessClass:   fdbv1beta2.ProcessClassTransaction,
+					ProcessGroupID: id,
+				}
+
+				newPVC, err := internal.GetPvc(cluster, processGroup)
+				Expect(err).NotTo(HaveOccurred())
+				pvcMap[id] = *newPVC
+				newPod, err := internal.GetPod(cluster, processGroup)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(k8sClient.Create(context.Background(), newPod)).NotTo(HaveOccurred())
+				// Populate process groups
+				cluster.Status.ProcessGroups = append(cluster.Status.ProcessGroups, fdbv1beta2.NewProcessGroupStatus(id, fdbv1beta2.ProcessClassTransaction, nil))
+			}
+
+			// Force a replacement of all processes
+			cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral].PodTemplate.Spec.NodeSelector = map[string]string{
+				"dummy": "test",
+			}
+		})
+
+		When("No replacements are allowed", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.MaxConcurrentReplacements = pointer.Int(0)
+			})
+
+			It("should not have a replacements", func() {
+				hasReplacement, err := ReplaceMisconfiguredProcessGroups(context.Background(), podmanager.StandardPodLifecycleManager{}, k8sClient, log, cluster, pvcMap, nil, true, ReplacementOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hasReplacement).To(BeFalse())
+
+				cntReplacements := 0
+				for _, pGroup := range cluster.Status.ProcessGroups {
+					if !pGroup.IsMarkedForRemoval() {
+						continue
+					}
+
+					cntReplacements++
+				}
+
+				Expect(cntReplacements).To(BeNumerically("==", 0))
+			})
+		})
+
+		When("Two replacements are allowed", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.MaxConcurrentReplacements = pointer.Int(2)
+			})
+
+			It("should have two replacements", func() {
+				hasReplacement, err := ReplaceMisconfiguredProcessGroups(context.Background(), podmanager.StandardPodLifecycleManager{}, k8sClient, log, cluster, pvcMap, nil, true, ReplacementOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hasReplacement).To(BeTrue())
+
+				cntReplacements := 0
+				for _, pGroup := range cluster.Status.ProcessGroups {
+					if !pGroup.IsMarkedForRemoval() {
+						continue
+					}
+
+					cntReplacements++
+				}
+
+				Expect(cntReplacements).To(BeNumerically("==", 2))
+			})
+		})
+
+		When("Two replacements are allowed and the first process groups are in the maintenance zone", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.MaxConcurrentReplacements = pointer.Int(2)
+				cluster.Status.ProcessGroups[0].FaultDomain = "zone-a"
+				cluster.Status.ProcessGroups[1].FaultDomain = "zone-a"
+			})
+
+			It("should defer the replacements in the maintenance zone and replace process groups in other zones", func() {
+				hasReplacement, err := ReplaceMisconfiguredProcessGroups(context.Background(), podmanager.StandardPodLifecycleManager{}, k8sClient, log, cluster, pvcMap, nil, true, ReplacementOptions{MaintenanceZone: "zone-a"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hasReplacement).To(BeTrue())
+
+				cntReplacements := 0
+				for _, pGroup := range cluster.Status.ProcessGroups {
+					if !pGroup.IsMarkedForRemoval() {
+						continue
+					}
+
+					Expect(pGroup.FaultDomain).NotTo(Equal(fdbv1beta2.FaultDomain("zone-a")))
+					cntReplacements++
+				}
+
+				Expect(cntReplacements).To(BeNumerically("==", 2))
+			})
+		})
+
+		When("Two replacements are allowed and the first process group is protected", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.MaxConcurrentReplacements = pointer.Int(2)
+				cluster.Spec.ProtectedProcessGroups = []fdbv1beta2.ProcessGroupID{cluster.Status.ProcessGroups[0].ProcessGroupID}
+			})
+
+			It("should not replace the protected process group", func() {
+				protection, err := NewProtection(context.Background(), k8sClient, cluster)
+				Expect(err).NotTo(HaveOccurred())
+
+				hasReplacement, err := ReplaceMisconfiguredProcessGroups(context.Background(), podmanager.StandardPodLifecycleManager{}, k8sClient, log, cluster, pvcMap, nil, true, ReplacementOptions{Protection: protection})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hasReplacement).To(BeTrue())
+
+				cntReplacements := 0
+				for _, pGroup := range cluster.Status.ProcessGroups {
+					if !pGroup.IsMarkedForRemoval() {
+						continue
+					}
+
+					cntReplacements++
+				}
+
+				Expect(cntReplacements).To(BeNumerically("==", 2))
+				Expect(cluster.Status.ProcessGroups[0].IsMarkedForRemoval()).To(BeFalse())
+				Expect(protection.Skipped()).To(ConsistOf(cluster.Status.ProcessGroups[0].ProcessGroupID))
+			})
+		})
+
+		When("Two replacements are allowed and the process groups are checked concurrently", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.MaxConcurrentReplacements = pointer.Int(2)
+			})
+
+			It("should replace the same process groups as the serial checks", func() {
+				serialCluster := cluster.DeepCopy()
+				hasReplacement, err := ReplaceMisconfiguredProcessGroups(context.Background(), podmanager.StandardPodLifecycleManager{}, k8sClient, log, serialCluster, pvcMap, nil, true, ReplacementOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hasReplacement).To(BeTrue())
+
+				hasReplacement, err = ReplaceMisconfiguredProcessGroups(context.Background(), podmanager.StandardPodLifecycleManager{}, k8sClient, log, cluster, pvcMap, nil, true, ReplacementOptions{MaxConcurrentChecks: 4})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hasReplacement).To(BeTrue())
+
+				var serialReplacements []fdbv1beta2.ProcessGroupID
+				for _, pGroup := range serialCluster.Status.ProcessGroups {
+					if pGroup.IsMarkedForRemoval() {
+						serialReplacements = append(serialReplacements, pGroup.ProcessGroupID)
+					}
+				}
+
+				var replacements []fdbv1beta2.ProcessGroupID
+				for _, pGroup := range cluster.Status.ProcessGroups {
+					if pGroup.IsMarkedForRemoval() {
+						replacements = append(replacements, pGroup.ProcessGroupID)
+					}
+				}
+
+				Expect(serialReplacements).To(HaveLen(2))
+				Expect(replacements).To(Equal(serialReplacements))
+			})
+		})
+
+		When("one replacement is allowed and a storage process group is failing", func() {
+			var failingProcessGroup *fdbv1beta2.ProcessGroupStatus
+
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.MaxConcurrentReplacements = pointer.Int(1)
+				failingProcessGroup = cluster.Status.ProcessGroups[5]
+				failingProcessGroup.ProcessGroupConditions = append(failingProcessGroup.ProcessGroupConditions, &fdbv1beta2.ProcessGroupCondition{
+					ProcessGroupConditionType: fdbv1beta2.PodFailing,
+					Timestamp:                 time.Now().Add(-3 * time.Hour).Unix(),
+				})
+			})
+
+			It("should replace the failing process group first", func() {
+				hasReplacement, err := ReplaceMisconfiguredProcessGroups(context.Background(), podmanager.StandardPodLifecycleManager{}, k8sClient, log, cluster, pvcMap, nil, true, ReplacementOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hasReplacement).To(BeTrue())
+
+				var replacements []fdbv1beta2.ProcessGroupID
+				for _, pGroup := range cluster.Status.ProcessGroups {
+					if pGroup.IsMarkedForRemoval() {
+						replacements = append(replacements, pGroup.ProcessGroupID)
+					}
+				}
+
+				Expect(replacements).To(ConsistOf(failingProcessGroup.ProcessGroupID))
+			})
+		})
+
+		When("one replacement is allowed and a priority order is defined", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.MaxConcurrentReplacements = pointer.Int(1)
+				cluster.Spec.AutomationOptions.Replacements.PriorityOrder = []fdbv1beta2.ProcessClass{
+					fdbv1beta2.ProcessClassTransaction,
+				}
+			})
+
+			It("should replace the transaction process group first", func() {
+				hasReplacement, err := ReplaceMisconfiguredProcessGroups(context.Background(), podmanager.StandardPodLifecycleManager{}, k8sClient, log, cluster, pvcMap, nil, true, ReplacementOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hasReplacement).To(BeTrue())
+
+				var replacements []fdbv1beta2.ProcessClass
+				for _, pGroup := range cluster.Status.ProcessGroups {
+					if pGroup.IsMarkedForRemoval() {
+						replacements = append(replacements, pGroup.ProcessClass)
+					}
+				}
+
+				Expect(replacements).To(ConsistOf(fdbv1beta2.ProcessClassTransaction))
+			})
+		})
+
+		When("a limit for the storage process class is defined", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.MaxConcurrentReplacements = pointer.Int(5)
+				cluster.Spec.AutomationOptions.Replacements.MaxConcurrentPerClass = map[fdbv1beta2.ProcessClass]int{
+					fdbv1beta2.ProcessClassStorage: 1,
+				}
+			})
+
+			It("should replace one storage and the transaction process group", func() {
+				hasReplacement, err := ReplaceMisconfiguredProcessGroups(context.Background(), podmanager.StandardPodLifecycleManager{}, k8sClient, log, cluster, pvcMap, nil, true, ReplacementOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hasReplacement).To(BeTrue())
+
+				replacements := map[fdbv1beta2.ProcessClass]int{}
+				for _, pGroup := range cluster.Status.ProcessGroups {
+					if !pGroup.IsMarkedForRemoval() {
+						continue
+					}
+
+					replacements[pGroup.ProcessClass]++
+				}
+
+				Expect(replacements).To(Equal(map[fdbv1beta2.ProcessClass]int{
+					fdbv1beta2.ProcessClassStorage:     1,
+					fdbv1beta2.ProcessClassTransaction: 1,
+				}))
+			})
+		})
+
+		When("the storage class is changed and a limit for storage class migrations is defined", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.MaxConcurrentReplacements = pointer.Int(5)
+				cluster.Spec.AutomationOptions.Replacements.MaxConcurrentStorageClassMigrations = pointer.Int(2)
+				processSettings := cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral]
+				processSettings.VolumeClaimTemplate = &corev1.PersistentVolumeClaim{
+					Spec: corev1.PersistentVolumeClaimSpec{
+						StorageClassName: pointer.String("fast"),
+					},
+				}
+				cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral] = processSettings
+			})
+
+			It("should only replace two process groups", func() {
+				hasReplacement, err := ReplaceMisconfiguredProcessGroups(context.Background(), podmanager.StandardPodLifecycleManager{}, k8sClient, log, cluster, pvcMap, nil, true, ReplacementOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hasReplacement).To(BeTrue())
+
+				cntReplacements := 0
+				for _, pGroup := range cluster.Status.ProcessGroups {
+					if !pGroup.IsMarkedForRemoval() {
+						continue
+					}
+
+					Expect(pGroup.RemovalReason.Type).To(Equal(fdbv1beta2.RemovalReasonStorageClassChanged))
+					cntReplacements++
+				}
+
+				Expect(cntReplacements).To(BeNumerically("==", 2))
+			})
+
+			When("a storage class migration is ongoing", func() {
+				BeforeEach(func() {
+					cluster.Status.ProcessGroups[0].MarkForRemovalWithReason(&fdbv1beta2.RemovalReason{Type: fdbv1beta2.RemovalReasonStorageClassChanged})
+				})
+
+				It("should only replace one additional process group", func() {
+					_, err := ReplaceMisconfiguredProcessGroups(context.Background(), podmanager.StandardPodLifecycleManager{}, k8sClient, log, cluster, pvcMap, nil, true, ReplacementOptions{})
+					Expect(err).NotTo(HaveOccurred())
+
+					cntReplacements := 0
+					for _, pGroup := range cluster.Status.ProcessGroups {
+						if pGroup.IsMarkedForRemoval() {
+							cntReplacements++
+						}
+					}
+
+					Expect(cntReplacements).To(BeNumerically("==", 2))
+				})
+			})
+		})
+
+		When("the nodes are outdated and a limit for node version skew replacements is defined", func() {
+			var node *corev1.Node
+
+			BeforeEach(func() {
+				cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral].PodTemplate.Spec.NodeSelector = nil
+				cluster.Spec.AutomationOptions.MaxConcurrentReplacements = pointer.Int(5)
+				cluster.Spec.AutomationOptions.Replacements.NodeVersionSkew = &fdbv1beta2.NodeVersionSkewOptions{
+					MinimumKubeletVersion:     "v1.28.0",
+					MaxConcurrentReplacements: pointer.Int(2),
+				}
+
+				node = &corev1.Node{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "node-1",
+					},
+					Status: corev1.NodeStatus{
+						NodeInfo: corev1.NodeSystemInfo{
+							KubeletVersion: "v1.27.5",
+						},
+					},
+				}
+				Expect(k8sClient.Create(context.Background(), node)).To(Succeed())
+
+				pods := &corev1.PodList{}
+				Expect(k8sClient.List(context.Background(), pods)).To(Succeed())
+				for _, pod := range pods.Items {
+					pod.Spec.NodeName = node.Name
+					Expect(k8sClient.Update(context.Background(), &pod)).To(Succeed())
+				}
+			})
+
+			It("should only replace two process groups", func() {
+				hasReplacement, err := ReplaceMisconfiguredProcessGroups(context.Background(), podmanager.StandardPodLifecycleManager{}, k8sClient, log, cluster, pvcMap, nil, true, ReplacementOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hasReplacement).To(BeTrue())
+
+				cntReplacements := 0
+				for _, pGroup := range cluster.Status.ProcessGroups {
+					if !pGroup.IsMarkedForRemoval() {
+						continue
+					}
+
+					Expect(pGroup.RemovalReason.Type).To(Equal(fdbv1beta2.RemovalReasonNodeVersionSkew))
+					Expect(pGroup.RemovalReason.Message).To(Equal("node node-1 is outdated: kubelet version v1.27.5 is older than v1.28.0"))
+					cntReplacements++
+				}
+
+				Expect(cntReplacements).To(BeNumerically("==", 2))
+			})
+
+			When("the node runs the minimum version", func() {
+				BeforeEach(func() {
+					node.Status.NodeInfo.KubeletVersion = "v1.28.0-eks-1"
+					Expect(k8sClient.Update(context.Background(), node)).To(Succeed())
+				})
+
+				It("should not replace any process group", func() {
+					hasReplacement, err := ReplaceMisconfiguredProcessGroups(context.Background(), podmanager.StandardPodLifecycleManager{}, k8sClient, log, cluster, pvcMap, nil, true, ReplacementOptions{})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(hasReplacement).To(BeFalse())
+				})
+			})
+		})
+
+		When("the replacements respect PodDisruptionBudgets", func() {
+			var pdb *policyv1.PodDisruptionBudget
+
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.MaxConcurrentReplacements = pointer.Int(5)
+				cluster.Spec.AutomationOptions.Replacements.RespectPodDisruptionBudgets = pointer.Bool(true)
+				pdb = &policyv1.PodDisruptionBudget{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "storage",
+						Namespace: cluster.Namespace,
+					},
+					Spec: policyv1.PodDisruptionBudgetSpec{
+						Selector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								cluster.GetProcessClassLabel(): string(fdbv1beta2.ProcessClassStorage),
+							},
+						},
+					},
+					Status: policyv1.PodDisruptionBudgetStatus{
+						// The mock client increases the generation during the creation.
+						ObservedGeneration: 1,
+						DisruptionsAllowed: 1,
+					},
+				}
+			})
+
+			JustBeforeEach(func() {
+				Expect(k8sClient.Create(context.Background(), pdb)).To(Succeed())
+			})
+
+			It("should replace one storage and the transaction process group", func() {
+				hasReplacement, err := ReplaceMisconfiguredProcessGroups(context.Background(), podmanager.StandardPodLifecycleManager{}, k8sClient, log, cluster, pvcMap, nil, true, ReplacementOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hasReplacement).To(BeTrue())
+
+				replacements := map[fdbv1beta2.ProcessClass]int{}
+				deferred := 0
+				for _, pGroup := range cluster.Status.ProcessGroups {
+					if pGroup.GetConditionTime(fdbv1beta2.ReplacementDeferredByDisruptionBudget) != nil {
+						Expect(pGroup.IsMarkedForRemoval()).To(BeFalse())
+						Expect(pGroup.ProcessClass).To(Equal(fdbv1beta2.ProcessClassStorage))
+						deferred++
+					}
+
+					if !pGroup.IsMarkedForRemoval() {
+						continue
+					}
+
+					replacements[pGroup.ProcessClass]++
+				}
+
+				Expect(replacements).To(Equal(map[fdbv1beta2.ProcessClass]int{
+					fdbv1beta2.ProcessClassStorage:     1,
+					fdbv1beta2.ProcessClassTransaction: 1,
+				}))
+				Expect(deferred).To(BeNumerically("==", 9))
+			})
+
+			When("a storage process group is already marked for removal", func() {
+				BeforeEach(func() {
+					cluster.Status.ProcessGroups[0].MarkForRemoval()
+				})
+
+				It("should only replace the transaction process group", func() {
+					hasReplacement, err := ReplaceMisconfiguredProcessGroups(context.Background(), podmanager.StandardPodLifecycleManager{}, k8sClient, log, cluster, pvcMap, nil, true, ReplacementOptions{})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(hasReplacement).To(BeTrue())
+
+					replacements := map[fdbv1beta2.ProcessClass]int{}
+					for _, pGroup := range cluster.Status.ProcessGroups {
+						if !pGroup.IsMarkedForRemoval() {
+							continue
+						}
+
+						replacements[pGroup.ProcessClass]++
+					}
+
+					Expect(replacements).To(Equal(map[fdbv1beta2.ProcessClass]int{
+						fdbv1beta2.ProcessClassStorage:     1,
+						fdbv1beta2.ProcessClassTransaction: 1,
+					}))
+				})
+			})
+
+			When("the status of the PodDisruptionBudget is outdated", func() {
+				BeforeEach(func() {
+					pdb.Generation = 1
+					pdb.Status.ObservedGeneration = 1
+				})
+
+				It("should not replace any storage process group", func() {
+					hasReplacement, err := ReplaceMisconfiguredProcessGroups(context.Background(), podmanager.StandardPodLifecycleManager{}, k8sClient, log, cluster, pvcMap, nil, true, ReplacementOptions{})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(hasReplacement).To(BeTrue())
+
+					for _, pGroup := range cluster.Status.ProcessGroups {
+						if pGroup.ProcessClass != fdbv1beta2.ProcessClassStorage {
+							continue
+						}
+
+						Expect(pGroup.IsMarkedForRemoval()).To(BeFalse())
+					}
+				})
+			})
+
+			When("the PodDisruptionBudgets are not respected anymore", func() {
+				It("should remove the deferred conditions", func() {
+					_, err := ReplaceMisconfiguredProcessGroups(context.Background(), podmanager.StandardPodLifecycleManager{}, k8sClient, log, cluster, pvcMap, nil, true, ReplacementOptions{})
+					Expect(err).NotTo(HaveOccurred())
+
+					cluster.Spec.AutomationOptions.Replacements.RespectPodDisruptionBudgets = nil
+					cluster.Spec.AutomationOptions.MaxConcurrentReplacements = pointer.Int(0)
+					hasReplacement, err := ReplaceMisconfiguredProcessGroups(context.Background(), podmanager.StandardPodLifecycleManager{}, k8sClient, log, cluster, pvcMap, nil, true, ReplacementOptions{})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(hasReplacement).To(BeTrue())
+
+					for _, pGroup := range cluster.Status.ProcessGroups {
+						Expect(pGroup.GetConditionTime(fdbv1beta2.ReplacementDeferredByDisruptionBudget)).To(BeNil())
+					}
 				})
 			})
 		})
 
-		When("a log Pod is checked", func() {
-			BeforeEach(func() {
-				processGroupName := fmt.Sprintf("%s-%d", fdbv1beta2.ProcessClassLog, 1337)
-				processGroup = &fdbv1beta2.ProcessGroupStatus{
-					ProcessGroupID: fdbv1beta2.ProcessGroupID(processGroupName),
-					ProcessClass:   fdbv1beta2.ProcessClassLog,
-				}
+		When("the replacements are batched by fault domain", func() {
+			var replacedFaultDomains map[fdbv1beta2.FaultDomain]int
 
-				pod = &corev1.Pod{
-					ObjectMeta: metav1.ObjectMeta{
-						Labels: map[string]string{
-							fdbv1beta2.FDBProcessGroupIDLabel: processGroupName,
-							fdbv1beta2.FDBProcessClassLabel:   string(processGroup.ProcessClass),
-						},
-						Annotations: map[string]string{},
-					},
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.MaxConcurrentReplacements = pointer.Int(5)
+				cluster.Spec.AutomationOptions.Replacements.BatchByFaultDomain = pointer.Bool(true)
+				for idx, processGroup := range cluster.Status.ProcessGroups {
+					processGroup.FaultDomain = fdbv1beta2.FaultDomain(fmt.Sprintf("zone-%d", idx%3))
 				}
+			})
 
-				spec, err := internal.GetPodSpec(cluster, processGroup)
+			JustBeforeEach(func() {
+				_, err := ReplaceMisconfiguredProcessGroups(context.Background(), podmanager.StandardPodLifecycleManager{}, k8sClient, log, cluster, pvcMap, nil, true, ReplacementOptions{})
 				Expect(err).NotTo(HaveOccurred())
 
-				pod.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey], err = internal.GetPodSpecHash(cluster, processGroup, spec)
-				Expect(err).NotTo(HaveOccurred())
+				replacedFaultDomains = map[fdbv1beta2.FaultDomain]int{}
+				for _, pGroup := range cluster.Status.ProcessGroups {
+					if !pGroup.IsMarkedForRemoval() {
+						continue
+					}
 
-				pod.Spec = *spec
-				Expect(internal.NormalizeClusterSpec(cluster, deprecationOptions)).NotTo(HaveOccurred())
+					replacedFaultDomains[pGroup.FaultDomain]++
+				}
 			})
 
-			When("the storageServersPerPod is changed for a non storage class process group", func() {
+			When("no replacements are ongoing", func() {
+				It("should only replace the process groups of the first fault domain", func() {
+					Expect(replacedFaultDomains).To(Equal(map[fdbv1beta2.FaultDomain]int{
+						"zone-0": 4,
+					}))
+				})
+			})
+
+			When("a replacement is ongoing in another fault domain", func() {
 				BeforeEach(func() {
-					cluster.Spec.StorageServersPerPod = 2
+					cluster.Status.ProcessGroups[1].MarkForRemoval()
 				})
 
-				It("should not need a removal", func() {
-					Expect(needsRemoval).To(BeFalse())
-					Expect(err).NotTo(HaveOccurred())
+				It("should only replace the process groups of the fault domain with the ongoing replacement", func() {
+					Expect(replacedFaultDomains).To(Equal(map[fdbv1beta2.FaultDomain]int{
+						"zone-1": 4,
+					}))
 				})
 			})
 
-			When("PodUpdateStrategyTransactionReplacement is set and the PodSpecHash doesn't match for transaction", func() {
+			When("replacements are ongoing in multiple fault domains", func() {
 				BeforeEach(func() {
-					pod.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey] = "-1"
-					cluster.Spec.AutomationOptions.PodUpdateStrategy = fdbv1beta2.PodUpdateStrategyTransactionReplacement
+					cluster.Status.ProcessGroups[1].MarkForRemoval()
+					cluster.Status.ProcessGroups[2].MarkForRemoval()
 				})
 
-				It("should need a removal", func() {
-					Expect(needsRemoval).To(BeTrue())
-					Expect(err).NotTo(HaveOccurred())
+				It("should not replace any additional process groups", func() {
+					Expect(replacedFaultDomains).To(Equal(map[fdbv1beta2.FaultDomain]int{
+						"zone-1": 1,
+						"zone-2": 1,
+					}))
 				})
 			})
+		})
 
-			When("process group ID prefix changes", func() {
+		When("the replacements are running in dry-run mode", func() {
+			var hasChanges bool
+
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.Replacements.DryRun = pointer.Bool(true)
+
+				var err error
+				hasChanges, err = ReplaceMisconfiguredProcessGroups(context.Background(), podmanager.StandardPodLifecycleManager{}, k8sClient, log, cluster, pvcMap, nil, true, ReplacementOptions{})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should only record the pending replacements in the cluster status", func() {
+				Expect(hasChanges).To(BeTrue())
+				Expect(cluster.Status.DryRunReplacements).To(HaveLen(len(cluster.Status.ProcessGroups)))
+				for idx, pGroup := range cluster.Status.ProcessGroups {
+					Expect(pGroup.IsMarkedForRemoval()).To(BeFalse())
+					Expect(cluster.Status.DryRunReplacements[idx].ProcessGroupID).To(Equal(pGroup.ProcessGroupID))
+					Expect(cluster.Status.DryRunReplacements[idx].Reason.Type).NotTo(BeEmpty())
+				}
+			})
+
+			When("the dry-run is executed again", func() {
 				BeforeEach(func() {
-					// Change the process group ID should trigger a removal
-					cluster.Spec.ProcessGroupIDPrefix = "test"
+					var err error
+					hasChanges, err = ReplaceMisconfiguredProcessGroups(context.Background(), podmanager.StandardPodLifecycleManager{}, k8sClient, log, cluster, pvcMap, nil, true, ReplacementOptions{})
+					Expect(err).NotTo(HaveOccurred())
 				})
 
-				It("should need a removal", func() {
-					Expect(needsRemoval).To(BeTrue())
+				It("should not change the cluster status", func() {
+					Expect(hasChanges).To(BeFalse())
+					Expect(cluster.Status.DryRunReplacements).To(HaveLen(len(cluster.Status.ProcessGroups)))
+				})
+			})
+
+			When("the dry-run mode is disabled", func() {
+				BeforeEach(func() {
+					cluster.Spec.AutomationOptions.Replacements.DryRun = nil
+					cluster.Spec.AutomationOptions.MaxConcurrentReplacements = pointer.Int(0)
+
+					var err error
+					hasChanges, err = ReplaceMisconfiguredProcessGroups(context.Background(), podmanager.StandardPodLifecycleManager{}, k8sClient, log, cluster, pvcMap, nil, true, ReplacementOptions{})
 					Expect(err).NotTo(HaveOccurred())
 				})
+
+				It("should remove the recorded replacements", func() {
+					Expect(hasChanges).To(BeTrue())
+					Expect(cluster.Status.DryRunReplacements).To(BeEmpty())
+					for _, pGroup := range cluster.Status.ProcessGroups {
+						Expect(pGroup.IsMarkedForRemoval()).To(BeFalse())
+					}
+				})
 			})
 		})
-	})
 
-	When("using MaxConcurrentMisconfiguredReplacements", func() {
-		var pvcMap map[fdbv1beta2.ProcessGroupID]corev1.PersistentVolumeClaim
+		When("the replacements require an approval", func() {
+			var hasChanges bool
 
-		BeforeEach(func() {
-			pvcMap = map[fdbv1beta2.ProcessGroupID]corev1.PersistentVolumeClaim{}
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.Replacements.RequireApproval = pointer.Bool(true)
+				cluster.Spec.ApprovedReplacements = []fdbv1beta2.ProcessGroupID{cluster.Status.ProcessGroups[0].ProcessGroupID}
 
-			for i := 0; i < 10; i++ {
-				_, id := cluster.GetProcessGroupID(fdbv1beta2.ProcessClassStorage, i)
-				processGroup := &fdbv1beta2.ProcessGroupStatus{
-					ProcessClass:   fdbv1beta2.ProcessClassStorage,
-					ProcessGroupID: id,
-				}
-				newPVC, err := internal.GetPvc(cluster, processGroup)
-				Expect(err).NotTo(HaveOccurred())
-				pvcMap[id] = *newPVC
-				newPod, err := internal.GetPod(cluster, processGroup)
+				var err error
+				hasChanges, err = ReplaceMisconfiguredProcessGroups(context.Background(), podmanager.StandardPodLifecycleManager{}, k8sClient, log, cluster, pvcMap, nil, true, ReplacementOptions{})
 				Expect(err).NotTo(HaveOccurred())
-				Expect(k8sClient.Create(context.Background(), newPod)).NotTo(HaveOccurred())
-				// Populate process groups
-				cluster.Status.ProcessGroups = append(cluster.Status.ProcessGroups, fdbv1beta2.NewProcessGroupStatus(id, fdbv1beta2.ProcessClassStorage, nil))
-			}
+			})
 
-			for i := 0; i < 1; i++ {
-				_, id := cluster.GetProcessGroupID(fdbv1beta2.ProcessClassTransaction, i)
-				processGroup := &fdbv1beta2.ProcessGroupStatus{
-					ProcessClass:   fdbv1beta2.ProcessClassTransaction,
-					ProcessGroupID: id,
+			It("should only replace the approved process group", func() {
+				Expect(hasChanges).To(BeTrue())
+				for idx, pGroup := range cluster.Status.ProcessGroups {
+					if idx == 0 {
+						Expect(pGroup.IsMarkedForRemoval()).To(BeTrue())
+						Expect(pGroup.GetConditionTime(fdbv1beta2.PendingReplacementApproval)).To(BeNil())
+						continue
+					}
+
+					Expect(pGroup.IsMarkedForRemoval()).To(BeFalse())
+					Expect(pGroup.GetConditionTime(fdbv1beta2.PendingReplacementApproval)).NotTo(BeNil())
 				}
+			})
 
-				newPVC, err := internal.GetPvc(cluster, processGroup)
-				Expect(err).NotTo(HaveOccurred())
-				pvcMap[id] = *newPVC
-				newPod, err := internal.GetPod(cluster, processGroup)
-				Expect(err).NotTo(HaveOccurred())
-				Expect(k8sClient.Create(context.Background(), newPod)).NotTo(HaveOccurred())
-				// Populate process groups
-				cluster.Status.ProcessGroups = append(cluster.Status.ProcessGroups, fdbv1beta2.NewProcessGroupStatus(id, fdbv1beta2.ProcessClassTransaction, nil))
-			}
+			When("the approval is no longer required", func() {
+				BeforeEach(func() {
+					cluster.Spec.AutomationOptions.Replacements.RequireApproval = nil
+					cluster.Spec.AutomationOptions.MaxConcurrentReplacements = pointer.Int(1)
 
-			// Force a replacement of all processes
-			cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral].PodTemplate.Spec.NodeSelector = map[string]string{
-				"dummy": "test",
-			}
+					var err error
+					hasChanges, err = ReplaceMisconfiguredProcessGroups(context.Background(), podmanager.StandardPodLifecycleManager{}, k8sClient, log, cluster, pvcMap, nil, true, ReplacementOptions{})
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("should remove the PendingReplacementApproval conditions", func() {
+					Expect(hasChanges).To(BeTrue())
+					for _, pGroup := range cluster.Status.ProcessGroups {
+						Expect(pGroup.GetConditionTime(fdbv1beta2.PendingReplacementApproval)).To(BeNil())
+					}
+				})
+			})
 		})
 
-		When("No replacements are allowed", func() {
+		When("a mass replacement threshold is defined", func() {
+			var hasReplacement bool
+			var err error
+
 			BeforeEach(func() {
-				cluster.Spec.AutomationOptions.MaxConcurrentReplacements = pointer.Int(0)
+				cluster.Spec.AutomationOptions.MassReplacementThresholdPercentage = pointer.Int(50)
 			})
 
-			It("should not have a replacements", func() {
-				hasReplacement, err := ReplaceMisconfiguredProcessGroups(context.Background(), podmanager.StandardPodLifecycleManager{}, k8sClient, log, cluster, pvcMap, true)
-				Expect(err).NotTo(HaveOccurred())
-				Expect(hasReplacement).To(BeFalse())
+			JustBeforeEach(func() {
+				hasReplacement, err = ReplaceMisconfiguredProcessGroups(context.Background(), podmanager.StandardPodLifecycleManager{}, k8sClient, log, cluster, pvcMap, nil, true, ReplacementOptions{})
+			})
 
-				cntReplacements := 0
-				for _, pGroup := range cluster.Status.ProcessGroups {
-					if !pGroup.IsMarkedForRemoval() {
-						continue
+			When("the mass replacement is not approved", func() {
+				It("should not replace any process groups", func() {
+					Expect(err).To(MatchError(&MassReplacementError{Replacements: 11, ProcessGroups: 11, Threshold: 50}))
+					Expect(hasReplacement).To(BeFalse())
+
+					for _, pGroup := range cluster.Status.ProcessGroups {
+						Expect(pGroup.IsMarkedForRemoval()).To(BeFalse())
+					}
+				})
+			})
+
+			When("the mass replacement is approved for a previous generation", func() {
+				BeforeEach(func() {
+					cluster.Generation = 2
+					cluster.Annotations = map[string]string{
+						fdbv1beta2.MassReplacementApprovalAnnotation: "1",
 					}
+				})
 
-					cntReplacements++
-				}
+				It("should not replace any process groups", func() {
+					Expect(err).To(HaveOccurred())
+					Expect(hasReplacement).To(BeFalse())
+				})
+			})
 
-				Expect(cntReplacements).To(BeNumerically("==", 0))
+			When("the mass replacement is approved", func() {
+				BeforeEach(func() {
+					cluster.Generation = 2
+					cluster.Annotations = map[string]string{
+						fdbv1beta2.MassReplacementApprovalAnnotation: "2",
+					}
+				})
+
+				It("should replace all process groups", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(hasReplacement).To(BeTrue())
+
+					for _, pGroup := range cluster.Status.ProcessGroups {
+						Expect(pGroup.IsMarkedForRemoval()).To(BeTrue())
+					}
+				})
+			})
+
+			When("the replacements are below the threshold", func() {
+				BeforeEach(func() {
+					cluster.Spec.AutomationOptions.MassReplacementThresholdPercentage = pointer.Int(100)
+				})
+
+				It("should replace all process groups", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(hasReplacement).To(BeTrue())
+				})
 			})
 		})
 
-		When("Two replacements are allowed", func() {
+		When("the maximum replacements per hour is defined", func() {
 			BeforeEach(func() {
-				cluster.Spec.AutomationOptions.MaxConcurrentReplacements = pointer.Int(2)
+				cluster.Spec.AutomationOptions.Replacements.MaxReplacementsPerHour = pointer.Int(3)
+				cluster.Status.ReplacementHistory = []metav1.Time{
+					{Time: time.Now().Add(-30 * time.Minute)},
+					{Time: time.Now().Add(-2 * time.Hour)},
+				}
 			})
 
-			It("should have two replacements", func() {
-				hasReplacement, err := ReplaceMisconfiguredProcessGroups(context.Background(), podmanager.StandardPodLifecycleManager{}, k8sClient, log, cluster, pvcMap, true)
+			It("should only replace the process groups that are left in the budget", func() {
+				hasReplacement, err := ReplaceMisconfiguredProcessGroups(context.Background(), podmanager.StandardPodLifecycleManager{}, k8sClient, log, cluster, pvcMap, nil, true, ReplacementOptions{})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(hasReplacement).To(BeTrue())
 
@@ -688,12 +2045,34 @@ var _ = Describe("replace_misconfigured_pods", func() {
 				}
 
 				Expect(cntReplacements).To(BeNumerically("==", 2))
+				Expect(cluster.Status.ReplacementHistory).To(HaveLen(3))
+			})
+		})
+
+		When("the current time is outside of the allowed replacement windows", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.Replacements.AllowedWindows = []fdbv1beta2.ReplacementWindow{
+					{
+						Schedule:        fmt.Sprintf("0 0 1 %d *", time.Now().UTC().AddDate(0, 2, 0).Month()),
+						DurationMinutes: 60,
+					},
+				}
+			})
+
+			It("should not have any replacements", func() {
+				hasReplacement, err := ReplaceMisconfiguredProcessGroups(context.Background(), podmanager.StandardPodLifecycleManager{}, k8sClient, log, cluster, pvcMap, nil, true, ReplacementOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hasReplacement).To(BeFalse())
+
+				for _, pGroup := range cluster.Status.ProcessGroups {
+					Expect(pGroup.IsMarkedForRemoval()).To(BeFalse())
+				}
 			})
 		})
 
 		When("Setting is unset", func() {
 			It("should replace all process groups", func() {
-				hasReplacement, err := ReplaceMisconfiguredProcessGroups(context.Background(), podmanager.StandardPodLifecycleManager{}, k8sClient, log, cluster, pvcMap, true)
+				hasReplacement, err := ReplaceMisconfiguredProcessGroups(context.Background(), podmanager.StandardPodLifecycleManager{}, k8sClient, log, cluster, pvcMap, nil, true, ReplacementOptions{})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(hasReplacement).To(BeTrue())
 
@@ -707,6 +2086,10 @@ var _ = Describe("replace_misconfigured_pods", func() {
 				}
 
 				Expect(cntReplacements).To(BeNumerically("==", len(cluster.Status.ProcessGroups)))
+				for _, pGroup := range cluster.Status.ProcessGroups {
+					Expect(pGroup.RemovalReason).NotTo(BeNil())
+					Expect(pGroup.RemovalReason.Type).To(Equal(fdbv1beta2.RemovalReasonNodeSelectorChanged))
+				}
 			})
 		})
 
@@ -738,7 +2121,7 @@ var _ = Describe("replace_misconfigured_pods", func() {
 				})
 
 				It("should not have any replacements", func() {
-					hasReplacement, err := ReplaceMisconfiguredProcessGroups(context.Background(), podmanager.StandardPodLifecycleManager{}, k8sClient, log, cluster, pvcMap, true)
+					hasReplacement, err := ReplaceMisconfiguredProcessGroups(context.Background(), podmanager.StandardPodLifecycleManager{}, k8sClient, log, cluster, pvcMap, nil, true, ReplacementOptions{})
 					Expect(err).NotTo(HaveOccurred())
 					Expect(hasReplacement).To(BeFalse())
 
@@ -755,9 +2138,153 @@ var _ = Describe("replace_misconfigured_pods", func() {
 				})
 			})
 		})
+
+		When("the process group ID prefix changes with a managed migration", func() {
+			var replacedProcessGroups []fdbv1beta2.ProcessGroupID
+			var hasReplacement bool
+
+			BeforeEach(func() {
+				cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral].PodTemplate.Spec.NodeSelector = map[string]string{}
+				for idx, processGroup := range cluster.Status.ProcessGroups {
+					processGroup.FaultDomain = fdbv1beta2.FaultDomain(fmt.Sprintf("zone-%d", idx%3))
+				}
+
+				cluster.Spec.ProcessGroupIDPrefix = "dev"
+				cluster.Spec.AutomationOptions.ProcessGroupIDPrefixMigration = &fdbv1beta2.ProcessGroupIDPrefixMigrationOptions{
+					Enabled: pointer.Bool(true),
+				}
+			})
+
+			JustBeforeEach(func() {
+				var err error
+				hasReplacement, err = ReplaceMisconfiguredProcessGroups(context.Background(), podmanager.StandardPodLifecycleManager{}, k8sClient, log, cluster, pvcMap, nil, true, ReplacementOptions{})
+				Expect(err).NotTo(HaveOccurred())
+
+				replacedProcessGroups = nil
+				for _, processGroup := range cluster.Status.ProcessGroups {
+					if !processGroup.IsMarkedForRemoval() {
+						continue
+					}
+
+					replacedProcessGroups = append(replacedProcessGroups, processGroup.ProcessGroupID)
+				}
+			})
+
+			When("the new prefix is not confirmed", func() {
+				It("should not have any replacements", func() {
+					Expect(hasReplacement).To(BeFalse())
+					Expect(replacedProcessGroups).To(BeEmpty())
+				})
+			})
+
+			When("the new prefix is confirmed", func() {
+				BeforeEach(func() {
+					cluster.Spec.AutomationOptions.ProcessGroupIDPrefixMigration.ConfirmedPrefix = pointer.String("dev")
+				})
+
+				It("should only replace the process groups of the first process class in the first fault domain", func() {
+					Expect(hasReplacement).To(BeTrue())
+					Expect(replacedProcessGroups).To(HaveLen(4))
+					for _, processGroup := range cluster.Status.ProcessGroups {
+						Expect(processGroup.IsMarkedForRemoval()).To(Equal(processGroup.ProcessClass == fdbv1beta2.ProcessClassStorage && processGroup.FaultDomain == "zone-0"))
+					}
+				})
+
+				It("should report the progress of the migration", func() {
+					Expect(GetProcessGroupIDPrefixMigrationStatus(cluster, cluster.Status.ProcessGroups)).To(Equal(&fdbv1beta2.ProcessGroupIDPrefixMigrationStatus{
+						TargetPrefix: "dev",
+						ProcessClass: fdbv1beta2.ProcessClassStorage,
+						FaultDomain:  "zone-0",
+						PendingProcessGroups: map[fdbv1beta2.ProcessClass]int{
+							fdbv1beta2.ProcessClassStorage:     10,
+							fdbv1beta2.ProcessClassTransaction: 1,
+						},
+						Confirmed: true,
+					}))
+				})
+
+				When("a process group in another fault domain is already marked for removal", func() {
+					BeforeEach(func() {
+						cluster.Status.ProcessGroups[1].MarkForRemoval()
+					})
+
+					It("should only replace the process groups in the fault domain that is currently migrated", func() {
+						Expect(hasReplacement).To(BeTrue())
+						Expect(replacedProcessGroups).To(HaveLen(3))
+						for _, processGroup := range cluster.Status.ProcessGroups {
+							Expect(processGroup.IsMarkedForRemoval()).To(Equal(processGroup.ProcessClass == fdbv1beta2.ProcessClassStorage && processGroup.FaultDomain == "zone-1"))
+						}
+					})
+				})
+
+				When("the process class order is defined", func() {
+					BeforeEach(func() {
+						cluster.Spec.AutomationOptions.ProcessGroupIDPrefixMigration.ProcessClassOrder = []fdbv1beta2.ProcessClass{fdbv1beta2.ProcessClassTransaction}
+					})
+
+					It("should replace the process groups of the first process class in the order", func() {
+						Expect(hasReplacement).To(BeTrue())
+						Expect(replacedProcessGroups).To(HaveLen(1))
+						for _, processGroup := range cluster.Status.ProcessGroups {
+							Expect(processGroup.IsMarkedForRemoval()).To(Equal(processGroup.ProcessClass == fdbv1beta2.ProcessClassTransaction))
+						}
+					})
+				})
+
+				When("the migration is paused", func() {
+					BeforeEach(func() {
+						cluster.Spec.AutomationOptions.ProcessGroupIDPrefixMigration.Paused = pointer.Bool(true)
+					})
+
+					It("should not have any replacements", func() {
+						Expect(hasReplacement).To(BeFalse())
+						Expect(replacedProcessGroups).To(BeEmpty())
+					})
+
+					It("should report the migration as paused", func() {
+						migrationStatus := GetProcessGroupIDPrefixMigrationStatus(cluster, cluster.Status.ProcessGroups)
+						Expect(migrationStatus).NotTo(BeNil())
+						Expect(migrationStatus.Paused).To(BeTrue())
+					})
+				})
+			})
+		})
 	})
 })
 
+var _ = DescribeTable("getting the Pod spec diff",
+	func(desired, current *corev1.PodSpec, expected string) {
+		diff, err := GetPodSpecDiff(desired, current)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diff).To(MatchJSON(expected))
+	},
+	Entry("the specs are equal",
+		&corev1.PodSpec{},
+		&corev1.PodSpec{},
+		"{}",
+	),
+	Entry("the node selector was changed",
+		&corev1.PodSpec{NodeSelector: map[string]string{"dummy": "test"}},
+		&corev1.PodSpec{},
+		`{"nodeSelector":{"dummy":"test"}}`,
+	),
+	Entry("the current spec contains defaulted fields",
+		&corev1.PodSpec{},
+		&corev1.PodSpec{DNSPolicy: corev1.DNSClusterFirst, SchedulerName: corev1.DefaultSchedulerName},
+		`{"dnsPolicy":null,"schedulerName":null}`,
+	),
+	Entry("the service account was changed",
+		&corev1.PodSpec{ServiceAccountName: "fdb"},
+		&corev1.PodSpec{ServiceAccountName: "default"},
+		`{"serviceAccountName":"fdb"}`,
+	),
+	Entry("a container was changed",
+		&corev1.PodSpec{Containers: []corev1.Container{{Name: "foundationdb", Image: "foundationdb:7.1.26"}}},
+		&corev1.PodSpec{Containers: []corev1.Container{{Name: "foundationdb", Image: "foundationdb:7.1.25"}}},
+		`{"$setElementOrder/containers":[{"name":"foundationdb"}],"containers":[{"image":"foundationdb:7.1.26","name":"foundationdb"}]}`,
+	),
+)
+
 var _ = DescribeTable("file_security_context_changed",
 	func(desired, current *corev1.PodSpec, wantResult bool) {
 		var log logr.Logger
@@ -1026,3 +2553,16 @@ var _ = DescribeTable("file_security_context_changed",
 		false,
 	),
 )
+
+// testPodSpecComparator is a PodSpecComparator that always returns the same result.
+type testPodSpecComparator struct {
+	result  podmanager.PodSpecComparisonResult
+	message string
+	calls   int
+}
+
+// ComparePodSpec returns the configured result.
+func (comparator *testPodSpecComparator) ComparePodSpec(_ *fdbv1beta2.FoundationDBCluster, _ *fdbv1beta2.ProcessGroupStatus, _ *corev1.PodSpec, _ *corev1.Pod) (podmanager.PodSpecComparisonResult, string) {
+	comparator.calls++
+	return comparator.result, comparator.message
+}
@@ -0,0 +1,86 @@
+/*
+ * security_context.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replacements
+
+import (
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/go-logr/logr"
+)
+
+// filterTransientSecurityContextChanges removes the replacement candidates with a changed file security context, if the
+// change didn't persist for the securityContextChangeGracePeriodSeconds. Those process groups get the
+// SecurityContextChangePending condition, which is removed once the change is gone or the grace period is disabled.
+// The returned bool reports if a condition was changed.
+func filterTransientSecurityContextChanges(log logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, candidates []*fdbv1beta2.ProcessGroupStatus, removalReasons map[fdbv1beta2.ProcessGroupID]*fdbv1beta2.RemovalReason, now time.Time) ([]*fdbv1beta2.ProcessGroupStatus, bool) {
+	gracePeriod := cluster.GetSecurityContextChangeGracePeriod()
+	pending := map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None{}
+	filtered := make([]*fdbv1beta2.ProcessGroupStatus, 0, len(candidates))
+	changed := false
+
+	for _, processGroup := range candidates {
+		reason := removalReasons[processGroup.ProcessGroupID]
+		if gracePeriod <= 0 || reason == nil || reason.Type != fdbv1beta2.RemovalReasonSecurityContextChanged {
+			filtered = append(filtered, processGroup)
+			continue
+		}
+
+		pending[processGroup.ProcessGroupID] = fdbv1beta2.None{}
+		conditionTime := processGroup.GetConditionTime(fdbv1beta2.SecurityContextChangePending)
+		if conditionTime == nil {
+			log.Info("Deferring replacement, the file security context change must persist for the grace period", "processGroupID", processGroup.ProcessGroupID, "gracePeriod", gracePeriod.String())
+			processGroup.UpdateCondition(fdbv1beta2.SecurityContextChangePending, true)
+			changed = true
+			continue
+		}
+
+		remaining := gracePeriod - now.Sub(time.Unix(*conditionTime, 0))
+		if remaining > 0 {
+			log.V(1).Info("Deferring replacement, the grace period for the file security context change is running", "processGroupID", processGroup.ProcessGroupID, "remaining", remaining.String())
+			continue
+		}
+
+		filtered = append(filtered, processGroup)
+	}
+
+	// Remove the condition from all process groups where the change is gone. Process groups that will be replaced
+	// keep the condition until they are removed.
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if processGroup.IsMarkedForRemoval() {
+			continue
+		}
+
+		if _, ok := pending[processGroup.ProcessGroupID]; ok {
+			continue
+		}
+
+		if processGroup.GetConditionTime(fdbv1beta2.SecurityContextChangePending) == nil {
+			continue
+		}
+
+		log.Info("File security context change is gone, removing the pending condition", "processGroupID", processGroup.ProcessGroupID)
+		processGroup.UpdateCondition(fdbv1beta2.SecurityContextChangePending, false)
+		changed = true
+	}
+
+	return filtered, changed
+}
@@ -0,0 +1,139 @@
+/*
+ * security_context_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replacements
+
+import (
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("security context changes", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var candidates []*fdbv1beta2.ProcessGroupStatus
+	var removalReasons map[fdbv1beta2.ProcessGroupID]*fdbv1beta2.RemovalReason
+	var filtered []*fdbv1beta2.ProcessGroupStatus
+	var changed bool
+	var now time.Time
+
+	BeforeEach(func() {
+		now = time.Now()
+		cluster = internal.CreateDefaultCluster()
+		cluster.Status.ProcessGroups = []*fdbv1beta2.ProcessGroupStatus{
+			{
+				ProcessGroupID: "storage-1",
+				ProcessClass:   fdbv1beta2.ProcessClassStorage,
+			},
+			{
+				ProcessGroupID: "storage-2",
+				ProcessClass:   fdbv1beta2.ProcessClassStorage,
+			},
+		}
+		candidates = cluster.Status.ProcessGroups
+		removalReasons = map[fdbv1beta2.ProcessGroupID]*fdbv1beta2.RemovalReason{
+			"storage-1": newRemovalReason(fdbv1beta2.RemovalReasonSecurityContextChanged, "file security context has changed"),
+			"storage-2": newRemovalReason(fdbv1beta2.RemovalReasonPodSpecChanged, "specHash has changed"),
+		}
+	})
+
+	JustBeforeEach(func() {
+		filtered, changed = filterTransientSecurityContextChanges(logr.Discard(), cluster, candidates, removalReasons, now)
+	})
+
+	When("no grace period is defined", func() {
+		It("should keep all candidates", func() {
+			Expect(filtered).To(HaveLen(2))
+			Expect(changed).To(BeFalse())
+		})
+	})
+
+	When("a grace period is defined", func() {
+		BeforeEach(func() {
+			cluster.Spec.ReplacementTriggerPolicy = &fdbv1beta2.ReplacementTriggerPolicy{
+				SecurityContextChangeGracePeriodSeconds: pointer.Int(300),
+			}
+		})
+
+		When("the change was detected for the first time", func() {
+			It("should defer the replacement and add the condition", func() {
+				Expect(changed).To(BeTrue())
+				Expect(filtered).To(ConsistOf(cluster.Status.ProcessGroups[1]))
+				Expect(cluster.Status.ProcessGroups[0].GetConditionTime(fdbv1beta2.SecurityContextChangePending)).NotTo(BeNil())
+				Expect(cluster.Status.ProcessGroups[1].GetConditionTime(fdbv1beta2.SecurityContextChangePending)).To(BeNil())
+			})
+		})
+
+		When("the grace period is running", func() {
+			BeforeEach(func() {
+				cluster.Status.ProcessGroups[0].ProcessGroupConditions = []*fdbv1beta2.ProcessGroupCondition{
+					{
+						ProcessGroupConditionType: fdbv1beta2.SecurityContextChangePending,
+						Timestamp:                 now.Add(-1 * time.Minute).Unix(),
+					},
+				}
+			})
+
+			It("should defer the replacement", func() {
+				Expect(changed).To(BeFalse())
+				Expect(filtered).To(ConsistOf(cluster.Status.ProcessGroups[1]))
+			})
+		})
+
+		When("the grace period has elapsed", func() {
+			BeforeEach(func() {
+				cluster.Status.ProcessGroups[0].ProcessGroupConditions = []*fdbv1beta2.ProcessGroupCondition{
+					{
+						ProcessGroupConditionType: fdbv1beta2.SecurityContextChangePending,
+						Timestamp:                 now.Add(-10 * time.Minute).Unix(),
+					},
+				}
+			})
+
+			It("should replace the process group", func() {
+				Expect(changed).To(BeFalse())
+				Expect(filtered).To(HaveLen(2))
+			})
+		})
+
+		When("the change is gone", func() {
+			BeforeEach(func() {
+				cluster.Status.ProcessGroups[0].ProcessGroupConditions = []*fdbv1beta2.ProcessGroupCondition{
+					{
+						ProcessGroupConditionType: fdbv1beta2.SecurityContextChangePending,
+						Timestamp:                 now.Add(-1 * time.Minute).Unix(),
+					},
+				}
+				candidates = cluster.Status.ProcessGroups[1:]
+			})
+
+			It("should remove the condition", func() {
+				Expect(changed).To(BeTrue())
+				Expect(filtered).To(ConsistOf(cluster.Status.ProcessGroups[1]))
+				Expect(cluster.Status.ProcessGroups[0].GetConditionTime(fdbv1beta2.SecurityContextChangePending)).To(BeNil())
+			})
+		})
+	})
+})
@@ -0,0 +1,140 @@
+/*
+ * simulate_replacements.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replacements
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/podmanager"
+)
+
+// SimulateReplacements returns all process groups that would currently be replaced because their Pod or PVC doesn't
+// match the spec of the provided cluster, together with the reason for the replacement. The checks are performed
+// against the provided Pods, PVCs and storage classes, so the cluster spec can be a rendered spec that is not yet
+// applied. The status of the cluster is not modified. Limits like the maximum number of concurrent replacements,
+// replacement windows or required approvals are not taken into account and replacements of failed process groups are
+// not part of the result.
+func SimulateReplacements(log logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, pods []corev1.Pod, pvcs []corev1.PersistentVolumeClaim, storageClasses []storagev1.StorageClass, replaceOnSecurityContextChange bool, comparators ...podmanager.PodSpecComparator) (map[fdbv1beta2.ProcessGroupID]*fdbv1beta2.RemovalReason, error) {
+	simulatedCluster := cluster.DeepCopy()
+	reader := newSimulationReader(pvcs, storageClasses)
+	pvcList := &corev1.PersistentVolumeClaimList{Items: pvcs}
+	pvcMap := internal.CreatePVCMap(simulatedCluster, pvcList)
+	additionalPVCMap := internal.CreateAdditionalPVCMap(simulatedCluster, pvcList)
+
+	podMap := make(map[string]*corev1.Pod, len(pods))
+	for idx := range pods {
+		podMap[pods[idx].Name] = &pods[idx]
+	}
+
+	prefixMigrationProcessClass, prefixMigrationFaultDomain, prefixMigrationAllowed := getProcessGroupIDPrefixMigrationStep(simulatedCluster)
+	removalReasons := map[fdbv1beta2.ProcessGroupID]*fdbv1beta2.RemovalReason{}
+	for _, processGroup := range simulatedCluster.Status.ProcessGroups {
+		if !isReplacementCandidate(simulatedCluster, processGroup, prefixMigrationProcessClass, prefixMigrationFaultDomain, prefixMigrationAllowed) {
+			continue
+		}
+
+		podName := processGroup.GetPodName(simulatedCluster)
+		pod, ok := podMap[podName]
+		var podErr error
+		if !ok {
+			podErr = k8serrors.NewNotFound(corev1.Resource("pods"), podName)
+		}
+
+		removalReason, err := processGroupNeedsRemoval(context.Background(), reader, log, simulatedCluster, processGroup, pod, podErr, pvcMap, additionalPVCMap, replaceOnSecurityContextChange, comparators)
+		if err != nil {
+			// The operator will not replace process groups without a Pod.
+			if !ok && k8serrors.IsNotFound(err) {
+				continue
+			}
+
+			return nil, fmt.Errorf("could not simulate replacement of process group %s: %w", processGroup.ProcessGroupID, err)
+		}
+
+		if removalReason != nil {
+			removalReasons[processGroup.ProcessGroupID] = removalReason
+		}
+	}
+
+	return removalReasons, nil
+}
+
+// simulationReader implements the client.Reader interface for the PVCs and storage classes that are provided to the
+// simulation, so that the replacement checks can be reused without access to the Kubernetes API.
+type simulationReader struct {
+	pvcs           map[client.ObjectKey]corev1.PersistentVolumeClaim
+	storageClasses map[string]storagev1.StorageClass
+}
+
+// newSimulationReader creates a new simulationReader for the provided PVCs and storage classes.
+func newSimulationReader(pvcs []corev1.PersistentVolumeClaim, storageClasses []storagev1.StorageClass) *simulationReader {
+	reader := &simulationReader{
+		pvcs:           make(map[client.ObjectKey]corev1.PersistentVolumeClaim, len(pvcs)),
+		storageClasses: make(map[string]storagev1.StorageClass, len(storageClasses)),
+	}
+
+	for _, pvc := range pvcs {
+		reader.pvcs[client.ObjectKey{Namespace: pvc.Namespace, Name: pvc.Name}] = pvc
+	}
+
+	for _, storageClass := range storageClasses {
+		reader.storageClasses[storageClass.Name] = storageClass
+	}
+
+	return reader
+}
+
+// Get returns the PVC or storage class with the provided key.
+func (reader *simulationReader) Get(_ context.Context, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	switch object := obj.(type) {
+	case *corev1.PersistentVolumeClaim:
+		pvc, ok := reader.pvcs[key]
+		if !ok {
+			return k8serrors.NewNotFound(corev1.Resource("persistentvolumeclaims"), key.Name)
+		}
+
+		pvc.DeepCopyInto(object)
+	case *storagev1.StorageClass:
+		storageClass, ok := reader.storageClasses[key.Name]
+		if !ok {
+			return k8serrors.NewNotFound(storagev1.Resource("storageclasses"), key.Name)
+		}
+
+		storageClass.DeepCopyInto(object)
+	default:
+		return fmt.Errorf("unsupported object type %T in replacement simulation", obj)
+	}
+
+	return nil
+}
+
+// List is not supported by the simulationReader.
+func (reader *simulationReader) List(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+	return fmt.Errorf("listing %T is not supported in replacement simulation", list)
+}
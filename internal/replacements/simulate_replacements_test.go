@@ -0,0 +1,189 @@
+/*
+ * simulate_replacements_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replacements
+
+import (
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+)
+
+var _ = Describe("simulate_replacements", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var pods []corev1.Pod
+	var pvcs []corev1.PersistentVolumeClaim
+	var storageClasses []storagev1.StorageClass
+	var removalReasons map[fdbv1beta2.ProcessGroupID]*fdbv1beta2.RemovalReason
+	var err error
+	log := logr.New(logf.NewDelegatingLogSink(logf.NullLogSink{}))
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		Expect(internal.NormalizeClusterSpec(cluster, internal.DeprecationOptions{})).NotTo(HaveOccurred())
+		cluster.Spec.LabelConfig.FilterOnOwnerReferences = pointer.Bool(false)
+		processSettings := cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral]
+		processSettings.VolumeClaimTemplate = &corev1.PersistentVolumeClaim{
+			Spec: corev1.PersistentVolumeClaimSpec{
+				StorageClassName: pointer.String("expandable"),
+			},
+		}
+		cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral] = processSettings
+		storageClasses = []storagev1.StorageClass{
+			{
+				ObjectMeta:           metav1.ObjectMeta{Name: "expandable"},
+				AllowVolumeExpansion: pointer.Bool(true),
+			},
+		}
+		pods = nil
+		pvcs = nil
+
+		for _, processClass := range []fdbv1beta2.ProcessClass{fdbv1beta2.ProcessClassStorage, fdbv1beta2.ProcessClassStateless} {
+			for i := 1; i <= 2; i++ {
+				_, id := cluster.GetProcessGroupID(processClass, i)
+				processGroup := fdbv1beta2.NewProcessGroupStatus(id, processClass, nil)
+				cluster.Status.ProcessGroups = append(cluster.Status.ProcessGroups, processGroup)
+
+				pod, err := internal.GetPod(cluster, processGroup)
+				Expect(err).NotTo(HaveOccurred())
+				pods = append(pods, *pod)
+
+				if !processClass.IsStateful() {
+					continue
+				}
+
+				pvc, err := internal.GetPvc(cluster, processGroup)
+				Expect(err).NotTo(HaveOccurred())
+				pvcs = append(pvcs, *pvc)
+			}
+		}
+	})
+
+	JustBeforeEach(func() {
+		removalReasons, err = SimulateReplacements(log, cluster, pods, pvcs, storageClasses, true)
+	})
+
+	When("the spec is not changed", func() {
+		It("should not report any replacements", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(removalReasons).To(BeEmpty())
+		})
+	})
+
+	When("the node selector is changed", func() {
+		var originalStatus fdbv1beta2.FoundationDBClusterStatus
+
+		BeforeEach(func() {
+			cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral].PodTemplate.Spec.NodeSelector = map[string]string{
+				"dummy": "test",
+			}
+			originalStatus = *cluster.Status.DeepCopy()
+		})
+
+		It("should report all process groups with the reason", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(removalReasons).To(HaveLen(4))
+			for _, reason := range removalReasons {
+				Expect(reason.Type).To(Equal(fdbv1beta2.RemovalReasonNodeSelectorChanged))
+			}
+		})
+
+		It("should not modify the status of the cluster", func() {
+			Expect(cluster.Status).To(Equal(originalStatus))
+		})
+	})
+
+	When("a process group is already marked for removal", func() {
+		BeforeEach(func() {
+			cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral].PodTemplate.Spec.NodeSelector = map[string]string{
+				"dummy": "test",
+			}
+			cluster.Status.ProcessGroups[0].MarkForRemoval()
+		})
+
+		It("should not report the process group", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(removalReasons).To(HaveLen(3))
+			Expect(removalReasons).NotTo(HaveKey(cluster.Status.ProcessGroups[0].ProcessGroupID))
+		})
+	})
+
+	When("a process group has no Pod", func() {
+		BeforeEach(func() {
+			cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral].PodTemplate.Spec.NodeSelector = map[string]string{
+				"dummy": "test",
+			}
+			pods = pods[1:]
+		})
+
+		It("should not report the process group", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(removalReasons).To(HaveLen(3))
+		})
+	})
+
+	When("the storage size is increased", func() {
+		BeforeEach(func() {
+			processSettings := cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral]
+			processSettings.VolumeClaimTemplate.Spec.Resources.Requests = corev1.ResourceList{
+				corev1.ResourceStorage: resource.MustParse("256G"),
+			}
+			cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral] = processSettings
+		})
+
+		It("should report the storage process groups", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(removalReasons).To(HaveLen(2))
+			for _, reason := range removalReasons {
+				Expect(reason.Type).To(Equal(fdbv1beta2.RemovalReasonPVCChanged))
+			}
+		})
+
+		When("online PVC expansion is enabled", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.UseOnlinePVCExpansion = pointer.Bool(true)
+			})
+
+			It("should not report any replacements", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(removalReasons).To(BeEmpty())
+			})
+
+			When("the storage class is not provided", func() {
+				BeforeEach(func() {
+					storageClasses = nil
+				})
+
+				It("should return an error", func() {
+					Expect(err).To(HaveOccurred())
+				})
+			})
+		})
+	})
+})
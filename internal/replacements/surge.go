@@ -0,0 +1,114 @@
+/*
+ * surge.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replacements
+
+import (
+	"fmt"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/go-logr/logr"
+)
+
+// surgeBlockingConditions contains the conditions that indicate that the processes of a process group have not joined
+// the cluster yet.
+var surgeBlockingConditions = []fdbv1beta2.ProcessGroupConditionType{
+	fdbv1beta2.MissingProcesses,
+	fdbv1beta2.MissingPod,
+	fdbv1beta2.MissingPVC,
+	fdbv1beta2.PodPending,
+}
+
+// CheckSurgeReplacementsReady returns an error if the process groups of the provided process class that are marked for
+// removal must not be excluded or removed yet, because the Surge replacement strategy is used and the replacements are
+// not ready. The replacements are ready once the desired number of process groups of the process class, that are not
+// marked for removal, have joined the cluster and the data distribution is healthy.
+func CheckSurgeReplacementsReady(cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, processClass fdbv1beta2.ProcessClass) error {
+	if !cluster.UseSurgeReplacements() {
+		return nil
+	}
+
+	counts, err := cluster.GetProcessCountsWithDefaults()
+	if err != nil {
+		return err
+	}
+
+	desiredCount := counts.Map()[processClass]
+	var readyCount int
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if processGroup.ProcessClass != processClass || processGroup.IsMarkedForRemoval() {
+			continue
+		}
+
+		if surgeReplacementReady(processGroup) {
+			readyCount++
+		}
+	}
+
+	if readyCount < desiredCount {
+		return fmt.Errorf("waiting for replacements of process class %s to join the cluster, %d of %d process groups are ready", processClass, readyCount, desiredCount)
+	}
+
+	if status != nil && !status.Cluster.Data.State.Healthy {
+		return fmt.Errorf("waiting for the data distribution to be healthy, current state: %s", status.Cluster.Data.State.Name)
+	}
+
+	return nil
+}
+
+// FilterSurgeRemovals returns the process groups that can be removed based on CheckSurgeReplacementsReady. If the Surge
+// replacement strategy is not used, all process groups will be returned.
+func FilterSurgeRemovals(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, processGroups []*fdbv1beta2.ProcessGroupStatus) []*fdbv1beta2.ProcessGroupStatus {
+	if !cluster.UseSurgeReplacements() {
+		return processGroups
+	}
+
+	readyClasses := map[fdbv1beta2.ProcessClass]bool{}
+	filtered := make([]*fdbv1beta2.ProcessGroupStatus, 0, len(processGroups))
+	for _, processGroup := range processGroups {
+		ready, ok := readyClasses[processGroup.ProcessClass]
+		if !ok {
+			err := CheckSurgeReplacementsReady(cluster, status, processGroup.ProcessClass)
+			if err != nil {
+				logger.Info("Skipping removal of process groups, replacements are not ready", "processClass", processGroup.ProcessClass, "reason", err.Error())
+			}
+
+			ready = err == nil
+			readyClasses[processGroup.ProcessClass] = ready
+		}
+
+		if ready {
+			filtered = append(filtered, processGroup)
+		}
+	}
+
+	return filtered
+}
+
+// surgeReplacementReady returns true if the processes of the process group have joined the cluster.
+func surgeReplacementReady(processGroup *fdbv1beta2.ProcessGroupStatus) bool {
+	for _, condition := range surgeBlockingConditions {
+		if processGroup.GetConditionTime(condition) != nil {
+			return false
+		}
+	}
+
+	return true
+}
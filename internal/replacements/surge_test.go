@@ -0,0 +1,128 @@
+/*
+ * surge_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replacements
+
+import (
+	"fmt"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("surge", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var status *fdbv1beta2.FoundationDBStatus
+	var replacedProcessGroup *fdbv1beta2.ProcessGroupStatus
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		Expect(internal.NormalizeClusterSpec(cluster, internal.DeprecationOptions{})).To(Succeed())
+		cluster.Spec.AutomationOptions.Replacements.Strategy = fdbv1beta2.ReplacementStrategySurge
+
+		counts, err := cluster.GetProcessCountsWithDefaults()
+		Expect(err).NotTo(HaveOccurred())
+		for processClass, count := range counts.Map() {
+			for idx := 1; idx <= count; idx++ {
+				processGroupID := fdbv1beta2.ProcessGroupID(fmt.Sprintf("%s-%d", processClass, idx))
+				processGroup := fdbv1beta2.NewProcessGroupStatus(processGroupID, processClass, nil)
+				processGroup.ProcessGroupConditions = nil
+				cluster.Status.ProcessGroups = append(cluster.Status.ProcessGroups, processGroup)
+			}
+		}
+
+		replacedProcessGroup = fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, "storage-1")
+		Expect(replacedProcessGroup).NotTo(BeNil())
+		replacedProcessGroup.MarkForRemoval()
+
+		status = &fdbv1beta2.FoundationDBStatus{}
+		status.Cluster.Data.State.Healthy = true
+	})
+
+	When("the replacement was not created", func() {
+		It("should not allow the exclusion", func() {
+			Expect(CheckSurgeReplacementsReady(cluster, status, fdbv1beta2.ProcessClassStorage)).To(MatchError(ContainSubstring("waiting for replacements of process class storage")))
+		})
+
+		It("should allow the exclusion for other process classes", func() {
+			Expect(CheckSurgeReplacementsReady(cluster, status, fdbv1beta2.ProcessClassLog)).To(Succeed())
+		})
+
+		It("should filter out the process group for removal", func() {
+			Expect(FilterSurgeRemovals(GinkgoLogr, cluster, status, []*fdbv1beta2.ProcessGroupStatus{replacedProcessGroup})).To(BeEmpty())
+		})
+
+		When("the Default replacement strategy is used", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.Replacements.Strategy = fdbv1beta2.ReplacementStrategyDefault
+			})
+
+			It("should allow the exclusion", func() {
+				Expect(CheckSurgeReplacementsReady(cluster, status, fdbv1beta2.ProcessClassStorage)).To(Succeed())
+			})
+
+			It("should not filter out the process group for removal", func() {
+				Expect(FilterSurgeRemovals(GinkgoLogr, cluster, status, []*fdbv1beta2.ProcessGroupStatus{replacedProcessGroup})).To(ConsistOf(replacedProcessGroup))
+			})
+		})
+	})
+
+	When("the replacement was created", func() {
+		var replacement *fdbv1beta2.ProcessGroupStatus
+
+		BeforeEach(func() {
+			replacement = fdbv1beta2.NewProcessGroupStatus("storage-replacement", fdbv1beta2.ProcessClassStorage, nil)
+			cluster.Status.ProcessGroups = append(cluster.Status.ProcessGroups, replacement)
+		})
+
+		When("the replacement has not joined the cluster", func() {
+			It("should not allow the exclusion", func() {
+				Expect(CheckSurgeReplacementsReady(cluster, status, fdbv1beta2.ProcessClassStorage)).To(HaveOccurred())
+			})
+		})
+
+		When("the replacement has joined the cluster", func() {
+			BeforeEach(func() {
+				replacement.ProcessGroupConditions = nil
+			})
+
+			It("should allow the exclusion", func() {
+				Expect(CheckSurgeReplacementsReady(cluster, status, fdbv1beta2.ProcessClassStorage)).To(Succeed())
+			})
+
+			It("should not filter out the process group for removal", func() {
+				Expect(FilterSurgeRemovals(GinkgoLogr, cluster, status, []*fdbv1beta2.ProcessGroupStatus{replacedProcessGroup})).To(ConsistOf(replacedProcessGroup))
+			})
+
+			When("the data distribution is not healthy", func() {
+				BeforeEach(func() {
+					status.Cluster.Data.State.Healthy = false
+					status.Cluster.Data.State.Name = "healing"
+				})
+
+				It("should not allow the exclusion", func() {
+					Expect(CheckSurgeReplacementsReady(cluster, status, fdbv1beta2.ProcessClassStorage)).To(MatchError("waiting for the data distribution to be healthy, current state: healing"))
+				})
+			})
+		})
+	})
+})
@@ -21,8 +21,11 @@
 package internal
 
 import (
+	"fmt"
+
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // GetHeadlessService builds a headless service for a FoundationDB cluster.
@@ -35,6 +38,7 @@ func GetHeadlessService(cluster *fdbv1beta2.FoundationDBCluster) *corev1.Service
 		ObjectMeta: GetObjectMetadata(cluster, nil, "", ""),
 	}
 	service.ObjectMeta.Name = cluster.ObjectMeta.Name
+	addPropagatedMetadata(cluster, &service.ObjectMeta)
 	service.Spec.ClusterIP = "None"
 	service.Spec.Selector = cluster.GetMatchLabels()
 
@@ -44,3 +48,40 @@ func GetHeadlessService(cluster *fdbv1beta2.FoundationDBCluster) *corev1.Service
 
 	return service
 }
+
+// GetProcessClassServiceName returns the name of the headless Service for the provided process class.
+func GetProcessClassServiceName(cluster *fdbv1beta2.FoundationDBCluster, processClass fdbv1beta2.ProcessClass) string {
+	return fmt.Sprintf("%s-%s", cluster.Name, processClass.GetProcessClassForPodName())
+}
+
+// GetProcessClassHeadlessService builds a headless Service that selects all Pods of the provided process class.
+func GetProcessClassHeadlessService(cluster *fdbv1beta2.FoundationDBCluster, processClass fdbv1beta2.ProcessClass) *corev1.Service {
+	metadata := GetObjectMetadata(cluster, nil, processClass, "")
+	metadata.Name = GetProcessClassServiceName(cluster, processClass)
+	metadata.Labels[fdbv1beta2.ProcessClassServiceLabel] = string(processClass)
+	metadata.OwnerReferences = BuildOwnerReference(cluster.TypeMeta, cluster.ObjectMeta)
+	addPropagatedMetadata(cluster, &metadata)
+
+	ports := generateServicePorts(cluster.GetDesiredServersPerPod(processClass))
+	// Set the defaults of Kubernetes to prevent updates of the Service on every reconciliation.
+	for idx := range ports {
+		ports[idx].Protocol = corev1.ProtocolTCP
+		ports[idx].TargetPort = intstr.FromInt(int(ports[idx].Port))
+	}
+
+	var ipFamilies []corev1.IPFamily
+	if cluster.IsPodIPFamily6() {
+		ipFamilies = []corev1.IPFamily{corev1.IPv6Protocol}
+	}
+
+	return &corev1.Service{
+		ObjectMeta: metadata,
+		Spec: corev1.ServiceSpec{
+			ClusterIP:                corev1.ClusterIPNone,
+			Ports:                    ports,
+			PublishNotReadyAddresses: true,
+			Selector:                 GetPodMatchLabels(cluster, processClass, ""),
+			IPFamilies:               ipFamilies,
+		},
+	}
+}
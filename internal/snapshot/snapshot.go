@@ -0,0 +1,246 @@
+/*
+ * snapshot.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/go-logr/logr"
+)
+
+// SchemaVersion defines the version of the Snapshot schema. The version must be increased for every change that is
+// not backwards compatible.
+const SchemaVersion = 1
+
+// filePrefix is the prefix for all snapshot files written by the Writer.
+const filePrefix = "status-"
+
+// Snapshot represents a compact subset of the machine-readable status that is useful for trend analysis.
+type Snapshot struct {
+	// SchemaVersion defines the version of the schema of this snapshot.
+	SchemaVersion int `json:"schemaVersion"`
+	// Timestamp defines when the snapshot was taken.
+	Timestamp time.Time `json:"timestamp"`
+	// Namespace of the FoundationDBCluster.
+	Namespace string `json:"namespace"`
+	// Cluster is the name of the FoundationDBCluster.
+	Cluster string `json:"cluster"`
+	// Available reports if the database is available.
+	Available bool `json:"available"`
+	// Healthy reports if the database is healthy.
+	Healthy bool `json:"healthy"`
+	// FullReplication reports if the database is fully replicated.
+	FullReplication bool `json:"fullReplication"`
+	// Generation is the current generation of the transaction system.
+	Generation int `json:"generation"`
+	// RecoveryState is the name of the current recovery state.
+	RecoveryState string `json:"recoveryState,omitempty"`
+	// ProcessCounts contains the number of reporting processes per process class.
+	ProcessCounts map[fdbv1beta2.ProcessClass]int `json:"processCounts"`
+	// ExcludedProcesses is the number of reporting processes that are excluded.
+	ExcludedProcesses int `json:"excludedProcesses"`
+	// RoleCounts contains the number of processes per role.
+	RoleCounts map[string]int `json:"roleCounts"`
+	// KVBytes is the total size of the key-value pairs in the database.
+	KVBytes int `json:"kvBytes"`
+	// StoredBytes is the sum of the stored bytes reported by all storage roles.
+	StoredBytes int `json:"storedBytes"`
+	// MovingDataInFlightBytes is the number of bytes that are currently moved.
+	MovingDataInFlightBytes int `json:"movingDataInFlightBytes"`
+	// MovingDataInQueueBytes is the number of bytes that are queued to be moved.
+	MovingDataInQueueBytes int `json:"movingDataInQueueBytes"`
+	// WorstDataLagSeconds is the worst data lag of any storage server.
+	WorstDataLagSeconds float64 `json:"worstDataLagSeconds"`
+	// WorstDurabilityLagSeconds is the worst durability lag of any storage server.
+	WorstDurabilityLagSeconds float64 `json:"worstDurabilityLagSeconds"`
+	// MaxZoneFailuresWithoutLosingData is the number of zones that can fail without losing data.
+	MaxZoneFailuresWithoutLosingData int `json:"maxZoneFailuresWithoutLosingData"`
+	// MaxZoneFailuresWithoutLosingAvailability is the number of zones that can fail without losing availability.
+	MaxZoneFailuresWithoutLosingAvailability int `json:"maxZoneFailuresWithoutLosingAvailability"`
+}
+
+// NewSnapshot creates a new Snapshot for the provided cluster and machine-readable status.
+func NewSnapshot(cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, timestamp time.Time) Snapshot {
+	snapshot := Snapshot{
+		SchemaVersion:                            SchemaVersion,
+		Timestamp:                                timestamp.UTC(),
+		Namespace:                                cluster.Namespace,
+		Cluster:                                  cluster.Name,
+		Available:                                status.Client.DatabaseStatus.Available,
+		Healthy:                                  status.Client.DatabaseStatus.Healthy,
+		FullReplication:                          status.Cluster.FullReplication,
+		Generation:                               status.Cluster.Generation,
+		RecoveryState:                            status.Cluster.RecoveryState.Name,
+		ProcessCounts:                            map[fdbv1beta2.ProcessClass]int{},
+		RoleCounts:                               map[string]int{},
+		KVBytes:                                  status.Cluster.Data.KVBytes,
+		MovingDataInFlightBytes:                  status.Cluster.Data.MovingData.InFlightBytes,
+		MovingDataInQueueBytes:                   status.Cluster.Data.MovingData.InQueueBytes,
+		WorstDataLagSeconds:                      status.Cluster.Qos.WorstDataLagStorageServer.Seconds,
+		WorstDurabilityLagSeconds:                status.Cluster.Qos.WorstDurabilityLagStorageServer.Seconds,
+		MaxZoneFailuresWithoutLosingData:         status.Cluster.FaultTolerance.MaxZoneFailuresWithoutLosingData,
+		MaxZoneFailuresWithoutLosingAvailability: status.Cluster.FaultTolerance.MaxZoneFailuresWithoutLosingAvailability,
+	}
+
+	for _, process := range status.Cluster.Processes {
+		snapshot.ProcessCounts[process.ProcessClass]++
+		if process.Excluded {
+			snapshot.ExcludedProcesses++
+		}
+
+		for _, role := range process.Roles {
+			snapshot.RoleCounts[role.Role]++
+			if role.Role == string(fdbv1beta2.ProcessRoleStorage) {
+				snapshot.StoredBytes += role.StoredBytes
+			}
+		}
+	}
+
+	return snapshot
+}
+
+// Writer writes snapshots of the machine-readable status into a directory and removes snapshots that are older than
+// the retention. The directory can be backed by a PersistentVolumeClaim or by an object store that is mounted into the
+// operator Pod.
+type Writer struct {
+	log       logr.Logger
+	directory string
+	interval  time.Duration
+	retention time.Duration
+	// lastWrite contains the time of the last written snapshot per cluster.
+	lastWrite map[string]time.Time
+	lock      sync.Mutex
+	// now returns the current time, this allows to modify the time in tests.
+	now func() time.Time
+}
+
+// NewWriter returns a new Writer that writes at most one snapshot per interval for every cluster into directory and
+// removes snapshots that are older than retention. A retention of 0 disables the removal of old snapshots.
+func NewWriter(log logr.Logger, directory string, interval time.Duration, retention time.Duration) *Writer {
+	return &Writer{
+		log:       log,
+		directory: directory,
+		interval:  interval,
+		retention: retention,
+		lastWrite: map[string]time.Time{},
+		now:       time.Now,
+	}
+}
+
+// Write writes a snapshot of the provided status for the cluster, if the last snapshot for this cluster was written
+// more than the configured interval ago. Snapshots are written to <directory>/<namespace>/<cluster>/status-<unix timestamp>.json.
+func (w *Writer) Write(cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus) error {
+	key := cluster.Namespace + "/" + cluster.Name
+	now := w.now()
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if lastWrite, ok := w.lastWrite[key]; ok && now.Sub(lastWrite) < w.interval {
+		return nil
+	}
+
+	content, err := json.Marshal(NewSnapshot(cluster, status, now))
+	if err != nil {
+		return err
+	}
+
+	clusterDirectory := filepath.Join(w.directory, cluster.Namespace, cluster.Name)
+	err = os.MkdirAll(clusterDirectory, 0755)
+	if err != nil {
+		return err
+	}
+
+	// Write the snapshot into a temporary file first to make sure readers never observe partial snapshots.
+	fileName := fmt.Sprintf("%s%d.json", filePrefix, now.Unix())
+	tmpFile := filepath.Join(clusterDirectory, "."+fileName)
+	err = os.WriteFile(tmpFile, content, 0644)
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(tmpFile, filepath.Join(clusterDirectory, fileName))
+	if err != nil {
+		return err
+	}
+
+	w.lastWrite[key] = now
+	w.log.V(1).Info("Wrote status snapshot", "namespace", cluster.Namespace, "cluster", cluster.Name, "file", fileName)
+
+	return w.removeExpiredSnapshots(clusterDirectory, now)
+}
+
+// removeExpiredSnapshots removes all snapshots in the directory that are older than the retention.
+func (w *Writer) removeExpiredSnapshots(directory string, now time.Time) error {
+	if w.retention <= 0 {
+		return nil
+	}
+
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		timestamp, ok := parseTimestamp(entry.Name())
+		if !ok {
+			continue
+		}
+
+		if now.Sub(timestamp) <= w.retention {
+			continue
+		}
+
+		err = os.Remove(filepath.Join(directory, entry.Name()))
+		// If the file doesn't exist move on, another routine could have removed it.
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseTimestamp returns the timestamp that is encoded in the snapshot file name.
+func parseTimestamp(fileName string) (time.Time, bool) {
+	if !strings.HasPrefix(fileName, filePrefix) || !strings.HasSuffix(fileName, ".json") {
+		return time.Time{}, false
+	}
+
+	seconds, err := strconv.ParseInt(strings.TrimSuffix(strings.TrimPrefix(fileName, filePrefix), ".json"), 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return time.Unix(seconds, 0), true
+}
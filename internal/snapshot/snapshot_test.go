@@ -0,0 +1,186 @@
+/*
+ * snapshot_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package snapshot
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("snapshot", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var status *fdbv1beta2.FoundationDBStatus
+
+	BeforeEach(func() {
+		cluster = &fdbv1beta2.FoundationDBCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "test-ns",
+			},
+		}
+
+		status = &fdbv1beta2.FoundationDBStatus{
+			Client: fdbv1beta2.FoundationDBStatusLocalClientInfo{
+				DatabaseStatus: fdbv1beta2.FoundationDBStatusClientDBStatus{
+					Available: true,
+					Healthy:   true,
+				},
+			},
+			Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+				Generation: 4,
+				Data: fdbv1beta2.FoundationDBStatusDataStatistics{
+					KVBytes: 1024,
+				},
+				Processes: map[fdbv1beta2.ProcessGroupID]fdbv1beta2.FoundationDBStatusProcessInfo{
+					"storage-1": {
+						ProcessClass: fdbv1beta2.ProcessClassStorage,
+						Roles: []fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+							{Role: string(fdbv1beta2.ProcessRoleStorage), StoredBytes: 100},
+						},
+					},
+					"storage-2": {
+						ProcessClass: fdbv1beta2.ProcessClassStorage,
+						Roles: []fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+							{Role: string(fdbv1beta2.ProcessRoleStorage), StoredBytes: 50},
+							{Role: string(fdbv1beta2.ProcessRoleCoordinator)},
+						},
+					},
+					"log-1": {
+						ProcessClass: fdbv1beta2.ProcessClassLog,
+						Excluded:     true,
+						Roles: []fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+							{Role: string(fdbv1beta2.ProcessRoleLog)},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	When("creating a new snapshot", func() {
+		var snapshot Snapshot
+		var timestamp time.Time
+
+		BeforeEach(func() {
+			timestamp = time.Unix(1700000000, 0)
+			snapshot = NewSnapshot(cluster, status, timestamp)
+		})
+
+		It("should contain the selected fields", func() {
+			Expect(snapshot.SchemaVersion).To(Equal(SchemaVersion))
+			Expect(snapshot.Timestamp).To(BeTemporally("==", timestamp))
+			Expect(snapshot.Namespace).To(Equal("test-ns"))
+			Expect(snapshot.Cluster).To(Equal("test"))
+			Expect(snapshot.Available).To(BeTrue())
+			Expect(snapshot.Healthy).To(BeTrue())
+			Expect(snapshot.Generation).To(Equal(4))
+			Expect(snapshot.KVBytes).To(Equal(1024))
+			Expect(snapshot.StoredBytes).To(Equal(150))
+			Expect(snapshot.ExcludedProcesses).To(Equal(1))
+			Expect(snapshot.ProcessCounts).To(Equal(map[fdbv1beta2.ProcessClass]int{
+				fdbv1beta2.ProcessClassStorage: 2,
+				fdbv1beta2.ProcessClassLog:     1,
+			}))
+			Expect(snapshot.RoleCounts).To(Equal(map[string]int{
+				string(fdbv1beta2.ProcessRoleStorage):     2,
+				string(fdbv1beta2.ProcessRoleCoordinator): 1,
+				string(fdbv1beta2.ProcessRoleLog):         1,
+			}))
+		})
+	})
+
+	When("writing snapshots", func() {
+		var writer *Writer
+		var directory string
+		var currentTime time.Time
+		var clusterDirectory string
+
+		BeforeEach(func() {
+			directory = GinkgoT().TempDir()
+			clusterDirectory = filepath.Join(directory, cluster.Namespace, cluster.Name)
+			currentTime = time.Unix(1700000000, 0)
+			writer = NewWriter(logr.Discard(), directory, 10*time.Minute, time.Hour)
+			writer.now = func() time.Time {
+				return currentTime
+			}
+
+			Expect(writer.Write(cluster, status)).NotTo(HaveOccurred())
+		})
+
+		It("should write the snapshot", func() {
+			content, err := os.ReadFile(filepath.Join(clusterDirectory, "status-1700000000.json"))
+			Expect(err).NotTo(HaveOccurred())
+
+			var snapshot Snapshot
+			Expect(json.Unmarshal(content, &snapshot)).NotTo(HaveOccurred())
+			Expect(snapshot.Cluster).To(Equal("test"))
+			Expect(snapshot.StoredBytes).To(Equal(150))
+		})
+
+		When("the interval has not passed", func() {
+			BeforeEach(func() {
+				currentTime = currentTime.Add(5 * time.Minute)
+				Expect(writer.Write(cluster, status)).NotTo(HaveOccurred())
+			})
+
+			It("should not write another snapshot", func() {
+				entries, err := os.ReadDir(clusterDirectory)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(entries).To(HaveLen(1))
+			})
+		})
+
+		When("the interval has passed", func() {
+			BeforeEach(func() {
+				currentTime = currentTime.Add(10 * time.Minute)
+				Expect(writer.Write(cluster, status)).NotTo(HaveOccurred())
+			})
+
+			It("should write another snapshot", func() {
+				entries, err := os.ReadDir(clusterDirectory)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(entries).To(HaveLen(2))
+			})
+		})
+
+		When("the retention has passed", func() {
+			BeforeEach(func() {
+				currentTime = currentTime.Add(2 * time.Hour)
+				Expect(writer.Write(cluster, status)).NotTo(HaveOccurred())
+			})
+
+			It("should remove the expired snapshot", func() {
+				entries, err := os.ReadDir(clusterDirectory)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(entries).To(HaveLen(1))
+				Expect(entries[0].Name()).To(Equal("status-1700007200.json"))
+			})
+		})
+	})
+})
@@ -0,0 +1,240 @@
+/*
+ * policy.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tenancy
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// Policies defines the tenant policies for the different namespaces.
+type Policies struct {
+	// Default defines the policy for all namespaces that have no specific policy. If unset, clusters in those
+	// namespaces will not be restricted.
+	Default *Policy `json:"default,omitempty"`
+
+	// Namespaces defines the policies for specific namespaces.
+	Namespaces map[string]Policy `json:"namespaces,omitempty"`
+}
+
+// Policy defines the restrictions for FoundationDBClusters in a tenant namespace.
+type Policy struct {
+	// AllowedImageRepositories defines the prefixes of the images that are allowed to be used, e.g.
+	// "docker.io/foundationdb/". If empty, all images are allowed.
+	AllowedImageRepositories []string `json:"allowedImageRepositories,omitempty"`
+
+	// MaxProcessCounts defines the maximum number of processes per process class.
+	MaxProcessCounts map[fdbv1beta2.ProcessClass]int `json:"maxProcessCounts,omitempty"`
+
+	// ForbiddenKnobs defines the custom parameters that are not allowed to be set, e.g. "knob_disable_posix_kernel_aio".
+	ForbiddenKnobs []string `json:"forbiddenKnobs,omitempty"`
+
+	// RequireTLS defines whether the cluster must enable TLS.
+	RequireTLS bool `json:"requireTLS,omitempty"`
+
+	// AllowedSysctls defines the sysctls that are allowed to be set in the kernel settings of the process classes. A
+	// trailing "*" matches all sysctls with the given prefix, e.g. "net.ipv4.tcp_keepalive_*". If unset, all sysctls are
+	// allowed, an empty list forbids all sysctls.
+	AllowedSysctls []string `json:"allowedSysctls,omitempty"`
+
+	// ForbidDisablingTransparentHugePages defines whether the privileged init container to disable transparent huge
+	// pages is forbidden.
+	ForbidDisablingTransparentHugePages bool `json:"forbidDisablingTransparentHugePages,omitempty"`
+}
+
+// LoadPolicies reads the tenant policies from the provided file.
+func LoadPolicies(path string) (*Policies, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	policies := &Policies{}
+	err = yaml.UnmarshalStrict(content, policies)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse tenant policies from %s: %w", path, err)
+	}
+
+	return policies, nil
+}
+
+// GetPolicy returns the policy for the provided namespace or nil if the namespace is not restricted.
+func (policies *Policies) GetPolicy(namespace string) *Policy {
+	if policies == nil {
+		return nil
+	}
+
+	policy, ok := policies.Namespaces[namespace]
+	if ok {
+		return &policy
+	}
+
+	return policies.Default
+}
+
+// Validate checks if the provided cluster complies with the policy and returns all violations.
+func (policy *Policy) Validate(cluster *fdbv1beta2.FoundationDBCluster) ([]string, error) {
+	if policy == nil {
+		return nil, nil
+	}
+
+	// Work on a normalized copy to make sure the defaults, e.g. for the images, are validated too.
+	normalized := cluster.DeepCopy()
+	err := internal.NormalizeClusterSpec(normalized, internal.DeprecationOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var violations []string
+	if policy.RequireTLS && !normalized.Spec.MainContainer.EnableTLS {
+		violations = append(violations, "TLS must be enabled")
+	}
+
+	processCounts, err := normalized.GetProcessCountsWithDefaults()
+	if err != nil {
+		return nil, err
+	}
+
+	desiredCounts := processCounts.Map()
+	for _, processClass := range sortedProcessClasses(policy.MaxProcessCounts) {
+		maxCount := policy.MaxProcessCounts[processClass]
+		if desiredCounts[processClass] > maxCount {
+			violations = append(violations, fmt.Sprintf("process count %d for process class %s exceeds the maximum of %d", desiredCounts[processClass], processClass, maxCount))
+		}
+	}
+
+	forbiddenKnobs := make(map[string]fdbv1beta2.None, len(policy.ForbiddenKnobs))
+	for _, knob := range policy.ForbiddenKnobs {
+		forbiddenKnobs[knob] = fdbv1beta2.None{}
+	}
+
+	for _, processClass := range sortedProcessClasses(desiredCounts) {
+		if desiredCounts[processClass] <= 0 {
+			continue
+		}
+
+		for _, parameter := range normalized.GetProcessSettings(processClass).CustomParameters {
+			parameterName := strings.TrimSpace(strings.Split(string(parameter), "=")[0])
+			if _, ok := forbiddenKnobs[parameterName]; ok {
+				violations = append(violations, fmt.Sprintf("customParameter %s for process class %s is forbidden", parameterName, processClass))
+			}
+		}
+
+		for _, sysctl := range normalized.GetSysctls(processClass) {
+			if !policy.isSysctlAllowed(sysctl.Name) {
+				violations = append(violations, fmt.Sprintf("sysctl %s for process class %s is not allowed", sysctl.Name, processClass))
+			}
+		}
+
+		if policy.ForbidDisablingTransparentHugePages && normalized.DisableTransparentHugePages(processClass) {
+			violations = append(violations, fmt.Sprintf("disabling transparent huge pages for process class %s is forbidden", processClass))
+		}
+
+		if len(policy.AllowedImageRepositories) == 0 {
+			continue
+		}
+
+		_, processGroupID := normalized.GetProcessGroupID(processClass, 1)
+		podSpec, err := internal.GetPodSpec(normalized, &fdbv1beta2.ProcessGroupStatus{ProcessGroupID: processGroupID, ProcessClass: processClass})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, image := range getImages(podSpec) {
+			if !policy.isImageAllowed(image) {
+				violations = append(violations, fmt.Sprintf("image %s for process class %s is not in the allowed image repositories", image, processClass))
+			}
+		}
+	}
+
+	return violations, nil
+}
+
+// isImageAllowed returns true if the image matches one of the allowed image repositories.
+func (policy *Policy) isImageAllowed(image string) bool {
+	for _, repository := range policy.AllowedImageRepositories {
+		if strings.HasPrefix(image, repository) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isSysctlAllowed returns true if the sysctl matches one of the allowed sysctls or if no allowed sysctls are defined.
+func (policy *Policy) isSysctlAllowed(name string) bool {
+	if policy.AllowedSysctls == nil {
+		return true
+	}
+
+	for _, allowed := range policy.AllowedSysctls {
+		if strings.HasSuffix(allowed, "*") && strings.HasPrefix(name, strings.TrimSuffix(allowed, "*")) {
+			return true
+		}
+
+		if allowed == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getImages returns the distinct images of all containers in the provided Pod spec.
+func getImages(podSpec *corev1.PodSpec) []string {
+	containers := make([]corev1.Container, 0, len(podSpec.InitContainers)+len(podSpec.Containers))
+	containers = append(containers, podSpec.InitContainers...)
+	containers = append(containers, podSpec.Containers...)
+
+	images := make([]string, 0, len(containers))
+	seen := map[string]fdbv1beta2.None{}
+	for _, container := range containers {
+		if _, ok := seen[container.Image]; ok {
+			continue
+		}
+
+		seen[container.Image] = fdbv1beta2.None{}
+		images = append(images, container.Image)
+	}
+
+	return images
+}
+
+// sortedProcessClasses returns the process classes of the provided map in a stable order.
+func sortedProcessClasses(counts map[fdbv1beta2.ProcessClass]int) []fdbv1beta2.ProcessClass {
+	processClasses := make([]fdbv1beta2.ProcessClass, 0, len(counts))
+	for processClass := range counts {
+		processClasses = append(processClasses, processClass)
+	}
+
+	sort.Slice(processClasses, func(i, j int) bool {
+		return processClasses[i] < processClasses[j]
+	})
+
+	return processClasses
+}
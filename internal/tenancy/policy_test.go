@@ -0,0 +1,282 @@
+/*
+ * policy_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tenancy
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("tenant policies", func() {
+	When("loading the policies from a file", func() {
+		var policies *Policies
+		var err error
+		var content string
+
+		JustBeforeEach(func() {
+			path := filepath.Join(GinkgoT().TempDir(), "policies.yaml")
+			Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+			policies, err = LoadPolicies(path)
+		})
+
+		When("the file is valid", func() {
+			BeforeEach(func() {
+				content = `
+default:
+  requireTLS: true
+namespaces:
+  team-a:
+    allowedImageRepositories:
+    - foundationdb/
+    maxProcessCounts:
+      storage: 10
+    forbiddenKnobs:
+    - knob_disable_posix_kernel_aio
+`
+			})
+
+			It("should parse the policies", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(policies.GetPolicy("team-a")).To(Equal(&Policy{
+					AllowedImageRepositories: []string{"foundationdb/"},
+					MaxProcessCounts: map[fdbv1beta2.ProcessClass]int{
+						fdbv1beta2.ProcessClassStorage: 10,
+					},
+					ForbiddenKnobs: []string{"knob_disable_posix_kernel_aio"},
+				}))
+				Expect(policies.GetPolicy("team-b")).To(Equal(&Policy{RequireTLS: true}))
+			})
+		})
+
+		When("the file contains unknown fields", func() {
+			BeforeEach(func() {
+				content = `
+default:
+  notAField: true
+`
+			})
+
+			It("should return an error", func() {
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	When("getting the policy for a namespace without a default policy", func() {
+		It("should return nil", func() {
+			policies := &Policies{
+				Namespaces: map[string]Policy{
+					"team-a": {RequireTLS: true},
+				},
+			}
+
+			Expect(policies.GetPolicy("team-b")).To(BeNil())
+		})
+	})
+
+	DescribeTable("validating a cluster against a policy", func(policy *Policy, modify func(*fdbv1beta2.FoundationDBCluster), expected []string) {
+		cluster := internal.CreateDefaultCluster()
+		if modify != nil {
+			modify(cluster)
+		}
+
+		violations, err := policy.Validate(cluster)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(violations).To(ConsistOf(expected))
+	},
+		Entry("without a policy",
+			nil,
+			nil,
+			nil),
+		Entry("with TLS required and disabled",
+			&Policy{RequireTLS: true},
+			nil,
+			[]string{"TLS must be enabled"}),
+		Entry("with TLS required and enabled",
+			&Policy{RequireTLS: true},
+			func(cluster *fdbv1beta2.FoundationDBCluster) {
+				cluster.Spec.MainContainer.EnableTLS = true
+			},
+			nil),
+		Entry("with too many storage processes",
+			&Policy{MaxProcessCounts: map[fdbv1beta2.ProcessClass]int{fdbv1beta2.ProcessClassStorage: 2}},
+			nil,
+			[]string{"process count 4 for process class storage exceeds the maximum of 2"}),
+		Entry("with a forbidden knob",
+			&Policy{ForbiddenKnobs: []string{"knob_disable_posix_kernel_aio"}},
+			func(cluster *fdbv1beta2.FoundationDBCluster) {
+				cluster.Spec.Processes = map[fdbv1beta2.ProcessClass]fdbv1beta2.ProcessSettings{
+					fdbv1beta2.ProcessClassGeneral: {
+						CustomParameters: fdbv1beta2.FoundationDBCustomParameters{"knob_disable_posix_kernel_aio=1"},
+					},
+				}
+			},
+			[]string{
+				"customParameter knob_disable_posix_kernel_aio for process class cluster_controller is forbidden",
+				"customParameter knob_disable_posix_kernel_aio for process class log is forbidden",
+				"customParameter knob_disable_posix_kernel_aio for process class stateless is forbidden",
+				"customParameter knob_disable_posix_kernel_aio for process class storage is forbidden",
+			}),
+		Entry("with an allowed sysctl",
+			&Policy{AllowedSysctls: []string{"net.ipv4.tcp_keepalive_*"}},
+			func(cluster *fdbv1beta2.FoundationDBCluster) {
+				cluster.Spec.Processes = map[fdbv1beta2.ProcessClass]fdbv1beta2.ProcessSettings{
+					fdbv1beta2.ProcessClassStorage: {
+						KernelSettings: &fdbv1beta2.KernelSettings{
+							Sysctls: []corev1.Sysctl{{Name: "net.ipv4.tcp_keepalive_time", Value: "60"}},
+						},
+					},
+				}
+			},
+			nil),
+		Entry("with a sysctl that is not allowed",
+			&Policy{AllowedSysctls: []string{"net.ipv4.tcp_keepalive_*"}},
+			func(cluster *fdbv1beta2.FoundationDBCluster) {
+				cluster.Spec.Processes = map[fdbv1beta2.ProcessClass]fdbv1beta2.ProcessSettings{
+					fdbv1beta2.ProcessClassStorage: {
+						KernelSettings: &fdbv1beta2.KernelSettings{
+							Sysctls: []corev1.Sysctl{{Name: "net.core.somaxconn", Value: "4096"}},
+						},
+					},
+				}
+			},
+			[]string{"sysctl net.core.somaxconn for process class storage is not allowed"}),
+		Entry("with disabled transparent huge pages when this is forbidden",
+			&Policy{ForbidDisablingTransparentHugePages: true},
+			func(cluster *fdbv1beta2.FoundationDBCluster) {
+				cluster.Spec.Processes = map[fdbv1beta2.ProcessClass]fdbv1beta2.ProcessSettings{
+					fdbv1beta2.ProcessClassStorage: {
+						KernelSettings: &fdbv1beta2.KernelSettings{
+							DisableTransparentHugePages: pointer.Bool(true),
+						},
+					},
+				}
+			},
+			[]string{"disabling transparent huge pages for process class storage is forbidden"}),
+		Entry("with the default images and an allowed repository",
+			&Policy{AllowedImageRepositories: []string{"foundationdb/"}},
+			nil,
+			nil),
+		Entry("with a main container image from a different repository",
+			&Policy{AllowedImageRepositories: []string{"foundationdb/"}},
+			func(cluster *fdbv1beta2.FoundationDBCluster) {
+				cluster.Spec.MainContainer.ImageConfigs = []fdbv1beta2.ImageConfig{
+					{
+						BaseImage: "registry.example.com/foundationdb",
+					},
+				}
+			},
+			[]string{
+				fmt.Sprintf("image registry.example.com/foundationdb:%s for process class cluster_controller is not in the allowed image repositories", fdbv1beta2.Versions.Default),
+				fmt.Sprintf("image registry.example.com/foundationdb:%s for process class log is not in the allowed image repositories", fdbv1beta2.Versions.Default),
+				fmt.Sprintf("image registry.example.com/foundationdb:%s for process class stateless is not in the allowed image repositories", fdbv1beta2.Versions.Default),
+				fmt.Sprintf("image registry.example.com/foundationdb:%s for process class storage is not in the allowed image repositories", fdbv1beta2.Versions.Default),
+			}),
+	)
+
+	When("validating a cluster with the webhook", func() {
+		var validator *ClusterValidator
+		var cluster *fdbv1beta2.FoundationDBCluster
+
+		BeforeEach(func() {
+			validator = NewClusterValidator(&Policies{
+				Namespaces: map[string]Policy{
+					"team-a": {RequireTLS: true},
+				},
+			})
+			cluster = internal.CreateDefaultCluster()
+		})
+
+		When("the namespace has a policy", func() {
+			BeforeEach(func() {
+				cluster.Namespace = "team-a"
+			})
+
+			It("should reject the cluster", func() {
+				err := validator.ValidateCreate(context.Background(), cluster)
+				Expect(err).To(MatchError("cluster team-a/operator-test-1 violates the tenant policy: TLS must be enabled"))
+			})
+
+			It("should allow the deletion", func() {
+				Expect(validator.ValidateDelete(context.Background(), cluster)).To(Succeed())
+			})
+		})
+
+		When("the namespace has no policy", func() {
+			BeforeEach(func() {
+				cluster.Namespace = "team-b"
+			})
+
+			It("should accept the cluster", func() {
+				Expect(validator.ValidateUpdate(context.Background(), cluster, cluster)).To(Succeed())
+			})
+
+			When("the process group ID prefix is invalid", func() {
+				BeforeEach(func() {
+					cluster.Spec.ProcessGroupIDPrefix = "-invalid"
+				})
+
+				It("should reject the cluster", func() {
+					err := validator.ValidateUpdate(context.Background(), cluster, cluster)
+					Expect(err).To(MatchError("processGroupIDPrefix -invalid must consist of alphanumeric characters, '-', '_' or '.' and must start and end with an alphanumeric character"))
+				})
+			})
+		})
+
+		When("the spec validation is enabled", func() {
+			BeforeEach(func() {
+				validator = NewClusterValidator(nil).WithSpecValidation(nil, internal.DeprecationOptions{})
+				cluster.Namespace = "team-b"
+			})
+
+			It("should accept a valid cluster", func() {
+				Expect(validator.ValidateCreate(context.Background(), cluster)).To(Succeed())
+			})
+
+			When("the spec is invalid", func() {
+				BeforeEach(func() {
+					cluster.Spec.DatabaseConfiguration.RedundancyMode = fdbv1beta2.RedundancyModeTriple
+					cluster.Spec.ProcessCounts.Storage = 2
+				})
+
+				It("should reject the cluster", func() {
+					err := validator.ValidateCreate(context.Background(), cluster)
+					Expect(err).To(MatchError("cluster team-b/operator-test-1 is not valid: processCounts.storage 2 must be at least 3 for redundancy mode triple"))
+				})
+
+				It("should not modify the cluster", func() {
+					original := cluster.DeepCopy()
+					Expect(validator.ValidateCreate(context.Background(), cluster)).NotTo(Succeed())
+					Expect(cluster).To(Equal(original))
+				})
+			})
+		})
+	})
+})
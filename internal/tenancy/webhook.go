@@ -0,0 +1,151 @@
+/*
+ * webhook.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tenancy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal/clustertemplate"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:webhook:path=/validate-apps-foundationdb-org-v1beta2-foundationdbcluster,mutating=false,failurePolicy=fail,sideEffects=None,groups=apps.foundationdb.org,resources=foundationdbclusters,verbs=create;update,versions=v1beta2,name=vfoundationdbcluster.kb.io,admissionReviewVersions=v1
+
+// ClusterValidator validates FoundationDBClusters against the tenant policies and optionally validates the cluster
+// spec.
+type ClusterValidator struct {
+	policies *Policies
+	// specValidation defines if the cluster spec should be validated like before a reconciliation.
+	specValidation bool
+	// reader is used to resolve the cluster templates if specValidation is enabled.
+	reader client.Reader
+	// deprecationOptions are used to normalize the cluster spec if specValidation is enabled.
+	deprecationOptions internal.DeprecationOptions
+}
+
+var _ admission.CustomValidator = &ClusterValidator{}
+
+// NewClusterValidator creates a new ClusterValidator for the provided policies.
+func NewClusterValidator(policies *Policies) *ClusterValidator {
+	return &ClusterValidator{
+		policies: policies,
+	}
+}
+
+// WithSpecValidation enables the validation of the cluster spec. The spec is validated with the same checks that the
+// operator performs before a reconciliation, so invalid clusters are rejected when they are created or updated. The
+// reader is used to resolve the referenced cluster templates.
+func (validator *ClusterValidator) WithSpecValidation(reader client.Reader, deprecationOptions internal.DeprecationOptions) *ClusterValidator {
+	validator.specValidation = true
+	validator.reader = reader
+	validator.deprecationOptions = deprecationOptions
+
+	return validator
+}
+
+// SetupWebhookWithManager registers the validating webhook for FoundationDBClusters.
+func (validator *ClusterValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&fdbv1beta2.FoundationDBCluster{}).
+		WithValidator(validator).
+		Complete()
+}
+
+// ValidateCreate validates a new FoundationDBCluster.
+func (validator *ClusterValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	return validator.validate(ctx, obj)
+}
+
+// ValidateUpdate validates the updated FoundationDBCluster.
+func (validator *ClusterValidator) ValidateUpdate(ctx context.Context, _ runtime.Object, newObj runtime.Object) error {
+	return validator.validate(ctx, newObj)
+}
+
+// ValidateDelete allows all deletions.
+func (validator *ClusterValidator) ValidateDelete(_ context.Context, _ runtime.Object) error {
+	return nil
+}
+
+// validate checks the processGroupIDPrefix of the provided object, validates the spec if the spec validation is
+// enabled and validates the object against the policy of its namespace.
+func (validator *ClusterValidator) validate(ctx context.Context, obj runtime.Object) error {
+	cluster, ok := obj.(*fdbv1beta2.FoundationDBCluster)
+	if !ok {
+		return fmt.Errorf("expected a FoundationDBCluster but got %T", obj)
+	}
+
+	err := cluster.ValidateProcessGroupIDPrefix(cluster.Spec.ProcessGroupIDPrefix)
+	if err != nil {
+		return err
+	}
+
+	namespace := cluster.Namespace
+	if namespace == "" {
+		req, err := admission.RequestFromContext(ctx)
+		if err == nil {
+			namespace = req.Namespace
+		}
+	}
+
+	if validator.specValidation {
+		err = validator.validateSpec(ctx, cluster, namespace)
+		if err != nil {
+			return fmt.Errorf("cluster %s/%s is not valid: %w", namespace, cluster.Name, err)
+		}
+	}
+
+	violations, err := validator.policies.GetPolicy(namespace).Validate(cluster)
+	if err != nil {
+		return err
+	}
+
+	if len(violations) > 0 {
+		return fmt.Errorf("cluster %s/%s violates the tenant policy: %s", namespace, cluster.Name, strings.Join(violations, ", "))
+	}
+
+	return nil
+}
+
+// validateSpec resolves the cluster template and normalizes a copy of the cluster, before the cluster spec is
+// validated.
+func (validator *ClusterValidator) validateSpec(ctx context.Context, cluster *fdbv1beta2.FoundationDBCluster, namespace string) error {
+	resolved := cluster.DeepCopy()
+	resolved.Namespace = namespace
+
+	err := clustertemplate.Resolve(ctx, validator.reader, resolved)
+	if err != nil {
+		return err
+	}
+
+	err = internal.NormalizeClusterSpec(resolved, validator.deprecationOptions)
+	if err != nil {
+		return err
+	}
+
+	return resolved.Validate()
+}
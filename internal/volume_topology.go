@@ -0,0 +1,135 @@
+/*
+ * volume_topology.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2021 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetVolumeTopologyConflict checks if the provided Pod spec can be scheduled on any node that satisfies the node
+// affinity of the persistent volume that is bound to the PVC. If no such node exists, a message describing the
+// conflict is returned. If the PVC is not bound, the persistent volume has no node affinity or no nodes are found, the
+// conflict can't be determined and an empty message is returned.
+func GetVolumeTopologyConflict(ctx context.Context, reader client.Reader, spec *corev1.PodSpec, pvc *corev1.PersistentVolumeClaim) (string, error) {
+	if pvc == nil || pvc.Spec.VolumeName == "" {
+		return "", nil
+	}
+
+	volume := &corev1.PersistentVolume{}
+	err := reader.Get(ctx, client.ObjectKey{Name: pvc.Spec.VolumeName}, volume)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return "", nil
+		}
+
+		return "", err
+	}
+
+	if volume.Spec.NodeAffinity == nil || volume.Spec.NodeAffinity.Required == nil {
+		return "", nil
+	}
+
+	nodes := &corev1.NodeList{}
+	err = reader.List(ctx, nodes)
+	if err != nil {
+		return "", err
+	}
+
+	if len(nodes.Items) == 0 {
+		return "", nil
+	}
+
+	for idx := range nodes.Items {
+		node := &nodes.Items[idx]
+		if nodeMatchesNodeSelector(node, volume.Spec.NodeAffinity.Required) && podSpecMatchesNode(spec, node) {
+			return "", nil
+		}
+	}
+
+	return fmt.Sprintf("no node satisfies the node affinity of persistent volume %s and the node constraints of the desired Pod spec", volume.Name), nil
+}
+
+// podSpecMatchesNode returns true if the node satisfies the node selector and the required node affinity of the Pod
+// spec.
+func podSpecMatchesNode(spec *corev1.PodSpec, node *corev1.Node) bool {
+	if len(spec.NodeSelector) > 0 && !labels.SelectorFromSet(spec.NodeSelector).Matches(labels.Set(node.Labels)) {
+		return false
+	}
+
+	if spec.Affinity == nil || spec.Affinity.NodeAffinity == nil || spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return true
+	}
+
+	return nodeMatchesNodeSelector(node, spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution)
+}
+
+// nodeMatchesNodeSelector returns true if the node matches any of the terms of the node selector. Like in the
+// Kubernetes scheduler, a term without any requirements matches no node.
+func nodeMatchesNodeSelector(node *corev1.Node, nodeSelector *corev1.NodeSelector) bool {
+	for _, term := range nodeSelector.NodeSelectorTerms {
+		if len(term.MatchExpressions) == 0 && len(term.MatchFields) == 0 {
+			continue
+		}
+
+		if nodeSelectorRequirementsMatch(term.MatchExpressions, labels.Set(node.Labels)) &&
+			nodeSelectorRequirementsMatch(term.MatchFields, fields.Set{"metadata.name": node.Name}) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// nodeSelectorOperators maps the node selector operators to the label selector operators.
+var nodeSelectorOperators = map[corev1.NodeSelectorOperator]selection.Operator{
+	corev1.NodeSelectorOpIn:           selection.In,
+	corev1.NodeSelectorOpNotIn:        selection.NotIn,
+	corev1.NodeSelectorOpExists:       selection.Exists,
+	corev1.NodeSelectorOpDoesNotExist: selection.DoesNotExist,
+	corev1.NodeSelectorOpGt:           selection.GreaterThan,
+	corev1.NodeSelectorOpLt:           selection.LessThan,
+}
+
+// nodeSelectorRequirementsMatch returns true if all requirements match the provided labels. Invalid requirements
+// match no labels.
+func nodeSelectorRequirementsMatch(requirements []corev1.NodeSelectorRequirement, values labels.Labels) bool {
+	for _, requirement := range requirements {
+		operator, ok := nodeSelectorOperators[requirement.Operator]
+		if !ok {
+			return false
+		}
+
+		labelRequirement, err := labels.NewRequirement(requirement.Key, operator, requirement.Values)
+		if err != nil || !labelRequirement.Matches(values) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,94 @@
+/*
+ * volume_topology_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2021 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("volume_topology", func() {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node-1",
+			Labels: map[string]string{
+				corev1.LabelTopologyZone: "zone-a",
+				"cpu-count":              "64",
+			},
+		},
+	}
+
+	requiredAffinity := func(terms ...corev1.NodeSelectorTerm) *corev1.Affinity {
+		return &corev1.Affinity{
+			NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+					NodeSelectorTerms: terms,
+				},
+			},
+		}
+	}
+
+	DescribeTable("checking if a Pod spec matches a node", func(spec *corev1.PodSpec, expected bool) {
+		Expect(podSpecMatchesNode(spec, node)).To(Equal(expected))
+	},
+		Entry("without node constraints", &corev1.PodSpec{}, true),
+		Entry("with a matching node selector", &corev1.PodSpec{
+			NodeSelector: map[string]string{corev1.LabelTopologyZone: "zone-a"},
+		}, true),
+		Entry("with a node selector for a different zone", &corev1.PodSpec{
+			NodeSelector: map[string]string{corev1.LabelTopologyZone: "zone-b"},
+		}, false),
+		Entry("with a matching node affinity", &corev1.PodSpec{
+			Affinity: requiredAffinity(corev1.NodeSelectorTerm{
+				MatchExpressions: []corev1.NodeSelectorRequirement{
+					{Key: corev1.LabelTopologyZone, Operator: corev1.NodeSelectorOpIn, Values: []string{"zone-a", "zone-b"}},
+					{Key: "cpu-count", Operator: corev1.NodeSelectorOpGt, Values: []string{"32"}},
+				},
+			}),
+		}, true),
+		Entry("with a node affinity that excludes the zone", &corev1.PodSpec{
+			Affinity: requiredAffinity(corev1.NodeSelectorTerm{
+				MatchExpressions: []corev1.NodeSelectorRequirement{
+					{Key: corev1.LabelTopologyZone, Operator: corev1.NodeSelectorOpNotIn, Values: []string{"zone-a"}},
+				},
+			}),
+		}, false),
+		Entry("with a node affinity where only the second term matches", &corev1.PodSpec{
+			Affinity: requiredAffinity(
+				corev1.NodeSelectorTerm{
+					MatchExpressions: []corev1.NodeSelectorRequirement{
+						{Key: "missing", Operator: corev1.NodeSelectorOpExists},
+					},
+				},
+				corev1.NodeSelectorTerm{
+					MatchFields: []corev1.NodeSelectorRequirement{
+						{Key: "metadata.name", Operator: corev1.NodeSelectorOpIn, Values: []string{"node-1"}},
+					},
+				},
+			),
+		}, true),
+		Entry("with a node affinity with an empty term", &corev1.PodSpec{
+			Affinity: requiredAffinity(corev1.NodeSelectorTerm{}),
+		}, false),
+	)
+})
@@ -0,0 +1,179 @@
+/*
+ * approve_deletions.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	ctx "context"
+	"errors"
+	"fmt"
+	"log"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newApproveDeletionsCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "approve-deletions",
+		Short: "Deletes the Pods and PVCs that the operator requested to delete for the given cluster",
+		Long:  "Deletes the Pods and PVCs of the process groups with the PodDeletionRequired or PVCDeletionRequired condition. Those conditions are added by an operator that runs with destructive actions disabled and must be approved by a user with the permissions to delete Pods and PVCs.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			wait, err := cmd.Root().Flags().GetBool("wait")
+			if err != nil {
+				return err
+			}
+			clusterName, err := cmd.Flags().GetString("fdb-cluster")
+			if err != nil {
+				return err
+			}
+
+			kubeClient, err := getKubeClient(cmd.Context(), o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			cluster, err := loadCluster(kubeClient, namespace, clusterName)
+			if err != nil {
+				return err
+			}
+
+			return approveDeletions(cmd, kubeClient, cluster, args, wait)
+		},
+		Example: `
+# Delete all Pods and PVCs that the operator requested to delete for a cluster in the current namespace
+kubectl fdb approve-deletions -c cluster
+
+# Only delete the Pods and PVCs of the process groups storage-1 and storage-2 for a cluster in the namespace default
+kubectl fdb -n default approve-deletions -c cluster storage-1 storage-2
+`,
+	}
+
+	cmd.Flags().StringP("fdb-cluster", "c", "", "approve the deletions of the provided cluster.")
+	err := cmd.MarkFlagRequired("fdb-cluster")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// approveDeletions deletes the Pods of the process groups with the PodDeletionRequired condition and the PVCs of the
+// process groups with the PVCDeletionRequired condition. If process group IDs are provided, only the resources of
+// those process groups will be deleted.
+func approveDeletions(cmd *cobra.Command, kubeClient client.Client, cluster *fdbv1beta2.FoundationDBCluster, processGroupIDs []string, wait bool) error {
+	selected := make(map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None, len(processGroupIDs))
+	for _, processGroupID := range processGroupIDs {
+		selected[fdbv1beta2.ProcessGroupID(processGroupID)] = fdbv1beta2.None{}
+	}
+
+	var pending []*fdbv1beta2.ProcessGroupStatus
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if len(selected) > 0 {
+			if _, ok := selected[processGroup.ProcessGroupID]; !ok {
+				continue
+			}
+		}
+
+		if processGroup.GetConditionTime(fdbv1beta2.PodDeletionRequired) == nil && processGroup.GetConditionTime(fdbv1beta2.PVCDeletionRequired) == nil {
+			continue
+		}
+
+		pending = append(pending, processGroup)
+	}
+
+	if len(pending) == 0 {
+		cmd.Printf("Cluster %s/%s has no deletions that require an approval\n", cluster.Namespace, cluster.Name)
+		return nil
+	}
+
+	pendingIDs := make([]fdbv1beta2.ProcessGroupID, 0, len(pending))
+	for _, processGroup := range pending {
+		pendingIDs = append(pendingIDs, processGroup.ProcessGroupID)
+	}
+
+	if wait {
+		if !confirmAction(fmt.Sprintf("Delete the resources of %v in cluster %s/%s", pendingIDs, cluster.Namespace, cluster.Name)) {
+			return fmt.Errorf("user aborted the deletion")
+		}
+	}
+
+	var deletionErr error
+	for _, processGroup := range pending {
+		if processGroup.GetConditionTime(fdbv1beta2.PodDeletionRequired) != nil {
+			pod := &corev1.Pod{}
+			err := kubeClient.Get(ctx.Background(), client.ObjectKey{Namespace: cluster.Namespace, Name: processGroup.GetPodName(cluster)}, pod)
+			if err == nil {
+				err = kubeClient.Delete(ctx.Background(), pod)
+			}
+
+			if err != nil && !k8serrors.IsNotFound(err) {
+				deletionErr = errors.Join(deletionErr, fmt.Errorf("could not delete Pod of process group %s: %w", processGroup.ProcessGroupID, err))
+			} else {
+				cmd.Printf("Deleted Pod of process group %s\n", processGroup.ProcessGroupID)
+			}
+		}
+
+		if processGroup.GetConditionTime(fdbv1beta2.PVCDeletionRequired) != nil {
+			labels := map[string]string{
+				cluster.GetProcessGroupIDLabel(): string(processGroup.ProcessGroupID),
+			}
+			for key, value := range cluster.GetMatchLabels() {
+				labels[key] = value
+			}
+
+			pvcs := &corev1.PersistentVolumeClaimList{}
+			err := kubeClient.List(ctx.Background(), pvcs, client.InNamespace(cluster.Namespace), client.MatchingLabels(labels))
+			if err != nil {
+				deletionErr = errors.Join(deletionErr, fmt.Errorf("could not list PVCs of process group %s: %w", processGroup.ProcessGroupID, err))
+				continue
+			}
+
+			for idx := range pvcs.Items {
+				err = kubeClient.Delete(ctx.Background(), &pvcs.Items[idx])
+				if err != nil && !k8serrors.IsNotFound(err) {
+					deletionErr = errors.Join(deletionErr, fmt.Errorf("could not delete PVC %s of process group %s: %w", pvcs.Items[idx].Name, processGroup.ProcessGroupID, err))
+					continue
+				}
+
+				cmd.Printf("Deleted PVC %s of process group %s\n", pvcs.Items[idx].Name, processGroup.ProcessGroupID)
+			}
+		}
+	}
+
+	return deletionErr
+}
@@ -0,0 +1,142 @@
+/*
+ * approve_deletions_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("[plugin] approve deletions command", func() {
+	When("running the approve deletions command", func() {
+		var err error
+		var processGroupIDs []string
+
+		BeforeEach(func() {
+			processGroupIDs = nil
+			cluster.Status.ProcessGroups = []*fdbv1beta2.ProcessGroupStatus{
+				{
+					ProcessGroupID: "test-storage-1",
+					ProcessClass:   fdbv1beta2.ProcessClassStorage,
+					ProcessGroupConditions: []*fdbv1beta2.ProcessGroupCondition{
+						fdbv1beta2.NewProcessGroupCondition(fdbv1beta2.PodDeletionRequired),
+						fdbv1beta2.NewProcessGroupCondition(fdbv1beta2.PVCDeletionRequired),
+					},
+				},
+				{
+					ProcessGroupID: "test-storage-2",
+					ProcessClass:   fdbv1beta2.ProcessClassStorage,
+					ProcessGroupConditions: []*fdbv1beta2.ProcessGroupCondition{
+						fdbv1beta2.NewProcessGroupCondition(fdbv1beta2.PodDeletionRequired),
+					},
+				},
+				{
+					ProcessGroupID: "test-storage-3",
+					ProcessClass:   fdbv1beta2.ProcessClassStorage,
+				},
+			}
+
+			for _, processGroupID := range []string{"test-storage-1", "test-storage-2", "test-storage-3"} {
+				labels := map[string]string{
+					fdbv1beta2.FDBClusterLabel:        clusterName,
+					fdbv1beta2.FDBProcessGroupIDLabel: processGroupID,
+				}
+
+				Expect(k8sClient.Create(context.Background(), &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      processGroupID,
+						Labels:    labels,
+					},
+				})).To(Succeed())
+
+				Expect(k8sClient.Create(context.Background(), &corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      processGroupID + "-data",
+						Labels:    labels,
+					},
+				})).To(Succeed())
+			}
+		})
+
+		JustBeforeEach(func() {
+			cmd := newApproveDeletionsCmd(genericclioptions.IOStreams{})
+			err = approveDeletions(cmd, k8sClient, cluster, processGroupIDs, false)
+		})
+
+		When("no process group IDs are provided", func() {
+			It("should delete the Pods and PVCs that require a deletion", func() {
+				Expect(err).NotTo(HaveOccurred())
+
+				pods := &corev1.PodList{}
+				Expect(k8sClient.List(context.Background(), pods, client.InNamespace(namespace))).To(Succeed())
+				Expect(pods.Items).To(HaveLen(1))
+				Expect(pods.Items[0].Name).To(Equal("test-storage-3"))
+
+				pvcs := &corev1.PersistentVolumeClaimList{}
+				Expect(k8sClient.List(context.Background(), pvcs, client.InNamespace(namespace))).To(Succeed())
+				Expect(pvcs.Items).To(HaveLen(2))
+				Expect([]string{pvcs.Items[0].Name, pvcs.Items[1].Name}).To(ConsistOf("test-storage-2-data", "test-storage-3-data"))
+			})
+		})
+
+		When("a process group ID is provided", func() {
+			BeforeEach(func() {
+				processGroupIDs = []string{"test-storage-2"}
+			})
+
+			It("should only delete the Pod of the provided process group", func() {
+				Expect(err).NotTo(HaveOccurred())
+
+				pods := &corev1.PodList{}
+				Expect(k8sClient.List(context.Background(), pods, client.InNamespace(namespace))).To(Succeed())
+				Expect(pods.Items).To(HaveLen(2))
+				Expect([]string{pods.Items[0].Name, pods.Items[1].Name}).To(ConsistOf("test-storage-1", "test-storage-3"))
+
+				pvcs := &corev1.PersistentVolumeClaimList{}
+				Expect(k8sClient.List(context.Background(), pvcs, client.InNamespace(namespace))).To(Succeed())
+				Expect(pvcs.Items).To(HaveLen(3))
+			})
+		})
+
+		When("the provided process group doesn't require a deletion", func() {
+			BeforeEach(func() {
+				processGroupIDs = []string{"test-storage-3"}
+			})
+
+			It("should not delete any resources", func() {
+				Expect(err).NotTo(HaveOccurred())
+
+				pods := &corev1.PodList{}
+				Expect(k8sClient.List(context.Background(), pods, client.InNamespace(namespace))).To(Succeed())
+				Expect(pods.Items).To(HaveLen(3))
+			})
+		})
+	})
+})
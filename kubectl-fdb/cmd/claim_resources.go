@@ -0,0 +1,177 @@
+/*
+ * claim_resources.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	ctx "context"
+	"errors"
+	"fmt"
+	"log"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newClaimResourcesCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "claim-resources",
+		Short: "Claims the Pods and PVCs of the process groups for the given cluster",
+		Long:  "Adds the foundationdb.org/cluster-claim annotation to the Pods and PVCs of the process groups of the given cluster. Resources that are claimed by a different cluster will be reported and only be claimed if --force is set.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			force, err := cmd.Flags().GetBool("force")
+			if err != nil {
+				return err
+			}
+			clusterName, err := cmd.Flags().GetString("fdb-cluster")
+			if err != nil {
+				return err
+			}
+
+			kubeClient, err := getKubeClient(cmd.Context(), o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			cluster, err := loadCluster(kubeClient, namespace, clusterName)
+			if err != nil {
+				return err
+			}
+
+			return claimResources(cmd, kubeClient, cluster, args, force)
+		},
+		Example: `
+# Claim all Pods and PVCs of a cluster in the current namespace
+kubectl fdb claim-resources -c cluster
+
+# Claim the Pod and PVC of the process group storage-1 for a cluster in the namespace default, even if they are claimed by a different cluster
+kubectl fdb -n default claim-resources -c cluster --force storage-1
+`,
+	}
+
+	cmd.Flags().StringP("fdb-cluster", "c", "", "claim the resources of the provided cluster.")
+	cmd.Flags().Bool("force", false, "claim resources that are claimed by a different cluster.")
+	err := cmd.MarkFlagRequired("fdb-cluster")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// claimResources adds the ClusterClaimAnnotation to the Pods and PVCs of the process groups of the cluster. If process
+// group IDs are provided, only the resources of those process groups will be claimed.
+func claimResources(cmd *cobra.Command, kubeClient client.Client, cluster *fdbv1beta2.FoundationDBCluster, processGroupIDs []string, force bool) error {
+	selected := make(map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None, len(processGroupIDs))
+	for _, processGroupID := range processGroupIDs {
+		selected[fdbv1beta2.ProcessGroupID(processGroupID)] = fdbv1beta2.None{}
+	}
+
+	var claimErr error
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if len(selected) > 0 {
+			if _, ok := selected[processGroup.ProcessGroupID]; !ok {
+				continue
+			}
+		}
+
+		pod := &corev1.Pod{}
+		err := kubeClient.Get(ctx.Background(), client.ObjectKey{Namespace: cluster.Namespace, Name: processGroup.GetPodName(cluster)}, pod)
+		if err == nil {
+			err = claimResource(cmd, kubeClient, cluster, pod, force)
+		}
+
+		if err != nil && !k8serrors.IsNotFound(err) {
+			claimErr = errors.Join(claimErr, fmt.Errorf("could not claim Pod of process group %s: %w", processGroup.ProcessGroupID, err))
+		}
+
+		if !processGroup.ProcessClass.IsStateful() {
+			continue
+		}
+
+		labels := map[string]string{
+			cluster.GetProcessGroupIDLabel(): string(processGroup.ProcessGroupID),
+		}
+		for key, value := range cluster.GetMatchLabels() {
+			labels[key] = value
+		}
+
+		pvcs := &corev1.PersistentVolumeClaimList{}
+		err = kubeClient.List(ctx.Background(), pvcs, client.InNamespace(cluster.Namespace), client.MatchingLabels(labels))
+		if err != nil {
+			claimErr = errors.Join(claimErr, fmt.Errorf("could not list PVCs of process group %s: %w", processGroup.ProcessGroupID, err))
+			continue
+		}
+
+		for idx := range pvcs.Items {
+			err = claimResource(cmd, kubeClient, cluster, &pvcs.Items[idx], force)
+			if err != nil {
+				claimErr = errors.Join(claimErr, fmt.Errorf("could not claim PVC %s of process group %s: %w", pvcs.Items[idx].Name, processGroup.ProcessGroupID, err))
+			}
+		}
+	}
+
+	return claimErr
+}
+
+// claimResource sets the ClusterClaimAnnotation of the object to the name of the cluster. Objects that are claimed by
+// a different cluster will only be updated if force is true.
+func claimResource(cmd *cobra.Command, kubeClient client.Client, cluster *fdbv1beta2.FoundationDBCluster, object client.Object, force bool) error {
+	annotations := object.GetAnnotations()
+	claim, ok := annotations[fdbv1beta2.ClusterClaimAnnotation]
+	if claim == cluster.Name {
+		return nil
+	}
+
+	if ok && !force {
+		return fmt.Errorf("%s is claimed by cluster %s", object.GetName(), claim)
+	}
+
+	if annotations == nil {
+		annotations = make(map[string]string, 1)
+	}
+	annotations[fdbv1beta2.ClusterClaimAnnotation] = cluster.Name
+	object.SetAnnotations(annotations)
+
+	err := kubeClient.Update(ctx.Background(), object)
+	if err != nil {
+		return err
+	}
+
+	cmd.Printf("Claimed %s for cluster %s/%s\n", object.GetName(), cluster.Namespace, cluster.Name)
+
+	return nil
+}
@@ -0,0 +1,125 @@
+/*
+ * claim_resources_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("[plugin] claim resources command", func() {
+	When("running the claim resources command", func() {
+		var err error
+		var force bool
+
+		BeforeEach(func() {
+			force = false
+			cluster.Status.ProcessGroups = []*fdbv1beta2.ProcessGroupStatus{
+				{
+					ProcessGroupID: "test-storage-1",
+					ProcessClass:   fdbv1beta2.ProcessClassStorage,
+				},
+				{
+					ProcessGroupID: "test-storage-2",
+					ProcessClass:   fdbv1beta2.ProcessClassStorage,
+				},
+			}
+
+			for _, processGroupID := range []string{"test-storage-1", "test-storage-2"} {
+				labels := map[string]string{
+					fdbv1beta2.FDBClusterLabel:        clusterName,
+					fdbv1beta2.FDBProcessGroupIDLabel: processGroupID,
+				}
+
+				var annotations map[string]string
+				if processGroupID == "test-storage-2" {
+					annotations = map[string]string{
+						fdbv1beta2.ClusterClaimAnnotation: "other",
+					}
+				}
+
+				Expect(k8sClient.Create(context.Background(), &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   namespace,
+						Name:        processGroupID,
+						Labels:      labels,
+						Annotations: annotations,
+					},
+				})).To(Succeed())
+
+				Expect(k8sClient.Create(context.Background(), &corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      processGroupID + "-data",
+						Labels:    labels,
+					},
+				})).To(Succeed())
+			}
+		})
+
+		JustBeforeEach(func() {
+			cmd := newClaimResourcesCmd(genericclioptions.IOStreams{})
+			err = claimResources(cmd, k8sClient, cluster, nil, force)
+		})
+
+		When("a Pod is claimed by a different cluster", func() {
+			It("should claim the unclaimed resources and report the conflict", func() {
+				Expect(err).To(HaveOccurred())
+
+				pod := &corev1.Pod{}
+				Expect(k8sClient.Get(context.Background(), client.ObjectKey{Namespace: namespace, Name: "test-storage-1"}, pod)).To(Succeed())
+				Expect(pod.Annotations).To(HaveKeyWithValue(fdbv1beta2.ClusterClaimAnnotation, clusterName))
+
+				Expect(k8sClient.Get(context.Background(), client.ObjectKey{Namespace: namespace, Name: "test-storage-2"}, pod)).To(Succeed())
+				Expect(pod.Annotations).To(HaveKeyWithValue(fdbv1beta2.ClusterClaimAnnotation, "other"))
+
+				pvcs := &corev1.PersistentVolumeClaimList{}
+				Expect(k8sClient.List(context.Background(), pvcs, client.InNamespace(namespace))).To(Succeed())
+				for _, pvc := range pvcs.Items {
+					Expect(pvc.Annotations).To(HaveKeyWithValue(fdbv1beta2.ClusterClaimAnnotation, clusterName))
+				}
+			})
+		})
+
+		When("the claim is forced", func() {
+			BeforeEach(func() {
+				force = true
+			})
+
+			It("should claim all resources", func() {
+				Expect(err).NotTo(HaveOccurred())
+
+				pods := &corev1.PodList{}
+				Expect(k8sClient.List(context.Background(), pods, client.InNamespace(namespace))).To(Succeed())
+				Expect(pods.Items).To(HaveLen(2))
+				for _, pod := range pods.Items {
+					Expect(pod.Annotations).To(HaveKeyWithValue(fdbv1beta2.ClusterClaimAnnotation, clusterName))
+				}
+			})
+		})
+	})
+})
@@ -0,0 +1,129 @@
+/*
+ * compact_process_group_ids.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	ctx "context"
+	"fmt"
+	"log"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newCompactProcessGroupIDsCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "compact-process-group-ids",
+		Short: "Replaces process groups to close the gaps in the process group ID numbers of the given cluster",
+		Long:  "Replaces process groups to close the gaps in the process group ID numbers of the given cluster. The process groups will be removed with exclusion and the operator will create new process groups with the lowest unused ID numbers.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			wait, err := cmd.Root().Flags().GetBool("wait")
+			if err != nil {
+				return err
+			}
+			clusterName, err := cmd.Flags().GetString("fdb-cluster")
+			if err != nil {
+				return err
+			}
+			maxReplacements, err := cmd.Flags().GetInt("max-replacements")
+			if err != nil {
+				return err
+			}
+
+			kubeClient, err := getKubeClient(cmd.Context(), o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			cluster, err := loadCluster(kubeClient, namespace, clusterName)
+			if err != nil {
+				return err
+			}
+
+			return compactProcessGroupIDs(cmd, kubeClient, cluster, maxReplacements, wait)
+		},
+		Example: `
+# Replace all process groups that are outside of the compact ID range for a cluster in the current namespace
+kubectl fdb compact-process-group-ids -c cluster
+
+# Replace at most 2 process groups that are outside of the compact ID range for a cluster in the namespace default
+kubectl fdb -n default compact-process-group-ids -c cluster --max-replacements 2
+`,
+	}
+
+	cmd.Flags().StringP("fdb-cluster", "c", "", "compact the process group IDs of the provided cluster.")
+	cmd.Flags().Int("max-replacements", 0, "defines how many process groups will be replaced at most, 0 means that all process groups will be replaced.")
+	err := cmd.MarkFlagRequired("fdb-cluster")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// compactProcessGroupIDs adds the process groups that are outside the compact ID range to the removal list of the
+// cluster. The operator will exclude those process groups and create new process groups with the lowest unused ID numbers.
+func compactProcessGroupIDs(cmd *cobra.Command, kubeClient client.Client, cluster *fdbv1beta2.FoundationDBCluster, maxReplacements int, wait bool) error {
+	if cluster.GetProcessGroupIDAllocationStrategy() != fdbv1beta2.ProcessGroupIDAllocationDense {
+		return fmt.Errorf("cluster %s/%s must use the Dense process group ID allocation strategy, otherwise new process groups will not get the lowest unused IDs", cluster.Namespace, cluster.Name)
+	}
+
+	processGroupIDs := cluster.GetProcessGroupsForIDCompaction()
+	if len(processGroupIDs) == 0 {
+		cmd.Printf("Cluster %s/%s has no gaps in the process group IDs\n", cluster.Namespace, cluster.Name)
+		return nil
+	}
+
+	if maxReplacements > 0 && len(processGroupIDs) > maxReplacements {
+		processGroupIDs = processGroupIDs[:maxReplacements]
+	}
+
+	if wait {
+		if !confirmAction(fmt.Sprintf("Replace %v in cluster %s/%s to compact the process group IDs", processGroupIDs, cluster.Namespace, cluster.Name)) {
+			return fmt.Errorf("user aborted the removal")
+		}
+	}
+
+	patch := client.MergeFrom(cluster.DeepCopy())
+	cluster.Spec.ProcessGroupsToRemove = cluster.GetProcessGroupsToRemove(processGroupIDs)
+	err := kubeClient.Patch(ctx.TODO(), cluster, patch)
+	if err != nil {
+		return err
+	}
+
+	cmd.Printf("Cluster %s/%s: replacing %v to compact the process group IDs\n", cluster.Namespace, cluster.Name, processGroupIDs)
+	return nil
+}
@@ -0,0 +1,107 @@
+/*
+ * compact_process_group_ids_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("[plugin] compact process group IDs command", func() {
+	When("running the compact process group IDs command", func() {
+		var err error
+		var maxReplacements int
+
+		BeforeEach(func() {
+			maxReplacements = 0
+			cluster.Spec.ProcessCounts.Stateless = 1
+			cluster.Status.ProcessGroups = []*fdbv1beta2.ProcessGroupStatus{
+				{
+					ProcessGroupID: "test-storage-1",
+					ProcessClass:   fdbv1beta2.ProcessClassStorage,
+				},
+				{
+					ProcessGroupID: "test-storage-5",
+					ProcessClass:   fdbv1beta2.ProcessClassStorage,
+				},
+				{
+					ProcessGroupID: "test-storage-8",
+					ProcessClass:   fdbv1beta2.ProcessClassStorage,
+				},
+				{
+					ProcessGroupID: "test-stateless-1",
+					ProcessClass:   fdbv1beta2.ProcessClassStateless,
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			cmd := newCompactProcessGroupIDsCmd(genericclioptions.IOStreams{})
+			err = compactProcessGroupIDs(cmd, k8sClient, cluster, maxReplacements, false)
+		})
+
+		When("compact process group IDs are disabled", func() {
+			It("should return an error", func() {
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		When("compact process group IDs are enabled", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.UseCompactProcessGroupIDs = pointer.Bool(true)
+			})
+
+			It("should add the process groups outside of the compact range to the removal list", func() {
+				Expect(err).NotTo(HaveOccurred())
+
+				var resCluster fdbv1beta2.FoundationDBCluster
+				Expect(k8sClient.Get(context.Background(), client.ObjectKey{
+					Namespace: namespace,
+					Name:      clusterName,
+				}, &resCluster)).NotTo(HaveOccurred())
+				Expect(resCluster.Spec.ProcessGroupsToRemove).To(ConsistOf(fdbv1beta2.ProcessGroupID("test-storage-5"), fdbv1beta2.ProcessGroupID("test-storage-8")))
+			})
+
+			When("the number of replacements is limited", func() {
+				BeforeEach(func() {
+					maxReplacements = 1
+				})
+
+				It("should only add one process group to the removal list", func() {
+					Expect(err).NotTo(HaveOccurred())
+
+					var resCluster fdbv1beta2.FoundationDBCluster
+					Expect(k8sClient.Get(context.Background(), client.ObjectKey{
+						Namespace: namespace,
+						Name:      clusterName,
+					}, &resCluster)).NotTo(HaveOccurred())
+					Expect(resCluster.Spec.ProcessGroupsToRemove).To(HaveLen(1))
+				})
+			})
+		})
+	})
+})
@@ -32,6 +32,7 @@ import (
 	fdbv1beta1 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta1"
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal/clustertemplate"
 
 	"github.com/go-logr/logr"
 	"github.com/spf13/cobra"
@@ -158,6 +159,10 @@ func loadCluster(kubeClient client.Client, namespace string, clusterName string)
 	if err != nil {
 		return nil, err
 	}
+	err = clustertemplate.Resolve(context.Background(), kubeClient, cluster)
+	if err != nil {
+		return nil, err
+	}
 	err = internal.NormalizeClusterSpec(cluster, internal.DeprecationOptions{})
 	if err != nil {
 		return nil, err
@@ -283,6 +288,7 @@ func getAllPodsFromClusterWithCondition(stdErr io.Writer, kubeClient client.Clie
 // getProcessGroupIDsFromPodName returns the process group IDs based on the cluster configuration.
 func getProcessGroupIDsFromPodName(cluster *fdbv1beta2.FoundationDBCluster, podNames []string) ([]fdbv1beta2.ProcessGroupID, error) {
 	processGroupIDs := make([]fdbv1beta2.ProcessGroupID, 0, len(podNames))
+	customPodNames := internal.CreateCustomPodNameMap(cluster)
 
 	// TODO(johscheuer): We could validate if the provided process group is actually part of the cluster
 	for _, podName := range podNames {
@@ -294,7 +300,7 @@ func getProcessGroupIDsFromPodName(cluster *fdbv1beta2.FoundationDBCluster, podN
 			return nil, fmt.Errorf("cluster name %s is not set as prefix for Pod name %s, please ensure the specified Pod is part of the cluster", cluster.Name, podName)
 		}
 
-		processGroupIDs = append(processGroupIDs, internal.GetProcessGroupIDFromPodName(cluster, podName))
+		processGroupIDs = append(processGroupIDs, internal.GetProcessGroupIDFromPodName(cluster, customPodNames, podName))
 	}
 
 	return processGroupIDs, nil
@@ -390,8 +396,9 @@ func fetchProcessGroupsCrossCluster(kubeClient client.Client, namespace string,
 			}
 			return nil, err
 		}
+		customPodNames := internal.CreateCustomPodNameMap(cluster)
 		for _, podName := range pods {
-			processGroupsByCluster[cluster] = append(processGroupsByCluster[cluster], internal.GetProcessGroupIDFromPodName(cluster, podName))
+			processGroupsByCluster[cluster] = append(processGroupsByCluster[cluster], internal.GetProcessGroupIDFromPodName(cluster, customPodNames, podName))
 		}
 	}
 	return processGroupsByCluster, nil
@@ -0,0 +1,186 @@
+/*
+ * recover_coordinator_quorum.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	ctx "context"
+	"fmt"
+	"log"
+	"os/exec"
+	"strings"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newRecoverCoordinatorQuorumCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "recover-coordinator-quorum",
+		Short: "Guides through the recovery of a cluster that permanently lost the quorum of its coordinators",
+		Long:  "Guides through the recovery of a cluster that permanently lost the quorum of its coordinators. The first run confirms the recovery in the cluster spec, which instructs the operator to re-seed the coordinators from the reachable coordinators. Once the operator has re-seeded the coordinators, the second run updates the cluster file in all Pods and restarts the fdbserver processes. The recovery can lose recently committed data.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			wait, err := cmd.Root().Flags().GetBool("wait")
+			if err != nil {
+				return err
+			}
+
+			clusterName, err := cmd.Flags().GetString("fdb-cluster")
+			if err != nil {
+				return err
+			}
+
+			dryRun, err := cmd.Flags().GetBool("dry-run")
+			if err != nil {
+				return err
+			}
+
+			kubeClient, err := getKubeClient(cmd.Context(), o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			cluster, err := loadCluster(kubeClient, namespace, clusterName)
+			if err != nil {
+				return err
+			}
+
+			return recoverCoordinatorQuorum(cmd, kubeClient, cluster, *o.configFlags.Context, namespace, wait, dryRun)
+		},
+		Example: `
+# Confirm the recovery of the coordinators and, once the operator re-seeded the coordinators, restart the processes
+kubectl fdb recover-coordinator-quorum -c cluster
+
+# Print the actions without performing them
+kubectl fdb recover-coordinator-quorum -c cluster --dry-run
+`,
+	}
+
+	cmd.Flags().StringP("fdb-cluster", "c", "", "recover the coordinators of the provided cluster.")
+	err := cmd.MarkFlagRequired("fdb-cluster")
+	if err != nil {
+		log.Fatal(err)
+	}
+	cmd.Flags().Bool("dry-run", false, "Print the actions without performing them")
+
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// recoverCoordinatorQuorum performs the next step of the coordinator recovery for the provided cluster. If the
+// recovery is not yet confirmed, the confirmations are added to the cluster spec. If the operator has re-seeded the
+// coordinators, the cluster file in all Pods is updated and the fdbserver processes are restarted.
+func recoverCoordinatorQuorum(cmd *cobra.Command, kubeClient client.Client, cluster *fdbv1beta2.FoundationDBCluster, context string, namespace string, wait bool, dryRun bool) error {
+	quorumLoss := cluster.Status.CoordinatorQuorumLoss
+	if quorumLoss == nil {
+		cmd.Printf("Cluster %s/%s has not lost the quorum of its coordinators\n", cluster.Namespace, cluster.Name)
+		return nil
+	}
+
+	cmd.Printf("Cluster %s/%s lost the quorum of the coordinators of %s at %s\n", cluster.Namespace, cluster.Name, quorumLoss.ConnectionString, quorumLoss.DetectionTimestamp.String())
+	cmd.Printf("Reachable coordinators: %v\n", quorumLoss.ReachableCoordinators)
+	cmd.Printf("Unreachable coordinators: %v\n", quorumLoss.UnreachableCoordinators)
+
+	if quorumLoss.RecoveryConnectionString == "" {
+		if cluster.ForceNewCoordinatorsConfirmed(quorumLoss.ConnectionString) {
+			cmd.Printf("The recovery is confirmed, waiting for the operator to re-seed the coordinators\n")
+			return nil
+		}
+
+		if len(quorumLoss.ReachableCoordinators) == 0 {
+			return fmt.Errorf("none of the coordinators is reachable, the coordinators cannot be re-seeded")
+		}
+
+		if dryRun {
+			cmd.Printf("Would confirm the recovery of the coordinators in the spec of cluster %s/%s\n", cluster.Namespace, cluster.Name)
+			return nil
+		}
+
+		if wait {
+			if !confirmAction(fmt.Sprintf("Re-seed the coordinators of cluster %s/%s with %v, this can lose recently committed data", cluster.Namespace, cluster.Name, quorumLoss.ReachableCoordinators)) {
+				return fmt.Errorf("user aborted the recovery")
+			}
+		}
+
+		patch := client.MergeFrom(cluster.DeepCopy())
+		cluster.Spec.Recovery.ForceNewCoordinators = &fdbv1beta2.ForceNewCoordinatorsOptions{
+			ConfirmConnectionString: quorumLoss.ConnectionString,
+			AcceptDataLoss:          true,
+		}
+
+		err := kubeClient.Patch(ctx.Background(), cluster, patch)
+		if err != nil {
+			return err
+		}
+
+		cmd.Printf("Confirmed the recovery, run this command again once the operator has re-seeded the coordinators\n")
+		return nil
+	}
+
+	if cluster.Status.ConnectionString != quorumLoss.RecoveryConnectionString {
+		return fmt.Errorf("the connection string %s differs from the re-seeded connection string %s", cluster.Status.ConnectionString, quorumLoss.RecoveryConnectionString)
+	}
+
+	cmd.Printf("The operator re-seeded the coordinators: %s\n", quorumLoss.RecoveryConnectionString)
+
+	if !dryRun && wait {
+		if !confirmAction(fmt.Sprintf("Update the cluster file and restart the fdbserver processes of cluster %s/%s", cluster.Namespace, cluster.Name)) {
+			return fmt.Errorf("user aborted the recovery")
+		}
+	}
+
+	kubectlPath, err := exec.LookPath("kubectl")
+	if err != nil {
+		return err
+	}
+
+	commands, err := buildClusterFileUpdateCommands(cluster, kubeClient, context, namespace, kubectlPath)
+	if err != nil {
+		return err
+	}
+
+	for _, command := range commands {
+		if dryRun {
+			log.Printf("Update command: %s", strings.Join(command.Args, " "))
+			continue
+		}
+
+		err = command.Run()
+		if err != nil {
+			log.Print(err.Error())
+		}
+	}
+
+	return nil
+}
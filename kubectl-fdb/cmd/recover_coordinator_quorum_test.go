@@ -0,0 +1,116 @@
+/*
+ * recover_coordinator_quorum_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("[plugin] recover coordinator quorum command", func() {
+	When("running the recover coordinator quorum command", func() {
+		var err error
+		var dryRun bool
+
+		connectionString := "test:abcd@127.0.0.1:4501,127.0.0.2:4501,127.0.0.3:4501"
+
+		BeforeEach(func() {
+			dryRun = false
+			cluster.Status.ConnectionString = connectionString
+		})
+
+		JustBeforeEach(func() {
+			cmd := newRecoverCoordinatorQuorumCmd(genericclioptions.IOStreams{})
+			err = recoverCoordinatorQuorum(cmd, k8sClient, cluster, "", namespace, false, dryRun)
+		})
+
+		When("the cluster has not lost the quorum", func() {
+			It("should not confirm a recovery", func() {
+				Expect(err).NotTo(HaveOccurred())
+
+				updated := &fdbv1beta2.FoundationDBCluster{}
+				Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cluster), updated)).To(Succeed())
+				Expect(updated.Spec.Recovery.ForceNewCoordinators).To(BeNil())
+			})
+		})
+
+		When("the cluster has lost the quorum", func() {
+			BeforeEach(func() {
+				cluster.Status.CoordinatorQuorumLoss = &fdbv1beta2.CoordinatorQuorumLossStatus{
+					ConnectionString:        connectionString,
+					ReachableCoordinators:   []string{"127.0.0.1:4501"},
+					UnreachableCoordinators: []string{"127.0.0.2:4501", "127.0.0.3:4501"},
+				}
+			})
+
+			It("should confirm the recovery in the cluster spec", func() {
+				Expect(err).NotTo(HaveOccurred())
+
+				updated := &fdbv1beta2.FoundationDBCluster{}
+				Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cluster), updated)).To(Succeed())
+				Expect(updated.Spec.Recovery.ForceNewCoordinators).To(Equal(&fdbv1beta2.ForceNewCoordinatorsOptions{
+					ConfirmConnectionString: connectionString,
+					AcceptDataLoss:          true,
+				}))
+				Expect(updated.ForceNewCoordinatorsConfirmed(connectionString)).To(BeTrue())
+			})
+
+			When("the dry-run flag is set", func() {
+				BeforeEach(func() {
+					dryRun = true
+				})
+
+				It("should not confirm the recovery", func() {
+					Expect(err).NotTo(HaveOccurred())
+
+					updated := &fdbv1beta2.FoundationDBCluster{}
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cluster), updated)).To(Succeed())
+					Expect(updated.Spec.Recovery.ForceNewCoordinators).To(BeNil())
+				})
+			})
+
+			When("none of the coordinators is reachable", func() {
+				BeforeEach(func() {
+					cluster.Status.CoordinatorQuorumLoss.ReachableCoordinators = nil
+				})
+
+				It("should return an error", func() {
+					Expect(err).To(MatchError("none of the coordinators is reachable, the coordinators cannot be re-seeded"))
+				})
+			})
+
+			When("the connection string differs from the re-seeded connection string", func() {
+				BeforeEach(func() {
+					cluster.Status.CoordinatorQuorumLoss.RecoveryConnectionString = "test:abcd@127.0.0.1:4501"
+				})
+
+				It("should return an error", func() {
+					Expect(err).To(MatchError("the connection string test:abcd@127.0.0.1:4501,127.0.0.2:4501,127.0.0.3:4501 differs from the re-seeded connection string test:abcd@127.0.0.1:4501"))
+				})
+			})
+		})
+	})
+})
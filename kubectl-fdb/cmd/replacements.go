@@ -0,0 +1,176 @@
+/*
+ * replacements.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal/replacements"
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+func newReplacementsCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "replacements",
+		Short: "Get the process groups that would be replaced by the operator because of a changed spec.",
+		Long:  "Get the process groups that would be replaced by the operator because of a changed spec.",
+		Args:  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clusterSpecFile, err := cmd.Flags().GetString("cluster-spec")
+			if err != nil {
+				return err
+			}
+
+			replaceOnSecurityContextChange, err := cmd.Flags().GetBool("replace-on-security-context-change")
+			if err != nil {
+				return err
+			}
+
+			kubeClient, err := getKubeClient(cmd.Context(), o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			var renderedCluster *fdbv1beta2.FoundationDBCluster
+			if clusterSpecFile != "" {
+				renderedCluster, err = loadClusterFromFile(clusterSpecFile)
+				if err != nil {
+					return err
+				}
+			}
+
+			return printReplacements(cmd, kubeClient, args[0], namespace, renderedCluster, replaceOnSecurityContextChange)
+		},
+		Example: `
+This command doesn't take the replacement limits of the operator into account, e.g. the maximum number of concurrent
+replacements, and doesn't show replacements of failed process groups.
+
+# Get the process groups of cluster c1 that would be replaced
+kubectl fdb get replacements c1
+
+# Get the process groups of cluster c1 that would be replaced after applying the spec from rendered-cluster.yaml
+kubectl fdb get replacements c1 --cluster-spec rendered-cluster.yaml
+`,
+	}
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+
+	cmd.Flags().String("cluster-spec", "", "defines a file with the FoundationDBCluster resource that should be used instead of the spec of the running cluster.")
+	cmd.Flags().Bool("replace-on-security-context-change", false, "defines if a change of the security context should be considered as a reason for a replacement, this should match the setting of the operator.")
+
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// loadClusterFromFile reads the FoundationDBCluster resource from the provided file.
+func loadClusterFromFile(fileName string) (*fdbv1beta2.FoundationDBCluster, error) {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster := &fdbv1beta2.FoundationDBCluster{}
+	err = yaml.Unmarshal(content, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	return cluster, nil
+}
+
+// printReplacements prints all process groups that would be replaced. If a rendered cluster is provided, the spec
+// of the rendered cluster will be used instead of the spec of the running cluster.
+func printReplacements(cmd *cobra.Command, kubeClient client.Client, clusterName string, namespace string, renderedCluster *fdbv1beta2.FoundationDBCluster, replaceOnSecurityContextChange bool) error {
+	cluster, err := loadCluster(kubeClient, namespace, clusterName)
+	if err != nil {
+		return err
+	}
+
+	if renderedCluster != nil {
+		if renderedCluster.Name != "" && renderedCluster.Name != cluster.Name {
+			return fmt.Errorf("cluster spec is for cluster %s, but cluster %s was requested", renderedCluster.Name, cluster.Name)
+		}
+
+		cluster.Spec = renderedCluster.Spec
+	}
+
+	pods, err := getPodsForCluster(kubeClient, cluster)
+	if err != nil {
+		return err
+	}
+
+	pvcs := &corev1.PersistentVolumeClaimList{}
+	err = kubeClient.List(context.Background(), pvcs, client.MatchingLabels(cluster.GetMatchLabels()), client.InNamespace(cluster.Namespace))
+	if err != nil {
+		return err
+	}
+
+	storageClasses := &storagev1.StorageClassList{}
+	err = kubeClient.List(context.Background(), storageClasses)
+	if err != nil {
+		return err
+	}
+
+	removalReasons, err := replacements.SimulateReplacements(logr.Discard(), cluster, pods.Items, pvcs.Items, storageClasses.Items, replaceOnSecurityContextChange)
+	if err != nil {
+		return err
+	}
+
+	if len(removalReasons) == 0 {
+		cmd.Printf("No process groups of cluster %s/%s would be replaced\n", cluster.Namespace, cluster.Name)
+		return nil
+	}
+
+	processGroupIDs := make([]fdbv1beta2.ProcessGroupID, 0, len(removalReasons))
+	for processGroupID := range removalReasons {
+		processGroupIDs = append(processGroupIDs, processGroupID)
+	}
+	sort.Slice(processGroupIDs, func(i, j int) bool {
+		return processGroupIDs[i] < processGroupIDs[j]
+	})
+
+	cmd.Printf("%d process groups of cluster %s/%s would be replaced:\n", len(removalReasons), cluster.Namespace, cluster.Name)
+	for _, processGroupID := range processGroupIDs {
+		reason := removalReasons[processGroupID]
+		cmd.Printf("%s\t%s\t%s\n", processGroupID, reason.Type, reason.Message)
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+/*
+ * replacements_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("[plugin] replacements command", func() {
+	When("getting the replacements of a cluster", func() {
+		var renderedCluster *fdbv1beta2.FoundationDBCluster
+		var outBuffer bytes.Buffer
+
+		BeforeEach(func() {
+			renderedCluster = nil
+			outBuffer = bytes.Buffer{}
+			cluster.Spec.Version = fdbv1beta2.Versions.Default.String()
+			Expect(internal.NormalizeClusterSpec(cluster, internal.DeprecationOptions{})).NotTo(HaveOccurred())
+			cluster.Spec.LabelConfig.FilterOnOwnerReferences = pointer.Bool(false)
+
+			for _, processGroup := range cluster.Status.ProcessGroups {
+				pod, err := internal.GetPod(cluster, processGroup)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(k8sClient.Create(context.TODO(), pod)).NotTo(HaveOccurred())
+
+				pvc, err := internal.GetPvc(cluster, processGroup)
+				Expect(err).NotTo(HaveOccurred())
+				if pvc == nil {
+					continue
+				}
+
+				Expect(k8sClient.Create(context.TODO(), pvc)).NotTo(HaveOccurred())
+			}
+		})
+
+		JustBeforeEach(func() {
+			cmd := newReplacementsCmd(genericclioptions.IOStreams{Out: &outBuffer, ErrOut: &outBuffer})
+			Expect(printReplacements(cmd, k8sClient, clusterName, namespace, renderedCluster, false)).To(Succeed())
+		})
+
+		When("the spec of the cluster is not changed", func() {
+			It("should not report any replacements", func() {
+				Expect(outBuffer.String()).To(Equal("No process groups of cluster test/test would be replaced\n"))
+			})
+		})
+
+		When("a rendered cluster spec with a changed node selector is provided", func() {
+			BeforeEach(func() {
+				renderedCluster = cluster.DeepCopy()
+				renderedCluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral].PodTemplate.Spec.NodeSelector = map[string]string{
+					"dummy": "test",
+				}
+			})
+
+			It("should report all process groups", func() {
+				Expect(outBuffer.String()).To(HavePrefix("3 process groups of cluster test/test would be replaced:\n"))
+				Expect(outBuffer.String()).To(ContainSubstring("test-storage-1\tNodeSelectorChanged"))
+				Expect(outBuffer.String()).To(ContainSubstring("test-stateless-3\tNodeSelectorChanged"))
+			})
+		})
+	})
+})
@@ -94,8 +94,12 @@ func NewRootCmd(streams genericclioptions.IOStreams, pluginVersionChecker Versio
 		newAnalyzeCmd(streams),
 		newDeprecationCmd(streams),
 		newFixCoordinatorIPsCmd(streams),
+		newRecoverCoordinatorQuorumCmd(streams),
 		newGetCmd(streams),
 		newBuggifyCmd(streams),
+		newCompactProcessGroupIDsCmd(streams),
+		newApproveDeletionsCmd(streams),
+		newClaimResourcesCmd(streams),
 	)
 
 	return cmd
@@ -61,6 +61,19 @@ type MockClient struct {
 
 	// createIndexes defines if the MockClient should create a predefined set of Indexer.
 	createIndexes bool
+
+	// indexes defines additional Indexer that will be added to the fake client.
+	indexes []Index
+}
+
+// Index defines a field index that will be added to the fake client of the MockClient.
+type Index struct {
+	// Object is the object type that will be indexed.
+	Object ctrlClient.Object
+	// Field is the name of the index.
+	Field string
+	// ExtractValue returns the indexed values of an object.
+	ExtractValue ctrlClient.IndexerFunc
 }
 
 // NewMockClient creates a new MockClient.
@@ -73,9 +86,21 @@ func NewMockClientWithHooks(scheme *runtime.Scheme, createHooks []func(ctx conte
 	return NewMockClientWithHooksAndIndexes(scheme, createHooks, updateHooks, false)
 }
 
+// NewMockClientWithIndexes creates a new MockClient with the provided field indexes.
+func NewMockClientWithIndexes(scheme *runtime.Scheme, indexes ...Index) *MockClient {
+	return newMockClient(scheme, nil, nil, false, indexes)
+}
+
 // NewMockClientWithHooksAndIndexes creates a new MockClient with hooks and indexes.
 func NewMockClientWithHooksAndIndexes(scheme *runtime.Scheme, createHooks []func(ctx context.Context, client *MockClient, object ctrlClient.Object) error,
 	updateHooks []func(ctx context.Context, client *MockClient, object ctrlClient.Object) error, createIndexes bool) *MockClient {
+	return newMockClient(scheme, createHooks, updateHooks, createIndexes, nil)
+}
+
+// newMockClient creates a new MockClient with hooks, the predefined set of indexes if createIndexes is true and the
+// provided additional indexes.
+func newMockClient(scheme *runtime.Scheme, createHooks []func(ctx context.Context, client *MockClient, object ctrlClient.Object) error,
+	updateHooks []func(ctx context.Context, client *MockClient, object ctrlClient.Object) error, createIndexes bool, indexes []Index) *MockClient {
 	serviceCreateHook := func(_ context.Context, client *MockClient, object ctrlClient.Object) error {
 		svc, isSvc := object.(*corev1.Service)
 		if !isSvc {
@@ -123,6 +148,7 @@ func NewMockClientWithHooksAndIndexes(scheme *runtime.Scheme, createHooks []func
 		createHooks:   append(createHooks, serviceCreateHook, podCreateHook),
 		updateHooks:   updateHooks,
 		createIndexes: createIndexes,
+		indexes:       indexes,
 	}
 
 	mockClient.setNewFakeClient()
@@ -143,6 +169,10 @@ func (client *MockClient) setNewFakeClient() {
 		})
 	}
 
+	for _, index := range client.indexes {
+		builder = builder.WithIndex(index.Object, index.Field, index.ExtractValue)
+	}
+
 	builder.WithScheme(client.scheme)
 	client.fakeClient = builder.Build()
 }
@@ -104,6 +104,11 @@ type AdminClient interface {
 	// SetKnobs sets the Knobs that should be used for the commandline call.
 	SetKnobs([]string)
 
+	// ReloadKnobs updates the provided knobs in the configuration database, so that the running fdbserver processes
+	// reload them without a restart. The keys are the knob names as used in the custom parameters, e.g.
+	// "knob_min_trace_severity".
+	ReloadKnobs(knobs map[string]string) error
+
 	// GetMaintenanceZone gets current maintenance zone, if any.
 	GetMaintenanceZone() (string, error)
 
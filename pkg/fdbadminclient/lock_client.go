@@ -21,6 +21,8 @@
 package fdbadminclient
 
 import (
+	"time"
+
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 )
 
@@ -53,4 +55,13 @@ type LockClient interface {
 
 	// UpdateDenyList updates the deny list to match a list of entries.
 	UpdateDenyList(locks []fdbv1beta2.LockDenyListEntry) error
+
+	// UpdateReplacements refreshes the leases of the provided in-flight replacements of the current operator instance
+	// in the global replacement budget and removes all other replacements of the current operator instance.
+	UpdateReplacements(processGroupIDs []fdbv1beta2.ProcessGroupID, leaseDuration time.Duration) error
+
+	// ReserveReplacement registers the replacement of the process group in the global replacement budget, if less
+	// than maxReplacements replacements of all operator instances are in flight. Replacements with an expired lease
+	// are not counted. The returned bool will be true if the replacement was registered.
+	ReserveReplacement(processGroupID fdbv1beta2.ProcessGroupID, maxReplacements int, leaseDuration time.Duration) (bool, error)
 }
@@ -48,6 +48,7 @@ type AdminClient struct {
 	ExcludedAddresses                        map[string]fdbv1beta2.None
 	KilledAddresses                          map[string]fdbv1beta2.None
 	Knobs                                    map[string]fdbv1beta2.None
+	ReloadedKnobs                            map[string]string
 	missingLocalities                        map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None
 	missingProcessGroups                     map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None
 	incorrectCommandLines                    map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None
@@ -103,6 +104,7 @@ func NewMockAdminClientUncast(cluster *fdbv1beta2.FoundationDBCluster, kubeClien
 			localityInfo:              make(map[fdbv1beta2.ProcessGroupID]map[string]string),
 			currentCommandLines:       make(map[string]string),
 			Knobs:                     make(map[string]fdbv1beta2.None),
+			ReloadedKnobs:             make(map[string]string),
 			VersionProcessGroups:      make(map[fdbv1beta2.ProcessGroupID]string),
 			LagInfo:                   make(map[string]fdbv1beta2.FoundationDBStatusLagInfo),
 			processesUnderMaintenance: make(map[fdbv1beta2.ProcessGroupID]int64),
@@ -967,6 +969,22 @@ func (client *AdminClient) SetKnobs(knobs []string) {
 	}
 }
 
+// ReloadKnobs updates the provided knobs in the mocked configuration database.
+func (client *AdminClient) ReloadKnobs(knobs map[string]string) error {
+	adminClientMutex.Lock()
+	defer adminClientMutex.Unlock()
+
+	if client.mockError != nil {
+		return client.mockError
+	}
+
+	for knob, value := range knobs {
+		client.ReloadedKnobs[knob] = value
+	}
+
+	return nil
+}
+
 // GetMaintenanceZone gets current maintenance zone, if any
 func (client *AdminClient) GetMaintenanceZone() (string, error) {
 	if client.mockError != nil {
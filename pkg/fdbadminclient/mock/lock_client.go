@@ -23,6 +23,7 @@ package mock
 import (
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient"
 
@@ -113,6 +114,94 @@ func (client *LockClient) ReleaseLock() error {
 	return nil
 }
 
+// UpdateReplacements refreshes the leases of the provided in-flight replacements of the current operator instance
+// in the global replacement budget and removes all other replacements of the current operator instance.
+func (client *LockClient) UpdateReplacements(processGroupIDs []fdbv1beta2.ProcessGroupID, leaseDuration time.Duration) error {
+	lockClientMutex.Lock()
+	defer lockClientMutex.Unlock()
+
+	budget := getReplacementBudget(client.cluster)
+	for processGroupID, replacement := range budget {
+		if replacement.owner == client.cluster.GetLockID() {
+			delete(budget, processGroupID)
+		}
+	}
+
+	expiry := time.Now().Add(leaseDuration)
+	for _, processGroupID := range processGroupIDs {
+		budget[processGroupID] = mockReplacement{owner: client.cluster.GetLockID(), expiry: expiry}
+	}
+
+	return nil
+}
+
+// ReserveReplacement registers the replacement of the process group in the global replacement budget, if less
+// than maxReplacements replacements of all operator instances are in flight. Replacements with an expired lease
+// are not counted. The returned bool will be true if the replacement was registered.
+func (client *LockClient) ReserveReplacement(processGroupID fdbv1beta2.ProcessGroupID, maxReplacements int, leaseDuration time.Duration) (bool, error) {
+	lockClientMutex.Lock()
+	defer lockClientMutex.Unlock()
+
+	now := time.Now()
+	budget := getReplacementBudget(client.cluster)
+	inFlight := 0
+	for currentID, replacement := range budget {
+		if currentID != processGroupID && !replacement.expiry.Before(now) {
+			inFlight++
+		}
+	}
+
+	if inFlight >= maxReplacements {
+		return false, nil
+	}
+
+	budget[processGroupID] = mockReplacement{owner: client.cluster.GetLockID(), expiry: now.Add(leaseDuration)}
+
+	return true, nil
+}
+
+// GetReplacements returns the process groups that are registered in the global replacement budget of the database of
+// the cluster.
+func (client *LockClient) GetReplacements() []fdbv1beta2.ProcessGroupID {
+	lockClientMutex.Lock()
+	defer lockClientMutex.Unlock()
+
+	budget := getReplacementBudget(client.cluster)
+	processGroupIDs := make([]fdbv1beta2.ProcessGroupID, 0, len(budget))
+	for processGroupID := range budget {
+		processGroupIDs = append(processGroupIDs, processGroupID)
+	}
+
+	sort.Slice(processGroupIDs, func(i, j int) bool {
+		return processGroupIDs[i] < processGroupIDs[j]
+	})
+
+	return processGroupIDs
+}
+
+// mockReplacement represents a replacement in the global replacement budget.
+type mockReplacement struct {
+	// owner is the lock ID of the operator instance that registered the replacement.
+	owner string
+	// expiry is the time when the lease of the replacement expires.
+	expiry time.Time
+}
+
+// replacementBudgets stores the global replacement budgets per lock prefix, so all mock lock clients that use the same
+// lock prefix share the same budget, like operator instances that manage the same database.
+var replacementBudgets = make(map[string]map[fdbv1beta2.ProcessGroupID]mockReplacement)
+
+// getReplacementBudget returns the global replacement budget for the cluster. The caller must hold the lockClientMutex.
+func getReplacementBudget(cluster *fdbv1beta2.FoundationDBCluster) map[fdbv1beta2.ProcessGroupID]mockReplacement {
+	budget, ok := replacementBudgets[cluster.GetLockPrefix()]
+	if !ok {
+		budget = make(map[fdbv1beta2.ProcessGroupID]mockReplacement)
+		replacementBudgets[cluster.GetLockPrefix()] = budget
+	}
+
+	return budget
+}
+
 // lockClientCache provides a cache of mock lock clients.
 var lockClientCache = make(map[string]*LockClient)
 var lockClientMutex sync.Mutex
@@ -144,4 +233,5 @@ func (client *LockClient) ClearPendingUpgrades() error {
 // ClearMockLockClients clears the cache of mock lock clients
 func ClearMockLockClients() {
 	lockClientCache = map[string]*LockClient{}
+	replacementBudgets = map[string]map[fdbv1beta2.ProcessGroupID]mockReplacement{}
 }
@@ -21,6 +21,8 @@
 package mock
 
 import (
+	"time"
+
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -107,4 +109,50 @@ var _ = Describe("lock_client_test", func() {
 			})
 		})
 	})
+
+	Describe("global replacement budget", func() {
+		var remoteLockClient *LockClient
+
+		BeforeEach(func() {
+			ClearMockLockClients()
+			cluster := internal.CreateDefaultCluster()
+			cluster.Spec.ProcessGroupIDPrefix = "dc1"
+			lockClient = NewMockLockClientUncast(cluster)
+			remote := internal.CreateDefaultCluster()
+			remote.Name = "remote"
+			remote.Spec.ProcessGroupIDPrefix = "dc2"
+			remoteLockClient = NewMockLockClientUncast(remote)
+
+			Expect(remoteLockClient.UpdateReplacements([]fdbv1beta2.ProcessGroupID{"dc2-storage-1"}, time.Hour)).To(Succeed())
+		})
+
+		It("shares the replacements between the lock clients", func() {
+			Expect(lockClient.GetReplacements()).To(ConsistOf(fdbv1beta2.ProcessGroupID("dc2-storage-1")))
+		})
+
+		It("only allows replacements within the budget", func() {
+			reserved, err := lockClient.ReserveReplacement("dc1-storage-1", 2, time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reserved).To(BeTrue())
+
+			reserved, err = lockClient.ReserveReplacement("dc1-storage-2", 2, time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reserved).To(BeFalse())
+
+			Expect(lockClient.GetReplacements()).To(ConsistOf(fdbv1beta2.ProcessGroupID("dc1-storage-1"), fdbv1beta2.ProcessGroupID("dc2-storage-1")))
+		})
+
+		It("removes completed replacements of the same lock client", func() {
+			Expect(remoteLockClient.UpdateReplacements(nil, time.Hour)).To(Succeed())
+			Expect(lockClient.GetReplacements()).To(BeEmpty())
+		})
+
+		It("ignores replacements with an expired lease", func() {
+			Expect(remoteLockClient.UpdateReplacements([]fdbv1beta2.ProcessGroupID{"dc2-storage-1"}, -1*time.Minute)).To(Succeed())
+
+			reserved, err := lockClient.ReserveReplacement("dc1-storage-1", 1, time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reserved).To(BeTrue())
+		})
+	})
 })
@@ -76,6 +76,8 @@ func getRemainingAndExcludedFromStatus(logger logr.Logger, status *fdbv1beta2.Fo
 	notExcludedAddresses := map[string]fdbv1beta2.None{}
 	fullyExcludedAddresses := map[string]int{}
 	visitedAddresses := map[string]int{}
+	// pendingProcesses contains the process IDs of the excluded processes per address that still serve at least one role.
+	pendingProcesses := map[string][]string{}
 
 	// If there are more than 1 active generations we can not handout any information about excluded processes based on
 	// the cluster status information as only the latest log processes will have the log process role. If we don't check
@@ -132,7 +134,10 @@ func getRemainingAndExcludedFromStatus(logger logr.Logger, status *fdbv1beta2.Fo
 			if len(process.Roles) == 0 {
 				logger.Info("found fully excluded process without any roles", "process", process)
 				fullyExcludedAddresses[address]++
+				continue
 			}
+
+			pendingProcesses[address] = append(pendingProcesses[address], process.Locality[fdbv1beta2.FDBLocalityProcessIDKey])
 		}
 	}
 
@@ -170,7 +175,7 @@ func getRemainingAndExcludedFromStatus(logger logr.Logger, status *fdbv1beta2.Fo
 				exclusions.fullyExcluded = append(exclusions.fullyExcluded, addr)
 				continue
 			}
-			logger.Info("found excluded addresses for machine, but not all processes are fully excluded", "visitedCount", visitedCount, "excludedCount", excludedCount, "address", address)
+			logger.Info("found excluded addresses for machine, but not all processes are fully excluded", "visitedCount", visitedCount, "excludedCount", excludedCount, "address", address, "pendingProcesses", pendingProcesses[address])
 		}
 
 		// Those are the processes that are marked as excluded but still serve at least one role.
@@ -461,6 +466,27 @@ func HasDesiredFaultToleranceFromStatus(log logr.Logger, status *fdbv1beta2.Foun
 	return true
 }
 
+// CheckClusterHealth returns nil if the cluster is healthy, e.g. the cluster is available, fully recovered and has the
+// desired fault tolerance. Otherwise an error with more information why the cluster is not healthy is returned. This
+// check is used by the backup and restore controllers before they perform operations that put additional load on the
+// cluster.
+func CheckClusterHealth(log logr.Logger, status *fdbv1beta2.FoundationDBStatus, cluster *fdbv1beta2.FoundationDBCluster) error {
+	if !status.Client.DatabaseStatus.Available {
+		return fmt.Errorf("cluster is unavailable")
+	}
+
+	// Older versions of FDB might not report the recovery state, in this case we only rely on the fault tolerance.
+	if status.Cluster.RecoveryState.Name != "" && status.Cluster.RecoveryState.Name != "fully_recovered" {
+		return fmt.Errorf("cluster is not fully recovered, current recovery state: %s", status.Cluster.RecoveryState.Name)
+	}
+
+	if !HasDesiredFaultToleranceFromStatus(log, status, cluster) {
+		return fmt.Errorf("cluster doesn't have the desired fault tolerance")
+	}
+
+	return nil
+}
+
 // DefaultSafetyChecks performs a set of default safety checks, e.g. it checks if the cluster is available from the
 // client perspective and it checks that there are not too many active generations.
 func DefaultSafetyChecks(status *fdbv1beta2.FoundationDBStatus, maximumActiveGenerations int, action string) error {
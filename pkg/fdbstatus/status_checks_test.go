@@ -1734,6 +1734,116 @@ var _ = Describe("status_checks", func() {
 			false)
 	})
 
+	When("checking the cluster health", func() {
+		log := logr.New(logf.NewDelegatingLogSink(logf.NullLogSink{}))
+		var status *fdbv1beta2.FoundationDBStatus
+		var cluster *fdbv1beta2.FoundationDBCluster
+
+		BeforeEach(func() {
+			cluster = &fdbv1beta2.FoundationDBCluster{
+				Spec: fdbv1beta2.FoundationDBClusterSpec{
+					DatabaseConfiguration: fdbv1beta2.DatabaseConfiguration{
+						RedundancyMode: fdbv1beta2.RedundancyModeDouble,
+					},
+				},
+			}
+
+			coordinators := make([]fdbv1beta2.FoundationDBStatusCoordinator, 0, 3)
+			for i := 1; i <= 3; i++ {
+				coordinators = append(coordinators, fdbv1beta2.FoundationDBStatusCoordinator{
+					Reachable: true,
+					Address: fdbv1beta2.ProcessAddress{
+						IPAddress: net.ParseIP(fmt.Sprintf("192.168.0.%d", i)),
+						Port:      4500,
+					},
+				})
+			}
+
+			status = &fdbv1beta2.FoundationDBStatus{
+				Client: fdbv1beta2.FoundationDBStatusLocalClientInfo{
+					Coordinators: fdbv1beta2.FoundationDBStatusCoordinatorInfo{
+						QuorumReachable: true,
+						Coordinators:    coordinators,
+					},
+					DatabaseStatus: fdbv1beta2.FoundationDBStatusClientDBStatus{
+						Available: true,
+					},
+				},
+				Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+					DatabaseConfiguration: fdbv1beta2.DatabaseConfiguration{
+						RedundancyMode: fdbv1beta2.RedundancyModeDouble,
+					},
+					Data: fdbv1beta2.FoundationDBStatusDataStatistics{
+						TeamTrackers: []fdbv1beta2.FoundationDBStatusTeamTracker{
+							{
+								Primary: true,
+								State: fdbv1beta2.FoundationDBStatusDataState{
+									Healthy:              true,
+									MinReplicasRemaining: 2,
+								},
+							},
+						},
+					},
+					Logs: []fdbv1beta2.FoundationDBStatusLogInfo{
+						{
+							LogFaultTolerance:    1,
+							LogReplicationFactor: 2,
+						},
+					},
+					RecoveryState: fdbv1beta2.RecoveryState{
+						Name: "fully_recovered",
+					},
+				},
+			}
+		})
+
+		When("the cluster is healthy", func() {
+			It("should return no error", func() {
+				Expect(CheckClusterHealth(log, status, cluster)).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the cluster is unavailable", func() {
+			BeforeEach(func() {
+				status.Client.DatabaseStatus.Available = false
+			})
+
+			It("should return an error", func() {
+				Expect(CheckClusterHealth(log, status, cluster)).To(MatchError("cluster is unavailable"))
+			})
+		})
+
+		When("the cluster is not fully recovered", func() {
+			BeforeEach(func() {
+				status.Cluster.RecoveryState.Name = "accepting_commits"
+			})
+
+			It("should return an error", func() {
+				Expect(CheckClusterHealth(log, status, cluster)).To(MatchError("cluster is not fully recovered, current recovery state: accepting_commits"))
+			})
+		})
+
+		When("the recovery state is not reported", func() {
+			BeforeEach(func() {
+				status.Cluster.RecoveryState.Name = ""
+			})
+
+			It("should return no error", func() {
+				Expect(CheckClusterHealth(log, status, cluster)).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the cluster is missing a replica", func() {
+			BeforeEach(func() {
+				status.Cluster.Data.TeamTrackers[0].State.MinReplicasRemaining = 1
+			})
+
+			It("should return an error", func() {
+				Expect(CheckClusterHealth(log, status, cluster)).To(MatchError("cluster doesn't have the desired fault tolerance"))
+			})
+		})
+	})
+
 	When("performing the default safety check.", func() {
 		DescribeTable("should return if the safety check is satisfied or not",
 			func(status *fdbv1beta2.FoundationDBStatus, maximumActiveGeneration int, expected error) {
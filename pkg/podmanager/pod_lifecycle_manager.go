@@ -84,17 +84,12 @@ func (manager StandardPodLifecycleManager) GetPods(ctx context.Context, r client
 	}
 	resPods := make([]*corev1.Pod, 0, len(pods.Items))
 	for _, pod := range pods.Items {
-		ownedByCluster := !cluster.ShouldFilterOnOwnerReferences()
-		if !ownedByCluster {
-			for _, reference := range pod.ObjectMeta.OwnerReferences {
-				if reference.UID == cluster.UID {
-					ownedByCluster = true
-					break
-				}
-			}
+		ownership := cluster.GetResourceOwnership(pod.ObjectMeta)
+		if ownership == fdbv1beta2.ResourceConflict {
+			logr.FromContextOrDiscard(ctx).Info("Ignoring Pod that is claimed by a different cluster", "name", pod.Name, "claim", pod.Annotations[fdbv1beta2.ClusterClaimAnnotation])
 		}
 
-		if ownedByCluster {
+		if ownership == fdbv1beta2.ResourceOwned {
 			resPod := pod
 			resPods = append(resPods, &resPod)
 		}
@@ -0,0 +1,52 @@
+/*
+ * pod_spec_comparator.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package podmanager
+
+import (
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// PodSpecComparisonResult describes the result of a PodSpecComparator.
+type PodSpecComparisonResult int
+
+const (
+	// PodSpecComparisonUndecided defines that the comparator has no opinion about the Pod and the operator will
+	// continue with the next comparator or with the default checks.
+	PodSpecComparisonUndecided PodSpecComparisonResult = iota
+	// PodSpecComparisonMatches defines that the Pod matches the desired spec, the remaining comparators and the default
+	// checks of the operator will be skipped and the process group will not be replaced.
+	PodSpecComparisonMatches
+	// PodSpecComparisonDrifted defines that the Pod has drifted from the desired spec and the process group must be
+	// replaced.
+	PodSpecComparisonDrifted
+)
+
+// PodSpecComparator can be implemented to add custom checks that decide if the Pod of a process group has drifted from
+// the desired Pod spec, e.g. to ignore fields that are mutated by an admission webhook. The comparators are called in
+// order before the default Pod spec checks of the operator, the first comparator that returns a result other than
+// PodSpecComparisonUndecided decides if the process group will be replaced. Changes of the process group ID, the public
+// IP source and the servers per Pod are always checked by the operator before the comparators are called.
+type PodSpecComparator interface {
+	// ComparePodSpec compares the current Pod with the desired Pod spec of the process group. If the result is
+	// PodSpecComparisonDrifted, the returned message will be used in the removal reason of the process group.
+	ComparePodSpec(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus, desired *corev1.PodSpec, current *corev1.Pod) (PodSpecComparisonResult, string)
+}
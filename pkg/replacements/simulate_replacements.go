@@ -0,0 +1,40 @@
+/*
+ * simulate_replacements.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package replacements provides the replacement checks of the operator for external consumers, e.g. to verify a
+// rendered cluster spec in a CI pipeline before it is applied.
+package replacements
+
+import (
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal/replacements"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/podmanager"
+)
+
+// SimulateReplacements provides an external interface for the internal SimulateReplacements method. It returns all
+// process groups that would currently be replaced because their Pod or PVC doesn't match the spec of the provided
+// cluster, together with the reason for the replacement. The status of the cluster is not modified.
+func SimulateReplacements(log logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, pods []corev1.Pod, pvcs []corev1.PersistentVolumeClaim, storageClasses []storagev1.StorageClass, replaceOnSecurityContextChange bool, comparators ...podmanager.PodSpecComparator) (map[fdbv1beta2.ProcessGroupID]*fdbv1beta2.RemovalReason, error) {
+	return replacements.SimulateReplacements(log, cluster, pods, pvcs, storageClasses, replaceOnSecurityContextChange, comparators...)
+}
@@ -21,6 +21,7 @@
 package setup
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"io"
@@ -42,6 +43,14 @@ import (
 	"github.com/FoundationDB/fdb-kubernetes-operator/controllers"
 	"github.com/FoundationDB/fdb-kubernetes-operator/fdbclient"
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal/airgap"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal/connectionstring"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal/defaulting"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal/fips"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal/healthendpoint"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal/interlock"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal/snapshot"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal/tenancy"
 	"gopkg.in/natefinch/lumberjack.v2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -66,16 +75,27 @@ type Options struct {
 	CacheDatabaseStatus                bool
 	EnableNodeIndex                    bool
 	ReplaceOnSecurityContextChange     bool
+	DisableDestructiveActions          bool
+	CacheConnectionStrings             bool
+	EnableClusterSpecValidation        bool
+	EnableClusterDefaulting            bool
 	MetricsAddr                        string
+	HealthEndpointAddr                 string
 	LeaderElectionID                   string
 	LogFile                            string
 	LogFilePermission                  string
 	LabelSelector                      string
 	ClusterLabelKeyForNodeTrigger      string
 	WatchNamespace                     string
+	TenantPolicyFile                   string
+	WebhookCertDir                     string
+	StatusSnapshotDirectory            string
+	ClientLibraryStoreDir              string
+	SafetyInterlockAllowedURLs         string
 	CliTimeout                         int
 	MaxCliTimeout                      int
 	MaxConcurrentReconciles            int
+	MaxConcurrentProcessGroupChecks    int
 	LogFileMaxSize                     int
 	LogFileMaxAge                      int
 	MaxNumberOfOldLogFiles             int
@@ -86,6 +106,11 @@ type Options struct {
 	PostTimeout                        time.Duration
 	MaintenanceListStaleDuration       time.Duration
 	MaintenanceListWaitDuration        time.Duration
+	StatusSnapshotInterval             time.Duration
+	StatusSnapshotRetention            time.Duration
+	ConnectionPoolIdleTimeout          time.Duration
+	SharedStatusCacheDuration          time.Duration
+	UpgradeObservationWindow           time.Duration
 	// LeaseDuration is the duration that non-leader candidates will
 	// wait to force acquire leadership. This is measured against time of
 	// last observed ack. Default is 15 seconds.
@@ -97,12 +122,14 @@ type Options struct {
 	// between tries of actions. Default is 2 seconds.
 	RetryPeriod                   time.Duration
 	DeprecationOptions            internal.DeprecationOptions
+	AirGapOptions                 airgap.Options
 	MinimumRequiredUptimeCCBounce time.Duration
 }
 
 // BindFlags will parse the given flagset for the operator option flags
 func (o *Options) BindFlags(fs *flag.FlagSet) {
 	fs.StringVar(&o.MetricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
+	fs.StringVar(&o.HealthEndpointAddr, "health-endpoint-addr", "0", "The address the health endpoints of the clusters bind to. The health endpoint of a cluster is served at /health/<namespace>/<cluster> if it is enabled in the cluster spec. A value of \"0\" disables the health endpoints.")
 	fs.BoolVar(&o.EnableLeaderElection, "enable-leader-election", true,
 		"Enable leader election for controller manager. Enabling this will ensure there is only one active controller manager.")
 	fs.StringVar(&o.LeaderElectionID, "leader-election-id", "fdb-kubernetes-operator",
@@ -114,6 +141,7 @@ func (o *Options) BindFlags(fs *flag.FlagSet) {
 	fs.IntVar(&o.CliTimeout, "cli-timeout", 10, "The timeout to use for CLI commands in seconds.")
 	fs.IntVar(&o.MaxCliTimeout, "max-cli-timeout", 40, "The maximum timeout to use for CLI commands in seconds. This timeout is used for CLI requests that are known to be potentially slow like get status or exclude.")
 	fs.IntVar(&o.MaxConcurrentReconciles, "max-concurrent-reconciles", 1, "Defines the maximum number of concurrent reconciles for all controllers.")
+	fs.IntVar(&o.MaxConcurrentProcessGroupChecks, "max-concurrent-process-group-checks", 1, "Defines the maximum number of process groups that are checked concurrently during a reconciliation of a cluster, e.g. when validating the process groups or when looking for misconfigured process groups. A value of 1 will check the process groups serially.")
 	fs.BoolVar(&o.CleanUpOldLogFile, "cleanup-old-cli-logs", true, "Defines if the operator should delete old fdbcli log files.")
 	fs.DurationVar(&o.LogFileMinAge, "log-file-min-age", 5*time.Minute, "Defines the minimum age of fdbcli log files before removing when \"--cleanup-old-cli-logs\" is set.")
 	fs.IntVar(&o.LogFileMaxAge, "log-file-max-age", 28, "Defines the maximum age to retain old operator log file in number of days.")
@@ -143,7 +171,24 @@ func (o *Options) BindFlags(fs *flag.FlagSet) {
 	fs.BoolVar(&o.ReplaceOnSecurityContextChange, "replace-on-security-context-change", false, "This flag enables the operator"+
 		" to automatically replace pods whose effective security context has one of the following fields change: "+
 		"FSGroup, FSGroupChangePolicy, RunAsGroup, RunAsUser")
+	fs.BoolVar(&o.CacheConnectionStrings, "cache-connection-strings", false, "This flag enables the caching of the connection string that was verified against the cluster. The cached connection string will be used until the generation or the connection string of the FoundationDBCluster resource changes, or until the connection string diverges from the connection string reported by the cluster.")
 	fs.Float64Var(&o.MinimumRecoveryTimeForInclusion, "minimum-recovery-time-for-inclusion", 600.0, "Defines the minimum uptime of the cluster before inclusions are allowed. For clusters after 7.1 this will use the recovery state. This should reduce the risk of frequent recoveries because of inclusions.")
+	fs.StringVar(&o.TenantPolicyFile, "tenant-policy-file", "", "The path to a file that defines the tenant policies for FoundationDBClusters. If set, the operator will serve a validating webhook that enforces those policies.")
+	fs.BoolVar(&o.EnableClusterSpecValidation, "enable-cluster-spec-validation", false, "Enables the validating webhook for FoundationDBClusters to reject clusters with an invalid spec when they are created or updated, instead of failing the reconciliation. The webhook uses the same validation that the operator performs before reconciling a cluster.")
+	fs.BoolVar(&o.EnableClusterDefaulting, "enable-cluster-defaulting", false, "Enables the mutating webhook for FoundationDBClusters that validates the custom parameters and applies the static defaults of the operator, e.g. the future defaults, when a cluster is created or updated. Defaults that are derived from other fields of the spec are applied during the reconciliation. Clusters that reference a cluster template are not modified.")
+	fs.StringVar(&o.WebhookCertDir, "webhook-cert-dir", "", "The directory that contains the server certificate and key for the validating webhook. If empty, the controller-runtime default is used.")
+	fs.StringVar(&o.StatusSnapshotDirectory, "status-snapshot-directory", "", "The directory to write periodic snapshots of the machine-readable status to, e.g. a mounted PersistentVolumeClaim. If empty, no snapshots will be written.")
+	fs.DurationVar(&o.StatusSnapshotInterval, "status-snapshot-interval", 15*time.Minute, "Defines the minimum duration between two status snapshots of the same cluster when \"--status-snapshot-directory\" is set.")
+	fs.DurationVar(&o.StatusSnapshotRetention, "status-snapshot-retention", 7*24*time.Hour, "Defines how long status snapshots are retained when \"--status-snapshot-directory\" is set. A value of 0 disables the removal of old snapshots.")
+	fs.StringVar(&o.ClientLibraryStoreDir, "client-library-store-dir", "", "The directory to store the FDB client libraries in. If set, only the client libraries that are required by the managed clusters will be linked into the external client directory and loaded by the operator. Clusters running a version without a loaded client library will be accessed with fdbcli.")
+	fs.DurationVar(&o.ConnectionPoolIdleTimeout, "connection-pool-idle-timeout", 10*time.Minute, "Defines after which duration the shared state of a FoundationDB cluster, that is used by the cluster, backup and restore controllers, will be evicted if it was not used. A value of 0 disables the eviction.")
+	fs.DurationVar(&o.SharedStatusCacheDuration, "shared-status-cache-duration", 0, "Defines how long a machine-readable status will be reused by the cluster, backup and restore controllers. Concurrent requests for the status of the same cluster will always be served by a single request.")
+	fs.DurationVar(&o.UpgradeObservationWindow, "upgrade-observation-window", 0, "Defines how long Pod updates and replacements of misconfigured process groups are held back after the operator was upgraded. During this window the operator reports the Pod updates and replacements it would perform in the cluster status. A value of 0 disables the observation of operator upgrades.")
+	fs.BoolVar(&o.DisableDestructiveActions, "disable-destructive-actions", false, "Disables the deletion of Pods and PVCs of the managed clusters, so the operator can run without the permissions to delete Pods and PVCs. Instead of deleting those resources, the operator adds the PodDeletionRequired or PVCDeletionRequired condition to the process group and the resources must be deleted by a privileged user or controller, e.g. with \"kubectl fdb approve-deletions\".")
+	fs.BoolVar(&o.AirGapOptions.Enabled, "air-gapped", false, "Enables the air-gapped mode. In air-gapped mode the operator validates that all images of the managed clusters are pulled from one of the registries defined in \"--air-gapped-registries\" and that the clusters don't depend on endpoints outside the Kubernetes cluster. Clusters that would require access outside of the Kubernetes cluster will not be reconciled.")
+	fs.StringVar(&o.AirGapOptions.Registries, "air-gapped-registries", "", "Defines a comma separated list of registries, optionally with a repository path, e.g. \"registry.local:5000/foundationdb\", from which images are allowed to be pulled when \"--air-gapped\" is set.")
+	fs.StringVar(&o.AirGapOptions.ImageConfigMap, "air-gapped-image-config-map", "", "Defines the ConfigMap, in the format \"namespace/name\" or \"name\", that provides the image configs for the main and the sidecar container when \"--air-gapped\" is set. If no namespace is provided, the namespace of the cluster will be used. The image configs of the cluster spec take precedence over the image configs of the ConfigMap.")
+	fs.StringVar(&o.SafetyInterlockAllowedURLs, "safety-interlock-allowed-urls", "", "Defines a comma separated list of URLs, e.g. \"https://freeze.example.com/api/\", that can be used as safety interlock endpoint by the clusters. The URL of a cluster must have the same scheme and host as one of the allowed URLs and its path must start with the path of the allowed URL. If empty, no safety interlock endpoint will be queried and the failure policy of the cluster applies.")
 	fs.Float64Var(&o.MinimumRecoveryTimeForExclusion, "minimum-recovery-time-for-exclusion", 120.0, "Defines the minimum uptime of the cluster before exclusions are allowed. For clusters after 7.1 this will use the recovery state. This should reduce the risk of frequent recoveries because of exclusions.")
 }
 
@@ -200,6 +245,8 @@ func StartManager(
 		// are cached by the operator if a label selector is provided.s
 		cacheOptions.SelectorsByObject = map[client.Object]cache.ObjectSelector{
 			&fdbv1beta2.FoundationDBCluster{}: selector,
+			&fdbv1beta2.FoundationDBBackup{}:  selector,
+			&fdbv1beta2.FoundationDBRestore{}: selector,
 			&corev1.Pod{}:                     selector,
 			&corev1.PersistentVolumeClaim{}:   selector,
 			&corev1.ConfigMap{}:               selector,
@@ -223,8 +270,11 @@ func StartManager(
 		RetryPeriod:        &operatorOpts.RetryPeriod,
 		Port:               9443,
 		NewCache:           cache.BuilderWithOptions(cacheOptions),
+		CertDir:            operatorOpts.WebhookCertDir,
 	}
 
+	setupLog.Info("Operator cryptography", "fips", fips.Enabled())
+
 	if operatorOpts.WatchNamespace != "" {
 		options.Namespace = operatorOpts.WatchNamespace
 		setupLog.Info("Operator starting in single namespace mode", "namespace", options.Namespace)
@@ -239,22 +289,38 @@ func StartManager(
 		os.Exit(1)
 	}
 
-	if err := moveFDBBinaries(setupLog); err != nil {
+	libraryDir := os.Getenv(fdbv1beta2.EnvNameFDBExternalClientDir)
+	if operatorOpts.ClientLibraryStoreDir != "" {
+		libraryDir = operatorOpts.ClientLibraryStoreDir
+	}
+
+	if err := moveFDBBinaries(setupLog, libraryDir); err != nil {
 		setupLog.Error(err, "unable to move FDB binaries")
 		os.Exit(1)
 	}
 
+	if operatorOpts.ClientLibraryStoreDir != "" {
+		if err := linkRequiredClientLibraries(setupLog, mgr.GetAPIReader(), operatorOpts); err != nil {
+			setupLog.Error(err, "unable to link required FDB client libraries")
+			os.Exit(1)
+		}
+	}
+
 	labelSelector, err := metav1.ParseToLabelSelector(strings.Trim(operatorOpts.LabelSelector, "\""))
 	if err != nil {
 		setupLog.Error(err, "unable to parse provided label selector")
 		os.Exit(1)
 	}
 
+	// All controllers share the same client and therefore the same informer cache. The database client provider is
+	// shared as well, so that the controllers can reuse the status requests for the same cluster.
+	databaseClientProvider := fdbclient.NewSharedDatabaseClientProvider(logger, operatorOpts.ConnectionPoolIdleTimeout, operatorOpts.SharedStatusCacheDuration)
+
 	if clusterReconciler != nil {
 		clusterReconciler.Client = mgr.GetClient()
 		clusterReconciler.Recorder = mgr.GetEventRecorderFor("foundationdbcluster-controller")
 		clusterReconciler.DeprecationOptions = operatorOpts.DeprecationOptions
-		clusterReconciler.DatabaseClientProvider = fdbclient.NewDatabaseClientProvider(logger)
+		clusterReconciler.DatabaseClientProvider = databaseClientProvider
 		clusterReconciler.GetTimeout = operatorOpts.GetTimeout
 		clusterReconciler.PostTimeout = operatorOpts.PostTimeout
 		clusterReconciler.Log = logr.WithName("controllers").WithName("FoundationDBCluster")
@@ -274,6 +340,21 @@ func StartManager(
 		clusterReconciler.MinimumRecoveryTimeForExclusion = operatorOpts.MinimumRecoveryTimeForExclusion
 		clusterReconciler.ClusterLabelKeyForNodeTrigger = strings.Trim(operatorOpts.ClusterLabelKeyForNodeTrigger, "\"")
 		clusterReconciler.Namespace = operatorOpts.WatchNamespace
+		clusterReconciler.MaxConcurrentProcessGroupChecks = operatorOpts.MaxConcurrentProcessGroupChecks
+		clusterReconciler.OperatorVersion = operatorVersion
+		clusterReconciler.UpgradeObservationWindow = operatorOpts.UpgradeObservationWindow
+		clusterReconciler.AirGapOptions = operatorOpts.AirGapOptions
+		clusterReconciler.DisableDestructiveActions = operatorOpts.DisableDestructiveActions
+		clusterReconciler.SafetyInterlockChecker = interlock.NewHTTPChecker(interlock.GetAllowedURLs(operatorOpts.SafetyInterlockAllowedURLs))
+
+		if operatorOpts.StatusSnapshotDirectory != "" {
+			setupLog.V(1).Info("setup status snapshot writer", "directory", operatorOpts.StatusSnapshotDirectory, "interval", operatorOpts.StatusSnapshotInterval.String(), "retention", operatorOpts.StatusSnapshotRetention.String())
+			clusterReconciler.StatusSnapshotWriter = snapshot.NewWriter(logger.WithName("snapshot"), operatorOpts.StatusSnapshotDirectory, operatorOpts.StatusSnapshotInterval, operatorOpts.StatusSnapshotRetention)
+		}
+
+		if operatorOpts.CacheConnectionStrings {
+			clusterReconciler.ConnectionStringCache = connectionstring.NewCache()
+		}
 
 		if err := clusterReconciler.SetupWithManager(mgr, operatorOpts.MaxConcurrentReconciles, *labelSelector, watchedObjects...); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "FoundationDBCluster")
@@ -288,7 +369,7 @@ func StartManager(
 	if backupReconciler != nil {
 		backupReconciler.Client = mgr.GetClient()
 		backupReconciler.Recorder = mgr.GetEventRecorderFor("foundationdbbackup-controller")
-		backupReconciler.DatabaseClientProvider = fdbclient.NewDatabaseClientProvider(logger)
+		backupReconciler.DatabaseClientProvider = databaseClientProvider
 		backupReconciler.Log = logr.WithName("controllers").WithName("FoundationDBBackup")
 		backupReconciler.ServerSideApply = operatorOpts.ServerSideApply
 
@@ -301,7 +382,7 @@ func StartManager(
 	if restoreReconciler != nil {
 		restoreReconciler.Client = mgr.GetClient()
 		restoreReconciler.Recorder = mgr.GetEventRecorderFor("foundationdbrestore-controller")
-		restoreReconciler.DatabaseClientProvider = fdbclient.NewDatabaseClientProvider(logger)
+		restoreReconciler.DatabaseClientProvider = databaseClientProvider
 		restoreReconciler.Log = logr.WithName("controllers").WithName("FoundationDBRestore")
 		restoreReconciler.ServerSideApply = operatorOpts.ServerSideApply
 
@@ -311,6 +392,42 @@ func StartManager(
 		}
 	}
 
+	if operatorOpts.HealthEndpointAddr != "0" {
+		setupLog.V(1).Info("setup health endpoint server", "address", operatorOpts.HealthEndpointAddr)
+		if err := mgr.Add(healthendpoint.NewServer(logger.WithName("healthendpoint"), operatorOpts.HealthEndpointAddr, mgr.GetClient(), databaseClientProvider)); err != nil {
+			setupLog.Error(err, "unable to add health endpoint server")
+			os.Exit(1)
+		}
+	}
+
+	if operatorOpts.EnableClusterDefaulting {
+		if err := defaulting.NewClusterDefaulter(operatorOpts.DeprecationOptions).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "FoundationDBCluster")
+			os.Exit(1)
+		}
+	}
+
+	if operatorOpts.TenantPolicyFile != "" || operatorOpts.EnableClusterSpecValidation {
+		var policies *tenancy.Policies
+		if operatorOpts.TenantPolicyFile != "" {
+			policies, err = tenancy.LoadPolicies(operatorOpts.TenantPolicyFile)
+			if err != nil {
+				setupLog.Error(err, "unable to load tenant policies", "file", operatorOpts.TenantPolicyFile)
+				os.Exit(1)
+			}
+		}
+
+		validator := tenancy.NewClusterValidator(policies)
+		if operatorOpts.EnableClusterSpecValidation {
+			validator = validator.WithSpecValidation(mgr.GetClient(), operatorOpts.DeprecationOptions)
+		}
+
+		if err := validator.SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "FoundationDBCluster")
+			os.Exit(1)
+		}
+	}
+
 	if operatorOpts.CleanUpOldLogFile {
 		setupLog.V(1).Info("setup log file cleaner", "LogFileMinAge", operatorOpts.LogFileMinAge.String())
 		cleaner := internal.NewCliLogFileCleaner(logger, operatorOpts.LogFileMinAge)
@@ -329,18 +446,18 @@ func StartManager(
 }
 
 // MoveFDBBinaries moves FDB binaries that are pulled from setup containers into
-// the correct locations.
-func moveFDBBinaries(log logr.Logger) error {
+// the correct locations. The client libraries will be moved into the provided library directory.
+func moveFDBBinaries(log logr.Logger, libraryDir string) error {
 	binFile, err := os.Open(os.Getenv("FDB_BINARY_DIR"))
 	if err != nil {
 		return err
 	}
 	defer binFile.Close()
 
-	libDir, err := os.Open(os.Getenv(fdbv1beta2.EnvNameFDBExternalClientDir))
+	_, err = os.Stat(libraryDir)
 	if err != nil {
 		if os.IsNotExist(err) {
-			err = os.MkdirAll(os.Getenv(fdbv1beta2.EnvNameFDBExternalClientDir), os.ModeDir|os.ModePerm)
+			err = os.MkdirAll(libraryDir, os.ModeDir|os.ModePerm)
 			if err != nil {
 				return err
 			}
@@ -399,7 +516,7 @@ func moveFDBBinaries(log logr.Logger) error {
 		}
 		if err == nil {
 			currentPath := path.Join(versionLibFile.Name())
-			newPath := path.Join(libDir.Name(), fmt.Sprintf("libfdb_c_%s.so", version))
+			newPath := path.Join(libraryDir, fdbclient.GetClientLibraryFileName(version))
 			log.Info("Moving FDB library file", "currentPath", currentPath, "newPath", newPath)
 			err = os.Rename(currentPath, newPath)
 			if err != nil {
@@ -412,6 +529,58 @@ func moveFDBBinaries(log logr.Logger) error {
 	return nil
 }
 
+// linkRequiredClientLibraries links the client libraries for the versions of all FoundationDBClusters that are managed by
+// this operator instance into the external client directory, so only those client libraries will be loaded.
+func linkRequiredClientLibraries(log logr.Logger, reader client.Reader, operatorOpts Options) error {
+	var listOptions []client.ListOption
+	if operatorOpts.WatchNamespace != "" {
+		listOptions = append(listOptions, client.InNamespace(operatorOpts.WatchNamespace))
+	}
+
+	if operatorOpts.LabelSelector != "" {
+		selector, err := labels.Parse(strings.Trim(operatorOpts.LabelSelector, "\""))
+		if err != nil {
+			return err
+		}
+
+		listOptions = append(listOptions, client.MatchingLabelsSelector{Selector: selector})
+	}
+
+	clusters := &fdbv1beta2.FoundationDBClusterList{}
+	err := reader.List(context.Background(), clusters, listOptions...)
+	if err != nil {
+		return err
+	}
+
+	versions := make([]fdbv1beta2.Version, 0, 2*len(clusters.Items))
+	for _, cluster := range clusters.Items {
+		// During an upgrade the client libraries for the running and the desired version are required.
+		for _, versionString := range []string{cluster.Spec.Version, cluster.Status.RunningVersion} {
+			if versionString == "" {
+				continue
+			}
+
+			version, err := fdbv1beta2.ParseFdbVersion(versionString)
+			if err != nil {
+				log.Info("Could not parse version of cluster", "namespace", cluster.Namespace, "cluster", cluster.Name, "version", versionString)
+				continue
+			}
+
+			versions = append(versions, version)
+		}
+	}
+
+	loadedVersions, err := fdbclient.LinkClientLibraries(log, operatorOpts.ClientLibraryStoreDir, os.Getenv(fdbv1beta2.EnvNameFDBExternalClientDir), versions)
+	if err != nil {
+		return err
+	}
+
+	log.Info("Linked required FDB client libraries", "versions", loadedVersions)
+	fdbclient.SetLoadedClientLibraryVersions(loadedVersions)
+
+	return nil
+}
+
 // setupLogger will return a MultiWriter if the operator should log to a file and stdout otherwise only the stdout
 // io.Writer is returned. If the operator should log to a file the operator will make sure to create the file with
 // the expected permissions.